@@ -519,6 +519,12 @@ func (la *loginAction) login(ctx context.Context) error {
 			); err != nil {
 				return fmt.Errorf("logging in: %w", err)
 			}
+		case la.flags.federatedTokenProvider == "gitlab":
+			if _, err := la.authManager.LoginWithGitLabFederatedTokenProvider(
+				ctx, la.flags.tenantID, la.flags.clientID,
+			); err != nil {
+				return fmt.Errorf("logging in: %w", err)
+			}
 		case la.flags.federatedTokenProvider == azurePipelinesProvider:
 			serviceConnectionID := os.Getenv(azurePipelinesServiceConnectionIDEnvVarName)
 
@@ -63,6 +63,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/dotnet"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/github"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/gradle"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/javac"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/kubectl"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/maven"
@@ -247,6 +248,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		}
 	})
 
+	container.MustRegisterSingleton(environment.NewValueEncryptor)
 	container.MustRegisterSingleton(environment.NewLocalFileDataStore)
 	container.MustRegisterSingleton(environment.NewManager)
 
@@ -513,6 +515,9 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.MustRegisterSingleton(project.NewDotNetImporter)
 	container.MustRegisterScoped(project.NewImportManager)
 	container.MustRegisterScoped(project.NewServiceManager)
+	container.MustRegisterScoped(project.NewExternalServiceTargetRegistry)
+	container.MustRegisterScoped(project.NewExternalFrameworkServiceRegistry)
+	container.MustRegisterScoped(project.NewDeploymentHistory)
 
 	// Even though the service manager is scoped based on its use of environment we can still
 	// register its internal cache as a singleton to ensure operation caching is consistent across all instances
@@ -597,6 +602,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.MustRegisterSingleton(javac.NewCli)
 	container.MustRegisterSingleton(kubectl.NewCli)
 	container.MustRegisterSingleton(maven.NewCli)
+	container.MustRegisterSingleton(gradle.NewCli)
 	container.MustRegisterSingleton(kubelogin.NewCli)
 	container.MustRegisterSingleton(helm.NewCli)
 	container.MustRegisterSingleton(kustomize.NewCli)
@@ -646,6 +652,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.MustRegisterScoped(infra.NewDeploymentManager)
 	container.MustRegisterSingleton(infra.NewAzureResourceManager)
 	container.MustRegisterScoped(provisioning.NewManager)
+	container.MustRegisterScoped(provisioning.NewExternalProviderRegistry)
 	container.MustRegisterScoped(provisioning.NewPrincipalIdProvider)
 	container.MustRegisterScoped(prompt.NewDefaultPrompter)
 
@@ -679,6 +686,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		project.ServiceLanguageJavaScript: project.NewNpmProject,
 		project.ServiceLanguageTypeScript: project.NewNpmProject,
 		project.ServiceLanguageJava:       project.NewMavenProject,
+		project.ServiceLanguageGradle:     project.NewGradleProject,
 		project.ServiceLanguageDocker:     project.NewDockerProject,
 		project.ServiceLanguageSwa:        project.NewSwaProject,
 	}
@@ -700,6 +708,8 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		"github-scm": pipeline.NewGitHubScmProvider,
 		"azdo-ci":    pipeline.NewAzdoCiProvider,
 		"azdo-scm":   pipeline.NewAzdoScmProvider,
+		"gitlab-ci":  pipeline.NewGitLabCiProvider,
+		"gitlab-scm": pipeline.NewGitLabScmProvider,
 	}
 
 	for provider, constructor := range pipelineProviderMap {
@@ -789,7 +799,8 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		return &workflowCmdAdapter{cmd: rootCmd}, nil
 
 	})
-	container.MustRegisterSingleton(workflow.NewRunner)
+	container.MustRegisterScoped(newWorkflowStepRunner)
+	container.MustRegisterScoped(workflow.NewRunner)
 
 	container.MustRegisterScoped(func(authManager *auth.Manager) prompt.AuthManager {
 		return authManager
@@ -807,6 +818,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.MustRegisterSingleton(extensions.NewManager)
 	container.MustRegisterSingleton(extensions.NewRunner)
 	container.MustRegisterSingleton(extensions.NewSourceManager)
+	container.MustRegisterSingleton(extensions.NewPacker)
 
 	// gRPC Server
 	container.MustRegisterScoped(grpcserver.NewServer)
@@ -815,6 +827,9 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.MustRegisterScoped(grpcserver.NewPromptService)
 	container.MustRegisterScoped(grpcserver.NewDeploymentService)
 	container.MustRegisterScoped(grpcserver.NewEventService)
+	container.MustRegisterScoped(grpcserver.NewServiceTargetService)
+	container.MustRegisterScoped(grpcserver.NewFrameworkService)
+	container.MustRegisterScoped(grpcserver.NewProvisioningService)
 	container.MustRegisterSingleton(grpcserver.NewUserConfigService)
 
 	// Required for nested actions called from composite actions like 'up'
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"slices"
 	"strings"
 	"time"
@@ -24,6 +25,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/bicep"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/keyvault"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	"github.com/azure/azure-dev/cli/azd/pkg/project"
@@ -104,6 +106,44 @@ func envActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 		ActionResolver: newEnvGetValueAction,
 	})
 
+	group.Add("diff", &actions.ActionDescriptorOptions{
+		Command:        newEnvDiffCmd(),
+		FlagsResolver:  newEnvDiffFlags,
+		ActionResolver: newEnvDiffAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
+	})
+
+	group.Add("copy", &actions.ActionDescriptorOptions{
+		Command:        newEnvCopyCmd(),
+		FlagsResolver:  newEnvCopyFlags,
+		ActionResolver: newEnvCopyAction,
+	})
+
+	group.Add("delete", &actions.ActionDescriptorOptions{
+		Command:        newEnvDeleteCmd(),
+		FlagsResolver:  newEnvDeleteFlags,
+		ActionResolver: newEnvDeleteAction,
+	})
+
+	group.Add("export", &actions.ActionDescriptorOptions{
+		Command:        newEnvExportCmd(),
+		FlagsResolver:  newEnvExportFlags,
+		ActionResolver: newEnvExportAction,
+	})
+
+	group.Add("import", &actions.ActionDescriptorOptions{
+		Command:        newEnvImportCmd(),
+		FlagsResolver:  newEnvImportFlags,
+		ActionResolver: newEnvImportAction,
+	})
+
+	group.Add("encrypt", &actions.ActionDescriptorOptions{
+		Command:        newEnvEncryptCmd(),
+		FlagsResolver:  newEnvEncryptFlags,
+		ActionResolver: newEnvEncryptAction,
+	})
+
 	return group
 }
 
@@ -469,7 +509,7 @@ func (e *envSetSecretAction) Run(ctx context.Context) (*actions.ActionResult, er
 
 	// akvs -> Azure Key Vault Secret (akvs://<subId>/<keyvault-name>/<secret-name>)
 	envValue := keyvault.NewAzureKeyVaultSecret(subId, kvAccount.Name, kvSecretName)
-	e.env.DotenvSet(secretName, envValue)
+	e.env.DotenvSetSecret(secretName, envValue)
 	if err := e.envManager.Save(ctx, e.env); err != nil {
 		return nil, fmt.Errorf("saving environment: %w", err)
 	}
@@ -908,11 +948,18 @@ func newEnvGetValuesCmd() *cobra.Command {
 
 type envGetValuesFlags struct {
 	internal.EnvFlag
+	reveal bool
 	global *internal.GlobalCommandOptions
 }
 
 func (eg *envGetValuesFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
 	eg.EnvFlag.Bind(local, global)
+	local.BoolVar(
+		&eg.reveal,
+		"reveal",
+		false,
+		"Shows the values of secrets, which are masked when encryption of environments is enabled.",
+	)
 	eg.global = global
 }
 
@@ -920,6 +967,7 @@ type envGetValuesAction struct {
 	azdCtx     *azdcontext.AzdContext
 	console    input.Console
 	envManager environment.Manager
+	encryptor  *environment.ValueEncryptor
 	formatter  output.Formatter
 	writer     io.Writer
 	flags      *envGetValuesFlags
@@ -928,6 +976,7 @@ type envGetValuesAction struct {
 func newEnvGetValuesAction(
 	azdCtx *azdcontext.AzdContext,
 	envManager environment.Manager,
+	encryptor *environment.ValueEncryptor,
 	console input.Console,
 	formatter output.Formatter,
 	writer io.Writer,
@@ -937,6 +986,7 @@ func newEnvGetValuesAction(
 		azdCtx:     azdCtx,
 		console:    console,
 		envManager: envManager,
+		encryptor:  encryptor,
 		formatter:  formatter,
 		writer:     writer,
 		flags:      flags,
@@ -964,7 +1014,52 @@ func (eg *envGetValuesAction) Run(ctx context.Context) (*actions.ActionResult, e
 		return nil, fmt.Errorf("ensuring environment exists: %w", err)
 	}
 
-	return nil, eg.formatter.Format(env.Dotenv(), eg.writer, nil)
+	if err := env.DecryptionError(); err != nil {
+		return nil, err
+	}
+
+	maskSecrets, err := shouldMaskSecrets(eg.encryptor, eg.flags.reveal)
+	if err != nil {
+		return nil, err
+	}
+
+	values := env.Dotenv()
+	if maskSecrets {
+		masked := false
+		for key := range values {
+			if env.IsSecret(key) {
+				values[key] = maskedSecretValue
+				masked = true
+			}
+		}
+
+		if masked {
+			fmt.Fprintln(
+				eg.console.Handles().Stderr,
+				output.WithWarningFormat("WARNING: Secret values are masked. Use --reveal to show them."),
+			)
+		}
+	}
+
+	return nil, eg.formatter.Format(values, eg.writer, nil)
+}
+
+// maskedSecretValue replaces the values of secrets in the output of commands that don't reveal secrets.
+const maskedSecretValue = "********"
+
+// shouldMaskSecrets returns whether secret values are masked in the output of commands. Secrets are only masked once
+// encryption of environments is enabled, so the output of plain text environments is unchanged.
+func shouldMaskSecrets(encryptor *environment.ValueEncryptor, reveal bool) (bool, error) {
+	if reveal {
+		return false, nil
+	}
+
+	keySource, err := encryptor.KeySource()
+	if err != nil {
+		return false, err
+	}
+
+	return keySource != "", nil
 }
 
 func newEnvGetValueFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envGetValueFlags {
@@ -1051,6 +1146,10 @@ func (eg *envGetValueAction) Run(ctx context.Context) (*actions.ActionResult, er
 		return nil, fmt.Errorf("ensuring environment exists: %w", err)
 	}
 
+	if err := env.DecryptionError(keyName); err != nil {
+		return nil, err
+	}
+
 	values := env.Dotenv()
 	keyValue, exists := values[keyName]
 	if !exists {
@@ -1078,5 +1177,654 @@ func getCmdEnvHelpDescription(*cobra.Command) string {
 			formatHelpNote(fmt.Sprintf("The environment name is stored as the %s environment variable in the %s file.",
 				output.WithHighLightFormat("AZURE_ENV_NAME"),
 				output.WithLinkFormat(".azure/<environment-name>/.env"))),
+			formatHelpNote(fmt.Sprintf("Secret values can be encrypted in the %s file with %s.",
+				output.WithLinkFormat(".env"),
+				output.WithHighLightFormat("azd config set env.encryption <keyring|passphrase>"))),
 		})
 }
+
+func newEnvEncryptFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envEncryptFlags {
+	flags := &envEncryptFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvEncryptCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt the secret values of existing environments.",
+		Long: "Encrypts the secret values stored in the .env file of existing environments, with the key configured with " +
+			"'azd config set env.encryption <keyring|passphrase>'.\n" +
+			"Values already encrypted are encrypted again with the configured key. References to Key Vault secrets, " +
+			"and the values of the keys provided with --key, are marked as secrets.",
+		Args: cobra.NoArgs,
+	}
+}
+
+type envEncryptFlags struct {
+	internal.EnvFlag
+	all    bool
+	keys   []string
+	global *internal.GlobalCommandOptions
+}
+
+func (f *envEncryptFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	f.EnvFlag.Bind(local, global)
+	local.BoolVar(&f.all, "all", false, "Encrypts all the environments instead of only the selected environment.")
+	local.StringSliceVar(&f.keys, "key", nil, "Marks the value of the key as a secret. Can be repeated.")
+	f.global = global
+}
+
+type envEncryptAction struct {
+	azdCtx     *azdcontext.AzdContext
+	envManager environment.Manager
+	encryptor  *environment.ValueEncryptor
+	console    input.Console
+	flags      *envEncryptFlags
+}
+
+func newEnvEncryptAction(
+	azdCtx *azdcontext.AzdContext,
+	envManager environment.Manager,
+	encryptor *environment.ValueEncryptor,
+	console input.Console,
+	flags *envEncryptFlags,
+) actions.Action {
+	return &envEncryptAction{
+		azdCtx:     azdCtx,
+		envManager: envManager,
+		encryptor:  encryptor,
+		console:    console,
+		flags:      flags,
+	}
+}
+
+func (e *envEncryptAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	keySource, err := e.encryptor.KeySource()
+	if err != nil {
+		return nil, err
+	}
+
+	if keySource == "" {
+		return nil, &internal.ErrorWithSuggestion{
+			Err: errors.New("encryption of environments is not enabled"),
+			Suggestion: fmt.Sprintf(
+				"Enable it with %s or %s",
+				output.WithHighLightFormat("azd config set %s keyring", environment.EncryptionConfigKey),
+				output.WithHighLightFormat("azd config set %s passphrase", environment.EncryptionConfigKey),
+			),
+		}
+	}
+
+	var names []string
+	if e.flags.all {
+		envs, err := e.envManager.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing environments: %w", err)
+		}
+
+		for _, env := range envs {
+			if env.HasLocal {
+				names = append(names, env.Name)
+			}
+		}
+	} else {
+		name := e.flags.EnvironmentName
+		if name == "" {
+			name, err = e.azdCtx.GetDefaultEnvironmentName()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if name == "" {
+			return nil, errors.New("no environment selected. Select one with 'azd env select', or use --all")
+		}
+
+		names = append(names, name)
+	}
+
+	secrets := 0
+	for _, name := range names {
+		env, err := e.envManager.Get(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("loading environment '%s': %w", name, err)
+		}
+
+		for key, value := range env.Dotenv() {
+			if env.IsSecret(key) || keyvault.IsAzureKeyVaultSecret(value) || slices.Contains(e.flags.keys, key) {
+				env.DotenvSetSecret(key, value)
+				secrets++
+			}
+		}
+
+		if err := e.envManager.Save(ctx, env); err != nil {
+			return nil, fmt.Errorf("saving environment '%s': %w", name, err)
+		}
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf(
+				"Encrypted %d secret value(s) in %d environment(s) with the %s key",
+				secrets,
+				len(names),
+				output.WithHighLightFormat(string(keySource)),
+			),
+		},
+	}, nil
+}
+
+func newEnvDiffFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envDiffFlags {
+	flags := &envDiffFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff <environment> <other-environment>",
+		Short: "Compare the values and configuration of two environments.",
+		Args:  cobra.ExactArgs(2),
+	}
+}
+
+type envDiffFlags struct {
+	reveal bool
+	global *internal.GlobalCommandOptions
+}
+
+func (f *envDiffFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.BoolVar(
+		&f.reveal,
+		"reveal",
+		false,
+		"Shows the values of secrets, which are masked when encryption of environments is enabled.",
+	)
+	f.global = global
+}
+
+type envDiffAction struct {
+	envManager environment.Manager
+	encryptor  *environment.ValueEncryptor
+	formatter  output.Formatter
+	writer     io.Writer
+	flags      *envDiffFlags
+	args       []string
+}
+
+func newEnvDiffAction(
+	envManager environment.Manager,
+	encryptor *environment.ValueEncryptor,
+	formatter output.Formatter,
+	writer io.Writer,
+	flags *envDiffFlags,
+	args []string,
+) actions.Action {
+	return &envDiffAction{
+		envManager: envManager,
+		encryptor:  encryptor,
+		formatter:  formatter,
+		writer:     writer,
+		flags:      flags,
+		args:       args,
+	}
+}
+
+func (e *envDiffAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	env, err := getExistingEnvironment(ctx, e.envManager, e.args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	other, err := getExistingEnvironment(ctx, e.envManager, e.args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	maskSecrets, err := shouldMaskSecrets(e.encryptor, e.flags.reveal)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := environment.Compare(env, other)
+	if maskSecrets {
+		maskSecretDifferences(diff.Values)
+		maskSecretDifferences(diff.Config)
+	}
+
+	if e.formatter.Kind() == output.JsonFormat {
+		return nil, e.formatter.Format(diff, e.writer, nil)
+	}
+
+	if diff.IsEmpty() {
+		fmt.Fprintf(e.writer, "Environments '%s' and '%s' have the same values and configuration.\n", e.args[0], e.args[1])
+		return nil, nil
+	}
+
+	writeDifferences(e.writer, environment.DotEnvFileName, diff.Values)
+	writeDifferences(e.writer, environment.ConfigFileName, diff.Config)
+
+	return nil, nil
+}
+
+func maskSecretDifferences(differences []environment.Difference) {
+	for i, difference := range differences {
+		if !difference.Secret {
+			continue
+		}
+
+		if difference.Value != "" {
+			differences[i].Value = maskedSecretValue
+		}
+
+		if difference.OtherValue != "" {
+			differences[i].OtherValue = maskedSecretValue
+		}
+	}
+}
+
+func writeDifferences(writer io.Writer, title string, differences []environment.Difference) {
+	if len(differences) == 0 {
+		return
+	}
+
+	fmt.Fprintln(writer, output.WithBold(title))
+	for _, difference := range differences {
+		switch difference.Kind {
+		case environment.DifferenceAdded:
+			fmt.Fprintln(writer, output.WithSuccessFormat("  + %s=%q", difference.Key, difference.OtherValue))
+		case environment.DifferenceRemoved:
+			fmt.Fprintln(writer, output.WithErrorFormat("  - %s=%q", difference.Key, difference.Value))
+		case environment.DifferenceChanged:
+			fmt.Fprintln(writer, output.WithWarningFormat(
+				"  ~ %s: %q -> %q", difference.Key, difference.Value, difference.OtherValue))
+		}
+	}
+}
+
+// getExistingEnvironment returns the environment with the given name, or an error suggesting how to create it when it
+// does not exist.
+func getExistingEnvironment(
+	ctx context.Context,
+	envManager environment.Manager,
+	name string,
+) (*environment.Environment, error) {
+	env, err := envManager.Get(ctx, name)
+	if errors.Is(err, environment.ErrNotFound) {
+		return nil, fmt.Errorf(
+			`environment '%s' does not exist. You can create it with "azd env new %s"`,
+			name,
+			name,
+		)
+	} else if err != nil {
+		return nil, fmt.Errorf("loading environment '%s': %w", name, err)
+	}
+
+	return env, nil
+}
+
+func newEnvCopyFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envCopyFlags {
+	flags := &envCopyFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvCopyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "copy <source-environment> <target-environment>",
+		Short: "Copy the values and configuration of an environment to a new environment.",
+		Long: "Copies the .env values and the configuration of an environment to a new environment.\n" +
+			"The copied values can be filtered with --include and --exclude patterns, which match the keys of .env " +
+			"values, such as 'AZURE_*', and the paths of configuration values, such as 'infra.parameters.*'.",
+		Args: cobra.ExactArgs(2),
+	}
+}
+
+type envCopyFlags struct {
+	include []string
+	exclude []string
+	force   bool
+	global  *internal.GlobalCommandOptions
+}
+
+func (f *envCopyFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.StringSliceVar(
+		&f.include, "include", nil, "Copies only the keys and config paths matching the pattern. Can be repeated.")
+	local.StringSliceVar(
+		&f.exclude, "exclude", nil, "Does not copy the keys and config paths matching the pattern. Can be repeated.")
+	local.BoolVar(&f.force, "force", false, "Replaces the target environment when it already exists.")
+	f.global = global
+}
+
+type envCopyAction struct {
+	envManager environment.Manager
+	flags      *envCopyFlags
+	args       []string
+}
+
+func newEnvCopyAction(envManager environment.Manager, flags *envCopyFlags, args []string) actions.Action {
+	return &envCopyAction{
+		envManager: envManager,
+		flags:      flags,
+		args:       args,
+	}
+}
+
+func (e *envCopyAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	source, err := getExistingEnvironment(ctx, e.envManager, e.args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := environment.NewBundle(source)
+	if err := bundle.Filter(e.flags.include, e.flags.exclude); err != nil {
+		return nil, err
+	}
+
+	target, err := applyBundle(ctx, e.envManager, bundle, e.args[1], e.flags.force)
+	if err != nil {
+		return nil, err
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf(
+				"Copied %d value(s) from environment %s to environment %s",
+				len(bundle.Values),
+				output.WithHighLightFormat(source.Name()),
+				output.WithHighLightFormat(target.Name()),
+			),
+		},
+	}, nil
+}
+
+// applyBundle applies the bundle to the environment with the given name, creating the environment when it does not
+// exist. An existing environment is only replaced when force is set.
+func applyBundle(
+	ctx context.Context,
+	envManager environment.Manager,
+	bundle *environment.Bundle,
+	name string,
+	force bool,
+) (*environment.Environment, error) {
+	env, err := envManager.Get(ctx, name)
+	if errors.Is(err, environment.ErrNotFound) {
+		env, err = envManager.Create(ctx, environment.Spec{Name: name})
+		if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("loading environment '%s': %w", name, err)
+	} else if !force {
+		return nil, fmt.Errorf("environment '%s' already exists. Use --force to replace it", name)
+	}
+
+	if err := bundle.Replace(env); err != nil {
+		return nil, err
+	}
+
+	if err := envManager.Save(ctx, env); err != nil {
+		return nil, fmt.Errorf("saving environment: %w", err)
+	}
+
+	return env, nil
+}
+
+func newEnvDeleteFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envDeleteFlags {
+	flags := &envDeleteFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <environment>",
+		Short: "Delete an environment.",
+		Long: "Deletes the .env values and the configuration of an environment. " +
+			"The Azure resources provisioned for the environment are not deleted, use 'azd down' to delete them.",
+		Args: cobra.ExactArgs(1),
+	}
+}
+
+type envDeleteFlags struct {
+	force  bool
+	remote bool
+	global *internal.GlobalCommandOptions
+}
+
+func (f *envDeleteFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.BoolVar(&f.force, "force", false, "Deletes the environment without confirmation.")
+	local.BoolVar(&f.remote, "remote", false, "Also deletes the environment from the remote state, when configured.")
+	f.global = global
+}
+
+type envDeleteAction struct {
+	envManager environment.Manager
+	console    input.Console
+	flags      *envDeleteFlags
+	args       []string
+}
+
+func newEnvDeleteAction(
+	envManager environment.Manager,
+	console input.Console,
+	flags *envDeleteFlags,
+	args []string,
+) actions.Action {
+	return &envDeleteAction{
+		envManager: envManager,
+		console:    console,
+		flags:      flags,
+		args:       args,
+	}
+}
+
+func (e *envDeleteAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	name := e.args[0]
+
+	if !e.flags.force {
+		confirm, err := e.console.Confirm(ctx, input.ConsoleOptions{
+			Message:      fmt.Sprintf("Delete environment '%s'?", name),
+			DefaultValue: false,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if !confirm {
+			return nil, errors.New("environment deletion cancelled")
+		}
+	}
+
+	err := e.envManager.DeleteWithOptions(ctx, name, &environment.DeleteOptions{Remote: e.flags.remote})
+	if errors.Is(err, environment.ErrNotFound) {
+		return nil, fmt.Errorf("environment '%s' does not exist", name)
+	} else if err != nil {
+		return nil, fmt.Errorf("deleting environment: %w", err)
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Deleted environment %s", output.WithHighLightFormat(name)),
+		},
+	}, nil
+}
+
+func newEnvExportFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envExportFlags {
+	flags := &envExportFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvExportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export",
+		Short: "Export an environment to a portable JSON or YAML bundle.",
+		Long: "Exports the .env values and the configuration of an environment to a bundle that can be imported on " +
+			"another machine with 'azd env import'.\n" +
+			"The bundle is written as YAML when the file has a .yaml or .yml extension, and as JSON otherwise. " +
+			"Secrets are written in plain text.",
+		Args: cobra.NoArgs,
+	}
+}
+
+type envExportFlags struct {
+	internal.EnvFlag
+	file   string
+	global *internal.GlobalCommandOptions
+}
+
+func (f *envExportFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	f.EnvFlag.Bind(local, global)
+	local.StringVar(&f.file, "file", "", "The file to write the bundle to. Writes JSON to the standard output when empty.")
+	f.global = global
+}
+
+type envExportAction struct {
+	azdCtx     *azdcontext.AzdContext
+	envManager environment.Manager
+	console    input.Console
+	writer     io.Writer
+	flags      *envExportFlags
+}
+
+func newEnvExportAction(
+	azdCtx *azdcontext.AzdContext,
+	envManager environment.Manager,
+	console input.Console,
+	writer io.Writer,
+	flags *envExportFlags,
+) actions.Action {
+	return &envExportAction{
+		azdCtx:     azdCtx,
+		envManager: envManager,
+		console:    console,
+		writer:     writer,
+		flags:      flags,
+	}
+}
+
+func (e *envExportAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	name := e.flags.EnvironmentName
+	if name == "" {
+		defaultName, err := e.azdCtx.GetDefaultEnvironmentName()
+		if err != nil {
+			return nil, err
+		}
+
+		name = defaultName
+	}
+
+	env, err := getExistingEnvironment(ctx, e.envManager, name)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := environment.NewBundle(env)
+	if len(bundle.Secrets) > 0 || len(bundle.ConfigSecrets) > 0 {
+		fmt.Fprintln(
+			e.console.Handles().Stderr,
+			output.WithWarningFormat("WARNING: The bundle contains secrets in plain text. Store it securely."),
+		)
+	}
+
+	if e.flags.file == "" {
+		return nil, bundle.Write(e.writer, environment.BundleFormatJson)
+	}
+
+	file, err := os.OpenFile(e.flags.file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, osutil.PermissionFileOwnerOnly)
+	if err != nil {
+		return nil, fmt.Errorf("creating bundle file: %w", err)
+	}
+	defer file.Close()
+
+	if err := bundle.Write(file, environment.BundleFormatFromPath(e.flags.file)); err != nil {
+		return nil, err
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf(
+				"Exported environment %s to %s", output.WithHighLightFormat(name), output.WithLinkFormat(e.flags.file)),
+		},
+	}, nil
+}
+
+func newEnvImportFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envImportFlags {
+	flags := &envImportFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvImportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import an environment from a bundle created with 'azd env export'.",
+		Args:  cobra.ExactArgs(1),
+	}
+}
+
+type envImportFlags struct {
+	name   string
+	force  bool
+	global *internal.GlobalCommandOptions
+}
+
+func (f *envImportFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.StringVar(&f.name, "name", "", "The name of the imported environment. Defaults to the name in the bundle.")
+	local.BoolVar(&f.force, "force", false, "Replaces the environment when it already exists.")
+	f.global = global
+}
+
+type envImportAction struct {
+	envManager environment.Manager
+	flags      *envImportFlags
+	args       []string
+}
+
+func newEnvImportAction(envManager environment.Manager, flags *envImportFlags, args []string) actions.Action {
+	return &envImportAction{
+		envManager: envManager,
+		flags:      flags,
+		args:       args,
+	}
+}
+
+func (e *envImportAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	filePath := e.args[0]
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("opening bundle file: %w", err)
+	}
+	defer file.Close()
+
+	bundle, err := environment.ReadBundle(file, environment.BundleFormatFromPath(filePath))
+	if err != nil {
+		return nil, err
+	}
+
+	name := e.flags.name
+	if name == "" {
+		name = bundle.Name
+	}
+
+	env, err := applyBundle(ctx, e.envManager, bundle, name, e.flags.force)
+	if err != nil {
+		return nil, err
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf(
+				"Imported environment %s from %s", output.WithHighLightFormat(env.Name()), output.WithLinkFormat(filePath)),
+		},
+	}, nil
+}
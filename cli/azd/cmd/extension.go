@@ -8,14 +8,25 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
+	"maps"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 	"github.com/spf13/cobra"
 )
 
@@ -56,11 +67,16 @@ func extensionActions(root *actions.ActionDescriptor) *actions.ActionDescriptor
 		ActionResolver: newExtensionShowAction,
 	})
 
-	// azd extension install <extension-name>
+	// azd extension install [extension-name]
 	group.Add("install", &actions.ActionDescriptorOptions{
 		Command: &cobra.Command{
-			Use:   "install <extension-name>",
+			Use:   "install [extension-name]",
 			Short: "Installs specified extensions.",
+			Long: fmt.Sprintf(
+				"Installs specified extensions.\n\nWhen no extension is specified, the extensions locked in the %s "+
+					"file of the current project are restored with their exact versions and digests.",
+				extensions.LockFileName,
+			),
 		},
 		ActionResolver: newExtensionInstallAction,
 		FlagsResolver:  newExtensionInstallFlags,
@@ -86,6 +102,42 @@ func extensionActions(root *actions.ActionDescriptor) *actions.ActionDescriptor
 		FlagsResolver:  newExtensionUpgradeFlags,
 	})
 
+	// azd extension init
+	group.Add("init", &actions.ActionDescriptorOptions{
+		Command: &cobra.Command{
+			Use:   "init",
+			Short: "Scaffold a new Go extension that uses the azd extension SDK.",
+		},
+		ActionResolver: newExtensionInitAction,
+		FlagsResolver:  newExtensionInitFlags,
+		OutputFormats:  []output.Format{output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
+	})
+
+	// azd extension pack
+	group.Add("pack", &actions.ActionDescriptorOptions{
+		Command: &cobra.Command{
+			Use:   "pack",
+			Short: "Cross-compile and package an extension for each platform.",
+		},
+		ActionResolver: newExtensionPackAction,
+		FlagsResolver:  newExtensionPackFlags,
+		OutputFormats:  []output.Format{output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
+	})
+
+	// azd extension publish
+	group.Add("publish", &actions.ActionDescriptorOptions{
+		Command: &cobra.Command{
+			Use:   "publish",
+			Short: "Add or update the packaged version of an extension in a registry.",
+		},
+		ActionResolver: newExtensionPublishAction,
+		FlagsResolver:  newExtensionPublishFlags,
+		OutputFormats:  []output.Format{output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
+	})
+
 	sourceGroup := group.Add("source", &actions.ActionDescriptorOptions{
 		Command: &cobra.Command{
 			Use:   "source",
@@ -406,6 +458,7 @@ type extensionInstallAction struct {
 	flags            *extensionInstallFlags
 	console          input.Console
 	extensionManager *extensions.Manager
+	lazyAzdCtx       *lazy.Lazy[*azdcontext.AzdContext]
 }
 
 func newExtensionInstallAction(
@@ -413,12 +466,14 @@ func newExtensionInstallAction(
 	flags *extensionInstallFlags,
 	console input.Console,
 	extensionManager *extensions.Manager,
+	lazyAzdCtx *lazy.Lazy[*azdcontext.AzdContext],
 ) actions.Action {
 	return &extensionInstallAction{
 		args:             args,
 		flags:            flags,
 		console:          console,
 		extensionManager: extensionManager,
+		lazyAzdCtx:       lazyAzdCtx,
 	}
 }
 
@@ -430,7 +485,7 @@ func (a *extensionInstallAction) Run(ctx context.Context) (*actions.ActionResult
 
 	extensionIds := a.args
 	if len(extensionIds) == 0 {
-		return nil, fmt.Errorf("must specify an extension name")
+		return a.restore(ctx)
 	}
 
 	if len(extensionIds) > 1 && a.flags.version != "" {
@@ -475,6 +530,18 @@ func (a *extensionInstallAction) Run(ctx context.Context) (*actions.ActionResult
 		}
 	}
 
+	// Pin the installed extensions the current project requires, if any
+	if azdCtx, err := a.lazyAzdCtx.GetValue(); err == nil {
+		projectConfig, err := project.Load(ctx, azdCtx.ProjectPath())
+		if err != nil {
+			return nil, fmt.Errorf("loading project config: %w", err)
+		}
+
+		if err := lockRequiredExtensions(ctx, azdCtx.ProjectDirectory(), projectConfig, a.extensionManager); err != nil {
+			return nil, err
+		}
+	}
+
 	return &actions.ActionResult{
 		Message: &actions.ResultMessage{
 			Header: "Extension(s) installed successfully",
@@ -482,6 +549,172 @@ func (a *extensionInstallAction) Run(ctx context.Context) (*actions.ActionResult
 	}, nil
 }
 
+// restore installs the exact versions of the extensions locked in the extensions lock file of the current project
+func (a *extensionInstallAction) restore(ctx context.Context) (*actions.ActionResult, error) {
+	if a.flags.version != "" {
+		return nil, fmt.Errorf("cannot specify --version flag when restoring extensions from %s", extensions.LockFileName)
+	}
+
+	suggestion := fmt.Sprintf(
+		"Specify the extensions to install, or run %s from a project with an %s file.",
+		output.WithHighLightFormat("azd extension install"),
+		extensions.LockFileName,
+	)
+
+	azdCtx, err := a.lazyAzdCtx.GetValue()
+	if err != nil {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        fmt.Errorf("must specify an extension name: %w", err),
+			Suggestion: suggestion,
+		}
+	}
+
+	lockFile, err := extensions.LoadLockFile(azdCtx.ProjectDirectory())
+	if errors.Is(err, extensions.ErrLockFileNotFound) {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        fmt.Errorf("must specify an extension name: %w", err),
+			Suggestion: suggestion,
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	extensionIds := slices.Sorted(maps.Keys(lockFile.Extensions))
+	for _, extensionId := range extensionIds {
+		locked := lockFile.Extensions[extensionId]
+
+		stepMessage := fmt.Sprintf("Restoring %s extension", output.WithHighLightFormat(extensionId))
+		a.console.ShowSpinner(ctx, stepMessage, input.Step)
+
+		lockedDigest, hasLockedDigest := locked.Digest()
+
+		// Extensions locked as dependencies of other locked extensions may have already been installed
+		installed, err := a.extensionManager.GetInstalled(extensions.LookupOptions{
+			Id: extensionId,
+		})
+		if err == nil {
+			if installed.Version != locked.Version {
+				a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+				return nil, &internal.ErrorWithSuggestion{
+					Err: fmt.Errorf(
+						"extension %s version %s is installed, but version %s is locked",
+						extensionId, installed.Version, locked.Version,
+					),
+					Suggestion: fmt.Sprintf(
+						"Install the locked version with %s",
+						output.WithHighLightFormat("azd extension upgrade %s --version %s", extensionId, locked.Version),
+					),
+				}
+			}
+
+			// The installed artifact must be the locked one, not just the same version
+			if hasLockedDigest && installed.Digest != lockedDigest {
+				a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+				return nil, &internal.ErrorWithSuggestion{
+					Err: fmt.Errorf(
+						"%w: extension %s version %s is installed with digest '%s', but digest '%s' is locked",
+						extensions.ErrDigestMismatch, extensionId, installed.Version, installed.Digest, lockedDigest,
+					),
+					Suggestion: fmt.Sprintf(
+						"Reinstall the locked artifact with %s, then %s",
+						output.WithHighLightFormat("azd extension uninstall %s", extensionId),
+						output.WithHighLightFormat("azd extension install"),
+					),
+				}
+			}
+
+			stepMessage += output.WithGrayFormat(" (version %s already installed)", installed.Version)
+			a.console.StopSpinner(ctx, stepMessage, input.StepSkipped)
+		} else {
+			_, err = a.extensionManager.Install(ctx, extensionId, locked.FilterOptions())
+			if err != nil {
+				a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+				return nil, fmt.Errorf("failed to restore extension %s: %w", extensionId, err)
+			}
+
+			installed, err = a.extensionManager.GetInstalled(extensions.LookupOptions{
+				Id: extensionId,
+			})
+			if err != nil {
+				a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+				return nil, fmt.Errorf("failed to restore extension %s: %w", extensionId, err)
+			}
+
+			stepMessage += output.WithGrayFormat(" (%s)", installed.Version)
+			a.console.StopSpinner(ctx, stepMessage, input.StepDone)
+		}
+
+		// Extension packs don't have artifacts, so only the artifacts of other extensions are expected to be locked
+		if !hasLockedDigest && installed.Digest != "" {
+			a.console.MessageUxItem(ctx, &ux.WarningMessage{
+				Description: fmt.Sprintf(
+					"The artifact of extension %s wasn't verified, since %s has no digest for the %s/%s platform.",
+					extensionId,
+					extensions.LockFileName,
+					runtime.GOOS,
+					runtime.GOARCH,
+				),
+			})
+		}
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Extension(s) restored successfully from %s", extensions.LockFileName),
+		},
+	}, nil
+}
+
+// lockRequiredExtensions records the installed versions & digests of the extensions the project requires
+// in the extensions lock file next to azure.yaml, with the registry checksums of the artifacts of every platform
+func lockRequiredExtensions(
+	ctx context.Context,
+	projectDir string,
+	projectConfig *project.ProjectConfig,
+	extensionManager *extensions.Manager,
+) error {
+	if projectConfig.RequiredVersions == nil || len(projectConfig.RequiredVersions.Extensions) == 0 {
+		return nil
+	}
+
+	installedExtensions, err := extensionManager.ListInstalled()
+	if err != nil {
+		return fmt.Errorf("listing installed extensions: %w", err)
+	}
+
+	lockFile, err := extensions.LoadOrCreateLockFile(projectDir)
+	if err != nil {
+		return err
+	}
+
+	for extensionId := range projectConfig.RequiredVersions.Extensions {
+		installed, has := installedExtensions[extensionId]
+		if !has {
+			continue
+		}
+
+		extension, err := extensionManager.GetFromRegistry(ctx, extensionId, &extensions.FilterOptions{
+			Source: installed.Source,
+		})
+		if err != nil {
+			return fmt.Errorf("getting extension %s from the registry: %w", extensionId, err)
+		}
+
+		var installedVersion *extensions.ExtensionVersion
+		for i, version := range extension.Versions {
+			if version.Version == installed.Version {
+				installedVersion = &extension.Versions[i]
+				break
+			}
+		}
+
+		lockFile.Lock(installed, installedVersion)
+	}
+
+	return lockFile.Save(projectDir)
+}
+
 // azd extension uninstall
 type extensionUninstallFlags struct {
 	all bool
@@ -704,6 +937,345 @@ func (a *extensionUpgradeAction) Run(ctx context.Context) (*actions.ActionResult
 	}, nil
 }
 
+// azd extension init
+type extensionInitFlags struct {
+	id           string
+	namespace    string
+	displayName  string
+	description  string
+	capabilities []string
+	path         string
+}
+
+func newExtensionInitFlags(cmd *cobra.Command) *extensionInitFlags {
+	flags := &extensionInitFlags{}
+	cmd.Flags().StringVar(&flags.id, "id", "", "The unique identifier of the extension, e.g. contoso.azd.rust")
+	cmd.Flags().StringVar(&flags.namespace, "namespace", "", "The command group of the extension commands")
+	cmd.Flags().StringVar(&flags.displayName, "name", "", "The display name of the extension")
+	cmd.Flags().StringVar(&flags.description, "description", "", "A brief description of the extension")
+	cmd.Flags().StringSliceVar(&flags.capabilities, "capabilities", nil, "The capabilities the extension provides")
+	cmd.Flags().StringVarP(&flags.path, "path", "p", "", "The directory of the extension. Defaults to ./<id>")
+
+	return flags
+}
+
+type extensionInitAction struct {
+	flags         *extensionInitFlags
+	console       input.Console
+	commandRunner exec.CommandRunner
+}
+
+func newExtensionInitAction(
+	flags *extensionInitFlags,
+	console input.Console,
+	commandRunner exec.CommandRunner,
+) actions.Action {
+	return &extensionInitAction{
+		flags:         flags,
+		console:       console,
+		commandRunner: commandRunner,
+	}
+}
+
+func (a *extensionInitAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	a.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title:     "Create a new azd extension (azd extension init)",
+		TitleNote: "Scaffolds a Go extension that uses the azd extension SDK",
+	})
+
+	options := extensions.ScaffoldOptions{
+		Id:          a.flags.id,
+		Namespace:   a.flags.namespace,
+		DisplayName: a.flags.displayName,
+		Description: a.flags.description,
+	}
+
+	if options.Id == "" {
+		id, err := a.console.Prompt(ctx, input.ConsoleOptions{
+			Message: "Enter the id of the extension (e.g. contoso.azd.rust):",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		options.Id = strings.TrimSpace(id)
+		if options.Id == "" {
+			return nil, errors.New("extension id is required")
+		}
+	}
+
+	if options.DisplayName == "" {
+		displayName, err := a.console.Prompt(ctx, input.ConsoleOptions{
+			Message:      "Enter the display name of the extension:",
+			DefaultValue: options.Id,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		options.DisplayName = displayName
+	}
+
+	capabilities := a.flags.capabilities
+	if len(capabilities) == 0 {
+		allCapabilities := []string{
+			string(extensions.CustomCommandCapability),
+			string(extensions.LifecycleEventsCapability),
+			string(extensions.ServiceTargetProviderCapability),
+			string(extensions.FrameworkServiceProviderCapability),
+			string(extensions.ProvisioningProviderCapability),
+		}
+
+		selected, err := a.console.MultiSelect(ctx, input.ConsoleOptions{
+			Message:      "Select the capabilities of the extension:",
+			Options:      allCapabilities,
+			DefaultValue: []string{string(extensions.CustomCommandCapability)},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		capabilities = selected
+	}
+
+	for _, capability := range capabilities {
+		options.Capabilities = append(options.Capabilities, extensions.CapabilityType(capability))
+	}
+
+	extensionDir := a.flags.path
+	if extensionDir == "" {
+		extensionDir = options.Id
+	}
+
+	spinnerMessage := fmt.Sprintf("Creating extension %s", output.WithHighLightFormat(options.Id))
+	a.console.ShowSpinner(ctx, spinnerMessage, input.Step)
+	err := extensions.Scaffold(extensionDir, options)
+	a.console.StopSpinner(ctx, spinnerMessage, input.GetStepResultFormat(err))
+	if err != nil {
+		return nil, fmt.Errorf("failed creating extension: %w", err)
+	}
+
+	followUp := fmt.Sprintf(
+		"Run %s in %s to build the extension for each platform.",
+		output.WithHighLightFormat("azd extension pack"),
+		extensionDir,
+	)
+
+	// go.mod requires the azd extension SDK azd is built with, go mod tidy downloads it with its dependencies
+	if err := a.tidyModule(ctx, extensionDir); err != nil {
+		log.Printf("failed downloading the dependencies of the extension: %v", err)
+		followUp = fmt.Sprintf(
+			"Run %s in %s to download the azd extension SDK, then %s to build the extension for each platform.",
+			output.WithHighLightFormat("go mod tidy"),
+			extensionDir,
+			output.WithHighLightFormat("azd extension pack"),
+		)
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header:   fmt.Sprintf("Created extension %s in %s", options.Id, extensionDir),
+			FollowUp: followUp,
+		},
+	}, nil
+}
+
+// tidyModule downloads the modules required by the Go module of the new extension, when Go is installed
+func (a *extensionInitAction) tidyModule(ctx context.Context, extensionDir string) error {
+	if err := tools.ToolInPath("go"); err != nil {
+		return err
+	}
+
+	spinnerMessage := "Downloading the azd extension SDK"
+	a.console.ShowSpinner(ctx, spinnerMessage, input.Step)
+	_, err := a.commandRunner.Run(ctx, exec.NewRunArgs("go", "mod", "tidy").WithCwd(extensionDir))
+	a.console.StopSpinner(ctx, spinnerMessage, input.GetStepResultFormat(err))
+
+	return err
+}
+
+// azd extension pack
+type extensionPackFlags struct {
+	path      string
+	output    string
+	platforms []string
+}
+
+func newExtensionPackFlags(cmd *cobra.Command) *extensionPackFlags {
+	flags := &extensionPackFlags{}
+	cmd.Flags().StringVarP(&flags.path, "path", "p", ".", "The directory of the extension")
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "dist", "The directory the packages are written to")
+	cmd.Flags().StringSliceVar(&flags.platforms,
+		"platforms", extensions.DefaultPackPlatforms, "The platforms (os/arch) to package the extension for")
+
+	return flags
+}
+
+type extensionPackAction struct {
+	flags   *extensionPackFlags
+	console input.Console
+	packer  *extensions.Packer
+}
+
+func newExtensionPackAction(
+	flags *extensionPackFlags,
+	console input.Console,
+	packer *extensions.Packer,
+) actions.Action {
+	return &extensionPackAction{
+		flags:   flags,
+		console: console,
+		packer:  packer,
+	}
+}
+
+func (a *extensionPackAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	a.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title:     "Package an azd extension (azd extension pack)",
+		TitleNote: "Cross-compiles and packages the extension for each platform",
+	})
+
+	manifest, err := extensions.LoadManifest(a.flags.path)
+	if err != nil {
+		return nil, err
+	}
+
+	spinnerMessage := fmt.Sprintf(
+		"Packaging %s %s for %d platforms",
+		output.WithHighLightFormat(manifest.Id), manifest.Version, len(a.flags.platforms),
+	)
+	a.console.ShowSpinner(ctx, spinnerMessage, input.Step)
+	artifacts, err := a.packer.Pack(ctx, a.flags.path, manifest, extensions.PackOptions{
+		OutputDir: a.flags.output,
+		Platforms: a.flags.platforms,
+	})
+	a.console.StopSpinner(ctx, spinnerMessage, input.GetStepResultFormat(err))
+	if err != nil {
+		return nil, fmt.Errorf("failed packaging extension: %w", err)
+	}
+
+	for _, artifact := range artifacts {
+		a.console.Message(ctx, fmt.Sprintf("  %s %s", artifact.Platform, output.WithGrayFormat(artifact.Path)))
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Packaged %s %s", manifest.Id, manifest.Version),
+			FollowUp: fmt.Sprintf(
+				"Run %s to add the version to a registry.",
+				output.WithHighLightFormat("azd extension publish"),
+			),
+		},
+	}, nil
+}
+
+// azd extension publish
+type extensionPublishFlags struct {
+	path     string
+	output   string
+	registry string
+	source   string
+	baseUrl  string
+}
+
+func newExtensionPublishFlags(cmd *cobra.Command) *extensionPublishFlags {
+	flags := &extensionPublishFlags{}
+	cmd.Flags().StringVarP(&flags.path, "path", "p", ".", "The directory of the extension")
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "dist", "The directory of the packages of azd extension pack")
+	cmd.Flags().StringVarP(&flags.registry, "registry", "r", "", "The path of the registry.json file to publish to")
+	cmd.Flags().StringVarP(&flags.source, "source", "s", "", "The name of the file extension source to publish to")
+	cmd.Flags().StringVarP(&flags.baseUrl,
+		"base-url", "b", "", "The URL the packages are uploaded to. Defaults to the local paths of the packages")
+
+	return flags
+}
+
+type extensionPublishAction struct {
+	flags         *extensionPublishFlags
+	console       input.Console
+	sourceManager *extensions.SourceManager
+}
+
+func newExtensionPublishAction(
+	flags *extensionPublishFlags,
+	console input.Console,
+	sourceManager *extensions.SourceManager,
+) actions.Action {
+	return &extensionPublishAction{
+		flags:         flags,
+		console:       console,
+		sourceManager: sourceManager,
+	}
+}
+
+func (a *extensionPublishAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	a.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title:     "Publish an azd extension (azd extension publish)",
+		TitleNote: "Adds or updates the packaged version of the extension in a registry",
+	})
+
+	if (a.flags.registry == "") == (a.flags.source == "") {
+		return nil, errors.New("must specify exactly one of --registry or --source")
+	}
+
+	registryPath := a.flags.registry
+	if a.flags.source != "" {
+		sourceConfig, err := a.sourceManager.Get(ctx, a.flags.source)
+		if err != nil {
+			return nil, err
+		}
+
+		registryPath, err = extensions.FileSourcePath(sourceConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	manifest, err := extensions.LoadManifest(a.flags.path)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := extensions.ListPackedArtifacts(a.flags.output, manifest)
+	if err != nil {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        err,
+			Suggestion: fmt.Sprintf("Run %s first.", output.WithHighLightFormat("azd extension pack")),
+		}
+	}
+
+	spinnerMessage := fmt.Sprintf("Publishing %s %s to %s", output.WithHighLightFormat(manifest.Id), manifest.Version, registryPath)
+	a.console.ShowSpinner(ctx, spinnerMessage, input.Step)
+	updated, err := extensions.Publish(registryPath, manifest, artifacts, extensions.PublishOptions{
+		BaseUrl: a.flags.baseUrl,
+	})
+	a.console.StopSpinner(ctx, spinnerMessage, input.GetStepResultFormat(err))
+	if err != nil {
+		return nil, fmt.Errorf("failed publishing extension: %w", err)
+	}
+
+	header := fmt.Sprintf("Added version %s of %s", manifest.Version, manifest.Id)
+	if updated {
+		header = fmt.Sprintf("Updated version %s of %s", manifest.Version, manifest.Id)
+	}
+
+	followUp := ""
+	if a.flags.baseUrl != "" {
+		followUp = fmt.Sprintf(
+			"Upload the packages in %s to %s.",
+			filepath.Join(a.flags.output, manifest.Id, manifest.Version),
+			a.flags.baseUrl,
+		)
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header:   header,
+			FollowUp: followUp,
+		},
+	}, nil
+}
+
 type extensionSourceListAction struct {
 	formatter     output.Formatter
 	writer        io.Writer
@@ -755,9 +1327,11 @@ func (a *extensionSourceListAction) Run(ctx context.Context) (*actions.ActionRes
 }
 
 type extensionSourceAddFlags struct {
-	name     string
-	location string
-	kind     string
+	name             string
+	location         string
+	kind             string
+	trustedKeys      []string
+	requireSignature bool
 }
 
 func newExtensionSourceAddFlags(cmd *cobra.Command) *extensionSourceAddFlags {
@@ -766,6 +1340,10 @@ func newExtensionSourceAddFlags(cmd *cobra.Command) *extensionSourceAddFlags {
 	cmd.Flags().StringVarP(&flags.location, "location", "l", "", "The location of the extension source")
 	cmd.Flags().StringVarP(&flags.kind,
 		"type", "t", "", "The type of the extension source. Supported types are 'file' and 'url'")
+	cmd.Flags().StringArrayVar(&flags.trustedKeys,
+		"trusted-key", nil, "A base64 encoded ed25519 public key trusted to sign the artifacts of the extension source")
+	cmd.Flags().BoolVar(&flags.requireSignature,
+		"require-signature", false, "Refuse to install artifacts that aren't signed by a trusted key")
 
 	return flags
 }
@@ -798,9 +1376,11 @@ func (a *extensionSourceAddAction) Run(ctx context.Context) (*actions.ActionResu
 	a.console.ShowSpinner(ctx, spinnerMessage, input.Step)
 
 	sourceConfig := &extensions.SourceConfig{
-		Type:     extensions.SourceKind(a.flags.kind),
-		Location: a.flags.location,
-		Name:     a.flags.name,
+		Type:             extensions.SourceKind(a.flags.kind),
+		Location:         a.flags.location,
+		Name:             a.flags.name,
+		TrustedKeys:      a.flags.trustedKeys,
+		RequireSignature: a.flags.requireSignature,
 	}
 
 	// Validate the custom source config
@@ -483,6 +483,12 @@ func (i *initAction) initializeExtensions(ctx context.Context, azdCtx *azdcontex
 		return fmt.Errorf("listing installed extensions: %w", err)
 	}
 
+	// Extensions locked by the project are installed with their exact versions & digests
+	lockFile, err := extensions.LoadOrCreateLockFile(azdCtx.ProjectDirectory())
+	if err != nil {
+		return err
+	}
+
 	i.console.Message(ctx, "\nInstalling required extensions...")
 
 	for extensionId, versionConstraint := range projectConfig.RequiredVersions.Extensions {
@@ -503,6 +509,10 @@ func (i *initAction) initializeExtensions(ctx context.Context, azdCtx *azdcontex
 			filterOptions := &extensions.FilterOptions{
 				Version: installConstraint,
 			}
+			if locked, has := lockFile.Extensions[extensionId]; has {
+				filterOptions = locked.FilterOptions()
+			}
+
 			extensionVersion, err := i.extensionsManager.Install(ctx, extensionId, filterOptions)
 			if err != nil {
 				i.console.StopSpinner(ctx, stepMessage, input.StepFailed)
@@ -514,7 +524,7 @@ func (i *initAction) initializeExtensions(ctx context.Context, azdCtx *azdcontex
 		}
 	}
 
-	return nil
+	return lockRequiredExtensions(ctx, azdCtx.ProjectDirectory(), projectConfig, i.extensionsManager)
 }
 
 func getCmdInitHelpDescription(*cobra.Command) string {
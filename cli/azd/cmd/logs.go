@@ -0,0 +1,356 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type logsFlags struct {
+	follow bool
+	since  time.Duration
+	global *internal.GlobalCommandOptions
+	internal.EnvFlag
+}
+
+func (l *logsFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.BoolVarP(&l.follow, "follow", "f", false, "Keep streaming the logs as they are written.")
+	local.DurationVar(
+		&l.since,
+		"since",
+		0,
+		"Only show the logs more recent than a duration, such as 10m or 1h. Not supported by App Service.",
+	)
+	l.EnvFlag.Bind(local, global)
+	l.global = global
+}
+
+func newLogsFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *logsFlags {
+	flags := &logsFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs [service]",
+		Short: fmt.Sprintf("Stream the logs of a deployed application. %s", output.WithWarningFormat("(Beta)")),
+	}
+	cmd.Args = cobra.MaximumNArgs(1)
+	return cmd
+}
+
+type logsAction struct {
+	flags           *logsFlags
+	args            []string
+	console         input.Console
+	formatter       output.Formatter
+	writer          io.Writer
+	env             *environment.Environment
+	projectConfig   *project.ProjectConfig
+	importManager   *project.ImportManager
+	serviceManager  project.ServiceManager
+	resourceManager project.ResourceManager
+}
+
+func newLogsAction(
+	flags *logsFlags,
+	args []string,
+	console input.Console,
+	formatter output.Formatter,
+	writer io.Writer,
+	env *environment.Environment,
+	projectConfig *project.ProjectConfig,
+	importManager *project.ImportManager,
+	serviceManager project.ServiceManager,
+	resourceManager project.ResourceManager,
+) actions.Action {
+	return &logsAction{
+		flags:           flags,
+		args:            args,
+		console:         console,
+		formatter:       formatter,
+		writer:          writer,
+		env:             env,
+		projectConfig:   projectConfig,
+		importManager:   importManager,
+		serviceManager:  serviceManager,
+		resourceManager: resourceManager,
+	}
+}
+
+// serviceLogs are the logs of a service, streamed by its service target.
+type serviceLogs struct {
+	serviceConfig  *project.ServiceConfig
+	logsTarget     project.ServiceLogsTarget
+	targetResource *environment.TargetResource
+}
+
+func (la *logsAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if la.flags.since < 0 {
+		return nil, fmt.Errorf("invalid --since '%s': the duration must be positive", la.flags.since)
+	}
+
+	if la.env.GetSubscriptionId() == "" {
+		return nil, errors.New(
+			"infrastructure has not been provisioned. Run `azd provision`",
+		)
+	}
+
+	targetServiceName := ""
+	if len(la.args) == 1 {
+		targetServiceName = la.args[0]
+		if has, err := la.importManager.HasService(ctx, la.projectConfig, targetServiceName); err != nil {
+			return nil, err
+		} else if !has {
+			return nil, fmt.Errorf("service name '%s' doesn't exist", targetServiceName)
+		}
+	}
+
+	stableServices, err := la.importManager.ServiceStable(ctx, la.projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []serviceLogs
+	for _, svc := range stableServices {
+		if targetServiceName != "" && targetServiceName != svc.Name {
+			continue
+		}
+
+		serviceTarget, err := la.serviceManager.GetServiceTarget(ctx, svc)
+		if err != nil {
+			return nil, err
+		}
+
+		logsTarget, ok := serviceTarget.(project.ServiceLogsTarget)
+		if !ok {
+			if targetServiceName != "" {
+				return nil, fmt.Errorf("streaming logs is not supported for service '%s' with host '%s'", svc.Name, svc.Host)
+			}
+
+			la.console.Message(ctx, output.WithWarningFormat(
+				"WARNING: Skipping service '%s', streaming logs is not supported for host '%s'.", svc.Name, svc.Host))
+			continue
+		}
+
+		if la.flags.since > 0 && svc.Host == project.AppServiceTarget {
+			la.console.Message(ctx, output.WithWarningFormat(
+				"WARNING: --since is not supported for service '%s' with host '%s', its logs start with the most "+
+					"recent lines.", svc.Name, svc.Host))
+		}
+
+		targetResource, err := la.resourceManager.GetTargetResource(ctx, la.env.GetSubscriptionId(), svc)
+		if err != nil {
+			return nil, fmt.Errorf("getting target resource of service '%s': %w", svc.Name, err)
+		}
+
+		services = append(services, serviceLogs{
+			serviceConfig:  svc,
+			logsTarget:     logsTarget,
+			targetResource: targetResource,
+		})
+	}
+
+	if len(services) == 0 {
+		return nil, errors.New("no service supports streaming logs")
+	}
+
+	names := make([]string, len(services))
+	for i, svc := range services {
+		names[i] = svc.serviceConfig.Name
+	}
+
+	logsOutput := &logsOutput{writer: la.writer, format: la.formatter.Kind(), names: names}
+	options := &project.ServiceLogsOptions{
+		Follow: la.flags.follow,
+		Since:  la.flags.since,
+	}
+
+	return nil, streamLogs(ctx, services, options, logsOutput)
+}
+
+// streamLogs streams the logs of the services concurrently. The first stream that fails stops the other streams, which
+// would otherwise keep streaming until the command is interrupted when following the logs.
+func streamLogs(
+	ctx context.Context,
+	services []serviceLogs,
+	options *project.ServiceLogsOptions,
+	logsOutput *logsOutput,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, len(services))
+	var wg sync.WaitGroup
+	for i, svc := range services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			writer := logsOutput.serviceWriter(i)
+			err := svc.logsTarget.Logs(ctx, svc.serviceConfig, svc.targetResource, options, writer)
+			writer.flush()
+
+			// the streams stopped by the failure of another stream are not failures themselves
+			if err != nil && ctx.Err() == nil {
+				errs[i] = fmt.Errorf("streaming logs of service '%s': %w", svc.serviceConfig.Name, err)
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// serviceLogColors are the colors of the prefixes of the lines of the services, in turn.
+var serviceLogColors = []color.Attribute{
+	color.FgCyan,
+	color.FgMagenta,
+	color.FgGreen,
+	color.FgYellow,
+	color.FgBlue,
+	color.FgRed,
+}
+
+// logsOutput multiplexes the logs of the services to the output of the command. In the JSON format, each line is a
+// contracts.LogLine, with the time the service wrote it at when its host reports it. Otherwise, each line is prefixed
+// with the name of its service, in the color of the service.
+type logsOutput struct {
+	mu     sync.Mutex
+	writer io.Writer
+	format output.Format
+	names  []string
+}
+
+// serviceWriter returns the writer of the logs of the service at index i of the names.
+func (o *logsOutput) serviceWriter(i int) *serviceLogWriter {
+	width := 0
+	for _, name := range o.names {
+		width = max(width, len(name))
+	}
+
+	prefix := color.New(serviceLogColors[i%len(serviceLogColors)]).Sprintf("%-*s | ", width, o.names[i])
+	return &serviceLogWriter{output: o, service: o.names[i], prefix: prefix}
+}
+
+// writeLine writes a line of the service, which the service wrote at the timestamp, unless the timestamp is nil.
+func (o *logsOutput) writeLine(service string, prefix string, timestamp *time.Time, line string) error {
+	var data []byte
+	if o.format == output.JsonFormat {
+		lineJson, err := json.Marshal(contracts.LogLine{
+			Timestamp: timestamp,
+			Service:   service,
+			Message:   line,
+		})
+		if err != nil {
+			return err
+		}
+
+		data = append(lineJson, '\n')
+	} else {
+		data = []byte(prefix + line + "\n")
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	_, err := o.writer.Write(data)
+	return err
+}
+
+var _ project.ServiceLogLineWriter = (*serviceLogWriter)(nil)
+
+// serviceLogWriter splits the logs of a service into lines, and writes each line to the output of the command at once,
+// so the lines of the services don't interleave.
+type serviceLogWriter struct {
+	output  *logsOutput
+	service string
+	prefix  string
+
+	mu      sync.Mutex
+	partial []byte
+}
+
+func (w *serviceLogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i == -1 {
+			break
+		}
+
+		line := bytes.TrimSuffix(w.partial[:i], []byte("\r"))
+		if err := w.output.writeLine(w.service, w.prefix, nil, string(line)); err != nil {
+			return 0, err
+		}
+		w.partial = w.partial[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// WriteLine writes a line of the logs that the service wrote at the timestamp.
+func (w *serviceLogWriter) WriteLine(timestamp time.Time, line string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.output.writeLine(w.service, w.prefix, &timestamp, line)
+}
+
+// flush writes the last line of the logs, when it doesn't end with a new line.
+func (w *serviceLogWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.partial) > 0 {
+		_ = w.output.writeLine(w.service, w.prefix, nil, string(w.partial))
+		w.partial = nil
+	}
+}
+
+func getCmdLogsHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		fmt.Sprintf("Stream the logs of a deployed application. %s", output.WithWarningFormat("(Beta)")),
+		[]string{
+			formatHelpNote("The console logs of each service are streamed from the resource hosting it: the log stream " +
+				"of Container Apps, App Service and Spring Apps, and the logs of the pods of the deployment on AKS."),
+			formatHelpNote("When no service is specified, the logs of all the services are streamed, each line " +
+				"prefixed with the name of its service."),
+		})
+}
+
+func getCmdLogsHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"Show the most recent logs of all the services.": output.WithHighLightFormat("azd logs"),
+		"Stream the logs of a specific service as they are written.": fmt.Sprintf("%s %s",
+			output.WithHighLightFormat("azd logs <service> --follow"),
+			output.WithWarningFormat("[Service name]")),
+		"Show the logs of the last 30 minutes as JSON lines.": output.WithHighLightFormat(
+			"azd logs --since 30m --output json"),
+	})
+}
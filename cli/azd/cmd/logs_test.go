@@ -0,0 +1,109 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_logsOutput(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	t.Cleanup(func() { color.NoColor = noColor })
+
+	t.Run("Prefixed", func(t *testing.T) {
+		writer := &strings.Builder{}
+		logsOutput := &logsOutput{writer: writer, format: output.NoneFormat, names: []string{"api", "web-app"}}
+
+		api := logsOutput.serviceWriter(0)
+		web := logsOutput.serviceWriter(1)
+
+		// partial lines are written once they are complete
+		_, err := api.Write([]byte("listening"))
+		require.NoError(t, err)
+		_, err = web.Write([]byte("ready\r\nGET /\n"))
+		require.NoError(t, err)
+		_, err = api.Write([]byte(" on 8080\nstopping"))
+		require.NoError(t, err)
+		api.flush()
+
+		require.Equal(t,
+			"web-app | ready\n"+
+				"web-app | GET /\n"+
+				"api     | listening on 8080\n"+
+				"api     | stopping\n",
+			writer.String())
+	})
+
+	t.Run("Json", func(t *testing.T) {
+		writer := &strings.Builder{}
+		logsOutput := &logsOutput{writer: writer, format: output.JsonFormat, names: []string{"api"}}
+
+		// the timestamp is only set when the host of the service reports it
+		timestamp := time.Date(2024, 5, 1, 11, 58, 0, 0, time.UTC)
+		api := logsOutput.serviceWriter(0)
+		_, err := api.Write([]byte("starting\n"))
+		require.NoError(t, err)
+		require.NoError(t, api.WriteLine(timestamp, "listening on 8080"))
+
+		lines := strings.Split(strings.TrimSuffix(writer.String(), "\n"), "\n")
+		require.Len(t, lines, 2)
+		require.NotContains(t, lines[0], "timestamp")
+
+		var line contracts.LogLine
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &line))
+		require.Equal(t, contracts.LogLine{Service: "api", Message: "starting"}, line)
+
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &line))
+		require.Equal(t, contracts.LogLine{Timestamp: &timestamp, Service: "api", Message: "listening on 8080"}, line)
+	})
+}
+
+func Test_streamLogs(t *testing.T) {
+	following := logsTargetFunc(func(ctx context.Context, writer io.Writer) error {
+		_, _ = writer.Write([]byte("listening\n"))
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	failing := logsTargetFunc(func(ctx context.Context, writer io.Writer) error {
+		return errors.New("replica not found")
+	})
+
+	services := []serviceLogs{
+		{serviceConfig: &project.ServiceConfig{Name: "api"}, logsTarget: following},
+		{serviceConfig: &project.ServiceConfig{Name: "web"}, logsTarget: failing},
+	}
+
+	writer := &strings.Builder{}
+	logsOutput := &logsOutput{writer: writer, format: output.NoneFormat, names: []string{"api", "web"}}
+
+	// the failure of a service stops following the logs of the other services
+	err := streamLogs(context.Background(), services, &project.ServiceLogsOptions{Follow: true}, logsOutput)
+	require.EqualError(t, err, "streaming logs of service 'web': replica not found")
+}
+
+type logsTargetFunc func(ctx context.Context, writer io.Writer) error
+
+func (f logsTargetFunc) Logs(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	targetResource *environment.TargetResource,
+	options *project.ServiceLogsOptions,
+	writer io.Writer,
+) error {
+	return f(ctx, writer)
+}
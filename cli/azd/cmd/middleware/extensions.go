@@ -45,18 +45,19 @@ func (m *ExtensionsMiddleware) Run(ctx context.Context, next NextFn) (*actions.A
 		return nil, err
 	}
 
-	requireLifecycleEvents := false
 	extensionList := []*extensions.Extension{}
 
-	// Find extensions that require lifecycle events
+	// Find extensions that require lifecycle events or provide service targets, framework services or
+	// provisioning providers, which listen for requests of azd
 	for _, extension := range installedExtensions {
-		if slices.Contains(extension.Capabilities, extensions.LifecycleEventsCapability) {
+		if slices.ContainsFunc(extension.Capabilities, func(capability extensions.CapabilityType) bool {
+			return slices.Contains(extensions.ListenCapabilities, capability)
+		}) {
 			extensionList = append(extensionList, extension)
-			requireLifecycleEvents = true
 		}
 	}
 
-	if !requireLifecycleEvents {
+	if len(extensionList) == 0 {
 		return next(ctx)
 	}
 
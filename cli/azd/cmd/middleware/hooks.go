@@ -195,6 +195,10 @@ func (m *HooksMiddleware) createServiceEventHandler(
 	hooksRunner *ext.HooksRunner,
 ) ext.EventHandlerFn[project.ServiceLifecycleEventArgs] {
 	return func(ctx context.Context, eventArgs project.ServiceLifecycleEventArgs) error {
+		if values, ok := eventArgs.Args[project.ServiceEventEnvArg].(map[string]string); ok {
+			return hooksRunner.WithEnv(values).RunHooks(ctx, hookType, nil, hookName)
+		}
+
 		return hooksRunner.RunHooks(ctx, hookType, nil, hookName)
 	}
 }
@@ -65,13 +65,16 @@ func (pc *pipelineConfigFlags) Bind(local *pflag.FlagSet, global *internal.Globa
 	// default provider is empty because it can be set from azure.yaml. By letting default here be empty, we know that
 	// there no customer input using --provider
 	local.StringVar(&pc.PipelineProvider, "provider", "",
-		"The pipeline provider to use (github for Github Actions and azdo for Azure Pipelines).")
+		"The pipeline provider to use (github for Github Actions, azdo for Azure Pipelines and gitlab for GitLab CI/CD).")
 	local.StringVarP(&pc.ServiceManagementReference, "applicationServiceManagementReference", "m", "",
 		"Service Management Reference. "+
 			"References application or service contact information from a Service or Asset Management database. "+
 			"This value must be a Universally Unique Identifier (UUID). "+
 			"You can set this value globally by running "+
 			"azd config set pipeline.config.applicationServiceManagementReference <UUID>.")
+	local.StringSliceVar(&pc.PipelineEnvironments, "environments", nil,
+		"The environments deployed by the pipeline, in order, one deployment stage per environment. "+
+			"The deployment to each environment after the first one waits for a manual approval.")
 	pc.EnvFlag.Bind(local, global)
 	pc.global = global
 }
@@ -253,5 +256,14 @@ func getCmdPipelineConfigHelpFooter(c *cobra.Command) string {
 			output.WithWarningFormat("app-test"),
 			output.WithHighLightFormat("--provider azdo"),
 		),
+		"Configure a deployment pipeline for 'app-test' environment on GitLab CI/CD.": fmt.Sprintf("%s %s %s",
+			output.WithHighLightFormat("azd pipeline config -e"),
+			output.WithWarningFormat("app-test"),
+			output.WithHighLightFormat("--provider gitlab"),
+		),
+		"Configure a deployment pipeline deploying to 'app-dev', then 'app-prod' after approval.": fmt.Sprintf("%s %s",
+			output.WithHighLightFormat("azd pipeline config --environments"),
+			output.WithWarningFormat("app-dev,app-prod"),
+		),
 	})
 }
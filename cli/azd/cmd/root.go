@@ -129,6 +129,7 @@ func NewRootCmd(
 	templatesActions(root)
 	authActions(root)
 	hooksActions(root)
+	workflowActions(root)
 
 	root.Add("version", &actions.ActionDescriptorOptions{
 		Command: &cobra.Command{
@@ -320,6 +321,21 @@ func NewRootCmd(
 		},
 	})
 
+	root.Add("logs", &actions.ActionDescriptorOptions{
+		Command:        newLogsCmd(),
+		FlagsResolver:  newLogsFlags,
+		ActionResolver: newLogsAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdLogsHelpDescription,
+			Footer:      getCmdLogsHelpFooter,
+		},
+		GroupingOptions: actions.CommandGroupOptions{
+			RootLevelHelp: actions.CmdGroupMonitor,
+		},
+	})
+
 	root.
 		Add("down", &actions.ActionDescriptorOptions{
 			Command:        newDownCmd(),
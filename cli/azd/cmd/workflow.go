@@ -0,0 +1,356 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/workflow"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func workflowActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
+	group := root.Add("workflow", &actions.ActionDescriptorOptions{
+		Command: &cobra.Command{
+			Use:   "workflow",
+			Short: fmt.Sprintf("Run the workflows of an application. %s", output.WithWarningFormat("(Beta)")),
+		},
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdWorkflowHelpDescription,
+		},
+		GroupingOptions: actions.CommandGroupOptions{
+			RootLevelHelp: actions.CmdGroupConfig,
+		},
+	})
+
+	group.Add("run", &actions.ActionDescriptorOptions{
+		Command:        newWorkflowRunCmd(),
+		FlagsResolver:  newWorkflowRunFlags,
+		ActionResolver: newWorkflowRunAction,
+		HelpOptions: actions.ActionHelpOptions{
+			Footer: getCmdWorkflowRunHelpFooter,
+		},
+	})
+
+	return group
+}
+
+func newWorkflowRunFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *workflowRunFlags {
+	flags := &workflowRunFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newWorkflowRunCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "run <name>",
+		Short: "Runs the specified workflow of the project.",
+		Args:  cobra.ExactArgs(1),
+	}
+}
+
+type workflowRunFlags struct {
+	internal.EnvFlag
+	global *internal.GlobalCommandOptions
+	dryRun bool
+}
+
+func (f *workflowRunFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	f.EnvFlag.Bind(local, global)
+	f.global = global
+
+	local.BoolVar(&f.dryRun, "dry-run", false, "Displays the steps of the workflow without running them.")
+}
+
+type workflowRunAction struct {
+	projectConfig  *project.ProjectConfig
+	workflowRunner *workflow.Runner
+	console        input.Console
+	flags          *workflowRunFlags
+	args           []string
+}
+
+func newWorkflowRunAction(
+	projectConfig *project.ProjectConfig,
+	workflowRunner *workflow.Runner,
+	console input.Console,
+	flags *workflowRunFlags,
+	args []string,
+) actions.Action {
+	return &workflowRunAction{
+		projectConfig:  projectConfig,
+		workflowRunner: workflowRunner,
+		console:        console,
+		flags:          flags,
+		args:           args,
+	}
+}
+
+func (a *workflowRunAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	name := a.args[0]
+
+	runWorkflow, has := a.projectConfig.Workflows[name]
+	if !has && name == defaultUpWorkflow.Name {
+		runWorkflow = defaultUpWorkflow
+	} else if !has {
+		names := []string{}
+		for workflowName := range a.projectConfig.Workflows {
+			names = append(names, workflowName)
+		}
+		slices.Sort(names)
+
+		if len(names) == 0 {
+			return nil, &internal.ErrorWithSuggestion{
+				Err:        fmt.Errorf("workflow '%s' doesn't exist", name),
+				Suggestion: "Add the workflow to the 'workflows' section of your azure.yaml.",
+			}
+		}
+
+		return nil, fmt.Errorf(
+			"workflow '%s' doesn't exist. Available workflows: %s", name, strings.Join(names, ", "))
+	}
+
+	if a.flags.dryRun {
+		if err := a.workflowRunner.DryRun(ctx, runWorkflow); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	a.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title:     "Running workflow (azd workflow run)",
+		TitleNote: fmt.Sprintf("Running the steps of the %s workflow", output.WithHighLightFormat(name)),
+	})
+
+	startTime := time.Now()
+	if err := a.workflowRunner.Run(ctx, runWorkflow); err != nil {
+		return nil, err
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Your %s workflow completed in %s.", name, ux.DurationAsText(since(startTime))),
+		},
+	}, nil
+}
+
+func getCmdWorkflowHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		heredoc.Docf(
+			`Run the workflows defined in the %s section of your %s.
+
+			Workflow steps run azd commands, scripts, or groups of steps in parallel:
+
+			-------------------------
+			%s
+			workflows:
+			  release:
+			    - azd: provision
+			    - name: seed
+			      run: scripts/seed.py
+			      if: AZURE_ENV_TYPE == prod
+			      continueOnError: true
+			    - parallel:
+			        - azd: deploy api
+			        - azd: deploy web
+			-------------------------
+
+			Scripts support the same shells and runtimes as hooks.`,
+			output.WithHighLightFormat("workflows"),
+			output.WithHighLightFormat("azure.yaml"),
+			output.WithGrayFormat("# azure.yaml"),
+		),
+		nil,
+	)
+}
+
+func getCmdWorkflowRunHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"Run the release workflow.": output.WithHighLightFormat("azd workflow run release"),
+		"Display the steps of the release workflow without running them.": output.WithHighLightFormat(
+			"azd workflow run release --dry-run",
+		),
+	})
+}
+
+// workflowStepRunner runs the steps of workflows that don't run within the current azd command, with the current
+// project and environment.
+type workflowStepRunner struct {
+	lazyAzdContext *lazy.Lazy[*azdcontext.AzdContext]
+	lazyEnvManager *lazy.Lazy[environment.Manager]
+	lazyEnv        *lazy.Lazy[*environment.Environment]
+	commandRunner  exec.CommandRunner
+	console        input.Console
+	serviceLocator ioc.ServiceLocator
+
+	// Serializes reloading the environment, as steps can run in parallel
+	envMu sync.Mutex
+}
+
+func newWorkflowStepRunner(
+	lazyAzdContext *lazy.Lazy[*azdcontext.AzdContext],
+	lazyEnvManager *lazy.Lazy[environment.Manager],
+	lazyEnv *lazy.Lazy[*environment.Environment],
+	commandRunner exec.CommandRunner,
+	console input.Console,
+	serviceLocator ioc.ServiceLocator,
+) workflow.StepRunner {
+	return &workflowStepRunner{
+		lazyAzdContext: lazyAzdContext,
+		lazyEnvManager: lazyEnvManager,
+		lazyEnv:        lazyEnv,
+		commandRunner:  commandRunner,
+		console:        console,
+		serviceLocator: serviceLocator,
+	}
+}
+
+// RunScript runs the script of a step from the project directory, like a project hook
+func (s *workflowStepRunner) RunScript(
+	ctx context.Context,
+	name string,
+	script *ext.HookConfig,
+	stdout io.Writer,
+) error {
+	azdCtx, err := s.lazyAzdContext.GetValue()
+	if err != nil {
+		return err
+	}
+
+	envManager, err := s.lazyEnvManager.GetValue()
+	if err != nil {
+		return err
+	}
+
+	env, err := s.lazyEnv.GetValue()
+	if err != nil {
+		return fmt.Errorf("scripts require an environment: %w", err)
+	}
+
+	// Scripts use their own instance of the environment, since other steps can run in parallel
+	scriptEnv, err := envManager.Get(ctx, env.Name())
+	if err != nil {
+		return err
+	}
+
+	// The hook name is used in the names of temporary files, and is matched in lowercase without spaces
+	hookName := "workflow-" + strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return unicode.ToLower(r)
+		}
+
+		return -1
+	}, name)
+	hooksRunner := ext.NewHooksRunner(
+		ext.NewHooksManager(azdCtx.ProjectDirectory()),
+		s.commandRunner,
+		envManager,
+		s.console,
+		azdCtx.ProjectDirectory(),
+		map[string][]*ext.HookConfig{hookName: {script}},
+		scriptEnv,
+		s.serviceLocator,
+	)
+
+	if err := hooksRunner.RunHooks(ctx, ext.HookTypeNone, &tools.ExecOptions{StdOut: stdout}, hookName); err != nil {
+		return err
+	}
+
+	// Pick up the values that the script has set in the environment
+	s.envMu.Lock()
+	defer s.envMu.Unlock()
+
+	return s.reloadEnv(ctx)
+}
+
+// RunAzdProcess runs an azd command in a new process of the current azd executable, without prompts
+func (s *workflowStepRunner) RunAzdProcess(ctx context.Context, args []string, stdout io.Writer) error {
+	azdPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("finding the azd executable: %w", err)
+	}
+
+	runArgs := exec.NewRunArgs(azdPath, slices.Concat(args, []string{"--no-prompt"})...).
+		WithStdOut(stdout).
+		WithStdErr(stdout)
+
+	if azdCtx, err := s.lazyAzdContext.GetValue(); err == nil {
+		runArgs = runArgs.WithCwd(azdCtx.ProjectDirectory())
+	}
+
+	if env, err := s.lazyEnv.GetValue(); err == nil {
+		runArgs = runArgs.WithEnv([]string{fmt.Sprintf("%s=%s", environment.EnvNameEnvVarName, env.Name())})
+	}
+
+	if _, err := s.commandRunner.Run(ctx, runArgs); err != nil {
+		return err
+	}
+
+	s.envMu.Lock()
+	defer s.envMu.Unlock()
+
+	return s.reloadEnv(ctx)
+}
+
+// EvaluateCondition evaluates the condition against the latest values of the environment, or the values of the OS
+// environment variables when there is no environment
+func (s *workflowStepRunner) EvaluateCondition(ctx context.Context, condition string) (bool, error) {
+	s.envMu.Lock()
+	defer s.envMu.Unlock()
+
+	if err := s.reloadEnv(ctx); err != nil {
+		return false, err
+	}
+
+	lookupEnv := os.LookupEnv
+	if env, err := s.lazyEnv.GetValue(); err == nil {
+		lookupEnv = env.LookupEnv
+	}
+
+	return ext.EvaluateCondition(condition, lookupEnv)
+}
+
+// reloadEnv reloads the environment, when available, to pick up the values set by previous steps
+func (s *workflowStepRunner) reloadEnv(ctx context.Context) error {
+	env, err := s.lazyEnv.GetValue()
+	if err != nil {
+		return nil
+	}
+
+	envManager, err := s.lazyEnvManager.GetValue()
+	if err != nil {
+		return err
+	}
+
+	if err := envManager.Reload(ctx, env); err != nil {
+		return fmt.Errorf("reloading environment: %w", err)
+	}
+
+	return nil
+}
@@ -60,6 +60,12 @@ const (
 	PyFlask   Dependency = "flask"
 	PyDjango  Dependency = "django"
 	PyFastApi Dependency = "fastapi"
+
+	JavaSpringBoot       Dependency = "spring-boot"
+	JavaSpringCloudAzure Dependency = "spring-cloud-azure"
+	JavaQuarkus          Dependency = "quarkus"
+	JavaMicronaut        Dependency = "micronaut"
+	JavaJakartaEE        Dependency = "jakarta-ee"
 )
 
 var WebUIFrameworks = map[Dependency]struct{}{
@@ -73,6 +79,8 @@ func (f Dependency) Language() Language {
 	switch f {
 	case JsReact, JsAngular, JsJQuery, JsVite:
 		return JavaScript
+	case JavaSpringBoot, JavaSpringCloudAzure, JavaQuarkus, JavaMicronaut, JavaJakartaEE:
+		return Java
 	}
 
 	return ""
@@ -90,6 +98,16 @@ func (f Dependency) Display() string {
 		return "Vite"
 	case JsNext:
 		return "Next.js"
+	case JavaSpringBoot:
+		return "Spring Boot"
+	case JavaSpringCloudAzure:
+		return "Spring Cloud Azure"
+	case JavaQuarkus:
+		return "Quarkus"
+	case JavaMicronaut:
+		return "Micronaut"
+	case JavaJakartaEE:
+		return "Jakarta EE"
 	}
 
 	return ""
@@ -113,6 +131,7 @@ const (
 	DbMySql     DatabaseDep = "mysql"
 	DbSqlServer DatabaseDep = "sqlserver"
 	DbRedis     DatabaseDep = "redis"
+	DbCosmos    DatabaseDep = "cosmos"
 )
 
 func (db DatabaseDep) Display() string {
@@ -127,11 +146,64 @@ func (db DatabaseDep) Display() string {
 		return "SQL Server"
 	case DbRedis:
 		return "Redis"
+	case DbCosmos:
+		return "Cosmos DB"
 	}
 
 	return ""
 }
 
+// An Azure service dependency that is inferred through heuristics while scanning project information.
+type AzureDep interface {
+	// ResourceDisplay returns the display name of the Azure service.
+	ResourceDisplay() string
+}
+
+// AzureDepServiceBus is a dependency on Azure Service Bus.
+type AzureDepServiceBus struct {
+	// The queues and topics that are referenced in the application configuration.
+	Queues []string
+	Topics []string
+
+	// If true, Service Bus is used through the JMS API.
+	IsJms bool
+}
+
+func (AzureDepServiceBus) ResourceDisplay() string {
+	return "Azure Service Bus"
+}
+
+// AzureDepEventHubs is a dependency on Azure Event Hubs.
+type AzureDepEventHubs struct {
+	// The event hubs that are referenced in the application configuration.
+	Names []string
+
+	// If true, Event Hubs is used through its Kafka endpoint.
+	UseKafka bool
+}
+
+func (AzureDepEventHubs) ResourceDisplay() string {
+	return "Azure Event Hubs"
+}
+
+// AzureDepStorageAccount is a dependency on Azure Blob Storage.
+type AzureDepStorageAccount struct {
+	// The blob containers that are referenced in the application configuration.
+	ContainerNames []string
+}
+
+func (AzureDepStorageAccount) ResourceDisplay() string {
+	return "Azure Storage Account"
+}
+
+// AzureDepKeyVault is a dependency on Azure Key Vault.
+type AzureDepKeyVault struct {
+}
+
+func (AzureDepKeyVault) ResourceDisplay() string {
+	return "Azure Key Vault"
+}
+
 type Project struct {
 	// The language associated with the project.
 	Language Language
@@ -142,6 +214,13 @@ type Project struct {
 	// Experimental: Database dependencies inferred through heuristics while scanning dependencies in the project.
 	DatabaseDeps []DatabaseDep
 
+	// Experimental: Azure service dependencies inferred through heuristics while scanning dependencies and
+	// configuration in the project.
+	AzureDeps []AzureDep
+
+	// The ports that the application is configured to listen on, such as 'server.port' of a Spring Boot application.
+	Ports []Port
+
 	// The path to the project directory.
 	Path string
 
@@ -150,6 +229,10 @@ type Project struct {
 
 	// If true, the project uses Docker for packaging. This is inferred through the presence of a Dockerfile.
 	Docker *Docker
+
+	// If true, the project is a library module of a multi-module build, such as a Maven module that other modules
+	// depend on. Libraries are built as part of the projects that use them and are not deployed on their own.
+	Library bool
 }
 
 func (p *Project) HasWebUIFramework() bool {
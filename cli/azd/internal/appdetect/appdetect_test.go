@@ -44,9 +44,26 @@ func TestDetect(t *testing.T) {
 					Path:          "java",
 					DetectionRule: "Inferred by presence of: pom.xml",
 				},
+				{
+					Language:      Java,
+					Path:          "java-gradle/app",
+					Dependencies:  []Dependency{JavaSpringBoot},
+					Ports:         []Port{{Number: 8081, Protocol: "http"}},
+					DetectionRule: "Inferred by presence of: build.gradle.kts",
+					DatabaseDeps: []DatabaseDep{
+						DbPostgres,
+					},
+				},
+				{
+					Language:      Java,
+					Path:          "java-gradle/library",
+					DetectionRule: "Inferred by presence of: build.gradle",
+					Library:       true,
+				},
 				{
 					Language:      Java,
 					Path:          "java-multimodules/application",
+					Dependencies:  []Dependency{JavaSpringBoot},
 					DetectionRule: "Inferred by presence of: pom.xml",
 					DatabaseDeps: []DatabaseDep{
 						DbMySql,
@@ -57,6 +74,7 @@ func TestDetect(t *testing.T) {
 					Language:      Java,
 					Path:          "java-multimodules/library",
 					DetectionRule: "Inferred by presence of: pom.xml",
+					Library:       true,
 				},
 				{
 					Language:      JavaScript,
@@ -128,9 +146,26 @@ func TestDetect(t *testing.T) {
 					Path:          "java",
 					DetectionRule: "Inferred by presence of: pom.xml",
 				},
+				{
+					Language:      Java,
+					Path:          "java-gradle/app",
+					Dependencies:  []Dependency{JavaSpringBoot},
+					Ports:         []Port{{Number: 8081, Protocol: "http"}},
+					DetectionRule: "Inferred by presence of: build.gradle.kts",
+					DatabaseDeps: []DatabaseDep{
+						DbPostgres,
+					},
+				},
+				{
+					Language:      Java,
+					Path:          "java-gradle/library",
+					DetectionRule: "Inferred by presence of: build.gradle",
+					Library:       true,
+				},
 				{
 					Language:      Java,
 					Path:          "java-multimodules/application",
+					Dependencies:  []Dependency{JavaSpringBoot},
 					DetectionRule: "Inferred by presence of: pom.xml",
 					DatabaseDeps: []DatabaseDep{
 						DbMySql,
@@ -141,6 +176,7 @@ func TestDetect(t *testing.T) {
 					Language:      Java,
 					Path:          "java-multimodules/library",
 					DetectionRule: "Inferred by presence of: pom.xml",
+					Library:       true,
 				},
 			},
 		},
@@ -161,9 +197,26 @@ func TestDetect(t *testing.T) {
 					Path:          "java",
 					DetectionRule: "Inferred by presence of: pom.xml",
 				},
+				{
+					Language:      Java,
+					Path:          "java-gradle/app",
+					Dependencies:  []Dependency{JavaSpringBoot},
+					Ports:         []Port{{Number: 8081, Protocol: "http"}},
+					DetectionRule: "Inferred by presence of: build.gradle.kts",
+					DatabaseDeps: []DatabaseDep{
+						DbPostgres,
+					},
+				},
+				{
+					Language:      Java,
+					Path:          "java-gradle/library",
+					DetectionRule: "Inferred by presence of: build.gradle",
+					Library:       true,
+				},
 				{
 					Language:      Java,
 					Path:          "java-multimodules/application",
+					Dependencies:  []Dependency{JavaSpringBoot},
 					DetectionRule: "Inferred by presence of: pom.xml",
 					DatabaseDeps: []DatabaseDep{
 						DbMySql,
@@ -174,6 +227,7 @@ func TestDetect(t *testing.T) {
 					Language:      Java,
 					Path:          "java-multimodules/library",
 					DetectionRule: "Inferred by presence of: pom.xml",
+					Library:       true,
 				},
 			},
 		},
@@ -197,9 +251,26 @@ func TestDetect(t *testing.T) {
 					Path:          "java",
 					DetectionRule: "Inferred by presence of: pom.xml",
 				},
+				{
+					Language:      Java,
+					Path:          "java-gradle/app",
+					Dependencies:  []Dependency{JavaSpringBoot},
+					Ports:         []Port{{Number: 8081, Protocol: "http"}},
+					DetectionRule: "Inferred by presence of: build.gradle.kts",
+					DatabaseDeps: []DatabaseDep{
+						DbPostgres,
+					},
+				},
+				{
+					Language:      Java,
+					Path:          "java-gradle/library",
+					DetectionRule: "Inferred by presence of: build.gradle",
+					Library:       true,
+				},
 				{
 					Language:      Java,
 					Path:          "java-multimodules/application",
+					Dependencies:  []Dependency{JavaSpringBoot},
 					DetectionRule: "Inferred by presence of: pom.xml",
 					DatabaseDeps: []DatabaseDep{
 						DbMySql,
@@ -210,6 +281,7 @@ func TestDetect(t *testing.T) {
 					Language:      Java,
 					Path:          "java-multimodules/library",
 					DetectionRule: "Inferred by presence of: pom.xml",
+					Library:       true,
 				},
 				{
 					Language:      Python,
@@ -6,10 +6,13 @@ package appdetect
 import (
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io/fs"
 	"maps"
+	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -17,8 +20,9 @@ import (
 )
 
 type javaDetector struct {
-	mvnCli       *maven.Cli
-	rootProjects []mavenProject
+	mvnCli             *maven.Cli
+	rootProjects       []mavenProject
+	gradleRootProjects []gradleProject
 }
 
 func (jd *javaDetector) Language() Language {
@@ -28,53 +32,169 @@ func (jd *javaDetector) Language() Language {
 func (jd *javaDetector) DetectProject(ctx context.Context, path string, entries []fs.DirEntry) (*Project, error) {
 	for _, entry := range entries {
 		if strings.ToLower(entry.Name()) == "pom.xml" {
-			pomFile := filepath.Join(path, entry.Name())
-			project, err := readMavenProject(ctx, jd.mvnCli, pomFile)
-			if err != nil {
-				return nil, fmt.Errorf("error reading pom.xml: %w", err)
-			}
+			return jd.detectMavenProject(ctx, filepath.Join(path, entry.Name()))
+		}
+	}
 
-			if len(project.Modules) > 0 {
-				// This is a multi-module project, we will capture the analysis, but return nil
-				// to continue recursing
-				jd.rootProjects = append(jd.rootProjects, *project)
-				return nil, nil
-			}
+	for _, entry := range entries {
+		if isGradleFile(entry.Name()) {
+			return jd.detectGradleProject(path)
+		}
+	}
 
-			var currentRoot *mavenProject
-			for _, rootProject := range jd.rootProjects {
-				// we can say that the project is in the root project if the path is under the project
-				if inRoot := strings.HasPrefix(pomFile, rootProject.path); inRoot {
-					currentRoot = &rootProject
-				}
-			}
+	return nil, nil
+}
 
-			_ = currentRoot // use currentRoot here in the analysis
-			result, err := detectDependencies(project, &Project{
-				Language:      Java,
-				Path:          path,
-				DetectionRule: "Inferred by presence of: pom.xml",
-			})
-			if err != nil {
-				return nil, fmt.Errorf("detecting dependencies: %w", err)
+func (jd *javaDetector) detectMavenProject(ctx context.Context, pomFile string) (*Project, error) {
+	project, err := readMavenProject(ctx, jd.mvnCli, pomFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading pom.xml: %w", err)
+	}
+
+	if len(project.Modules) > 0 {
+		// This is a multi-module project, we will capture the analysis, but return nil
+		// to continue recursing
+		if err := project.readReactorDependencies(); err != nil {
+			return nil, err
+		}
+
+		jd.rootProjects = append(jd.rootProjects, *project)
+		return nil, nil
+	}
+
+	var currentRoot *mavenProject
+	for i := range jd.rootProjects {
+		// we can say that the project is in the root project if the path is under the project.
+		// The innermost root wins for nested multi-module projects.
+		rootProject := &jd.rootProjects[i]
+		if inRoot := isUnderDir(project.path, rootProject.path); inRoot {
+			if currentRoot == nil || len(rootProject.path) > len(currentRoot.path) {
+				currentRoot = rootProject
 			}
+		}
+	}
+
+	library := false
+	if currentRoot != nil {
+		project.mergeParent(currentRoot)
+		library = !project.isRunnable() && currentRoot.reactorDependencies[project.coordinates()]
+	}
 
-			return result, nil
+	result, err := detectDependencies(project.Dependencies, &Project{
+		Language:      Java,
+		Path:          filepath.Dir(pomFile),
+		DetectionRule: "Inferred by presence of: pom.xml",
+		Library:       library,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("detecting dependencies: %w", err)
+	}
+
+	if currentRoot != nil && !currentRoot.springBootModules[project.coordinates()] {
+		// The Spring Boot dependencies that a module inherits from its parent don't make it a Spring Boot application
+		result.Dependencies = slices.DeleteFunc(result.Dependencies, func(dep Dependency) bool {
+			return dep == JavaSpringBoot
+		})
+		if len(result.Dependencies) == 0 {
+			result.Dependencies = nil
 		}
 	}
 
-	return nil, nil
+	return result, nil
+}
+
+func (jd *javaDetector) detectGradleProject(path string) (*Project, error) {
+	project, err := readGradleProject(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading gradle build: %w", err)
+	}
+
+	if len(project.Subprojects) > 0 {
+		// This is a multi-project build, we will capture the analysis, but return nil
+		// to continue recursing
+		if err := project.readBuildDependencies(); err != nil {
+			return nil, err
+		}
+
+		jd.gradleRootProjects = append(jd.gradleRootProjects, *project)
+		return nil, nil
+	}
+
+	library := false
+	for _, rootProject := range jd.gradleRootProjects {
+		if !rootProject.contains(path) {
+			continue
+		}
+
+		// Within a multi-project build, only the included subprojects are projects. This skips directories such as
+		// buildSrc, which hold build logic.
+		if !rootProject.includes(path) {
+			return nil, nil
+		}
+
+		library = rootProject.isLibraryOf(path, project)
+	}
+
+	if project.buildFile == "" {
+		return nil, nil
+	}
+
+	result, err := detectDependencies(project.Dependencies, &Project{
+		Language:      Java,
+		Path:          path,
+		DetectionRule: "Inferred by presence of: " + project.buildFile,
+		Library:       library,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("detecting dependencies: %w", err)
+	}
+
+	return result, nil
 }
 
 // mavenProject represents the top-level structure of a Maven POM file.
 type mavenProject struct {
 	XmlName              xml.Name             `xml:"project"`
+	GroupId              string               `xml:"groupId"`
+	ArtifactId           string               `xml:"artifactId"`
+	Version              string               `xml:"version"`
+	Packaging            string               `xml:"packaging"`
 	Parent               parent               `xml:"parent"`
 	Modules              []string             `xml:"modules>module"` // Capture the modules
+	Properties           properties           `xml:"properties"`
 	Dependencies         []dependency         `xml:"dependencies>dependency"`
 	DependencyManagement dependencyManagement `xml:"dependencyManagement"`
 	Build                build                `xml:"build"`
 	path                 string
+
+	// For multi-module projects, the coordinates of the modules that other modules of the reactor depend on.
+	reactorDependencies map[string]bool
+	// For multi-module projects, the coordinates of the modules that use Spring Boot in their own POM.
+	springBootModules map[string]bool
+}
+
+// properties are the user defined properties of a POM, keyed by the element name.
+type properties map[string]string
+
+func (p *properties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = properties{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			(*p)[t.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			return nil
+		}
+	}
 }
 
 // Parent represents the parent POM if this project is a module.
@@ -109,6 +229,191 @@ type plugin struct {
 	Version    string `xml:"version"`
 }
 
+// runnablePlugins are the maven plugins that package a module as an application that can be run on its own.
+var runnablePlugins = map[string]struct{}{
+	"org.springframework.boot:spring-boot-maven-plugin": {},
+	"io.quarkus:quarkus-maven-plugin":                   {},
+	"io.quarkus.platform:quarkus-maven-plugin":          {},
+	"io.micronaut.maven:micronaut-maven-plugin":         {},
+	"com.microsoft.azure:azure-functions-maven-plugin":  {},
+	"org.apache.maven.plugins:maven-shade-plugin":       {},
+	"org.apache.maven.plugins:maven-assembly-plugin":    {},
+	"io.openliberty.tools:liberty-maven-plugin":         {},
+	"org.wildfly.plugins:wildfly-jar-maven-plugin":      {},
+	"com.google.cloud.tools:jib-maven-plugin":           {},
+	"org.graalvm.buildtools:native-maven-plugin":        {},
+}
+
+// groupId returns the group id of the project, which is inherited from the parent when absent.
+func (p *mavenProject) groupId() string {
+	if p.GroupId == "" {
+		return p.Parent.GroupId
+	}
+
+	return p.GroupId
+}
+
+// coordinates returns 'groupId:artifactId' of the project.
+func (p *mavenProject) coordinates() string {
+	return p.groupId() + ":" + p.ArtifactId
+}
+
+// isRunnable returns true when the project is packaged as an application, as opposed to a library
+// that is consumed by other modules.
+func (p *mavenProject) isRunnable() bool {
+	switch p.Packaging {
+	case "war", "ear":
+		return true
+	}
+
+	for _, plugin := range p.Build.Plugins {
+		if _, has := runnablePlugins[plugin.GroupId+":"+plugin.ArtifactId]; has {
+			return true
+		}
+	}
+
+	return false
+}
+
+// usesSpringBoot returns true when the project applies the Spring Boot maven plugin or depends on a Spring Boot starter.
+func (p *mavenProject) usesSpringBoot() bool {
+	for _, plugin := range p.Build.Plugins {
+		if plugin.GroupId == "org.springframework.boot" && plugin.ArtifactId == "spring-boot-maven-plugin" {
+			return true
+		}
+	}
+
+	return slices.ContainsFunc(p.Dependencies, func(dep dependency) bool {
+		return dep.GroupId == "org.springframework.boot" && strings.HasPrefix(dep.ArtifactId, "spring-boot-starter") &&
+			dep.Scope != "test"
+	})
+}
+
+// mergeParent applies the configuration that a module inherits from the root of its multi-module project:
+// properties, managed dependencies, and the versions of dependencies that are declared without one. A module whose
+// parent is the root itself takes the parent of the root, so that BOM parents such as spring-boot-starter-parent
+// are visible on the module.
+//
+// The effective POM normally includes all of this already; merging again covers modules whose effective POM was
+// computed without the reactor, for example when the parent is not installed in the local repository.
+func (p *mavenProject) mergeParent(root *mavenProject) {
+	if p.Properties == nil {
+		p.Properties = properties{}
+	}
+
+	for key, value := range root.Properties {
+		if _, has := p.Properties[key]; !has {
+			p.Properties[key] = value
+		}
+	}
+
+	for _, managed := range root.DependencyManagement.Dependencies {
+		if !slices.ContainsFunc(p.DependencyManagement.Dependencies, func(dep dependency) bool {
+			return dep.GroupId == managed.GroupId && dep.ArtifactId == managed.ArtifactId
+		}) {
+			p.DependencyManagement.Dependencies = append(p.DependencyManagement.Dependencies, managed)
+		}
+	}
+
+	for i, dep := range p.Dependencies {
+		if dep.Version == "" {
+			for _, managed := range p.DependencyManagement.Dependencies {
+				if managed.GroupId == dep.GroupId && managed.ArtifactId == dep.ArtifactId {
+					dep.Version = managed.Version
+					break
+				}
+			}
+		}
+
+		dep.GroupId = p.resolveProperties(dep.GroupId)
+		dep.Version = p.resolveProperties(dep.Version)
+		p.Dependencies[i] = dep
+	}
+
+	isRootParent := p.Parent.GroupId == root.groupId() && p.Parent.ArtifactId == root.ArtifactId
+	if isRootParent && root.Parent.ArtifactId != "" {
+		// Keep the group id inherited from the root before the parent is replaced
+		p.GroupId = p.groupId()
+		p.Parent = root.Parent
+	}
+}
+
+var mavenPropertyRegex = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// resolveProperties replaces the property references, such as ${spring-cloud.version}, in value.
+// References that cannot be resolved are left as-is.
+func (p *mavenProject) resolveProperties(value string) string {
+	return mavenPropertyRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		name := ref[2 : len(ref)-1]
+		switch name {
+		case "project.groupId", "pom.groupId":
+			return p.groupId()
+		case "project.version", "pom.version":
+			if p.Version != "" {
+				return p.Version
+			}
+			return p.Parent.Version
+		case "project.parent.version":
+			return p.Parent.Version
+		}
+
+		if value, has := p.Properties[name]; has {
+			return value
+		}
+
+		return ref
+	})
+}
+
+// readReactorDependencies records which modules of this multi-module project are dependencies of other modules.
+// The module POMs are read as-is, since only the coordinates of the modules and their dependencies are needed.
+func (p *mavenProject) readReactorDependencies() error {
+	p.reactorDependencies = map[string]bool{}
+	p.springBootModules = map[string]bool{}
+	return p.readModuleDependencies(p.path, p.Modules)
+}
+
+func (p *mavenProject) readModuleDependencies(dir string, modules []string) error {
+	for _, module := range modules {
+		pomFile := filepath.Join(dir, module)
+		if !strings.HasSuffix(strings.ToLower(module), ".xml") {
+			pomFile = filepath.Join(pomFile, "pom.xml")
+		}
+
+		contents, err := os.ReadFile(pomFile)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("reading module pom.xml: %w", err)
+		}
+
+		var moduleProject mavenProject
+		if err := xml.Unmarshal(contents, &moduleProject); err != nil {
+			return fmt.Errorf("parsing module pom.xml %s: %w", pomFile, err)
+		}
+
+		for _, dep := range moduleProject.Dependencies {
+			p.reactorDependencies[moduleProject.resolveProperties(dep.GroupId)+":"+dep.ArtifactId] = true
+		}
+
+		if moduleProject.usesSpringBoot() {
+			p.springBootModules[moduleProject.coordinates()] = true
+		}
+
+		if err := p.readModuleDependencies(filepath.Dir(pomFile), moduleProject.Modules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isUnderDir returns true when path is dir or is located under dir.
+func isUnderDir(path string, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && !strings.HasPrefix(rel, "..")
+}
+
 func readMavenProject(ctx context.Context, mvnCli *maven.Cli, filePath string) (*mavenProject, error) {
 	effectivePom, err := mvnCli.EffectivePom(ctx, filePath)
 	if err != nil {
@@ -122,9 +427,35 @@ func readMavenProject(ctx context.Context, mvnCli *maven.Cli, filePath string) (
 	return &project, nil
 }
 
-func detectDependencies(mavenProject *mavenProject, project *Project) (*Project, error) {
+// javaFrameworks maps the group id of dependencies to the Java framework that they belong to. Group ids nested under
+// these, such as io.micronaut.data, belong to the same framework.
+var javaFrameworks = map[string]Dependency{
+	"org.springframework.boot": JavaSpringBoot,
+	"com.azure.spring":         JavaSpringCloudAzure,
+	"io.quarkus":               JavaQuarkus,
+	"io.micronaut":             JavaMicronaut,
+	"jakarta.platform":         JavaJakartaEE,
+}
+
+// javaPortKeys are the configuration keys that set the HTTP port of the application for each framework.
+var javaPortKeys = map[Dependency]string{
+	JavaSpringBoot: "server.port",
+	JavaQuarkus:    "quarkus.http.port",
+	JavaMicronaut:  "micronaut.server.port",
+}
+
+func detectDependencies(dependencies []dependency, project *Project) (*Project, error) {
 	databaseDepMap := map[DatabaseDep]struct{}{}
-	for _, dep := range mavenProject.Dependencies {
+	frameworkMap := map[Dependency]struct{}{}
+	artifacts := map[string]struct{}{}
+	for _, dep := range dependencies {
+		artifacts[dep.GroupId+":"+dep.ArtifactId] = struct{}{}
+		for groupId, framework := range javaFrameworks {
+			if dep.GroupId == groupId || strings.HasPrefix(dep.GroupId, groupId+".") {
+				frameworkMap[framework] = struct{}{}
+			}
+		}
+
 		if (dep.GroupId == "com.mysql" && dep.ArtifactId == "mysql-connector-j") ||
 			(dep.GroupId == "com.azure.spring" && dep.ArtifactId == "spring-cloud-azure-starter-jdbc-mysql") {
 			databaseDepMap[DbMySql] = struct{}{}
@@ -144,7 +475,12 @@ func detectDependencies(mavenProject *mavenProject, project *Project) (*Project,
 			(dep.GroupId == "org.springframework.boot" && dep.ArtifactId == "spring-boot-starter-data-mongodb-reactive") {
 			databaseDepMap[DbMongo] = struct{}{}
 		}
-		// todo: Add DbCosmos
+
+		if (dep.GroupId == "com.azure.spring" && dep.ArtifactId == "spring-cloud-azure-starter-data-cosmos") ||
+			(dep.GroupId == "com.azure.spring" && dep.ArtifactId == "spring-cloud-azure-starter-cosmos") ||
+			(dep.GroupId == "com.azure" && dep.ArtifactId == "azure-spring-data-cosmos") {
+			databaseDepMap[DbCosmos] = struct{}{}
+		}
 	}
 
 	if len(databaseDepMap) > 0 {
@@ -154,5 +490,163 @@ func detectDependencies(mavenProject *mavenProject, project *Project) (*Project,
 			})
 	}
 
+	if len(frameworkMap) > 0 {
+		project.Dependencies = slices.SortedFunc(maps.Keys(frameworkMap),
+			func(a, b Dependency) int {
+				return strings.Compare(string(a), string(b))
+			})
+	}
+
+	if len(frameworkMap) == 0 || project.Library {
+		return project, nil
+	}
+
+	config, err := readAppConfig(project.Path)
+	if err != nil {
+		return nil, fmt.Errorf("reading application configuration: %w", err)
+	}
+
+	for _, framework := range project.Dependencies {
+		if port := config.port(javaPortKeys[framework]); port != 0 {
+			project.Ports = append(project.Ports, Port{Number: port, Protocol: "http"})
+		}
+	}
+
+	project.AzureDeps = detectAzureDeps(artifacts, config)
 	return project, nil
 }
+
+// hasAnyArtifact returns true when artifacts contains any of the artifacts in 'groupId:artifactId' form.
+func hasAnyArtifact(artifacts map[string]struct{}, candidates ...string) bool {
+	for _, candidate := range candidates {
+		if _, has := artifacts[candidate]; has {
+			return true
+		}
+	}
+
+	return false
+}
+
+// detectAzureDeps infers the Azure services that are used by a Java project from the Spring Cloud Azure starters in
+// artifacts. The names of queues, topics, event hubs and containers are read from the application configuration.
+func detectAzureDeps(artifacts map[string]struct{}, config appConfig) []AzureDep {
+	var azureDeps []AzureDep
+
+	jms := hasAnyArtifact(artifacts, "com.azure.spring:spring-cloud-azure-starter-servicebus-jms")
+	if jms || hasAnyArtifact(artifacts,
+		"com.azure.spring:spring-cloud-azure-starter-servicebus",
+		"com.azure.spring:spring-cloud-azure-starter-integration-servicebus",
+		"com.azure.spring:spring-cloud-azure-starter-stream-servicebus",
+		"com.azure.spring:spring-messaging-azure-servicebus") {
+		serviceBus := AzureDepServiceBus{IsJms: jms}
+		for _, prefix := range []string{
+			"spring.cloud.azure.servicebus",
+			"spring.cloud.azure.servicebus.processor",
+			"spring.cloud.azure.servicebus.producer",
+			"spring.cloud.azure.servicebus.consumer",
+		} {
+			name := config.get(prefix + ".entity-name")
+			if name == "" {
+				continue
+			}
+
+			if config.get(prefix+".entity-type") == "topic" {
+				serviceBus.Topics = appendUnique(serviceBus.Topics, name)
+			} else {
+				serviceBus.Queues = appendUnique(serviceBus.Queues, name)
+			}
+		}
+
+		if hasAnyArtifact(artifacts, "com.azure.spring:spring-cloud-azure-starter-stream-servicebus") {
+			for _, binding := range streamBindings(config) {
+				if config.get("spring.cloud.stream.servicebus.bindings."+binding+".producer.entity-type") == "topic" ||
+					config.get("spring.cloud.stream.servicebus.bindings."+binding+".consumer.entity-type") == "topic" {
+					serviceBus.Topics = appendUnique(serviceBus.Topics,
+						config.get("spring.cloud.stream.bindings."+binding+".destination"))
+				} else {
+					serviceBus.Queues = appendUnique(serviceBus.Queues,
+						config.get("spring.cloud.stream.bindings."+binding+".destination"))
+				}
+			}
+		}
+
+		azureDeps = append(azureDeps, serviceBus)
+	}
+
+	// Kafka clients use Event Hubs when Spring Cloud Azure configures them, or when they connect to a namespace.
+	kafka := hasAnyArtifact(artifacts,
+		"org.springframework.kafka:spring-kafka",
+		"org.springframework.cloud:spring-cloud-starter-stream-kafka") &&
+		(hasAnyArtifact(artifacts, "com.azure.spring:spring-cloud-azure-starter") ||
+			strings.Contains(config.get("spring.kafka.bootstrap-servers"), ".servicebus.windows.net"))
+	streamEventHubs := hasAnyArtifact(artifacts, "com.azure.spring:spring-cloud-azure-starter-stream-eventhubs")
+	if kafka || streamEventHubs || hasAnyArtifact(artifacts,
+		"com.azure.spring:spring-cloud-azure-starter-eventhubs",
+		"com.azure.spring:spring-cloud-azure-starter-integration-eventhubs",
+		"com.azure.spring:spring-messaging-azure-eventhubs") {
+		eventHubs := AzureDepEventHubs{UseKafka: kafka}
+		for _, prefix := range []string{
+			"spring.cloud.azure.eventhubs",
+			"spring.cloud.azure.eventhubs.processor",
+			"spring.cloud.azure.eventhubs.producer",
+			"spring.cloud.azure.eventhubs.consumer",
+		} {
+			eventHubs.Names = appendUnique(eventHubs.Names, config.get(prefix+".event-hub-name"))
+		}
+
+		if kafka {
+			eventHubs.Names = appendUnique(eventHubs.Names, config.get("spring.kafka.template.default-topic"))
+		}
+
+		if kafka || streamEventHubs {
+			for _, binding := range streamBindings(config) {
+				eventHubs.Names = appendUnique(eventHubs.Names,
+					config.get("spring.cloud.stream.bindings."+binding+".destination"))
+			}
+		}
+
+		azureDeps = append(azureDeps, eventHubs)
+	}
+
+	if hasAnyArtifact(artifacts,
+		"com.azure.spring:spring-cloud-azure-starter-storage-blob",
+		"com.azure.spring:spring-cloud-azure-starter-storage") {
+		storage := AzureDepStorageAccount{}
+		storage.ContainerNames = appendUnique(storage.ContainerNames,
+			config.get("spring.cloud.azure.storage.blob.container-name"))
+		azureDeps = append(azureDeps, storage)
+	}
+
+	if hasAnyArtifact(artifacts,
+		"com.azure.spring:spring-cloud-azure-starter-keyvault",
+		"com.azure.spring:spring-cloud-azure-starter-keyvault-secrets",
+		"com.azure.spring:spring-cloud-azure-starter-keyvault-certificates") {
+		azureDeps = append(azureDeps, AzureDepKeyVault{})
+	}
+
+	return azureDeps
+}
+
+var streamBindingRegex = regexp.MustCompile(`^spring\.cloud\.stream\.bindings\.([^.]+)\.destination$`)
+
+// streamBindings returns the sorted names of the Spring Cloud Stream bindings that have a destination.
+func streamBindings(config appConfig) []string {
+	var bindings []string
+	for key := range config {
+		if match := streamBindingRegex.FindStringSubmatch(key); match != nil {
+			bindings = append(bindings, match[1])
+		}
+	}
+
+	slices.Sort(bindings)
+	return bindings
+}
+
+// appendUnique appends value to values when it is not empty or already present.
+func appendUnique(values []string, value string) []string {
+	if value == "" || slices.Contains(values, value) {
+		return values
+	}
+
+	return append(values, value)
+}
@@ -0,0 +1,273 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package appdetect
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/braydonk/yaml"
+)
+
+// appConfig is the application configuration of a Java project, flattened to dot-separated keys such as 'server.port'.
+type appConfig map[string]string
+
+// appConfigDoc is a single document of a configuration file.
+type appConfigDoc struct {
+	values appConfig
+
+	// The profile that activates the document, empty when the document is always active.
+	onProfile string
+}
+
+// appConfigBaseName is the base name of the configuration files read by Spring Boot, Quarkus and Micronaut.
+const appConfigBaseName = "application"
+
+// appConfigExtensions are the extensions of the configuration files, in increasing order of precedence.
+var appConfigExtensions = []string{".yaml", ".yml", ".properties"}
+
+// readAppConfig reads the application configuration under src/main/resources of the Java project at projectPath.
+//
+// The configuration is read as Spring Boot would: documents that are activated for a profile, and the
+// application-<profile> files, are applied on top of the default configuration for each profile listed in
+// 'spring.profiles.active'.
+func readAppConfig(projectPath string) (appConfig, error) {
+	resourcesDir := filepath.Join(projectPath, "src", "main", "resources")
+
+	docs, err := readAppConfigDocs(resourcesDir, appConfigBaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	config := appConfig{}
+	for _, doc := range docs {
+		if doc.onProfile == "" {
+			config.merge(doc.values)
+		}
+	}
+
+	for _, profile := range config.list("spring.profiles.active") {
+		for _, doc := range docs {
+			if doc.onProfile == profile {
+				config.merge(doc.values)
+			}
+		}
+
+		profileDocs, err := readAppConfigDocs(resourcesDir, appConfigBaseName+"-"+profile)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range profileDocs {
+			if doc.onProfile == "" || doc.onProfile == profile {
+				config.merge(doc.values)
+			}
+		}
+	}
+
+	return config, nil
+}
+
+// readAppConfigDocs reads the documents of the configuration files with the given base name in dir.
+func readAppConfigDocs(dir string, baseName string) ([]appConfigDoc, error) {
+	var docs []appConfigDoc
+	for _, ext := range appConfigExtensions {
+		path := filepath.Join(dir, baseName+ext)
+		contents, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+
+		var fileDocs []appConfig
+		if ext == ".properties" {
+			fileDocs = parseProperties(contents)
+		} else {
+			fileDocs, err = parseYamlConfig(contents)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", path, err)
+			}
+		}
+
+		for _, values := range fileDocs {
+			onProfile := values["spring.config.activate.on-profile"]
+			if onProfile == "" {
+				// legacy form, prior to Spring Boot 2.4
+				onProfile = values["spring.profiles"]
+			}
+
+			docs = append(docs, appConfigDoc{values: values, onProfile: onProfile})
+		}
+	}
+
+	return docs, nil
+}
+
+// parseProperties parses a .properties file. Documents are separated by '#---' lines.
+func parseProperties(contents []byte) []appConfig {
+	docs := []appConfig{{}}
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	continued := ""
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if continued != "" {
+			line = continued + line
+			continued = ""
+		}
+
+		if line == "#---" {
+			docs = append(docs, appConfig{})
+			continue
+		}
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		if strings.HasSuffix(line, `\`) {
+			continued = strings.TrimSuffix(line, `\`)
+			continue
+		}
+
+		// As in java.util.Properties, the key ends at the first '=', ':' or whitespace, and the separator is an optional
+		// '=' or ':' surrounded by optional whitespace
+		key, value := line, ""
+		if i := strings.IndexAny(line, "=: \t\f"); i >= 0 {
+			key, value = line[:i], strings.TrimLeft(line[i:], " \t\f")
+			if strings.HasPrefix(value, "=") || strings.HasPrefix(value, ":") {
+				value = value[1:]
+			}
+		}
+
+		docs[len(docs)-1][strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return docs
+}
+
+// parseYamlConfig parses a YAML configuration file, which may contain multiple documents.
+func parseYamlConfig(contents []byte) ([]appConfig, error) {
+	var docs []appConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(contents))
+	for {
+		var doc map[string]any
+		if err := decoder.Decode(&doc); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		config := appConfig{}
+		config.flatten("", doc)
+		docs = append(docs, config)
+	}
+
+	return docs, nil
+}
+
+// flatten adds the values of a YAML node under the given key prefix. Sequences are keyed by index, as in 'a.b[0]'.
+func (c appConfig) flatten(prefix string, node any) {
+	switch node := node.(type) {
+	case map[string]any:
+		for key, value := range node {
+			c.flatten(joinConfigKey(prefix, key), value)
+		}
+	case map[any]any:
+		for key, value := range node {
+			c.flatten(joinConfigKey(prefix, fmt.Sprint(key)), value)
+		}
+	case []any:
+		for i, value := range node {
+			c.flatten(fmt.Sprintf("%s[%d]", prefix, i), value)
+		}
+	case nil:
+		c[prefix] = ""
+	default:
+		c[prefix] = fmt.Sprint(node)
+	}
+}
+
+func joinConfigKey(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
+
+func (c appConfig) merge(other appConfig) {
+	for key, value := range other {
+		c[key] = value
+	}
+}
+
+var configPlaceholderRegex = regexp.MustCompile(`\$\{([^}:]+)(?::([^}]*))?\}`)
+
+// get returns the value of key with ${name:default} placeholders resolved against the configuration. Placeholders
+// that refer to undefined keys, such as environment variables, resolve to their default value when one is provided.
+func (c appConfig) get(key string) string {
+	return c.resolve(c[key], 0)
+}
+
+func (c appConfig) resolve(value string, depth int) string {
+	// guard against placeholders that refer to each other
+	if depth > 8 {
+		return value
+	}
+
+	return configPlaceholderRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		match := configPlaceholderRegex.FindStringSubmatch(ref)
+		if value, has := c[match[1]]; has {
+			return c.resolve(value, depth+1)
+		}
+
+		if strings.Contains(ref, ":") {
+			return match[2]
+		}
+
+		return ref
+	})
+}
+
+// list returns the comma-separated values of key. Values written as a YAML sequence are also supported.
+func (c appConfig) list(key string) []string {
+	var values []string
+	for _, value := range strings.Split(c.get(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	for i := 0; ; i++ {
+		value, has := c[fmt.Sprintf("%s[%d]", key, i)]
+		if !has {
+			break
+		}
+
+		if value = strings.TrimSpace(c.resolve(value, 0)); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// port returns the port number of key, or 0 when it is not set to a valid port.
+func (c appConfig) port(key string) int {
+	port, err := strconv.Atoi(c.get(key))
+	if err != nil || port <= 0 || port > 65535 {
+		return 0
+	}
+
+	return port
+}
@@ -0,0 +1,164 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package appdetect
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAppConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected map[string]string
+	}{
+		{
+			name: "Properties",
+			files: map[string]string{
+				"application.properties": `
+# comment
+server.port 8081
+spring.application.name: demo
+spring.cloud.azure.servicebus.entity-name = \
+    orders
+management.server.port=8082
+spring.cloud.azure.eventhubs.namespace	events
+app.url https://example.com:8443
+`,
+			},
+			expected: map[string]string{
+				"server.port":                               "8081",
+				"spring.application.name":                   "demo",
+				"spring.cloud.azure.servicebus.entity-name": "orders",
+				"management.server.port":                    "8082",
+				"spring.cloud.azure.eventhubs.namespace":    "events",
+				"app.url":                                   "https://example.com:8443",
+			},
+		},
+		{
+			name: "Yaml",
+			files: map[string]string{
+				"application.yml": `
+server:
+  port: 8081
+spring:
+  cloud:
+    stream:
+      bindings:
+        consume-in-0:
+          destination: orders
+  profiles:
+    active:
+      - cloud
+`,
+			},
+			expected: map[string]string{
+				"server.port": "8081",
+				"spring.cloud.stream.bindings.consume-in-0.destination": "orders",
+				"spring.profiles.active[0]":                             "cloud",
+			},
+		},
+		{
+			name: "PropertiesOverrideYaml",
+			files: map[string]string{
+				"application.yaml":       "server.port: 8081",
+				"application.properties": "server.port=8082",
+			},
+			expected: map[string]string{
+				"server.port": "8082",
+			},
+		},
+		{
+			name: "ProfileFiles",
+			files: map[string]string{
+				"application.properties":       "spring.profiles.active=dev,cloud\nserver.port=8081",
+				"application-cloud.properties": "server.port=8083",
+				"application-dev.yml":          "server.port: 8082\nspring.application.name: dev",
+				"application-test.properties":  "server.port=8084",
+			},
+			expected: map[string]string{
+				"spring.profiles.active":  "dev,cloud",
+				"server.port":             "8083",
+				"spring.application.name": "dev",
+			},
+		},
+		{
+			name: "ProfileDocuments",
+			files: map[string]string{
+				"application.yml": `
+server.port: 8081
+spring.profiles.active: cloud
+---
+spring.config.activate.on-profile: cloud
+server.port: 8082
+---
+spring.config.activate.on-profile: test
+server.port: 8083
+`,
+				"application.properties": `
+spring.application.name=demo
+#---
+spring.config.activate.on-profile=cloud
+spring.application.name=demo-cloud
+`,
+			},
+			expected: map[string]string{
+				"server.port":                       "8082",
+				"spring.profiles.active":            "cloud",
+				"spring.config.activate.on-profile": "cloud",
+				"spring.application.name":           "demo-cloud",
+			},
+		},
+		{
+			name:     "None",
+			files:    map[string]string{},
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			resourcesDir := filepath.Join(dir, "src", "main", "resources")
+			require.NoError(t, os.MkdirAll(resourcesDir, 0755))
+			for name, contents := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(resourcesDir, name), []byte(contents), 0600))
+			}
+
+			config, err := readAppConfig(dir)
+			require.NoError(t, err)
+			require.Equal(t, appConfig(tt.expected), config)
+		})
+	}
+}
+
+func TestAppConfigGet(t *testing.T) {
+	config := appConfig{
+		"server.port":       "${PORT:8081}",
+		"app.queue":         "${app.prefix}-orders",
+		"app.prefix":        "demo",
+		"app.topic":         "${TOPIC}",
+		"app.self":          "${app.self}",
+		"app.profiles":      "dev, cloud,",
+		"app.profiles2[0]":  "dev",
+		"app.profiles2[1]":  "${PROFILE:cloud}",
+		"management.port":   "-1",
+		"spring.cloud.name": "",
+	}
+
+	require.Equal(t, "8081", config.get("server.port"))
+	require.Equal(t, 8081, config.port("server.port"))
+	require.Equal(t, "demo-orders", config.get("app.queue"))
+	require.Equal(t, "${TOPIC}", config.get("app.topic"))
+	require.Equal(t, "${app.self}", config.get("app.self"))
+	require.Equal(t, []string{"dev", "cloud"}, config.list("app.profiles"))
+	require.Equal(t, []string{"dev", "cloud"}, config.list("app.profiles2"))
+	require.Equal(t, 0, config.port("management.port"))
+	require.Equal(t, 0, config.port("missing.port"))
+	require.Empty(t, config.get("spring.cloud.name"))
+}
@@ -0,0 +1,417 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package appdetect
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// gradleProject is the information azd reads from gradle build scripts.
+//
+// Build scripts are programs rather than declarations, so they are scanned with heuristics instead of being
+// evaluated by gradle. This covers the conventional dependency notations used by Groovy and Kotlin DSL scripts.
+type gradleProject struct {
+	// Directories of the subprojects included by settings.gradle(.kts), relative to path.
+	Subprojects []string
+	// Dependencies declared in build.gradle(.kts).
+	Dependencies []dependency
+	// Plugins applied in build.gradle(.kts).
+	Plugins []string
+	// Paths of the other projects of the build that this project depends on, e.g. ':library'.
+	ProjectDependencies []string
+	// The name of the build file that was read, empty if there is none.
+	buildFile string
+	path      string
+
+	// For multi-project builds, the directories of the subprojects that other subprojects depend on.
+	buildDependencies map[string]bool
+}
+
+// runnableGradlePlugins are the gradle plugins that package a project as an application that can be run on its own.
+var runnableGradlePlugins = map[string]struct{}{
+	"application":                        {},
+	"war":                                {},
+	"org.springframework.boot":           {},
+	"io.quarkus":                         {},
+	"io.micronaut.application":           {},
+	"com.microsoft.azure.azurefunctions": {},
+	"com.google.cloud.tools.jib":         {},
+	"org.graalvm.buildtools.native":      {},
+}
+
+// isGradleFile returns true for gradle build and settings scripts, in both Groovy and Kotlin DSL.
+func isGradleFile(name string) bool {
+	switch strings.ToLower(name) {
+	case "build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts":
+		return true
+	}
+
+	return false
+}
+
+// readGradleProject reads the gradle build located in dir.
+func readGradleProject(dir string) (*gradleProject, error) {
+	project := &gradleProject{
+		path: dir,
+	}
+
+	_, settings, err := readFirstFile(dir, "settings.gradle", "settings.gradle.kts")
+	if err != nil {
+		return nil, err
+	}
+
+	if settings != nil {
+		project.Subprojects = parseGradleIncludes(settings)
+	}
+
+	buildFile, build, err := readFirstFile(dir, "build.gradle", "build.gradle.kts")
+	if err != nil {
+		return nil, err
+	}
+
+	if build != nil {
+		catalog, err := readGradleVersionCatalog(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		project.buildFile = buildFile
+		project.Dependencies = parseGradleDependencies(build, catalog)
+		project.Plugins = parseGradlePlugins(build)
+		project.ProjectDependencies = parseGradleProjectDependencies(build)
+	}
+
+	return project, nil
+}
+
+// readBuildDependencies records which subprojects of this multi-project build are dependencies of other subprojects.
+func (p *gradleProject) readBuildDependencies() error {
+	p.buildDependencies = map[string]bool{}
+	for _, subproject := range p.Subprojects {
+		_, build, err := readFirstFile(filepath.Join(p.path, subproject), "build.gradle", "build.gradle.kts")
+		if err != nil {
+			return err
+		}
+
+		for _, projectPath := range parseGradleProjectDependencies(build) {
+			p.buildDependencies[gradleProjectDir(projectPath)] = true
+		}
+	}
+
+	return nil
+}
+
+// isRunnable returns true when the project is packaged as an application, as opposed to a library
+// that is consumed by other projects.
+func (p *gradleProject) isRunnable() bool {
+	for _, plugin := range p.Plugins {
+		if _, has := runnableGradlePlugins[plugin]; has {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isLibraryOf returns true when the project located at dir is a library of the multi-project build p: another
+// subproject depends on it, and it is not packaged as an application itself.
+func (p *gradleProject) isLibraryOf(dir string, project *gradleProject) bool {
+	rel, err := filepath.Rel(p.path, dir)
+	if err != nil {
+		return false
+	}
+
+	return p.buildDependencies[rel] && !project.isRunnable()
+}
+
+// gradleProjectDir maps a project path such as ':services:api' to the conventional directory 'services/api'.
+func gradleProjectDir(projectPath string) string {
+	return filepath.FromSlash(strings.ReplaceAll(strings.Trim(projectPath, ":"), ":", "/"))
+}
+
+// readFirstFile returns the name and contents of the first of names that exists in dir, or nil if none exist.
+func readFirstFile(dir string, names ...string) (string, []byte, error) {
+	for _, name := range names {
+		contents, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return "", nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+
+		return name, contents, nil
+	}
+
+	return "", nil, nil
+}
+
+// contains returns true when dir is located under the build rooted at p.
+func (p *gradleProject) contains(dir string) bool {
+	rel, err := filepath.Rel(p.path, dir)
+	return err == nil && rel != "." && !strings.HasPrefix(rel, "..")
+}
+
+// includes returns true when dir is one of the subprojects included by the settings of p.
+func (p *gradleProject) includes(dir string) bool {
+	for _, subproject := range p.Subprojects {
+		if filepath.Join(p.path, subproject) == filepath.Clean(dir) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// gradleIncludeRegex matches 'include' statements in settings scripts, such as:
+//
+//	include 'app', 'library'
+//	include(":app", ":services:api")
+var gradleIncludeRegex = regexp.MustCompile(`(?m)^\s*include\s*\(?\s*((?:["'][^"']+["']\s*,?\s*)+)\)?`)
+
+var gradleQuotedRegex = regexp.MustCompile(`["']([^"']+)["']`)
+
+// parseGradleIncludes returns the directories of the subprojects included in a settings script.
+// Project paths such as ':services:api' are mapped to the conventional directory 'services/api'.
+func parseGradleIncludes(settings []byte) []string {
+	var subprojects []string
+	for _, match := range gradleIncludeRegex.FindAllSubmatch(stripGradleComments(settings), -1) {
+		for _, quoted := range gradleQuotedRegex.FindAllSubmatch(match[1], -1) {
+			projectDir := gradleProjectDir(string(quoted[1]))
+			if projectDir == "" {
+				continue
+			}
+
+			subprojects = append(subprojects, projectDir)
+		}
+	}
+
+	return subprojects
+}
+
+// gradleStringDepRegex matches dependencies declared with the string notation, such as:
+//
+//	implementation 'org.springframework.boot:spring-boot-starter-web'
+//	runtimeOnly("org.postgresql:postgresql:42.7.1")
+//	implementation(platform("com.azure.spring:spring-cloud-azure-dependencies:5.8.0"))
+var gradleStringDepRegex = regexp.MustCompile(
+	`(?m)^\s*(\w+)\s*\(?\s*(?:(?:enforcedPlatform|platform)\s*\(\s*)?["']([^"':\s]+):([^"':\s]+)(?::([^"'\s]+))?["']`)
+
+// gradleMapDepRegex matches dependencies declared with the map notation, such as:
+//
+//	implementation group: 'com.mysql', name: 'mysql-connector-j', version: '8.2.0'
+//	implementation(group = "com.mysql", name = "mysql-connector-j")
+var gradleMapDepRegex = regexp.MustCompile(
+	`(?m)^\s*(\w+)\s*\(?\s*group\s*[:=]\s*["']([^"']+)["']\s*,\s*name\s*[:=]\s*["']([^"']+)["']` +
+		`(?:\s*,\s*version\s*[:=]\s*["']([^"']+)["'])?`)
+
+// gradleCatalogDepRegex matches dependencies declared with a version catalog accessor, such as:
+//
+//	implementation libs.spring.boot.starter.web
+//	implementation(libs.postgresql)
+var gradleCatalogDepRegex = regexp.MustCompile(`(?m)^\s*(\w+)\s*\(?\s*libs\.([\w.]+)`)
+
+// parseGradleDependencies returns the dependencies declared in a build script. Dependencies referenced through the
+// version catalog accessor 'libs' are resolved with catalog, which maps accessor names to 'group:name' coordinates.
+func parseGradleDependencies(build []byte, catalog map[string]string) []dependency {
+	build = stripGradleComments(build)
+
+	var dependencies []dependency
+	add := func(configuration, groupId, artifactId, version string) {
+		scope, ok := gradleConfigurationScope(configuration)
+		if !ok {
+			return
+		}
+
+		dependencies = append(dependencies, dependency{
+			GroupId:    groupId,
+			ArtifactId: artifactId,
+			Version:    version,
+			Scope:      scope,
+		})
+	}
+
+	for _, match := range gradleStringDepRegex.FindAllSubmatch(build, -1) {
+		add(string(match[1]), string(match[2]), string(match[3]), string(match[4]))
+	}
+
+	for _, match := range gradleMapDepRegex.FindAllSubmatch(build, -1) {
+		add(string(match[1]), string(match[2]), string(match[3]), string(match[4]))
+	}
+
+	for _, match := range gradleCatalogDepRegex.FindAllSubmatch(build, -1) {
+		coordinates, has := catalog[string(match[2])]
+		if !has {
+			continue
+		}
+
+		parts := strings.SplitN(coordinates, ":", 3)
+		if len(parts) < 2 {
+			continue
+		}
+
+		version := ""
+		if len(parts) == 3 {
+			version = parts[2]
+		}
+
+		add(string(match[1]), parts[0], parts[1], version)
+	}
+
+	return dependencies
+}
+
+// gradleProjectDepRegex matches dependencies on other projects of the build, such as:
+//
+//	implementation project(':library')
+//	implementation(project(":library"))
+var gradleProjectDepRegex = regexp.MustCompile(`(?m)^\s*(\w+)\s*\(?\s*project\s*\(\s*(?:path\s*[:=]\s*)?["']([^"']+)["']`)
+
+// parseGradleProjectDependencies returns the paths of the projects of the build that a build script depends on.
+func parseGradleProjectDependencies(build []byte) []string {
+	var projectPaths []string
+	for _, match := range gradleProjectDepRegex.FindAllSubmatch(stripGradleComments(build), -1) {
+		if _, ok := gradleConfigurationScope(string(match[1])); ok {
+			projectPaths = append(projectPaths, string(match[2]))
+		}
+	}
+
+	return projectPaths
+}
+
+// gradlePluginIdRegex matches plugins applied by id, such as:
+//
+//	id 'org.springframework.boot' version '3.2.2'
+//	id("io.quarkus")
+//	apply plugin: 'war'
+var gradlePluginIdRegex = regexp.MustCompile(`(?m)^\s*(?:id\s*\(?|apply\s+plugin\s*:)\s*["']([^"']+)["']`)
+
+// gradleCorePluginRegex matches core plugins applied by name in a plugins block, such as 'application' or `war`.
+var gradleCorePluginRegex = regexp.MustCompile("(?m)^\\s*`?(application|war|java-library|java)`?\\s*$")
+
+// parseGradlePlugins returns the ids of the plugins applied in a build script.
+func parseGradlePlugins(build []byte) []string {
+	build = stripGradleComments(build)
+
+	var plugins []string
+	for _, match := range gradlePluginIdRegex.FindAllSubmatch(build, -1) {
+		plugins = append(plugins, string(match[1]))
+	}
+
+	for _, match := range gradleCorePluginRegex.FindAllSubmatch(build, -1) {
+		plugins = append(plugins, string(match[1]))
+	}
+
+	return plugins
+}
+
+// gradleConfigurationScope maps a gradle dependency configuration to the equivalent maven scope.
+// Configurations that do not declare dependencies, such as 'id' in a plugins block, are not mapped.
+func gradleConfigurationScope(configuration string) (string, bool) {
+	switch configuration {
+	case "implementation", "api", "compile", "compileOnlyApi":
+		return "compile", true
+	case "compileOnly", "annotationProcessor", "developmentOnly":
+		return "provided", true
+	case "runtimeOnly", "runtime":
+		return "runtime", true
+	case "testImplementation", "testCompileOnly", "testRuntimeOnly", "testCompile", "testRuntime":
+		return "test", true
+	}
+
+	return "", false
+}
+
+// gradleCatalogLibraryRegex matches the entries in the [libraries] section of a version catalog, such as:
+//
+//	postgresql = "org.postgresql:postgresql:42.7.1"
+//	spring-boot-starter-web = { module = "org.springframework.boot:spring-boot-starter-web" }
+//	mysql = { group = "com.mysql", name = "mysql-connector-j", version.ref = "mysql" }
+var gradleCatalogLibraryRegex = regexp.MustCompile(`^\s*([\w.-]+)\s*=\s*(.+)$`)
+var gradleCatalogModuleRegex = regexp.MustCompile(`module\s*=\s*"([^"]+)"`)
+var gradleCatalogGroupRegex = regexp.MustCompile(`group\s*=\s*"([^"]+)"`)
+var gradleCatalogNameRegex = regexp.MustCompile(`name\s*=\s*"([^"]+)"`)
+
+// readGradleVersionCatalog reads the libraries of the default version catalog 'gradle/libs.versions.toml',
+// searching dir and its parents. The returned map is keyed by accessor name, e.g. 'spring.boot.starter.web'.
+func readGradleVersionCatalog(dir string) (map[string]string, error) {
+	catalog := map[string]string{}
+
+	var contents []byte
+	for searchDir := dir; ; {
+		c, err := os.ReadFile(filepath.Join(searchDir, "gradle", "libs.versions.toml"))
+		if err == nil {
+			contents = c
+			break
+		}
+
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("reading version catalog: %w", err)
+		}
+
+		parent := filepath.Dir(searchDir)
+		if parent == searchDir {
+			return catalog, nil
+		}
+
+		searchDir = parent
+	}
+
+	inLibraries := false
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inLibraries = line == "[libraries]"
+			continue
+		}
+
+		if !inLibraries {
+			continue
+		}
+
+		match := gradleCatalogLibraryRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		accessor := strings.NewReplacer("-", ".", "_", ".").Replace(match[1])
+		value := match[2]
+		if strings.HasPrefix(value, `"`) {
+			catalog[accessor] = strings.Trim(value, `"`)
+		} else if module := gradleCatalogModuleRegex.FindStringSubmatch(value); module != nil {
+			catalog[accessor] = module[1]
+		} else {
+			group := gradleCatalogGroupRegex.FindStringSubmatch(value)
+			name := gradleCatalogNameRegex.FindStringSubmatch(value)
+			if group != nil && name != nil {
+				catalog[accessor] = group[1] + ":" + name[1]
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading version catalog: %w", err)
+	}
+
+	return catalog, nil
+}
+
+var gradleBlockCommentRegex = regexp.MustCompile(`(?s)/\*.*?\*/`)
+var gradleLineCommentRegex = regexp.MustCompile(`(?m)^\s*//.*$`)
+
+// stripGradleComments removes block comments and whole-line comments from a script,
+// so that commented out dependencies are not detected.
+func stripGradleComments(script []byte) []byte {
+	script = gradleBlockCommentRegex.ReplaceAll(script, nil)
+	return gradleLineCommentRegex.ReplaceAll(script, nil)
+}
@@ -0,0 +1,178 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package appdetect
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGradleIncludes(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings string
+		expected []string
+	}{
+		{
+			name: "Groovy",
+			settings: `
+				rootProject.name = 'demo'
+				include 'app', 'library'
+			`,
+			expected: []string{"app", "library"},
+		},
+		{
+			name: "Kotlin",
+			settings: `
+				rootProject.name = "demo"
+				include(":services:api")
+				include(
+					":web",
+				)
+				// include(":disabled")
+			`,
+			expected: []string{filepath.Join("services", "api"), "web"},
+		},
+		{
+			name:     "NoIncludes",
+			settings: `rootProject.name = "demo"`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, parseGradleIncludes([]byte(tt.settings)))
+		})
+	}
+}
+
+func TestParseGradleDependencies(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    string
+		catalog  map[string]string
+		expected []dependency
+	}{
+		{
+			name: "Groovy",
+			build: `
+				plugins {
+					id 'org.springframework.boot' version '3.2.2'
+				}
+				dependencies {
+					implementation 'org.springframework.boot:spring-boot-starter-web'
+					implementation group: 'com.mysql', name: 'mysql-connector-j', version: '8.2.0'
+					testImplementation 'org.springframework.boot:spring-boot-starter-test'
+				}
+			`,
+			expected: []dependency{
+				{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter-web", Scope: "compile"},
+				{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter-test", Scope: "test"},
+				{GroupId: "com.mysql", ArtifactId: "mysql-connector-j", Version: "8.2.0", Scope: "compile"},
+			},
+		},
+		{
+			name: "Kotlin",
+			build: `
+				plugins {
+					id("org.springframework.boot") version "3.2.2"
+				}
+				dependencies {
+					implementation(platform("com.azure.spring:spring-cloud-azure-dependencies:5.8.0"))
+					runtimeOnly("org.postgresql:postgresql:42.7.1")
+					/*
+					implementation("com.mysql:mysql-connector-j")
+					*/
+				}
+			`,
+			expected: []dependency{
+				{
+					GroupId:    "com.azure.spring",
+					ArtifactId: "spring-cloud-azure-dependencies",
+					Version:    "5.8.0",
+					Scope:      "compile",
+				},
+				{GroupId: "org.postgresql", ArtifactId: "postgresql", Version: "42.7.1", Scope: "runtime"},
+			},
+		},
+		{
+			name: "VersionCatalog",
+			build: `
+				dependencies {
+					implementation(libs.spring.boot.starter.data.redis)
+					implementation(libs.unknown)
+				}
+			`,
+			catalog: map[string]string{
+				"spring.boot.starter.data.redis": "org.springframework.boot:spring-boot-starter-data-redis",
+			},
+			expected: []dependency{
+				{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter-data-redis", Scope: "compile"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, parseGradleDependencies([]byte(tt.build), tt.catalog))
+		})
+	}
+}
+
+func TestReadGradleVersionCatalog(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "gradle"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "app"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gradle", "libs.versions.toml"), []byte(`
+[versions]
+mysql = "8.2.0"
+
+[libraries]
+postgresql = "org.postgresql:postgresql:42.7.1"
+spring-boot-starter-web = { module = "org.springframework.boot:spring-boot-starter-web" }
+mysql_connector = { group = "com.mysql", name = "mysql-connector-j", version.ref = "mysql" }
+
+[plugins]
+spring-boot = { id = "org.springframework.boot", version = "3.2.2" }
+`), 0600))
+
+	catalog, err := readGradleVersionCatalog(filepath.Join(dir, "app"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"postgresql":              "org.postgresql:postgresql:42.7.1",
+		"spring.boot.starter.web": "org.springframework.boot:spring-boot-starter-web",
+		"mysql.connector":         "com.mysql:mysql-connector-j",
+	}, catalog)
+}
+
+func TestDetectGradle(t *testing.T) {
+	dir := t.TempDir()
+	err := copyTestDataDir("**/java-gradle/**", dir)
+	require.NoError(t, err)
+
+	projects, err := Detect(context.Background(), dir, WithJava())
+	require.NoError(t, err)
+
+	require.Equal(t, []Project{
+		{
+			Language:      Java,
+			Path:          filepath.Join(dir, "java-gradle", "app"),
+			DetectionRule: "Inferred by presence of: build.gradle.kts",
+			Dependencies:  []Dependency{JavaSpringBoot},
+			DatabaseDeps:  []DatabaseDep{DbPostgres},
+			Ports:         []Port{{Number: 8081, Protocol: "http"}},
+		},
+		{
+			Language:      Java,
+			Path:          filepath.Join(dir, "java-gradle", "library"),
+			DetectionRule: "Inferred by presence of: build.gradle",
+			Library:       true,
+		},
+	}, projects)
+}
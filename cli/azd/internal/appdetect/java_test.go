@@ -13,6 +13,7 @@ import (
 
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/maven"
+	"github.com/stretchr/testify/require"
 )
 
 func TestToMavenProject(t *testing.T) {
@@ -400,3 +401,205 @@ func prepareTestPomFiles(testPoms []testPom) (string, error) {
 	}
 	return tempDir, nil
 }
+
+func TestMergeParent(t *testing.T) {
+	root := &mavenProject{
+		GroupId:    "com.example",
+		ArtifactId: "parent",
+		Version:    "1.0.0",
+		Parent: parent{
+			GroupId:    "org.springframework.boot",
+			ArtifactId: "spring-boot-starter-parent",
+			Version:    "3.2.2",
+		},
+		Properties: properties{
+			"spring-cloud-azure.version": "5.8.0",
+			"mysql.version":              "8.0.0",
+		},
+		DependencyManagement: dependencyManagement{
+			Dependencies: []dependency{
+				{GroupId: "com.azure.spring", ArtifactId: "spring-cloud-azure-dependencies",
+					Version: "${spring-cloud-azure.version}"},
+				{GroupId: "com.mysql", ArtifactId: "mysql-connector-j", Version: "${mysql.version}"},
+			},
+		},
+	}
+
+	module := &mavenProject{
+		ArtifactId: "app",
+		Parent: parent{
+			GroupId:    "com.example",
+			ArtifactId: "parent",
+			Version:    "1.0.0",
+		},
+		Properties: properties{
+			"mysql.version": "8.2.0",
+		},
+		Dependencies: []dependency{
+			{GroupId: "com.mysql", ArtifactId: "mysql-connector-j"},
+			{GroupId: "${project.groupId}", ArtifactId: "library", Version: "${project.version}"},
+		},
+	}
+
+	module.mergeParent(root)
+
+	require.Equal(t, root.Parent, module.Parent)
+	require.Equal(t, "com.example:app", module.coordinates())
+	require.Equal(t, "8.2.0", module.Properties["mysql.version"])
+	require.Equal(t, "5.8.0", module.Properties["spring-cloud-azure.version"])
+	require.Equal(t, []dependency{
+		{GroupId: "com.mysql", ArtifactId: "mysql-connector-j", Version: "8.2.0"},
+		{GroupId: "com.example", ArtifactId: "library", Version: "1.0.0"},
+	}, module.Dependencies)
+}
+
+func TestReadReactorDependencies(t *testing.T) {
+	dir := t.TempDir()
+	err := copyTestDataDir("**/java-multimodules/**", dir)
+	require.NoError(t, err)
+
+	root := &mavenProject{
+		GroupId:    "org.springframework",
+		ArtifactId: "gs-multi-module",
+		Modules:    []string{"library", "application"},
+		path:       filepath.Join(dir, "java-multimodules"),
+	}
+	require.NoError(t, root.readReactorDependencies())
+	require.True(t, root.reactorDependencies["com.example:library"])
+	require.False(t, root.reactorDependencies["com.example:application"])
+	require.True(t, root.springBootModules["com.example:application"])
+	require.False(t, root.springBootModules["com.example:library"])
+
+	application := &mavenProject{
+		Build: build{
+			Plugins: []plugin{{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-maven-plugin"}},
+		},
+	}
+	require.True(t, application.isRunnable())
+	require.False(t, (&mavenProject{Packaging: "jar"}).isRunnable())
+	require.True(t, (&mavenProject{Packaging: "war"}).isRunnable())
+}
+
+func TestDetectDependencies(t *testing.T) {
+	tests := []struct {
+		name         string
+		dependencies []dependency
+		config       string
+		expected     Project
+	}{
+		{
+			name: "SpringCloudAzure",
+			dependencies: []dependency{
+				{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter-web"},
+				{GroupId: "com.azure.spring", ArtifactId: "spring-cloud-azure-starter-servicebus"},
+				{GroupId: "com.azure.spring", ArtifactId: "spring-cloud-azure-starter-eventhubs"},
+				{GroupId: "com.azure.spring", ArtifactId: "spring-cloud-azure-starter-storage-blob"},
+				{GroupId: "com.azure.spring", ArtifactId: "spring-cloud-azure-starter-keyvault-secrets"},
+				{GroupId: "com.azure.spring", ArtifactId: "spring-cloud-azure-starter-data-cosmos"},
+			},
+			config: `
+server.port=9090
+spring.cloud.azure.servicebus.processor.entity-name=orders
+spring.cloud.azure.servicebus.processor.entity-type=queue
+spring.cloud.azure.servicebus.producer.entity-name=events
+spring.cloud.azure.servicebus.producer.entity-type=topic
+spring.cloud.azure.eventhubs.event-hub-name=telemetry
+spring.cloud.azure.storage.blob.container-name=${CONTAINER:uploads}
+`,
+			expected: Project{
+				Dependencies: []Dependency{JavaSpringBoot, JavaSpringCloudAzure},
+				DatabaseDeps: []DatabaseDep{DbCosmos},
+				Ports:        []Port{{Number: 9090, Protocol: "http"}},
+				AzureDeps: []AzureDep{
+					AzureDepServiceBus{Queues: []string{"orders"}, Topics: []string{"events"}},
+					AzureDepEventHubs{Names: []string{"telemetry"}},
+					AzureDepStorageAccount{ContainerNames: []string{"uploads"}},
+					AzureDepKeyVault{},
+				},
+			},
+		},
+		{
+			name: "Stream",
+			dependencies: []dependency{
+				{GroupId: "com.azure.spring", ArtifactId: "spring-cloud-azure-starter-stream-servicebus"},
+				{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter"},
+			},
+			config: `
+spring.cloud.stream.bindings.consume-in-0.destination=orders
+spring.cloud.stream.bindings.supply-out-0.destination=events
+spring.cloud.stream.servicebus.bindings.supply-out-0.producer.entity-type=topic
+`,
+			expected: Project{
+				Dependencies: []Dependency{JavaSpringBoot, JavaSpringCloudAzure},
+				AzureDeps: []AzureDep{
+					AzureDepServiceBus{Queues: []string{"orders"}, Topics: []string{"events"}},
+				},
+			},
+		},
+		{
+			name: "JmsAndKafka",
+			dependencies: []dependency{
+				{GroupId: "com.azure.spring", ArtifactId: "spring-cloud-azure-starter-servicebus-jms"},
+				{GroupId: "com.azure.spring", ArtifactId: "spring-cloud-azure-starter"},
+				{GroupId: "org.springframework.kafka", ArtifactId: "spring-kafka"},
+			},
+			config: "spring.kafka.template.default-topic=telemetry",
+			expected: Project{
+				Dependencies: []Dependency{JavaSpringCloudAzure},
+				AzureDeps: []AzureDep{
+					AzureDepServiceBus{IsJms: true},
+					AzureDepEventHubs{Names: []string{"telemetry"}, UseKafka: true},
+				},
+			},
+		},
+		{
+			name: "KafkaWithoutAzure",
+			dependencies: []dependency{
+				{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter"},
+				{GroupId: "org.springframework.kafka", ArtifactId: "spring-kafka"},
+			},
+			config: "spring.kafka.bootstrap-servers=localhost:9092",
+			expected: Project{
+				Dependencies: []Dependency{JavaSpringBoot},
+			},
+		},
+		{
+			name: "Quarkus",
+			dependencies: []dependency{
+				{GroupId: "io.quarkus", ArtifactId: "quarkus-resteasy-reactive"},
+			},
+			config: "quarkus.http.port=8081\nserver.port=9090",
+			expected: Project{
+				Dependencies: []Dependency{JavaQuarkus},
+				Ports:        []Port{{Number: 8081, Protocol: "http"}},
+			},
+		},
+		{
+			name: "MicronautAndJakartaEE",
+			dependencies: []dependency{
+				{GroupId: "io.micronaut.data", ArtifactId: "micronaut-data-jdbc"},
+				{GroupId: "jakarta.platform", ArtifactId: "jakarta.jakartaee-api", Scope: "provided"},
+			},
+			expected: Project{
+				Dependencies: []Dependency{JavaJakartaEE, JavaMicronaut},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			resourcesDir := filepath.Join(dir, "src", "main", "resources")
+			require.NoError(t, os.MkdirAll(resourcesDir, 0755))
+			err := os.WriteFile(filepath.Join(resourcesDir, "application.properties"), []byte(tt.config), 0600)
+			require.NoError(t, err)
+
+			project, err := detectDependencies(tt.dependencies, &Project{Language: Java, Path: dir})
+			require.NoError(t, err)
+
+			tt.expected.Language = Java
+			tt.expected.Path = dir
+			require.Equal(t, tt.expected, *project)
+		})
+	}
+}
@@ -0,0 +1,3 @@
+rootProject.name = "java-gradle"
+
+include("app", "library")
@@ -22,6 +22,7 @@ var DbMap = map[appdetect.DatabaseDep]project.ResourceType{
 	appdetect.DbPostgres: project.ResourceTypeDbPostgres,
 	appdetect.DbMySql:    project.ResourceTypeDbMySql,
 	appdetect.DbRedis:    project.ResourceTypeDbRedis,
+	appdetect.DbCosmos:   project.ResourceTypeDbCosmos,
 }
 
 // PromptOptions contains common options for prompting.
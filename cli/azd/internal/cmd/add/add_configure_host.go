@@ -351,6 +351,10 @@ func PromptPort(
 	name string,
 	svc appdetect.Project) (int, error) {
 	if svc.Docker == nil || svc.Docker.Path == "" { // using default builder from azd
+		if len(svc.Ports) == 1 { // the port is set in the application configuration
+			return svc.Ports[0].Number, nil
+		}
+
 		if svc.Language == appdetect.Java || svc.Language == appdetect.DotNet {
 			return 8080, nil
 		}
@@ -8,6 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
@@ -33,6 +37,8 @@ type DeployFlags struct {
 	serviceName string
 	All         bool
 	fromPackage string
+	rollback    bool
+	rollbackTo  string
 	global      *internal.GlobalCommandOptions
 	*internal.EnvFlag
 }
@@ -74,6 +80,18 @@ func (d *DeployFlags) bindCommon(local *pflag.FlagSet, global *internal.GlobalCo
 		//nolint:lll
 		"Deploys the packaged service located at the provided path. Supports zipped file packages (file path) or container images (image tag).",
 	)
+	local.BoolVar(
+		&d.rollback,
+		"rollback",
+		false,
+		"Redeploys the previous deployment of the service, from the deployment history of the environment.",
+	)
+	local.StringVar(
+		&d.rollbackTo,
+		"to",
+		"",
+		"When used with --rollback, the id of the deployment to redeploy.",
+	)
 }
 
 func (d *DeployFlags) SetCommon(envFlag *internal.EnvFlag) {
@@ -122,6 +140,7 @@ type DeployAction struct {
 	commandRunner       exec.CommandRunner
 	alphaFeatureManager *alpha.FeatureManager
 	importManager       *project.ImportManager
+	deploymentHistory   *project.DeploymentHistory
 }
 
 func NewDeployAction(
@@ -142,6 +161,7 @@ func NewDeployAction(
 	writer io.Writer,
 	alphaFeatureManager *alpha.FeatureManager,
 	importManager *project.ImportManager,
+	deploymentHistory *project.DeploymentHistory,
 ) actions.Action {
 	return &DeployAction{
 		flags:               flags,
@@ -161,6 +181,7 @@ func NewDeployAction(
 		commandRunner:       commandRunner,
 		alphaFeatureManager: alphaFeatureManager,
 		importManager:       importManager,
+		deploymentHistory:   deploymentHistory,
 	}
 }
 
@@ -183,6 +204,30 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		)
 	}
 
+	if da.flags.rollbackTo != "" && !da.flags.rollback {
+		return nil, errors.New("'--to' can only be specified with '--rollback'")
+	}
+
+	if da.flags.rollback && da.flags.fromPackage != "" {
+		return nil, errors.New("'--from-package' cannot be specified when '--rollback' is set")
+	}
+
+	if da.flags.rollbackTo != "" {
+		if da.flags.All {
+			return nil, errors.New("'--to' cannot be specified when '--all' is set")
+		}
+
+		// The deployment to roll back to identifies the service
+		if targetServiceName == "" {
+			deployment, err := da.deploymentHistory.Get(da.flags.rollbackTo)
+			if err != nil {
+				return nil, err
+			}
+
+			targetServiceName = deployment.Service
+		}
+	}
+
 	targetServiceName, err := getTargetServiceName(
 		ctx,
 		da.projectManager,
@@ -226,11 +271,24 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 	startTime := time.Now()
 
 	deployResults := map[string]*project.ServiceDeployResult{}
+	// the deployments of the services whose changes are deployed with a service deployed later, by service
+	deferred := map[string][]deferredDeployment{}
 	stableServices, err := da.importManager.ServiceStable(ctx, da.projectConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	// Services rolled back are skipped when they have no previous deployment, so each service is rolled back on its own
+	if !da.flags.rollback {
+		deployedServices := []string{}
+		for _, svc := range stableServices {
+			if targetServiceName == "" || targetServiceName == svc.Name {
+				deployedServices = append(deployedServices, svc.Name)
+			}
+		}
+		ctx = project.WithDeployedServices(ctx, deployedServices)
+	}
+
 	for _, svc := range stableServices {
 		stepMessage := fmt.Sprintf("Deploying service %s", svc.Name)
 		da.console.ShowSpinner(ctx, stepMessage, input.Step)
@@ -250,7 +308,28 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		}
 
 		var packageResult *project.ServicePackageResult
-		if da.flags.fromPackage != "" {
+		var rollbackOf string
+		if da.flags.rollback {
+			previous, err := da.deploymentHistory.RollbackTarget(svc.Name, da.flags.rollbackTo)
+			if errors.Is(err, project.ErrNoPreviousDeployment) && targetServiceName == "" {
+				// Services that were never deployed again are skipped when rolling back all services
+				da.console.StopSpinner(ctx, stepMessage, input.StepSkipped)
+				continue
+			} else if err != nil {
+				da.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+				return nil, err
+			}
+
+			packageResult, err = da.deploymentHistory.Package(previous)
+			if err != nil {
+				da.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+				return nil, err
+			}
+
+			rollbackOf = previous.Id
+			stepMessage = fmt.Sprintf("Rolling back service %s to deployment %s", svc.Name, previous.Id)
+			da.console.ShowSpinner(ctx, stepMessage, input.Step)
+		} else if da.flags.fromPackage != "" {
 			// --from-package set, skip packaging
 			packageResult = &project.ServicePackageResult{
 				PackagePath: da.flags.fromPackage,
@@ -270,10 +349,17 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 			// do not stop progress here as next step is to deploy
 			if err != nil {
 				da.console.StopSpinner(ctx, stepMessage, input.StepFailed)
-				return nil, err
+				return nil, da.completeDeferred(deferred, svc.Name, err)
 			}
 		}
 
+		deployment, err := da.deploymentHistory.Start(ctx, svc, packageResult)
+		if err != nil {
+			log.Printf("failed recording deployment of service '%s', skipping: %v", svc.Name, err)
+		} else {
+			deployment.RollbackOf = rollbackOf
+		}
+
 		deployResult, err := async.RunWithProgress(
 			func(deployProgress project.ServiceProgress) {
 				progressMessage := fmt.Sprintf("Deploying service %s (%s)", svc.Name, deployProgress.Message)
@@ -284,6 +370,27 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 			},
 		)
 
+		// The deployment of a service deferred to a service deployed later completes with the deployment of that service
+		if err == nil && deployResult.DeferredTo != "" {
+			deferred[deployResult.DeferredTo] = append(deferred[deployResult.DeferredTo], deferredDeployment{
+				serviceName: svc.Name,
+				record:      deployment,
+				result:      deployResult,
+			})
+			stepMessage = fmt.Sprintf("Deploying service %s (with service %s)", svc.Name, deployResult.DeferredTo)
+		} else if deployment != nil {
+			if err := da.deploymentHistory.Complete(deployment, deployResult, err); err != nil {
+				log.Printf("failed recording deployment of service '%s': %v", svc.Name, err)
+			}
+		}
+		err = da.completeDeferred(deferred, svc.Name, err)
+
+		if da.flags.rollback {
+			if err := da.deploymentHistory.Release(packageResult); err != nil {
+				log.Printf("failed releasing the rollback package of service '%s': %v", svc.Name, err)
+			}
+		}
+
 		da.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
 		if err != nil {
 			return nil, err
@@ -326,6 +433,55 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 	}, nil
 }
 
+// deferredDeployment is the deployment of a service whose changes are deployed with a service deployed later.
+type deferredDeployment struct {
+	serviceName string
+	record      *project.DeploymentRecord
+	result      *project.ServiceDeployResult
+}
+
+// completeDeferred completes the deployments deferred to the service once the service is deployed. When the service
+// failed with err, no other service is deployed, so all the pending deferred deployments fail, and the returned error
+// lists their services.
+func (da *DeployAction) completeDeferred(
+	deferred map[string][]deferredDeployment,
+	serviceName string,
+	err error,
+) error {
+	deployedWith := []string{serviceName}
+	if err != nil {
+		deployedWith = slices.Sorted(maps.Keys(deferred))
+	}
+
+	failedServiceNames := []string{}
+	for _, name := range deployedWith {
+		for _, deployment := range deferred[name] {
+			var deferredErr error
+			if err != nil {
+				failedServiceNames = append(failedServiceNames, deployment.serviceName)
+				deferredErr = fmt.Errorf("service '%s' wasn't deployed: %w", name, err)
+			}
+
+			if deployment.record != nil {
+				if err := da.deploymentHistory.Complete(deployment.record, deployment.result, deferredErr); err != nil {
+					log.Printf("failed recording deployment of service '%s': %v", deployment.serviceName, err)
+				}
+			}
+		}
+		delete(deferred, name)
+	}
+
+	if len(failedServiceNames) > 0 {
+		return fmt.Errorf(
+			"%w. The changes of services %s weren't deployed, as they are deployed with a service that wasn't deployed",
+			err,
+			strings.Join(failedServiceNames, ", "),
+		)
+	}
+
+	return err
+}
+
 func GetCmdDeployHelpDescription(*cobra.Command) string {
 	return generateCmdHelpDescription("Deploy application to Azure.", []string{
 		formatHelpNote(
@@ -335,6 +491,9 @@ func GetCmdDeployHelpDescription(*cobra.Command) string {
 			fmt.Sprintf("When %s is set, only the specific service is deployed.", output.WithHighLightFormat("<service>"))),
 		formatHelpNote("After the deployment is complete, the endpoint is printed. To start the service, select" +
 			" the endpoint or paste it in a browser."),
+		formatHelpNote(fmt.Sprintf(
+			"When %s is set, the previous deployment of the service is redeployed from the deployment history"+
+				" of the environment. Container images are not rebuilt.", output.WithHighLightFormat("--rollback"))),
 	})
 }
 
@@ -352,5 +511,11 @@ func GetCmdDeployHelpFooter(*cobra.Command) string {
 		"Deploy the service named 'api' to Azure from a previously generated package.": output.WithHighLightFormat(
 			"azd deploy api --from-package <package-path>",
 		),
+		"Roll back the service named 'api' to its previous deployment.": output.WithHighLightFormat(
+			"azd deploy api --rollback",
+		),
+		"Roll back the service named 'api' to a specific deployment.": output.WithHighLightFormat(
+			"azd deploy api --rollback --to <deployment-id>",
+		),
 	})
 }
@@ -184,6 +184,19 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 
 	startTime := time.Now()
 
+	// Lock the environment so that concurrent provisioning operations don't overwrite each other's state
+	if !previewMode {
+		release, err := p.envManager.Lock(ctx, p.env)
+		if err != nil {
+			return nil, fmt.Errorf("locking environment: %w", err)
+		}
+		defer func() {
+			if err := release(); err != nil {
+				log.Printf("failed releasing environment lock: %v", err)
+			}
+		}()
+	}
+
 	if err := p.projectManager.Initialize(ctx, p.projectConfig); err != nil {
 		return nil, err
 	}
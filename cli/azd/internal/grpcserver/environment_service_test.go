@@ -36,7 +36,7 @@ func Test_EnvironmentService_NoEnvironment(t *testing.T) {
 
 	// Setup environment data store and manager.
 	fileConfigManager := config.NewFileConfigManager(config.NewManager())
-	localDataStore := environment.NewLocalFileDataStore(azdContext, fileConfigManager)
+	localDataStore := environment.NewLocalFileDataStore(azdContext, fileConfigManager, nil)
 	envManager, err := environment.NewManager(mockContext.Container, azdContext, mockContext.Console, localDataStore, nil)
 	require.NoError(t, err)
 	require.NotNil(t, envManager)
@@ -77,7 +77,7 @@ func Test_EnvironmentService_Flow(t *testing.T) {
 
 	// Configure environment data store and manager.
 	fileConfigManager := config.NewFileConfigManager(config.NewManager())
-	localDataStore := environment.NewLocalFileDataStore(azdContext, fileConfigManager)
+	localDataStore := environment.NewLocalFileDataStore(azdContext, fileConfigManager, nil)
 	envManager, err := environment.NewManager(mockContext.Container, azdContext, mockContext.Console, localDataStore, nil)
 	require.NoError(t, err)
 	require.NotNil(t, envManager)
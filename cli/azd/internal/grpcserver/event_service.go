@@ -117,7 +117,7 @@ func (s *eventService) EventStream(stream grpc.BidiStreamingServer[azdext.EventM
 }
 
 func (s *eventService) handleReadyEvent(extension *extensions.Extension) {
-	extension.Initialize()
+	extension.MarkReady(extensions.LifecycleEventsCapability)
 }
 
 // ----- Project Event Handlers -----
@@ -365,9 +365,18 @@ func (s *eventService) createProjectConfig(proj *project.ProjectConfig) *azdext.
 
 // createServiceConfig converts a project.ServiceConfig into the azdext.ServiceConfig wire format.
 func (s *eventService) createServiceConfig(svc *project.ServiceConfig) *azdext.ServiceConfig {
+	return createServiceConfig(s.lazyEnv, svc)
+}
+
+// createServiceConfig converts a project.ServiceConfig into the azdext.ServiceConfig wire format,
+// resolving the values that reference environment variables with the current environment when available.
+func createServiceConfig(
+	lazyEnv *lazy.Lazy[*environment.Environment],
+	svc *project.ServiceConfig,
+) *azdext.ServiceConfig {
 	resolver := noEnvResolver
 
-	env, err := s.lazyEnv.GetValue()
+	env, err := lazyEnv.GetValue()
 	if err == nil && env != nil {
 		resolver = env.Getenv
 	}
@@ -0,0 +1,182 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+// extensionFrameworkService is a project.FrameworkService provided by an extension for a language.
+// The operations of the framework service are sent as requests to the extension over the stream of the extension.
+type extensionFrameworkService struct {
+	stream       *frameworkServiceStream
+	lazyEnv      *lazy.Lazy[*environment.Environment]
+	requirements project.FrameworkRequirements
+}
+
+func newExtensionFrameworkService(
+	stream *frameworkServiceStream,
+	lazyEnv *lazy.Lazy[*environment.Environment],
+	requirements *azdext.FrameworkRequirements,
+) *extensionFrameworkService {
+	return &extensionFrameworkService{
+		stream:  stream,
+		lazyEnv: lazyEnv,
+		requirements: project.FrameworkRequirements{
+			Package: project.FrameworkPackageRequirements{
+				RequireRestore: requirements.GetRequireRestore(),
+				RequireBuild:   requirements.GetRequireBuild(),
+			},
+		},
+	}
+}
+
+// RequiredExternalTools returns an empty list, extensions are responsible for the tools they require
+func (f *extensionFrameworkService) RequiredExternalTools(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+) []tools.ExternalTool {
+	return []tools.ExternalTool{}
+}
+
+// Initialize is a no-op, extensions subscribe to the lifecycle events of services with the event service
+func (f *extensionFrameworkService) Initialize(ctx context.Context, serviceConfig *project.ServiceConfig) error {
+	return nil
+}
+
+// Requirements returns the requirements that the extension registered with the framework service
+func (f *extensionFrameworkService) Requirements() project.FrameworkRequirements {
+	return f.requirements
+}
+
+// Restore requests the extension to restore the dependencies of the service
+func (f *extensionFrameworkService) Restore(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	progress *async.Progress[project.ServiceProgress],
+) (*project.ServiceRestoreResult, error) {
+	response, err := f.stream.request(ctx, &azdext.FrameworkServiceMessage{
+		MessageType: &azdext.FrameworkServiceMessage_RestoreRequest{
+			RestoreRequest: &azdext.FrameworkServiceRestoreRequest{
+				Service: createServiceConfig(f.lazyEnv, serviceConfig),
+			},
+		},
+	}, serviceProgress(progress))
+	if err != nil {
+		return nil, err
+	}
+
+	restoreResult := &project.ServiceRestoreResult{}
+	if details := response.GetRestoreResponse().GetResult().GetDetails(); len(details) > 0 {
+		restoreResult.Details = details
+	}
+
+	return restoreResult, nil
+}
+
+// Build requests the extension to build the service
+func (f *extensionFrameworkService) Build(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	restoreOutput *project.ServiceRestoreResult,
+	progress *async.Progress[project.ServiceProgress],
+) (*project.ServiceBuildResult, error) {
+	response, err := f.stream.request(ctx, &azdext.FrameworkServiceMessage{
+		MessageType: &azdext.FrameworkServiceMessage_BuildRequest{
+			BuildRequest: &azdext.FrameworkServiceBuildRequest{
+				Service: createServiceConfig(f.lazyEnv, serviceConfig),
+				Restore: createServiceRestoreResult(restoreOutput),
+			},
+		},
+	}, serviceProgress(progress))
+	if err != nil {
+		return nil, err
+	}
+
+	result := response.GetBuildResponse().GetResult()
+	buildResult := &project.ServiceBuildResult{
+		Restore:         restoreOutput,
+		BuildOutputPath: result.GetBuildOutputPath(),
+	}
+
+	if details := result.GetDetails(); len(details) > 0 {
+		buildResult.Details = details
+	}
+
+	return buildResult, nil
+}
+
+// Package requests the extension to package the service
+func (f *extensionFrameworkService) Package(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	buildOutput *project.ServiceBuildResult,
+	progress *async.Progress[project.ServiceProgress],
+) (*project.ServicePackageResult, error) {
+	response, err := f.stream.request(ctx, &azdext.FrameworkServiceMessage{
+		MessageType: &azdext.FrameworkServiceMessage_PackageRequest{
+			PackageRequest: &azdext.FrameworkServicePackageRequest{
+				Service: createServiceConfig(f.lazyEnv, serviceConfig),
+				Build:   createServiceBuildResult(buildOutput),
+			},
+		},
+	}, serviceProgress(progress))
+	if err != nil {
+		return nil, err
+	}
+
+	result := response.GetPackageResponse().GetPackage()
+	packageResult := &project.ServicePackageResult{
+		Build:       buildOutput,
+		PackagePath: result.GetPackagePath(),
+	}
+
+	if details := result.GetDetails(); len(details) > 0 {
+		packageResult.Details = details
+	}
+
+	return packageResult, nil
+}
+
+// createServiceRestoreResult converts a project.ServiceRestoreResult into the azdext.ServiceRestoreResult wire format.
+func createServiceRestoreResult(restoreResult *project.ServiceRestoreResult) *azdext.ServiceRestoreResult {
+	if restoreResult == nil {
+		return nil
+	}
+
+	result := &azdext.ServiceRestoreResult{}
+
+	// Only details set by extensions can be sent back, the details of the framework services of azd are internal
+	if details, ok := restoreResult.Details.(map[string]string); ok {
+		result.Details = details
+	}
+
+	return result
+}
+
+// createServiceBuildResult converts a project.ServiceBuildResult into the azdext.ServiceBuildResult wire format.
+func createServiceBuildResult(buildResult *project.ServiceBuildResult) *azdext.ServiceBuildResult {
+	if buildResult == nil {
+		return nil
+	}
+
+	result := &azdext.ServiceBuildResult{
+		Restore:         createServiceRestoreResult(buildResult.Restore),
+		BuildOutputPath: buildResult.BuildOutputPath,
+	}
+
+	// Only details set by extensions can be sent back, the details of the framework services of azd are internal
+	if details, ok := buildResult.Details.(map[string]string); ok {
+		result.Details = details
+	}
+
+	return result
+}
@@ -0,0 +1,235 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+)
+
+// extensionProvisioningProvider is a provisioning.Provider provided by an extension.
+// The operations of the provider are sent as requests to the extension over the stream of the extension.
+type extensionProvisioningProvider struct {
+	stream  *provisioningStream
+	console input.Console
+	name    string
+}
+
+func newExtensionProvisioningProvider(
+	stream *provisioningStream,
+	console input.Console,
+	name string,
+) *extensionProvisioningProvider {
+	return &extensionProvisioningProvider{
+		stream:  stream,
+		console: console,
+		name:    name,
+	}
+}
+
+// Name gets the name of the provider, as registered by the extension
+func (p *extensionProvisioningProvider) Name() string {
+	return p.name
+}
+
+// Initialize requests the extension to initialize the provider for the project
+func (p *extensionProvisioningProvider) Initialize(
+	ctx context.Context,
+	projectPath string,
+	options provisioning.Options,
+) error {
+	_, err := p.request(ctx, &azdext.ProvisioningMessage{
+		MessageType: &azdext.ProvisioningMessage_InitializeRequest{
+			InitializeRequest: &azdext.ProvisioningInitializeRequest{
+				ProjectPath: projectPath,
+				Options: &azdext.InfraOptions{
+					Provider: string(options.Provider),
+					Path:     options.Path,
+					Module:   options.Module,
+				},
+			},
+		},
+	})
+
+	return err
+}
+
+// State requests the current state of the infrastructure from the extension
+func (p *extensionProvisioningProvider) State(
+	ctx context.Context,
+	options *provisioning.StateOptions,
+) (*provisioning.StateResult, error) {
+	request := &azdext.ProvisioningStateRequest{}
+	if options != nil {
+		request.Hint = options.Hint()
+	}
+
+	response, err := p.request(ctx, &azdext.ProvisioningMessage{
+		MessageType: &azdext.ProvisioningMessage_StateRequest{
+			StateRequest: request,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state := response.GetStateResponse().GetState()
+	outputs, err := createOutputParameters(state.GetOutputs())
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provisioning.Resource, len(state.GetResources()))
+	for i, resourceId := range state.GetResources() {
+		resources[i] = provisioning.Resource{Id: resourceId}
+	}
+
+	return &provisioning.StateResult{
+		State: &provisioning.State{
+			Outputs:   outputs,
+			Resources: resources,
+		},
+	}, nil
+}
+
+// Deploy requests the extension to deploy the infrastructure
+func (p *extensionProvisioningProvider) Deploy(ctx context.Context) (*provisioning.DeployResult, error) {
+	response, err := p.request(ctx, &azdext.ProvisioningMessage{
+		MessageType: &azdext.ProvisioningMessage_DeployRequest{
+			DeployRequest: &azdext.ProvisioningDeployRequest{},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := response.GetDeployResponse().GetResult()
+	outputs, err := createOutputParameters(result.GetOutputs())
+	if err != nil {
+		return nil, err
+	}
+
+	return &provisioning.DeployResult{
+		Deployment: &provisioning.Deployment{
+			Parameters: map[string]provisioning.InputParameter{},
+			Outputs:    outputs,
+		},
+		SkippedReason: provisioning.SkippedReasonType(result.GetSkippedReason()),
+	}, nil
+}
+
+// Preview requests a preview of the changes of a deployment of the infrastructure from the extension
+func (p *extensionProvisioningProvider) Preview(ctx context.Context) (*provisioning.DeployPreviewResult, error) {
+	response, err := p.request(ctx, &azdext.ProvisioningMessage{
+		MessageType: &azdext.ProvisioningMessage_PreviewRequest{
+			PreviewRequest: &azdext.ProvisioningPreviewRequest{},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	preview := response.GetPreviewResponse().GetPreview()
+	changes := make([]*provisioning.DeploymentPreviewChange, len(preview.GetChanges()))
+	for i, change := range preview.GetChanges() {
+		changes[i] = &provisioning.DeploymentPreviewChange{
+			ChangeType:   provisioning.ChangeType(change.ChangeType),
+			ResourceId:   provisioning.Resource{Id: change.ResourceId},
+			ResourceType: change.ResourceType,
+			Name:         change.Name,
+		}
+	}
+
+	return &provisioning.DeployPreviewResult{
+		Preview: &provisioning.DeploymentPreview{
+			Status: preview.GetStatus(),
+			Properties: &provisioning.DeploymentPreviewProperties{
+				Changes: changes,
+			},
+		},
+	}, nil
+}
+
+// Destroy requests the extension to destroy the infrastructure
+func (p *extensionProvisioningProvider) Destroy(
+	ctx context.Context,
+	options provisioning.DestroyOptions,
+) (*provisioning.DestroyResult, error) {
+	response, err := p.request(ctx, &azdext.ProvisioningMessage{
+		MessageType: &azdext.ProvisioningMessage_DestroyRequest{
+			DestroyRequest: &azdext.ProvisioningDestroyRequest{
+				Force: options.Force(),
+				Purge: options.Purge(),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &provisioning.DestroyResult{
+		InvalidatedEnvKeys: response.GetDestroyResponse().GetInvalidatedEnvKeys(),
+	}, nil
+}
+
+// EnsureEnv requests the extension to ensure the environment has the values the provider requires
+func (p *extensionProvisioningProvider) EnsureEnv(ctx context.Context) error {
+	_, err := p.request(ctx, &azdext.ProvisioningMessage{
+		MessageType: &azdext.ProvisioningMessage_EnsureEnvRequest{
+			EnsureEnvRequest: &azdext.ProvisioningEnsureEnvRequest{},
+		},
+	})
+
+	return err
+}
+
+func (p *extensionProvisioningProvider) request(
+	ctx context.Context,
+	msg *azdext.ProvisioningMessage,
+) (*azdext.ProvisioningMessage, error) {
+	msg.Provider = p.name
+
+	// Progress updates of the request are displayed on the spinner of the console
+	return p.stream.request(ctx, msg, func(message string, _ time.Time) {
+		p.console.ShowSpinner(ctx, message, input.Step)
+	})
+}
+
+// createOutputParameters converts the azdext.ProvisioningOutputParameter wire format into provisioning.OutputParameter.
+func createOutputParameters(
+	outputs map[string]*azdext.ProvisioningOutputParameter,
+) (map[string]provisioning.OutputParameter, error) {
+	result := make(map[string]provisioning.OutputParameter, len(outputs))
+
+	for key, output := range outputs {
+		parameter := provisioning.OutputParameter{
+			Type:   provisioning.ParameterType(output.Type),
+			Secure: output.Secure,
+		}
+
+		switch parameter.Type {
+		case provisioning.ParameterTypeString:
+			parameter.Value = output.Value
+		case provisioning.ParameterTypeNumber,
+			provisioning.ParameterTypeBoolean,
+			provisioning.ParameterTypeObject,
+			provisioning.ParameterTypeArray:
+			if err := json.Unmarshal([]byte(output.Value), &parameter.Value); err != nil {
+				return nil, fmt.Errorf("invalid value for output parameter '%s' (%s): %w", key, output.Type, err)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported type '%s' for output parameter '%s'", output.Type, key)
+		}
+
+		result[key] = parameter
+	}
+
+	return result, nil
+}
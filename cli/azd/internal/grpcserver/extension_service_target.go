@@ -0,0 +1,183 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+// extensionServiceTarget is a project.ServiceTarget provided by an extension for a host.
+// The operations of the service target are sent as requests to the extension over the stream of the extension.
+type extensionServiceTarget struct {
+	stream  *serviceTargetStream
+	lazyEnv *lazy.Lazy[*environment.Environment]
+}
+
+func newExtensionServiceTarget(
+	stream *serviceTargetStream,
+	lazyEnv *lazy.Lazy[*environment.Environment],
+) *extensionServiceTarget {
+	return &extensionServiceTarget{
+		stream:  stream,
+		lazyEnv: lazyEnv,
+	}
+}
+
+// Initialize is a no-op, extensions subscribe to the lifecycle events of services with the event service
+func (t *extensionServiceTarget) Initialize(ctx context.Context, serviceConfig *project.ServiceConfig) error {
+	return nil
+}
+
+// RequiredExternalTools returns an empty list, extensions are responsible for the tools they require
+func (t *extensionServiceTarget) RequiredExternalTools(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+) []tools.ExternalTool {
+	return []tools.ExternalTool{}
+}
+
+// Package requests the extension to package the service
+func (t *extensionServiceTarget) Package(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	frameworkPackageOutput *project.ServicePackageResult,
+	progress *async.Progress[project.ServiceProgress],
+) (*project.ServicePackageResult, error) {
+	response, err := t.stream.request(ctx, &azdext.ServiceTargetMessage{
+		MessageType: &azdext.ServiceTargetMessage_PackageRequest{
+			PackageRequest: &azdext.ServiceTargetPackageRequest{
+				Service:          createServiceConfig(t.lazyEnv, serviceConfig),
+				FrameworkPackage: createServicePackageResult(frameworkPackageOutput),
+			},
+		},
+	}, serviceProgress(progress))
+	if err != nil {
+		return nil, err
+	}
+
+	packageResult := &project.ServicePackageResult{
+		PackagePath: response.GetPackageResponse().GetPackage().GetPackagePath(),
+	}
+
+	if frameworkPackageOutput != nil {
+		packageResult.Build = frameworkPackageOutput.Build
+	}
+
+	if details := response.GetPackageResponse().GetPackage().GetDetails(); len(details) > 0 {
+		packageResult.Details = details
+	}
+
+	return packageResult, nil
+}
+
+// Deploy requests the extension to deploy the service
+func (t *extensionServiceTarget) Deploy(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	servicePackage *project.ServicePackageResult,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[project.ServiceProgress],
+) (*project.ServiceDeployResult, error) {
+	response, err := t.stream.request(ctx, &azdext.ServiceTargetMessage{
+		MessageType: &azdext.ServiceTargetMessage_DeployRequest{
+			DeployRequest: &azdext.ServiceTargetDeployRequest{
+				Service:        createServiceConfig(t.lazyEnv, serviceConfig),
+				Package:        createServicePackageResult(servicePackage),
+				TargetResource: createTargetResource(targetResource),
+			},
+		},
+	}, serviceProgress(progress))
+	if err != nil {
+		return nil, err
+	}
+
+	result := response.GetDeployResponse().GetResult()
+	deployResult := &project.ServiceDeployResult{
+		Package:          servicePackage,
+		TargetResourceId: result.GetTargetResourceId(),
+		Kind:             serviceConfig.Host,
+		Endpoints:        result.GetEndpoints(),
+	}
+
+	if details := result.GetDetails(); len(details) > 0 {
+		deployResult.Details = details
+	}
+
+	return deployResult, nil
+}
+
+// Endpoints requests the endpoints that the service exposes from the extension
+func (t *extensionServiceTarget) Endpoints(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	targetResource *environment.TargetResource,
+) ([]string, error) {
+	response, err := t.stream.request(ctx, &azdext.ServiceTargetMessage{
+		MessageType: &azdext.ServiceTargetMessage_EndpointsRequest{
+			EndpointsRequest: &azdext.ServiceTargetEndpointsRequest{
+				Service:        createServiceConfig(t.lazyEnv, serviceConfig),
+				TargetResource: createTargetResource(targetResource),
+			},
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.GetEndpointsResponse().GetEndpoints(), nil
+}
+
+// serviceProgress reports the progress updates of a request to the progress of a service operation
+func serviceProgress(progress *async.Progress[project.ServiceProgress]) progressFunc {
+	if progress == nil {
+		return nil
+	}
+
+	return func(message string, timestamp time.Time) {
+		progress.SetProgress(project.ServiceProgress{
+			Message:   message,
+			Timestamp: timestamp,
+		})
+	}
+}
+
+// createServicePackageResult converts a project.ServicePackageResult into the azdext.ServicePackageResult wire format.
+func createServicePackageResult(packageResult *project.ServicePackageResult) *azdext.ServicePackageResult {
+	if packageResult == nil {
+		return nil
+	}
+
+	result := &azdext.ServicePackageResult{
+		PackagePath: packageResult.PackagePath,
+	}
+
+	// Only details set by extensions can be sent back, the details of the service targets of azd are internal
+	if details, ok := packageResult.Details.(map[string]string); ok {
+		result.Details = details
+	}
+
+	return result
+}
+
+// createTargetResource converts an environment.TargetResource into the azdext.TargetResource wire format.
+func createTargetResource(targetResource *environment.TargetResource) *azdext.TargetResource {
+	if targetResource == nil {
+		return nil
+	}
+
+	return &azdext.TargetResource{
+		SubscriptionId:    targetResource.SubscriptionId(),
+		ResourceGroupName: targetResource.ResourceGroupName(),
+		ResourceName:      targetResource.ResourceName(),
+		ResourceType:      targetResource.ResourceType(),
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// extensionMessage is implemented by the pointers to the messages of the provider streams of extensions
+type extensionMessage[T any] interface {
+	*T
+	azdext.ProviderMessage
+}
+
+// progressFunc reports a progress update of a request sent to an extension
+type progressFunc func(message string, timestamp time.Time)
+
+// extensionStream sends the requests of azd to the providers of an extension over a provider stream,
+// and dispatches the responses of the extension back to the requests.
+type extensionStream[T any, M extensionMessage[T]] struct {
+	extension *extensions.Extension
+	stream    grpc.BidiStreamingServer[T, T]
+	// Kind of the providers of the stream, e.g. "service target"
+	kind string
+
+	// Serializes sending messages, since a stream doesn't support concurrent sends
+	sendMu sync.Mutex
+
+	responses sync.Map // key: request id, value: chan M
+	progress  sync.Map // key: request id, value: progressFunc
+
+	// Closed when the stream has ended and no more responses will be received
+	closed chan struct{}
+}
+
+func newExtensionStream[T any, M extensionMessage[T]](
+	extension *extensions.Extension,
+	stream grpc.BidiStreamingServer[T, T],
+	kind string,
+) *extensionStream[T, M] {
+	return &extensionStream[T, M]{
+		extension: extension,
+		stream:    stream,
+		kind:      kind,
+		closed:    make(chan struct{}),
+	}
+}
+
+// request sends the request to the extension and waits for its response.
+// Progress updates of the request are reported to progress when set.
+func (s *extensionStream[T, M]) request(ctx context.Context, msg M, progress progressFunc) (M, error) {
+	requestId := uuid.NewString()
+	msg.SetRequestId(requestId)
+
+	responseCh := make(chan M, 1)
+	s.responses.Store(requestId, responseCh)
+	defer s.responses.Delete(requestId)
+
+	if progress != nil {
+		s.progress.Store(requestId, progress)
+		defer s.progress.Delete(requestId)
+	}
+
+	if err := s.send(msg); err != nil {
+		return nil, fmt.Errorf("failed sending request to extension %s: %w", s.extension.Id, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.closed:
+		return nil, fmt.Errorf("extension %s exited before completing the request", s.extension.Id)
+	case response := <-responseCh:
+		if response.GetErrorMessage() != "" {
+			return nil, fmt.Errorf("extension %s %s failed: %s", s.extension.Id, s.kind, response.GetErrorMessage())
+		}
+
+		return response, nil
+	}
+}
+
+// handleMessage dispatches the responses and progress updates received from the extension to their requests
+func (s *extensionStream[T, M]) handleMessage(msg M) {
+	if update := msg.ProgressUpdate(); update != nil {
+		if val, ok := s.progress.Load(msg.GetRequestId()); ok {
+			timestamp := time.Now()
+			if update.GetTimestamp() > 0 {
+				timestamp = time.UnixMilli(update.GetTimestamp())
+			}
+
+			val.(progressFunc)(update.GetMessage(), timestamp)
+		}
+
+		return
+	}
+
+	val, ok := s.responses.Load(msg.GetRequestId())
+	if !ok {
+		log.Printf("extension %s sent a response for unknown request '%s'", s.extension.Id, msg.GetRequestId())
+		return
+	}
+
+	select {
+	case val.(chan M) <- msg:
+	default:
+		log.Printf("extension %s sent more than one response for request '%s'", s.extension.Id, msg.GetRequestId())
+	}
+}
+
+func (s *extensionStream[T, M]) send(msg M) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	return s.stream.Send(msg)
+}
+
+// close fails the pending and future requests, once the stream has ended
+func (s *extensionStream[T, M]) close() {
+	close(s.closed)
+}
+
+// extensionStreamBroker serves the provider streams of extensions for a capability. It registers the providers
+// requested by the extension, marks the capability of the extension ready once the extension signals readiness,
+// dispatches the responses of the extension to the requests of azd, and unregisters the providers once the stream ends.
+type extensionStreamBroker[T any, M extensionMessage[T]] struct {
+	extensionManager *extensions.Manager
+	capability       extensions.CapabilityType
+	// Kind of the providers of the capability, e.g. "service target"
+	kind string
+
+	// isRegisterRequest reports whether the message requests the registration of a provider
+	isRegisterRequest func(msg M) bool
+	// register registers the provider requested by the message. It returns the response to the request,
+	// and unregister which removes the provider once registered.
+	register func(stream *extensionStream[T, M], msg M) (response M, unregister func(), err error)
+}
+
+// serve handles the provider stream of an extension until the stream ends
+func (b *extensionStreamBroker[T, M]) serve(stream grpc.BidiStreamingServer[T, T]) error {
+	ctx := stream.Context()
+	extensionClaims, err := GetExtensionClaims(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get extension claims: %w", err)
+	}
+
+	options := extensions.LookupOptions{
+		Id: extensionClaims.Subject,
+	}
+
+	extension, err := b.extensionManager.GetInstalled(options)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "failed to get extension: %s", err.Error())
+	}
+
+	if !extension.HasCapability(b.capability) {
+		return status.Errorf(codes.PermissionDenied, "extension does not support the '%s' capability", b.capability)
+	}
+
+	// All the providers registered on the stream send their requests over the stream
+	extensionStream := newExtensionStream[T, M](extension, stream, b.kind)
+	unregisterFuncs := []func(){}
+	defer func() {
+		// The providers can't handle requests once the stream has ended
+		for _, unregister := range unregisterFuncs {
+			unregister()
+		}
+
+		extensionStream.close()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("Context cancelled by caller, exiting %s stream", b.kind)
+			return nil
+		default:
+			var msg M
+			msg, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				log.Println("Stream closed by server")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case b.isRegisterRequest(msg):
+				unregister, err := b.handleRegister(extension, extensionStream, msg)
+				if err != nil {
+					log.Println(err.Error())
+					continue
+				}
+
+				unregisterFuncs = append(unregisterFuncs, unregister)
+			case msg.IsReady():
+				// Sent by the extension once all its providers are registered
+				extension.MarkReady(b.capability)
+			default:
+				extensionStream.handleMessage(msg)
+			}
+		}
+	}
+}
+
+// handleRegister registers the provider requested by the extension, and sends the response back to the extension
+func (b *extensionStreamBroker[T, M]) handleRegister(
+	extension *extensions.Extension,
+	extensionStream *extensionStream[T, M],
+	msg M,
+) (func(), error) {
+	response, unregister, err := b.register(extensionStream, msg)
+	response.SetRequestId(msg.GetRequestId())
+	if err != nil {
+		err = fmt.Errorf("extension %s failed to register %s: %w", extension.Id, b.kind, err)
+		response.SetErrorMessage(err.Error())
+	}
+
+	if sendErr := extensionStream.send(response); sendErr != nil {
+		if err == nil {
+			unregister()
+		}
+
+		return nil, errors.Join(err, sendErr)
+	}
+
+	return unregister, err
+}
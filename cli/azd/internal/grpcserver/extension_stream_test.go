@@ -0,0 +1,228 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// providerTestServer serves the service target, framework service and provisioning streams to the test extensions:
+// "test.provider" which provides all the kinds of providers, and "test.commands" which provides none.
+type providerTestServer struct {
+	mockContext           *mocks.MockContext
+	extensionManager      *extensions.Manager
+	serviceTargets        *project.ExternalServiceTargetRegistry
+	frameworkServices     *project.ExternalFrameworkServiceRegistry
+	provisioningProviders *provisioning.ExternalProviderRegistry
+	serverInfo            *ServerInfo
+}
+
+func newProviderTestServer(t *testing.T) *providerTestServer {
+	mockContext := mocks.NewMockContext(context.Background())
+	userConfig := config.NewEmptyConfig()
+	err := userConfig.Set("extension.installed", map[string]any{
+		"test.provider": map[string]any{
+			"id": "test.provider",
+			"capabilities": []string{
+				string(extensions.ServiceTargetProviderCapability),
+				string(extensions.FrameworkServiceProviderCapability),
+				string(extensions.ProvisioningProviderCapability),
+			},
+		},
+		"test.commands": map[string]any{
+			"id":           "test.commands",
+			"capabilities": []string{string(extensions.CustomCommandCapability)},
+		},
+	})
+	require.NoError(t, err)
+	mockContext.ConfigManager.WithConfig(userConfig)
+
+	userConfigManager := config.NewUserConfigManager(mockContext.ConfigManager)
+	extensionManager, err := extensions.NewManager(userConfigManager, nil, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	s := &providerTestServer{
+		mockContext:           mockContext,
+		extensionManager:      extensionManager,
+		serviceTargets:        project.NewExternalServiceTargetRegistry(),
+		frameworkServices:     project.NewExternalFrameworkServiceRegistry(),
+		provisioningProviders: provisioning.NewExternalProviderRegistry(),
+	}
+
+	lazyEnv := lazy.From(environment.NewWithValues("test", map[string]string{}))
+	server := NewServer(
+		azdext.UnimplementedProjectServiceServer{},
+		azdext.UnimplementedEnvironmentServiceServer{},
+		azdext.UnimplementedPromptServiceServer{},
+		azdext.UnimplementedUserConfigServiceServer{},
+		azdext.UnimplementedDeploymentServiceServer{},
+		azdext.UnimplementedEventServiceServer{},
+		NewServiceTargetService(extensionManager, s.serviceTargets, lazyEnv),
+		NewFrameworkService(extensionManager, s.frameworkServices, lazyEnv),
+		NewProvisioningService(extensionManager, s.provisioningProviders, mockContext.Console),
+	)
+
+	s.serverInfo, err = server.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop())
+	})
+
+	return s
+}
+
+// connect connects a client of the extension to the server, closed at the end of the test
+func (s *providerTestServer) connect(t *testing.T, extensionId string) (context.Context, *azdext.AzdClient) {
+	accessToken, err := GenerateExtensionToken(s.extension(t, extensionId), s.serverInfo)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(azdext.WithAccessToken(*s.mockContext.Context, accessToken))
+	t.Cleanup(cancel)
+
+	client, err := azdext.NewAzdClient(azdext.WithAddress(s.serverInfo.Address))
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	return ctx, client
+}
+
+func (s *providerTestServer) extension(t *testing.T, extensionId string) *extensions.Extension {
+	extension, err := s.extensionManager.GetInstalled(extensions.LookupOptions{Id: extensionId})
+	require.NoError(t, err)
+
+	return extension
+}
+
+// The behavior of the provider streams is shared by the service target, framework service and provisioning streams,
+// and tested with the service target stream.
+func Test_ExtensionStream(t *testing.T) {
+	server := newProviderTestServer(t)
+
+	connect := func(t *testing.T, extensionId string) (context.Context, *azdext.ServiceTargetManager) {
+		ctx, client := server.connect(t, extensionId)
+		manager := azdext.NewServiceTargetManager(client)
+		t.Cleanup(func() { _ = manager.Close() })
+
+		return ctx, manager
+	}
+
+	t.Run("ReadyOnceAllProvidersRegistered", func(t *testing.T) {
+		ctx, client := server.connect(t, "test.provider")
+
+		serviceTargetManager := azdext.NewServiceTargetManager(client)
+		t.Cleanup(func() { _ = serviceTargetManager.Close() })
+		require.NoError(t, serviceTargetManager.Register(ctx, "azure.batch", &fakeServiceTargetProvider{}))
+		require.NoError(t, serviceTargetManager.Register(ctx, "azure.vmss", &fakeServiceTargetProvider{}))
+
+		extension := server.extension(t, "test.provider")
+		notReady := func() {
+			waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+			require.ErrorIs(t, extension.WaitUntilReady(waitCtx), context.DeadlineExceeded)
+		}
+
+		// The extension isn't ready until it signals that all its service targets are registered
+		notReady()
+		go func() {
+			_ = serviceTargetManager.Receive(ctx)
+		}()
+
+		// nor until its framework services and provisioning providers are registered
+		notReady()
+
+		frameworkServiceManager := azdext.NewFrameworkServiceManager(client)
+		t.Cleanup(func() { _ = frameworkServiceManager.Close() })
+		go func() {
+			_ = frameworkServiceManager.Receive(ctx)
+		}()
+
+		provisioningManager := azdext.NewProvisioningManager(client)
+		t.Cleanup(func() { _ = provisioningManager.Close() })
+		go func() {
+			_ = provisioningManager.Receive(ctx)
+		}()
+
+		require.NoError(t, extension.WaitUntilReady(ctx))
+
+		for _, host := range []project.ServiceTargetKind{"azure.batch", "azure.vmss"} {
+			_, has := server.serviceTargets.Get(host)
+			require.True(t, has)
+		}
+	})
+
+	t.Run("AlreadyRegistered", func(t *testing.T) {
+		ctx, manager := connect(t, "test.provider")
+		require.NoError(t, manager.Register(ctx, "azure.webjobs", &fakeServiceTargetProvider{}))
+
+		ctx, manager = connect(t, "test.provider")
+		err := manager.Register(ctx, "azure.webjobs", &fakeServiceTargetProvider{})
+		require.ErrorContains(t, err, "host 'azure.webjobs' has already been registered")
+	})
+
+	t.Run("UnregisteredOnClose", func(t *testing.T) {
+		ctx, manager := connect(t, "test.provider")
+		require.NoError(t, manager.Register(ctx, "azure.spot", &fakeServiceTargetProvider{}))
+
+		_, has := server.serviceTargets.Get("azure.spot")
+		require.True(t, has)
+
+		require.NoError(t, manager.Close())
+		require.Eventually(t, func() bool {
+			_, has := server.serviceTargets.Get("azure.spot")
+			return !has
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("RequestFailed", func(t *testing.T) {
+		ctx, manager := connect(t, "test.provider")
+
+		err := manager.Register(ctx, "azure.cloudservice", &fakeServiceTargetProvider{deployErr: errors.New("no capacity")})
+		require.NoError(t, err)
+
+		go func() {
+			_ = manager.Receive(ctx)
+		}()
+
+		serviceTarget, has := server.serviceTargets.Get("azure.cloudservice")
+		require.True(t, has)
+
+		_, err = async.RunWithProgress(
+			func(project.ServiceProgress) {},
+			func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceDeployResult, error) {
+				return serviceTarget.Deploy(
+					ctx,
+					&project.ServiceConfig{Name: "api", Host: "azure.cloudservice"},
+					&project.ServicePackageResult{},
+					nil,
+					progress,
+				)
+			},
+		)
+		require.ErrorContains(t, err, "extension test.provider service target failed: no capacity")
+	})
+
+	t.Run("MissingCapability", func(t *testing.T) {
+		ctx, manager := connect(t, "test.commands")
+
+		err := manager.Register(ctx, "azure.functions.flex", &fakeServiceTargetProvider{})
+		require.ErrorContains(t, err, "extension does not support the 'service-target-provider' capability")
+
+		_, has := server.serviceTargets.Get("azure.functions.flex")
+		require.False(t, has)
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"log"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"google.golang.org/grpc"
+)
+
+type frameworkServiceStream = extensionStream[azdext.FrameworkServiceMessage, *azdext.FrameworkServiceMessage]
+
+// frameworkService implements azdext.FrameworkServiceServer.
+type frameworkService struct {
+	azdext.UnimplementedFrameworkServiceServer
+	broker             *extensionStreamBroker[azdext.FrameworkServiceMessage, *azdext.FrameworkServiceMessage]
+	externalFrameworks *project.ExternalFrameworkServiceRegistry
+	lazyEnv            *lazy.Lazy[*environment.Environment]
+}
+
+func NewFrameworkService(
+	extensionManager *extensions.Manager,
+	externalFrameworks *project.ExternalFrameworkServiceRegistry,
+	lazyEnv *lazy.Lazy[*environment.Environment],
+) azdext.FrameworkServiceServer {
+	s := &frameworkService{
+		externalFrameworks: externalFrameworks,
+		lazyEnv:            lazyEnv,
+	}
+	s.broker = &extensionStreamBroker[azdext.FrameworkServiceMessage, *azdext.FrameworkServiceMessage]{
+		extensionManager: extensionManager,
+		capability:       extensions.FrameworkServiceProviderCapability,
+		kind:             "framework service",
+		isRegisterRequest: func(msg *azdext.FrameworkServiceMessage) bool {
+			return msg.GetRegisterFrameworkServiceRequest() != nil
+		},
+		register: s.register,
+	}
+
+	return s
+}
+
+// Stream handles bidirectional streaming.
+func (s *frameworkService) Stream(
+	stream grpc.BidiStreamingServer[azdext.FrameworkServiceMessage, azdext.FrameworkServiceMessage],
+) error {
+	return s.broker.serve(stream)
+}
+
+// register registers the framework service of the extension for the requested language
+func (s *frameworkService) register(
+	stream *frameworkServiceStream,
+	msg *azdext.FrameworkServiceMessage,
+) (*azdext.FrameworkServiceMessage, func(), error) {
+	request := msg.GetRegisterFrameworkServiceRequest()
+	language := project.ServiceLanguageKind(request.Language)
+
+	response := &azdext.FrameworkServiceMessage{
+		MessageType: &azdext.FrameworkServiceMessage_RegisterFrameworkServiceResponse{
+			RegisterFrameworkServiceResponse: &azdext.RegisterFrameworkServiceResponse{},
+		},
+	}
+
+	frameworkService := newExtensionFrameworkService(stream, s.lazyEnv, request.Requirements)
+	if err := s.externalFrameworks.Register(language, frameworkService); err != nil {
+		return response, nil, err
+	}
+
+	log.Printf("extension %s registered framework service for language '%s'", stream.extension.Id, language)
+
+	return response, func() { s.externalFrameworks.Unregister(language, frameworkService) }, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFrameworkServiceProvider is the framework service provider of the test extension
+type fakeFrameworkServiceProvider struct{}
+
+func (p *fakeFrameworkServiceProvider) Requirements() *azdext.FrameworkRequirements {
+	return &azdext.FrameworkRequirements{
+		RequireBuild: true,
+	}
+}
+
+func (p *fakeFrameworkServiceProvider) Restore(
+	ctx context.Context,
+	service *azdext.ServiceConfig,
+	progress azdext.ProgressReporter,
+) (*azdext.ServiceRestoreResult, error) {
+	progress("fetching crates")
+
+	return &azdext.ServiceRestoreResult{}, nil
+}
+
+func (p *fakeFrameworkServiceProvider) Build(
+	ctx context.Context,
+	service *azdext.ServiceConfig,
+	restore *azdext.ServiceRestoreResult,
+	progress azdext.ProgressReporter,
+) (*azdext.ServiceBuildResult, error) {
+	progress("compiling " + service.Name)
+
+	return &azdext.ServiceBuildResult{
+		BuildOutputPath: "target/release",
+		Details:         map[string]string{"profile": "release"},
+	}, nil
+}
+
+func (p *fakeFrameworkServiceProvider) Package(
+	ctx context.Context,
+	service *azdext.ServiceConfig,
+	build *azdext.ServiceBuildResult,
+	progress azdext.ProgressReporter,
+) (*azdext.ServicePackageResult, error) {
+	progress("packaging " + build.BuildOutputPath)
+
+	return &azdext.ServicePackageResult{
+		PackagePath: build.BuildOutputPath + "/" + service.Name + ".zip",
+	}, nil
+}
+
+func Test_FrameworkService_Stream(t *testing.T) {
+	server := newProviderTestServer(t)
+
+	connect := func(t *testing.T) (context.Context, *azdext.FrameworkServiceManager) {
+		ctx, client := server.connect(t, "test.provider")
+		manager := azdext.NewFrameworkServiceManager(client)
+		t.Cleanup(func() { _ = manager.Close() })
+
+		return ctx, manager
+	}
+
+	t.Run("RegisterAndPackage", func(t *testing.T) {
+		ctx, manager := connect(t)
+
+		err := manager.Register(ctx, "rust", &fakeFrameworkServiceProvider{})
+		require.NoError(t, err)
+
+		go func() {
+			_ = manager.Receive(ctx)
+		}()
+
+		frameworkService, has := server.frameworkServices.Get("rust")
+		require.True(t, has)
+		require.False(t, frameworkService.Requirements().Package.RequireRestore)
+		require.True(t, frameworkService.Requirements().Package.RequireBuild)
+
+		serviceConfig := &project.ServiceConfig{
+			Name:     "api",
+			Language: project.ServiceLanguageKind("rust"),
+		}
+
+		var progressMessages []string
+		reportProgress := func(progress project.ServiceProgress) {
+			progressMessages = append(progressMessages, progress.Message)
+		}
+
+		restoreResult, err := async.RunWithProgress(
+			reportProgress,
+			func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceRestoreResult, error) {
+				return frameworkService.Restore(ctx, serviceConfig, progress)
+			},
+		)
+		require.NoError(t, err)
+
+		buildResult, err := async.RunWithProgress(
+			reportProgress,
+			func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceBuildResult, error) {
+				return frameworkService.Build(ctx, serviceConfig, restoreResult, progress)
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, "target/release", buildResult.BuildOutputPath)
+		require.Equal(t, map[string]string{"profile": "release"}, buildResult.Details)
+		require.Same(t, restoreResult, buildResult.Restore)
+
+		packageResult, err := async.RunWithProgress(
+			reportProgress,
+			func(progress *async.Progress[project.ServiceProgress]) (*project.ServicePackageResult, error) {
+				return frameworkService.Package(ctx, serviceConfig, buildResult, progress)
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, "target/release/api.zip", packageResult.PackagePath)
+		require.Same(t, buildResult, packageResult.Build)
+
+		require.Equal(t, []string{"fetching crates", "compiling api", "packaging target/release"}, progressMessages)
+	})
+
+	t.Run("BuiltInLanguage", func(t *testing.T) {
+		ctx, manager := connect(t)
+
+		err := manager.Register(ctx, string(project.ServiceLanguagePython), &fakeFrameworkServiceProvider{})
+		require.ErrorContains(t, err, "language 'python' is provided by azd")
+	})
+}
@@ -56,7 +56,7 @@ func Test_ProjectService_Flow(t *testing.T) {
 
 	// Configure and initialize environment manager.
 	fileConfigManager := config.NewFileConfigManager(config.NewManager())
-	localDataStore := environment.NewLocalFileDataStore(azdContext, fileConfigManager)
+	localDataStore := environment.NewLocalFileDataStore(azdContext, fileConfigManager, nil)
 	envManager, err := environment.NewManager(mockContext.Container, azdContext, mockContext.Console, localDataStore, nil)
 	require.NoError(t, err)
 	require.NotNil(t, envManager)
@@ -0,0 +1,77 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"log"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"google.golang.org/grpc"
+)
+
+type provisioningStream = extensionStream[azdext.ProvisioningMessage, *azdext.ProvisioningMessage]
+
+// provisioningService implements azdext.ProvisioningServiceServer.
+type provisioningService struct {
+	azdext.UnimplementedProvisioningServiceServer
+	broker            *extensionStreamBroker[azdext.ProvisioningMessage, *azdext.ProvisioningMessage]
+	externalProviders *provisioning.ExternalProviderRegistry
+	console           input.Console
+}
+
+func NewProvisioningService(
+	extensionManager *extensions.Manager,
+	externalProviders *provisioning.ExternalProviderRegistry,
+	console input.Console,
+) azdext.ProvisioningServiceServer {
+	s := &provisioningService{
+		externalProviders: externalProviders,
+		console:           console,
+	}
+	s.broker = &extensionStreamBroker[azdext.ProvisioningMessage, *azdext.ProvisioningMessage]{
+		extensionManager: extensionManager,
+		capability:       extensions.ProvisioningProviderCapability,
+		kind:             "provisioning provider",
+		isRegisterRequest: func(msg *azdext.ProvisioningMessage) bool {
+			return msg.GetRegisterProvisioningProviderRequest() != nil
+		},
+		register: s.register,
+	}
+
+	return s
+}
+
+// Stream handles bidirectional streaming.
+func (s *provisioningService) Stream(
+	stream grpc.BidiStreamingServer[azdext.ProvisioningMessage, azdext.ProvisioningMessage],
+) error {
+	return s.broker.serve(stream)
+}
+
+// register registers the provisioning provider of the extension for the requested provider name
+func (s *provisioningService) register(
+	stream *provisioningStream,
+	msg *azdext.ProvisioningMessage,
+) (*azdext.ProvisioningMessage, func(), error) {
+	name := msg.GetRegisterProvisioningProviderRequest().Name
+
+	response := &azdext.ProvisioningMessage{
+		Provider: name,
+		MessageType: &azdext.ProvisioningMessage_RegisterProvisioningProviderResponse{
+			RegisterProvisioningProviderResponse: &azdext.RegisterProvisioningProviderResponse{},
+		},
+	}
+
+	provider := newExtensionProvisioningProvider(stream, s.console, name)
+	if err := s.externalProviders.Register(provisioning.ProviderKind(name), provider); err != nil {
+		return response, nil, err
+	}
+
+	log.Printf("extension %s registered provisioning provider '%s'", stream.extension.Id, name)
+
+	return response, func() { s.externalProviders.Unregister(provisioning.ProviderKind(name), provider) }, nil
+}
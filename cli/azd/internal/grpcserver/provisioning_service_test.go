@@ -0,0 +1,160 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockinput"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvisioningProvider is the provisioning provider of the test extension
+type fakeProvisioningProvider struct {
+	options *azdext.InfraOptions
+}
+
+func (p *fakeProvisioningProvider) Initialize(ctx context.Context, projectPath string, options *azdext.InfraOptions) error {
+	p.options = options
+	return nil
+}
+
+func (p *fakeProvisioningProvider) State(ctx context.Context, hint string) (*azdext.ProvisioningState, error) {
+	return &azdext.ProvisioningState{
+		Outputs: map[string]*azdext.ProvisioningOutputParameter{
+			"WEBSITE_URL": {Type: "string", Value: "https://example.com"},
+		},
+		Resources: []string{"/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-test"},
+	}, nil
+}
+
+func (p *fakeProvisioningProvider) Deploy(
+	ctx context.Context,
+	progress azdext.ProgressReporter,
+) (*azdext.ProvisioningDeployResult, error) {
+	progress("applying plan")
+
+	return &azdext.ProvisioningDeployResult{
+		Outputs: map[string]*azdext.ProvisioningOutputParameter{
+			"WEBSITE_URL":   {Type: "string", Value: "https://example.com"},
+			"REPLICA_COUNT": {Type: "number", Value: "3"},
+			"REGIONS":       {Type: "array", Value: `["eastus2","westus3"]`},
+			"DB_PASSWORD":   {Type: "string", Value: "secret", Secure: true},
+		},
+	}, nil
+}
+
+func (p *fakeProvisioningProvider) Preview(
+	ctx context.Context,
+	progress azdext.ProgressReporter,
+) (*azdext.ProvisioningDeploymentPreview, error) {
+	return &azdext.ProvisioningDeploymentPreview{
+		Status: "done",
+		Changes: []*azdext.ProvisioningDeploymentPreviewChange{
+			{ChangeType: "Create", ResourceId: "rg-test", ResourceType: "Microsoft.Resources/resourceGroups"},
+		},
+	}, nil
+}
+
+func (p *fakeProvisioningProvider) Destroy(
+	ctx context.Context,
+	force bool,
+	purge bool,
+	progress azdext.ProgressReporter,
+) ([]string, error) {
+	return []string{"WEBSITE_URL"}, nil
+}
+
+func (p *fakeProvisioningProvider) EnsureEnv(ctx context.Context) error {
+	return nil
+}
+
+func Test_ProvisioningService_Stream(t *testing.T) {
+	server := newProviderTestServer(t)
+
+	connect := func(t *testing.T) (context.Context, *azdext.ProvisioningManager) {
+		ctx, client := server.connect(t, "test.provider")
+		manager := azdext.NewProvisioningManager(client)
+		t.Cleanup(func() { _ = manager.Close() })
+
+		return ctx, manager
+	}
+
+	t.Run("RegisterAndDeploy", func(t *testing.T) {
+		ctx, manager := connect(t)
+
+		fakeProvider := &fakeProvisioningProvider{}
+		err := manager.Register(ctx, "opentofu", fakeProvider)
+		require.NoError(t, err)
+
+		go func() {
+			_ = manager.Receive(ctx)
+		}()
+
+		provider, has := server.provisioningProviders.Get("opentofu")
+		require.True(t, has)
+		require.Equal(t, "opentofu", provider.Name())
+
+		err = provider.Initialize(ctx, "/project", provisioning.Options{Provider: "opentofu", Path: "infra"})
+		require.NoError(t, err)
+		require.Equal(t, "opentofu", fakeProvider.options.Provider)
+		require.Equal(t, "infra", fakeProvider.options.Path)
+
+		deployResult, err := provider.Deploy(ctx)
+		require.NoError(t, err)
+		require.Equal(t, map[string]provisioning.OutputParameter{
+			"WEBSITE_URL":   {Type: provisioning.ParameterTypeString, Value: "https://example.com"},
+			"REPLICA_COUNT": {Type: provisioning.ParameterTypeNumber, Value: float64(3)},
+			"REGIONS":       {Type: provisioning.ParameterTypeArray, Value: []any{"eastus2", "westus3"}},
+			"DB_PASSWORD":   {Type: provisioning.ParameterTypeString, Value: "secret", Secure: true},
+		}, deployResult.Deployment.Outputs)
+
+		spinnerOps := server.mockContext.Console.SpinnerOps()
+		require.Contains(t, spinnerOps, mockinput.SpinnerOp{
+			Op:      mockinput.SpinnerOpShow,
+			Message: "applying plan",
+			Format:  input.Step,
+		})
+
+		stateResult, err := provider.State(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, stateResult.State.Resources, 1)
+		require.Equal(t, "https://example.com", stateResult.State.Outputs["WEBSITE_URL"].Value)
+
+		previewResult, err := provider.Preview(ctx)
+		require.NoError(t, err)
+		require.Len(t, previewResult.Preview.Properties.Changes, 1)
+		require.Equal(t, provisioning.ChangeTypeCreate, previewResult.Preview.Properties.Changes[0].ChangeType)
+
+		destroyResult, err := provider.Destroy(ctx, provisioning.NewDestroyOptions(true, false))
+		require.NoError(t, err)
+		require.Equal(t, []string{"WEBSITE_URL"}, destroyResult.InvalidatedEnvKeys)
+
+		require.NoError(t, provider.EnsureEnv(ctx))
+	})
+
+	t.Run("BuiltInProvider", func(t *testing.T) {
+		ctx, manager := connect(t)
+
+		err := manager.Register(ctx, string(provisioning.Bicep), &fakeProvisioningProvider{})
+		require.ErrorContains(t, err, "provider 'bicep' is provided by azd")
+	})
+
+}
+
+func Test_createOutputParameters_InvalidType(t *testing.T) {
+	_, err := createOutputParameters(map[string]*azdext.ProvisioningOutputParameter{
+		"COUNT": {Type: "int", Value: "3"},
+	})
+	require.ErrorContains(t, err, "unsupported type 'int' for output parameter 'COUNT'")
+
+	_, err = createOutputParameters(map[string]*azdext.ProvisioningOutputParameter{
+		"TAGS": {Type: "object", Value: "not json"},
+	})
+	require.ErrorContains(t, err, "invalid value for output parameter 'TAGS' (object)")
+}
@@ -24,13 +24,16 @@ type ServerInfo struct {
 }
 
 type Server struct {
-	grpcServer         *grpc.Server
-	projectService     azdext.ProjectServiceServer
-	environmentService azdext.EnvironmentServiceServer
-	promptService      azdext.PromptServiceServer
-	userConfigService  azdext.UserConfigServiceServer
-	deploymentService  azdext.DeploymentServiceServer
-	eventService       azdext.EventServiceServer
+	grpcServer           *grpc.Server
+	projectService       azdext.ProjectServiceServer
+	environmentService   azdext.EnvironmentServiceServer
+	promptService        azdext.PromptServiceServer
+	userConfigService    azdext.UserConfigServiceServer
+	deploymentService    azdext.DeploymentServiceServer
+	eventService         azdext.EventServiceServer
+	serviceTargetService azdext.ServiceTargetServiceServer
+	frameworkService     azdext.FrameworkServiceServer
+	provisioningService  azdext.ProvisioningServiceServer
 }
 
 func NewServer(
@@ -40,14 +43,20 @@ func NewServer(
 	userConfigService azdext.UserConfigServiceServer,
 	deploymentService azdext.DeploymentServiceServer,
 	eventService azdext.EventServiceServer,
+	serviceTargetService azdext.ServiceTargetServiceServer,
+	frameworkService azdext.FrameworkServiceServer,
+	provisioningService azdext.ProvisioningServiceServer,
 ) *Server {
 	return &Server{
-		projectService:     projectService,
-		environmentService: environmentService,
-		promptService:      promptService,
-		userConfigService:  userConfigService,
-		deploymentService:  deploymentService,
-		eventService:       eventService,
+		projectService:       projectService,
+		environmentService:   environmentService,
+		promptService:        promptService,
+		userConfigService:    userConfigService,
+		deploymentService:    deploymentService,
+		eventService:         eventService,
+		serviceTargetService: serviceTargetService,
+		frameworkService:     frameworkService,
+		provisioningService:  provisioningService,
 	}
 }
 
@@ -79,6 +88,9 @@ func (s *Server) Start() (*ServerInfo, error) {
 	azdext.RegisterUserConfigServiceServer(s.grpcServer, s.userConfigService)
 	azdext.RegisterDeploymentServiceServer(s.grpcServer, s.deploymentService)
 	azdext.RegisterEventServiceServer(s.grpcServer, s.eventService)
+	azdext.RegisterServiceTargetServiceServer(s.grpcServer, s.serviceTargetService)
+	azdext.RegisterFrameworkServiceServer(s.grpcServer, s.frameworkService)
+	azdext.RegisterProvisioningServiceServer(s.grpcServer, s.provisioningService)
 
 	serverInfo.Address = fmt.Sprintf("localhost:%d", randomPort)
 	serverInfo.Port = randomPort
@@ -0,0 +1,77 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"log"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"google.golang.org/grpc"
+)
+
+type serviceTargetStream = extensionStream[azdext.ServiceTargetMessage, *azdext.ServiceTargetMessage]
+
+// serviceTargetService implements azdext.ServiceTargetServiceServer.
+type serviceTargetService struct {
+	azdext.UnimplementedServiceTargetServiceServer
+	broker                 *extensionStreamBroker[azdext.ServiceTargetMessage, *azdext.ServiceTargetMessage]
+	externalServiceTargets *project.ExternalServiceTargetRegistry
+	lazyEnv                *lazy.Lazy[*environment.Environment]
+}
+
+func NewServiceTargetService(
+	extensionManager *extensions.Manager,
+	externalServiceTargets *project.ExternalServiceTargetRegistry,
+	lazyEnv *lazy.Lazy[*environment.Environment],
+) azdext.ServiceTargetServiceServer {
+	s := &serviceTargetService{
+		externalServiceTargets: externalServiceTargets,
+		lazyEnv:                lazyEnv,
+	}
+	s.broker = &extensionStreamBroker[azdext.ServiceTargetMessage, *azdext.ServiceTargetMessage]{
+		extensionManager: extensionManager,
+		capability:       extensions.ServiceTargetProviderCapability,
+		kind:             "service target",
+		isRegisterRequest: func(msg *azdext.ServiceTargetMessage) bool {
+			return msg.GetRegisterServiceTargetRequest() != nil
+		},
+		register: s.register,
+	}
+
+	return s
+}
+
+// Stream handles bidirectional streaming.
+func (s *serviceTargetService) Stream(
+	stream grpc.BidiStreamingServer[azdext.ServiceTargetMessage, azdext.ServiceTargetMessage],
+) error {
+	return s.broker.serve(stream)
+}
+
+// register registers the service target of the extension for the requested host
+func (s *serviceTargetService) register(
+	stream *serviceTargetStream,
+	msg *azdext.ServiceTargetMessage,
+) (*azdext.ServiceTargetMessage, func(), error) {
+	host := project.ServiceTargetKind(msg.GetRegisterServiceTargetRequest().Host)
+
+	response := &azdext.ServiceTargetMessage{
+		MessageType: &azdext.ServiceTargetMessage_RegisterServiceTargetResponse{
+			RegisterServiceTargetResponse: &azdext.RegisterServiceTargetResponse{},
+		},
+	}
+
+	serviceTarget := newExtensionServiceTarget(stream, s.lazyEnv)
+	if err := s.externalServiceTargets.Register(host, serviceTarget); err != nil {
+		return response, nil, err
+	}
+
+	log.Printf("extension %s registered service target for host '%s'", stream.extension.Id, host)
+
+	return response, func() { s.externalServiceTargets.Unregister(host, serviceTarget) }, nil
+}
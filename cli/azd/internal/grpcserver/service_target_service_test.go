@@ -0,0 +1,138 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServiceTargetProvider is the service target provider of the test extension
+type fakeServiceTargetProvider struct {
+	deployErr error
+}
+
+func (p *fakeServiceTargetProvider) Package(
+	ctx context.Context,
+	service *azdext.ServiceConfig,
+	frameworkPackage *azdext.ServicePackageResult,
+	progress azdext.ProgressReporter,
+) (*azdext.ServicePackageResult, error) {
+	progress("packaging")
+
+	return &azdext.ServicePackageResult{
+		PackagePath: frameworkPackage.PackagePath + ".zip",
+	}, nil
+}
+
+func (p *fakeServiceTargetProvider) Deploy(
+	ctx context.Context,
+	service *azdext.ServiceConfig,
+	servicePackage *azdext.ServicePackageResult,
+	targetResource *azdext.TargetResource,
+	progress azdext.ProgressReporter,
+) (*azdext.ServiceDeployResult, error) {
+	if p.deployErr != nil {
+		return nil, p.deployErr
+	}
+
+	progress("uploading " + servicePackage.PackagePath)
+	progress("deploying " + service.Name)
+
+	return &azdext.ServiceDeployResult{
+		TargetResourceId: "batch://" + targetResource.SubscriptionId + "/" + service.Name,
+		Endpoints:        []string{"https://" + service.Name + ".example.com"},
+		Details:          map[string]string{"pool": "default"},
+	}, nil
+}
+
+func (p *fakeServiceTargetProvider) Endpoints(
+	ctx context.Context,
+	service *azdext.ServiceConfig,
+	targetResource *azdext.TargetResource,
+) ([]string, error) {
+	return []string{"https://" + service.Name + ".example.com"}, nil
+}
+
+func Test_ServiceTargetService_Stream(t *testing.T) {
+	server := newProviderTestServer(t)
+
+	connect := func(t *testing.T) (context.Context, *azdext.ServiceTargetManager) {
+		ctx, client := server.connect(t, "test.provider")
+		manager := azdext.NewServiceTargetManager(client)
+		t.Cleanup(func() { _ = manager.Close() })
+
+		return ctx, manager
+	}
+
+	serviceConfig := &project.ServiceConfig{
+		Name: "worker",
+		Host: project.ServiceTargetKind("azure.batch"),
+	}
+
+	t.Run("RegisterAndDeploy", func(t *testing.T) {
+		ctx, manager := connect(t)
+
+		err := manager.Register(ctx, "azure.batch", &fakeServiceTargetProvider{})
+		require.NoError(t, err)
+
+		go func() {
+			_ = manager.Receive(ctx)
+		}()
+
+		serviceTarget, has := server.serviceTargets.Get("azure.batch")
+		require.True(t, has)
+
+		var progressMessages []string
+		packageResult, err := async.RunWithProgress(
+			func(progress project.ServiceProgress) {
+				progressMessages = append(progressMessages, progress.Message)
+			},
+			func(progress *async.Progress[project.ServiceProgress]) (*project.ServicePackageResult, error) {
+				return serviceTarget.Package(
+					ctx, serviceConfig, &project.ServicePackageResult{PackagePath: "worker"}, progress)
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, "worker.zip", packageResult.PackagePath)
+
+		deployResult, err := async.RunWithProgress(
+			func(progress project.ServiceProgress) {
+				progressMessages = append(progressMessages, progress.Message)
+			},
+			func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceDeployResult, error) {
+				return serviceTarget.Deploy(
+					ctx,
+					serviceConfig,
+					packageResult,
+					environment.NewTargetResource("SUBSCRIPTION_ID", "", "", ""),
+					progress,
+				)
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, "batch://SUBSCRIPTION_ID/worker", deployResult.TargetResourceId)
+		require.Equal(t, project.ServiceTargetKind("azure.batch"), deployResult.Kind)
+		require.Equal(t, []string{"https://worker.example.com"}, deployResult.Endpoints)
+		require.Equal(t, map[string]string{"pool": "default"}, deployResult.Details)
+		require.Equal(t, []string{"packaging", "uploading worker.zip", "deploying worker"}, progressMessages)
+
+		endpoints, err := serviceTarget.Endpoints(ctx, serviceConfig, nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{"https://worker.example.com"}, endpoints)
+	})
+
+	t.Run("BuiltInHost", func(t *testing.T) {
+		ctx, manager := connect(t)
+
+		err := manager.Register(ctx, string(project.ContainerAppTarget), &fakeServiceTargetProvider{})
+		require.ErrorContains(t, err, "host 'containerapp' is provided by azd")
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package names
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var kindRegex = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*$`)
+
+// ValidateExtensionKind checks that a kind azd doesn't provide, such as a service host, language or IaC provider
+// provided by an extension, is a valid name that isn't a misspelling of one of the kinds provided by azd.
+func ValidateExtensionKind[K ~string](kind K, builtInKinds []K) error {
+	if kind == "" {
+		return errors.New("name cannot be empty")
+	}
+
+	for _, builtInKind := range builtInKinds {
+		if builtInKind != "" && isMisspelling(strings.ToLower(string(kind)), string(builtInKind)) {
+			return fmt.Errorf("did you mean '%s'?", builtInKind)
+		}
+	}
+
+	if !kindRegex.MatchString(string(kind)) {
+		return errors.New(
+			"name must contain only lower-cased alphanumeric characters separated by '.', '-' or '_'")
+	}
+
+	return nil
+}
+
+// isMisspelling returns true when the name is within a few edits of the known name,
+// allowing one edit for every four characters of the known name.
+func isMisspelling(name string, known string) bool {
+	maxEdits := max(1, len(known)/4)
+
+	return editDistance(name, known) <= maxEdits
+}
+
+// editDistance returns the number of insertions, deletions, substitutions and transpositions of adjacent characters
+// needed to turn a into b.
+func editDistance(a string, b string) int {
+	// distances[i][j] is the distance between the first i characters of a and the first j characters of b
+	distances := make([][]int, len(a)+1)
+	for i := range distances {
+		distances[i] = make([]int, len(b)+1)
+		distances[i][0] = i
+	}
+
+	for j := range distances[0] {
+		distances[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			distances[i][j] = min(distances[i-1][j]+1, distances[i][j-1]+1, distances[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				distances[i][j] = min(distances[i][j], distances[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return distances[len(a)][len(b)]
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package names
+
+import (
+	"strings"
+	"testing"
+)
+
+//cspell:disable
+
+func TestValidateExtensionKind(t *testing.T) {
+	builtInKinds := []string{"", "appservice", "containerapp", "aks", "js", "ts", "python", "bicep", "terraform"}
+
+	tests := []struct {
+		name    string
+		kind    string
+		wantErr string
+	}{
+		{"Namespaced", "azure.batch", ""},
+		{"Simple", "rust", ""},
+		{"Hyphenated", "open-tofu", ""},
+		{"Empty", "", "name cannot be empty"},
+		{"Misspelled", "containerap", "did you mean 'containerapp'?"},
+		{"Transposed", "pyhton", "did you mean 'python'?"},
+		{"Cased", "Bicep", "did you mean 'bicep'?"},
+		{"ShortMisspelled", "aws", "did you mean 'aks'?"},
+		{"InvalidCharacters", "azure/batch", "name must contain only lower-cased alphanumeric characters"},
+		{"TrailingSeparator", "azure.", "name must contain only lower-cased alphanumeric characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateExtensionKind(tt.kind, builtInKinds)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateExtensionKind(%q) = %v, want no error", tt.kind, err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateExtensionKind(%q) = %v, want error containing %q", tt.kind, err, tt.wantErr)
+			}
+		})
+	}
+}
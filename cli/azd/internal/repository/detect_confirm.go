@@ -68,7 +68,8 @@ func (d *detectConfirm) Init(projects []appdetect.Project, root string) {
 	d.root = root
 
 	for _, project := range projects {
-		if _, supported := add.LanguageMap[project.Language]; supported {
+		// Library modules are built as part of the services that depend on them
+		if _, supported := add.LanguageMap[project.Language]; supported && !project.Library {
 			d.Services = append(d.Services, project)
 		}
 
@@ -217,6 +218,8 @@ func (d *detectConfirm) render(ctx context.Context) error {
 			recommendedServices = append(recommendedServices, "Azure CosmosDB API for MongoDB")
 		case appdetect.DbRedis:
 			recommendedServices = append(recommendedServices, "Azure Container Apps Redis add-on")
+		case appdetect.DbCosmos:
+			recommendedServices = append(recommendedServices, "Azure Cosmos DB for NoSQL")
 		}
 
 		status := ""
@@ -230,6 +233,14 @@ func (d *detectConfirm) render(ctx context.Context) error {
 		d.console.Message(ctx, "")
 	}
 
+	for _, svc := range d.Services {
+		for _, azureDep := range svc.AzureDeps {
+			if !slices.Contains(recommendedServices, azureDep.ResourceDisplay()) {
+				recommendedServices = append(recommendedServices, azureDep.ResourceDisplay())
+			}
+		}
+	}
+
 	displayedServices := make([]string, 0, len(recommendedServices))
 	for _, svc := range recommendedServices {
 		displayedServices = append(displayedServices, color.MagentaString(svc))
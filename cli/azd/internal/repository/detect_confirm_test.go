@@ -78,6 +78,29 @@ func Test_detectConfirm_confirm(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "skip library modules",
+			detection: []appdetect.Project{
+				{
+					Language: appdetect.DotNet,
+					Path:     dotNetDir,
+				},
+				{
+					Language: appdetect.Java,
+					Path:     javaDir,
+					Library:  true,
+				},
+			},
+			interactions: []string{
+				"Confirm and continue initializing my app",
+			},
+			want: []appdetect.Project{
+				{
+					Language: appdetect.DotNet,
+					Path:     dotNetDir,
+				},
+			},
+		},
 		{
 			name: "add a language",
 			detection: []appdetect.Project{
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/internal/appdetect"
@@ -56,6 +57,15 @@ func (i *Initializer) infraSpecFromDetect(
 				spec.DbPostgres = &scaffold.DatabasePostgres{
 					DatabaseName: dbName,
 				}
+			case appdetect.DbCosmos:
+				if dbName == "" {
+					i.console.Message(ctx, "Database name is required.")
+					continue
+				}
+
+				spec.DbCosmos = &scaffold.DatabaseCosmos{
+					DatabaseName: dbName,
+				}
 			}
 			break dbPrompt
 		}
@@ -99,6 +109,37 @@ func (i *Initializer) infraSpecFromDetect(
 				serviceSpec.DbRedis = &scaffold.DatabaseReference{
 					DatabaseName: "redis",
 				}
+			case appdetect.DbCosmos:
+				serviceSpec.DbCosmos = &scaffold.DatabaseReference{
+					DatabaseName: spec.DbCosmos.DatabaseName,
+				}
+			}
+		}
+
+		for _, azureDep := range svc.AzureDeps {
+			switch azureDep := azureDep.(type) {
+			case appdetect.AzureDepServiceBus:
+				if spec.ServiceBus == nil {
+					spec.ServiceBus = &scaffold.ServiceBus{}
+				}
+				spec.ServiceBus.Queues = appendUnique(spec.ServiceBus.Queues, azureDep.Queues...)
+				spec.ServiceBus.Topics = appendUnique(spec.ServiceBus.Topics, azureDep.Topics...)
+				serviceSpec.ServiceBus = &scaffold.ServiceBus{Queues: azureDep.Queues, Topics: azureDep.Topics}
+			case appdetect.AzureDepEventHubs:
+				if spec.EventHubs == nil {
+					spec.EventHubs = &scaffold.EventHubs{}
+				}
+				spec.EventHubs.Hubs = appendUnique(spec.EventHubs.Hubs, azureDep.Names...)
+				serviceSpec.EventHubs = &scaffold.EventHubs{Hubs: azureDep.Names}
+			case appdetect.AzureDepStorageAccount:
+				if spec.StorageAccount == nil {
+					spec.StorageAccount = &scaffold.StorageAccount{}
+				}
+				spec.StorageAccount.Containers = appendUnique(spec.StorageAccount.Containers, azureDep.ContainerNames...)
+				serviceSpec.StorageAccount = &scaffold.StorageReference{}
+			case appdetect.AzureDepKeyVault:
+				spec.KeyVault = &scaffold.KeyVault{}
+				serviceSpec.KeyVault = &scaffold.KeyVaultReference{}
 			}
 		}
 		spec.Services = append(spec.Services, serviceSpec)
@@ -134,6 +175,17 @@ func (i *Initializer) infraSpecFromDetect(
 	return spec, nil
 }
 
+// appendUnique appends the values that are not already present in slice.
+func appendUnique(slice []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(slice, value) {
+			slice = append(slice, value)
+		}
+	}
+
+	return slice
+}
+
 func promptDbName(console input.Console, ctx context.Context, database appdetect.DatabaseDep) (string, error) {
 	for {
 		dbName, err := console.Prompt(ctx, input.ConsoleOptions{
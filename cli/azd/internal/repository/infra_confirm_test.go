@@ -204,6 +204,56 @@ func TestInitializer_infraSpecFromDetect(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "spring boot with azure services",
+			detect: detectConfirm{
+				Services: []appdetect.Project{
+					{
+						Language:     appdetect.Java,
+						Path:         "java",
+						Dependencies: []appdetect.Dependency{appdetect.JavaSpringBoot},
+						DatabaseDeps: []appdetect.DatabaseDep{appdetect.DbCosmos},
+						Ports:        []appdetect.Port{{Number: 8081, Protocol: "http"}},
+						AzureDeps: []appdetect.AzureDep{
+							appdetect.AzureDepServiceBus{Queues: []string{"orders"}},
+							appdetect.AzureDepEventHubs{Names: []string{"telemetry"}},
+							appdetect.AzureDepStorageAccount{ContainerNames: []string{"uploads"}},
+							appdetect.AzureDepKeyVault{},
+						},
+					},
+				},
+				Databases: map[appdetect.DatabaseDep]EntryKind{
+					appdetect.DbCosmos: EntryKindDetected,
+				},
+			},
+			interactions: []string{
+				"", // db name is required
+				"appdb",
+			},
+			want: scaffold.InfraSpec{
+				DbCosmos: &scaffold.DatabaseCosmos{
+					DatabaseName: "appdb",
+				},
+				ServiceBus:     &scaffold.ServiceBus{Queues: []string{"orders"}},
+				EventHubs:      &scaffold.EventHubs{Hubs: []string{"telemetry"}},
+				StorageAccount: &scaffold.StorageAccount{Containers: []string{"uploads"}},
+				KeyVault:       &scaffold.KeyVault{},
+				Services: []scaffold.ServiceSpec{
+					{
+						Name:    "java",
+						Port:    8081,
+						Backend: &scaffold.Backend{},
+						DbCosmos: &scaffold.DatabaseReference{
+							DatabaseName: "appdb",
+						},
+						ServiceBus:     &scaffold.ServiceBus{Queues: []string{"orders"}},
+						EventHubs:      &scaffold.EventHubs{Hubs: []string{"telemetry"}},
+						StorageAccount: &scaffold.StorageReference{},
+						KeyVault:       &scaffold.KeyVaultReference{},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -63,6 +63,17 @@ var errNoSystemAccessTokenEnvVar = fmt.Errorf(
 	"system access token not found, ensure the System.AccessToken value is mapped to an environment variable named %s",
 	azurePipelinesSystemAccessTokenEnvVarName)
 
+// gitLabIdTokenEnvVarName is the name of the environment variable that contains the OIDC id token issued by GitLab CI.
+// It needs to be declared by the job that runs the azd command in its `id_tokens` section, with the
+// `api://AzureADTokenExchange` audience.
+const gitLabIdTokenEnvVarName = "GITLAB_OIDC_TOKEN"
+
+// errNoGitLabIdTokenEnvVar is returned when the GitLab id token environment variable is not set.
+var errNoGitLabIdTokenEnvVar = fmt.Errorf(
+	"gitlab id token not found, ensure the job declares an id token named %s with the %s audience",
+	gitLabIdTokenEnvVarName,
+	"api://AzureADTokenExchange")
+
 // HttpClient interface as required by MSAL library.
 type HttpClient interface {
 	// Do sends an HTTP request and returns an HTTP response.
@@ -559,6 +570,28 @@ func (m *Manager) newCredentialFromFederatedTokenProvider(
 
 		return cred, nil
 
+	case gitLabFederatedTokenProvider:
+		cred, err := azidentity.NewClientAssertionCredential(
+			tenantID,
+			clientID,
+			func(ctx context.Context) (string, error) {
+				// GitLab injects the id token in the job environment, and the token is valid for the job duration.
+				federatedToken := os.Getenv(gitLabIdTokenEnvVarName)
+				if federatedToken == "" {
+					return "", errNoGitLabIdTokenEnvVar
+				}
+
+				return federatedToken, nil
+			},
+			&azidentity.ClientAssertionCredentialOptions{
+				ClientOptions: clientOptions,
+			})
+		if err != nil {
+			return nil, fmt.Errorf("creating credential: %w", err)
+		}
+
+		return cred, nil
+
 	case azurePipelinesFederatedTokenProvider:
 		systemAccessToken := os.Getenv(azurePipelinesSystemAccessTokenEnvVarName)
 		if systemAccessToken == "" {
@@ -815,6 +848,33 @@ func (m *Manager) LoginWithGitHubFederatedTokenProvider(
 	return cred, nil
 }
 
+func (m *Manager) LoginWithGitLabFederatedTokenProvider(
+	ctx context.Context, tenantId, clientId string,
+) (azcore.TokenCredential, error) {
+	if os.Getenv(gitLabIdTokenEnvVarName) == "" {
+		return nil, errNoGitLabIdTokenEnvVar
+	}
+
+	cred, err := m.newCredentialFromFederatedTokenProvider(tenantId, clientId, gitLabFederatedTokenProvider, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.saveLoginForServicePrincipal(
+		tenantId,
+		clientId,
+		&persistedSecret{
+			FederatedAuth: &federatedAuth{
+				TokenProvider: &gitLabFederatedTokenProvider,
+			},
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return cred, nil
+}
+
 func (m *Manager) LoginWithAzurePipelinesFederatedTokenProvider(
 	ctx context.Context, tenantID string, clientID string, serviceConnectionID string,
 ) (azcore.TokenCredential, error) {
@@ -1091,6 +1151,7 @@ type persistedSecret struct {
 var (
 	gitHubFederatedTokenProvider         federatedTokenProvider = "github"
 	azurePipelinesFederatedTokenProvider federatedTokenProvider = "azure-pipelines"
+	gitLabFederatedTokenProvider         federatedTokenProvider = "gitlab"
 )
 
 // token provider for federated auth
@@ -169,6 +169,34 @@ func TestServicePrincipalLoginFederatedTokenProvider(t *testing.T) {
 	require.True(t, errors.Is(err, ErrNoCurrentUser))
 }
 
+func TestServicePrincipalLoginGitLabFederatedTokenProvider(t *testing.T) {
+	credentialCache := &memoryCache{
+		cache: make(map[string][]byte),
+	}
+
+	m := Manager{
+		configManager:     newMemoryConfigManager(),
+		userConfigManager: newMemoryUserConfigManager(),
+		credentialCache:   credentialCache,
+		cloud:             cloud.AzurePublic(),
+	}
+
+	t.Setenv(gitLabIdTokenEnvVarName, "")
+	_, err := m.LoginWithGitLabFederatedTokenProvider(context.Background(), "testTenantId", "testClientId")
+	require.ErrorIs(t, err, errNoGitLabIdTokenEnvVar)
+
+	t.Setenv(gitLabIdTokenEnvVarName, "gitlab-id-token")
+	cred, err := m.LoginWithGitLabFederatedTokenProvider(context.Background(), "testTenantId", "testClientId")
+
+	require.NoError(t, err)
+	require.IsType(t, new(azidentity.ClientAssertionCredential), cred)
+
+	cred, err = m.CredentialForCurrentUser(context.Background(), nil)
+
+	require.NoError(t, err)
+	require.IsType(t, new(azidentity.ClientAssertionCredential), cred)
+}
+
 func TestLegacyAzCliCredentialSupport(t *testing.T) {
 	mgr := newMemoryUserConfigManager()
 
@@ -7,14 +7,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appplatform/armappplatform/v2"
 	"github.com/Azure/azure-storage-file-go/azfile"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 )
 
 // SpringService provides artifacts upload/deploy and query to Azure Spring Apps (ASA)
@@ -27,34 +34,115 @@ type SpringService interface {
 		instanceName string,
 		appName string,
 	) (*SpringAppProperties, error)
-	// Deploy jar artifact to ASA app deployment
-	DeploySpringAppArtifact(
+	// Upload jar artifact to ASA app Storage File
+	UploadSpringArtifact(
 		ctx context.Context,
 		subscriptionId string,
 		resourceGroup string,
 		instanceName string,
 		appName string,
-		relativePath string,
+		artifactPath string,
+	) (*string, error)
+	// Get Spring app deployment
+	GetSpringAppDeployment(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		instanceName string,
+		appName string,
 		deploymentName string,
 	) (*string, error)
-	// Upload jar artifact to ASA app Storage File
-	UploadSpringArtifact(
+	// List the deployments of an ASA app, including the status of their instances
+	ListSpringAppDeployments(
 		ctx context.Context,
 		subscriptionId string,
-		resourceGroup string,
+		resourceGroupName string,
 		instanceName string,
 		appName string,
-		artifactPath string,
+	) ([]SpringAppDeployment, error)
+	// Create or update an ASA app deployment from the given source, without making it the active deployment. The
+	// deployment gets the settings of the active deployment of the app, so that it runs the same way once activated.
+	CreateOrUpdateSpringAppDeployment(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		instanceName string,
+		appName string,
+		deploymentName string,
+		source armappplatform.UserSourceInfoClassification,
 	) (*string, error)
-	// Get Spring app deployment
-	GetSpringAppDeployment(
+	// Set the active deployment of an ASA app, which receives the production traffic
+	SetSpringAppActiveDeployment(
 		ctx context.Context,
 		subscriptionId string,
 		resourceGroupName string,
 		instanceName string,
 		appName string,
 		deploymentName string,
+	) error
+	// Get the pricing tier of an ASA instance, such as Standard or Enterprise
+	GetSpringServiceTier(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		instanceName string,
+	) (string, error)
+	// Upload source code to the Storage File of the build service of an Enterprise tier ASA instance
+	UploadSpringBuildSource(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		instanceName string,
+		sourcePath string,
 	) (*string, error)
+	// Build uploaded source code with the build service of an Enterprise tier ASA instance.
+	// Returns the resource id of the build result.
+	BuildSpringAppSource(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		instanceName string,
+		appName string,
+		relativePath string,
+		builder string,
+	) (*string, error)
+	// Open the log stream of an instance of an ASA app. The stream must be closed by the caller.
+	OpenSpringAppLogStream(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		instanceName string,
+		appName string,
+		appInstanceName string,
+		options *SpringAppLogStreamOptions,
+	) (io.ReadCloser, error)
+}
+
+// SpringAppLogStreamOptions are the options of the log stream of an ASA app instance
+type SpringAppLogStreamOptions struct {
+	// Follow keeps streaming the logs as they are written, until the context is canceled
+	Follow bool
+	// Since only includes the logs more recent than the duration. Zero includes the most recent lines only.
+	Since time.Duration
+}
+
+// The name of the build service, and of its default agent pool, in an Enterprise tier ASA instance
+const springBuildServiceName = "default"
+
+// SpringAppDeployment is a deployment of an ASA app
+type SpringAppDeployment struct {
+	Name      string
+	Active    bool
+	Status    string
+	Instances []SpringAppDeploymentInstance
+}
+
+// SpringAppDeploymentInstance is a running instance of an ASA app deployment
+type SpringAppDeploymentInstance struct {
+	Name            string
+	Status          string
+	DiscoveryStatus string
+	Reason          string
 }
 
 type springService struct {
@@ -133,74 +221,352 @@ func (ss *springService) UploadSpringArtifact(
 		return nil, fmt.Errorf("failed to parse storage upload url %s : %w", *storageInfo.UploadURL, err)
 	}
 
-	// Pass NewAnonymousCredential here, since the URL returned by Azure Spring Apps already contains a SAS token
-	fileURL := azfile.NewFileURL(*url, azfile.NewPipeline(azfile.NewAnonymousCredential(), azfile.PipelineOptions{}))
-	err = azfile.UploadFileToAzureFile(ctx, file, fileURL,
-		azfile.UploadToAzureFileOptions{
-			Metadata: azfile.Metadata{
-				"createdby": "AZD",
-			},
-		})
-
-	if err != nil {
+	if err := uploadToFileShare(ctx, file, url); err != nil {
 		return nil, fmt.Errorf("failed to upload artifact %s : %w", artifactPath, err)
 	}
 
 	return storageInfo.RelativePath, nil
 }
 
-func (ss *springService) DeploySpringAppArtifact(
+func (ss *springService) GetSpringAppDeployment(
 	ctx context.Context,
 	subscriptionId string,
-	resourceGroup string,
+	resourceGroupName string,
 	instanceName string,
 	appName string,
-	relativePath string,
 	deploymentName string,
 ) (*string, error) {
-	deploymentClient, err := ss.createSpringAppDeploymentClient(ctx, subscriptionId)
+	client, err := ss.createSpringAppDeploymentClient(ctx, subscriptionId)
 	if err != nil {
 		return nil, err
 	}
 
-	springClient, err := ss.createSpringAppClient(ctx, subscriptionId)
+	resp, err := client.Get(ctx, resourceGroupName, instanceName, appName, deploymentName, nil)
+
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = ss.createOrUpdateDeployment(deploymentClient, ctx, resourceGroup, instanceName, appName,
-		deploymentName, relativePath)
+	return resp.Name, nil
+}
+
+func (ss *springService) ListSpringAppDeployments(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	instanceName string,
+	appName string,
+) ([]SpringAppDeployment, error) {
+	client, err := ss.createSpringAppDeploymentClient(ctx, subscriptionId)
 	if err != nil {
 		return nil, err
 	}
-	resName, err := ss.activeDeployment(springClient, ctx, resourceGroup, instanceName, appName, deploymentName)
+
+	var deployments []SpringAppDeployment
+	pager := client.NewListPager(resourceGroupName, instanceName, appName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing deployments of spring app %s: %w", appName, err)
+		}
+
+		for _, resource := range page.Value {
+			deployment := SpringAppDeployment{
+				Name: *resource.Name,
+			}
+
+			if props := resource.Properties; props != nil {
+				deployment.Active = props.Active != nil && *props.Active
+				if props.Status != nil {
+					deployment.Status = string(*props.Status)
+				}
+
+				for _, instance := range props.Instances {
+					deployment.Instances = append(deployment.Instances, SpringAppDeploymentInstance{
+						Name:            convert.ToValueWithDefault(instance.Name, ""),
+						Status:          convert.ToValueWithDefault(instance.Status, ""),
+						DiscoveryStatus: convert.ToValueWithDefault(instance.DiscoveryStatus, ""),
+						Reason:          convert.ToValueWithDefault(instance.Reason, ""),
+					})
+				}
+			}
+
+			deployments = append(deployments, deployment)
+		}
+	}
+
+	return deployments, nil
+}
+
+func (ss *springService) CreateOrUpdateSpringAppDeployment(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	instanceName string,
+	appName string,
+	deploymentName string,
+	source armappplatform.UserSourceInfoClassification,
+) (*string, error) {
+	deploymentClient, err := ss.createSpringAppDeploymentClient(ctx, subscriptionId)
 	if err != nil {
 		return nil, err
 	}
 
-	return resName, nil
+	return ss.createOrUpdateDeployment(
+		deploymentClient, ctx, resourceGroupName, instanceName, appName, deploymentName, source)
 }
 
-func (ss *springService) GetSpringAppDeployment(
+func (ss *springService) SetSpringAppActiveDeployment(
 	ctx context.Context,
 	subscriptionId string,
 	resourceGroupName string,
 	instanceName string,
 	appName string,
 	deploymentName string,
+) error {
+	springClient, err := ss.createSpringAppClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	_, err = ss.activeDeployment(springClient, ctx, resourceGroupName, instanceName, appName, deploymentName)
+	return err
+}
+
+func (ss *springService) GetSpringServiceTier(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	instanceName string,
+) (string, error) {
+	credential, err := ss.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := armappplatform.NewServicesClient(subscriptionId, credential, ss.armClientOptions)
+	if err != nil {
+		return "", fmt.Errorf("creating SpringService client: %w", err)
+	}
+
+	service, err := client.Get(ctx, resourceGroupName, instanceName, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed retrieving spring service %s: %w", instanceName, err)
+	}
+
+	if service.SKU == nil || service.SKU.Tier == nil {
+		return "", nil
+	}
+
+	return *service.SKU.Tier, nil
+}
+
+func (ss *springService) UploadSpringBuildSource(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	instanceName string,
+	sourcePath string,
 ) (*string, error) {
-	client, err := ss.createSpringAppDeploymentClient(ctx, subscriptionId)
+	file, err := os.Open(sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading source archive %s: %w", sourcePath, err)
+	}
+	defer file.Close()
+
+	client, err := ss.createSpringBuildServiceClient(ctx, subscriptionId)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := client.Get(ctx, resourceGroupName, instanceName, appName, deploymentName, nil)
+	storageInfo, err := client.GetResourceUploadURL(ctx, resourceGroupName, instanceName, springBuildServiceName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get build service upload URL: %w", err)
+	}
 
+	url, err := url.Parse(*storageInfo.UploadURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse storage upload url %s : %w", *storageInfo.UploadURL, err)
+	}
+
+	if err := uploadToFileShare(ctx, file, url); err != nil {
+		return nil, fmt.Errorf("failed to upload source %s : %w", sourcePath, err)
+	}
+
+	return storageInfo.RelativePath, nil
+}
+
+// springBuildPollInterval is the delay between checks of the status of a build result
+var springBuildPollInterval = 10 * time.Second
+
+func (ss *springService) BuildSpringAppSource(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	instanceName string,
+	appName string,
+	relativePath string,
+	builder string,
+) (*string, error) {
+	client, err := ss.createSpringBuildServiceClient(ctx, subscriptionId)
 	if err != nil {
 		return nil, err
 	}
 
-	return resp.Name, nil
+	buildServiceId := fmt.Sprintf(
+		"/subscriptions/%s/resourceGroups/%s/providers/Microsoft.AppPlatform/Spring/%s/buildServices/%s",
+		subscriptionId, resourceGroupName, instanceName, springBuildServiceName)
+
+	// Builds are named after the app, so that each deployment of the app replaces its previous build
+	build, err := client.CreateOrUpdateBuild(ctx, resourceGroupName, instanceName, springBuildServiceName, appName,
+		armappplatform.Build{
+			Properties: &armappplatform.BuildProperties{
+				RelativePath: to.Ptr(relativePath),
+				Builder:      to.Ptr(fmt.Sprintf("%s/builders/%s", buildServiceId, builder)),
+				AgentPool:    to.Ptr(fmt.Sprintf("%s/agentPools/%s", buildServiceId, springBuildServiceName)),
+			},
+		}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating build for spring app %s: %w", appName, err)
+	}
+
+	if build.Properties == nil ||
+		build.Properties.TriggeredBuildResult == nil ||
+		build.Properties.TriggeredBuildResult.ID == nil {
+		return nil, fmt.Errorf("build for spring app %s did not trigger a build result", appName)
+	}
+
+	buildResultId := *build.Properties.TriggeredBuildResult.ID
+	buildResultName := buildResultId[strings.LastIndex(buildResultId, "/")+1:]
+	for {
+		result, err := client.GetBuildResult(
+			ctx, resourceGroupName, instanceName, springBuildServiceName, appName, buildResultName, nil)
+		if err != nil {
+			return nil, fmt.Errorf("getting build result %s: %w", buildResultName, err)
+		}
+
+		if result.Properties != nil && result.Properties.ProvisioningState != nil {
+			switch *result.Properties.ProvisioningState {
+			case armappplatform.BuildResultProvisioningStateSucceeded:
+				return &buildResultId, nil
+			case armappplatform.BuildResultProvisioningStateFailed,
+				armappplatform.BuildResultProvisioningStateDeleting:
+				reason := ""
+				if result.Properties.Error != nil && result.Properties.Error.Message != nil {
+					reason = ": " + *result.Properties.Error.Message
+				}
+				return nil, fmt.Errorf("build of spring app %s failed%s", appName, reason)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(springBuildPollInterval):
+		}
+	}
+}
+
+// The number of past lines of an ASA app instance included in its log stream when no start time is set
+const defaultSpringLogTailLines = 20
+
+func (ss *springService) OpenSpringAppLogStream(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	instanceName string,
+	appName string,
+	appInstanceName string,
+	options *SpringAppLogStreamOptions,
+) (io.ReadCloser, error) {
+	if options == nil {
+		options = &SpringAppLogStreamOptions{}
+	}
+
+	credential, err := ss.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := armappplatform.NewServicesClient(subscriptionId, credential, ss.armClientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("creating SpringService client: %w", err)
+	}
+
+	service, err := client.Get(ctx, resourceGroupName, instanceName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving spring service %s: %w", instanceName, err)
+	}
+
+	if service.Properties == nil || service.Properties.Fqdn == nil {
+		return nil, fmt.Errorf("spring service %s has no host name", instanceName)
+	}
+
+	// The log stream is authenticated with the keys of the test endpoint
+	testKeys, err := client.ListTestKeys(ctx, resourceGroupName, instanceName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving test keys of spring service %s: %w", instanceName, err)
+	}
+
+	if testKeys.Enabled == nil || !*testKeys.Enabled || testKeys.PrimaryKey == nil {
+		return nil, fmt.Errorf(
+			"the test endpoint of spring service %s is disabled. Enable it to stream the logs of its apps", instanceName)
+	}
+
+	query := url.Values{}
+	query.Set("follow", strconv.FormatBool(options.Follow))
+	if options.Since > 0 {
+		query.Set("sinceSeconds", strconv.Itoa(int(options.Since.Seconds())))
+	} else {
+		query.Set("tailLines", strconv.Itoa(defaultSpringLogTailLines))
+	}
+
+	endpoint := fmt.Sprintf(
+		"https://%s/api/logstream/apps/%s/instances/%s?%s",
+		*service.Properties.Fqdn,
+		url.PathEscape(appName),
+		url.PathEscape(appInstanceName),
+		query.Encode(),
+	)
+
+	req, err := runtime.NewRequest(ctx, http.MethodGet, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("creating log stream request: %w", err)
+	}
+	req.Raw().SetBasicAuth("primary", *testKeys.PrimaryKey)
+	// the stream doesn't end when following the logs, so the body is read by the caller as it is written
+	runtime.SkipBodyDownload(req)
+
+	pipeline := runtime.NewPipeline("spring-log-stream", "1.0.0", runtime.PipelineOptions{}, &ss.armClientOptions.ClientOptions)
+	response, err := pipeline.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("opening log stream of spring app instance %s: %w", appInstanceName, err)
+	}
+
+	if !runtime.HasStatusCode(response, http.StatusOK) {
+		defer response.Body.Close()
+		return nil, fmt.Errorf(
+			"opening log stream of spring app instance %s: %w", appInstanceName, runtime.NewResponseError(response))
+	}
+
+	return response.Body, nil
+}
+
+func (ss *springService) createSpringBuildServiceClient(
+	ctx context.Context,
+	subscriptionId string,
+) (*armappplatform.BuildServiceClient, error) {
+	credential, err := ss.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := armappplatform.NewBuildServiceClient(subscriptionId, credential, ss.armClientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("creating SpringBuildService client: %w", err)
+	}
+
+	return client, nil
 }
 
 func (ss *springService) createSpringAppClient(
@@ -244,17 +610,25 @@ func (ss *springService) createOrUpdateDeployment(
 	instanceName string,
 	appName string,
 	deploymentName string,
-	relativePath string,
+	source armappplatform.UserSourceInfoClassification,
 ) (*string, error) {
-	poller, err := deploymentClient.BeginCreateOrUpdate(ctx, resourceGroup, instanceName, appName, deploymentName,
-		armappplatform.DeploymentResource{
-			Properties: &armappplatform.DeploymentResourceProperties{
-				Source: &armappplatform.JarUploadedUserSourceInfo{
-					Type:         to.Ptr("Jar"),
-					RelativePath: to.Ptr(relativePath),
-				},
-			},
-		}, nil)
+	base, err := ss.baseDeployment(deploymentClient, ctx, resourceGroup, instanceName, appName, deploymentName)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := armappplatform.DeploymentResource{
+		SKU:        base.SKU,
+		Properties: &armappplatform.DeploymentResourceProperties{},
+	}
+	if base.Properties != nil {
+		deployment.Properties.DeploymentSettings = base.Properties.DeploymentSettings
+		source = withSourceSettings(source, base.Properties.Source)
+	}
+	deployment.Properties.Source = source
+
+	poller, err := deploymentClient.BeginCreateOrUpdate(
+		ctx, resourceGroup, instanceName, appName, deploymentName, deployment, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -267,6 +641,75 @@ func (ss *springService) createOrUpdateDeployment(
 	return res.Name, nil
 }
 
+// baseDeployment returns the deployment whose settings a deployment of the app is created or updated with: the active
+// deployment of the app, or the deployment itself when the app has no active deployment. An empty deployment is
+// returned when neither exists.
+func (ss *springService) baseDeployment(
+	deploymentClient *armappplatform.DeploymentsClient,
+	ctx context.Context,
+	resourceGroup string,
+	instanceName string,
+	appName string,
+	deploymentName string,
+) (armappplatform.DeploymentResource, error) {
+	var target *armappplatform.DeploymentResource
+	pager := deploymentClient.NewListPager(resourceGroup, instanceName, appName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return armappplatform.DeploymentResource{}, fmt.Errorf(
+				"listing deployments of spring app %s: %w", appName, err)
+		}
+
+		for _, resource := range page.Value {
+			if resource.Properties != nil && resource.Properties.Active != nil && *resource.Properties.Active {
+				return *resource, nil
+			}
+
+			if resource.Name != nil && *resource.Name == deploymentName {
+				target = resource
+			}
+		}
+	}
+
+	if target != nil {
+		return *target, nil
+	}
+
+	return armappplatform.DeploymentResource{}, nil
+}
+
+// withSourceSettings returns source with the runtime settings of the base source, such as the JVM options of a jar,
+// when both sources are of the same type and source doesn't set them.
+func withSourceSettings(
+	source armappplatform.UserSourceInfoClassification,
+	base armappplatform.UserSourceInfoClassification,
+) armappplatform.UserSourceInfoClassification {
+	switch source := source.(type) {
+	case *armappplatform.JarUploadedUserSourceInfo:
+		if base, ok := base.(*armappplatform.JarUploadedUserSourceInfo); ok {
+			withSettings := *source
+			if withSettings.JvmOptions == nil {
+				withSettings.JvmOptions = base.JvmOptions
+			}
+			if withSettings.RuntimeVersion == nil {
+				withSettings.RuntimeVersion = base.RuntimeVersion
+			}
+
+			return &withSettings
+		}
+	case *armappplatform.SourceUploadedUserSourceInfo:
+		if base, ok := base.(*armappplatform.SourceUploadedUserSourceInfo); ok && source.RuntimeVersion == nil {
+			withSettings := *source
+			withSettings.RuntimeVersion = base.RuntimeVersion
+
+			return &withSettings
+		}
+	}
+
+	return source
+}
+
 func (ss *springService) activeDeployment(
 	springClient *armappplatform.AppsClient,
 	ctx context.Context,
@@ -293,3 +736,15 @@ func (ss *springService) activeDeployment(
 
 	return res.Name, nil
 }
+
+// uploadToFileShare uploads the contents of file to the Azure Files URL returned by Azure Spring Apps.
+func uploadToFileShare(ctx context.Context, file *os.File, url *url.URL) error {
+	// Pass NewAnonymousCredential here, since the URL returned by Azure Spring Apps already contains a SAS token
+	fileURL := azfile.NewFileURL(*url, azfile.NewPipeline(azfile.NewAnonymousCredential(), azfile.PipelineOptions{}))
+	return azfile.UploadFileToAzureFile(ctx, file, fileURL,
+		azfile.UploadToAzureFileOptions{
+			Metadata: azfile.Metadata{
+				"createdby": "AZD",
+			},
+		})
+}
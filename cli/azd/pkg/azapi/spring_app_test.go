@@ -0,0 +1,103 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appplatform/armappplatform/v2"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockaccount"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateOrUpdateSpringAppDeployment(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	springService := NewSpringService(
+		mockaccount.SubscriptionCredentialProviderFunc(func(_ context.Context, _ string) (azcore.TokenCredential, error) {
+			return mockContext.Credentials, nil
+		}),
+		mockContext.ArmClientOptions,
+	)
+
+	deploymentsPath := "/providers/Microsoft.AppPlatform/Spring/SPRING_SERVICE/apps/APP_NAME/deployments"
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && strings.HasSuffix(request.URL.Path, deploymentsPath)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armappplatform.DeploymentResourceCollection{
+			Value: []*armappplatform.DeploymentResource{
+				{
+					Name: to.Ptr("blue"),
+					SKU:  &armappplatform.SKU{Name: to.Ptr("S0"), Tier: to.Ptr("Standard"), Capacity: to.Ptr[int32](2)},
+					Properties: &armappplatform.DeploymentResourceProperties{
+						Active: to.Ptr(true),
+						DeploymentSettings: &armappplatform.DeploymentSettings{
+							EnvironmentVariables: map[string]*string{"SPRING_PROFILES_ACTIVE": to.Ptr("prod")},
+						},
+						Source: &armappplatform.JarUploadedUserSourceInfo{
+							Type:           to.Ptr("Jar"),
+							RelativePath:   to.Ptr("resources/blue.jar"),
+							JvmOptions:     to.Ptr("-Xmx1g"),
+							RuntimeVersion: to.Ptr("Java_17"),
+						},
+					},
+				},
+				{
+					Name:       to.Ptr("green"),
+					Properties: &armappplatform.DeploymentResourceProperties{Active: to.Ptr(false)},
+				},
+			},
+		})
+	})
+
+	var deployment armappplatform.DeploymentResource
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPut && strings.HasSuffix(request.URL.Path, deploymentsPath+"/green")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(body, &deployment); err != nil {
+			return nil, err
+		}
+
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armappplatform.DeploymentResource{
+			Name: to.Ptr("green"),
+		})
+	})
+
+	name, err := springService.CreateOrUpdateSpringAppDeployment(
+		*mockContext.Context,
+		"SUBSCRIPTION_ID",
+		"RESOURCE_GROUP",
+		"SPRING_SERVICE",
+		"APP_NAME",
+		"green",
+		&armappplatform.JarUploadedUserSourceInfo{
+			Type:         to.Ptr("Jar"),
+			RelativePath: to.Ptr("resources/green.jar"),
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "green", *name)
+
+	// the staging deployment runs with the settings of the active deployment, only its source is replaced
+	require.Equal(t, "S0", *deployment.SKU.Name)
+	require.Equal(t, int32(2), *deployment.SKU.Capacity)
+	require.Equal(t, "prod", *deployment.Properties.DeploymentSettings.EnvironmentVariables["SPRING_PROFILES_ACTIVE"])
+
+	source, ok := deployment.Properties.Source.(*armappplatform.JarUploadedUserSourceInfo)
+	require.True(t, ok)
+	require.Equal(t, "resources/green.jar", *source.RelativePath)
+	require.Equal(t, "-Xmx1g", *source.JvmOptions)
+	require.Equal(t, "Java_17", *source.RuntimeVersion)
+}
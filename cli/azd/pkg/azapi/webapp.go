@@ -204,3 +204,39 @@ func (cli *AzureClient) createZipDeployClient(
 
 	return client, nil
 }
+
+// OpenAppServiceLogStream opens the log stream of the app service, which streams the application logs of the app as
+// they are written, until the context is canceled. The stream must be closed by the caller.
+func (cli *AzureClient) OpenAppServiceLogStream(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+) (io.ReadCloser, error) {
+	app, err := cli.appService(ctx, subscriptionId, resourceGroup, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	hostName, err := appServiceRepositoryHost(app, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := cli.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := azsdk.NewLogStreamClient(hostName, credential, cli.armClientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("creating log stream client: %w", err)
+	}
+
+	stream, err := client.Open(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("opening log stream of webapp %s: %w", appName, err)
+	}
+
+	return stream, nil
+}
@@ -4,7 +4,9 @@
 package azd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	infraBicep "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/bicep"
+	infraPulumi "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/pulumi"
 	infraTerraform "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/terraform"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
 	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
@@ -22,6 +25,8 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/state"
 	"github.com/azure/azure-dev/cli/azd/pkg/templates"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/bicep"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/pulumi"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/terraform"
 )
 
@@ -50,6 +55,7 @@ func (p *DefaultPlatform) IsEnabled() bool {
 func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) error {
 	// Tools
 	container.MustRegisterSingleton(terraform.NewCli)
+	container.MustRegisterSingleton(pulumi.NewCli)
 	container.MustRegisterSingleton(bicep.NewCli)
 
 	container.MustRegisterTransient(func() *lazy.Lazy[*infraBicep.BicepProvider] {
@@ -80,6 +86,7 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 	provisionProviderMap := map[provisioning.ProviderKind]any{
 		provisioning.Bicep:     infraBicep.NewBicepProvider,
 		provisioning.Terraform: infraTerraform.NewTerraformProvider,
+		provisioning.Pulumi:    infraPulumi.NewPulumiProvider,
 	}
 
 	for provider, constructor := range provisionProviderMap {
@@ -96,6 +103,8 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 	// Remote Environment State Providers
 	remoteStateProviderMap := map[environment.RemoteKind]any{
 		environment.RemoteKindAzureBlobStorage: environment.NewStorageBlobDataStore,
+		environment.RemoteKindGit:              environment.NewGitDataStore,
+		environment.RemoteKindHttp:             environment.NewHttpDataStore,
 	}
 
 	for remoteKind, constructor := range remoteStateProviderMap {
@@ -111,13 +120,8 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 		}
 
 		var storageAccountConfig *storage.AccountConfig
-		jsonBytes, err := json.Marshal(remoteStateConfig.Config)
-		if err != nil {
-			return nil, fmt.Errorf("marshalling remote state config: %w", err)
-		}
-
-		if err := json.Unmarshal(jsonBytes, &storageAccountConfig); err != nil {
-			return nil, fmt.Errorf("unmarshalling remote state config: %w", err)
+		if err := decodeRemoteConfig(remoteStateConfig, &storageAccountConfig); err != nil {
+			return nil, err
 		}
 
 		// If a container name has not been explicitly configured
@@ -131,6 +135,60 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 		return storageAccountConfig, nil
 	})
 
+	container.MustRegisterSingleton(func(
+		remoteStateConfig *state.RemoteConfig,
+		projectConfig *project.ProjectConfig,
+		gitCli *git.Cli,
+	) (*environment.GitDataStoreConfig, error) {
+		if remoteStateConfig == nil {
+			return nil, nil
+		}
+
+		// The config is optional for the Git backend, so start from an empty config
+		gitConfig := &environment.GitDataStoreConfig{}
+		if err := decodeRemoteConfig(remoteStateConfig, gitConfig); err != nil {
+			return nil, err
+		}
+
+		// If a repository has not been explicitly configured
+		// Default to use the origin of the repository of the project
+		if gitConfig.Repository == "" {
+			remoteUrl, err := gitCli.GetRemoteUrl(context.Background(), projectConfig.Path, "origin")
+			if err != nil {
+				return nil, fmt.Errorf("getting the git repository of the project: %w", err)
+			}
+
+			gitConfig.Repository = remoteUrl
+		}
+
+		// If a path has not been explicitly configured
+		// Default to use the project name as the folder name
+		if gitConfig.Path == "" {
+			gitConfig.Path = projectConfig.Name
+		}
+
+		return gitConfig, nil
+	})
+
+	container.MustRegisterSingleton(func(
+		remoteStateConfig *state.RemoteConfig,
+	) (*environment.HttpDataStoreConfig, error) {
+		if remoteStateConfig == nil {
+			return nil, nil
+		}
+
+		httpConfig := &environment.HttpDataStoreConfig{}
+		if err := decodeRemoteConfig(remoteStateConfig, httpConfig); err != nil {
+			return nil, err
+		}
+
+		if httpConfig.Url == "" {
+			return nil, errors.New("remote state configuration is invalid. The 'url' of the Http backend is not configured")
+		}
+
+		return httpConfig, nil
+	})
+
 	// Storage components
 	container.MustRegisterSingleton(storage.NewBlobClient)
 	container.MustRegisterSingleton(storage.NewBlobSdkClient)
@@ -153,3 +211,17 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 
 	return nil
 }
+
+// decodeRemoteConfig decodes the backend specific configuration of the remote state configuration into target.
+func decodeRemoteConfig(remoteStateConfig *state.RemoteConfig, target any) error {
+	jsonBytes, err := json.Marshal(remoteStateConfig.Config)
+	if err != nil {
+		return fmt.Errorf("marshalling remote state config: %w", err)
+	}
+
+	if err := json.Unmarshal(jsonBytes, target); err != nil {
+		return fmt.Errorf("unmarshalling remote state config: %w", err)
+	}
+
+	return nil
+}
@@ -4,10 +4,17 @@
 package azd
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/state"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockexec"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,3 +43,37 @@ func Test_DefaultPlatform_ConfigureContainer(t *testing.T) {
 		require.Equal(t, expected, actual)
 	})
 }
+
+func Test_DefaultPlatform_RemoteStateConfig(t *testing.T) {
+	newContainer := func(t *testing.T, remoteStateConfig *state.RemoteConfig) *ioc.NestedContainer {
+		commandRunner := mockexec.NewMockCommandRunner()
+		commandRunner.When(func(args exec.RunArgs, command string) bool {
+			return strings.Contains(command, "remote get-url origin")
+		}).Respond(exec.NewRunResult(0, "https://github.com/contoso/app.git\n", ""))
+
+		container := ioc.NewNestedContainer(nil)
+		require.NoError(t, NewDefaultPlatform().ConfigureContainer(container))
+		ioc.RegisterInstance(container, remoteStateConfig)
+		ioc.RegisterInstance(container, &project.ProjectConfig{Name: "app", Path: t.TempDir()})
+		ioc.RegisterInstance(container, git.NewCli(commandRunner))
+
+		return container
+	}
+
+	t.Run("GitWithoutConfig", func(t *testing.T) {
+		container := newContainer(t, &state.RemoteConfig{Backend: string(environment.RemoteKindGit)})
+
+		var gitConfig *environment.GitDataStoreConfig
+		require.NoError(t, container.Resolve(&gitConfig))
+		require.Equal(t, "https://github.com/contoso/app.git", gitConfig.Repository)
+		require.Equal(t, "app", gitConfig.Path)
+	})
+
+	t.Run("HttpWithoutConfig", func(t *testing.T) {
+		container := newContainer(t, &state.RemoteConfig{Backend: string(environment.RemoteKindHttp)})
+
+		var httpConfig *environment.HttpDataStoreConfig
+		err := container.Resolve(&httpConfig)
+		require.ErrorContains(t, err, "'url' of the Http backend is not configured")
+	})
+}
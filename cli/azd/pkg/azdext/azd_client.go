@@ -17,13 +17,16 @@ type AzdClientOption func(*AzdClient) error
 
 // AzdClient is the client for the `azd` gRPC server.
 type AzdClient struct {
-	connection        *grpc.ClientConn
-	projectClient     ProjectServiceClient
-	environmentClient EnvironmentServiceClient
-	userConfigClient  UserConfigServiceClient
-	promptClient      PromptServiceClient
-	deploymentClient  DeploymentServiceClient
-	eventsClient      EventServiceClient
+	connection          *grpc.ClientConn
+	projectClient       ProjectServiceClient
+	environmentClient   EnvironmentServiceClient
+	userConfigClient    UserConfigServiceClient
+	promptClient        PromptServiceClient
+	deploymentClient    DeploymentServiceClient
+	eventsClient        EventServiceClient
+	serviceTargetClient ServiceTargetServiceClient
+	frameworkClient     FrameworkServiceClient
+	provisioningClient  ProvisioningServiceClient
 }
 
 // WithAddress sets the address of the `azd` gRPC server.
@@ -125,3 +128,30 @@ func (c *AzdClient) Events() EventServiceClient {
 
 	return c.eventsClient
 }
+
+// ServiceTarget returns the service target client.
+func (c *AzdClient) ServiceTarget() ServiceTargetServiceClient {
+	if c.serviceTargetClient == nil {
+		c.serviceTargetClient = NewServiceTargetServiceClient(c.connection)
+	}
+
+	return c.serviceTargetClient
+}
+
+// FrameworkService returns the framework service client.
+func (c *AzdClient) FrameworkService() FrameworkServiceClient {
+	if c.frameworkClient == nil {
+		c.frameworkClient = NewFrameworkServiceClient(c.connection)
+	}
+
+	return c.frameworkClient
+}
+
+// Provisioning returns the provisioning client.
+func (c *AzdClient) Provisioning() ProvisioningServiceClient {
+	if c.provisioningClient == nil {
+		c.provisioningClient = NewProvisioningServiceClient(c.connection)
+	}
+
+	return c.provisioningClient
+}
@@ -0,0 +1,1158 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.29.1
+// source: framework_service.proto
+
+package azdext
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents different types of messages sent over the stream
+type FrameworkServiceMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Correlates responses and progress updates with the request they belong to.
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Error message of a failed request, set on responses.
+	ErrorMessage string `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// Types that are assignable to MessageType:
+	//
+	//	*FrameworkServiceMessage_RegisterFrameworkServiceRequest
+	//	*FrameworkServiceMessage_RegisterFrameworkServiceResponse
+	//	*FrameworkServiceMessage_RestoreRequest
+	//	*FrameworkServiceMessage_RestoreResponse
+	//	*FrameworkServiceMessage_BuildRequest
+	//	*FrameworkServiceMessage_BuildResponse
+	//	*FrameworkServiceMessage_PackageRequest
+	//	*FrameworkServiceMessage_PackageResponse
+	//	*FrameworkServiceMessage_ProgressMessage
+	//	*FrameworkServiceMessage_ReadyMessage
+	MessageType isFrameworkServiceMessage_MessageType `protobuf_oneof:"message_type"`
+}
+
+func (x *FrameworkServiceMessage) Reset() {
+	*x = FrameworkServiceMessage{}
+	mi := &file_framework_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceMessage) ProtoMessage() {}
+
+func (x *FrameworkServiceMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceMessage.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceMessage) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FrameworkServiceMessage) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *FrameworkServiceMessage) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (m *FrameworkServiceMessage) GetMessageType() isFrameworkServiceMessage_MessageType {
+	if m != nil {
+		return m.MessageType
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetRegisterFrameworkServiceRequest() *RegisterFrameworkServiceRequest {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_RegisterFrameworkServiceRequest); ok {
+		return x.RegisterFrameworkServiceRequest
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetRegisterFrameworkServiceResponse() *RegisterFrameworkServiceResponse {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_RegisterFrameworkServiceResponse); ok {
+		return x.RegisterFrameworkServiceResponse
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetRestoreRequest() *FrameworkServiceRestoreRequest {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_RestoreRequest); ok {
+		return x.RestoreRequest
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetRestoreResponse() *FrameworkServiceRestoreResponse {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_RestoreResponse); ok {
+		return x.RestoreResponse
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetBuildRequest() *FrameworkServiceBuildRequest {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_BuildRequest); ok {
+		return x.BuildRequest
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetBuildResponse() *FrameworkServiceBuildResponse {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_BuildResponse); ok {
+		return x.BuildResponse
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetPackageRequest() *FrameworkServicePackageRequest {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_PackageRequest); ok {
+		return x.PackageRequest
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetPackageResponse() *FrameworkServicePackageResponse {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_PackageResponse); ok {
+		return x.PackageResponse
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetProgressMessage() *FrameworkServiceProgressMessage {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_ProgressMessage); ok {
+		return x.ProgressMessage
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetReadyMessage() *FrameworkServiceReadyMessage {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_ReadyMessage); ok {
+		return x.ReadyMessage
+	}
+	return nil
+}
+
+type isFrameworkServiceMessage_MessageType interface {
+	isFrameworkServiceMessage_MessageType()
+}
+
+type FrameworkServiceMessage_RegisterFrameworkServiceRequest struct {
+	RegisterFrameworkServiceRequest *RegisterFrameworkServiceRequest `protobuf:"bytes,3,opt,name=register_framework_service_request,json=registerFrameworkServiceRequest,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_RegisterFrameworkServiceResponse struct {
+	RegisterFrameworkServiceResponse *RegisterFrameworkServiceResponse `protobuf:"bytes,4,opt,name=register_framework_service_response,json=registerFrameworkServiceResponse,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_RestoreRequest struct {
+	RestoreRequest *FrameworkServiceRestoreRequest `protobuf:"bytes,5,opt,name=restore_request,json=restoreRequest,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_RestoreResponse struct {
+	RestoreResponse *FrameworkServiceRestoreResponse `protobuf:"bytes,6,opt,name=restore_response,json=restoreResponse,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_BuildRequest struct {
+	BuildRequest *FrameworkServiceBuildRequest `protobuf:"bytes,7,opt,name=build_request,json=buildRequest,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_BuildResponse struct {
+	BuildResponse *FrameworkServiceBuildResponse `protobuf:"bytes,8,opt,name=build_response,json=buildResponse,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_PackageRequest struct {
+	PackageRequest *FrameworkServicePackageRequest `protobuf:"bytes,9,opt,name=package_request,json=packageRequest,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_PackageResponse struct {
+	PackageResponse *FrameworkServicePackageResponse `protobuf:"bytes,10,opt,name=package_response,json=packageResponse,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_ProgressMessage struct {
+	ProgressMessage *FrameworkServiceProgressMessage `protobuf:"bytes,11,opt,name=progress_message,json=progressMessage,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_ReadyMessage struct {
+	ReadyMessage *FrameworkServiceReadyMessage `protobuf:"bytes,12,opt,name=ready_message,json=readyMessage,proto3,oneof"`
+}
+
+func (*FrameworkServiceMessage_RegisterFrameworkServiceRequest) isFrameworkServiceMessage_MessageType() {
+}
+
+func (*FrameworkServiceMessage_RegisterFrameworkServiceResponse) isFrameworkServiceMessage_MessageType() {
+}
+
+func (*FrameworkServiceMessage_RestoreRequest) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_RestoreResponse) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_BuildRequest) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_BuildResponse) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_PackageRequest) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_PackageResponse) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_ProgressMessage) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_ReadyMessage) isFrameworkServiceMessage_MessageType() {}
+
+// Client registers the framework service for a language
+type RegisterFrameworkServiceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Language used by services in azure.yaml, e.g. "rust".
+	Language string `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	// Lifecycle commands that the framework service requires.
+	Requirements *FrameworkRequirements `protobuf:"bytes,2,opt,name=requirements,proto3" json:"requirements,omitempty"`
+}
+
+func (x *RegisterFrameworkServiceRequest) Reset() {
+	*x = RegisterFrameworkServiceRequest{}
+	mi := &file_framework_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterFrameworkServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterFrameworkServiceRequest) ProtoMessage() {}
+
+func (x *RegisterFrameworkServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterFrameworkServiceRequest.ProtoReflect.Descriptor instead.
+func (*RegisterFrameworkServiceRequest) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RegisterFrameworkServiceRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *RegisterFrameworkServiceRequest) GetRequirements() *FrameworkRequirements {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+// Server confirms the registration of the framework service
+type RegisterFrameworkServiceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RegisterFrameworkServiceResponse) Reset() {
+	*x = RegisterFrameworkServiceResponse{}
+	mi := &file_framework_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterFrameworkServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterFrameworkServiceResponse) ProtoMessage() {}
+
+func (x *RegisterFrameworkServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterFrameworkServiceResponse.ProtoReflect.Descriptor instead.
+func (*RegisterFrameworkServiceResponse) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{2}
+}
+
+// Lifecycle commands that a framework service requires before packaging
+type FrameworkRequirements struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether the service must be restored before it is packaged.
+	RequireRestore bool `protobuf:"varint,1,opt,name=require_restore,json=requireRestore,proto3" json:"require_restore,omitempty"`
+	// Whether the service must be built before it is packaged.
+	RequireBuild bool `protobuf:"varint,2,opt,name=require_build,json=requireBuild,proto3" json:"require_build,omitempty"`
+}
+
+func (x *FrameworkRequirements) Reset() {
+	*x = FrameworkRequirements{}
+	mi := &file_framework_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkRequirements) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkRequirements) ProtoMessage() {}
+
+func (x *FrameworkRequirements) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkRequirements.ProtoReflect.Descriptor instead.
+func (*FrameworkRequirements) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FrameworkRequirements) GetRequireRestore() bool {
+	if x != nil {
+		return x.RequireRestore
+	}
+	return false
+}
+
+func (x *FrameworkRequirements) GetRequireBuild() bool {
+	if x != nil {
+		return x.RequireBuild
+	}
+	return false
+}
+
+// Server requests the framework service to restore the dependencies of a service
+type FrameworkServiceRestoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Configuration of the service to restore.
+	Service *ServiceConfig `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+}
+
+func (x *FrameworkServiceRestoreRequest) Reset() {
+	*x = FrameworkServiceRestoreRequest{}
+	mi := &file_framework_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceRestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceRestoreRequest) ProtoMessage() {}
+
+func (x *FrameworkServiceRestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceRestoreRequest.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceRestoreRequest) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FrameworkServiceRestoreRequest) GetService() *ServiceConfig {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+// Client returns the result of a restore
+type FrameworkServiceRestoreResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ServiceRestoreResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *FrameworkServiceRestoreResponse) Reset() {
+	*x = FrameworkServiceRestoreResponse{}
+	mi := &file_framework_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceRestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceRestoreResponse) ProtoMessage() {}
+
+func (x *FrameworkServiceRestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceRestoreResponse.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceRestoreResponse) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FrameworkServiceRestoreResponse) GetResult() *ServiceRestoreResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Server requests the framework service to build a service
+type FrameworkServiceBuildRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Configuration of the service to build.
+	Service *ServiceConfig `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Result of the restore of the service, when it was restored.
+	Restore *ServiceRestoreResult `protobuf:"bytes,2,opt,name=restore,proto3" json:"restore,omitempty"`
+}
+
+func (x *FrameworkServiceBuildRequest) Reset() {
+	*x = FrameworkServiceBuildRequest{}
+	mi := &file_framework_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceBuildRequest) ProtoMessage() {}
+
+func (x *FrameworkServiceBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceBuildRequest.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceBuildRequest) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FrameworkServiceBuildRequest) GetService() *ServiceConfig {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *FrameworkServiceBuildRequest) GetRestore() *ServiceRestoreResult {
+	if x != nil {
+		return x.Restore
+	}
+	return nil
+}
+
+// Client returns the result of a build
+type FrameworkServiceBuildResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ServiceBuildResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *FrameworkServiceBuildResponse) Reset() {
+	*x = FrameworkServiceBuildResponse{}
+	mi := &file_framework_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceBuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceBuildResponse) ProtoMessage() {}
+
+func (x *FrameworkServiceBuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceBuildResponse.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceBuildResponse) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FrameworkServiceBuildResponse) GetResult() *ServiceBuildResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Server requests the framework service to package a service
+type FrameworkServicePackageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Configuration of the service to package.
+	Service *ServiceConfig `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Result of the build of the service, when it was built.
+	Build *ServiceBuildResult `protobuf:"bytes,2,opt,name=build,proto3" json:"build,omitempty"`
+}
+
+func (x *FrameworkServicePackageRequest) Reset() {
+	*x = FrameworkServicePackageRequest{}
+	mi := &file_framework_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServicePackageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServicePackageRequest) ProtoMessage() {}
+
+func (x *FrameworkServicePackageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServicePackageRequest.ProtoReflect.Descriptor instead.
+func (*FrameworkServicePackageRequest) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FrameworkServicePackageRequest) GetService() *ServiceConfig {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *FrameworkServicePackageRequest) GetBuild() *ServiceBuildResult {
+	if x != nil {
+		return x.Build
+	}
+	return nil
+}
+
+// Client returns the package of a service
+type FrameworkServicePackageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Package *ServicePackageResult `protobuf:"bytes,1,opt,name=package,proto3" json:"package,omitempty"`
+}
+
+func (x *FrameworkServicePackageResponse) Reset() {
+	*x = FrameworkServicePackageResponse{}
+	mi := &file_framework_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServicePackageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServicePackageResponse) ProtoMessage() {}
+
+func (x *FrameworkServicePackageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServicePackageResponse.ProtoReflect.Descriptor instead.
+func (*FrameworkServicePackageResponse) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FrameworkServicePackageResponse) GetPackage() *ServicePackageResult {
+	if x != nil {
+		return x.Package
+	}
+	return nil
+}
+
+// Client reports the progress of a restore, build or package request
+type FrameworkServiceProgressMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Progress message displayed to the user.
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Time of the progress update, in milliseconds since the Unix epoch.
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *FrameworkServiceProgressMessage) Reset() {
+	*x = FrameworkServiceProgressMessage{}
+	mi := &file_framework_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceProgressMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceProgressMessage) ProtoMessage() {}
+
+func (x *FrameworkServiceProgressMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceProgressMessage.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceProgressMessage) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FrameworkServiceProgressMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *FrameworkServiceProgressMessage) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// Client signals that all its framework services are registered and it's ready to receive requests
+type FrameworkServiceReadyMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *FrameworkServiceReadyMessage) Reset() {
+	*x = FrameworkServiceReadyMessage{}
+	mi := &file_framework_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceReadyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceReadyMessage) ProtoMessage() {}
+
+func (x *FrameworkServiceReadyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceReadyMessage.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceReadyMessage) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{11}
+}
+
+// ServiceRestoreResult message definition
+type ServiceRestoreResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Details map[string]string `protobuf:"bytes,1,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ServiceRestoreResult) Reset() {
+	*x = ServiceRestoreResult{}
+	mi := &file_framework_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceRestoreResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceRestoreResult) ProtoMessage() {}
+
+func (x *ServiceRestoreResult) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceRestoreResult.ProtoReflect.Descriptor instead.
+func (*ServiceRestoreResult) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ServiceRestoreResult) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// ServiceBuildResult message definition
+type ServiceBuildResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Restore         *ServiceRestoreResult `protobuf:"bytes,1,opt,name=restore,proto3" json:"restore,omitempty"`
+	BuildOutputPath string                `protobuf:"bytes,2,opt,name=build_output_path,json=buildOutputPath,proto3" json:"build_output_path,omitempty"`
+	Details         map[string]string     `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ServiceBuildResult) Reset() {
+	*x = ServiceBuildResult{}
+	mi := &file_framework_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceBuildResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceBuildResult) ProtoMessage() {}
+
+func (x *ServiceBuildResult) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceBuildResult.ProtoReflect.Descriptor instead.
+func (*ServiceBuildResult) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ServiceBuildResult) GetRestore() *ServiceRestoreResult {
+	if x != nil {
+		return x.Restore
+	}
+	return nil
+}
+
+func (x *ServiceBuildResult) GetBuildOutputPath() string {
+	if x != nil {
+		return x.BuildOutputPath
+	}
+	return ""
+}
+
+func (x *ServiceBuildResult) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+var File_framework_service_proto protoreflect.FileDescriptor
+
+var file_framework_service_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x61, 0x7a, 0x64, 0x65, 0x78,
+	0x74, 0x1a, 0x0c, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x14, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf2, 0x07, 0x0a, 0x17, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77,
+	0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64,
+	0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x76, 0x0a, 0x22, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x1f, 0x72, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x79, 0x0a,
+	0x23, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x77,
+	0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x61, 0x7a, 0x64,
+	0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x20, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x26, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65,
+	0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x72, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x54, 0x0a, 0x10, 0x72,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00,
+	0x52, 0x0f, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4b, 0x0a, 0x0d, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78,
+	0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4e,
+	0x0a, 0x0e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e,
+	0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52,
+	0x0d, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51,
+	0x0a, 0x0f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74,
+	0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x54, 0x0a, 0x10, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x7a,
+	0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65,
+	0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x4b, 0x0a,
+	0x0d, 0x72, 0x65, 0x61, 0x64, 0x79, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72,
+	0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65,
+	0x61, 0x64, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65,
+	0x61, 0x64, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0x80, 0x01, 0x0a, 0x1f, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x41, 0x0a, 0x0c, 0x72, 0x65,
+	0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77,
+	0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x22, 0x0a,
+	0x20, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f,
+	0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x65, 0x0a, 0x15, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65,
+	0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x22, 0x51, 0x0a, 0x1e, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x7a,
+	0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x22, 0x57, 0x0a, 0x1f, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x22, 0x87, 0x01, 0x0a, 0x1c, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f,
+	0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x22, 0x53,
+	0x0a, 0x1d, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x32, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x22, 0x83, 0x01, 0x0a, 0x1e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72,
+	0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x05, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x22, 0x59, 0x0a, 0x1f, 0x46, 0x72, 0x61,
+	0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x07,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x22, 0x59, 0x0a, 0x1f, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72,
+	0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22,
+	0x1e, 0x0a, 0x1c, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x65, 0x61, 0x64, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x97, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x43, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x61, 0x7a, 0x64, 0x65,
+	0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x1a, 0x3a, 0x0a,
+	0x0c, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf7, 0x01, 0x0a, 0x12, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x36, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52,
+	0x07, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x50, 0x61, 0x74, 0x68, 0x12, 0x41, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x32, 0x62, 0x0a, 0x10, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4e, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x12, 0x1f, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65,
+	0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x1a, 0x1f, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x7a, 0x75, 0x72,
+	0x65, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x63, 0x6c, 0x69, 0x2f, 0x61, 0x7a, 0x64, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_framework_service_proto_rawDescOnce sync.Once
+	file_framework_service_proto_rawDescData = file_framework_service_proto_rawDesc
+)
+
+func file_framework_service_proto_rawDescGZIP() []byte {
+	file_framework_service_proto_rawDescOnce.Do(func() {
+		file_framework_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_framework_service_proto_rawDescData)
+	})
+	return file_framework_service_proto_rawDescData
+}
+
+var file_framework_service_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_framework_service_proto_goTypes = []any{
+	(*FrameworkServiceMessage)(nil),          // 0: azdext.FrameworkServiceMessage
+	(*RegisterFrameworkServiceRequest)(nil),  // 1: azdext.RegisterFrameworkServiceRequest
+	(*RegisterFrameworkServiceResponse)(nil), // 2: azdext.RegisterFrameworkServiceResponse
+	(*FrameworkRequirements)(nil),            // 3: azdext.FrameworkRequirements
+	(*FrameworkServiceRestoreRequest)(nil),   // 4: azdext.FrameworkServiceRestoreRequest
+	(*FrameworkServiceRestoreResponse)(nil),  // 5: azdext.FrameworkServiceRestoreResponse
+	(*FrameworkServiceBuildRequest)(nil),     // 6: azdext.FrameworkServiceBuildRequest
+	(*FrameworkServiceBuildResponse)(nil),    // 7: azdext.FrameworkServiceBuildResponse
+	(*FrameworkServicePackageRequest)(nil),   // 8: azdext.FrameworkServicePackageRequest
+	(*FrameworkServicePackageResponse)(nil),  // 9: azdext.FrameworkServicePackageResponse
+	(*FrameworkServiceProgressMessage)(nil),  // 10: azdext.FrameworkServiceProgressMessage
+	(*FrameworkServiceReadyMessage)(nil),     // 11: azdext.FrameworkServiceReadyMessage
+	(*ServiceRestoreResult)(nil),             // 12: azdext.ServiceRestoreResult
+	(*ServiceBuildResult)(nil),               // 13: azdext.ServiceBuildResult
+	nil,                                      // 14: azdext.ServiceRestoreResult.DetailsEntry
+	nil,                                      // 15: azdext.ServiceBuildResult.DetailsEntry
+	(*ServiceConfig)(nil),                    // 16: azdext.ServiceConfig
+	(*ServicePackageResult)(nil),             // 17: azdext.ServicePackageResult
+}
+var file_framework_service_proto_depIdxs = []int32{
+	1,  // 0: azdext.FrameworkServiceMessage.register_framework_service_request:type_name -> azdext.RegisterFrameworkServiceRequest
+	2,  // 1: azdext.FrameworkServiceMessage.register_framework_service_response:type_name -> azdext.RegisterFrameworkServiceResponse
+	4,  // 2: azdext.FrameworkServiceMessage.restore_request:type_name -> azdext.FrameworkServiceRestoreRequest
+	5,  // 3: azdext.FrameworkServiceMessage.restore_response:type_name -> azdext.FrameworkServiceRestoreResponse
+	6,  // 4: azdext.FrameworkServiceMessage.build_request:type_name -> azdext.FrameworkServiceBuildRequest
+	7,  // 5: azdext.FrameworkServiceMessage.build_response:type_name -> azdext.FrameworkServiceBuildResponse
+	8,  // 6: azdext.FrameworkServiceMessage.package_request:type_name -> azdext.FrameworkServicePackageRequest
+	9,  // 7: azdext.FrameworkServiceMessage.package_response:type_name -> azdext.FrameworkServicePackageResponse
+	10, // 8: azdext.FrameworkServiceMessage.progress_message:type_name -> azdext.FrameworkServiceProgressMessage
+	11, // 9: azdext.FrameworkServiceMessage.ready_message:type_name -> azdext.FrameworkServiceReadyMessage
+	3,  // 10: azdext.RegisterFrameworkServiceRequest.requirements:type_name -> azdext.FrameworkRequirements
+	16, // 11: azdext.FrameworkServiceRestoreRequest.service:type_name -> azdext.ServiceConfig
+	12, // 12: azdext.FrameworkServiceRestoreResponse.result:type_name -> azdext.ServiceRestoreResult
+	16, // 13: azdext.FrameworkServiceBuildRequest.service:type_name -> azdext.ServiceConfig
+	12, // 14: azdext.FrameworkServiceBuildRequest.restore:type_name -> azdext.ServiceRestoreResult
+	13, // 15: azdext.FrameworkServiceBuildResponse.result:type_name -> azdext.ServiceBuildResult
+	16, // 16: azdext.FrameworkServicePackageRequest.service:type_name -> azdext.ServiceConfig
+	13, // 17: azdext.FrameworkServicePackageRequest.build:type_name -> azdext.ServiceBuildResult
+	17, // 18: azdext.FrameworkServicePackageResponse.package:type_name -> azdext.ServicePackageResult
+	14, // 19: azdext.ServiceRestoreResult.details:type_name -> azdext.ServiceRestoreResult.DetailsEntry
+	12, // 20: azdext.ServiceBuildResult.restore:type_name -> azdext.ServiceRestoreResult
+	15, // 21: azdext.ServiceBuildResult.details:type_name -> azdext.ServiceBuildResult.DetailsEntry
+	0,  // 22: azdext.FrameworkService.Stream:input_type -> azdext.FrameworkServiceMessage
+	0,  // 23: azdext.FrameworkService.Stream:output_type -> azdext.FrameworkServiceMessage
+	23, // [23:24] is the sub-list for method output_type
+	22, // [22:23] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_framework_service_proto_init() }
+func file_framework_service_proto_init() {
+	if File_framework_service_proto != nil {
+		return
+	}
+	file_models_proto_init()
+	file_service_target_proto_init()
+	file_framework_service_proto_msgTypes[0].OneofWrappers = []any{
+		(*FrameworkServiceMessage_RegisterFrameworkServiceRequest)(nil),
+		(*FrameworkServiceMessage_RegisterFrameworkServiceResponse)(nil),
+		(*FrameworkServiceMessage_RestoreRequest)(nil),
+		(*FrameworkServiceMessage_RestoreResponse)(nil),
+		(*FrameworkServiceMessage_BuildRequest)(nil),
+		(*FrameworkServiceMessage_BuildResponse)(nil),
+		(*FrameworkServiceMessage_PackageRequest)(nil),
+		(*FrameworkServiceMessage_PackageResponse)(nil),
+		(*FrameworkServiceMessage_ProgressMessage)(nil),
+		(*FrameworkServiceMessage_ReadyMessage)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_framework_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_framework_service_proto_goTypes,
+		DependencyIndexes: file_framework_service_proto_depIdxs,
+		MessageInfos:      file_framework_service_proto_msgTypes,
+	}.Build()
+	File_framework_service_proto = out.File
+	file_framework_service_proto_rawDesc = nil
+	file_framework_service_proto_goTypes = nil
+	file_framework_service_proto_depIdxs = nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// FrameworkServiceProvider provides the framework service of a language that azd doesn't support,
+// such as the build system of the language.
+type FrameworkServiceProvider interface {
+	// Requirements gets the lifecycle commands that the framework service requires before packaging
+	Requirements() *FrameworkRequirements
+
+	// Restore restores the dependencies of the service
+	Restore(ctx context.Context, service *ServiceConfig, progress ProgressReporter) (*ServiceRestoreResult, error)
+
+	// Build builds the source of the service
+	Build(
+		ctx context.Context,
+		service *ServiceConfig,
+		restore *ServiceRestoreResult,
+		progress ProgressReporter,
+	) (*ServiceBuildResult, error)
+
+	// Package packages the service suitable for deployment
+	Package(
+		ctx context.Context,
+		service *ServiceConfig,
+		build *ServiceBuildResult,
+		progress ProgressReporter,
+	) (*ServicePackageResult, error)
+}
+
+type FrameworkServiceManager struct {
+	manager *providerManager[FrameworkServiceMessage, *FrameworkServiceMessage, FrameworkServiceProvider]
+}
+
+func NewFrameworkServiceManager(azdClient *AzdClient) *FrameworkServiceManager {
+	m := &FrameworkServiceManager{}
+	m.manager = &providerManager[FrameworkServiceMessage, *FrameworkServiceMessage, FrameworkServiceProvider]{
+		kind: "framework service",
+		key:  "language",
+		openStream: func(ctx context.Context) (
+			grpc.BidiStreamingClient[FrameworkServiceMessage, FrameworkServiceMessage], error) {
+			return azdClient.FrameworkService().Stream(ctx)
+		},
+		newReadyMessage: func() *FrameworkServiceMessage {
+			return &FrameworkServiceMessage{
+				MessageType: &FrameworkServiceMessage_ReadyMessage{
+					ReadyMessage: &FrameworkServiceReadyMessage{},
+				},
+			}
+		},
+		newProgressMessage: func(request *FrameworkServiceMessage, message string) *FrameworkServiceMessage {
+			return &FrameworkServiceMessage{
+				RequestId: request.RequestId,
+				MessageType: &FrameworkServiceMessage_ProgressMessage{
+					ProgressMessage: &FrameworkServiceProgressMessage{
+						Message:   message,
+						Timestamp: time.Now().UnixMilli(),
+					},
+				},
+			}
+		},
+		handle:    m.handleRequest,
+		providers: make(map[string]FrameworkServiceProvider),
+	}
+
+	return m
+}
+
+func (m *FrameworkServiceManager) Close() error {
+	return m.manager.close()
+}
+
+// Register registers the provider of the framework service for the language.
+// Framework services must be registered before calling Receive.
+func (m *FrameworkServiceManager) Register(
+	ctx context.Context,
+	language string,
+	provider FrameworkServiceProvider,
+) error {
+	request := &FrameworkServiceMessage{
+		MessageType: &FrameworkServiceMessage_RegisterFrameworkServiceRequest{
+			RegisterFrameworkServiceRequest: &RegisterFrameworkServiceRequest{
+				Language:     language,
+				Requirements: provider.Requirements(),
+			},
+		},
+	}
+
+	return m.manager.register(ctx, language, provider, request, func(msg *FrameworkServiceMessage) bool {
+		return msg.GetRegisterFrameworkServiceResponse() != nil
+	})
+}
+
+// Receive signals azd that the registered framework services are ready,
+// then handles the requests of azd to the framework services until the stream is closed.
+func (m *FrameworkServiceManager) Receive(ctx context.Context) error {
+	return m.manager.receive(ctx)
+}
+
+func (m *FrameworkServiceManager) handleRequest(
+	ctx context.Context,
+	msg *FrameworkServiceMessage,
+	progress ProgressReporter,
+) (*FrameworkServiceMessage, error) {
+	response := &FrameworkServiceMessage{}
+
+	var err error
+	switch msg.MessageType.(type) {
+	case *FrameworkServiceMessage_RestoreRequest:
+		request := msg.GetRestoreRequest()
+		var result *ServiceRestoreResult
+		provider, providerErr := m.manager.provider(request.Service.GetLanguage())
+		if err = providerErr; err == nil {
+			result, err = provider.Restore(ctx, request.Service, progress)
+		}
+
+		response.MessageType = &FrameworkServiceMessage_RestoreResponse{
+			RestoreResponse: &FrameworkServiceRestoreResponse{Result: result},
+		}
+	case *FrameworkServiceMessage_BuildRequest:
+		request := msg.GetBuildRequest()
+		var result *ServiceBuildResult
+		provider, providerErr := m.manager.provider(request.Service.GetLanguage())
+		if err = providerErr; err == nil {
+			result, err = provider.Build(ctx, request.Service, request.Restore, progress)
+		}
+
+		response.MessageType = &FrameworkServiceMessage_BuildResponse{
+			BuildResponse: &FrameworkServiceBuildResponse{Result: result},
+		}
+	case *FrameworkServiceMessage_PackageRequest:
+		request := msg.GetPackageRequest()
+		var result *ServicePackageResult
+		provider, providerErr := m.manager.provider(request.Service.GetLanguage())
+		if err = providerErr; err == nil {
+			result, err = provider.Package(ctx, request.Service, request.Build, progress)
+		}
+
+		response.MessageType = &FrameworkServiceMessage_PackageResponse{
+			PackageResponse: &FrameworkServicePackageResponse{Package: result},
+		}
+	default:
+		return nil, fmt.Errorf("%w %T", errUnhandledMessage, msg.MessageType)
+	}
+
+	return response, err
+}
@@ -0,0 +1,279 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProviderMessage is a message of the streams between azd and the providers of extensions,
+// such as service targets, framework services and provisioning providers.
+type ProviderMessage interface {
+	GetRequestId() string
+	SetRequestId(requestId string)
+	GetErrorMessage() string
+	SetErrorMessage(errorMessage string)
+
+	// ProgressUpdate returns the progress update carried by the message, or nil for other messages
+	ProgressUpdate() ProgressUpdate
+	// IsReady reports whether the message signals that all the providers of the extension are registered
+	IsReady() bool
+}
+
+// ProgressUpdate is the progress of a request, reported by the provider handling the request.
+type ProgressUpdate interface {
+	GetMessage() string
+	GetTimestamp() int64
+}
+
+// providerMessage is implemented by the pointers to the messages of the provider streams
+type providerMessage[T any] interface {
+	*T
+	ProviderMessage
+}
+
+var errUnhandledMessage = errors.New("unhandled message type")
+
+// providerManager handles the stream of the providers of a kind between azd and the extension: it registers the
+// providers, signals readiness, and dispatches the requests of azd to the providers.
+type providerManager[T any, M providerMessage[T], P any] struct {
+	// Kind of the providers and name of the key they are registered for, e.g. "service target" and "host"
+	kind string
+	key  string
+
+	openStream         func(ctx context.Context) (grpc.BidiStreamingClient[T, T], error)
+	newReadyMessage    func() M
+	newProgressMessage func(request M, message string) M
+	// handle sends the request to its provider and returns the response, with the error of the provider
+	handle func(ctx context.Context, request M, progress ProgressReporter) (M, error)
+
+	stream    grpc.BidiStreamingClient[T, T]
+	providers map[string]P
+
+	// Serializes sending messages, since requests are handled concurrently
+	sendMu sync.Mutex
+}
+
+func (m *providerManager[T, M, P]) close() error {
+	if m.stream != nil {
+		return m.stream.CloseSend()
+	}
+
+	return nil
+}
+
+func (m *providerManager[T, M, P]) init(ctx context.Context) error {
+	if m.stream == nil {
+		stream, err := m.openStream(ctx)
+		if err != nil {
+			return err
+		}
+
+		m.stream = stream
+	}
+
+	return nil
+}
+
+// register sends the registration request of the provider and waits for its response.
+// isResponse reports whether a message is the response to the registration request.
+func (m *providerManager[T, M, P]) register(
+	ctx context.Context,
+	name string,
+	provider P,
+	request M,
+	isResponse func(msg M) bool,
+) error {
+	if err := m.init(ctx); err != nil {
+		return err
+	}
+
+	requestId := uuid.NewString()
+	request.SetRequestId(requestId)
+	if err := m.send(request); err != nil {
+		return err
+	}
+
+	var msg M
+	msg, err := m.stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	if msg.GetRequestId() != requestId || !isResponse(msg) {
+		return fmt.Errorf("unexpected response to the registration of %s '%s'", m.key, name)
+	}
+
+	if msg.GetErrorMessage() != "" {
+		return errors.New(msg.GetErrorMessage())
+	}
+
+	m.providers[name] = provider
+
+	return nil
+}
+
+// receive signals azd that the registered providers are ready,
+// then handles the requests of azd to the providers until the stream is closed.
+func (m *providerManager[T, M, P]) receive(ctx context.Context) error {
+	if err := m.init(ctx); err != nil {
+		return err
+	}
+
+	if err := m.send(m.newReadyMessage()); err != nil {
+		return fmt.Errorf("failed signaling readiness: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Context cancelled by caller, exiting receive")
+			return nil
+		default:
+			var msg M
+			msg, err := m.stream.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					log.Println("Stream closed by server (EOF), treating as expected")
+					return nil
+				}
+
+				if st, ok := status.FromError(err); ok {
+					if st.Code() == codes.Unavailable {
+						log.Println("Stream closed by server (unavailable), treating as expected")
+						return nil
+					}
+				}
+
+				return err
+			}
+
+			// Requests are handled concurrently, since azd can run the operations of several services at the same time
+			go func() {
+				if err := m.handleRequest(ctx, msg); err != nil {
+					log.Printf("handleRequest error for request %s: %v", msg.GetRequestId(), err)
+				}
+			}()
+		}
+	}
+}
+
+func (m *providerManager[T, M, P]) handleRequest(ctx context.Context, msg M) error {
+	progress := func(message string) {
+		if err := m.send(m.newProgressMessage(msg, message)); err != nil {
+			log.Printf("failed sending progress for request %s: %v", msg.GetRequestId(), err)
+		}
+	}
+
+	response, err := m.handle(ctx, msg, progress)
+	if errors.Is(err, errUnhandledMessage) {
+		return err
+	}
+
+	response.SetRequestId(msg.GetRequestId())
+	if err != nil {
+		response.SetErrorMessage(err.Error())
+	}
+
+	return m.send(response)
+}
+
+// provider gets the provider registered for the name
+func (m *providerManager[T, M, P]) provider(name string) (P, error) {
+	provider, has := m.providers[name]
+	if !has {
+		return provider, fmt.Errorf("no %s registered for %s '%s'", m.kind, m.key, name)
+	}
+
+	return provider, nil
+}
+
+func (m *providerManager[T, M, P]) send(msg M) error {
+	m.sendMu.Lock()
+	defer m.sendMu.Unlock()
+
+	return m.stream.Send(msg)
+}
+
+// SetRequestId sets the id correlating the message with its request
+func (x *ServiceTargetMessage) SetRequestId(requestId string) {
+	x.RequestId = requestId
+}
+
+// SetErrorMessage sets the error message of a failed request
+func (x *ServiceTargetMessage) SetErrorMessage(errorMessage string) {
+	x.ErrorMessage = errorMessage
+}
+
+// ProgressUpdate returns the progress message, or nil for other messages
+func (x *ServiceTargetMessage) ProgressUpdate() ProgressUpdate {
+	if progressMessage := x.GetProgressMessage(); progressMessage != nil {
+		return progressMessage
+	}
+
+	return nil
+}
+
+// IsReady reports whether the message is a ready message
+func (x *ServiceTargetMessage) IsReady() bool {
+	return x.GetReadyMessage() != nil
+}
+
+// SetRequestId sets the id correlating the message with its request
+func (x *FrameworkServiceMessage) SetRequestId(requestId string) {
+	x.RequestId = requestId
+}
+
+// SetErrorMessage sets the error message of a failed request
+func (x *FrameworkServiceMessage) SetErrorMessage(errorMessage string) {
+	x.ErrorMessage = errorMessage
+}
+
+// ProgressUpdate returns the progress message, or nil for other messages
+func (x *FrameworkServiceMessage) ProgressUpdate() ProgressUpdate {
+	if progressMessage := x.GetProgressMessage(); progressMessage != nil {
+		return progressMessage
+	}
+
+	return nil
+}
+
+// IsReady reports whether the message is a ready message
+func (x *FrameworkServiceMessage) IsReady() bool {
+	return x.GetReadyMessage() != nil
+}
+
+// SetRequestId sets the id correlating the message with its request
+func (x *ProvisioningMessage) SetRequestId(requestId string) {
+	x.RequestId = requestId
+}
+
+// SetErrorMessage sets the error message of a failed request
+func (x *ProvisioningMessage) SetErrorMessage(errorMessage string) {
+	x.ErrorMessage = errorMessage
+}
+
+// ProgressUpdate returns the progress message, or nil for other messages
+func (x *ProvisioningMessage) ProgressUpdate() ProgressUpdate {
+	if progressMessage := x.GetProgressMessage(); progressMessage != nil {
+		return progressMessage
+	}
+
+	return nil
+}
+
+// IsReady reports whether the message is a ready message
+func (x *ProvisioningMessage) IsReady() bool {
+	return x.GetReadyMessage() != nil
+}
@@ -0,0 +1,1679 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.29.1
+// source: provisioning.proto
+
+package azdext
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents different types of messages sent over the stream
+type ProvisioningMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Correlates responses and progress updates with the request they belong to.
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Error message of a failed request, set on responses.
+	ErrorMessage string `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// Name of the provisioning provider that the request is sent to.
+	Provider string `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	// Types that are assignable to MessageType:
+	//
+	//	*ProvisioningMessage_RegisterProvisioningProviderRequest
+	//	*ProvisioningMessage_RegisterProvisioningProviderResponse
+	//	*ProvisioningMessage_InitializeRequest
+	//	*ProvisioningMessage_InitializeResponse
+	//	*ProvisioningMessage_StateRequest
+	//	*ProvisioningMessage_StateResponse
+	//	*ProvisioningMessage_DeployRequest
+	//	*ProvisioningMessage_DeployResponse
+	//	*ProvisioningMessage_PreviewRequest
+	//	*ProvisioningMessage_PreviewResponse
+	//	*ProvisioningMessage_DestroyRequest
+	//	*ProvisioningMessage_DestroyResponse
+	//	*ProvisioningMessage_EnsureEnvRequest
+	//	*ProvisioningMessage_EnsureEnvResponse
+	//	*ProvisioningMessage_ProgressMessage
+	//	*ProvisioningMessage_ReadyMessage
+	MessageType isProvisioningMessage_MessageType `protobuf_oneof:"message_type"`
+}
+
+func (x *ProvisioningMessage) Reset() {
+	*x = ProvisioningMessage{}
+	mi := &file_provisioning_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningMessage) ProtoMessage() {}
+
+func (x *ProvisioningMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningMessage.ProtoReflect.Descriptor instead.
+func (*ProvisioningMessage) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProvisioningMessage) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ProvisioningMessage) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ProvisioningMessage) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (m *ProvisioningMessage) GetMessageType() isProvisioningMessage_MessageType {
+	if m != nil {
+		return m.MessageType
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetRegisterProvisioningProviderRequest() *RegisterProvisioningProviderRequest {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_RegisterProvisioningProviderRequest); ok {
+		return x.RegisterProvisioningProviderRequest
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetRegisterProvisioningProviderResponse() *RegisterProvisioningProviderResponse {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_RegisterProvisioningProviderResponse); ok {
+		return x.RegisterProvisioningProviderResponse
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetInitializeRequest() *ProvisioningInitializeRequest {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_InitializeRequest); ok {
+		return x.InitializeRequest
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetInitializeResponse() *ProvisioningInitializeResponse {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_InitializeResponse); ok {
+		return x.InitializeResponse
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetStateRequest() *ProvisioningStateRequest {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_StateRequest); ok {
+		return x.StateRequest
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetStateResponse() *ProvisioningStateResponse {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_StateResponse); ok {
+		return x.StateResponse
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetDeployRequest() *ProvisioningDeployRequest {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_DeployRequest); ok {
+		return x.DeployRequest
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetDeployResponse() *ProvisioningDeployResponse {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_DeployResponse); ok {
+		return x.DeployResponse
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetPreviewRequest() *ProvisioningPreviewRequest {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_PreviewRequest); ok {
+		return x.PreviewRequest
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetPreviewResponse() *ProvisioningPreviewResponse {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_PreviewResponse); ok {
+		return x.PreviewResponse
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetDestroyRequest() *ProvisioningDestroyRequest {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_DestroyRequest); ok {
+		return x.DestroyRequest
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetDestroyResponse() *ProvisioningDestroyResponse {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_DestroyResponse); ok {
+		return x.DestroyResponse
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetEnsureEnvRequest() *ProvisioningEnsureEnvRequest {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_EnsureEnvRequest); ok {
+		return x.EnsureEnvRequest
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetEnsureEnvResponse() *ProvisioningEnsureEnvResponse {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_EnsureEnvResponse); ok {
+		return x.EnsureEnvResponse
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetProgressMessage() *ProvisioningProgressMessage {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_ProgressMessage); ok {
+		return x.ProgressMessage
+	}
+	return nil
+}
+
+func (x *ProvisioningMessage) GetReadyMessage() *ProvisioningReadyMessage {
+	if x, ok := x.GetMessageType().(*ProvisioningMessage_ReadyMessage); ok {
+		return x.ReadyMessage
+	}
+	return nil
+}
+
+type isProvisioningMessage_MessageType interface {
+	isProvisioningMessage_MessageType()
+}
+
+type ProvisioningMessage_RegisterProvisioningProviderRequest struct {
+	RegisterProvisioningProviderRequest *RegisterProvisioningProviderRequest `protobuf:"bytes,4,opt,name=register_provisioning_provider_request,json=registerProvisioningProviderRequest,proto3,oneof"`
+}
+
+type ProvisioningMessage_RegisterProvisioningProviderResponse struct {
+	RegisterProvisioningProviderResponse *RegisterProvisioningProviderResponse `protobuf:"bytes,5,opt,name=register_provisioning_provider_response,json=registerProvisioningProviderResponse,proto3,oneof"`
+}
+
+type ProvisioningMessage_InitializeRequest struct {
+	InitializeRequest *ProvisioningInitializeRequest `protobuf:"bytes,6,opt,name=initialize_request,json=initializeRequest,proto3,oneof"`
+}
+
+type ProvisioningMessage_InitializeResponse struct {
+	InitializeResponse *ProvisioningInitializeResponse `protobuf:"bytes,7,opt,name=initialize_response,json=initializeResponse,proto3,oneof"`
+}
+
+type ProvisioningMessage_StateRequest struct {
+	StateRequest *ProvisioningStateRequest `protobuf:"bytes,8,opt,name=state_request,json=stateRequest,proto3,oneof"`
+}
+
+type ProvisioningMessage_StateResponse struct {
+	StateResponse *ProvisioningStateResponse `protobuf:"bytes,9,opt,name=state_response,json=stateResponse,proto3,oneof"`
+}
+
+type ProvisioningMessage_DeployRequest struct {
+	DeployRequest *ProvisioningDeployRequest `protobuf:"bytes,10,opt,name=deploy_request,json=deployRequest,proto3,oneof"`
+}
+
+type ProvisioningMessage_DeployResponse struct {
+	DeployResponse *ProvisioningDeployResponse `protobuf:"bytes,11,opt,name=deploy_response,json=deployResponse,proto3,oneof"`
+}
+
+type ProvisioningMessage_PreviewRequest struct {
+	PreviewRequest *ProvisioningPreviewRequest `protobuf:"bytes,12,opt,name=preview_request,json=previewRequest,proto3,oneof"`
+}
+
+type ProvisioningMessage_PreviewResponse struct {
+	PreviewResponse *ProvisioningPreviewResponse `protobuf:"bytes,13,opt,name=preview_response,json=previewResponse,proto3,oneof"`
+}
+
+type ProvisioningMessage_DestroyRequest struct {
+	DestroyRequest *ProvisioningDestroyRequest `protobuf:"bytes,14,opt,name=destroy_request,json=destroyRequest,proto3,oneof"`
+}
+
+type ProvisioningMessage_DestroyResponse struct {
+	DestroyResponse *ProvisioningDestroyResponse `protobuf:"bytes,15,opt,name=destroy_response,json=destroyResponse,proto3,oneof"`
+}
+
+type ProvisioningMessage_EnsureEnvRequest struct {
+	EnsureEnvRequest *ProvisioningEnsureEnvRequest `protobuf:"bytes,16,opt,name=ensure_env_request,json=ensureEnvRequest,proto3,oneof"`
+}
+
+type ProvisioningMessage_EnsureEnvResponse struct {
+	EnsureEnvResponse *ProvisioningEnsureEnvResponse `protobuf:"bytes,17,opt,name=ensure_env_response,json=ensureEnvResponse,proto3,oneof"`
+}
+
+type ProvisioningMessage_ProgressMessage struct {
+	ProgressMessage *ProvisioningProgressMessage `protobuf:"bytes,18,opt,name=progress_message,json=progressMessage,proto3,oneof"`
+}
+
+type ProvisioningMessage_ReadyMessage struct {
+	ReadyMessage *ProvisioningReadyMessage `protobuf:"bytes,19,opt,name=ready_message,json=readyMessage,proto3,oneof"`
+}
+
+func (*ProvisioningMessage_RegisterProvisioningProviderRequest) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_RegisterProvisioningProviderResponse) isProvisioningMessage_MessageType() {
+}
+
+func (*ProvisioningMessage_InitializeRequest) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_InitializeResponse) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_StateRequest) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_StateResponse) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_DeployRequest) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_DeployResponse) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_PreviewRequest) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_PreviewResponse) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_DestroyRequest) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_DestroyResponse) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_EnsureEnvRequest) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_EnsureEnvResponse) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_ProgressMessage) isProvisioningMessage_MessageType() {}
+
+func (*ProvisioningMessage_ReadyMessage) isProvisioningMessage_MessageType() {}
+
+// Client registers the provisioning provider for a provider name
+type RegisterProvisioningProviderRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Provider name used by the infra configuration in azure.yaml, e.g. "opentofu".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *RegisterProvisioningProviderRequest) Reset() {
+	*x = RegisterProvisioningProviderRequest{}
+	mi := &file_provisioning_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterProvisioningProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterProvisioningProviderRequest) ProtoMessage() {}
+
+func (x *RegisterProvisioningProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterProvisioningProviderRequest.ProtoReflect.Descriptor instead.
+func (*RegisterProvisioningProviderRequest) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RegisterProvisioningProviderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Server confirms the registration of the provisioning provider
+type RegisterProvisioningProviderResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RegisterProvisioningProviderResponse) Reset() {
+	*x = RegisterProvisioningProviderResponse{}
+	mi := &file_provisioning_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterProvisioningProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterProvisioningProviderResponse) ProtoMessage() {}
+
+func (x *RegisterProvisioningProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterProvisioningProviderResponse.ProtoReflect.Descriptor instead.
+func (*RegisterProvisioningProviderResponse) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{2}
+}
+
+// Server requests the provisioning provider to initialize for a project
+type ProvisioningInitializeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the project.
+	ProjectPath string `protobuf:"bytes,1,opt,name=project_path,json=projectPath,proto3" json:"project_path,omitempty"`
+	// Infra configuration of the project.
+	Options *InfraOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *ProvisioningInitializeRequest) Reset() {
+	*x = ProvisioningInitializeRequest{}
+	mi := &file_provisioning_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningInitializeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningInitializeRequest) ProtoMessage() {}
+
+func (x *ProvisioningInitializeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningInitializeRequest.ProtoReflect.Descriptor instead.
+func (*ProvisioningInitializeRequest) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProvisioningInitializeRequest) GetProjectPath() string {
+	if x != nil {
+		return x.ProjectPath
+	}
+	return ""
+}
+
+func (x *ProvisioningInitializeRequest) GetOptions() *InfraOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Client confirms the initialization of the provisioning provider
+type ProvisioningInitializeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ProvisioningInitializeResponse) Reset() {
+	*x = ProvisioningInitializeResponse{}
+	mi := &file_provisioning_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningInitializeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningInitializeResponse) ProtoMessage() {}
+
+func (x *ProvisioningInitializeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningInitializeResponse.ProtoReflect.Descriptor instead.
+func (*ProvisioningInitializeResponse) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{4}
+}
+
+// Server requests the current state of the infrastructure
+type ProvisioningStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Value used to lookup the state of a specific deployment.
+	Hint string `protobuf:"bytes,1,opt,name=hint,proto3" json:"hint,omitempty"`
+}
+
+func (x *ProvisioningStateRequest) Reset() {
+	*x = ProvisioningStateRequest{}
+	mi := &file_provisioning_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningStateRequest) ProtoMessage() {}
+
+func (x *ProvisioningStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningStateRequest.ProtoReflect.Descriptor instead.
+func (*ProvisioningStateRequest) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProvisioningStateRequest) GetHint() string {
+	if x != nil {
+		return x.Hint
+	}
+	return ""
+}
+
+// Client returns the current state of the infrastructure
+type ProvisioningStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State *ProvisioningState `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+}
+
+func (x *ProvisioningStateResponse) Reset() {
+	*x = ProvisioningStateResponse{}
+	mi := &file_provisioning_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningStateResponse) ProtoMessage() {}
+
+func (x *ProvisioningStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningStateResponse.ProtoReflect.Descriptor instead.
+func (*ProvisioningStateResponse) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProvisioningStateResponse) GetState() *ProvisioningState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+// Server requests the deployment of the infrastructure
+type ProvisioningDeployRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ProvisioningDeployRequest) Reset() {
+	*x = ProvisioningDeployRequest{}
+	mi := &file_provisioning_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningDeployRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningDeployRequest) ProtoMessage() {}
+
+func (x *ProvisioningDeployRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningDeployRequest.ProtoReflect.Descriptor instead.
+func (*ProvisioningDeployRequest) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{7}
+}
+
+// Client returns the result of the deployment
+type ProvisioningDeployResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ProvisioningDeployResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *ProvisioningDeployResponse) Reset() {
+	*x = ProvisioningDeployResponse{}
+	mi := &file_provisioning_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningDeployResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningDeployResponse) ProtoMessage() {}
+
+func (x *ProvisioningDeployResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningDeployResponse.ProtoReflect.Descriptor instead.
+func (*ProvisioningDeployResponse) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ProvisioningDeployResponse) GetResult() *ProvisioningDeployResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Server requests a preview of the changes of a deployment of the infrastructure
+type ProvisioningPreviewRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ProvisioningPreviewRequest) Reset() {
+	*x = ProvisioningPreviewRequest{}
+	mi := &file_provisioning_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningPreviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningPreviewRequest) ProtoMessage() {}
+
+func (x *ProvisioningPreviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningPreviewRequest.ProtoReflect.Descriptor instead.
+func (*ProvisioningPreviewRequest) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{9}
+}
+
+// Client returns the preview of the changes of a deployment
+type ProvisioningPreviewResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Preview *ProvisioningDeploymentPreview `protobuf:"bytes,1,opt,name=preview,proto3" json:"preview,omitempty"`
+}
+
+func (x *ProvisioningPreviewResponse) Reset() {
+	*x = ProvisioningPreviewResponse{}
+	mi := &file_provisioning_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningPreviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningPreviewResponse) ProtoMessage() {}
+
+func (x *ProvisioningPreviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningPreviewResponse.ProtoReflect.Descriptor instead.
+func (*ProvisioningPreviewResponse) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ProvisioningPreviewResponse) GetPreview() *ProvisioningDeploymentPreview {
+	if x != nil {
+		return x.Preview
+	}
+	return nil
+}
+
+// Server requests the destruction of the infrastructure
+type ProvisioningDestroyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether to delete the resources without confirmation.
+	Force bool `protobuf:"varint,1,opt,name=force,proto3" json:"force,omitempty"`
+	// Whether to purge the resources that support soft delete.
+	Purge bool `protobuf:"varint,2,opt,name=purge,proto3" json:"purge,omitempty"`
+}
+
+func (x *ProvisioningDestroyRequest) Reset() {
+	*x = ProvisioningDestroyRequest{}
+	mi := &file_provisioning_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningDestroyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningDestroyRequest) ProtoMessage() {}
+
+func (x *ProvisioningDestroyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningDestroyRequest.ProtoReflect.Descriptor instead.
+func (*ProvisioningDestroyRequest) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ProvisioningDestroyRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *ProvisioningDestroyRequest) GetPurge() bool {
+	if x != nil {
+		return x.Purge
+	}
+	return false
+}
+
+// Client returns the result of the destruction
+type ProvisioningDestroyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Environment variables that are no longer valid once the infrastructure is destroyed.
+	InvalidatedEnvKeys []string `protobuf:"bytes,1,rep,name=invalidated_env_keys,json=invalidatedEnvKeys,proto3" json:"invalidated_env_keys,omitempty"`
+}
+
+func (x *ProvisioningDestroyResponse) Reset() {
+	*x = ProvisioningDestroyResponse{}
+	mi := &file_provisioning_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningDestroyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningDestroyResponse) ProtoMessage() {}
+
+func (x *ProvisioningDestroyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningDestroyResponse.ProtoReflect.Descriptor instead.
+func (*ProvisioningDestroyResponse) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ProvisioningDestroyResponse) GetInvalidatedEnvKeys() []string {
+	if x != nil {
+		return x.InvalidatedEnvKeys
+	}
+	return nil
+}
+
+// Server requests the provisioning provider to ensure the environment has the values it requires
+type ProvisioningEnsureEnvRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ProvisioningEnsureEnvRequest) Reset() {
+	*x = ProvisioningEnsureEnvRequest{}
+	mi := &file_provisioning_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningEnsureEnvRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningEnsureEnvRequest) ProtoMessage() {}
+
+func (x *ProvisioningEnsureEnvRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningEnsureEnvRequest.ProtoReflect.Descriptor instead.
+func (*ProvisioningEnsureEnvRequest) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{13}
+}
+
+// Client confirms that the environment has the values the provisioning provider requires
+type ProvisioningEnsureEnvResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ProvisioningEnsureEnvResponse) Reset() {
+	*x = ProvisioningEnsureEnvResponse{}
+	mi := &file_provisioning_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningEnsureEnvResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningEnsureEnvResponse) ProtoMessage() {}
+
+func (x *ProvisioningEnsureEnvResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningEnsureEnvResponse.ProtoReflect.Descriptor instead.
+func (*ProvisioningEnsureEnvResponse) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{14}
+}
+
+// Client reports the progress of a request
+type ProvisioningProgressMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Progress message displayed to the user.
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Time of the progress update, in milliseconds since the Unix epoch.
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *ProvisioningProgressMessage) Reset() {
+	*x = ProvisioningProgressMessage{}
+	mi := &file_provisioning_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningProgressMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningProgressMessage) ProtoMessage() {}
+
+func (x *ProvisioningProgressMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningProgressMessage.ProtoReflect.Descriptor instead.
+func (*ProvisioningProgressMessage) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ProvisioningProgressMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ProvisioningProgressMessage) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// Client signals that all its provisioning providers are registered and it's ready to receive requests
+type ProvisioningReadyMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ProvisioningReadyMessage) Reset() {
+	*x = ProvisioningReadyMessage{}
+	mi := &file_provisioning_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningReadyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningReadyMessage) ProtoMessage() {}
+
+func (x *ProvisioningReadyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningReadyMessage.ProtoReflect.Descriptor instead.
+func (*ProvisioningReadyMessage) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{16}
+}
+
+// Output of a deployment of the infrastructure
+type ProvisioningOutputParameter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type of the output: string, number, bool, object or array.
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Value of the output. Values of the number, bool, object and array types are JSON encoded.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Whether the value is sensitive, in which case it is stored as a secret in the environment.
+	Secure bool `protobuf:"varint,3,opt,name=secure,proto3" json:"secure,omitempty"`
+}
+
+func (x *ProvisioningOutputParameter) Reset() {
+	*x = ProvisioningOutputParameter{}
+	mi := &file_provisioning_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningOutputParameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningOutputParameter) ProtoMessage() {}
+
+func (x *ProvisioningOutputParameter) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningOutputParameter.ProtoReflect.Descriptor instead.
+func (*ProvisioningOutputParameter) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ProvisioningOutputParameter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProvisioningOutputParameter) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ProvisioningOutputParameter) GetSecure() bool {
+	if x != nil {
+		return x.Secure
+	}
+	return false
+}
+
+// ProvisioningState message definition
+type ProvisioningState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Outputs map[string]*ProvisioningOutputParameter `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Ids of the resources that make up the application.
+	Resources []string `protobuf:"bytes,2,rep,name=resources,proto3" json:"resources,omitempty"`
+}
+
+func (x *ProvisioningState) Reset() {
+	*x = ProvisioningState{}
+	mi := &file_provisioning_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningState) ProtoMessage() {}
+
+func (x *ProvisioningState) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningState.ProtoReflect.Descriptor instead.
+func (*ProvisioningState) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ProvisioningState) GetOutputs() map[string]*ProvisioningOutputParameter {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *ProvisioningState) GetResources() []string {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+// ProvisioningDeployResult message definition
+type ProvisioningDeployResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Outputs map[string]*ProvisioningOutputParameter `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Reason the deployment was skipped, when the infrastructure was already up to date.
+	SkippedReason string `protobuf:"bytes,2,opt,name=skipped_reason,json=skippedReason,proto3" json:"skipped_reason,omitempty"`
+}
+
+func (x *ProvisioningDeployResult) Reset() {
+	*x = ProvisioningDeployResult{}
+	mi := &file_provisioning_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningDeployResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningDeployResult) ProtoMessage() {}
+
+func (x *ProvisioningDeployResult) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningDeployResult.ProtoReflect.Descriptor instead.
+func (*ProvisioningDeployResult) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ProvisioningDeployResult) GetOutputs() map[string]*ProvisioningOutputParameter {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *ProvisioningDeployResult) GetSkippedReason() string {
+	if x != nil {
+		return x.SkippedReason
+	}
+	return ""
+}
+
+// ProvisioningDeploymentPreview message definition
+type ProvisioningDeploymentPreview struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status  string                                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Changes []*ProvisioningDeploymentPreviewChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *ProvisioningDeploymentPreview) Reset() {
+	*x = ProvisioningDeploymentPreview{}
+	mi := &file_provisioning_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningDeploymentPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningDeploymentPreview) ProtoMessage() {}
+
+func (x *ProvisioningDeploymentPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningDeploymentPreview.ProtoReflect.Descriptor instead.
+func (*ProvisioningDeploymentPreview) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ProvisioningDeploymentPreview) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ProvisioningDeploymentPreview) GetChanges() []*ProvisioningDeploymentPreviewChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// ProvisioningDeploymentPreviewChange message definition
+type ProvisioningDeploymentPreviewChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type of the change: Create, Delete, Deploy, Ignore, Modify, NoChange, Replace or Unsupported.
+	ChangeType   string `protobuf:"bytes,1,opt,name=change_type,json=changeType,proto3" json:"change_type,omitempty"`
+	ResourceId   string `protobuf:"bytes,2,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	ResourceType string `protobuf:"bytes,3,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	Name         string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *ProvisioningDeploymentPreviewChange) Reset() {
+	*x = ProvisioningDeploymentPreviewChange{}
+	mi := &file_provisioning_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningDeploymentPreviewChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningDeploymentPreviewChange) ProtoMessage() {}
+
+func (x *ProvisioningDeploymentPreviewChange) ProtoReflect() protoreflect.Message {
+	mi := &file_provisioning_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningDeploymentPreviewChange.ProtoReflect.Descriptor instead.
+func (*ProvisioningDeploymentPreviewChange) Descriptor() ([]byte, []int) {
+	return file_provisioning_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ProvisioningDeploymentPreviewChange) GetChangeType() string {
+	if x != nil {
+		return x.ChangeType
+	}
+	return ""
+}
+
+func (x *ProvisioningDeploymentPreviewChange) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *ProvisioningDeploymentPreviewChange) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *ProvisioningDeploymentPreviewChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_provisioning_proto protoreflect.FileDescriptor
+
+var file_provisioning_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x1a, 0x0c, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x81, 0x0c, 0x0a, 0x13, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49,
+	0x64, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x12, 0x82, 0x01, 0x0a, 0x26, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f,
+	0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e,
+	0x67, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x48, 0x00, 0x52, 0x23, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x85, 0x01, 0x0a, 0x27, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e,
+	0x67, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x61, 0x7a, 0x64, 0x65,
+	0x78, 0x74, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x24, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x56, 0x0a, 0x12, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x7a,
+	0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e,
+	0x67, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x11, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x59, 0x0a, 0x13, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61,
+	0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x12,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x47, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x61, 0x7a, 0x64, 0x65,
+	0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4a, 0x0a, 0x0e, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0e, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x0f, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x5f, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69,
+	0x6e, 0x67, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x0e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x7a,
+	0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e,
+	0x67, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x50, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x7a,
+	0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e,
+	0x67, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x5f, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69,
+	0x6e, 0x67, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x48, 0x00, 0x52, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x50, 0x0a, 0x10, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x5f, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69,
+	0x6e, 0x67, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x00, 0x52, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x12, 0x65, 0x6e, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x65,
+	0x6e, 0x76, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x73, 0x75, 0x72, 0x65, 0x45, 0x6e, 0x76, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x10, 0x65, 0x6e, 0x73, 0x75, 0x72, 0x65,
+	0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x57, 0x0a, 0x13, 0x65, 0x6e,
+	0x73, 0x75, 0x72, 0x65, 0x5f, 0x65, 0x6e, 0x76, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74,
+	0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x73,
+	0x75, 0x72, 0x65, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00,
+	0x52, 0x11, 0x65, 0x6e, 0x73, 0x75, 0x72, 0x65, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x47, 0x0a, 0x0d, 0x72, 0x65, 0x61, 0x64, 0x79, 0x5f, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x61, 0x64, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x0c, 0x72, 0x65, 0x61, 0x64, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x0e,
+	0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0x39,
+	0x0a, 0x23, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x26, 0x0a, 0x24, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e,
+	0x67, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x72, 0x0a, 0x1d, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e,
+	0x67, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x2e, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e,
+	0x49, 0x6e, 0x66, 0x72, 0x61, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x20, 0x0a, 0x1e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2e, 0x0a, 0x18, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x68, 0x69, 0x6e, 0x74, 0x22, 0x4c, 0x0a, 0x19, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x1b, 0x0a, 0x19, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x56, 0x0a, 0x1a, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69,
+	0x6e, 0x67, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x38, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x1c, 0x0a, 0x1a, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5e, 0x0a, 0x1b, 0x50, 0x72, 0x6f, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78,
+	0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x44, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52,
+	0x07, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x22, 0x48, 0x0a, 0x1a, 0x50, 0x72, 0x6f, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x70, 0x75, 0x72, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70, 0x75, 0x72,
+	0x67, 0x65, 0x22, 0x4f, 0x0a, 0x1b, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69,
+	0x6e, 0x67, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x30, 0x0a, 0x14, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x65, 0x6e, 0x76, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x12, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x76, 0x4b,
+	0x65, 0x79, 0x73, 0x22, 0x1e, 0x0a, 0x1c, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x69, 0x6e, 0x67, 0x45, 0x6e, 0x73, 0x75, 0x72, 0x65, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x1f, 0x0a, 0x1d, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x69, 0x6e, 0x67, 0x45, 0x6e, 0x73, 0x75, 0x72, 0x65, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x55, 0x0a, 0x1b, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x1a, 0x0a, 0x18, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x61, 0x64, 0x79,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x5f, 0x0a, 0x1b, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x22, 0xd4, 0x01, 0x0a, 0x11, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x40,
+	0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x26, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x1a, 0x5f,
+	0x0a, 0x0c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x39, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0xeb, 0x01, 0x0a, 0x18, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67,
+	0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x47, 0x0a, 0x07,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e,
+	0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x69, 0x6e, 0x67, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73,
+	0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x1a, 0x5f, 0x0a, 0x0c,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x39,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x69, 0x6e, 0x67, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x7e, 0x0a,
+	0x1d, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x45, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74,
+	0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0xa0, 0x01,
+	0x0a, 0x23, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x44, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x32, 0x5d, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x12, 0x1b, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x1b,
+	0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42,
+	0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x7a,
+	0x75, 0x72, 0x65, 0x2f, 0x61, 0x7a, 0x75, 0x72, 0x65, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x63, 0x6c,
+	0x69, 0x2f, 0x61, 0x7a, 0x64, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_provisioning_proto_rawDescOnce sync.Once
+	file_provisioning_proto_rawDescData = file_provisioning_proto_rawDesc
+)
+
+func file_provisioning_proto_rawDescGZIP() []byte {
+	file_provisioning_proto_rawDescOnce.Do(func() {
+		file_provisioning_proto_rawDescData = protoimpl.X.CompressGZIP(file_provisioning_proto_rawDescData)
+	})
+	return file_provisioning_proto_rawDescData
+}
+
+var file_provisioning_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_provisioning_proto_goTypes = []any{
+	(*ProvisioningMessage)(nil),                  // 0: azdext.ProvisioningMessage
+	(*RegisterProvisioningProviderRequest)(nil),  // 1: azdext.RegisterProvisioningProviderRequest
+	(*RegisterProvisioningProviderResponse)(nil), // 2: azdext.RegisterProvisioningProviderResponse
+	(*ProvisioningInitializeRequest)(nil),        // 3: azdext.ProvisioningInitializeRequest
+	(*ProvisioningInitializeResponse)(nil),       // 4: azdext.ProvisioningInitializeResponse
+	(*ProvisioningStateRequest)(nil),             // 5: azdext.ProvisioningStateRequest
+	(*ProvisioningStateResponse)(nil),            // 6: azdext.ProvisioningStateResponse
+	(*ProvisioningDeployRequest)(nil),            // 7: azdext.ProvisioningDeployRequest
+	(*ProvisioningDeployResponse)(nil),           // 8: azdext.ProvisioningDeployResponse
+	(*ProvisioningPreviewRequest)(nil),           // 9: azdext.ProvisioningPreviewRequest
+	(*ProvisioningPreviewResponse)(nil),          // 10: azdext.ProvisioningPreviewResponse
+	(*ProvisioningDestroyRequest)(nil),           // 11: azdext.ProvisioningDestroyRequest
+	(*ProvisioningDestroyResponse)(nil),          // 12: azdext.ProvisioningDestroyResponse
+	(*ProvisioningEnsureEnvRequest)(nil),         // 13: azdext.ProvisioningEnsureEnvRequest
+	(*ProvisioningEnsureEnvResponse)(nil),        // 14: azdext.ProvisioningEnsureEnvResponse
+	(*ProvisioningProgressMessage)(nil),          // 15: azdext.ProvisioningProgressMessage
+	(*ProvisioningReadyMessage)(nil),             // 16: azdext.ProvisioningReadyMessage
+	(*ProvisioningOutputParameter)(nil),          // 17: azdext.ProvisioningOutputParameter
+	(*ProvisioningState)(nil),                    // 18: azdext.ProvisioningState
+	(*ProvisioningDeployResult)(nil),             // 19: azdext.ProvisioningDeployResult
+	(*ProvisioningDeploymentPreview)(nil),        // 20: azdext.ProvisioningDeploymentPreview
+	(*ProvisioningDeploymentPreviewChange)(nil),  // 21: azdext.ProvisioningDeploymentPreviewChange
+	nil,                  // 22: azdext.ProvisioningState.OutputsEntry
+	nil,                  // 23: azdext.ProvisioningDeployResult.OutputsEntry
+	(*InfraOptions)(nil), // 24: azdext.InfraOptions
+}
+var file_provisioning_proto_depIdxs = []int32{
+	1,  // 0: azdext.ProvisioningMessage.register_provisioning_provider_request:type_name -> azdext.RegisterProvisioningProviderRequest
+	2,  // 1: azdext.ProvisioningMessage.register_provisioning_provider_response:type_name -> azdext.RegisterProvisioningProviderResponse
+	3,  // 2: azdext.ProvisioningMessage.initialize_request:type_name -> azdext.ProvisioningInitializeRequest
+	4,  // 3: azdext.ProvisioningMessage.initialize_response:type_name -> azdext.ProvisioningInitializeResponse
+	5,  // 4: azdext.ProvisioningMessage.state_request:type_name -> azdext.ProvisioningStateRequest
+	6,  // 5: azdext.ProvisioningMessage.state_response:type_name -> azdext.ProvisioningStateResponse
+	7,  // 6: azdext.ProvisioningMessage.deploy_request:type_name -> azdext.ProvisioningDeployRequest
+	8,  // 7: azdext.ProvisioningMessage.deploy_response:type_name -> azdext.ProvisioningDeployResponse
+	9,  // 8: azdext.ProvisioningMessage.preview_request:type_name -> azdext.ProvisioningPreviewRequest
+	10, // 9: azdext.ProvisioningMessage.preview_response:type_name -> azdext.ProvisioningPreviewResponse
+	11, // 10: azdext.ProvisioningMessage.destroy_request:type_name -> azdext.ProvisioningDestroyRequest
+	12, // 11: azdext.ProvisioningMessage.destroy_response:type_name -> azdext.ProvisioningDestroyResponse
+	13, // 12: azdext.ProvisioningMessage.ensure_env_request:type_name -> azdext.ProvisioningEnsureEnvRequest
+	14, // 13: azdext.ProvisioningMessage.ensure_env_response:type_name -> azdext.ProvisioningEnsureEnvResponse
+	15, // 14: azdext.ProvisioningMessage.progress_message:type_name -> azdext.ProvisioningProgressMessage
+	16, // 15: azdext.ProvisioningMessage.ready_message:type_name -> azdext.ProvisioningReadyMessage
+	24, // 16: azdext.ProvisioningInitializeRequest.options:type_name -> azdext.InfraOptions
+	18, // 17: azdext.ProvisioningStateResponse.state:type_name -> azdext.ProvisioningState
+	19, // 18: azdext.ProvisioningDeployResponse.result:type_name -> azdext.ProvisioningDeployResult
+	20, // 19: azdext.ProvisioningPreviewResponse.preview:type_name -> azdext.ProvisioningDeploymentPreview
+	22, // 20: azdext.ProvisioningState.outputs:type_name -> azdext.ProvisioningState.OutputsEntry
+	23, // 21: azdext.ProvisioningDeployResult.outputs:type_name -> azdext.ProvisioningDeployResult.OutputsEntry
+	21, // 22: azdext.ProvisioningDeploymentPreview.changes:type_name -> azdext.ProvisioningDeploymentPreviewChange
+	17, // 23: azdext.ProvisioningState.OutputsEntry.value:type_name -> azdext.ProvisioningOutputParameter
+	17, // 24: azdext.ProvisioningDeployResult.OutputsEntry.value:type_name -> azdext.ProvisioningOutputParameter
+	0,  // 25: azdext.ProvisioningService.Stream:input_type -> azdext.ProvisioningMessage
+	0,  // 26: azdext.ProvisioningService.Stream:output_type -> azdext.ProvisioningMessage
+	26, // [26:27] is the sub-list for method output_type
+	25, // [25:26] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_provisioning_proto_init() }
+func file_provisioning_proto_init() {
+	if File_provisioning_proto != nil {
+		return
+	}
+	file_models_proto_init()
+	file_provisioning_proto_msgTypes[0].OneofWrappers = []any{
+		(*ProvisioningMessage_RegisterProvisioningProviderRequest)(nil),
+		(*ProvisioningMessage_RegisterProvisioningProviderResponse)(nil),
+		(*ProvisioningMessage_InitializeRequest)(nil),
+		(*ProvisioningMessage_InitializeResponse)(nil),
+		(*ProvisioningMessage_StateRequest)(nil),
+		(*ProvisioningMessage_StateResponse)(nil),
+		(*ProvisioningMessage_DeployRequest)(nil),
+		(*ProvisioningMessage_DeployResponse)(nil),
+		(*ProvisioningMessage_PreviewRequest)(nil),
+		(*ProvisioningMessage_PreviewResponse)(nil),
+		(*ProvisioningMessage_DestroyRequest)(nil),
+		(*ProvisioningMessage_DestroyResponse)(nil),
+		(*ProvisioningMessage_EnsureEnvRequest)(nil),
+		(*ProvisioningMessage_EnsureEnvResponse)(nil),
+		(*ProvisioningMessage_ProgressMessage)(nil),
+		(*ProvisioningMessage_ReadyMessage)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_provisioning_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_provisioning_proto_goTypes,
+		DependencyIndexes: file_provisioning_proto_depIdxs,
+		MessageInfos:      file_provisioning_proto_msgTypes,
+	}.Build()
+	File_provisioning_proto = out.File
+	file_provisioning_proto_rawDesc = nil
+	file_provisioning_proto_goTypes = nil
+	file_provisioning_proto_depIdxs = nil
+}
@@ -0,0 +1,173 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// ProvisioningProvider provides an infrastructure provisioning provider that azd doesn't support.
+// The outputs of deployments are stored in the azd environment like the outputs of the providers of azd.
+type ProvisioningProvider interface {
+	// Initialize initializes the provider for the project
+	Initialize(ctx context.Context, projectPath string, options *InfraOptions) error
+
+	// State gets the current state of the infrastructure
+	State(ctx context.Context, hint string) (*ProvisioningState, error)
+
+	// Deploy deploys the infrastructure
+	Deploy(ctx context.Context, progress ProgressReporter) (*ProvisioningDeployResult, error)
+
+	// Preview previews the changes of a deployment of the infrastructure
+	Preview(ctx context.Context, progress ProgressReporter) (*ProvisioningDeploymentPreview, error)
+
+	// Destroy destroys the infrastructure, and returns the environment variables that are no longer valid
+	Destroy(ctx context.Context, force bool, purge bool, progress ProgressReporter) ([]string, error)
+
+	// EnsureEnv ensures the environment has the values the provider requires
+	EnsureEnv(ctx context.Context) error
+}
+
+type ProvisioningManager struct {
+	manager *providerManager[ProvisioningMessage, *ProvisioningMessage, ProvisioningProvider]
+}
+
+func NewProvisioningManager(azdClient *AzdClient) *ProvisioningManager {
+	m := &ProvisioningManager{}
+	m.manager = &providerManager[ProvisioningMessage, *ProvisioningMessage, ProvisioningProvider]{
+		kind: "provisioning provider",
+		key:  "provider",
+		openStream: func(ctx context.Context) (
+			grpc.BidiStreamingClient[ProvisioningMessage, ProvisioningMessage], error) {
+			return azdClient.Provisioning().Stream(ctx)
+		},
+		newReadyMessage: func() *ProvisioningMessage {
+			return &ProvisioningMessage{
+				MessageType: &ProvisioningMessage_ReadyMessage{
+					ReadyMessage: &ProvisioningReadyMessage{},
+				},
+			}
+		},
+		newProgressMessage: func(request *ProvisioningMessage, message string) *ProvisioningMessage {
+			return &ProvisioningMessage{
+				RequestId: request.RequestId,
+				Provider:  request.Provider,
+				MessageType: &ProvisioningMessage_ProgressMessage{
+					ProgressMessage: &ProvisioningProgressMessage{
+						Message:   message,
+						Timestamp: time.Now().UnixMilli(),
+					},
+				},
+			}
+		},
+		handle:    m.handleRequest,
+		providers: make(map[string]ProvisioningProvider),
+	}
+
+	return m
+}
+
+func (m *ProvisioningManager) Close() error {
+	return m.manager.close()
+}
+
+// Register registers the provisioning provider for the provider name.
+// Providers must be registered before calling Receive.
+func (m *ProvisioningManager) Register(ctx context.Context, name string, provider ProvisioningProvider) error {
+	request := &ProvisioningMessage{
+		Provider: name,
+		MessageType: &ProvisioningMessage_RegisterProvisioningProviderRequest{
+			RegisterProvisioningProviderRequest: &RegisterProvisioningProviderRequest{
+				Name: name,
+			},
+		},
+	}
+
+	return m.manager.register(ctx, name, provider, request, func(msg *ProvisioningMessage) bool {
+		return msg.GetRegisterProvisioningProviderResponse() != nil
+	})
+}
+
+// Receive signals azd that the registered provisioning providers are ready,
+// then handles the requests of azd to the providers until the stream is closed.
+func (m *ProvisioningManager) Receive(ctx context.Context) error {
+	return m.manager.receive(ctx)
+}
+
+func (m *ProvisioningManager) handleRequest(
+	ctx context.Context,
+	msg *ProvisioningMessage,
+	progress ProgressReporter,
+) (*ProvisioningMessage, error) {
+	response := &ProvisioningMessage{
+		Provider: msg.Provider,
+	}
+
+	provider, err := m.manager.provider(msg.Provider)
+
+	switch msg.MessageType.(type) {
+	case *ProvisioningMessage_InitializeRequest:
+		if err == nil {
+			request := msg.GetInitializeRequest()
+			err = provider.Initialize(ctx, request.ProjectPath, request.Options)
+		}
+
+		response.MessageType = &ProvisioningMessage_InitializeResponse{
+			InitializeResponse: &ProvisioningInitializeResponse{},
+		}
+	case *ProvisioningMessage_StateRequest:
+		var state *ProvisioningState
+		if err == nil {
+			state, err = provider.State(ctx, msg.GetStateRequest().Hint)
+		}
+
+		response.MessageType = &ProvisioningMessage_StateResponse{
+			StateResponse: &ProvisioningStateResponse{State: state},
+		}
+	case *ProvisioningMessage_DeployRequest:
+		var result *ProvisioningDeployResult
+		if err == nil {
+			result, err = provider.Deploy(ctx, progress)
+		}
+
+		response.MessageType = &ProvisioningMessage_DeployResponse{
+			DeployResponse: &ProvisioningDeployResponse{Result: result},
+		}
+	case *ProvisioningMessage_PreviewRequest:
+		var preview *ProvisioningDeploymentPreview
+		if err == nil {
+			preview, err = provider.Preview(ctx, progress)
+		}
+
+		response.MessageType = &ProvisioningMessage_PreviewResponse{
+			PreviewResponse: &ProvisioningPreviewResponse{Preview: preview},
+		}
+	case *ProvisioningMessage_DestroyRequest:
+		var invalidatedEnvKeys []string
+		if err == nil {
+			request := msg.GetDestroyRequest()
+			invalidatedEnvKeys, err = provider.Destroy(ctx, request.Force, request.Purge, progress)
+		}
+
+		response.MessageType = &ProvisioningMessage_DestroyResponse{
+			DestroyResponse: &ProvisioningDestroyResponse{InvalidatedEnvKeys: invalidatedEnvKeys},
+		}
+	case *ProvisioningMessage_EnsureEnvRequest:
+		if err == nil {
+			err = provider.EnsureEnv(ctx)
+		}
+
+		response.MessageType = &ProvisioningMessage_EnsureEnvResponse{
+			EnsureEnvResponse: &ProvisioningEnsureEnvResponse{},
+		}
+	default:
+		return nil, fmt.Errorf("%w %T", errUnhandledMessage, msg.MessageType)
+	}
+
+	return response, err
+}
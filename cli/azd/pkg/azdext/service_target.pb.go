@@ -0,0 +1,1189 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.29.1
+// source: service_target.proto
+
+package azdext
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents different types of messages sent over the stream
+type ServiceTargetMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Correlates responses and progress updates with the request they belong to.
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Error message of a failed request, set on responses.
+	ErrorMessage string `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// Types that are assignable to MessageType:
+	//
+	//	*ServiceTargetMessage_RegisterServiceTargetRequest
+	//	*ServiceTargetMessage_RegisterServiceTargetResponse
+	//	*ServiceTargetMessage_PackageRequest
+	//	*ServiceTargetMessage_PackageResponse
+	//	*ServiceTargetMessage_DeployRequest
+	//	*ServiceTargetMessage_DeployResponse
+	//	*ServiceTargetMessage_EndpointsRequest
+	//	*ServiceTargetMessage_EndpointsResponse
+	//	*ServiceTargetMessage_ProgressMessage
+	//	*ServiceTargetMessage_ReadyMessage
+	MessageType isServiceTargetMessage_MessageType `protobuf_oneof:"message_type"`
+}
+
+func (x *ServiceTargetMessage) Reset() {
+	*x = ServiceTargetMessage{}
+	mi := &file_service_target_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceTargetMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceTargetMessage) ProtoMessage() {}
+
+func (x *ServiceTargetMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceTargetMessage.ProtoReflect.Descriptor instead.
+func (*ServiceTargetMessage) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ServiceTargetMessage) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ServiceTargetMessage) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (m *ServiceTargetMessage) GetMessageType() isServiceTargetMessage_MessageType {
+	if m != nil {
+		return m.MessageType
+	}
+	return nil
+}
+
+func (x *ServiceTargetMessage) GetRegisterServiceTargetRequest() *RegisterServiceTargetRequest {
+	if x, ok := x.GetMessageType().(*ServiceTargetMessage_RegisterServiceTargetRequest); ok {
+		return x.RegisterServiceTargetRequest
+	}
+	return nil
+}
+
+func (x *ServiceTargetMessage) GetRegisterServiceTargetResponse() *RegisterServiceTargetResponse {
+	if x, ok := x.GetMessageType().(*ServiceTargetMessage_RegisterServiceTargetResponse); ok {
+		return x.RegisterServiceTargetResponse
+	}
+	return nil
+}
+
+func (x *ServiceTargetMessage) GetPackageRequest() *ServiceTargetPackageRequest {
+	if x, ok := x.GetMessageType().(*ServiceTargetMessage_PackageRequest); ok {
+		return x.PackageRequest
+	}
+	return nil
+}
+
+func (x *ServiceTargetMessage) GetPackageResponse() *ServiceTargetPackageResponse {
+	if x, ok := x.GetMessageType().(*ServiceTargetMessage_PackageResponse); ok {
+		return x.PackageResponse
+	}
+	return nil
+}
+
+func (x *ServiceTargetMessage) GetDeployRequest() *ServiceTargetDeployRequest {
+	if x, ok := x.GetMessageType().(*ServiceTargetMessage_DeployRequest); ok {
+		return x.DeployRequest
+	}
+	return nil
+}
+
+func (x *ServiceTargetMessage) GetDeployResponse() *ServiceTargetDeployResponse {
+	if x, ok := x.GetMessageType().(*ServiceTargetMessage_DeployResponse); ok {
+		return x.DeployResponse
+	}
+	return nil
+}
+
+func (x *ServiceTargetMessage) GetEndpointsRequest() *ServiceTargetEndpointsRequest {
+	if x, ok := x.GetMessageType().(*ServiceTargetMessage_EndpointsRequest); ok {
+		return x.EndpointsRequest
+	}
+	return nil
+}
+
+func (x *ServiceTargetMessage) GetEndpointsResponse() *ServiceTargetEndpointsResponse {
+	if x, ok := x.GetMessageType().(*ServiceTargetMessage_EndpointsResponse); ok {
+		return x.EndpointsResponse
+	}
+	return nil
+}
+
+func (x *ServiceTargetMessage) GetProgressMessage() *ServiceTargetProgressMessage {
+	if x, ok := x.GetMessageType().(*ServiceTargetMessage_ProgressMessage); ok {
+		return x.ProgressMessage
+	}
+	return nil
+}
+
+func (x *ServiceTargetMessage) GetReadyMessage() *ServiceTargetReadyMessage {
+	if x, ok := x.GetMessageType().(*ServiceTargetMessage_ReadyMessage); ok {
+		return x.ReadyMessage
+	}
+	return nil
+}
+
+type isServiceTargetMessage_MessageType interface {
+	isServiceTargetMessage_MessageType()
+}
+
+type ServiceTargetMessage_RegisterServiceTargetRequest struct {
+	RegisterServiceTargetRequest *RegisterServiceTargetRequest `protobuf:"bytes,3,opt,name=register_service_target_request,json=registerServiceTargetRequest,proto3,oneof"`
+}
+
+type ServiceTargetMessage_RegisterServiceTargetResponse struct {
+	RegisterServiceTargetResponse *RegisterServiceTargetResponse `protobuf:"bytes,4,opt,name=register_service_target_response,json=registerServiceTargetResponse,proto3,oneof"`
+}
+
+type ServiceTargetMessage_PackageRequest struct {
+	PackageRequest *ServiceTargetPackageRequest `protobuf:"bytes,5,opt,name=package_request,json=packageRequest,proto3,oneof"`
+}
+
+type ServiceTargetMessage_PackageResponse struct {
+	PackageResponse *ServiceTargetPackageResponse `protobuf:"bytes,6,opt,name=package_response,json=packageResponse,proto3,oneof"`
+}
+
+type ServiceTargetMessage_DeployRequest struct {
+	DeployRequest *ServiceTargetDeployRequest `protobuf:"bytes,7,opt,name=deploy_request,json=deployRequest,proto3,oneof"`
+}
+
+type ServiceTargetMessage_DeployResponse struct {
+	DeployResponse *ServiceTargetDeployResponse `protobuf:"bytes,8,opt,name=deploy_response,json=deployResponse,proto3,oneof"`
+}
+
+type ServiceTargetMessage_EndpointsRequest struct {
+	EndpointsRequest *ServiceTargetEndpointsRequest `protobuf:"bytes,9,opt,name=endpoints_request,json=endpointsRequest,proto3,oneof"`
+}
+
+type ServiceTargetMessage_EndpointsResponse struct {
+	EndpointsResponse *ServiceTargetEndpointsResponse `protobuf:"bytes,10,opt,name=endpoints_response,json=endpointsResponse,proto3,oneof"`
+}
+
+type ServiceTargetMessage_ProgressMessage struct {
+	ProgressMessage *ServiceTargetProgressMessage `protobuf:"bytes,11,opt,name=progress_message,json=progressMessage,proto3,oneof"`
+}
+
+type ServiceTargetMessage_ReadyMessage struct {
+	ReadyMessage *ServiceTargetReadyMessage `protobuf:"bytes,12,opt,name=ready_message,json=readyMessage,proto3,oneof"`
+}
+
+func (*ServiceTargetMessage_RegisterServiceTargetRequest) isServiceTargetMessage_MessageType() {}
+
+func (*ServiceTargetMessage_RegisterServiceTargetResponse) isServiceTargetMessage_MessageType() {}
+
+func (*ServiceTargetMessage_PackageRequest) isServiceTargetMessage_MessageType() {}
+
+func (*ServiceTargetMessage_PackageResponse) isServiceTargetMessage_MessageType() {}
+
+func (*ServiceTargetMessage_DeployRequest) isServiceTargetMessage_MessageType() {}
+
+func (*ServiceTargetMessage_DeployResponse) isServiceTargetMessage_MessageType() {}
+
+func (*ServiceTargetMessage_EndpointsRequest) isServiceTargetMessage_MessageType() {}
+
+func (*ServiceTargetMessage_EndpointsResponse) isServiceTargetMessage_MessageType() {}
+
+func (*ServiceTargetMessage_ProgressMessage) isServiceTargetMessage_MessageType() {}
+
+func (*ServiceTargetMessage_ReadyMessage) isServiceTargetMessage_MessageType() {}
+
+// Client registers the service target for a host kind
+type RegisterServiceTargetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Host kind used by services in azure.yaml, e.g. "azure.batch".
+	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+}
+
+func (x *RegisterServiceTargetRequest) Reset() {
+	*x = RegisterServiceTargetRequest{}
+	mi := &file_service_target_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterServiceTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterServiceTargetRequest) ProtoMessage() {}
+
+func (x *RegisterServiceTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterServiceTargetRequest.ProtoReflect.Descriptor instead.
+func (*RegisterServiceTargetRequest) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RegisterServiceTargetRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+// Server confirms the registration of the service target
+type RegisterServiceTargetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RegisterServiceTargetResponse) Reset() {
+	*x = RegisterServiceTargetResponse{}
+	mi := &file_service_target_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterServiceTargetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterServiceTargetResponse) ProtoMessage() {}
+
+func (x *RegisterServiceTargetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterServiceTargetResponse.ProtoReflect.Descriptor instead.
+func (*RegisterServiceTargetResponse) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{2}
+}
+
+// Server requests the service target to package a service
+type ServiceTargetPackageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Configuration of the service to package.
+	Service *ServiceConfig `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Package produced by the framework service of the service language.
+	FrameworkPackage *ServicePackageResult `protobuf:"bytes,2,opt,name=framework_package,json=frameworkPackage,proto3" json:"framework_package,omitempty"`
+}
+
+func (x *ServiceTargetPackageRequest) Reset() {
+	*x = ServiceTargetPackageRequest{}
+	mi := &file_service_target_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceTargetPackageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceTargetPackageRequest) ProtoMessage() {}
+
+func (x *ServiceTargetPackageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceTargetPackageRequest.ProtoReflect.Descriptor instead.
+func (*ServiceTargetPackageRequest) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ServiceTargetPackageRequest) GetService() *ServiceConfig {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *ServiceTargetPackageRequest) GetFrameworkPackage() *ServicePackageResult {
+	if x != nil {
+		return x.FrameworkPackage
+	}
+	return nil
+}
+
+// Client returns the package of a service
+type ServiceTargetPackageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Package *ServicePackageResult `protobuf:"bytes,1,opt,name=package,proto3" json:"package,omitempty"`
+}
+
+func (x *ServiceTargetPackageResponse) Reset() {
+	*x = ServiceTargetPackageResponse{}
+	mi := &file_service_target_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceTargetPackageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceTargetPackageResponse) ProtoMessage() {}
+
+func (x *ServiceTargetPackageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceTargetPackageResponse.ProtoReflect.Descriptor instead.
+func (*ServiceTargetPackageResponse) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ServiceTargetPackageResponse) GetPackage() *ServicePackageResult {
+	if x != nil {
+		return x.Package
+	}
+	return nil
+}
+
+// Server requests the service target to deploy a service
+type ServiceTargetDeployRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Configuration of the service to deploy.
+	Service *ServiceConfig `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Package produced by the package request.
+	Package *ServicePackageResult `protobuf:"bytes,2,opt,name=package,proto3" json:"package,omitempty"`
+	// Azure resource tagged for the service, when one exists.
+	TargetResource *TargetResource `protobuf:"bytes,3,opt,name=target_resource,json=targetResource,proto3" json:"target_resource,omitempty"`
+}
+
+func (x *ServiceTargetDeployRequest) Reset() {
+	*x = ServiceTargetDeployRequest{}
+	mi := &file_service_target_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceTargetDeployRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceTargetDeployRequest) ProtoMessage() {}
+
+func (x *ServiceTargetDeployRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceTargetDeployRequest.ProtoReflect.Descriptor instead.
+func (*ServiceTargetDeployRequest) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ServiceTargetDeployRequest) GetService() *ServiceConfig {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *ServiceTargetDeployRequest) GetPackage() *ServicePackageResult {
+	if x != nil {
+		return x.Package
+	}
+	return nil
+}
+
+func (x *ServiceTargetDeployRequest) GetTargetResource() *TargetResource {
+	if x != nil {
+		return x.TargetResource
+	}
+	return nil
+}
+
+// Client returns the result of a deployment
+type ServiceTargetDeployResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ServiceDeployResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *ServiceTargetDeployResponse) Reset() {
+	*x = ServiceTargetDeployResponse{}
+	mi := &file_service_target_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceTargetDeployResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceTargetDeployResponse) ProtoMessage() {}
+
+func (x *ServiceTargetDeployResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceTargetDeployResponse.ProtoReflect.Descriptor instead.
+func (*ServiceTargetDeployResponse) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ServiceTargetDeployResponse) GetResult() *ServiceDeployResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Server requests the endpoints that a service exposes
+type ServiceTargetEndpointsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Configuration of the service.
+	Service *ServiceConfig `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Azure resource tagged for the service, when one exists.
+	TargetResource *TargetResource `protobuf:"bytes,2,opt,name=target_resource,json=targetResource,proto3" json:"target_resource,omitempty"`
+}
+
+func (x *ServiceTargetEndpointsRequest) Reset() {
+	*x = ServiceTargetEndpointsRequest{}
+	mi := &file_service_target_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceTargetEndpointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceTargetEndpointsRequest) ProtoMessage() {}
+
+func (x *ServiceTargetEndpointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceTargetEndpointsRequest.ProtoReflect.Descriptor instead.
+func (*ServiceTargetEndpointsRequest) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ServiceTargetEndpointsRequest) GetService() *ServiceConfig {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *ServiceTargetEndpointsRequest) GetTargetResource() *TargetResource {
+	if x != nil {
+		return x.TargetResource
+	}
+	return nil
+}
+
+// Client returns the endpoints that a service exposes
+type ServiceTargetEndpointsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Endpoints []string `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+}
+
+func (x *ServiceTargetEndpointsResponse) Reset() {
+	*x = ServiceTargetEndpointsResponse{}
+	mi := &file_service_target_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceTargetEndpointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceTargetEndpointsResponse) ProtoMessage() {}
+
+func (x *ServiceTargetEndpointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceTargetEndpointsResponse.ProtoReflect.Descriptor instead.
+func (*ServiceTargetEndpointsResponse) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ServiceTargetEndpointsResponse) GetEndpoints() []string {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+// Client reports the progress of a package or deploy request
+type ServiceTargetProgressMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Progress message displayed to the user.
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Time of the progress update, in milliseconds since the Unix epoch.
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *ServiceTargetProgressMessage) Reset() {
+	*x = ServiceTargetProgressMessage{}
+	mi := &file_service_target_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceTargetProgressMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceTargetProgressMessage) ProtoMessage() {}
+
+func (x *ServiceTargetProgressMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceTargetProgressMessage.ProtoReflect.Descriptor instead.
+func (*ServiceTargetProgressMessage) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ServiceTargetProgressMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ServiceTargetProgressMessage) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// Client signals that all its service targets are registered and it's ready to receive requests
+type ServiceTargetReadyMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ServiceTargetReadyMessage) Reset() {
+	*x = ServiceTargetReadyMessage{}
+	mi := &file_service_target_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceTargetReadyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceTargetReadyMessage) ProtoMessage() {}
+
+func (x *ServiceTargetReadyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceTargetReadyMessage.ProtoReflect.Descriptor instead.
+func (*ServiceTargetReadyMessage) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{10}
+}
+
+// ServicePackageResult message definition
+type ServicePackageResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackagePath string            `protobuf:"bytes,1,opt,name=package_path,json=packagePath,proto3" json:"package_path,omitempty"`
+	Details     map[string]string `protobuf:"bytes,2,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ServicePackageResult) Reset() {
+	*x = ServicePackageResult{}
+	mi := &file_service_target_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServicePackageResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServicePackageResult) ProtoMessage() {}
+
+func (x *ServicePackageResult) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServicePackageResult.ProtoReflect.Descriptor instead.
+func (*ServicePackageResult) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ServicePackageResult) GetPackagePath() string {
+	if x != nil {
+		return x.PackagePath
+	}
+	return ""
+}
+
+func (x *ServicePackageResult) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// ServiceDeployResult message definition
+type ServiceDeployResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetResourceId string            `protobuf:"bytes,1,opt,name=target_resource_id,json=targetResourceId,proto3" json:"target_resource_id,omitempty"`
+	Endpoints        []string          `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	Details          map[string]string `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ServiceDeployResult) Reset() {
+	*x = ServiceDeployResult{}
+	mi := &file_service_target_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceDeployResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceDeployResult) ProtoMessage() {}
+
+func (x *ServiceDeployResult) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceDeployResult.ProtoReflect.Descriptor instead.
+func (*ServiceDeployResult) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ServiceDeployResult) GetTargetResourceId() string {
+	if x != nil {
+		return x.TargetResourceId
+	}
+	return ""
+}
+
+func (x *ServiceDeployResult) GetEndpoints() []string {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *ServiceDeployResult) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// TargetResource message definition
+type TargetResource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriptionId    string `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	ResourceGroupName string `protobuf:"bytes,2,opt,name=resource_group_name,json=resourceGroupName,proto3" json:"resource_group_name,omitempty"`
+	ResourceName      string `protobuf:"bytes,3,opt,name=resource_name,json=resourceName,proto3" json:"resource_name,omitempty"`
+	ResourceType      string `protobuf:"bytes,4,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+}
+
+func (x *TargetResource) Reset() {
+	*x = TargetResource{}
+	mi := &file_service_target_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetResource) ProtoMessage() {}
+
+func (x *TargetResource) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetResource.ProtoReflect.Descriptor instead.
+func (*TargetResource) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TargetResource) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *TargetResource) GetResourceGroupName() string {
+	if x != nil {
+		return x.ResourceGroupName
+	}
+	return ""
+}
+
+func (x *TargetResource) GetResourceName() string {
+	if x != nil {
+		return x.ResourceName
+	}
+	return ""
+}
+
+func (x *TargetResource) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+var File_service_target_proto protoreflect.FileDescriptor
+
+var file_service_target_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x1a, 0x0c,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd7, 0x07, 0x0a,
+	0x14, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x6d, 0x0a, 0x1f, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x1c, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x70, 0x0a, 0x20, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x1d, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x51, 0x0a, 0x10, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a,
+	0x0e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4e, 0x0a, 0x0f, 0x64, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x64, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x11, 0x65, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x45, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x10,
+	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x57, 0x0a, 0x12, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x5f, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x11, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x10, 0x70, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x48, 0x0a, 0x0d,
+	0x72, 0x65, 0x61, 0x64, 0x79, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64, 0x79, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x61, 0x64, 0x79, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0x32, 0x0a, 0x1c, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x22, 0x1f, 0x0a, 0x1d, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x1b,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x11,
+	0x66, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x10, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x22, 0x56, 0x0a, 0x1c, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78,
+	0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x22,
+	0xc6, 0x01, 0x0a, 0x1a, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f,
+	0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x36, 0x0a, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x3f, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x52, 0x0a, 0x1b, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x91, 0x01, 0x0a,
+	0x1d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x45, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f,
+	0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x3f, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78,
+	0x74, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x22, 0x3e, 0x0a, 0x1e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x22, 0x56, 0x0a, 0x1c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x1b, 0x0a, 0x19, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64, 0x79, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xba, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x21,
+	0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x50, 0x61, 0x74,
+	0x68, 0x12, 0x43, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0xe1, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x65, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x42, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb3, 0x01, 0x0a, 0x0e, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x32, 0x60, 0x0a, 0x14,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1c,
+	0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x1c, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x2f,
+	0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x7a, 0x75,
+	0x72, 0x65, 0x2f, 0x61, 0x7a, 0x75, 0x72, 0x65, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x63, 0x6c, 0x69,
+	0x2f, 0x61, 0x7a, 0x64, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_service_target_proto_rawDescOnce sync.Once
+	file_service_target_proto_rawDescData = file_service_target_proto_rawDesc
+)
+
+func file_service_target_proto_rawDescGZIP() []byte {
+	file_service_target_proto_rawDescOnce.Do(func() {
+		file_service_target_proto_rawDescData = protoimpl.X.CompressGZIP(file_service_target_proto_rawDescData)
+	})
+	return file_service_target_proto_rawDescData
+}
+
+var file_service_target_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_service_target_proto_goTypes = []any{
+	(*ServiceTargetMessage)(nil),           // 0: azdext.ServiceTargetMessage
+	(*RegisterServiceTargetRequest)(nil),   // 1: azdext.RegisterServiceTargetRequest
+	(*RegisterServiceTargetResponse)(nil),  // 2: azdext.RegisterServiceTargetResponse
+	(*ServiceTargetPackageRequest)(nil),    // 3: azdext.ServiceTargetPackageRequest
+	(*ServiceTargetPackageResponse)(nil),   // 4: azdext.ServiceTargetPackageResponse
+	(*ServiceTargetDeployRequest)(nil),     // 5: azdext.ServiceTargetDeployRequest
+	(*ServiceTargetDeployResponse)(nil),    // 6: azdext.ServiceTargetDeployResponse
+	(*ServiceTargetEndpointsRequest)(nil),  // 7: azdext.ServiceTargetEndpointsRequest
+	(*ServiceTargetEndpointsResponse)(nil), // 8: azdext.ServiceTargetEndpointsResponse
+	(*ServiceTargetProgressMessage)(nil),   // 9: azdext.ServiceTargetProgressMessage
+	(*ServiceTargetReadyMessage)(nil),      // 10: azdext.ServiceTargetReadyMessage
+	(*ServicePackageResult)(nil),           // 11: azdext.ServicePackageResult
+	(*ServiceDeployResult)(nil),            // 12: azdext.ServiceDeployResult
+	(*TargetResource)(nil),                 // 13: azdext.TargetResource
+	nil,                                    // 14: azdext.ServicePackageResult.DetailsEntry
+	nil,                                    // 15: azdext.ServiceDeployResult.DetailsEntry
+	(*ServiceConfig)(nil),                  // 16: azdext.ServiceConfig
+}
+var file_service_target_proto_depIdxs = []int32{
+	1,  // 0: azdext.ServiceTargetMessage.register_service_target_request:type_name -> azdext.RegisterServiceTargetRequest
+	2,  // 1: azdext.ServiceTargetMessage.register_service_target_response:type_name -> azdext.RegisterServiceTargetResponse
+	3,  // 2: azdext.ServiceTargetMessage.package_request:type_name -> azdext.ServiceTargetPackageRequest
+	4,  // 3: azdext.ServiceTargetMessage.package_response:type_name -> azdext.ServiceTargetPackageResponse
+	5,  // 4: azdext.ServiceTargetMessage.deploy_request:type_name -> azdext.ServiceTargetDeployRequest
+	6,  // 5: azdext.ServiceTargetMessage.deploy_response:type_name -> azdext.ServiceTargetDeployResponse
+	7,  // 6: azdext.ServiceTargetMessage.endpoints_request:type_name -> azdext.ServiceTargetEndpointsRequest
+	8,  // 7: azdext.ServiceTargetMessage.endpoints_response:type_name -> azdext.ServiceTargetEndpointsResponse
+	9,  // 8: azdext.ServiceTargetMessage.progress_message:type_name -> azdext.ServiceTargetProgressMessage
+	10, // 9: azdext.ServiceTargetMessage.ready_message:type_name -> azdext.ServiceTargetReadyMessage
+	16, // 10: azdext.ServiceTargetPackageRequest.service:type_name -> azdext.ServiceConfig
+	11, // 11: azdext.ServiceTargetPackageRequest.framework_package:type_name -> azdext.ServicePackageResult
+	11, // 12: azdext.ServiceTargetPackageResponse.package:type_name -> azdext.ServicePackageResult
+	16, // 13: azdext.ServiceTargetDeployRequest.service:type_name -> azdext.ServiceConfig
+	11, // 14: azdext.ServiceTargetDeployRequest.package:type_name -> azdext.ServicePackageResult
+	13, // 15: azdext.ServiceTargetDeployRequest.target_resource:type_name -> azdext.TargetResource
+	12, // 16: azdext.ServiceTargetDeployResponse.result:type_name -> azdext.ServiceDeployResult
+	16, // 17: azdext.ServiceTargetEndpointsRequest.service:type_name -> azdext.ServiceConfig
+	13, // 18: azdext.ServiceTargetEndpointsRequest.target_resource:type_name -> azdext.TargetResource
+	14, // 19: azdext.ServicePackageResult.details:type_name -> azdext.ServicePackageResult.DetailsEntry
+	15, // 20: azdext.ServiceDeployResult.details:type_name -> azdext.ServiceDeployResult.DetailsEntry
+	0,  // 21: azdext.ServiceTargetService.Stream:input_type -> azdext.ServiceTargetMessage
+	0,  // 22: azdext.ServiceTargetService.Stream:output_type -> azdext.ServiceTargetMessage
+	22, // [22:23] is the sub-list for method output_type
+	21, // [21:22] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_service_target_proto_init() }
+func file_service_target_proto_init() {
+	if File_service_target_proto != nil {
+		return
+	}
+	file_models_proto_init()
+	file_service_target_proto_msgTypes[0].OneofWrappers = []any{
+		(*ServiceTargetMessage_RegisterServiceTargetRequest)(nil),
+		(*ServiceTargetMessage_RegisterServiceTargetResponse)(nil),
+		(*ServiceTargetMessage_PackageRequest)(nil),
+		(*ServiceTargetMessage_PackageResponse)(nil),
+		(*ServiceTargetMessage_DeployRequest)(nil),
+		(*ServiceTargetMessage_DeployResponse)(nil),
+		(*ServiceTargetMessage_EndpointsRequest)(nil),
+		(*ServiceTargetMessage_EndpointsResponse)(nil),
+		(*ServiceTargetMessage_ProgressMessage)(nil),
+		(*ServiceTargetMessage_ReadyMessage)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_service_target_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_service_target_proto_goTypes,
+		DependencyIndexes: file_service_target_proto_depIdxs,
+		MessageInfos:      file_service_target_proto_msgTypes,
+	}.Build()
+	File_service_target_proto = out.File
+	file_service_target_proto_rawDesc = nil
+	file_service_target_proto_goTypes = nil
+	file_service_target_proto_depIdxs = nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// ProgressReporter reports the progress of a service target operation to azd.
+type ProgressReporter func(message string)
+
+// ServiceTargetProvider provides the service target of a host that azd doesn't support.
+type ServiceTargetProvider interface {
+	// Package prepares the artifacts of the service for deployment
+	Package(
+		ctx context.Context,
+		service *ServiceConfig,
+		frameworkPackage *ServicePackageResult,
+		progress ProgressReporter,
+	) (*ServicePackageResult, error)
+
+	// Deploy deploys the package of the service
+	Deploy(
+		ctx context.Context,
+		service *ServiceConfig,
+		servicePackage *ServicePackageResult,
+		targetResource *TargetResource,
+		progress ProgressReporter,
+	) (*ServiceDeployResult, error)
+
+	// Endpoints gets the endpoints that the service exposes
+	Endpoints(ctx context.Context, service *ServiceConfig, targetResource *TargetResource) ([]string, error)
+}
+
+type ServiceTargetManager struct {
+	manager *providerManager[ServiceTargetMessage, *ServiceTargetMessage, ServiceTargetProvider]
+}
+
+func NewServiceTargetManager(azdClient *AzdClient) *ServiceTargetManager {
+	m := &ServiceTargetManager{}
+	m.manager = &providerManager[ServiceTargetMessage, *ServiceTargetMessage, ServiceTargetProvider]{
+		kind: "service target",
+		key:  "host",
+		openStream: func(ctx context.Context) (
+			grpc.BidiStreamingClient[ServiceTargetMessage, ServiceTargetMessage], error) {
+			return azdClient.ServiceTarget().Stream(ctx)
+		},
+		newReadyMessage: func() *ServiceTargetMessage {
+			return &ServiceTargetMessage{
+				MessageType: &ServiceTargetMessage_ReadyMessage{
+					ReadyMessage: &ServiceTargetReadyMessage{},
+				},
+			}
+		},
+		newProgressMessage: func(request *ServiceTargetMessage, message string) *ServiceTargetMessage {
+			return &ServiceTargetMessage{
+				RequestId: request.RequestId,
+				MessageType: &ServiceTargetMessage_ProgressMessage{
+					ProgressMessage: &ServiceTargetProgressMessage{
+						Message:   message,
+						Timestamp: time.Now().UnixMilli(),
+					},
+				},
+			}
+		},
+		handle:    m.handleRequest,
+		providers: make(map[string]ServiceTargetProvider),
+	}
+
+	return m
+}
+
+func (m *ServiceTargetManager) Close() error {
+	return m.manager.close()
+}
+
+// Register registers the provider of the service target for the host.
+// Service targets must be registered before calling Receive.
+func (m *ServiceTargetManager) Register(ctx context.Context, host string, provider ServiceTargetProvider) error {
+	request := &ServiceTargetMessage{
+		MessageType: &ServiceTargetMessage_RegisterServiceTargetRequest{
+			RegisterServiceTargetRequest: &RegisterServiceTargetRequest{
+				Host: host,
+			},
+		},
+	}
+
+	return m.manager.register(ctx, host, provider, request, func(msg *ServiceTargetMessage) bool {
+		return msg.GetRegisterServiceTargetResponse() != nil
+	})
+}
+
+// Receive signals azd that the registered service targets are ready,
+// then handles the requests of azd to the service targets until the stream is closed.
+func (m *ServiceTargetManager) Receive(ctx context.Context) error {
+	return m.manager.receive(ctx)
+}
+
+func (m *ServiceTargetManager) handleRequest(
+	ctx context.Context,
+	msg *ServiceTargetMessage,
+	progress ProgressReporter,
+) (*ServiceTargetMessage, error) {
+	response := &ServiceTargetMessage{}
+
+	var err error
+	switch msg.MessageType.(type) {
+	case *ServiceTargetMessage_PackageRequest:
+		request := msg.GetPackageRequest()
+		var result *ServicePackageResult
+		provider, providerErr := m.manager.provider(request.Service.GetHost())
+		if err = providerErr; err == nil {
+			result, err = provider.Package(ctx, request.Service, request.FrameworkPackage, progress)
+		}
+
+		response.MessageType = &ServiceTargetMessage_PackageResponse{
+			PackageResponse: &ServiceTargetPackageResponse{Package: result},
+		}
+	case *ServiceTargetMessage_DeployRequest:
+		request := msg.GetDeployRequest()
+		var result *ServiceDeployResult
+		provider, providerErr := m.manager.provider(request.Service.GetHost())
+		if err = providerErr; err == nil {
+			result, err = provider.Deploy(ctx, request.Service, request.Package, request.TargetResource, progress)
+		}
+
+		response.MessageType = &ServiceTargetMessage_DeployResponse{
+			DeployResponse: &ServiceTargetDeployResponse{Result: result},
+		}
+	case *ServiceTargetMessage_EndpointsRequest:
+		request := msg.GetEndpointsRequest()
+		var endpoints []string
+		provider, providerErr := m.manager.provider(request.Service.GetHost())
+		if err = providerErr; err == nil {
+			endpoints, err = provider.Endpoints(ctx, request.Service, request.TargetResource)
+		}
+
+		response.MessageType = &ServiceTargetMessage_EndpointsResponse{
+			EndpointsResponse: &ServiceTargetEndpointsResponse{Endpoints: endpoints},
+		}
+	default:
+		return nil, fmt.Errorf("%w %T", errUnhandledMessage, msg.MessageType)
+	}
+
+	return response, err
+}
@@ -0,0 +1,335 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/location"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/pipelinepermissions"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/pipelineschecks"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+)
+
+const (
+	// pipelines resource type of an Azure Pipelines environment
+	environmentResourceType = "environment"
+	// pipelines resource type of a variable group
+	variableGroupResourceType = "variablegroup"
+	// timeout in minutes of the approval checks created by azd (30 days, the maximum allowed)
+	approvalCheckTimeout = 43200
+)
+
+// id of the "Approval" check type of Azure Pipelines
+var approvalCheckTypeId = uuid.MustParse("8C6F20A7-A545-4486-9777-F762FAFE0D4D")
+
+// StageServiceConnectionName returns the name of the service connection used by the deployment stage of an azd
+// environment, when the pipeline deploys to several environments.
+func StageServiceConnectionName(envName string) string {
+	return fmt.Sprintf("%s-%s", ServiceConnectionName, envName)
+}
+
+// StageVariableGroupName returns the name of the variable group with the variables and secrets of the deployment
+// stage of an azd environment, when the pipeline deploys to several environments.
+func StageVariableGroupName(envName string) string {
+	return fmt.Sprintf("azd-%s", envName)
+}
+
+// CreateOrUpdateEnvironment makes sure the Azure Pipelines environment exists and authorizes all the pipelines to
+// deploy to it. When requireApproval is set, deployments to the environment wait for the approval of the user
+// authenticated with the PAT, unless the environment already has an approval check.
+func CreateOrUpdateEnvironment(
+	ctx context.Context,
+	connection *azuredevops.Connection,
+	projectId string,
+	name string,
+	requireApproval bool,
+	console input.Console,
+) (*taskagent.EnvironmentInstance, error) {
+	client, err := taskagent.NewClient(ctx, connection)
+	if err != nil {
+		return nil, fmt.Errorf("creating new azdo client: %w", err)
+	}
+
+	environments, err := client.GetEnvironments(ctx, taskagent.GetEnvironmentsArgs{
+		Project: &projectId,
+		Name:    &name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("looking for existing environment %s: %w", name, err)
+	}
+
+	var pipelineEnvironment *taskagent.EnvironmentInstance
+	for _, existing := range environments.Value {
+		if existing.Name != nil && *existing.Name == name {
+			pipelineEnvironment = &existing
+			break
+		}
+	}
+
+	if pipelineEnvironment == nil {
+		pipelineEnvironment, err = client.AddEnvironment(ctx, taskagent.AddEnvironmentArgs{
+			Project: &projectId,
+			EnvironmentCreateParameter: &taskagent.EnvironmentCreateParameter{
+				Name:        &name,
+				Description: to.Ptr("Environment created by azd"),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating environment %s: %w", name, err)
+		}
+		console.MessageUxItem(ctx, &ux.DisplayedResource{
+			Type: "Azure DevOps",
+			Name: fmt.Sprintf("Environment %s", name),
+		})
+	}
+
+	environmentId := strconv.Itoa(*pipelineEnvironment.Id)
+	if err := authorizeResourceToAllPipelines(
+		ctx, connection, projectId, environmentResourceType, environmentId); err != nil {
+		return nil, fmt.Errorf("authorizing environment %s: %w", name, err)
+	}
+
+	if !requireApproval {
+		return pipelineEnvironment, nil
+	}
+
+	added, err := ensureApprovalCheck(ctx, connection, projectId, environmentId, name)
+	if err != nil {
+		return nil, fmt.Errorf("adding approval check to environment %s: %w", name, err)
+	}
+	if added {
+		console.MessageUxItem(ctx, &ux.DisplayedResource{
+			Type: "Azure DevOps",
+			Name: fmt.Sprintf("Approval check for environment %s", name),
+		})
+	}
+
+	return pipelineEnvironment, nil
+}
+
+// ensureApprovalCheck adds an approval check by the authenticated user to the environment, when the environment
+// doesn't have an approval check yet. The returned bool indicates if the check was added.
+func ensureApprovalCheck(
+	ctx context.Context,
+	connection *azuredevops.Connection,
+	projectId string,
+	environmentId string,
+	environmentName string,
+) (bool, error) {
+	checksClient, err := pipelineschecks.NewClient(ctx, connection)
+	if err != nil {
+		return false, fmt.Errorf("creating new azdo client: %w", err)
+	}
+
+	checks, err := checksClient.GetCheckConfigurationsOnResource(ctx, pipelineschecks.GetCheckConfigurationsOnResourceArgs{
+		Project:      &projectId,
+		ResourceType: to.Ptr(environmentResourceType),
+		ResourceId:   &environmentId,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, check := range *checks {
+		if check.Type != nil && check.Type.Id != nil && *check.Type.Id == approvalCheckTypeId {
+			return false, nil
+		}
+	}
+
+	connectionData, err := location.NewClient(ctx, connection).GetConnectionData(ctx, location.GetConnectionDataArgs{})
+	if err != nil {
+		return false, fmt.Errorf("getting authenticated user: %w", err)
+	}
+
+	// The check configuration model of the SDK doesn't include the settings of the check, so the request is sent
+	// with the generic client.
+	client, err := connection.GetClientByResourceAreaId(ctx, pipelineschecks.ResourceAreaId)
+	if err != nil {
+		return false, fmt.Errorf("creating new azdo client: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"type": map[string]any{
+			"id":   approvalCheckTypeId.String(),
+			"name": "Approval",
+		},
+		"settings": map[string]any{
+			"approvers": []map[string]any{
+				{"id": connectionData.AuthenticatedUser.Id.String()},
+			},
+			"executionOrder":            1,
+			"instructions":              fmt.Sprintf("Approve the deployment to %s.", environmentName),
+			"blockedApprovers":          []any{},
+			"minRequiredApprovers":      0,
+			"requesterCannotBeApprover": false,
+		},
+		"resource": map[string]any{
+			"type": environmentResourceType,
+			"id":   environmentId,
+			"name": environmentName,
+		},
+		"timeout": approvalCheckTimeout,
+	})
+	if err != nil {
+		return false, fmt.Errorf("marshalling approval check: %w", err)
+	}
+
+	locationId := uuid.MustParse("86c8381e-5aee-4cde-8ae4-25c0c7f5eaea")
+	res, err := client.Send(
+		ctx,
+		http.MethodPost,
+		locationId,
+		"7.1-preview.1",
+		map[string]string{"project": projectId},
+		nil,
+		bytes.NewReader(body),
+		"application/json",
+		"application/json",
+		nil,
+	)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	return true, nil
+}
+
+// CreateOrUpdateVariableGroup creates the variable group holding the variables and secrets used by the deployment
+// stage of an azd environment, or replaces its variables when the group already exists. All the pipelines are
+// authorized to use the group.
+func CreateOrUpdateVariableGroup(
+	ctx context.Context,
+	connection *azuredevops.Connection,
+	projectId string,
+	projectName string,
+	name string,
+	serviceConnectionName string,
+	env *environment.Environment,
+	credentials *entraid.AzureCredentials,
+	provisioningProvider provisioning.Options,
+	additionalSecrets map[string]string,
+	additionalVariables map[string]string,
+	console input.Console,
+) (*taskagent.VariableGroup, error) {
+	client, err := taskagent.NewClient(ctx, connection)
+	if err != nil {
+		return nil, fmt.Errorf("creating new azdo client: %w", err)
+	}
+
+	definitionVariables, err := getDefinitionVariables(
+		env, credentials, serviceConnectionName, provisioningProvider, additionalSecrets, additionalVariables)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := make(map[string]any, len(*definitionVariables))
+	for key, variable := range *definitionVariables {
+		variables[key] = taskagent.VariableValue{
+			Value:    variable.Value,
+			IsSecret: variable.IsSecret,
+		}
+	}
+
+	description := fmt.Sprintf("Variables of the azd environment %s, created by azd", env.Name())
+	parameters := &taskagent.VariableGroupParameters{
+		Name:        &name,
+		Description: &description,
+		Type:        to.Ptr("Vsts"),
+		Variables:   &variables,
+		VariableGroupProjectReferences: &[]taskagent.VariableGroupProjectReference{
+			{
+				Name:        &name,
+				Description: &description,
+				ProjectReference: &taskagent.ProjectReference{
+					Id:   to.Ptr(uuid.MustParse(projectId)),
+					Name: &projectName,
+				},
+			},
+		},
+	}
+
+	groups, err := client.GetVariableGroups(ctx, taskagent.GetVariableGroupsArgs{
+		Project:   &projectId,
+		GroupName: &name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("looking for existing variable group %s: %w", name, err)
+	}
+
+	var group *taskagent.VariableGroup
+	for _, existing := range *groups {
+		if existing.Name != nil && *existing.Name == name {
+			group, err = client.UpdateVariableGroup(ctx, taskagent.UpdateVariableGroupArgs{
+				GroupId:                 existing.Id,
+				VariableGroupParameters: parameters,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("updating variable group %s: %w", name, err)
+			}
+			break
+		}
+	}
+
+	if group == nil {
+		group, err = client.AddVariableGroup(ctx, taskagent.AddVariableGroupArgs{
+			VariableGroupParameters: parameters,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating variable group %s: %w", name, err)
+		}
+	}
+	console.MessageUxItem(ctx, &ux.DisplayedResource{
+		Type: "Azure DevOps",
+		Name: fmt.Sprintf("Variable group %s", name),
+	})
+
+	if err := authorizeResourceToAllPipelines(
+		ctx, connection, projectId, variableGroupResourceType, strconv.Itoa(*group.Id)); err != nil {
+		return nil, fmt.Errorf("authorizing variable group %s: %w", name, err)
+	}
+
+	return group, nil
+}
+
+// authorizeResourceToAllPipelines allows all the pipelines of the project to use the resource, so the first run of
+// the pipeline doesn't wait for the resource to be permitted.
+func authorizeResourceToAllPipelines(
+	ctx context.Context,
+	connection *azuredevops.Connection,
+	projectId string,
+	resourceType string,
+	resourceId string,
+) error {
+	client, err := pipelinepermissions.NewClient(ctx, connection)
+	if err != nil {
+		return fmt.Errorf("creating new azdo client: %w", err)
+	}
+
+	_, err = client.UpdatePipelinePermisionsForResource(ctx, pipelinepermissions.UpdatePipelinePermisionsForResourceArgs{
+		Project:      &projectId,
+		ResourceType: &resourceType,
+		ResourceId:   &resourceId,
+		ResourceAuthorization: &pipelinepermissions.ResourcePipelinePermissions{
+			AllPipelines: &pipelinepermissions.Permission{
+				Authorized: to.Ptr(true),
+			},
+		},
+	})
+	return err
+}
@@ -95,6 +95,35 @@ func CreatePipeline(
 	provisioningProvider provisioning.Options,
 	additionalSecrets map[string]string,
 	additionalVariables map[string]string) (*build.BuildDefinition, error) {
+	buildDefinitionVariables, err := getDefinitionVariables(
+		env, credentials, ServiceConnectionName, provisioningProvider, additionalSecrets, additionalVariables)
+	if err != nil {
+		return nil, err
+	}
+
+	return createOrUpdatePipeline(ctx, projectId, name, repoName, connection, buildDefinitionVariables)
+}
+
+// create a new Azure DevOps pipeline deploying to several azd environments. The pipeline doesn't define variables,
+// each deployment stage gets them from the variable group of its azd environment.
+func CreateStagedPipeline(
+	ctx context.Context,
+	projectId string,
+	name string,
+	repoName string,
+	connection *azuredevops.Connection) (*build.BuildDefinition, error) {
+	return createOrUpdatePipeline(
+		ctx, projectId, name, repoName, connection, &map[string]build.BuildDefinitionVariable{})
+}
+
+// create the Azure DevOps pipeline, or update the variables of the pipeline when it already exists
+func createOrUpdatePipeline(
+	ctx context.Context,
+	projectId string,
+	name string,
+	repoName string,
+	connection *azuredevops.Connection,
+	buildDefinitionVariables *map[string]build.BuildDefinitionVariable) (*build.BuildDefinition, error) {
 
 	client, err := build.NewClient(ctx, connection)
 	if err != nil {
@@ -111,11 +140,6 @@ func CreatePipeline(
 		// Pipeline is already created. It uses the same connection but
 		// we need to update the variables and secrets as they
 		// might have been updated
-		buildDefinitionVariables, err := getDefinitionVariables(
-			env, credentials, provisioningProvider, additionalSecrets, additionalVariables)
-		if err != nil {
-			return nil, err
-		}
 		definition.Variables = buildDefinitionVariables
 		definition, err := client.UpdateDefinition(ctx, build.UpdateDefinitionArgs{
 			Definition:   definition,
@@ -133,13 +157,7 @@ func CreatePipeline(
 		return nil, err
 	}
 
-	createDefinitionArgs, err := createAzureDevPipelineArgs(
-		projectId, name, repoName, credentials, env, queue,
-		provisioningProvider, additionalSecrets, additionalVariables)
-	if err != nil {
-		return nil, err
-	}
-
+	createDefinitionArgs := createAzureDevPipelineArgs(projectId, name, repoName, queue, buildDefinitionVariables)
 	newBuildDefinition, err := client.CreateDefinition(ctx, *createDefinitionArgs)
 	if err != nil {
 		return nil, err
@@ -151,13 +169,14 @@ func CreatePipeline(
 func getDefinitionVariables(
 	env *environment.Environment,
 	credentials *entraid.AzureCredentials,
+	serviceConnectionName string,
 	provisioningProvider provisioning.Options,
 	additionalSecrets map[string]string,
 	additionalVariables map[string]string) (*map[string]build.BuildDefinitionVariable, error) {
 	variables := map[string]build.BuildDefinitionVariable{
 		"AZURE_LOCATION":           createBuildDefinitionVariable(env.GetLocation(), false, false),
 		"AZURE_ENV_NAME":           createBuildDefinitionVariable(env.Name(), false, false),
-		"AZURE_SERVICE_CONNECTION": createBuildDefinitionVariable(ServiceConnectionName, false, false),
+		"AZURE_SERVICE_CONNECTION": createBuildDefinitionVariable(serviceConnectionName, false, false),
 		"AZURE_SUBSCRIPTION_ID":    createBuildDefinitionVariable(credentials.SubscriptionId, false, false),
 	}
 
@@ -201,13 +220,9 @@ func createAzureDevPipelineArgs(
 	projectId string,
 	name string,
 	repoName string,
-	credentials *entraid.AzureCredentials,
-	env *environment.Environment,
 	queue *taskagent.TaskAgentQueue,
-	provisioningProvider provisioning.Options,
-	additionalSecrets map[string]string,
-	additionalVariables map[string]string,
-) (*build.CreateDefinitionArgs, error) {
+	buildDefinitionVariables *map[string]build.BuildDefinitionVariable,
+) *build.CreateDefinitionArgs {
 
 	repoType := "tfsgit"
 	buildDefinitionType := build.DefinitionType("build")
@@ -243,11 +258,6 @@ func createAzureDevPipelineArgs(
 		trigger,
 	}
 
-	buildDefinitionVariables, err := getDefinitionVariables(
-		env, credentials, provisioningProvider, additionalSecrets, additionalVariables)
-	if err != nil {
-		return nil, err
-	}
 	buildDefinition := &build.BuildDefinition{
 		Name:        &name,
 		Type:        &buildDefinitionType,
@@ -263,7 +273,7 @@ func createAzureDevPipelineArgs(
 		Project:    &projectId,
 		Definition: buildDefinition,
 	}
-	return createDefinitionArgs, nil
+	return createDefinitionArgs
 }
 
 // run a pipeline. This is used to invoke the deploy pipeline after a successful push of the code
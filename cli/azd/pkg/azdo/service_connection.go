@@ -101,18 +101,31 @@ func CreateServiceConnection(
 	azdEnvironment environment.Environment,
 	credentials *entraid.AzureCredentials,
 	console input.Console) (*serviceendpoint.ServiceEndpoint, error) {
+	return CreateNamedServiceConnection(
+		ctx, connection, projectId, projectName, ServiceConnectionName, credentials, console)
+}
+
+// create or update the service connection with the given name, which is used in the deployment pipeline
+func CreateNamedServiceConnection(
+	ctx context.Context,
+	connection *azuredevops.Connection,
+	projectId string,
+	projectName string,
+	serviceConnectionName string,
+	credentials *entraid.AzureCredentials,
+	console input.Console) (*serviceendpoint.ServiceEndpoint, error) {
 
 	client, err := serviceendpoint.NewClient(ctx, connection)
 	if err != nil {
 		return nil, fmt.Errorf("creating new azdo client: %w", err)
 	}
 
-	foundServiceConnection, err := serviceConnectionExists(ctx, &client, &projectId, &ServiceConnectionName)
+	foundServiceConnection, err := serviceConnectionExists(ctx, &client, &projectId, &serviceConnectionName)
 	if err != nil {
 		return nil, fmt.Errorf("creating service connection: looking for existing connection: %w", err)
 	}
 
-	createServiceEndpointArgs, err := createAzureRMServiceEndPointArgs(&projectId, &projectName, credentials)
+	createServiceEndpointArgs, err := createAzureRMServiceEndPointArgs(&projectId, &projectName, serviceConnectionName, credentials)
 	if err != nil {
 		return nil, fmt.Errorf("creating Azure DevOps endpoint: %w", err)
 	}
@@ -128,7 +141,7 @@ func CreateServiceConnection(
 		}
 		console.MessageUxItem(ctx, &ux.DisplayedResource{
 			Type: "Azure DevOps",
-			Name: "Updated service connection",
+			Name: serviceConnectionMessage("Updated service connection", serviceConnectionName),
 		})
 		return updated, nil
 	}
@@ -140,7 +153,7 @@ func CreateServiceConnection(
 	}
 	console.MessageUxItem(ctx, &ux.DisplayedResource{
 		Type: "Azure DevOps",
-		Name: "Service connection",
+		Name: serviceConnectionMessage("Service connection", serviceConnectionName),
 	})
 
 	err = authorizeServiceConnectionToAllPipelines(ctx, projectId, endpoint, connection)
@@ -151,6 +164,14 @@ func CreateServiceConnection(
 	return endpoint, nil
 }
 
+// serviceConnectionMessage includes the name of the service connection in the message, unless it is the default one
+func serviceConnectionMessage(message string, serviceConnectionName string) string {
+	if serviceConnectionName == ServiceConnectionName {
+		return message
+	}
+	return fmt.Sprintf("%s %s", message, serviceConnectionName)
+}
+
 func ListTypes(
 	ctx context.Context,
 	connection *azuredevops.Connection,
@@ -168,6 +189,7 @@ func ListTypes(
 func createAzureRMServiceEndPointArgs(
 	projectId *string,
 	projectName *string,
+	serviceConnectionName string,
 	credentials *entraid.AzureCredentials,
 ) (serviceendpoint.CreateServiceEndpointArgs, error) {
 	endpointScheme := "WorkloadIdentityFederation"
@@ -196,7 +218,7 @@ func createAzureRMServiceEndPointArgs(
 	description := "Azure Service Connection created by azd"
 
 	pRef := []serviceendpoint.ServiceEndpointProjectReference{{
-		Name:        &ServiceConnectionName,
+		Name:        &serviceConnectionName,
 		Description: &description,
 		ProjectReference: &serviceendpoint.ProjectReference{
 			Id:   to.Ptr(uuid.MustParse(*projectId)),
@@ -207,7 +229,7 @@ func createAzureRMServiceEndPointArgs(
 		Type:                             to.Ptr("azurerm"),
 		Owner:                            to.Ptr("library"),
 		Url:                              to.Ptr("https://management.azure.com/"),
-		Name:                             &ServiceConnectionName,
+		Name:                             &serviceConnectionName,
 		IsShared:                         to.Ptr(false),
 		Authorization:                    &endpointAuthorization,
 		Data:                             &endpointData,
@@ -0,0 +1,75 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azsdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	armruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// LogStreamClient wraps usage of the app service log stream, which streams the application logs of an app as they are
+// written.
+// More info can be found at the following:
+// https://github.com/projectkudu/kudu/wiki/Diagnostic-Log-Stream
+type LogStreamClient struct {
+	hostName string
+	pipeline runtime.Pipeline
+}
+
+// Creates a new LogStreamClient instance
+func NewLogStreamClient(
+	hostName string,
+	credential azcore.TokenCredential,
+	armClientOptions *arm.ClientOptions,
+) (*LogStreamClient, error) {
+	logStreamOptions := &arm.ClientOptions{}
+	if armClientOptions != nil {
+		optionsCopy := *armClientOptions
+		logStreamOptions = &optionsCopy
+	}
+
+	// We do not have a Resource provider to register
+	logStreamOptions.DisableRPRegistration = true
+
+	pipeline, err := armruntime.NewPipeline("log-stream", "1.0.0", credential, runtime.PipelineOptions{}, logStreamOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating HTTP pipeline: %w", err)
+	}
+
+	return &LogStreamClient{
+		hostName: hostName,
+		pipeline: pipeline,
+	}, nil
+}
+
+// Open opens the log stream. The stream is written to until the context is canceled, and must be closed by the caller.
+func (c *LogStreamClient) Open(ctx context.Context) (io.ReadCloser, error) {
+	endpoint := fmt.Sprintf("https://%s/api/logstream", c.hostName)
+	req, err := runtime.NewRequest(ctx, http.MethodGet, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("creating log stream request: %w", err)
+	}
+
+	// the stream doesn't end, so the body is read by the caller as it is written
+	runtime.SkipBodyDownload(req)
+
+	response, err := c.pipeline.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !runtime.HasStatusCode(response, http.StatusOK) {
+		defer response.Body.Close()
+		return nil, runtime.NewResponseError(response)
+	}
+
+	return response.Body, nil
+}
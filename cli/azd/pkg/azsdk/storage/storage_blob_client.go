@@ -13,6 +13,8 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/lease"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 )
@@ -26,6 +28,7 @@ type AccountConfig struct {
 
 var (
 	ErrContainerNotFound = errors.New("container not found")
+	ErrLeaseAlreadyHeld  = errors.New("lease already held")
 )
 
 type BlobClient interface {
@@ -40,6 +43,16 @@ type BlobClient interface {
 
 	// Items returns a list of blobs in the configured storage account container.
 	Items(ctx context.Context) ([]*Blob, error)
+
+	// AcquireLease acquires a lease on a blob, creating an empty blob when it does not exist, and returns the lease id.
+	// ErrLeaseAlreadyHeld is returned when the blob is already leased.
+	AcquireLease(ctx context.Context, blobPath string, duration time.Duration) (string, error)
+
+	// RenewLease renews a lease on a blob.
+	RenewLease(ctx context.Context, blobPath string, leaseId string) error
+
+	// ReleaseLease releases a lease on a blob.
+	ReleaseLease(ctx context.Context, blobPath string, leaseId string) error
 }
 
 // NewBlobClient creates a new BlobClient instance to manage blobs within a container.
@@ -136,6 +149,78 @@ func (bc *blobClient) Delete(ctx context.Context, blobPath string) error {
 	return nil
 }
 
+// AcquireLease acquires a lease on a blob, creating an empty blob when it does not exist, and returns the lease id.
+// ErrLeaseAlreadyHeld is returned when the blob is already leased.
+func (bc *blobClient) AcquireLease(ctx context.Context, blobPath string, duration time.Duration) (string, error) {
+	if err := bc.ensureContainerExists(ctx); err != nil {
+		return "", err
+	}
+
+	leaseClient, err := bc.leaseClient(blobPath, nil)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := leaseClient.AcquireLease(ctx, int32(duration.Seconds()), nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		_, err = bc.client.UploadBuffer(ctx, bc.config.ContainerName, blobPath, []byte{}, nil)
+		if bloberror.HasCode(err, bloberror.LeaseIDMissing) {
+			// the blob was created and leased concurrently
+			return "", ErrLeaseAlreadyHeld
+		} else if err != nil {
+			return "", fmt.Errorf("failed to create blob '%s', %w", blobPath, err)
+		}
+
+		res, err = leaseClient.AcquireLease(ctx, int32(duration.Seconds()), nil)
+	}
+
+	if bloberror.HasCode(err, bloberror.LeaseAlreadyPresent) {
+		return "", ErrLeaseAlreadyHeld
+	} else if err != nil {
+		return "", fmt.Errorf("failed to acquire lease on blob '%s', %w", blobPath, err)
+	}
+
+	return *res.LeaseID, nil
+}
+
+// RenewLease renews a lease on a blob.
+func (bc *blobClient) RenewLease(ctx context.Context, blobPath string, leaseId string) error {
+	leaseClient, err := bc.leaseClient(blobPath, &leaseId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := leaseClient.RenewLease(ctx, nil); err != nil {
+		return fmt.Errorf("failed to renew lease on blob '%s', %w", blobPath, err)
+	}
+
+	return nil
+}
+
+// ReleaseLease releases a lease on a blob.
+func (bc *blobClient) ReleaseLease(ctx context.Context, blobPath string, leaseId string) error {
+	leaseClient, err := bc.leaseClient(blobPath, &leaseId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := leaseClient.ReleaseLease(ctx, nil); err != nil {
+		return fmt.Errorf("failed to release lease on blob '%s', %w", blobPath, err)
+	}
+
+	return nil
+}
+
+func (bc *blobClient) leaseClient(blobPath string, leaseId *string) (*lease.BlobClient, error) {
+	blobClient := bc.client.ServiceClient().NewContainerClient(bc.config.ContainerName).NewBlobClient(blobPath)
+	leaseClient, err := lease.NewBlobClient(blobClient, &lease.BlobClientOptions{LeaseID: leaseId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lease client, %w", err)
+	}
+
+	return leaseClient, nil
+}
+
 // Check if the specified container exists
 // If it doesn't already exist then create it
 func (bc *blobClient) ensureContainerExists(ctx context.Context) error {
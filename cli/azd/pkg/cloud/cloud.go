@@ -36,6 +36,13 @@ type Cloud struct {
 	ContainerRegistryEndpointSuffix string
 
 	KeyVaultEndpointSuffix string
+
+	// The suffix for the cloud's database endpoints (e.g. database.windows.net for
+	// Azure public cloud), that the Microsoft Entra token scopes of Azure SQL
+	// Database and of Azure Database for PostgreSQL and MySQL derive from. These
+	// are well known values and can be found at:
+	// https://<management-endpoint>/metadata/endpoints?api-version=2023-12-01
+	SqlDatabaseEndpointSuffix string
 }
 
 type Config struct {
@@ -72,6 +79,7 @@ func AzurePublic() *Cloud {
 		StorageEndpointSuffix:           "core.windows.net",
 		ContainerRegistryEndpointSuffix: "azurecr.io",
 		KeyVaultEndpointSuffix:          "vault.azure.net",
+		SqlDatabaseEndpointSuffix:       "database.windows.net",
 	}
 }
 
@@ -82,6 +90,7 @@ func AzureGovernment() *Cloud {
 		StorageEndpointSuffix:           "core.usgovcloudapi.net",
 		ContainerRegistryEndpointSuffix: "azurecr.us",
 		KeyVaultEndpointSuffix:          "vault.usgovcloudapi.net",
+		SqlDatabaseEndpointSuffix:       "database.usgovcloudapi.net",
 	}
 }
 
@@ -92,6 +101,7 @@ func AzureChina() *Cloud {
 		StorageEndpointSuffix:           "core.chinacloudapi.cn",
 		ContainerRegistryEndpointSuffix: "azurecr.cn",
 		KeyVaultEndpointSuffix:          "vault.azure.cn",
+		SqlDatabaseEndpointSuffix:       "database.chinacloudapi.cn",
 	}
 }
 
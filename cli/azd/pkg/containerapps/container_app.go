@@ -8,15 +8,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v3"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
@@ -31,6 +32,7 @@ const (
 	pathTemplate                           = "properties.template"
 	pathTemplateRevisionSuffix             = "properties.template.revisionSuffix"
 	pathTemplateContainers                 = "properties.template.containers"
+	pathTemplateInitContainers             = "properties.template.initContainers"
 	pathConfigurationActiveRevisionsMode   = "properties.configuration.activeRevisionsMode"
 	pathConfigurationSecrets               = "properties.configuration.secrets"
 	pathConfigurationIngressTraffic        = "properties.configuration.ingress.traffic"
@@ -57,15 +59,24 @@ type ContainerAppService interface {
 		containerAppYaml []byte,
 		options *ContainerAppOptions,
 	) error
-	// Adds and activates a new revision to the specified container app
+	// Adds and activates a new revision to the specified container app, with the images of its containers updated
 	AddRevision(
 		ctx context.Context,
 		subscriptionId string,
 		resourceGroupName string,
 		appName string,
-		imageName string,
+		images []ContainerImage,
 		options *ContainerAppOptions,
 	) error
+	// Writes the console logs of the latest revision of the specified container app to the writer
+	Logs(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		options *LogsOptions,
+		writer io.Writer,
+	) error
 }
 
 // NewContainerAppService creates a new ContainerAppService
@@ -92,6 +103,17 @@ type containerAppService struct {
 
 type ContainerAppOptions struct {
 	ApiVersion string
+	// TrafficShift shifts the traffic to a new revision progressively, in multiple revision mode. When nil, all the
+	// traffic is sent to the new revision at once.
+	TrafficShift *TrafficShift
+}
+
+// ContainerImage is the image of a container of a revision.
+type ContainerImage struct {
+	// ContainerName is the name of the container, or init container, in the template of the revision. When empty, the
+	// image is the image of the first container.
+	ContainerName string
+	Image         string
 }
 
 type ContainerAppIngressConfiguration struct {
@@ -274,7 +296,7 @@ func (cas *containerAppService) AddRevision(
 	subscriptionId string,
 	resourceGroupName string,
 	appName string,
-	imageName string,
+	images []ContainerImage,
 	options *ContainerAppOptions,
 ) error {
 	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
@@ -313,14 +335,8 @@ func (cas *containerAppService) AddRevision(
 		return fmt.Errorf("setting revision suffix: %w", err)
 	}
 
-	var containers []map[string]any
-	if ok, err := revision.GetSection(pathTemplateContainers, &containers); !ok || err != nil {
-		return fmt.Errorf("getting containers: %w", err)
-	}
-
-	containers[0]["image"] = imageName
-	if err := revision.Set(pathTemplateContainers, containers); err != nil {
-		return fmt.Errorf("setting containers: %w", err)
+	if err := setContainerImages(revision, images); err != nil {
+		return err
 	}
 
 	// Update the container app with the new revision
@@ -338,26 +354,64 @@ func (cas *containerAppService) AddRevision(
 		return fmt.Errorf("syncing secrets: %w", err)
 	}
 
+	revisionMode, ok := containerApp.GetString(pathConfigurationActiveRevisionsMode)
+	if !ok {
+		return fmt.Errorf("getting active revisions mode: %w", err)
+	}
+	multipleRevisions := revisionMode == string(armappcontainers.ActiveRevisionsModeMultiple)
+
+	// In single revision mode, all the traffic goes to the new revision at once
+	if !multipleRevisions && options != nil && options.TrafficShift != nil {
+		return fmt.Errorf(
+			"the traffic of container app '%s' can't be shifted progressively, as its active revisions mode is '%s'. "+
+				"Set the active revisions mode to '%s' to shift the traffic progressively",
+			appName,
+			revisionMode,
+			armappcontainers.ActiveRevisionsModeMultiple,
+		)
+	}
+
+	// When the traffic is shifted progressively, the traffic is pinned to the current revision while the new revision
+	// is created, as traffic sent to the latest revision would otherwise go to the new revision at once.
+	if multipleRevisions && options != nil && options.TrafficShift != nil {
+		trafficWeightsJson, err := convert.ToJsonArray(revisionTrafficWeights(currentRevisionName, "", 100))
+		if err != nil {
+			return fmt.Errorf("converting traffic weights to JSON: %w", err)
+		}
+
+		if err := containerApp.Set(pathConfigurationIngressTraffic, trafficWeightsJson); err != nil {
+			return fmt.Errorf("setting traffic weights: %w", err)
+		}
+	}
+
 	// Update the container app
 	err = cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp, options)
 	if err != nil {
 		return fmt.Errorf("updating container app revision: %w", err)
 	}
 
-	revisionMode, ok := containerApp.GetString(pathConfigurationActiveRevisionsMode)
-	if !ok {
-		return fmt.Errorf("getting active revisions mode: %w", err)
-	}
-
 	// If the container app is in multiple revision mode, update the traffic to point to the new revision
-	if revisionMode == string(armappcontainers.ActiveRevisionsModeMultiple) {
+	if multipleRevisions {
 		revisionSuffix, ok := revision.GetString(pathTemplateRevisionSuffix)
 		if !ok {
 			return fmt.Errorf("getting revision suffix: %w", err)
 		}
 		newRevisionName := fmt.Sprintf("%s--%s", appName, revisionSuffix)
 
-		err = cas.setTrafficWeights(ctx, subscriptionId, resourceGroupName, appName, containerApp, newRevisionName, options)
+		if options != nil && options.TrafficShift != nil {
+			return cas.shiftTraffic(
+				ctx, subscriptionId, resourceGroupName, appName, containerApp, currentRevisionName, newRevisionName, options)
+		}
+
+		err = cas.setTrafficWeights(
+			ctx,
+			subscriptionId,
+			resourceGroupName,
+			appName,
+			containerApp,
+			revisionTrafficWeights(newRevisionName, currentRevisionName, 100),
+			options,
+		)
 		if err != nil {
 			return fmt.Errorf("setting traffic weights: %w", err)
 		}
@@ -366,6 +420,69 @@ func (cas *containerAppService) AddRevision(
 	return nil
 }
 
+// setContainerImages sets the images of the containers and init containers of the revision.
+func setContainerImages(revision config.Config, images []ContainerImage) error {
+	var containers []map[string]any
+	if ok, err := revision.GetSection(pathTemplateContainers, &containers); !ok || err != nil {
+		return fmt.Errorf("getting containers: %w", err)
+	}
+
+	var initContainers []map[string]any
+	if _, err := revision.GetSection(pathTemplateInitContainers, &initContainers); err != nil {
+		return fmt.Errorf("getting init containers: %w", err)
+	}
+
+	for _, image := range images {
+		if image.ContainerName == "" {
+			containers[0]["image"] = image.Image
+			continue
+		}
+
+		container := findContainer(containers, image.ContainerName)
+		if container == nil {
+			container = findContainer(initContainers, image.ContainerName)
+		}
+		if container == nil {
+			names := []string{}
+			for _, container := range slices.Concat(containers, initContainers) {
+				if name, ok := container["name"].(string); ok {
+					names = append(names, name)
+				}
+			}
+
+			return fmt.Errorf(
+				"container '%s' not found in the revision template. Available containers: %s",
+				image.ContainerName,
+				strings.Join(names, ", "),
+			)
+		}
+
+		container["image"] = image.Image
+	}
+
+	if err := revision.Set(pathTemplateContainers, containers); err != nil {
+		return fmt.Errorf("setting containers: %w", err)
+	}
+
+	if len(initContainers) > 0 {
+		if err := revision.Set(pathTemplateInitContainers, initContainers); err != nil {
+			return fmt.Errorf("setting init containers: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// findContainer returns the container with the name, nil when there is none.
+func findContainer(containers []map[string]any, name string) map[string]any {
+	for _, container := range containers {
+		if containerName, ok := container["name"].(string); ok && containerName == name {
+			return container
+		}
+	}
+	return nil
+}
+
 func (cas *containerAppService) syncSecrets(
 	ctx context.Context,
 	subscriptionId string,
@@ -412,16 +529,9 @@ func (cas *containerAppService) setTrafficWeights(
 	resourceGroupName string,
 	appName string,
 	containerApp config.Config,
-	revisionName string,
+	trafficWeights []*armappcontainers.TrafficWeight,
 	options *ContainerAppOptions,
 ) error {
-	trafficWeights := []*armappcontainers.TrafficWeight{
-		{
-			RevisionName: &revisionName,
-			Weight:       to.Ptr[int32](100),
-		},
-	}
-
 	trafficWeightsJson, err := convert.ToJsonArray(trafficWeights)
 	if err != nil {
 		return fmt.Errorf("converting traffic weights to JSON: %w", err)
@@ -6,11 +6,14 @@ package containerapps
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v3"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockazsdk"
 	"github.com/benbjohnson/clock"
@@ -141,7 +144,7 @@ func Test_ContainerApp_AddRevision(t *testing.T) {
 		mockContext.ArmClientOptions,
 		mockContext.AlphaFeaturesManager,
 	)
-	err := cas.AddRevision(*mockContext.Context, subscriptionId, resourceGroup, appName, updatedImageName, nil)
+	err := cas.AddRevision(*mockContext.Context, subscriptionId, resourceGroup, appName, []ContainerImage{{Image: updatedImageName}}, nil)
 	require.NoError(t, err)
 
 	// Verify lastest revision is read
@@ -252,3 +255,260 @@ properties:
 	require.Equal(t, expected.Properties.Configuration, actual.Properties.Configuration)
 	require.Equal(t, expected.Properties.Template, actual.Properties.Template)
 }
+
+func Test_ContainerApp_AddRevision_TrafficShift(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+	originalRevisionName := "ORIGINAL_REVISION_NAME"
+	newRevisionName := "APP_NAME--azd-0"
+	newRevisionFqdn := "app-name--azd-0.eastus2.azurecontainerapps.io"
+
+	containerApp := &armappcontainers.ContainerApp{
+		Location: &location,
+		Name:     &appName,
+		Properties: &armappcontainers.ContainerAppProperties{
+			LatestRevisionName: &originalRevisionName,
+			Configuration: &armappcontainers.Configuration{
+				ActiveRevisionsMode: to.Ptr(armappcontainers.ActiveRevisionsModeMultiple),
+				// all the traffic follows the latest revision, including a revision created by the deployment
+				Ingress: &armappcontainers.Ingress{
+					Traffic: []*armappcontainers.TrafficWeight{
+						{
+							LatestRevision: to.Ptr(true),
+							Weight:         to.Ptr(int32(100)),
+						},
+					},
+				},
+			},
+			Template: &armappcontainers.Template{
+				Containers: []*armappcontainers.Container{
+					{
+						Image: to.Ptr("ORIGINAL_IMAGE_NAME"),
+					},
+				},
+			},
+		},
+	}
+
+	revision := func(fqdn string) *armappcontainers.Revision {
+		return &armappcontainers.Revision{
+			Properties: &armappcontainers.RevisionProperties{
+				Fqdn: to.Ptr(fqdn),
+				Template: &armappcontainers.Template{
+					Containers: []*armappcontainers.Container{
+						{
+							Image: to.Ptr("ORIGINAL_IMAGE_NAME"),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	setupMocks := func(
+		mockContext *mocks.MockContext,
+		containerApp *armappcontainers.ContainerApp,
+	) *[][]*armappcontainers.TrafficWeight {
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+		_ = mockazsdk.MockContainerAppRevisionGet(
+			mockContext, subscriptionId, resourceGroup, appName, originalRevisionName, revision("original"))
+		_ = mockazsdk.MockContainerAppRevisionGet(
+			mockContext, subscriptionId, resourceGroup, appName, newRevisionName, revision(newRevisionFqdn))
+		_ = mockazsdk.MockContainerAppUpdate(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+
+		// record the traffic of each update of the container app
+		traffic := [][]*armappcontainers.TrafficWeight{}
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodPatch
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			var updated armappcontainers.ContainerApp
+			if err := mocks.ReadHttpBody(request.Body, &updated); err != nil {
+				return nil, err
+			}
+			if ingress := updated.Properties.Configuration.Ingress; ingress != nil {
+				traffic = append(traffic, ingress.Traffic)
+			}
+
+			return mocks.CreateHttpResponseWithBody(
+				request, http.StatusAccepted, armappcontainers.ContainerAppsClientUpdateResponse{})
+		})
+
+		return &traffic
+	}
+
+	weights := func(weights map[string]int32) []*armappcontainers.TrafficWeight {
+		trafficWeights := []*armappcontainers.TrafficWeight{}
+		for _, revisionName := range []string{newRevisionName, originalRevisionName} {
+			if weight, has := weights[revisionName]; has {
+				trafficWeights = append(trafficWeights, &armappcontainers.TrafficWeight{
+					RevisionName: to.Ptr(revisionName),
+					Weight:       to.Ptr(weight),
+				})
+			}
+		}
+		return trafficWeights
+	}
+
+	t.Run("Steps", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		traffic := setupMocks(mockContext, containerApp)
+
+		statuses := []TrafficShiftStatus{}
+		options := &ContainerAppOptions{
+			TrafficShift: &TrafficShift{
+				Steps: []TrafficShiftStep{{Weight: 10}, {Weight: 50}},
+				Gate: func(ctx context.Context, status TrafficShiftStatus) error {
+					statuses = append(statuses, status)
+					return nil
+				},
+			},
+		}
+
+		cas := NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			clock.NewMock(),
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+		err := cas.AddRevision(*mockContext.Context, subscriptionId, resourceGroup, appName, []ContainerImage{{Image: "UPDATED_IMAGE"}}, options)
+		require.NoError(t, err)
+
+		// the traffic stays on the original revision when the new revision is created
+		require.Equal(t, [][]*armappcontainers.TrafficWeight{
+			weights(map[string]int32{originalRevisionName: 100}),
+			weights(map[string]int32{newRevisionName: 10, originalRevisionName: 90}),
+			weights(map[string]int32{newRevisionName: 50, originalRevisionName: 50}),
+			weights(map[string]int32{newRevisionName: 100}),
+		}, *traffic)
+
+		require.Len(t, statuses, 3)
+		require.Equal(t, int32(100), statuses[2].Weight)
+		require.Equal(t, newRevisionName, statuses[2].RevisionName)
+		require.Equal(t, newRevisionFqdn, statuses[2].RevisionFqdn)
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		traffic := setupMocks(mockContext, containerApp)
+
+		options := &ContainerAppOptions{
+			TrafficShift: &TrafficShift{
+				Steps: []TrafficShiftStep{{Weight: 10}, {Weight: 50}, {Weight: 100}},
+				Gate: func(ctx context.Context, status TrafficShiftStatus) error {
+					if status.Weight == 50 {
+						return errors.New("unhealthy")
+					}
+					return nil
+				},
+			},
+		}
+
+		cas := NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			clock.NewMock(),
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+		err := cas.AddRevision(*mockContext.Context, subscriptionId, resourceGroup, appName, []ContainerImage{{Image: "UPDATED_IMAGE"}}, options)
+		require.ErrorContains(t, err, "shifting 50% of traffic to revision 'APP_NAME--azd-0': unhealthy")
+		require.ErrorContains(t, err, "Traffic was rolled back to revision 'ORIGINAL_REVISION_NAME'")
+
+		require.Equal(t, [][]*armappcontainers.TrafficWeight{
+			weights(map[string]int32{originalRevisionName: 100}),
+			weights(map[string]int32{newRevisionName: 10, originalRevisionName: 90}),
+			weights(map[string]int32{newRevisionName: 50, originalRevisionName: 50}),
+			weights(map[string]int32{originalRevisionName: 100}),
+		}, *traffic)
+	})
+
+	t.Run("SingleRevisionMode", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+
+		singleRevisionApp := *containerApp
+		properties := *containerApp.Properties
+		configuration := *containerApp.Properties.Configuration
+		configuration.ActiveRevisionsMode = to.Ptr(armappcontainers.ActiveRevisionsModeSingle)
+		properties.Configuration = &configuration
+		singleRevisionApp.Properties = &properties
+		traffic := setupMocks(mockContext, &singleRevisionApp)
+
+		options := &ContainerAppOptions{
+			TrafficShift: &TrafficShift{
+				Steps: []TrafficShiftStep{{Weight: 10}, {Weight: 100}},
+				Gate: func(ctx context.Context, status TrafficShiftStatus) error {
+					return nil
+				},
+			},
+		}
+
+		cas := NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			clock.NewMock(),
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+		err := cas.AddRevision(
+			*mockContext.Context, subscriptionId, resourceGroup, appName, []ContainerImage{{Image: "UPDATED_IMAGE"}}, options)
+		require.ErrorContains(t, err, "active revisions mode is 'Single'")
+
+		// the container app isn't updated
+		require.Empty(t, *traffic)
+	})
+}
+
+func Test_setContainerImages(t *testing.T) {
+	newRevision := func() config.Config {
+		return config.NewConfig(map[string]any{
+			"properties": map[string]any{
+				"template": map[string]any{
+					"containers": []any{
+						map[string]any{"name": "api", "image": "api:1"},
+						map[string]any{"name": "otel-collector", "image": "otel:1"},
+					},
+					"initContainers": []any{
+						map[string]any{"name": "migrations", "image": "migrations:1"},
+					},
+				},
+			},
+		})
+	}
+
+	images := func(revision config.Config, path string) []string {
+		var containers []map[string]any
+		_, err := revision.GetSection(path, &containers)
+		require.NoError(t, err)
+
+		images := []string{}
+		for _, container := range containers {
+			images = append(images, container["image"].(string))
+		}
+		return images
+	}
+
+	t.Run("FirstContainer", func(t *testing.T) {
+		revision := newRevision()
+		err := setContainerImages(revision, []ContainerImage{{Image: "api:2"}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"api:2", "otel:1"}, images(revision, pathTemplateContainers))
+		require.Equal(t, []string{"migrations:1"}, images(revision, pathTemplateInitContainers))
+	})
+
+	t.Run("NamedContainers", func(t *testing.T) {
+		revision := newRevision()
+		err := setContainerImages(revision, []ContainerImage{
+			{ContainerName: "otel-collector", Image: "otel:2"},
+			{ContainerName: "migrations", Image: "migrations:2"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"api:1", "otel:2"}, images(revision, pathTemplateContainers))
+		require.Equal(t, []string{"migrations:2"}, images(revision, pathTemplateInitContainers))
+	})
+
+	t.Run("MissingContainer", func(t *testing.T) {
+		err := setContainerImages(newRevision(), []ContainerImage{{ContainerName: "dapr", Image: "dapr:1"}})
+		require.ErrorContains(
+			t, err, "container 'dapr' not found in the revision template. Available containers: api, otel-collector, migrations")
+	})
+}
@@ -0,0 +1,283 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package containerapps
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v3"
+)
+
+const (
+	// defaultLogsTailLines is the number of past lines of each container written when no start time is set
+	defaultLogsTailLines = 20
+	// maxLogsTailLines is the maximum number of past lines the log stream of a container returns
+	maxLogsTailLines = 300
+)
+
+// LogsOptions are the options for streaming the console logs of a container app.
+type LogsOptions struct {
+	ContainerAppOptions
+	// Follow keeps streaming the logs until the context is canceled
+	Follow bool
+	// Since only includes the logs more recent than the duration. Zero includes the most recent lines only.
+	Since time.Duration
+	// ContainerName only includes the logs of the container with the name, when set
+	ContainerName string
+}
+
+// lineWriter is implemented by the writers that record the time of each line of the logs, such as the writers of the
+// logs of the services.
+type lineWriter interface {
+	WriteLine(timestamp time.Time, line string) error
+}
+
+// logStreamLine is a line of the log stream of a container, in the json output format.
+type logStreamLine struct {
+	TimeStamp time.Time `json:"TimeStamp"`
+	Log       string    `json:"Log"`
+}
+
+// Logs writes the console logs of the containers of the replicas of the latest revision of the container app to the
+// writer. Each line is written with a single call to the writer, prefixed with the replica and the container it
+// comes from. When the writer has a WriteLine method, the lines are written with the time the container wrote them at.
+func (cas *containerAppService) Logs(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	options *LogsOptions,
+	writer io.Writer,
+) error {
+	if options == nil {
+		options = &LogsOptions{}
+	}
+
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, &options.ContainerAppOptions)
+	if err != nil {
+		return fmt.Errorf("getting container app: %w", err)
+	}
+
+	revisionName, has := containerApp.GetString(pathLatestRevisionName)
+	if !has {
+		return fmt.Errorf("container app '%s' has no revision", appName)
+	}
+
+	appClient, err := cas.createContainerAppsClient(ctx, subscriptionId, nil)
+	if err != nil {
+		return err
+	}
+
+	authToken, err := appClient.GetAuthToken(ctx, resourceGroupName, appName, nil)
+	if err != nil {
+		return fmt.Errorf("getting log stream token: %w", err)
+	}
+	if authToken.Properties == nil || authToken.Properties.Token == nil {
+		return errors.New("getting log stream token: the token is empty")
+	}
+
+	replicasClient, err := cas.createRevisionReplicasClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	replicas, err := replicasClient.ListReplicas(ctx, resourceGroupName, appName, revisionName, nil)
+	if err != nil {
+		return fmt.Errorf("listing replicas of revision '%s': %w", revisionName, err)
+	}
+
+	pipeline := runtime.NewPipeline("containerapps", "1.0.0", runtime.PipelineOptions{}, &cas.armClientOptions.ClientOptions)
+	since := time.Time{}
+	if options.Since > 0 {
+		since = cas.clock.Now().Add(-options.Since)
+	}
+
+	var streams []logStream
+	for _, replica := range replicas.Value {
+		if replica.Properties == nil {
+			continue
+		}
+
+		for _, container := range replica.Properties.Containers {
+			if container.Name == nil || container.LogStreamEndpoint == nil {
+				continue
+			}
+			if options.ContainerName != "" && *container.Name != options.ContainerName {
+				continue
+			}
+
+			streams = append(streams, logStream{
+				source:   fmt.Sprintf("%s/%s", *replica.Name, *container.Name),
+				endpoint: *container.LogStreamEndpoint,
+			})
+		}
+	}
+
+	if len(streams) == 0 && options.ContainerName != "" {
+		return fmt.Errorf("container '%s' not found in the replicas of revision '%s'", options.ContainerName, revisionName)
+	}
+	if len(streams) == 0 {
+		return fmt.Errorf("revision '%s' has no running replica", revisionName)
+	}
+
+	// each line is written at once, so the lines of the containers don't interleave
+	var writeMu sync.Mutex
+	errs := make([]error, len(streams))
+	var wg sync.WaitGroup
+	for i, stream := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			token := *authToken.Properties.Token
+			errs[i] = stream.copy(ctx, pipeline, token, options.Follow, since, func(timestamp time.Time, line string) error {
+				writeMu.Lock()
+				defer writeMu.Unlock()
+
+				line = fmt.Sprintf("[%s] %s", stream.source, line)
+				if timestampWriter, ok := writer.(lineWriter); ok && !timestamp.IsZero() {
+					return timestampWriter.WriteLine(timestamp, line)
+				}
+
+				_, err := fmt.Fprintln(writer, line)
+				return err
+			})
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// logStream is the log stream of a container of a replica.
+type logStream struct {
+	// source identifies the replica and the container of the stream
+	source   string
+	endpoint string
+}
+
+// copy reads the log stream and passes the lines more recent than since to write, with the time they were written at,
+// zero when unknown.
+func (s logStream) copy(
+	ctx context.Context,
+	pipeline runtime.Pipeline,
+	token string,
+	follow bool,
+	since time.Time,
+	write func(timestamp time.Time, line string) error,
+) error {
+	body, err := s.open(ctx, pipeline, token, follow, !since.IsZero())
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		timestamp, line, ok := parseLogStreamLine(scanner.Bytes(), since)
+		if !ok {
+			continue
+		}
+
+		if err := write(timestamp, line); err != nil {
+			return err
+		}
+	}
+
+	// the stream is closed when the context is canceled, which ends following the logs
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("reading logs of '%s': %w", s.source, err)
+	}
+
+	return nil
+}
+
+func (s logStream) open(
+	ctx context.Context,
+	pipeline runtime.Pipeline,
+	token string,
+	follow bool,
+	allLines bool,
+) (io.ReadCloser, error) {
+	tailLines := defaultLogsTailLines
+	if allLines {
+		tailLines = maxLogsTailLines
+	}
+
+	streamUrl, err := url.Parse(s.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parsing log stream endpoint of '%s': %w", s.source, err)
+	}
+
+	query := streamUrl.Query()
+	query.Set("follow", strconv.FormatBool(follow))
+	query.Set("tailLines", strconv.Itoa(tailLines))
+	query.Set("output", "json")
+	streamUrl.RawQuery = query.Encode()
+
+	req, err := runtime.NewRequest(ctx, http.MethodGet, streamUrl.String())
+	if err != nil {
+		return nil, fmt.Errorf("creating log stream request: %w", err)
+	}
+	req.Raw().Header.Set("Authorization", "Bearer "+token)
+	runtime.SkipBodyDownload(req)
+
+	res, err := pipeline.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("opening log stream of '%s': %w", s.source, err)
+	}
+
+	if !runtime.HasStatusCode(res, http.StatusOK) {
+		defer res.Body.Close()
+		return nil, fmt.Errorf("opening log stream of '%s': %w", s.source, runtime.NewResponseError(res))
+	}
+
+	return res.Body, nil
+}
+
+// parseLogStreamLine returns the time and the log of a line of the log stream, unless it is older than since. Lines
+// that aren't in the json output format are returned as is, with a zero time.
+func parseLogStreamLine(data []byte, since time.Time) (time.Time, string, bool) {
+	var line logStreamLine
+	if err := json.Unmarshal(data, &line); err != nil {
+		log.Printf("unexpected log stream line format: %v", err)
+		return time.Time{}, string(data), true
+	}
+
+	if !since.IsZero() && line.TimeStamp.Before(since) {
+		return time.Time{}, "", false
+	}
+
+	return line.TimeStamp, strings.TrimRight(line.Log, "\r\n"), true
+}
+
+func (cas *containerAppService) createRevisionReplicasClient(
+	ctx context.Context,
+	subscriptionId string,
+) (*armappcontainers.ContainerAppsRevisionReplicasClient, error) {
+	credential, err := cas.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := armappcontainers.NewContainerAppsRevisionReplicasClient(subscriptionId, credential, cas.armClientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("creating ContainerApps client: %w", err)
+	}
+
+	return client, nil
+}
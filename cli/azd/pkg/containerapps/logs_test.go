@@ -0,0 +1,205 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package containerapps
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v3"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockazsdk"
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ContainerApp_Logs(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+	revisionName := "APP_NAME--azd-0"
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	containerApp := &armappcontainers.ContainerApp{
+		Name: &appName,
+		Properties: &armappcontainers.ContainerAppProperties{
+			LatestRevisionName: &revisionName,
+		},
+	}
+
+	replica := func(name string, containers ...string) *armappcontainers.Replica {
+		replicaContainers := []*armappcontainers.ReplicaContainer{}
+		for _, container := range containers {
+			replicaContainers = append(replicaContainers, &armappcontainers.ReplicaContainer{
+				Name:              to.Ptr(container),
+				LogStreamEndpoint: to.Ptr("https://eastus2.azurecontainerapps.dev/" + name + "/" + container + "/logstream"),
+			})
+		}
+
+		return &armappcontainers.Replica{
+			Name:       to.Ptr(name),
+			Properties: &armappcontainers.ReplicaProperties{Containers: replicaContainers},
+		}
+	}
+
+	setupMocks := func(mockContext *mocks.MockContext) *[]*http.Request {
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodPost && strings.HasSuffix(request.URL.Path, "/getAuthtoken")
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armappcontainers.ContainerAppAuthToken{
+				Properties: &armappcontainers.ContainerAppAuthTokenProperties{Token: to.Ptr("TOKEN")},
+			})
+		})
+
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodGet && strings.HasSuffix(request.URL.Path, "/replicas")
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armappcontainers.ReplicaCollection{
+				Value: []*armappcontainers.Replica{
+					replica("replica-1", "web", "sidecar"),
+					replica("replica-2", "web"),
+				},
+			})
+		})
+
+		// the log streams of the containers are requested concurrently
+		var mu sync.Mutex
+		logStreamRequests := []*http.Request{}
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.URL.Host == "eastus2.azurecontainerapps.dev"
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			mu.Lock()
+			logStreamRequests = append(logStreamRequests, request)
+			mu.Unlock()
+
+			body := `{"TimeStamp":"2024-05-01T11:00:00Z","Log":"starting"}` + "\n" +
+				`{"TimeStamp":"2024-05-01T11:58:00Z","Log":"listening on 8080\r"}` + "\n"
+
+			return &http.Response{
+				Request:    request,
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		})
+
+		return &logStreamRequests
+	}
+
+	newService := func(mockContext *mocks.MockContext) ContainerAppService {
+		mockClock := clock.NewMock()
+		mockClock.Set(now)
+
+		return NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			mockClock,
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+	}
+
+	t.Run("AllContainers", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		logStreamRequests := setupMocks(mockContext)
+
+		writer := &strings.Builder{}
+		err := newService(mockContext).Logs(
+			*mockContext.Context, subscriptionId, resourceGroup, appName, &LogsOptions{Follow: true}, writer)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSuffix(writer.String(), "\n"), "\n")
+		sort.Strings(lines)
+		require.Equal(t, []string{
+			"[replica-1/sidecar] listening on 8080",
+			"[replica-1/sidecar] starting",
+			"[replica-1/web] listening on 8080",
+			"[replica-1/web] starting",
+			"[replica-2/web] listening on 8080",
+			"[replica-2/web] starting",
+		}, lines)
+
+		require.Len(t, *logStreamRequests, 3)
+		request := (*logStreamRequests)[0]
+		require.Equal(t, "Bearer TOKEN", request.Header.Get("Authorization"))
+		require.Equal(t, "true", request.URL.Query().Get("follow"))
+		require.Equal(t, "20", request.URL.Query().Get("tailLines"))
+		require.Equal(t, "json", request.URL.Query().Get("output"))
+	})
+
+	t.Run("ContainerSince", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		logStreamRequests := setupMocks(mockContext)
+
+		writer := &strings.Builder{}
+		err := newService(mockContext).Logs(
+			*mockContext.Context,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			&LogsOptions{Since: 10 * time.Minute, ContainerName: "sidecar"},
+			writer,
+		)
+		require.NoError(t, err)
+		require.Equal(t, "[replica-1/sidecar] listening on 8080\n", writer.String())
+
+		require.Len(t, *logStreamRequests, 1)
+		require.Equal(t, "false", (*logStreamRequests)[0].URL.Query().Get("follow"))
+		require.Equal(t, "300", (*logStreamRequests)[0].URL.Query().Get("tailLines"))
+	})
+
+	t.Run("Timestamps", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		_ = setupMocks(mockContext)
+
+		writer := &timestampWriter{}
+		err := newService(mockContext).Logs(
+			*mockContext.Context,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			&LogsOptions{ContainerName: "sidecar"},
+			writer,
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"2024-05-01T11:00:00Z [replica-1/sidecar] starting",
+			"2024-05-01T11:58:00Z [replica-1/sidecar] listening on 8080",
+		}, writer.lines)
+	})
+
+	t.Run("ContainerNotFound", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		_ = setupMocks(mockContext)
+
+		err := newService(mockContext).Logs(
+			*mockContext.Context,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			&LogsOptions{ContainerName: "worker"},
+			io.Discard,
+		)
+		require.ErrorContains(t, err, "container 'worker' not found in the replicas of revision 'APP_NAME--azd-0'")
+	})
+}
+
+// timestampWriter records the lines written with their time.
+type timestampWriter struct {
+	strings.Builder
+	lines []string
+}
+
+func (w *timestampWriter) WriteLine(timestamp time.Time, line string) error {
+	w.lines = append(w.lines, timestamp.Format(time.RFC3339)+" "+line)
+	return nil
+}
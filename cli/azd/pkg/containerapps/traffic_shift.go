@@ -0,0 +1,194 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package containerapps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v3"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+)
+
+// TrafficShift shifts the traffic of a container app to a new revision in steps, checking the new revision between
+// the steps. When a step fails, all the traffic is rolled back to the previous revision.
+type TrafficShift struct {
+	// Steps are the steps of the shift, in order. A final step sending all the traffic to the new revision is added
+	// when the last step doesn't.
+	Steps []TrafficShiftStep
+	// Gate checks the new revision once the traffic of a step is shifted and its pause has elapsed. Returning an error
+	// rolls back the traffic to the previous revision.
+	Gate func(ctx context.Context, status TrafficShiftStatus) error
+	// Progress reports the progress of the shift
+	Progress func(message string)
+}
+
+// TrafficShiftStep is a step of a traffic shift.
+type TrafficShiftStep struct {
+	// Weight is the percentage of the traffic sent to the new revision, the rest is sent to the previous revision
+	Weight int32
+	// Pause is the time to wait after the traffic is shifted, before the new revision is checked
+	Pause time.Duration
+}
+
+// TrafficShiftStatus is the status of a traffic shift, once the traffic of a step is shifted.
+type TrafficShiftStatus struct {
+	TrafficShiftStep
+	// RevisionName is the name of the new revision
+	RevisionName string
+	// RevisionFqdn is the fully qualified domain name of the new revision, which only serves the new revision. Empty
+	// when the container app has no ingress.
+	RevisionFqdn string
+}
+
+// shiftTraffic shifts the traffic from the previous revision to the new revision, step by step.
+func (cas *containerAppService) shiftTraffic(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	containerApp config.Config,
+	previousRevisionName string,
+	newRevisionName string,
+	options *ContainerAppOptions,
+) error {
+	shift := options.TrafficShift
+	steps := shift.Steps
+	if len(steps) == 0 || steps[len(steps)-1].Weight < 100 {
+		steps = append(steps, TrafficShiftStep{Weight: 100})
+	}
+
+	revisionFqdn, err := cas.revisionFqdn(ctx, subscriptionId, resourceGroupName, appName, newRevisionName, options)
+	if err != nil {
+		return err
+	}
+
+	for _, step := range steps {
+		cas.reportProgress(shift, fmt.Sprintf("Shifting %d%% of traffic to revision %s", step.Weight, newRevisionName))
+		err := cas.setTrafficWeights(
+			ctx,
+			subscriptionId,
+			resourceGroupName,
+			appName,
+			containerApp,
+			revisionTrafficWeights(newRevisionName, previousRevisionName, step.Weight),
+			options,
+		)
+		if err == nil && step.Pause > 0 {
+			cas.reportProgress(shift, fmt.Sprintf("Waiting %s at %d%% of traffic", step.Pause, step.Weight))
+			err = cas.pause(ctx, step.Pause)
+		}
+		if err == nil && shift.Gate != nil {
+			err = shift.Gate(ctx, TrafficShiftStatus{
+				TrafficShiftStep: step,
+				RevisionName:     newRevisionName,
+				RevisionFqdn:     revisionFqdn,
+			})
+		}
+
+		if err != nil {
+			err = fmt.Errorf("shifting %d%% of traffic to revision '%s': %w", step.Weight, newRevisionName, err)
+			return cas.rollbackTraffic(
+				ctx, subscriptionId, resourceGroupName, appName, containerApp, previousRevisionName, options, err)
+		}
+	}
+
+	return nil
+}
+
+// rollbackTraffic sends all the traffic back to the previous revision after the shift failed with shiftErr.
+func (cas *containerAppService) rollbackTraffic(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	containerApp config.Config,
+	previousRevisionName string,
+	options *ContainerAppOptions,
+	shiftErr error,
+) error {
+	cas.reportProgress(options.TrafficShift, fmt.Sprintf("Rolling back traffic to revision %s", previousRevisionName))
+	log.Printf("rolling back traffic of container app '%s' to revision '%s': %v", appName, previousRevisionName, shiftErr)
+
+	// the rollback runs even when the shift was canceled, so the traffic isn't left split between the revisions
+	err := cas.setTrafficWeights(
+		context.WithoutCancel(ctx),
+		subscriptionId,
+		resourceGroupName,
+		appName,
+		containerApp,
+		revisionTrafficWeights(previousRevisionName, "", 100),
+		options,
+	)
+	if err != nil {
+		return errors.Join(shiftErr, fmt.Errorf("rolling back traffic to revision '%s': %w", previousRevisionName, err))
+	}
+
+	return fmt.Errorf("%w. Traffic was rolled back to revision '%s'", shiftErr, previousRevisionName)
+}
+
+// revisionFqdn returns the fully qualified domain name of the revision.
+func (cas *containerAppService) revisionFqdn(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	revisionName string,
+	options *ContainerAppOptions,
+) (string, error) {
+	revisionsClient, err := cas.createRevisionsClient(ctx, subscriptionId, createApiVersionPolicy(options))
+	if err != nil {
+		return "", err
+	}
+
+	revision, err := revisionsClient.GetRevision(ctx, resourceGroupName, appName, revisionName, nil)
+	if err != nil {
+		return "", fmt.Errorf("getting revision '%s': %w", revisionName, err)
+	}
+
+	if revision.Properties == nil || revision.Properties.Fqdn == nil {
+		return "", nil
+	}
+	return *revision.Properties.Fqdn, nil
+}
+
+// pause waits for the duration, or until the context is canceled.
+func (cas *containerAppService) pause(ctx context.Context, duration time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-cas.clock.After(duration):
+		return nil
+	}
+}
+
+func (cas *containerAppService) reportProgress(shift *TrafficShift, message string) {
+	if shift != nil && shift.Progress != nil {
+		shift.Progress(message)
+	}
+}
+
+// revisionTrafficWeights returns the traffic weights sending the percentage of traffic in weight to the revision and the
+// rest to the other revision.
+func revisionTrafficWeights(revisionName string, otherRevisionName string, weight int32) []*armappcontainers.TrafficWeight {
+	trafficWeights := []*armappcontainers.TrafficWeight{
+		{
+			RevisionName: to.Ptr(revisionName),
+			Weight:       to.Ptr(weight),
+		},
+	}
+
+	if weight < 100 {
+		trafficWeights = append(trafficWeights, &armappcontainers.TrafficWeight{
+			RevisionName: to.Ptr(otherRevisionName),
+			Weight:       to.Ptr(100 - weight),
+		})
+	}
+
+	return trafficWeights
+}
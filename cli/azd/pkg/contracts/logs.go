@@ -0,0 +1,15 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package contracts
+
+import "time"
+
+// LogLine is the contract for a line of the output of `azd logs`. The lines are written to stdout as they are streamed,
+// each as a JSON object on its own line.
+type LogLine struct {
+	// Timestamp is the time the service wrote the line at, omitted when the host of the service doesn't report it
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	Service   string     `json:"service"`
+	Message   string     `json:"message"`
+}
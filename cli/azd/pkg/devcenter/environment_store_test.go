@@ -316,7 +316,7 @@ func newEnvironmentStoreForTest(
 
 	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
 	fileConfigManager := config.NewFileConfigManager(config.NewManager())
-	dataStore := environment.NewLocalFileDataStore(azdContext, fileConfigManager)
+	dataStore := environment.NewLocalFileDataStore(azdContext, fileConfigManager, nil)
 
 	return NewEnvironmentStore(devCenterConfig, devCenterClient, prompter, manager, dataStore)
 }
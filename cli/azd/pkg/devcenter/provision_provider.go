@@ -25,7 +25,7 @@ import (
 
 const (
 	ProvisionParametersConfigPath string                    = "provision.parameters"
-	ProvisionKindDevCenter        provisioning.ProviderKind = "devcenter"
+	ProvisionKindDevCenter        provisioning.ProviderKind = provisioning.DevCenter
 
 	// ADE environment ARM deployment tags
 	DeploymentTagDevCenterName    = "AdeDevCenterName"
@@ -0,0 +1,297 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/braydonk/yaml"
+)
+
+// BundleVersion is the version of the format of environment bundles.
+const BundleVersion = 1
+
+// BundleFormat is the serialization format of an environment bundle.
+type BundleFormat string
+
+const (
+	BundleFormatJson BundleFormat = "json"
+	BundleFormatYaml BundleFormat = "yaml"
+)
+
+// BundleFormatFromPath returns the format of a bundle file from its extension, JSON unless the extension is .yaml or
+// .yml.
+func BundleFormatFromPath(filePath string) BundleFormat {
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".yaml", ".yml":
+		return BundleFormatYaml
+	default:
+		return BundleFormatJson
+	}
+}
+
+// Bundle is a portable representation of an environment, with its .env values and its configuration. Secrets are
+// stored in plain text, and restored as secrets when the bundle is applied to an environment.
+type Bundle struct {
+	Version int    `json:"version" yaml:"version"`
+	Name    string `json:"name" yaml:"name"`
+	// The .env values of the environment
+	Values map[string]string `json:"values" yaml:"values"`
+	// The keys of the .env values that are secrets
+	Secrets []string `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+	// The configuration of the environment, with secrets resolved
+	Config map[string]any `json:"config,omitempty" yaml:"config,omitempty"`
+	// The paths of the configuration values that are secrets
+	ConfigSecrets []string `json:"configSecrets,omitempty" yaml:"configSecrets,omitempty"`
+}
+
+// NewBundle creates a bundle of the values and configuration of the environment.
+func NewBundle(env *Environment) *Bundle {
+	bundle := &Bundle{
+		Version: BundleVersion,
+		Name:    env.Name(),
+		Values:  env.Dotenv(),
+		Config:  env.Config.ResolvedRaw(),
+	}
+
+	// The name is not a value of the bundle, as it is replaced when the bundle is applied to another environment
+	delete(bundle.Values, EnvNameEnvVarName)
+
+	for key := range bundle.Values {
+		if env.IsSecret(key) {
+			bundle.Secrets = append(bundle.Secrets, key)
+		}
+	}
+
+	for _, configPath := range configPaths(env.Config.Raw()) {
+		if isVaultReference(configValue(env.Config.Raw(), configPath)) {
+			bundle.ConfigSecrets = append(bundle.ConfigSecrets, configPath)
+		}
+	}
+
+	slices.Sort(bundle.Secrets)
+	slices.Sort(bundle.ConfigSecrets)
+
+	return bundle
+}
+
+// ReadBundle reads a bundle in the given format.
+func ReadBundle(reader io.Reader, format BundleFormat) (*Bundle, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading bundle: %w", err)
+	}
+
+	var bundle Bundle
+	if format == BundleFormatYaml {
+		err = yaml.Unmarshal(contents, &bundle)
+	} else {
+		err = json.Unmarshal(contents, &bundle)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parsing bundle: %w", err)
+	}
+
+	if bundle.Version != BundleVersion {
+		return nil, fmt.Errorf("unsupported bundle version %d, expected %d", bundle.Version, BundleVersion)
+	}
+
+	return &bundle, nil
+}
+
+// Write writes the bundle in the given format.
+func (b *Bundle) Write(writer io.Writer, format BundleFormat) error {
+	var contents []byte
+	var err error
+
+	if format == BundleFormatYaml {
+		contents, err = yaml.Marshal(b)
+	} else {
+		contents, err = json.MarshalIndent(b, "", "  ")
+		contents = append(contents, '\n')
+	}
+
+	if err != nil {
+		return fmt.Errorf("marshalling bundle: %w", err)
+	}
+
+	if _, err := writer.Write(contents); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+
+	return nil
+}
+
+// Filter removes the .env values and the configuration values whose keys or paths don't match any of the include
+// patterns, when include patterns are provided, or match any of the exclude patterns. Patterns use the syntax of
+// [path.Match], for example 'AZURE_*' for .env values, or 'infra.parameters.*' for configuration values.
+func (b *Bundle) Filter(include []string, exclude []string) error {
+	for _, pattern := range slices.Concat(include, exclude) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid key pattern '%s': %w", pattern, err)
+		}
+	}
+
+	matches := func(key string, patterns []string) bool {
+		return slices.ContainsFunc(patterns, func(pattern string) bool {
+			matched, _ := path.Match(pattern, key)
+			return matched
+		})
+	}
+	excluded := func(key string) bool {
+		return (len(include) > 0 && !matches(key, include)) || matches(key, exclude)
+	}
+
+	for key := range b.Values {
+		if excluded(key) {
+			delete(b.Values, key)
+		}
+	}
+
+	b.Secrets = slices.DeleteFunc(b.Secrets, func(key string) bool {
+		_, has := b.Values[key]
+		return !has
+	})
+
+	filteredConfig := map[string]any{}
+	for _, configPath := range configPaths(b.Config) {
+		if !excluded(configPath) {
+			setConfigValue(filteredConfig, configPath, configValue(b.Config, configPath))
+		}
+	}
+
+	b.Config = filteredConfig
+	b.ConfigSecrets = slices.DeleteFunc(b.ConfigSecrets, func(configPath string) bool {
+		return configValue(b.Config, configPath) == nil
+	})
+
+	return nil
+}
+
+// Replace replaces the values and the configuration of the environment with the ones of the bundle. The name of the
+// environment is kept.
+func (b *Bundle) Replace(env *Environment) error {
+	for key := range env.Dotenv() {
+		if key != EnvNameEnvVarName {
+			env.DotenvDelete(key)
+		}
+	}
+
+	env.Config = config.NewEmptyConfig()
+
+	return b.Apply(env)
+}
+
+// Apply sets the values and the configuration of the bundle in the environment. The name of the environment is kept.
+func (b *Bundle) Apply(env *Environment) error {
+	for key, value := range b.Values {
+		if key == EnvNameEnvVarName {
+			continue
+		}
+
+		if slices.Contains(b.Secrets, key) {
+			env.DotenvSetSecret(key, value)
+		} else {
+			env.DotenvSet(key, value)
+		}
+	}
+
+	for _, configPath := range configPaths(b.Config) {
+		value := configValue(b.Config, configPath)
+
+		var err error
+		if stringValue, isString := value.(string); isString && slices.Contains(b.ConfigSecrets, configPath) {
+			err = env.Config.SetSecret(configPath, stringValue)
+		} else {
+			err = env.Config.Set(configPath, value)
+		}
+
+		if err != nil {
+			return fmt.Errorf("setting config '%s': %w", configPath, err)
+		}
+	}
+
+	return nil
+}
+
+// configVaultKey is the key of the reference to the vault of the secrets of a configuration.
+const configVaultKey = "vault"
+
+// configPaths returns the sorted paths of the leaf values of a configuration, except for the reference to the vault of
+// the configuration secrets.
+func configPaths(data map[string]any) []string {
+	all := leafPaths(data)
+	all = slices.DeleteFunc(all, func(configPath string) bool {
+		return configPath == configVaultKey
+	})
+
+	slices.Sort(all)
+	return all
+}
+
+func leafPaths(data map[string]any) []string {
+	var all []string
+	for key, value := range data {
+		if node, isNode := value.(map[string]any); isNode {
+			for _, child := range leafPaths(node) {
+				all = append(all, key+"."+child)
+			}
+		} else {
+			all = append(all, key)
+		}
+	}
+
+	return all
+}
+
+// configValue returns the value at the given path of raw configuration data, without resolving vault references.
+func configValue(data map[string]any, configPath string) any {
+	node := data
+	parts := strings.Split(configPath, ".")
+	for i, part := range parts {
+		value, has := node[part]
+		if !has {
+			return nil
+		}
+
+		if i == len(parts)-1 {
+			return value
+		}
+
+		if node, has = value.(map[string]any); !has {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// setConfigValue sets the value at the given path of raw configuration data, creating the intermediate nodes.
+func setConfigValue(data map[string]any, configPath string, value any) {
+	node := data
+	parts := strings.Split(configPath, ".")
+	for _, part := range parts[:len(parts)-1] {
+		child, has := node[part].(map[string]any)
+		if !has {
+			child = map[string]any{}
+			node[part] = child
+		}
+
+		node = child
+	}
+
+	node[parts[len(parts)-1]] = value
+}
+
+func isVaultReference(value any) bool {
+	stringValue, isString := value.(string)
+	return isString && strings.HasPrefix(stringValue, "vault://")
+}
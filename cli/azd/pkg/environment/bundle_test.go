@@ -0,0 +1,120 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Bundle_RoundTrip(t *testing.T) {
+	env := New("dev")
+	env.DotenvSet("AZURE_LOCATION", "westus2")
+	env.DotenvSetSecret("API_KEY", "secret")
+	require.NoError(t, env.Config.Set("infra.parameters.sku", "basic"))
+	require.NoError(t, env.Config.SetSecret("infra.parameters.password", "p@ss"))
+
+	for _, format := range []BundleFormat{BundleFormatJson, BundleFormatYaml} {
+		t.Run(string(format), func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			require.NoError(t, NewBundle(env).Write(buf, format))
+			require.NotContains(t, buf.String(), "vault://")
+
+			bundle, err := ReadBundle(buf, format)
+			require.NoError(t, err)
+			require.Equal(t, "dev", bundle.Name)
+			require.Equal(t, []string{"API_KEY"}, bundle.Secrets)
+			require.Equal(t, []string{"infra.parameters.password"}, bundle.ConfigSecrets)
+
+			imported := New("feature-x")
+			require.NoError(t, bundle.Apply(imported))
+
+			require.Equal(t, "feature-x", imported.Name())
+			require.Equal(t, "feature-x", imported.Getenv(EnvNameEnvVarName))
+			require.Equal(t, "westus2", imported.Getenv("AZURE_LOCATION"))
+			require.Equal(t, "secret", imported.Getenv("API_KEY"))
+			require.True(t, imported.IsSecret("API_KEY"))
+
+			sku, _ := imported.Config.Get("infra.parameters.sku")
+			require.Equal(t, "basic", sku)
+
+			password, _ := imported.Config.Get("infra.parameters.password")
+			require.Equal(t, "p@ss", password)
+			require.True(t, isVaultReference(configValue(imported.Config.Raw(), "infra.parameters.password")))
+		})
+	}
+}
+
+func Test_Bundle_ReadUnsupportedVersion(t *testing.T) {
+	_, err := ReadBundle(bytes.NewBufferString(`{"version": 2, "name": "dev"}`), BundleFormatJson)
+	require.ErrorContains(t, err, "unsupported bundle version 2")
+}
+
+func Test_Bundle_Filter(t *testing.T) {
+	bundle := &Bundle{
+		Values: map[string]string{
+			"AZURE_LOCATION":        "westus2",
+			"AZURE_SUBSCRIPTION_ID": "sub",
+			"API_KEY":               "secret",
+			"SERVICE_WEB_URI":       "https://web",
+		},
+		Secrets: []string{"API_KEY"},
+		Config: map[string]any{
+			"infra": map[string]any{
+				"parameters": map[string]any{"sku": "basic", "password": "p@ss"},
+			},
+			"provision": map[string]any{"skipPreview": true},
+		},
+		ConfigSecrets: []string{"infra.parameters.password"},
+	}
+
+	require.NoError(t, bundle.Filter(
+		[]string{"AZURE_*", "API_*", "infra.parameters.*"}, []string{"AZURE_SUBSCRIPTION_ID", "*.sku"}))
+	require.Equal(t, map[string]string{
+		"AZURE_LOCATION": "westus2",
+		"API_KEY":        "secret",
+	}, bundle.Values)
+	require.Equal(t, []string{"API_KEY"}, bundle.Secrets)
+	require.Equal(t, map[string]any{
+		"infra": map[string]any{
+			"parameters": map[string]any{"password": "p@ss"},
+		},
+	}, bundle.Config)
+	require.Equal(t, []string{"infra.parameters.password"}, bundle.ConfigSecrets)
+
+	require.NoError(t, bundle.Filter(nil, []string{"infra.*"}))
+	require.Empty(t, bundle.Config)
+	require.Empty(t, bundle.ConfigSecrets)
+
+	require.NoError(t, bundle.Filter(nil, []string{"API_KEY"}))
+	require.Empty(t, bundle.Secrets)
+
+	require.Error(t, bundle.Filter([]string{"["}, nil))
+}
+
+func Test_Bundle_Replace(t *testing.T) {
+	env := New("dev")
+	env.DotenvSet("STALE", "stale")
+	env.DotenvSet("AZURE_LOCATION", "eastus")
+	require.NoError(t, env.Config.Set("infra.parameters.stale", "stale"))
+
+	bundle := &Bundle{
+		Values: map[string]string{"AZURE_LOCATION": "westus2"},
+		Config: map[string]any{"infra": map[string]any{"parameters": map[string]any{"sku": "basic"}}},
+	}
+	require.NoError(t, bundle.Replace(env))
+
+	require.Equal(t, map[string]string{
+		EnvNameEnvVarName: "dev",
+		"AZURE_LOCATION":  "westus2",
+	}, env.Dotenv())
+
+	_, has := env.Config.Get("infra.parameters.stale")
+	require.False(t, has)
+
+	sku, _ := env.Config.Get("infra.parameters.sku")
+	require.Equal(t, "basic", sku)
+}
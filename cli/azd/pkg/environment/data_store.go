@@ -5,6 +5,7 @@ package environment
 
 import (
 	"context"
+	"errors"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 )
@@ -15,12 +16,19 @@ type RemoteKind string
 
 const (
 	RemoteKindAzureBlobStorage RemoteKind = "AzureBlobStorage"
+	RemoteKindGit              RemoteKind = "Git"
+	RemoteKindHttp             RemoteKind = "Http"
 )
 
 var ValidRemoteKinds = []string{
 	string(RemoteKindAzureBlobStorage),
+	string(RemoteKindGit),
+	string(RemoteKindHttp),
 }
 
+// ErrLocked is returned when an environment is locked by another operation.
+var ErrLocked = errors.New("environment is locked")
+
 // SaveOptions provide additional metadata for the save operation
 type SaveOptions struct {
 	// Whether or not the environment is new
@@ -52,3 +60,11 @@ type DataStore interface {
 
 type LocalDataStore DataStore
 type RemoteDataStore DataStore
+
+// Locker is implemented by data stores that can lock an environment, so that concurrent operations cannot overwrite
+// each other's changes.
+type Locker interface {
+	// Lock acquires an exclusive lock on the environment with the specified name and returns a function that releases it.
+	// ErrLocked is returned when the environment is already locked.
+	Lock(ctx context.Context, name string) (release func() error, err error)
+}
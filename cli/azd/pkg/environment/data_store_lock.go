@@ -0,0 +1,88 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LockFileName is the name of the file that locks an environment in data stores that use lock files.
+const LockFileName = ".lock"
+
+// lockFileDuration is how long a lock file is valid for. A lock file that is older, for example because the operation
+// that acquired it was interrupted, is ignored.
+const lockFileDuration = 2 * time.Hour
+
+// lockInfo is the content of a lock file.
+type lockInfo struct {
+	Id         string    `json:"id"`
+	Owner      string    `json:"owner"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+// newLockInfo creates the lock file content for an operation of the current user.
+func newLockInfo() *lockInfo {
+	now := time.Now().UTC()
+
+	return &lockInfo{
+		Id:         uuid.NewString(),
+		Owner:      lockOwner(),
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(lockFileDuration),
+	}
+}
+
+// parseLockInfo parses the content of a lock file. Content that can't be parsed is treated as an expired lock.
+func parseLockInfo(contents []byte) *lockInfo {
+	var info lockInfo
+	if err := json.Unmarshal(contents, &info); err != nil {
+		return &lockInfo{}
+	}
+
+	return &info
+}
+
+func (l *lockInfo) expired() bool {
+	return time.Now().After(l.ExpiresAt)
+}
+
+func (l *lockInfo) bytes() []byte {
+	// marshalling a struct of strings and times can't fail
+	contents, _ := json.MarshalIndent(l, "", "  ")
+	return contents
+}
+
+// lockedError returns the error for an environment locked by the given lock.
+func lockedError(name string, l *lockInfo) error {
+	return fmt.Errorf(
+		"%w: '%s' is locked by %s since %s. The lock expires at %s if it is not released",
+		ErrLocked,
+		name,
+		l.Owner,
+		l.AcquiredAt.Local().Format(time.DateTime),
+		l.ExpiresAt.Local().Format(time.DateTime),
+	)
+}
+
+// lockOwner describes the current user and machine, as 'user@host'.
+func lockOwner() string {
+	userName := "unknown"
+	if current, err := user.Current(); err == nil {
+		userName = current.Username
+	}
+
+	hostName, err := os.Hostname()
+	if err != nil {
+		hostName = "unknown"
+	}
+
+	return fmt.Sprintf("%s@%s", userName, hostName)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DifferenceKind describes how a value differs between two environments.
+type DifferenceKind string
+
+const (
+	// DifferenceAdded is a value that only exists in the second environment.
+	DifferenceAdded DifferenceKind = "added"
+	// DifferenceRemoved is a value that only exists in the first environment.
+	DifferenceRemoved DifferenceKind = "removed"
+	// DifferenceChanged is a value that exists in both environments, with different values.
+	DifferenceChanged DifferenceKind = "changed"
+)
+
+// Difference is a value that differs between two environments.
+type Difference struct {
+	// The .env key, or the path of the configuration value
+	Key  string         `json:"key"`
+	Kind DifferenceKind `json:"kind"`
+	// The value in the first environment, empty when the value was added
+	Value string `json:"value,omitempty"`
+	// The value in the second environment, empty when the value was removed
+	OtherValue string `json:"otherValue,omitempty"`
+	// Secret is set when the value is a secret in any of the environments
+	Secret bool `json:"secret"`
+}
+
+// Diff contains the differences between two environments.
+type Diff struct {
+	// The differences of the .env values
+	Values []Difference `json:"values"`
+	// The differences of the configuration values, from config.json
+	Config []Difference `json:"config"`
+}
+
+// IsEmpty returns true when the environments have the same values and configuration.
+func (d *Diff) IsEmpty() bool {
+	return len(d.Values) == 0 && len(d.Config) == 0
+}
+
+// Compare returns the differences of the .env values and of the configuration of two environments. The name of the
+// environments, which always differs, is not compared.
+func Compare(env *Environment, other *Environment) *Diff {
+	diff := &Diff{
+		Values: []Difference{},
+		Config: []Difference{},
+	}
+
+	values := env.Dotenv()
+	otherValues := other.Dotenv()
+	delete(values, EnvNameEnvVarName)
+	delete(otherValues, EnvNameEnvVarName)
+
+	diff.Values = compareValues(values, otherValues, func(key string) bool {
+		return env.IsSecret(key) || other.IsSecret(key)
+	})
+
+	config := flattenConfig(env.Config.ResolvedRaw())
+	otherConfig := flattenConfig(other.Config.ResolvedRaw())
+
+	diff.Config = compareValues(config, otherConfig, func(configPath string) bool {
+		return isVaultReference(configValue(env.Config.Raw(), configPath)) ||
+			isVaultReference(configValue(other.Config.Raw(), configPath))
+	})
+
+	return diff
+}
+
+func compareValues(values map[string]string, otherValues map[string]string, isSecret func(string) bool) []Difference {
+	differences := []Difference{}
+
+	for key, value := range values {
+		otherValue, has := otherValues[key]
+		switch {
+		case !has:
+			differences = append(differences, Difference{
+				Key: key, Kind: DifferenceRemoved, Value: value, Secret: isSecret(key),
+			})
+		case value != otherValue:
+			differences = append(differences, Difference{
+				Key: key, Kind: DifferenceChanged, Value: value, OtherValue: otherValue, Secret: isSecret(key),
+			})
+		}
+	}
+
+	for key, otherValue := range otherValues {
+		if _, has := values[key]; !has {
+			differences = append(differences, Difference{
+				Key: key, Kind: DifferenceAdded, OtherValue: otherValue, Secret: isSecret(key),
+			})
+		}
+	}
+
+	slices.SortFunc(differences, func(a, b Difference) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return differences
+}
+
+// flattenConfig returns the leaf values of configuration data by path, with non string values JSON encoded.
+func flattenConfig(data map[string]any) map[string]string {
+	flattened := map[string]string{}
+	for _, configPath := range configPaths(data) {
+		switch value := configValue(data, configPath).(type) {
+		case string:
+			flattened[configPath] = value
+		default:
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				encoded = []byte(fmt.Sprintf("%v", value))
+			}
+
+			flattened[configPath] = string(encoded)
+		}
+	}
+
+	return flattened
+}
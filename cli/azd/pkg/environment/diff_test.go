@@ -0,0 +1,41 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Compare(t *testing.T) {
+	env := New("dev")
+	env.DotenvSet("SAME", "value")
+	env.DotenvSet("REMOVED", "value")
+	env.DotenvSet("CHANGED", "dev")
+	env.DotenvSetSecret("API_KEY", "dev-secret")
+	require.NoError(t, env.Config.Set("infra.parameters.sku", "basic"))
+
+	other := New("prod")
+	other.DotenvSet("SAME", "value")
+	other.DotenvSet("ADDED", "value")
+	other.DotenvSet("CHANGED", "prod")
+	other.DotenvSet("API_KEY", "prod-secret")
+	require.NoError(t, other.Config.Set("infra.parameters.sku", "premium"))
+	require.NoError(t, other.Config.Set("infra.parameters.replicas", 3))
+
+	diff := Compare(env, other)
+	require.Equal(t, []Difference{
+		{Key: "ADDED", Kind: DifferenceAdded, OtherValue: "value"},
+		{Key: "API_KEY", Kind: DifferenceChanged, Value: "dev-secret", OtherValue: "prod-secret", Secret: true},
+		{Key: "CHANGED", Kind: DifferenceChanged, Value: "dev", OtherValue: "prod"},
+		{Key: "REMOVED", Kind: DifferenceRemoved, Value: "value"},
+	}, diff.Values)
+	require.Equal(t, []Difference{
+		{Key: "infra.parameters.replicas", Kind: DifferenceAdded, OtherValue: "3"},
+		{Key: "infra.parameters.sku", Kind: DifferenceChanged, Value: "basic", OtherValue: "premium"},
+	}, diff.Config)
+
+	require.True(t, Compare(env, env).IsEmpty())
+}
@@ -0,0 +1,385 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"golang.org/x/crypto/scrypt"
+)
+
+// EncryptionConfigKey is the user configuration key that enables encryption of the secret values of local environments.
+// The value is the source of the encryption key, see [EncryptionKeySource].
+const EncryptionConfigKey = "env.encryption"
+
+// EncryptionPassphraseEnvVarName is the name of the environment variable that holds the passphrase the encryption key
+// is derived from, when the passphrase key source is used.
+const EncryptionPassphraseEnvVarName = "AZD_ENV_PASSPHRASE"
+
+// EncryptionKeySource is the source of the key secret values of local environments are encrypted with.
+type EncryptionKeySource string
+
+const (
+	// EncryptionKeySourceKeyring holds a random key in the OS keyring.
+	EncryptionKeySourceKeyring EncryptionKeySource = "keyring"
+	// EncryptionKeySourcePassphrase derives the key from a passphrase, with the parameters of the derivation stored in
+	// a key file in the azd configuration directory.
+	EncryptionKeySourcePassphrase EncryptionKeySource = "passphrase"
+)
+
+// ValidEncryptionKeySources are the values supported for [EncryptionConfigKey].
+var ValidEncryptionKeySources = []EncryptionKeySource{
+	EncryptionKeySourceKeyring,
+	EncryptionKeySourcePassphrase,
+}
+
+// encryptedValuePrefix prefixes values encrypted at rest, as azdenc:v1:<key source>:<base64 nonce and ciphertext>.
+const encryptedValuePrefix = "azdenc:v1:"
+
+// encryptionKeyName is the name of the key in the OS keyring.
+const encryptionKeyName = "env-encryption-key"
+
+// passphraseKeyFileName is the name of the key file, in the azd configuration directory, of the passphrase key source.
+const passphraseKeyFileName = "env-encryption.json"
+
+// passphraseKeyCheck is encrypted in the key file, to detect an incorrect passphrase.
+const passphraseKeyCheck = "azd"
+
+// ErrEncryptionKeyNotFound is returned when a value can't be decrypted because its encryption key doesn't exist.
+var ErrEncryptionKeyNotFound = errors.New("encryption key not found")
+
+// DecryptionError is returned when secret values are read that couldn't be decrypted when the environment was loaded.
+type DecryptionError struct {
+	// The keys of the values that couldn't be decrypted
+	Keys []string
+	// The reason the first of the values couldn't be decrypted
+	Err error
+}
+
+func (e *DecryptionError) Error() string {
+	return fmt.Sprintf(
+		"the secret value(s) %s can't be decrypted: %v. Set the passphrase the values were encrypted with in the %s "+
+			"environment variable, or make the OS keyring available when the values were encrypted with the keyring. "+
+			"Otherwise, set the values again with 'azd env set' and encrypt them with 'azd env encrypt'",
+		strings.Join(e.Keys, ", "),
+		e.Err,
+		EncryptionPassphraseEnvVarName,
+	)
+}
+
+func (e *DecryptionError) Unwrap() error {
+	return e.Err
+}
+
+// IsEncryptedValue returns true when value is a value encrypted at rest.
+func IsEncryptedValue(value string) bool {
+	return strings.HasPrefix(value, encryptedValuePrefix)
+}
+
+// ValueEncryptor encrypts and decrypts the secret values of local environments with AES-GCM. The encryption key comes
+// from the key source configured with [EncryptionConfigKey].
+type ValueEncryptor struct {
+	userConfigManager config.UserConfigManager
+	keyring           keyring
+
+	mu   sync.Mutex
+	keys map[EncryptionKeySource][]byte
+}
+
+func NewValueEncryptor(userConfigManager config.UserConfigManager, commandRunner exec.CommandRunner) *ValueEncryptor {
+	return &ValueEncryptor{
+		userConfigManager: userConfigManager,
+		keyring:           newKeyring(commandRunner),
+		keys:              map[EncryptionKeySource][]byte{},
+	}
+}
+
+// KeySource returns the configured key source, or an empty key source when encryption is not enabled.
+func (e *ValueEncryptor) KeySource() (EncryptionKeySource, error) {
+	userConfig, err := e.userConfigManager.Load()
+	if err != nil {
+		return "", fmt.Errorf("loading user config: %w", err)
+	}
+
+	value, has := userConfig.GetString(EncryptionConfigKey)
+	if !has || value == "" {
+		return "", nil
+	}
+
+	keySource := EncryptionKeySource(value)
+	if !isValidKeySource(keySource) {
+		return "", fmt.Errorf(
+			"invalid value '%s' for '%s'. Supported values are '%s' and '%s'",
+			value,
+			EncryptionConfigKey,
+			EncryptionKeySourceKeyring,
+			EncryptionKeySourcePassphrase,
+		)
+	}
+
+	return keySource, nil
+}
+
+// Encrypt encrypts the value of the given .env key with the key of the given key source. The .env key is
+// authenticated with the value, so that an encrypted value can't be moved to another key.
+func (e *ValueEncryptor) Encrypt(
+	ctx context.Context,
+	keySource EncryptionKeySource,
+	name string,
+	value string,
+) (string, error) {
+	key, err := e.key(ctx, keySource, true)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAead(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(name))
+	return fmt.Sprintf("%s%s:%s", encryptedValuePrefix, keySource, base64.StdEncoding.EncodeToString(sealed)), nil
+}
+
+// Decrypt decrypts a value encrypted by [ValueEncryptor.Encrypt], with the key of the key source it was encrypted with.
+func (e *ValueEncryptor) Decrypt(ctx context.Context, name string, value string) (string, error) {
+	source, data, ok := strings.Cut(strings.TrimPrefix(value, encryptedValuePrefix), ":")
+	if !ok || !isValidKeySource(EncryptionKeySource(source)) {
+		return "", fmt.Errorf("decrypting '%s': unsupported encrypted value", name)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", fmt.Errorf("decrypting '%s': %w", name, err)
+	}
+
+	key, err := e.key(ctx, EncryptionKeySource(source), false)
+	if err != nil {
+		return "", fmt.Errorf("decrypting '%s': %w", name, err)
+	}
+
+	aead, err := newAead(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("decrypting '%s': unsupported encrypted value", name)
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(name))
+	if err != nil {
+		return "", fmt.Errorf("decrypting '%s': the value was encrypted with a different key: %w", name, err)
+	}
+
+	return string(plaintext), nil
+}
+
+// key returns the key of the key source, creating it when create is set and the key doesn't exist yet.
+func (e *ValueEncryptor) key(ctx context.Context, keySource EncryptionKeySource, create bool) ([]byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if key, has := e.keys[keySource]; has {
+		return key, nil
+	}
+
+	var key []byte
+	var err error
+
+	switch keySource {
+	case EncryptionKeySourceKeyring:
+		key, err = e.keyringKey(ctx, create)
+	case EncryptionKeySourcePassphrase:
+		key, err = passphraseKey(create)
+	default:
+		err = fmt.Errorf("unsupported encryption key source '%s'", keySource)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	e.keys[keySource] = key
+	return key, nil
+}
+
+func (e *ValueEncryptor) keyringKey(ctx context.Context, create bool) ([]byte, error) {
+	key, err := e.keyring.Get(ctx, encryptionKeyName)
+	if errors.Is(err, errKeyringItemNotFound) {
+		if !create {
+			return nil, fmt.Errorf("%w in the OS keyring", ErrEncryptionKeyNotFound)
+		}
+
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("generating encryption key: %w", err)
+		}
+
+		if err := e.keyring.Set(ctx, encryptionKeyName, key); err != nil {
+			return nil, fmt.Errorf("storing encryption key in the OS keyring: %w", err)
+		}
+
+		return key, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading encryption key from the OS keyring: %w", err)
+	}
+
+	if len(key) != 32 {
+		return nil, errors.New("the encryption key in the OS keyring is invalid")
+	}
+
+	return key, nil
+}
+
+// passphraseKeyFile is the content of the key file of the passphrase key source.
+type passphraseKeyFile struct {
+	// The salt of the key derivation, base64 encoded
+	Salt string `json:"salt"`
+	// passphraseKeyCheck encrypted with the derived key, base64 encoded
+	Check string `json:"check"`
+}
+
+// passphraseKey derives the key from the passphrase in the EncryptionPassphraseEnvVarName environment variable. The
+// salt of the derivation is stored in the key file, which is created when create is set and the file doesn't exist yet.
+func passphraseKey(create bool) ([]byte, error) {
+	passphrase := os.Getenv(EncryptionPassphraseEnvVarName)
+	if passphrase == "" {
+		return nil, fmt.Errorf(
+			"the encryption passphrase is not set. Set the passphrase in the %s environment variable",
+			EncryptionPassphraseEnvVarName,
+		)
+	}
+
+	configDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	keyFilePath := filepath.Join(configDir, passphraseKeyFileName)
+	contents, err := os.ReadFile(keyFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		if !create {
+			return nil, fmt.Errorf("%w: %s does not exist", ErrEncryptionKeyNotFound, keyFilePath)
+		}
+
+		return createPassphraseKeyFile(keyFilePath, passphrase)
+	} else if err != nil {
+		return nil, fmt.Errorf("reading key file: %w", err)
+	}
+
+	var keyFile passphraseKeyFile
+	if err := json.Unmarshal(contents, &keyFile); err != nil {
+		return nil, fmt.Errorf("parsing key file %s: %w", keyFilePath, err)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(keyFile.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("parsing key file %s: %w", keyFilePath, err)
+	}
+
+	check, err := base64.StdEncoding.DecodeString(keyFile.Check)
+	if err != nil {
+		return nil, fmt.Errorf("parsing key file %s: %w", keyFilePath, err)
+	}
+
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAead(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(check) < aead.NonceSize() {
+		return nil, fmt.Errorf("parsing key file %s: invalid check value", keyFilePath)
+	}
+
+	if _, err := aead.Open(nil, check[:aead.NonceSize()], check[aead.NonceSize():], nil); err != nil {
+		return nil, fmt.Errorf("the passphrase in the %s environment variable is incorrect", EncryptionPassphraseEnvVarName)
+	}
+
+	return key, nil
+}
+
+func createPassphraseKeyFile(keyFilePath string, passphrase string) ([]byte, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generating salt: %w", err)
+	}
+
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAead(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+
+	contents, err := json.MarshalIndent(passphraseKeyFile{
+		Salt:  base64.StdEncoding.EncodeToString(salt),
+		Check: base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(passphraseKeyCheck), nil)),
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshalling key file: %w", err)
+	}
+
+	if err := os.WriteFile(keyFilePath, contents, osutil.PermissionFileOwnerOnly); err != nil {
+		return nil, fmt.Errorf("writing key file: %w", err)
+	}
+
+	return key, nil
+}
+
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, fmt.Errorf("deriving encryption key: %w", err)
+	}
+
+	return key, nil
+}
+
+func newAead(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func isValidKeySource(keySource EncryptionKeySource) bool {
+	return slices.Contains(ValidEncryptionKeySources, keySource)
+}
@@ -0,0 +1,23 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"context"
+	"errors"
+)
+
+// keyringService is the service the items of azd are stored under in the OS keyring.
+const keyringService = "azd"
+
+// errKeyringItemNotFound is returned when an item doesn't exist in the OS keyring.
+var errKeyringItemNotFound = errors.New("item not found in the OS keyring")
+
+// keyring stores items in the OS keyring.
+type keyring interface {
+	// Get returns the item with the given name, or errKeyringItemNotFound when it doesn't exist.
+	Get(ctx context.Context, name string) ([]byte, error)
+	// Set creates or replaces the item with the given name.
+	Set(ctx context.Context, name string, value []byte) error
+}
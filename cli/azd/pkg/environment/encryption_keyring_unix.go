@@ -0,0 +1,98 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+//go:build !windows
+// +build !windows
+
+package environment
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	osexec "os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+)
+
+// commandKeyring stores items in the OS keyring with the command line tools of the OS: 'security' for the macOS
+// keychain, and 'secret-tool' for the Secret Service (for example GNOME Keyring or KWallet) on Linux.
+type commandKeyring struct {
+	commandRunner exec.CommandRunner
+}
+
+func newKeyring(commandRunner exec.CommandRunner) keyring {
+	return &commandKeyring{
+		commandRunner: commandRunner,
+	}
+}
+
+func (k *commandKeyring) Get(ctx context.Context, name string) ([]byte, error) {
+	var runArgs exec.RunArgs
+	// the exit code of the command when the item doesn't exist
+	var notFoundExitCode int
+
+	if runtime.GOOS == "darwin" {
+		runArgs = exec.NewRunArgs("security", "find-generic-password", "-s", keyringService, "-a", name, "-w")
+		notFoundExitCode = 44
+	} else {
+		runArgs = exec.NewRunArgs("secret-tool", "lookup", "service", keyringService, "account", name)
+		notFoundExitCode = 1
+	}
+
+	res, err := k.commandRunner.Run(ctx, runArgs)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode == notFoundExitCode {
+		return nil, errKeyringItemNotFound
+	} else if err != nil {
+		return nil, k.commandError(runArgs.Cmd, err)
+	}
+
+	value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(res.Stdout))
+	if err != nil {
+		return nil, fmt.Errorf("decoding keyring item: %w", err)
+	}
+
+	return value, nil
+}
+
+func (k *commandKeyring) Set(ctx context.Context, name string, value []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(value)
+
+	var runArgs exec.RunArgs
+	if runtime.GOOS == "darwin" {
+		// '-w' without a value, as the last argument, reads the password from the input (twice, to confirm it), so that
+		// it isn't visible in the arguments of the process
+		runArgs = exec.NewRunArgs(
+			"security", "add-generic-password", "-U", "-s", keyringService, "-a", name, "-w",
+		).WithStdIn(strings.NewReader(encoded + "\n" + encoded + "\n"))
+	} else {
+		runArgs = exec.NewRunArgs(
+			"secret-tool", "store", "--label", fmt.Sprintf("%s %s", keyringService, name),
+			"service", keyringService, "account", name,
+		).WithStdIn(strings.NewReader(encoded))
+	}
+
+	if _, err := k.commandRunner.Run(ctx, runArgs); err != nil {
+		return k.commandError(runArgs.Cmd, err)
+	}
+
+	return nil
+}
+
+func (k *commandKeyring) commandError(cmd string, err error) error {
+	if errors.Is(err, osexec.ErrNotFound) {
+		return fmt.Errorf(
+			"'%s' is required to access the OS keyring. Install it, or use the '%s' key source for '%s': %w",
+			cmd,
+			EncryptionKeySourcePassphrase,
+			EncryptionConfigKey,
+			err,
+		)
+	}
+
+	return err
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+//go:build windows
+// +build windows
+
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"golang.org/x/sys/windows"
+)
+
+// dpapiKeyring stores items in files in the azd configuration directory, encrypted for the current user with
+// CryptProtectData. See https://learn.microsoft.com/windows/win32/api/dpapi/nf-dpapi-cryptprotectdata for more
+// information on these APIs.
+type dpapiKeyring struct {
+}
+
+func newKeyring(commandRunner exec.CommandRunner) keyring {
+	return &dpapiKeyring{}
+}
+
+func (k *dpapiKeyring) Get(ctx context.Context, name string) ([]byte, error) {
+	itemPath, err := k.itemPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := os.ReadFile(itemPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errKeyringItemNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("reading keyring item: %w", err)
+	}
+
+	if len(encrypted) == 0 {
+		return nil, errKeyringItemNotFound
+	}
+
+	encryptedBlob := windows.DataBlob{
+		Size: uint32(len(encrypted)),
+		Data: &encrypted[0],
+	}
+	var plaintext windows.DataBlob
+
+	if err := windows.CryptUnprotectData(&encryptedBlob, nil, nil, uintptr(0), nil, 0, &plaintext); err != nil {
+		return nil, fmt.Errorf("failed to decrypt keyring item: %w", err)
+	}
+
+	value := make([]byte, plaintext.Size)
+	copy(value, unsafe.Slice(plaintext.Data, plaintext.Size))
+
+	if _, err := windows.LocalFree(windows.Handle(unsafe.Pointer(plaintext.Data))); err != nil {
+		return nil, fmt.Errorf("failed to free decrypted data: %w", err)
+	}
+
+	return value, nil
+}
+
+func (k *dpapiKeyring) Set(ctx context.Context, name string, value []byte) error {
+	itemPath, err := k.itemPath(name)
+	if err != nil {
+		return err
+	}
+
+	plaintext := windows.DataBlob{
+		Size: uint32(len(value)),
+		Data: &value[0],
+	}
+	var encryptedBlob windows.DataBlob
+
+	if err := windows.CryptProtectData(&plaintext, nil, nil, uintptr(0), nil, 0, &encryptedBlob); err != nil {
+		return fmt.Errorf("failed to encrypt keyring item: %w", err)
+	}
+
+	encrypted := make([]byte, encryptedBlob.Size)
+	copy(encrypted, unsafe.Slice(encryptedBlob.Data, encryptedBlob.Size))
+
+	if _, err := windows.LocalFree(windows.Handle(unsafe.Pointer(encryptedBlob.Data))); err != nil {
+		return fmt.Errorf("failed to free encrypted data: %w", err)
+	}
+
+	if err := os.WriteFile(itemPath, encrypted, osutil.PermissionFileOwnerOnly); err != nil {
+		return fmt.Errorf("writing keyring item: %w", err)
+	}
+
+	return nil
+}
+
+func (k *dpapiKeyring) itemPath(name string) (string, error) {
+	configDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, name+".bin"), nil
+}
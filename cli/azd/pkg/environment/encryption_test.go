@@ -0,0 +1,222 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValueEncryptor_Passphrase(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+	t.Setenv(EncryptionPassphraseEnvVarName, "correct horse battery staple")
+	ctx := context.Background()
+
+	encryptor := newEncryptorForTest(t, EncryptionKeySourcePassphrase)
+
+	encrypted, err := encryptor.Encrypt(ctx, EncryptionKeySourcePassphrase, "SECRET", "value")
+	require.NoError(t, err)
+	require.True(t, IsEncryptedValue(encrypted))
+	require.NotContains(t, encrypted, "value")
+
+	t.Run("Decrypt", func(t *testing.T) {
+		// A new encryptor derives the key again from the key file
+		decrypted, err := newEncryptorForTest(t, EncryptionKeySourcePassphrase).Decrypt(ctx, "SECRET", encrypted)
+		require.NoError(t, err)
+		require.Equal(t, "value", decrypted)
+	})
+
+	t.Run("DifferentKeyName", func(t *testing.T) {
+		_, err := encryptor.Decrypt(ctx, "OTHER", encrypted)
+		require.Error(t, err)
+	})
+
+	t.Run("IncorrectPassphrase", func(t *testing.T) {
+		t.Setenv(EncryptionPassphraseEnvVarName, "incorrect")
+
+		_, err := newEncryptorForTest(t, EncryptionKeySourcePassphrase).Decrypt(ctx, "SECRET", encrypted)
+		require.ErrorContains(t, err, "is incorrect")
+	})
+
+	t.Run("MissingPassphrase", func(t *testing.T) {
+		t.Setenv(EncryptionPassphraseEnvVarName, "")
+
+		_, err := newEncryptorForTest(t, EncryptionKeySourcePassphrase).Decrypt(ctx, "SECRET", encrypted)
+		require.ErrorContains(t, err, EncryptionPassphraseEnvVarName)
+	})
+}
+
+func Test_ValueEncryptor_Keyring(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+	ctx := context.Background()
+
+	keyring := &memoryKeyring{items: map[string][]byte{}}
+	encryptor := newEncryptorForTest(t, EncryptionKeySourceKeyring)
+	encryptor.keyring = keyring
+
+	_, err := encryptor.Decrypt(ctx, "SECRET", encryptedValuePrefix+"keyring:AAAA")
+	require.ErrorIs(t, err, ErrEncryptionKeyNotFound)
+
+	encrypted, err := encryptor.Encrypt(ctx, EncryptionKeySourceKeyring, "SECRET", "value")
+	require.NoError(t, err)
+	require.Len(t, keyring.items[encryptionKeyName], 32)
+
+	decrypted, err := encryptor.Decrypt(ctx, "SECRET", encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "value", decrypted)
+}
+
+func Test_ValueEncryptor_KeySource(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	keySource, err := newEncryptorForTest(t, "").KeySource()
+	require.NoError(t, err)
+	require.Empty(t, keySource)
+
+	_, err = newEncryptorForTest(t, "invalid").KeySource()
+	require.ErrorContains(t, err, "invalid value 'invalid'")
+}
+
+func Test_LocalFileDataStore_Encryption(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+	t.Setenv(EncryptionPassphraseEnvVarName, "passphrase")
+	ctx := context.Background()
+
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+	encryptor := newEncryptorForTest(t, EncryptionKeySourcePassphrase)
+	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager, encryptor)
+
+	env := New("env1")
+	env.DotenvSet("PLAIN", "plain-value")
+	env.DotenvSetSecret("SECRET", "secret-value")
+	require.NoError(t, dataStore.Save(ctx, env, nil))
+
+	contents, err := os.ReadFile(dataStore.EnvPath(env))
+	require.NoError(t, err)
+	require.Contains(t, string(contents), `PLAIN="plain-value"`)
+	require.Contains(t, string(contents), `SECRET="`+encryptedValuePrefix+"passphrase:")
+	require.NotContains(t, string(contents), "secret-value")
+
+	t.Run("Get", func(t *testing.T) {
+		env, err := dataStore.Get(ctx, "env1")
+		require.NoError(t, err)
+		require.Equal(t, "secret-value", env.Getenv("SECRET"))
+		require.True(t, env.IsSecret("SECRET"))
+		require.False(t, env.IsSecret("PLAIN"))
+	})
+
+	t.Run("KeyUnavailable", func(t *testing.T) {
+		t.Setenv(EncryptionPassphraseEnvVarName, "")
+
+		// The environment still loads, and only reading the secret fails
+		lockedDataStore := NewLocalFileDataStore(
+			azdContext, fileConfigManager, newEncryptorForTest(t, EncryptionKeySourcePassphrase))
+		env, err := lockedDataStore.Get(ctx, "env1")
+		require.NoError(t, err)
+		require.Equal(t, "plain-value", env.Getenv("PLAIN"))
+		require.Empty(t, env.Getenv("SECRET"))
+		require.NotContains(t, env.Dotenv(), "SECRET")
+		require.NoError(t, env.DecryptionError("PLAIN"))
+
+		var decryptionErr *DecryptionError
+		require.ErrorAs(t, env.DecryptionError(), &decryptionErr)
+		require.Equal(t, []string{"SECRET"}, decryptionErr.Keys)
+		require.ErrorContains(t, decryptionErr, EncryptionPassphraseEnvVarName)
+		require.ErrorContains(t, decryptionErr, "azd env encrypt")
+
+		// The secret is saved unchanged
+		env.DotenvSet("PLAIN", "other-value")
+		require.NoError(t, lockedDataStore.Save(ctx, env, nil))
+		t.Setenv(EncryptionPassphraseEnvVarName, "passphrase")
+
+		env, err = dataStore.Get(ctx, "env1")
+		require.NoError(t, err)
+		require.Equal(t, "secret-value", env.Getenv("SECRET"))
+		require.Equal(t, "other-value", env.Getenv("PLAIN"))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		// Encrypted values are still decrypted, and are saved in plain text
+		env, err := dataStore.Get(ctx, "env1")
+		require.NoError(t, err)
+
+		plainDataStore := NewLocalFileDataStore(azdContext, fileConfigManager, newEncryptorForTest(t, ""))
+		require.NoError(t, plainDataStore.Save(ctx, env, nil))
+
+		contents, err := os.ReadFile(dataStore.EnvPath(env))
+		require.NoError(t, err)
+		require.Contains(t, string(contents), `SECRET="secret-value"`)
+	})
+}
+
+func Test_LocalFileDataStore_EncryptPlainSecrets(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+	t.Setenv(EncryptionPassphraseEnvVarName, "passphrase")
+	ctx := context.Background()
+
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+
+	// secrets saved while encryption is disabled are stored in plain text
+	plainDataStore := NewLocalFileDataStore(azdContext, fileConfigManager, newEncryptorForTest(t, ""))
+	env := New("env1")
+	env.DotenvSet("PLAIN", "plain-value")
+	env.DotenvSetSecret("SECRET", "secret-value")
+	require.NoError(t, plainDataStore.Save(ctx, env, nil))
+
+	contents, err := os.ReadFile(plainDataStore.EnvPath(env))
+	require.NoError(t, err)
+	require.Contains(t, string(contents), `SECRET="secret-value"`)
+
+	// and are still known to be secrets, so they are encrypted once encryption is enabled
+	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager, newEncryptorForTest(t, EncryptionKeySourcePassphrase))
+	env, err = dataStore.Get(ctx, "env1")
+	require.NoError(t, err)
+	require.True(t, env.IsSecret("SECRET"))
+	require.False(t, env.IsSecret("PLAIN"))
+	require.NoError(t, dataStore.Save(ctx, env, nil))
+
+	contents, err = os.ReadFile(dataStore.EnvPath(env))
+	require.NoError(t, err)
+	require.Contains(t, string(contents), `SECRET="`+encryptedValuePrefix+"passphrase:")
+	require.Contains(t, string(contents), `PLAIN="plain-value"`)
+}
+
+// newEncryptorForTest creates an encryptor with the given key source configured in a user config stored in the
+// AZD_CONFIG_DIR directory.
+func newEncryptorForTest(t *testing.T, keySource EncryptionKeySource) *ValueEncryptor {
+	userConfigManager := config.NewUserConfigManager(config.NewFileConfigManager(config.NewManager()))
+
+	userConfig, err := userConfigManager.Load()
+	require.NoError(t, err)
+	require.NoError(t, userConfig.Set(EncryptionConfigKey, string(keySource)))
+	require.NoError(t, userConfigManager.Save(userConfig))
+
+	return NewValueEncryptor(userConfigManager, exec.NewCommandRunner(nil))
+}
+
+type memoryKeyring struct {
+	items map[string][]byte
+}
+
+func (k *memoryKeyring) Get(ctx context.Context, name string) ([]byte, error) {
+	value, has := k.items[name]
+	if !has {
+		return nil, errKeyringItemNotFound
+	}
+
+	return value, nil
+}
+
+func (k *memoryKeyring) Set(ctx context.Context, name string, value []byte) error {
+	k.items[name] = value
+	return nil
+}
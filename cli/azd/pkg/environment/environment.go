@@ -8,12 +8,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
 
-	"maps"
-
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/joho/godotenv"
 )
@@ -58,6 +58,15 @@ type Environment struct {
 	// happens in Save
 	deletedKeys map[string]struct{}
 
+	// secretKeys keeps track of the keys from the `.env` whose values are secrets, which are encrypted at rest when
+	// encryption of local environments is enabled.
+	secretKeys map[string]struct{}
+
+	// decryptionErrors keeps track of the secret values that couldn't be decrypted when the environment was loaded, for
+	// example because the encryption key isn't available. Their values are kept encrypted in dotenv, so they are saved
+	// unchanged, and are only reported as an error when they are read.
+	decryptionErrors map[string]error
+
 	// Config is environment specific config
 	Config config.Config
 }
@@ -145,34 +154,84 @@ func CleanName(name string) string {
 
 // Getenv behaves like os.Getenv, except that any keys in the `.env` file associated with this environment are considered
 // first.
+// Secret values that couldn't be decrypted are empty, see [Environment.DecryptionError].
 func (e *Environment) Getenv(key string) string {
 	if v, has := e.dotenv[key]; has {
-		return v
+		return e.decrypted(key, v)
 	}
 
 	return os.Getenv(key)
 }
 
 // LookupEnv behaves like os.LookupEnv, except that any keys in the `.env` file associated with this environment are
-// considered first.
+// considered first. Secret values that couldn't be decrypted are empty, see [Environment.DecryptionError].
 func (e *Environment) LookupEnv(key string) (string, bool) {
 	if v, has := e.dotenv[key]; has {
-		return v, true
+		return e.decrypted(key, v), true
 	}
 
 	return os.LookupEnv(key)
 }
 
+// decrypted returns the value of key, or an empty value when it is a secret value that couldn't be decrypted.
+func (e *Environment) decrypted(key string, value string) string {
+	if !IsEncryptedValue(value) {
+		return value
+	}
+
+	log.Printf("the value of '%s' can't be decrypted: %v", key, e.decryptionErrors[key])
+	return ""
+}
+
+// DecryptionError returns an error naming the secret values among keys, or among all the values when no keys are
+// given, that couldn't be decrypted when the environment was loaded. It returns nil when all of them were decrypted.
+func (e *Environment) DecryptionError(keys ...string) error {
+	if len(keys) == 0 {
+		keys = slices.Collect(maps.Keys(e.dotenv))
+	}
+
+	var undecrypted []string
+	var cause error
+	for _, key := range keys {
+		if !IsEncryptedValue(e.dotenv[key]) {
+			continue
+		}
+
+		undecrypted = append(undecrypted, key)
+		if cause == nil {
+			cause = e.decryptionErrors[key]
+		}
+	}
+
+	if len(undecrypted) == 0 {
+		return nil
+	}
+
+	if cause == nil {
+		cause = ErrEncryptionKeyNotFound
+	}
+
+	slices.Sort(undecrypted)
+	return &DecryptionError{Keys: undecrypted, Err: cause}
+}
+
 // DotenvDelete removes the given key from the .env file in the environment, it is a no-op if the key
 // does not exist. [Save] should be called to ensure this change is persisted.
 func (e *Environment) DotenvDelete(key string) {
 	delete(e.dotenv, key)
+	delete(e.secretKeys, key)
 	e.deletedKeys[key] = struct{}{}
 }
 
-// Dotenv returns a copy of the key value pairs from the .env file in the environment.
+// Dotenv returns a copy of the key value pairs from the .env file in the environment. Secret values that couldn't be
+// decrypted are left out, see [Environment.DecryptionError].
 func (e *Environment) Dotenv() map[string]string {
-	return maps.Clone(e.dotenv)
+	values := maps.Clone(e.dotenv)
+	maps.DeleteFunc(values, func(key string, value string) bool {
+		return IsEncryptedValue(value)
+	})
+
+	return values
 }
 
 // DotenvSet sets the value of [key] to [value] in the .env file associated with the environment. [Save] should be
@@ -182,6 +241,25 @@ func (e *Environment) DotenvSet(key string, value string) {
 	delete(e.deletedKeys, key)
 }
 
+// DotenvSetSecret sets the value of [key] to [value] in the .env file associated with the environment, and marks the
+// value as a secret. Secret values are encrypted at rest when encryption of local environments is enabled. [Save] should
+// be called to ensure this change is persisted.
+func (e *Environment) DotenvSetSecret(key string, value string) {
+	e.DotenvSet(key, value)
+
+	if e.secretKeys == nil {
+		e.secretKeys = make(map[string]struct{})
+	}
+
+	e.secretKeys[key] = struct{}{}
+}
+
+// IsSecret returns true when the value of [key] in the .env file associated with the environment is a secret.
+func (e *Environment) IsSecret(key string) bool {
+	_, has := e.secretKeys[key]
+	return has
+}
+
 // Name gets the name of the environment
 // If empty will fallback to the value of the AZURE_ENV_NAME environment variable
 func (e *Environment) Name() string {
@@ -236,7 +314,7 @@ func (e *Environment) SetServiceProperty(serviceName string, propertyName string
 // can be used to pass into command runner or similar constructs.
 func (e *Environment) Environ() []string {
 	envVars := []string{}
-	for k, v := range e.dotenv {
+	for k, v := range e.Dotenv() {
 		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
 	}
 
@@ -282,10 +360,14 @@ func fixupUnquotedDotenv(values map[string]string, dotenv string) string {
 // Instead of calling `godotenv.Write` directly, we need to save the file ourselves, so we can fixup any numeric values
 // that were incorrectly unquoted.
 func marshallDotEnv(env *Environment) (string, error) {
-	marshalled, err := godotenv.Marshal(env.dotenv)
+	return marshallDotEnvValues(env.dotenv)
+}
+
+func marshallDotEnvValues(values map[string]string) (string, error) {
+	marshalled, err := godotenv.Marshal(values)
 	if err != nil {
 		return "", fmt.Errorf("marshalling .env: %w", err)
 	}
 
-	return fixupUnquotedDotenv(env.dotenv, marshalled), nil
+	return fixupUnquotedDotenv(values, marshalled), nil
 }
@@ -269,7 +269,7 @@ func Test_fixupUnquotedDotenv(t *testing.T) {
 func createEnvManager(mockContext *mocks.MockContext, root string) (Manager, *azdcontext.AzdContext) {
 	azdCtx := azdcontext.NewAzdContextWithDirectory(root)
 	configManager := config.NewFileConfigManager(config.NewManager())
-	localDataStore := NewLocalFileDataStore(azdCtx, configManager)
+	localDataStore := NewLocalFileDataStore(azdCtx, configManager, nil)
 
 	return newManagerForTest(azdCtx, mockContext.Console, localDataStore, nil), azdCtx
 }
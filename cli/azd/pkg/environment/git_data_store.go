@@ -0,0 +1,374 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/internal/tracing"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+)
+
+// DefaultGitStateBranch is the branch environments are stored in when no branch is configured.
+const DefaultGitStateBranch = "azd-env-state"
+
+// gitRemoteName is the name of the remote of the local clone used by the Git data store.
+const gitRemoteName = "origin"
+
+// gitStateAuthorName and gitStateAuthorEmail are the identity changes are committed with when git has no identity
+// configured, as is common on CI agents.
+const (
+	gitStateAuthorName  = "azd"
+	gitStateAuthorEmail = "azd@localhost"
+)
+
+// gitMaxPushAttempts is the number of times a change is applied and pushed when the branch was concurrently updated.
+const gitMaxPushAttempts = 5
+
+// GitDataStoreConfig contains the configuration for storing environments in a branch of a git repository
+type GitDataStoreConfig struct {
+	// The URL of the git repository
+	Repository string `json:"repository"`
+	// The branch environments are stored in, created on first use
+	Branch string `json:"branch"`
+	// The folder of the branch environments are stored in
+	Path string `json:"path"`
+}
+
+// GitDataStore stores environments in a branch of a git repository, as <path>/<environment>/.env and
+// <path>/<environment>/config.json. Changes are committed and pushed to the branch, the push failing when another
+// change was pushed concurrently, in which case the change is applied again on top of it.
+type GitDataStore struct {
+	configManager config.Manager
+	config        *GitDataStoreConfig
+	gitCli        *git.Cli
+}
+
+func NewGitDataStore(configManager config.Manager, config *GitDataStoreConfig, gitCli *git.Cli) RemoteDataStore {
+	return &GitDataStore{
+		configManager: configManager,
+		config:        config,
+		gitCli:        gitCli,
+	}
+}
+
+// EnvPath returns the path to the .env file for the given environment
+func (gs *GitDataStore) EnvPath(env *Environment) string {
+	return path.Join(gs.config.Path, env.name, DotEnvFileName)
+}
+
+// ConfigPath returns the path to the config.json file for the given environment
+func (gs *GitDataStore) ConfigPath(env *Environment) string {
+	return path.Join(gs.config.Path, env.name, ConfigFileName)
+}
+
+func (gs *GitDataStore) List(ctx context.Context) ([]*contracts.EnvListEnvironment, error) {
+	cloneDir, err := gs.sync(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(cloneDir, gs.config.Path))
+	if errors.Is(err, os.ErrNotExist) {
+		return []*contracts.EnvListEnvironment{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("listing environments: %w", err)
+	}
+
+	envs := []*contracts.EnvListEnvironment{}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		env := &contracts.EnvListEnvironment{
+			Name: entry.Name(),
+		}
+
+		envDir := path.Join(gs.config.Path, entry.Name())
+		if _, err := os.Stat(filepath.Join(cloneDir, envDir, DotEnvFileName)); err == nil {
+			env.DotEnvPath = path.Join(envDir, DotEnvFileName)
+		}
+
+		if _, err := os.Stat(filepath.Join(cloneDir, envDir, ConfigFileName)); err == nil {
+			env.ConfigPath = path.Join(envDir, ConfigFileName)
+		}
+
+		if env.DotEnvPath != "" || env.ConfigPath != "" {
+			envs = append(envs, env)
+		}
+	}
+
+	slices.SortFunc(envs, func(a, b *contracts.EnvListEnvironment) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return envs, nil
+}
+
+func (gs *GitDataStore) Get(ctx context.Context, name string) (*Environment, error) {
+	cloneDir, err := gs.sync(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	env := &Environment{
+		name: name,
+	}
+
+	if err := gs.load(cloneDir, env); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+func (gs *GitDataStore) Reload(ctx context.Context, env *Environment) error {
+	cloneDir, err := gs.sync(ctx)
+	if err != nil {
+		return err
+	}
+
+	return gs.load(cloneDir, env)
+}
+
+func (gs *GitDataStore) Save(ctx context.Context, env *Environment, options *SaveOptions) error {
+	dotEnv, cfg, err := encodeEnvironment(gs.configManager, env)
+	if err != nil {
+		return err
+	}
+
+	err = gs.update(ctx, fmt.Sprintf("Update environment %s", env.name), func(cloneDir string) error {
+		if err := os.MkdirAll(filepath.Join(cloneDir, gs.config.Path, env.name), osutil.PermissionDirectory); err != nil {
+			return fmt.Errorf("creating environment directory: %w", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(cloneDir, gs.ConfigPath(env)), cfg, osutil.PermissionFile); err != nil {
+			return fmt.Errorf("writing config: %w", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(cloneDir, gs.EnvPath(env)), dotEnv, osutil.PermissionFile); err != nil {
+			return fmt.Errorf("writing .env: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("saving environment to git repository: %w", err)
+	}
+
+	tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
+	return nil
+}
+
+func (gs *GitDataStore) Delete(ctx context.Context, name string) error {
+	return gs.update(ctx, fmt.Sprintf("Delete environment %s", name), func(cloneDir string) error {
+		envDir := filepath.Join(cloneDir, gs.config.Path, name)
+		if _, err := os.Stat(envDir); errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("'%s': %w", name, ErrNotFound)
+		}
+
+		return os.RemoveAll(envDir)
+	})
+}
+
+// Lock locks the environment by pushing a lock file to the branch. Pushes are atomic, so only one of the operations
+// that concurrently lock the environment succeeds.
+func (gs *GitDataStore) Lock(ctx context.Context, name string) (func() error, error) {
+	lock := newLockInfo()
+	lockPath := path.Join(gs.config.Path, name, LockFileName)
+
+	err := gs.update(ctx, fmt.Sprintf("Lock environment %s", name), func(cloneDir string) error {
+		contents, err := os.ReadFile(filepath.Join(cloneDir, lockPath))
+		if err == nil {
+			if existing := parseLockInfo(contents); !existing.expired() {
+				return lockedError(name, existing)
+			}
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("reading lock file: %w", err)
+		}
+
+		if err := os.MkdirAll(filepath.Join(cloneDir, gs.config.Path, name), osutil.PermissionDirectory); err != nil {
+			return fmt.Errorf("creating environment directory: %w", err)
+		}
+
+		return os.WriteFile(filepath.Join(cloneDir, lockPath), lock.bytes(), osutil.PermissionFile)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	release := func() error {
+		// the lock is released even when the operation holding it was canceled
+		ctx := context.WithoutCancel(ctx)
+		return gs.update(ctx, fmt.Sprintf("Unlock environment %s", name), func(cloneDir string) error {
+			contents, err := os.ReadFile(filepath.Join(cloneDir, lockPath))
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			} else if err != nil {
+				return fmt.Errorf("reading lock file: %w", err)
+			}
+
+			// the lock expired and was acquired by another operation
+			if parseLockInfo(contents).Id != lock.Id {
+				return nil
+			}
+
+			return os.Remove(filepath.Join(cloneDir, lockPath))
+		})
+	}
+
+	return release, nil
+}
+
+// update applies a change to the branch, commits and pushes it. Nothing is committed when the change leaves the branch
+// unchanged. When the branch was updated concurrently, the change is applied again on top of the updated branch.
+func (gs *GitDataStore) update(ctx context.Context, message string, change func(cloneDir string) error) error {
+	for attempt := 1; ; attempt++ {
+		cloneDir, err := gs.sync(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := change(cloneDir); err != nil {
+			return err
+		}
+
+		if err := gs.gitCli.AddFile(ctx, cloneDir, "."); err != nil {
+			return err
+		}
+
+		hasChanges, err := gs.gitCli.HasChanges(ctx, cloneDir)
+		if err != nil {
+			return err
+		}
+
+		if !hasChanges {
+			return nil
+		}
+
+		if gs.gitCli.HasIdentity(ctx, cloneDir) {
+			err = gs.gitCli.Commit(ctx, cloneDir, message)
+		} else {
+			err = gs.gitCli.CommitAs(ctx, cloneDir, message, gitStateAuthorName, gitStateAuthorEmail)
+		}
+		if err != nil {
+			return err
+		}
+
+		err = gs.gitCli.Push(ctx, cloneDir, gitRemoteName, gs.branch())
+		if errors.Is(err, git.ErrPushRejected) && attempt < gitMaxPushAttempts {
+			continue
+		}
+
+		return err
+	}
+}
+
+// sync updates the local clone of the branch to the latest commit of the remote branch, discarding local changes, and
+// returns the path of the clone.
+func (gs *GitDataStore) sync(ctx context.Context) (string, error) {
+	cloneDir, err := gs.cloneDir()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(filepath.Join(cloneDir, ".git")); errors.Is(err, os.ErrNotExist) {
+		if err := gs.init(ctx, cloneDir); err != nil {
+			return "", err
+		}
+	}
+
+	err = gs.gitCli.Fetch(ctx, cloneDir, gitRemoteName, gs.branch())
+	if errors.Is(err, git.ErrRemoteBranchNotFound) {
+		// The branch is created by the first push. Start over from an empty branch, so that local commits that were
+		// never pushed are not resurrected.
+		if err := os.RemoveAll(cloneDir); err != nil {
+			return "", fmt.Errorf("resetting local clone: %w", err)
+		}
+
+		return cloneDir, gs.init(ctx, cloneDir)
+	} else if err != nil {
+		return "", err
+	}
+
+	if err := gs.gitCli.Checkout(ctx, cloneDir, gs.branch(), "FETCH_HEAD"); err != nil {
+		return "", err
+	}
+
+	return cloneDir, nil
+}
+
+// init creates an empty local repository, on the state branch, that tracks the configured repository.
+func (gs *GitDataStore) init(ctx context.Context, cloneDir string) error {
+	if gs.config.Repository == "" {
+		return errors.New("remote state configuration is invalid. The git repository is not configured")
+	}
+
+	if err := os.MkdirAll(cloneDir, osutil.PermissionDirectory); err != nil {
+		return fmt.Errorf("creating local clone directory: %w", err)
+	}
+
+	if err := gs.gitCli.InitRepo(ctx, cloneDir); err != nil {
+		return err
+	}
+
+	if err := gs.gitCli.Checkout(ctx, cloneDir, gs.branch(), ""); err != nil {
+		return err
+	}
+
+	return gs.gitCli.AddRemote(ctx, cloneDir, gitRemoteName, gs.config.Repository)
+}
+
+// load loads the environment from the local clone.
+func (gs *GitDataStore) load(cloneDir string, env *Environment) error {
+	dotEnv, err := os.ReadFile(filepath.Join(cloneDir, gs.EnvPath(env)))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("'%s': %w", env.name, ErrNotFound)
+	} else if err != nil {
+		return fmt.Errorf("reading .env: %w", err)
+	}
+
+	cfg, err := os.ReadFile(filepath.Join(cloneDir, gs.ConfigPath(env)))
+	if errors.Is(err, os.ErrNotExist) {
+		return decodeEnvironment(gs.configManager, env, bytes.NewReader(dotEnv), nil)
+	} else if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+
+	return decodeEnvironment(gs.configManager, env, bytes.NewReader(dotEnv), bytes.NewReader(cfg))
+}
+
+func (gs *GitDataStore) branch() string {
+	if gs.config.Branch == "" {
+		return DefaultGitStateBranch
+	}
+
+	return gs.config.Branch
+}
+
+// cloneDir returns the directory of the local clone of the branch, within the azd configuration directory.
+func (gs *GitDataStore) cloneDir() (string, error) {
+	configDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256([]byte(gs.config.Repository + "#" + gs.branch()))
+	return filepath.Join(configDir, "state", "git", hex.EncodeToString(hash[:8])), nil
+}
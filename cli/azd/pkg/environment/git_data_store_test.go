@@ -0,0 +1,141 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"context"
+	osexec "os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GitDataStore(t *testing.T) {
+	if _, err := osexec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+	// No git identity is configured, as on CI agents
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), ".gitconfig"))
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "user.useConfigOnly")
+	t.Setenv("GIT_CONFIG_VALUE_0", "true")
+
+	// The remote repository, shared by the data stores of two users
+	repository := t.TempDir()
+	out, err := osexec.Command("git", "init", "--bare", repository).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	gitCli := git.NewCli(exec.NewCommandRunner(nil))
+	newDataStore := func(repository string) RemoteDataStore {
+		return NewGitDataStore(config.NewManager(), &GitDataStoreConfig{
+			Repository: repository,
+			Path:       "myproject",
+		}, gitCli)
+	}
+
+	ctx := context.Background()
+	dataStore := newDataStore(repository)
+	// The local clone depends on the repository URL, so a different URL of the same repository simulates another machine.
+	other := newDataStore(repository + "/")
+
+	t.Run("ListEmpty", func(t *testing.T) {
+		envs, err := dataStore.List(ctx)
+		require.NoError(t, err)
+		require.Empty(t, envs)
+	})
+
+	t.Run("GetNotFound", func(t *testing.T) {
+		_, err := dataStore.Get(ctx, "env1")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("SaveAndGet", func(t *testing.T) {
+		env := New("env1")
+		env.DotenvSet("AZURE_LOCATION", "westus2")
+		require.NoError(t, env.Config.Set("infra.parameters.sku", "basic"))
+		require.NoError(t, dataStore.Save(ctx, env, nil))
+
+		out, err := osexec.Command(
+			"git", "-C", repository, "show", DefaultGitStateBranch+":myproject/env1/.env").CombinedOutput()
+		require.NoError(t, err, string(out))
+		require.Contains(t, string(out), `AZURE_LOCATION="westus2"`)
+
+		loaded, err := dataStore.Get(ctx, "env1")
+		require.NoError(t, err)
+		require.Equal(t, "westus2", loaded.Getenv("AZURE_LOCATION"))
+
+		sku, has := loaded.Config.Get("infra.parameters.sku")
+		require.True(t, has)
+		require.Equal(t, "basic", sku)
+
+		out, err = osexec.Command(
+			"git", "-C", repository, "log", "-1", "--format=%an <%ae>", DefaultGitStateBranch).CombinedOutput()
+		require.NoError(t, err, string(out))
+		require.Equal(t, "azd <azd@localhost>", strings.TrimSpace(string(out)))
+	})
+
+	t.Run("SaveUnchanged", func(t *testing.T) {
+		commitCount := func() string {
+			out, err := osexec.Command(
+				"git", "-C", repository, "rev-list", "--count", DefaultGitStateBranch).CombinedOutput()
+			require.NoError(t, err, string(out))
+			return strings.TrimSpace(string(out))
+		}
+
+		env, err := dataStore.Get(ctx, "env1")
+		require.NoError(t, err)
+
+		before := commitCount()
+		require.NoError(t, dataStore.Save(ctx, env, nil))
+		require.Equal(t, before, commitCount())
+	})
+
+	t.Run("SaveFromAnotherClone", func(t *testing.T) {
+		_, err := other.List(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, dataStore.Save(ctx, New("env2"), nil))
+		require.NoError(t, other.Save(ctx, New("env3"), nil))
+
+		envs, err := dataStore.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, envs, 3)
+		require.Equal(t, "env1", envs[0].Name)
+		require.Equal(t, "env2", envs[1].Name)
+		require.Equal(t, "env3", envs[2].Name)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, dataStore.Delete(ctx, "env3"))
+		require.ErrorIs(t, dataStore.Delete(ctx, "env3"), ErrNotFound)
+
+		envs, err := dataStore.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, envs, 2)
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		locker := dataStore.(Locker)
+
+		release, err := locker.Lock(ctx, "env1")
+		require.NoError(t, err)
+
+		_, err = other.(Locker).Lock(ctx, "env1")
+		require.ErrorIs(t, err, ErrLocked)
+
+		require.NoError(t, release())
+
+		release, err = locker.Lock(ctx, "env1")
+		require.NoError(t, err)
+		require.NoError(t, release())
+	})
+}
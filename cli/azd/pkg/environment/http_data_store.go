@@ -0,0 +1,403 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/internal/tracing"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+)
+
+// HttpDataStoreConfig contains the configuration for storing environments on an HTTP server that supports WebDAV
+type HttpDataStoreConfig struct {
+	// The URL of the collection environments are stored in
+	Url string `json:"url"`
+	// Headers sent with every request, such as 'Authorization'. Values can reference environment variables as ${NAME}.
+	Headers map[string]string `json:"headers"`
+}
+
+// HttpDataStore stores environments on an HTTP server, as <url>/<environment>/.env and <url>/<environment>/config.json.
+// Environments are listed with the WebDAV PROPFIND method, and collections are created with MKCOL.
+type HttpDataStore struct {
+	configManager config.Manager
+	config        *HttpDataStoreConfig
+	httpClient    auth.HttpClient
+}
+
+func NewHttpDataStore(
+	configManager config.Manager,
+	config *HttpDataStoreConfig,
+	httpClient auth.HttpClient,
+) RemoteDataStore {
+	return &HttpDataStore{
+		configManager: configManager,
+		config:        config,
+		httpClient:    httpClient,
+	}
+}
+
+// EnvPath returns the path to the .env file for the given environment
+func (hs *HttpDataStore) EnvPath(env *Environment) string {
+	return fmt.Sprintf("%s/%s", env.name, DotEnvFileName)
+}
+
+// ConfigPath returns the path to the config.json file for the given environment
+func (hs *HttpDataStore) ConfigPath(env *Environment) string {
+	return fmt.Sprintf("%s/%s", env.name, ConfigFileName)
+}
+
+func (hs *HttpDataStore) List(ctx context.Context) ([]*contracts.EnvListEnvironment, error) {
+	body := `<?xml version="1.0" encoding="utf-8"?><propfind xmlns="DAV:"><prop><resourcetype/></prop></propfind>`
+	res, err := hs.send(ctx, "PROPFIND", "", strings.NewReader(body), map[string]string{
+		"Depth":        "1",
+		"Content-Type": "application/xml",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return []*contracts.EnvListEnvironment{}, nil
+	}
+
+	if res.StatusCode != http.StatusMultiStatus {
+		return nil, hs.statusError(res)
+	}
+
+	var multiStatus webDavMultiStatus
+	if err := xml.NewDecoder(res.Body).Decode(&multiStatus); err != nil {
+		return nil, fmt.Errorf("parsing environments list: %w", err)
+	}
+
+	baseUrl, err := url.Parse(hs.url(""))
+	if err != nil {
+		return nil, err
+	}
+
+	envs := []*contracts.EnvListEnvironment{}
+	for _, response := range multiStatus.Responses {
+		if !response.isCollection() {
+			continue
+		}
+
+		href, err := baseUrl.Parse(response.Href)
+		if err != nil {
+			continue
+		}
+
+		name := path.Base(strings.TrimSuffix(href.Path, "/"))
+		if strings.TrimSuffix(href.Path, "/") == strings.TrimSuffix(baseUrl.Path, "/") || !IsValidEnvironmentName(name) {
+			continue
+		}
+
+		env := &Environment{name: name}
+		envs = append(envs, &contracts.EnvListEnvironment{
+			Name:       name,
+			DotEnvPath: hs.EnvPath(env),
+			ConfigPath: hs.ConfigPath(env),
+		})
+	}
+
+	slices.SortFunc(envs, func(a, b *contracts.EnvListEnvironment) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return envs, nil
+}
+
+func (hs *HttpDataStore) Get(ctx context.Context, name string) (*Environment, error) {
+	env := &Environment{
+		name: name,
+	}
+
+	if err := hs.Reload(ctx, env); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+func (hs *HttpDataStore) Reload(ctx context.Context, env *Environment) error {
+	dotEnv, err := hs.download(ctx, hs.EnvPath(env))
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("'%s': %w", env.name, ErrNotFound)
+	} else if err != nil {
+		return fmt.Errorf("downloading .env: %w", err)
+	}
+
+	cfg, err := hs.download(ctx, hs.ConfigPath(env))
+	if errors.Is(err, ErrNotFound) {
+		return decodeEnvironment(hs.configManager, env, bytes.NewReader(dotEnv), nil)
+	} else if err != nil {
+		return fmt.Errorf("downloading config: %w", err)
+	}
+
+	return decodeEnvironment(hs.configManager, env, bytes.NewReader(dotEnv), bytes.NewReader(cfg))
+}
+
+func (hs *HttpDataStore) Save(ctx context.Context, env *Environment, options *SaveOptions) error {
+	dotEnv, cfg, err := encodeEnvironment(hs.configManager, env)
+	if err != nil {
+		return err
+	}
+
+	if err := hs.ensureCollection(ctx, env.name); err != nil {
+		return err
+	}
+
+	if err := hs.upload(ctx, hs.ConfigPath(env), cfg, nil); err != nil {
+		return fmt.Errorf("uploading config: %w", err)
+	}
+
+	if err := hs.upload(ctx, hs.EnvPath(env), dotEnv, nil); err != nil {
+		return fmt.Errorf("uploading .env: %w", err)
+	}
+
+	tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
+	return nil
+}
+
+func (hs *HttpDataStore) Delete(ctx context.Context, name string) error {
+	if _, err := hs.download(ctx, hs.EnvPath(&Environment{name: name})); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("'%s': %w", name, ErrNotFound)
+		}
+
+		return err
+	}
+
+	if err := hs.delete(ctx, name+"/"); err != nil {
+		return fmt.Errorf("deleting remote environment: %w", err)
+	}
+
+	return nil
+}
+
+// Lock locks the environment by creating a lock file, with a conditional request that fails when the lock file already
+// exists.
+func (hs *HttpDataStore) Lock(ctx context.Context, name string) (func() error, error) {
+	lock := newLockInfo()
+	lockPath := fmt.Sprintf("%s/%s", name, LockFileName)
+
+	if err := hs.ensureCollection(ctx, name); err != nil {
+		return nil, err
+	}
+
+	// A lock file that expired is deleted, and creating the lock file is attempted again.
+	for attempt := 1; ; attempt++ {
+		err := hs.upload(ctx, lockPath, lock.bytes(), map[string]string{"If-None-Match": "*"})
+		if err == nil {
+			break
+		} else if !errors.Is(err, errPreconditionFailed) {
+			return nil, fmt.Errorf("creating lock file: %w", err)
+		}
+
+		contents, err := hs.download(ctx, lockPath)
+		if errors.Is(err, ErrNotFound) && attempt < httpMaxLockAttempts {
+			// the lock was released in the meantime
+			continue
+		} else if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("reading lock file: %w", err)
+		}
+
+		existing := parseLockInfo(contents)
+		if !existing.expired() || attempt == httpMaxLockAttempts {
+			return nil, lockedError(name, existing)
+		}
+
+		if err := hs.delete(ctx, lockPath); err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("deleting expired lock file: %w", err)
+		}
+	}
+
+	release := func() error {
+		// the lock is released even when the operation holding it was canceled
+		ctx := context.WithoutCancel(ctx)
+		contents, err := hs.download(ctx, lockPath)
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("reading lock file: %w", err)
+		}
+
+		// the lock expired and was acquired by another operation
+		if parseLockInfo(contents).Id != lock.Id {
+			return nil
+		}
+
+		if err := hs.delete(ctx, lockPath); err != nil && !errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("deleting lock file: %w", err)
+		}
+
+		return nil
+	}
+
+	return release, nil
+}
+
+// httpMaxLockAttempts is the number of times creating the lock file is attempted.
+const httpMaxLockAttempts = 3
+
+// errPreconditionFailed is returned when a conditional request is not applied.
+var errPreconditionFailed = errors.New("precondition failed")
+
+func (hs *HttpDataStore) download(ctx context.Context, filePath string) ([]byte, error) {
+	res, err := hs.send(ctx, http.MethodGet, filePath, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, hs.statusError(res)
+	}
+
+	return io.ReadAll(res.Body)
+}
+
+func (hs *HttpDataStore) upload(ctx context.Context, filePath string, contents []byte, headers map[string]string) error {
+	res, err := hs.send(ctx, http.MethodPut, filePath, bytes.NewReader(contents), headers)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusPreconditionFailed {
+		return errPreconditionFailed
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return hs.statusError(res)
+	}
+
+	return nil
+}
+
+func (hs *HttpDataStore) delete(ctx context.Context, filePath string) error {
+	res, err := hs.send(ctx, http.MethodDelete, filePath, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return hs.statusError(res)
+	}
+
+	return nil
+}
+
+// ensureCollection creates the collection of the environment, and the collection environments are stored in, when
+// they don't exist.
+func (hs *HttpDataStore) ensureCollection(ctx context.Context, name string) error {
+	for _, collectionPath := range []string{"", name + "/"} {
+		res, err := hs.send(ctx, "MKCOL", collectionPath, nil, nil)
+		if err != nil {
+			return err
+		}
+		res.Body.Close()
+
+		// 405 Method Not Allowed is returned when the collection already exists
+		if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusMethodNotAllowed {
+			return fmt.Errorf("creating collection: %w", hs.statusError(res))
+		}
+	}
+
+	return nil
+}
+
+func (hs *HttpDataStore) send(
+	ctx context.Context,
+	method string,
+	filePath string,
+	body io.Reader,
+	headers map[string]string,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, hs.url(filePath), body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	for key, value := range hs.config.Headers {
+		req.Header.Set(key, os.ExpandEnv(value))
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := hs.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, req.URL.Redacted(), err)
+	}
+
+	return res, nil
+}
+
+// url returns the URL of a path relative to the configured URL.
+func (hs *HttpDataStore) url(relativePath string) string {
+	return strings.TrimSuffix(hs.config.Url, "/") + "/" + relativePath
+}
+
+func (hs *HttpDataStore) statusError(res *http.Response) error {
+	if res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden {
+		return fmt.Errorf(
+			"access denied connecting to %s (%s). Ensure the configured headers authorize access",
+			res.Request.URL.Redacted(),
+			res.Status,
+		)
+	}
+
+	return fmt.Errorf("%s %s: unexpected status %s", res.Request.Method, res.Request.URL.Redacted(), res.Status)
+}
+
+// webDavMultiStatus is a model type for the response of a WebDAV PROPFIND request.
+type webDavMultiStatus struct {
+	Responses []webDavResponse `xml:"DAV: response"`
+}
+
+type webDavResponse struct {
+	Href      string `xml:"DAV: href"`
+	PropStats []struct {
+		Prop struct {
+			ResourceType struct {
+				Collection *struct{} `xml:"DAV: collection"`
+			} `xml:"DAV: resourcetype"`
+		} `xml:"DAV: prop"`
+	} `xml:"DAV: propstat"`
+}
+
+func (r webDavResponse) isCollection() bool {
+	for _, propStat := range r.PropStats {
+		if propStat.Prop.ResourceType.Collection != nil {
+			return true
+		}
+	}
+
+	return strings.HasSuffix(r.Href, "/")
+}
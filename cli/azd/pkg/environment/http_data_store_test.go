@@ -0,0 +1,205 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HttpDataStore(t *testing.T) {
+	server := newWebDavServer(t)
+	dataStore := NewHttpDataStore(config.NewManager(), &HttpDataStoreConfig{
+		Url: server.URL + "/envs",
+		Headers: map[string]string{
+			"Authorization": "Bearer ${AZD_TEST_STATE_TOKEN}",
+		},
+	}, server.Client())
+	t.Setenv("AZD_TEST_STATE_TOKEN", "token")
+
+	ctx := context.Background()
+
+	t.Run("ListEmpty", func(t *testing.T) {
+		envs, err := dataStore.List(ctx)
+		require.NoError(t, err)
+		require.Empty(t, envs)
+	})
+
+	t.Run("GetNotFound", func(t *testing.T) {
+		_, err := dataStore.Get(ctx, "env1")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("SaveAndGet", func(t *testing.T) {
+		for _, name := range []string{"env2", "env1"} {
+			env := New(name)
+			env.DotenvSet("AZURE_LOCATION", "westus2")
+			require.NoError(t, env.Config.Set("infra.parameters.sku", "basic"))
+			require.NoError(t, dataStore.Save(ctx, env, nil))
+		}
+
+		env, err := dataStore.Get(ctx, "env1")
+		require.NoError(t, err)
+		require.Equal(t, "westus2", env.Getenv("AZURE_LOCATION"))
+
+		sku, has := env.Config.Get("infra.parameters.sku")
+		require.True(t, has)
+		require.Equal(t, "basic", sku)
+
+		envs, err := dataStore.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, envs, 2)
+		require.Equal(t, "env1", envs[0].Name)
+		require.Equal(t, "env2", envs[1].Name)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, dataStore.Delete(ctx, "env2"))
+		require.ErrorIs(t, dataStore.Delete(ctx, "env2"), ErrNotFound)
+
+		envs, err := dataStore.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, envs, 1)
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		locker := dataStore.(Locker)
+
+		release, err := locker.Lock(ctx, "env1")
+		require.NoError(t, err)
+
+		_, err = locker.Lock(ctx, "env1")
+		require.ErrorIs(t, err, ErrLocked)
+
+		require.NoError(t, release())
+
+		release, err = locker.Lock(ctx, "env1")
+		require.NoError(t, err)
+		require.NoError(t, release())
+	})
+
+	t.Run("LockExpired", func(t *testing.T) {
+		expired := newLockInfo()
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		server.files["/envs/env1/.lock"] = expired.bytes()
+
+		release, err := dataStore.(Locker).Lock(ctx, "env1")
+		require.NoError(t, err)
+		require.NoError(t, release())
+		require.NotContains(t, server.files, "/envs/env1/.lock")
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		t.Setenv("AZD_TEST_STATE_TOKEN", "invalid")
+
+		_, err := dataStore.Get(ctx, "env1")
+		require.ErrorContains(t, err, "access denied")
+	})
+}
+
+// webDavServer is an in-memory server implementing the subset of WebDAV used by the HTTP data store.
+type webDavServer struct {
+	*httptest.Server
+	mu    sync.Mutex
+	files map[string][]byte
+	dirs  map[string]bool
+}
+
+func newWebDavServer(t *testing.T) *webDavServer {
+	s := &webDavServer{
+		files: map[string][]byte{},
+		dirs:  map[string]bool{"/": true},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *webDavServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	p := r.URL.Path
+	switch r.Method {
+	case "MKCOL":
+		if s.dirs[strings.TrimSuffix(p, "/")] {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		s.dirs[strings.TrimSuffix(p, "/")] = true
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodPut:
+		if _, has := s.files[p]; has && r.Header.Get("If-None-Match") == "*" {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		contents, _ := io.ReadAll(r.Body)
+		s.files[p] = contents
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet:
+		contents, has := s.files[p]
+		if !has {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(contents)
+	case http.MethodDelete:
+		dir := strings.TrimSuffix(p, "/")
+		if _, has := s.files[p]; !has && !s.dirs[dir] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.files, p)
+		delete(s.dirs, dir)
+		for name := range s.files {
+			if strings.HasPrefix(name, dir+"/") {
+				delete(s.files, name)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "PROPFIND":
+		dir := strings.TrimSuffix(p, "/")
+		if !s.dirs[dir] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		hrefs := []string{dir + "/"}
+		for name := range s.dirs {
+			if strings.HasPrefix(name, dir+"/") && !strings.Contains(strings.TrimPrefix(name, dir+"/"), "/") {
+				hrefs = append(hrefs, name+"/")
+			}
+		}
+		sort.Strings(hrefs)
+
+		w.WriteHeader(http.StatusMultiStatus)
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><D:multistatus xmlns:D="DAV:">`)
+		for _, href := range hrefs {
+			fmt.Fprintf(w,
+				`<D:response><D:href>%s</D:href><D:propstat><D:prop><D:resourcetype><D:collection/></D:resourcetype>`+
+					`</D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`,
+				href,
+			)
+		}
+		fmt.Fprint(w, `</D:multistatus>`)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -5,8 +5,11 @@ package environment
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
@@ -17,21 +20,29 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 )
 
 // LocalFileDataStore is a DataStore implementation that stores environment data in the local file system.
+// When encryption is enabled with the `env.encryption` user config, secret values are encrypted in the `.env` file.
 type LocalFileDataStore struct {
 	azdContext    *azdcontext.AzdContext
 	configManager config.FileConfigManager
+	encryptor     *ValueEncryptor
 }
 
-// NewLocalFileDataStore creates a new LocalFileDataStore instance
-func NewLocalFileDataStore(azdContext *azdcontext.AzdContext, configManager config.FileConfigManager) LocalDataStore {
+// NewLocalFileDataStore creates a new LocalFileDataStore instance. A nil encryptor disables encryption of secret values.
+func NewLocalFileDataStore(
+	azdContext *azdcontext.AzdContext,
+	configManager config.FileConfigManager,
+	encryptor *ValueEncryptor,
+) LocalDataStore {
 	return &LocalFileDataStore{
 		azdContext:    azdContext,
 		configManager: configManager,
+		encryptor:     encryptor,
 	}
 }
 
@@ -45,6 +56,11 @@ func (fs *LocalFileDataStore) ConfigPath(env *Environment) string {
 	return filepath.Join(fs.azdContext.EnvironmentRoot(env.name), ConfigFileName)
 }
 
+// SecretKeysPath returns the path to the file listing the keys of the secret values of the given environment
+func (fs *LocalFileDataStore) SecretKeysPath(env *Environment) string {
+	return filepath.Join(fs.azdContext.EnvironmentRoot(env.name), SecretKeysFileName)
+}
+
 // List returns a list of all environments within the data store
 func (fs *LocalFileDataStore) List(ctx context.Context) ([]*contracts.EnvListEnvironment, error) {
 	defaultEnv, err := fs.azdContext.GetDefaultEnvironmentName()
@@ -113,6 +129,41 @@ func (fs *LocalFileDataStore) Reload(ctx context.Context, env *Environment) erro
 		env.deletedKeys = make(map[string]struct{})
 	}
 
+	// Decrypt secret values. Values that can't be decrypted stay encrypted, and only fail the commands that read them.
+	env.secretKeys = make(map[string]struct{})
+	env.decryptionErrors = make(map[string]error)
+	for key, value := range env.dotenv {
+		if !IsEncryptedValue(value) {
+			continue
+		}
+
+		env.secretKeys[key] = struct{}{}
+		if fs.encryptor == nil {
+			env.decryptionErrors[key] = errors.New("encryption is not supported")
+			continue
+		}
+
+		decrypted, err := fs.encryptor.Decrypt(ctx, key, value)
+		if err != nil {
+			log.Printf("loading .env: %v", err)
+			env.decryptionErrors[key] = err
+			continue
+		}
+
+		env.dotenv[key] = decrypted
+	}
+
+	// Secret values stored in plain text, while encryption was disabled
+	secretKeys, err := fs.loadSecretKeys(env)
+	if err != nil {
+		return err
+	}
+	for _, key := range secretKeys {
+		if _, has := env.dotenv[key]; has {
+			env.secretKeys[key] = struct{}{}
+		}
+	}
+
 	// Reload env config
 	if cfg, err := fs.configManager.Load(fs.ConfigPath(env)); errors.Is(err, os.ErrNotExist) {
 		env.Config = config.NewEmptyConfig()
@@ -145,6 +196,7 @@ func (fs *LocalFileDataStore) Save(ctx context.Context, env *Environment, option
 	// Cache current values & reload to get any new env vars
 	currentValues := env.dotenv
 	deletedValues := env.deletedKeys
+	secretKeys := env.secretKeys
 	if err := fs.Reload(ctx, env); err != nil {
 		return fmt.Errorf("failed reloading env vars, %w", err)
 	}
@@ -159,7 +211,16 @@ func (fs *LocalFileDataStore) Save(ctx context.Context, env *Environment, option
 		delete(env.dotenv, key)
 	}
 
-	marshalled, err := marshallDotEnv(env)
+	for key := range secretKeys {
+		env.secretKeys[key] = struct{}{}
+	}
+
+	values, err := fs.encryptSecrets(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	marshalled, err := marshallDotEnvValues(values)
 	if err != nil {
 		return fmt.Errorf("marshalling .env: %w", err)
 	}
@@ -179,10 +240,97 @@ func (fs *LocalFileDataStore) Save(ctx context.Context, env *Environment, option
 		return fmt.Errorf("saving .env: %w", err)
 	}
 
+	if err := fs.saveSecretKeys(env); err != nil {
+		return err
+	}
+
 	tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
 	return nil
 }
 
+// encryptSecrets returns the values of the environment to persist, with the secret values encrypted when encryption is
+// enabled.
+func (fs *LocalFileDataStore) encryptSecrets(ctx context.Context, env *Environment) (map[string]string, error) {
+	if fs.encryptor == nil || len(env.secretKeys) == 0 {
+		return env.dotenv, nil
+	}
+
+	keySource, err := fs.encryptor.KeySource()
+	if err != nil {
+		return nil, err
+	}
+
+	if keySource == "" {
+		return env.dotenv, nil
+	}
+
+	values := maps.Clone(env.dotenv)
+	for key := range env.secretKeys {
+		// values that couldn't be decrypted are saved unchanged
+		value, has := values[key]
+		if !has || IsEncryptedValue(value) {
+			continue
+		}
+
+		encrypted, err := fs.encryptor.Encrypt(ctx, keySource, key, value)
+		if err != nil {
+			return nil, fmt.Errorf("encrypting '%s': %w", key, err)
+		}
+
+		values[key] = encrypted
+	}
+
+	return values, nil
+}
+
+// loadSecretKeys returns the keys of the secret values of the environment, as saved by [saveSecretKeys].
+func (fs *LocalFileDataStore) loadSecretKeys(env *Environment) ([]string, error) {
+	contents, err := os.ReadFile(fs.SecretKeysPath(env))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("loading secret keys: %w", err)
+	}
+
+	var secretKeys []string
+	if err := json.Unmarshal(contents, &secretKeys); err != nil {
+		return nil, fmt.Errorf("loading secret keys: %w", err)
+	}
+
+	return secretKeys, nil
+}
+
+// saveSecretKeys saves the keys of the secret values of the environment, whether or not the values are encrypted, so that
+// values saved in plain text are still known to be secrets once encryption is enabled.
+func (fs *LocalFileDataStore) saveSecretKeys(env *Environment) error {
+	var secretKeys []string
+	for key := range env.secretKeys {
+		if _, has := env.dotenv[key]; has {
+			secretKeys = append(secretKeys, key)
+		}
+	}
+
+	if len(secretKeys) == 0 {
+		if err := os.Remove(fs.SecretKeysPath(env)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("saving secret keys: %w", err)
+		}
+
+		return nil
+	}
+
+	slices.Sort(secretKeys)
+	contents, err := json.Marshal(secretKeys)
+	if err != nil {
+		return fmt.Errorf("saving secret keys: %w", err)
+	}
+
+	if err := os.WriteFile(fs.SecretKeysPath(env), contents, osutil.PermissionFile); err != nil {
+		return fmt.Errorf("saving secret keys: %w", err)
+	}
+
+	return nil
+}
+
 func (fs *LocalFileDataStore) Delete(ctx context.Context, name string) error {
 	envRoot := fs.azdContext.EnvironmentRoot(name)
 	_, err := os.Stat(envRoot)
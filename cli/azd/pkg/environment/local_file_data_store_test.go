@@ -18,7 +18,7 @@ func Test_LocalFileDataStore_List(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
 	fileConfigManager := config.NewFileConfigManager(config.NewManager())
-	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager)
+	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager, nil)
 
 	t.Run("List", func(t *testing.T) {
 		env1 := New("env1")
@@ -46,7 +46,7 @@ func Test_LocalFileDataStore_SaveAndGet(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
 	fileConfigManager := config.NewFileConfigManager(config.NewManager())
-	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager)
+	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager, nil)
 
 	t.Run("Success", func(t *testing.T) {
 		env1 := New("env1")
@@ -66,7 +66,7 @@ func Test_LocalFileDataStore_SaveAndGet(t *testing.T) {
 func Test_LocalFileDataStore_Path(t *testing.T) {
 	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
 	fileConfigManager := config.NewFileConfigManager(config.NewManager())
-	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager)
+	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager, nil)
 
 	env := New("env1")
 	expected := filepath.Join(azdContext.EnvironmentRoot("env1"), DotEnvFileName)
@@ -78,7 +78,7 @@ func Test_LocalFileDataStore_Path(t *testing.T) {
 func Test_LocalFileDataStore_ConfigPath(t *testing.T) {
 	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
 	fileConfigManager := config.NewFileConfigManager(config.NewManager())
-	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager)
+	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager, nil)
 
 	env := New("env1")
 	expected := filepath.Join(azdContext.EnvironmentRoot("env1"), ConfigFileName)
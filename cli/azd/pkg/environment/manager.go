@@ -44,6 +44,10 @@ type Spec struct {
 const DotEnvFileName = ".env"
 const ConfigFileName = "config.json"
 
+// SecretKeysFileName is the name of the file that lists the keys of the .env file whose values are secrets, so that they
+// are known even when the values are stored in plain text.
+const SecretKeysFileName = "secret-keys.json"
+
 var (
 	// Error returned when an environment with the specified name already exists
 	ErrExists = errors.New("environment already exists")
@@ -78,9 +82,22 @@ type Manager interface {
 
 	// Delete deletes the environment from local storage.
 	Delete(ctx context.Context, name string) error
+	// DeleteWithOptions deletes the environment from local storage, and from remote storage when requested.
+	DeleteWithOptions(ctx context.Context, name string, options *DeleteOptions) error
 
 	EnvPath(env *Environment) string
 	ConfigPath(env *Environment) string
+
+	// Lock locks the environment in the remote data store, when the remote data store supports locking, so that
+	// concurrent operations cannot overwrite each other's changes. Once locked, the environment is reloaded with the
+	// latest values from the remote data store. The returned function releases the lock.
+	Lock(ctx context.Context, env *Environment) (func() error, error)
+}
+
+// DeleteOptions provides options for deleting an environment
+type DeleteOptions struct {
+	// When set, the environment is also deleted from the remote data store, when one is configured
+	Remote bool
 }
 
 type manager struct {
@@ -416,16 +433,77 @@ func (m *manager) Reload(ctx context.Context, env *Environment) error {
 	return m.local.Reload(ctx, env)
 }
 
+// Lock locks the environment in the remote data store, when the remote data store supports locking
+func (m *manager) Lock(ctx context.Context, env *Environment) (func() error, error) {
+	locker, ok := m.remote.(Locker)
+	if !ok {
+		return func() error { return nil }, nil
+	}
+
+	release, err := locker.Lock(ctx, env.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	// Another operation may have updated the environment before the lock was acquired
+	remoteEnv, err := m.remote.Get(ctx, env.Name())
+	if errors.Is(err, ErrNotFound) {
+		return release, nil
+	} else if err != nil {
+		_ = release()
+		return nil, fmt.Errorf("getting remote environment: %w", err)
+	}
+
+	env.dotenv = remoteEnv.dotenv
+	env.deletedKeys = make(map[string]struct{})
+	env.Config = remoteEnv.Config
+
+	// Keep the secret markers, so the secrets are still encrypted by the local save
+	for key := range remoteEnv.secretKeys {
+		if env.secretKeys == nil {
+			env.secretKeys = make(map[string]struct{})
+		}
+
+		env.secretKeys[key] = struct{}{}
+	}
+
+	if err := m.local.Save(ctx, env, nil); err != nil {
+		_ = release()
+		return nil, fmt.Errorf("saving local environment: %w", err)
+	}
+
+	return release, nil
+}
+
 func (m *manager) Delete(ctx context.Context, name string) error {
+	return m.DeleteWithOptions(ctx, name, nil)
+}
+
+func (m *manager) DeleteWithOptions(ctx context.Context, name string, options *DeleteOptions) error {
 	if name == "" {
 		return ErrNameNotSpecified
 	}
 
+	if options == nil {
+		options = &DeleteOptions{}
+	}
+
+	deleteRemote := options.Remote && m.remote != nil
+
 	err := m.local.Delete(ctx, name)
-	if err != nil {
+	localNotFound := errors.Is(err, ErrNotFound)
+	if err != nil && !(localNotFound && deleteRemote) {
 		return err
 	}
 
+	if deleteRemote {
+		// The environment may only exist in one of the data stores
+		err := m.remote.Delete(ctx, name)
+		if err != nil && !(errors.Is(err, ErrNotFound) && !localNotFound) {
+			return fmt.Errorf("deleting remote environment: %w", err)
+		}
+	}
+
 	defaultEnvName, err := m.azdContext.GetDefaultEnvironmentName()
 	if err != nil {
 		return fmt.Errorf("getting default environment: %w", err)
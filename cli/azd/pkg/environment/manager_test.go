@@ -117,7 +117,7 @@ func Test_EnvManager_PromptEnvironmentName(t *testing.T) {
 
 func createEnvManagerForManagerTest(t *testing.T, mockContext *mocks.MockContext) Manager {
 	azdCtx := azdcontext.NewAzdContextWithDirectory(t.TempDir())
-	localDataStore := NewLocalFileDataStore(azdCtx, config.NewFileConfigManager(config.NewManager()))
+	localDataStore := NewLocalFileDataStore(azdCtx, config.NewFileConfigManager(config.NewManager()), nil)
 
 	return newManagerForTest(azdCtx, mockContext.Console, localDataStore, nil)
 }
@@ -316,6 +316,91 @@ func Test_EnvManager_Save(t *testing.T) {
 	})
 }
 
+func Test_EnvManager_Lock(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+
+	localDataStore := &MockDataStore{}
+	remoteDataStore := &mockLockingDataStore{}
+
+	env := New("env1")
+	env.DotenvSetSecret("LOCAL_SECRET", "local")
+
+	remoteEnv := New("env1")
+	remoteEnv.DotenvSet("LOCAL_SECRET", "local")
+	remoteEnv.DotenvSetSecret("REMOTE_SECRET", "remote")
+
+	remoteDataStore.On("Lock", *mockContext.Context, "env1").Return(func() error { return nil }, nil)
+	remoteDataStore.On("Get", *mockContext.Context, "env1").Return(remoteEnv, nil)
+	localDataStore.On("Save", *mockContext.Context, env, mock.Anything).Return(nil)
+
+	manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+	release, err := manager.Lock(*mockContext.Context, env)
+	require.NoError(t, err)
+	require.NoError(t, release())
+
+	require.Equal(t, "remote", env.Getenv("REMOTE_SECRET"))
+	require.True(t, env.IsSecret("LOCAL_SECRET"))
+	require.True(t, env.IsSecret("REMOTE_SECRET"))
+	localDataStore.AssertCalled(t, "Save", *mockContext.Context, env, mock.Anything)
+}
+
+func Test_EnvManager_DeleteWithOptions(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+
+	t.Run("LocalOnly", func(t *testing.T) {
+		localDataStore := &MockDataStore{}
+		remoteDataStore := &MockDataStore{}
+
+		localDataStore.On("Delete", *mockContext.Context, "env1").Return(nil)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+		err := manager.DeleteWithOptions(*mockContext.Context, "env1", nil)
+		require.NoError(t, err)
+
+		remoteDataStore.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Remote", func(t *testing.T) {
+		localDataStore := &MockDataStore{}
+		remoteDataStore := &MockDataStore{}
+
+		localDataStore.On("Delete", *mockContext.Context, "env1").Return(nil)
+		remoteDataStore.On("Delete", *mockContext.Context, "env1").Return(nil)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+		err := manager.DeleteWithOptions(*mockContext.Context, "env1", &DeleteOptions{Remote: true})
+		require.NoError(t, err)
+
+		remoteDataStore.AssertCalled(t, "Delete", *mockContext.Context, "env1")
+	})
+
+	t.Run("RemoteOnly", func(t *testing.T) {
+		localDataStore := &MockDataStore{}
+		remoteDataStore := &MockDataStore{}
+
+		localDataStore.On("Delete", *mockContext.Context, "env1").Return(ErrNotFound)
+		remoteDataStore.On("Delete", *mockContext.Context, "env1").Return(nil)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+		err := manager.DeleteWithOptions(*mockContext.Context, "env1", &DeleteOptions{Remote: true})
+		require.NoError(t, err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		localDataStore := &MockDataStore{}
+		remoteDataStore := &MockDataStore{}
+
+		localDataStore.On("Delete", *mockContext.Context, "env1").Return(ErrNotFound)
+		remoteDataStore.On("Delete", *mockContext.Context, "env1").Return(ErrNotFound)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+		err := manager.DeleteWithOptions(*mockContext.Context, "env1", &DeleteOptions{Remote: true})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
 func Test_EnvManager_CreateFromContainer(t *testing.T) {
 	t.Run("WithRemoteConfig", func(t *testing.T) {
 		mockContext := mocks.NewMockContext(context.Background())
@@ -366,6 +451,9 @@ func registerContainerComponents(t *testing.T, mockContext *mocks.MockContext) {
 
 	mockContext.Container.MustRegisterSingleton(NewManager)
 	mockContext.Container.MustRegisterSingleton(NewLocalFileDataStore)
+	mockContext.Container.MustRegisterSingleton(func() *ValueEncryptor {
+		return nil
+	})
 	mockContext.Container.MustRegisterNamedSingleton(string(RemoteKindAzureBlobStorage), NewStorageBlobDataStore)
 
 	mockContext.Container.MustRegisterSingleton(func() *azcore.ClientOptions {
@@ -440,3 +528,12 @@ func (m *MockDataStore) Delete(ctx context.Context, name string) error {
 	args := m.Called(ctx, name)
 	return args.Error(0)
 }
+
+type mockLockingDataStore struct {
+	MockDataStore
+}
+
+func (m *mockLockingDataStore) Lock(ctx context.Context, name string) (func() error, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(func() error), args.Error(1)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/azure/azure-dev/cli/azd/internal/tracing"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+)
+
+// encodeEnvironment returns the contents of the .env file and of the config file of the environment, as stored by the
+// file based remote data stores.
+func encodeEnvironment(configManager config.Manager, env *Environment) ([]byte, []byte, error) {
+	marshalled, err := marshallDotEnv(env)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshalling .env: %w", err)
+	}
+
+	cfgWriter := new(bytes.Buffer)
+	if err := configManager.Save(env.Config, cfgWriter); err != nil {
+		return nil, nil, fmt.Errorf("saving config: %w", err)
+	}
+
+	return []byte(marshalled), cfgWriter.Bytes(), nil
+}
+
+// decodeEnvironment loads the environment from the contents of its .env file and of its config file. A nil config
+// reader loads an empty config.
+func decodeEnvironment(configManager config.Manager, env *Environment, dotEnv io.Reader, cfg io.Reader) error {
+	envMap, err := godotenv.Parse(dotEnv)
+	if err != nil {
+		envMap = make(map[string]string)
+	}
+
+	env.dotenv = envMap
+	env.deletedKeys = make(map[string]struct{})
+
+	if cfg == nil {
+		env.Config = config.NewEmptyConfig()
+	} else if loaded, err := configManager.Load(cfg); errors.Is(err, os.ErrNotExist) {
+		env.Config = config.NewEmptyConfig()
+	} else if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	} else {
+		env.Config = loaded
+	}
+
+	if env.Name() != "" {
+		tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
+	}
+
+	if _, err := uuid.Parse(env.GetSubscriptionId()); err == nil {
+		tracing.SetGlobalAttributes(fields.SubscriptionIdKey.String(env.GetSubscriptionId()))
+	} else {
+		tracing.SetGlobalAttributes(fields.StringHashed(fields.SubscriptionIdKey, env.GetSubscriptionId()))
+	}
+
+	return nil
+}
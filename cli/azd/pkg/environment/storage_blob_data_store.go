@@ -8,10 +8,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing"
@@ -65,6 +67,10 @@ func (sbd *StorageBlobDataStore) List(ctx context.Context) ([]*contracts.EnvList
 	envMap := map[string]*contracts.EnvListEnvironment{}
 
 	for _, blob := range blobs {
+		if blob.Name != ConfigFileName && blob.Name != DotEnvFileName {
+			continue
+		}
+
 		envName := filepath.Base(filepath.Dir(blob.Path))
 		env, has := envMap[envName]
 		if !has {
@@ -226,6 +232,46 @@ func (sbd *StorageBlobDataStore) Delete(ctx context.Context, name string) error
 	return nil
 }
 
+// blobLeaseDuration is the duration of the lease that locks an environment. The lease is renewed while the lock is held,
+// so that the environment is unlocked shortly after the process holding the lock exits.
+const blobLeaseDuration = 60 * time.Second
+
+// Lock locks the environment by acquiring a lease on its lock blob.
+func (sbd *StorageBlobDataStore) Lock(ctx context.Context, name string) (func() error, error) {
+	lockPath := fmt.Sprintf("%s/%s", name, LockFileName)
+	leaseId, err := sbd.blobClient.AcquireLease(ctx, lockPath, blobLeaseDuration)
+	if errors.Is(err, storage.ErrLeaseAlreadyHeld) {
+		return nil, fmt.Errorf("%w: '%s' is locked by another operation", ErrLocked, name)
+	} else if err != nil {
+		return nil, fmt.Errorf("locking environment: %w", describeError(err))
+	}
+
+	// the lease is released even when the operation holding it was canceled
+	leaseCtx, stopRenewing := context.WithCancel(context.WithoutCancel(ctx))
+	go func() {
+		ticker := time.NewTicker(blobLeaseDuration / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-leaseCtx.Done():
+				return
+			case <-ticker.C:
+				if err := sbd.blobClient.RenewLease(leaseCtx, lockPath, leaseId); err != nil {
+					log.Printf("failed renewing lease on '%s': %v", lockPath, err)
+				}
+			}
+		}
+	}()
+
+	release := func() error {
+		stopRenewing()
+		return sbd.blobClient.ReleaseLease(context.WithoutCancel(ctx), lockPath, leaseId)
+	}
+
+	return release, nil
+}
+
 func describeError(err error) error {
 	var responseErr *azcore.ResponseError
 
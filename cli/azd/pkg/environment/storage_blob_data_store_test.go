@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
@@ -117,6 +118,34 @@ func Test_StorageBlobDataStore_ConfigPath(t *testing.T) {
 	require.Equal(t, expected, actual)
 }
 
+func Test_StorageBlobDataStore_Lock(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	configManager := config.NewManager()
+
+	t.Run("Success", func(t *testing.T) {
+		blobClient := &MockBlobClient{}
+		blobClient.On("AcquireLease", *mockContext.Context, "env1/.lock", blobLeaseDuration).Return("lease-id", nil)
+		blobClient.On("ReleaseLease", mock.Anything, "env1/.lock", "lease-id").Return(nil)
+		dataStore := NewStorageBlobDataStore(configManager, blobClient).(*StorageBlobDataStore)
+
+		release, err := dataStore.Lock(*mockContext.Context, "env1")
+		require.NoError(t, err)
+		require.NoError(t, release())
+		blobClient.AssertCalled(t, "ReleaseLease", mock.Anything, "env1/.lock", "lease-id")
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		blobClient := &MockBlobClient{}
+		blobClient.On("AcquireLease", *mockContext.Context, "env1/.lock", blobLeaseDuration).
+			Return("", storage.ErrLeaseAlreadyHeld)
+		dataStore := NewStorageBlobDataStore(configManager, blobClient).(*StorageBlobDataStore)
+
+		release, err := dataStore.Lock(*mockContext.Context, "env1")
+		require.ErrorIs(t, err, ErrLocked)
+		require.Nil(t, release)
+	})
+}
+
 type MockBlobClient struct {
 	mock.Mock
 }
@@ -146,3 +175,18 @@ func (m *MockBlobClient) Items(ctx context.Context) ([]*storage.Blob, error) {
 
 	return value, args.Error(1)
 }
+
+func (m *MockBlobClient) AcquireLease(ctx context.Context, blobPath string, duration time.Duration) (string, error) {
+	args := m.Called(ctx, blobPath, duration)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockBlobClient) RenewLease(ctx context.Context, blobPath string, leaseId string) error {
+	args := m.Called(ctx, blobPath, leaseId)
+	return args.Error(0)
+}
+
+func (m *MockBlobClient) ReleaseLease(ctx context.Context, blobPath string, leaseId string) error {
+	args := m.Called(ctx, blobPath, leaseId)
+	return args.Error(0)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/kballard/go-shellquote"
+)
+
+// newExecScript creates a script runner that runs executables directly, without a shell
+func newExecScript(commandRunner exec.CommandRunner, cwd string, envVars []string) tools.Script {
+	return &execScript{
+		commandRunner: commandRunner,
+		cwd:           cwd,
+		envVars:       envVars,
+	}
+}
+
+type execScript struct {
+	commandRunner exec.CommandRunner
+	cwd           string
+	envVars       []string
+}
+
+// Executes the executable at the specified path, relative to the working directory. When there is no such file, the
+// path is the command line of an executable in the PATH, for example 'terraform fmt -check'.
+// When interactive is true will attach to stdin, stdout & stderr
+func (es *execScript) Execute(ctx context.Context, path string, options tools.ExecOptions) (exec.RunResult, error) {
+	executable := path
+	if !filepath.IsAbs(executable) {
+		executable = filepath.Join(es.cwd, path)
+	}
+
+	var runArgs exec.RunArgs
+	if info, err := os.Stat(executable); err == nil && !info.IsDir() {
+		runArgs = exec.NewRunArgs(executable)
+	} else {
+		words, err := shellquote.Split(path)
+		if err != nil {
+			return exec.RunResult{}, fmt.Errorf("parsing command line '%s': %w", path, err)
+		}
+
+		if len(words) == 0 {
+			return exec.RunResult{}, errors.New("the command line to run is empty")
+		}
+
+		runArgs = exec.NewRunArgs(words[0], words[1:]...)
+	}
+
+	runArgs = runArgs.
+		WithCwd(es.cwd).
+		WithEnv(es.envVars)
+
+	if options.Interactive != nil {
+		runArgs = runArgs.WithInteractive(*options.Interactive)
+	}
+
+	if options.StdOut != nil {
+		runArgs = runArgs.WithStdOut(options.StdOut)
+	}
+
+	return es.commandRunner.Run(ctx, runArgs)
+}
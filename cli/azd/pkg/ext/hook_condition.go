@@ -0,0 +1,305 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ext
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// LookupEnvFn looks up the value of an environment variable, like os.LookupEnv
+type LookupEnvFn func(key string) (string, bool)
+
+// hookCondition is a parsed `if` condition of a hook.
+//
+// Conditions compare environment values with literals and can be combined with `&&`, `||`, `!` and parentheses:
+//
+//	AZURE_ENV_TYPE == prod
+//	AZURE_ENV_TYPE != 'dev' && !SKIP_SEED
+//
+// The left operand of a comparison is the name of an environment variable and the right operand is a literal, which can
+// be quoted with single or double quotes. An environment variable on its own is true when it is set to a value other
+// than an empty string, `false` or `0`.
+type hookCondition interface {
+	eval(lookupEnv LookupEnvFn) bool
+}
+
+type notCondition struct {
+	operand hookCondition
+}
+
+func (c *notCondition) eval(lookupEnv LookupEnvFn) bool {
+	return !c.operand.eval(lookupEnv)
+}
+
+type andCondition struct {
+	left  hookCondition
+	right hookCondition
+}
+
+func (c *andCondition) eval(lookupEnv LookupEnvFn) bool {
+	return c.left.eval(lookupEnv) && c.right.eval(lookupEnv)
+}
+
+type orCondition struct {
+	left  hookCondition
+	right hookCondition
+}
+
+func (c *orCondition) eval(lookupEnv LookupEnvFn) bool {
+	return c.left.eval(lookupEnv) || c.right.eval(lookupEnv)
+}
+
+type compareCondition struct {
+	name   string
+	value  string
+	negate bool
+}
+
+func (c *compareCondition) eval(lookupEnv LookupEnvFn) bool {
+	value, _ := lookupEnv(c.name)
+	return (value == c.value) != c.negate
+}
+
+type truthyCondition struct {
+	name string
+}
+
+func (c *truthyCondition) eval(lookupEnv LookupEnvFn) bool {
+	value, has := lookupEnv(c.name)
+	if !has {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "false", "0":
+		return false
+	default:
+		return true
+	}
+}
+
+type conditionTokenKind int
+
+const (
+	conditionTokenWord conditionTokenKind = iota
+	conditionTokenString
+	conditionTokenOperator
+)
+
+type conditionToken struct {
+	kind  conditionTokenKind
+	value string
+}
+
+// parseHookCondition parses the `if` condition of a hook
+func parseHookCondition(condition string) (hookCondition, error) {
+	tokens, err := tokenizeHookCondition(condition)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("condition '%s' is empty", condition)
+	}
+
+	parser := &conditionParser{tokens: tokens}
+	result, err := parser.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("parsing condition '%s': %w", condition, err)
+	}
+
+	if token, has := parser.peek(); has {
+		return nil, fmt.Errorf("parsing condition '%s': unexpected '%s'", condition, token.value)
+	}
+
+	return result, nil
+}
+
+func tokenizeHookCondition(condition string) ([]conditionToken, error) {
+	tokens := []conditionToken{}
+	runes := []rune(condition)
+
+	for i := 0; i < len(runes); {
+		c := runes[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, conditionToken{kind: conditionTokenOperator, value: string(c)})
+			i++
+		case c == '!' || c == '=' || c == '&' || c == '|':
+			if i+1 < len(runes) {
+				operator := string(runes[i : i+2])
+				if operator == "!=" || operator == "==" || operator == "&&" || operator == "||" {
+					tokens = append(tokens, conditionToken{kind: conditionTokenOperator, value: operator})
+					i += 2
+					continue
+				}
+			}
+
+			if c != '!' {
+				return nil, fmt.Errorf("parsing condition '%s': unexpected '%c'", condition, c)
+			}
+
+			tokens = append(tokens, conditionToken{kind: conditionTokenOperator, value: "!"})
+			i++
+		case c == '\'' || c == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != c {
+				end++
+			}
+
+			if end == len(runes) {
+				return nil, fmt.Errorf("parsing condition '%s': unterminated string", condition)
+			}
+
+			tokens = append(tokens, conditionToken{kind: conditionTokenString, value: string(runes[i+1 : end])})
+			i = end + 1
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("()!=&|'\"", runes[i]) {
+				i++
+			}
+
+			tokens = append(tokens, conditionToken{kind: conditionTokenWord, value: string(runes[start:i])})
+		}
+	}
+
+	return tokens, nil
+}
+
+type conditionParser struct {
+	tokens   []conditionToken
+	position int
+}
+
+func (p *conditionParser) peek() (conditionToken, bool) {
+	if p.position >= len(p.tokens) {
+		return conditionToken{}, false
+	}
+
+	return p.tokens[p.position], true
+}
+
+func (p *conditionParser) acceptOperator(operator string) bool {
+	if token, has := p.peek(); has && token.kind == conditionTokenOperator && token.value == operator {
+		p.position++
+		return true
+	}
+
+	return false
+}
+
+func (p *conditionParser) parseOr() (hookCondition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptOperator("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &orCondition{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd() (hookCondition, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptOperator("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &andCondition{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *conditionParser) parseUnary() (hookCondition, error) {
+	if p.acceptOperator("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notCondition{operand: operand}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *conditionParser) parsePrimary() (hookCondition, error) {
+	if p.acceptOperator("(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.acceptOperator(")") {
+			return nil, fmt.Errorf("missing ')'")
+		}
+
+		return inner, nil
+	}
+
+	token, has := p.peek()
+	if !has {
+		return nil, fmt.Errorf("unexpected end of condition")
+	}
+
+	if token.kind != conditionTokenWord {
+		return nil, fmt.Errorf("expected the name of an environment variable, found '%s'", token.value)
+	}
+
+	p.position++
+	name := token.value
+
+	negate := false
+	switch {
+	case p.acceptOperator("=="):
+	case p.acceptOperator("!="):
+		negate = true
+	default:
+		return &truthyCondition{name: name}, nil
+	}
+
+	value, has := p.peek()
+	if !has || value.kind == conditionTokenOperator {
+		return nil, fmt.Errorf("expected a value to compare '%s' with", name)
+	}
+
+	p.position++
+
+	return &compareCondition{name: name, value: value.value, negate: negate}, nil
+}
+
+// EvaluateCondition evaluates a condition that uses the syntax of the `if` condition of hooks
+func EvaluateCondition(condition string, lookupEnv LookupEnvFn) (bool, error) {
+	parsed, err := parseHookCondition(condition)
+	if err != nil {
+		return false, err
+	}
+
+	return parsed.eval(lookupEnv), nil
+}
+
+// ValidateCondition returns an error when a condition is not valid
+func ValidateCondition(condition string) error {
+	_, err := parseHookCondition(condition)
+	return err
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HookCondition(t *testing.T) {
+	values := map[string]string{
+		"AZURE_ENV_TYPE": "prod",
+		"REGION":         "west us",
+		"SKIP_SEED":      "false",
+		"ENABLE_CACHE":   "true",
+		"EMPTY":          "",
+	}
+	lookupEnv := func(key string) (string, bool) {
+		value, has := values[key]
+		return value, has
+	}
+
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{"AZURE_ENV_TYPE == prod", true},
+		{"AZURE_ENV_TYPE==prod", true},
+		{"AZURE_ENV_TYPE == 'dev'", false},
+		{"AZURE_ENV_TYPE != \"dev\"", true},
+		{"REGION == 'west us'", true},
+		{"MISSING == ''", true},
+		{"ENABLE_CACHE", true},
+		{"SKIP_SEED", false},
+		{"EMPTY", false},
+		{"MISSING", false},
+		{"!SKIP_SEED && ENABLE_CACHE", true},
+		{"AZURE_ENV_TYPE == dev || ENABLE_CACHE", true},
+		{"AZURE_ENV_TYPE == dev || AZURE_ENV_TYPE == test && ENABLE_CACHE", false},
+		{"(AZURE_ENV_TYPE == dev || AZURE_ENV_TYPE == prod) && !(REGION == eastus)", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.condition, func(t *testing.T) {
+			condition, err := parseHookCondition(test.condition)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, condition.eval(lookupEnv))
+		})
+	}
+}
+
+func Test_HookCondition_Invalid(t *testing.T) {
+	tests := []struct {
+		condition     string
+		expectedError string
+	}{
+		{"  ", "is empty"},
+		{"AZURE_ENV_TYPE = prod", "unexpected '='"},
+		{"AZURE_ENV_TYPE ==", "expected a value to compare 'AZURE_ENV_TYPE' with"},
+		{"AZURE_ENV_TYPE == 'prod", "unterminated string"},
+		{"(AZURE_ENV_TYPE == prod", "missing ')'"},
+		{"AZURE_ENV_TYPE == prod)", "unexpected ')'"},
+		{"'prod' == AZURE_ENV_TYPE", "expected the name of an environment variable, found 'prod'"},
+		{"AZURE_ENV_TYPE == prod &&", "unexpected end of condition"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.condition, func(t *testing.T) {
+			_, err := parseHookCondition(test.condition)
+			require.ErrorContains(t, err, test.expectedError)
+		})
+	}
+}
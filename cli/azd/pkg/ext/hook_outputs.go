@@ -0,0 +1,83 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ext
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// HookOutputEnvVarName is the name of the environment variable with the path of the file that hooks write their
+// outputs to. Outputs are merged into the azd environment after the hook has completed.
+//
+// Each output is written on its own line as `KEY=VALUE`. Values that span multiple lines use a delimiter:
+//
+//	KEY<<EOF
+//	first line
+//	second line
+//	EOF
+const HookOutputEnvVarName = "AZD_HOOK_OUTPUT"
+
+// createHookOutputFile creates the empty file that a hook writes its outputs to
+func createHookOutputFile(hookName string) (string, error) {
+	file, err := os.CreateTemp(os.TempDir(), fmt.Sprintf("azd-%s-output-*", hookName))
+	if err != nil {
+		return "", fmt.Errorf("failed creating hook output file: %w", err)
+	}
+
+	defer file.Close()
+
+	return file.Name(), nil
+}
+
+// readHookOutputs reads the outputs written by a hook to the file at the specified path
+func readHookOutputs(path string) (map[string]string, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading hook output file: %w", err)
+	}
+
+	outputs := map[string]string{}
+	lines := strings.Split(strings.ReplaceAll(string(contents), "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		eqIdx := strings.Index(line, "=")
+		delimIdx := strings.Index(line, "<<")
+
+		if delimIdx > 0 && (eqIdx < 0 || delimIdx < eqIdx) {
+			key := strings.TrimSpace(line[:delimIdx])
+			delimiter := strings.TrimSpace(line[delimIdx+2:])
+			if delimiter == "" {
+				return nil, fmt.Errorf("hook output '%s' is missing a delimiter", key)
+			}
+
+			end := i + 1
+			for end < len(lines) && lines[end] != delimiter {
+				end++
+			}
+
+			if end == len(lines) {
+				return nil, fmt.Errorf("hook output '%s' is missing the closing delimiter '%s'", key, delimiter)
+			}
+
+			outputs[key] = strings.Join(lines[i+1:end], "\n")
+			i = end
+			continue
+		}
+
+		if eqIdx <= 0 {
+			return nil, fmt.Errorf("hook output '%s' is invalid. Outputs must be written as 'KEY=VALUE'", line)
+		}
+
+		outputs[strings.TrimSpace(line[:eqIdx])] = line[eqIdx+1:]
+	}
+
+	return outputs, nil
+}
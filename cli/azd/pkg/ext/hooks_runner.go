@@ -5,6 +5,7 @@ package ext
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -18,7 +19,10 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/bash"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/dotnet"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/node"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/powershell"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/python"
 )
 
 // Hooks enable support to invoke integration scripts before & after commands
@@ -32,6 +36,8 @@ type HooksRunner struct {
 	env            *environment.Environment
 	envManager     environment.Manager
 	serviceLocator ioc.ServiceLocator
+	// extraEnv holds values that are only available to the hooks, on top of the values of env
+	extraEnv map[string]string
 }
 
 // NewHooks creates a new instance of CommandHooks
@@ -67,6 +73,23 @@ func NewHooksRunner(
 	}
 }
 
+// WithEnv returns a copy of the hooks runner whose hooks also get the given environment values, which are not saved in
+// the environment.
+func (h *HooksRunner) WithEnv(values map[string]string) *HooksRunner {
+	runner := *h
+	runner.extraEnv = values
+	return &runner
+}
+
+// lookupEnv looks up the value of key in the values only available to the hooks, then in the environment.
+func (h *HooksRunner) lookupEnv(key string) (string, bool) {
+	if value, has := h.extraEnv[key]; has {
+		return value, true
+	}
+
+	return h.env.LookupEnv(key)
+}
+
 // Invokes an action run runs any registered pre or post script hooks for the specified command.
 func (h *HooksRunner) Invoke(ctx context.Context, commands []string, actionFn InvokeFn) error {
 	err := h.RunHooks(ctx, HookTypePre, nil, commands...)
@@ -104,7 +127,17 @@ func (h *HooksRunner) RunHooks(
 			return fmt.Errorf("reloading environment before running hook: %w", err)
 		}
 
-		err := h.execHook(ctx, hookConfig, options)
+		if hookConfig.condition != nil && !hookConfig.condition.eval(h.lookupEnv) {
+			log.Printf("Skipping hook '%s', the condition '%s' is not met\n", hookConfig.Name, hookConfig.If)
+
+			if hookConfig.location == ScriptLocationInline && hookConfig.path != "" {
+				os.Remove(hookConfig.path)
+			}
+
+			continue
+		}
+
+		outputs, err := h.execHook(ctx, hookConfig, options)
 		if err != nil {
 			return err
 		}
@@ -112,6 +145,16 @@ func (h *HooksRunner) RunHooks(
 		if err := h.envManager.Reload(ctx, h.env); err != nil {
 			return fmt.Errorf("reloading environment after running hook: %w", err)
 		}
+
+		if len(outputs) > 0 {
+			for key, value := range outputs {
+				h.env.DotenvSet(key, value)
+			}
+
+			if err := h.envManager.Save(ctx, h.env); err != nil {
+				return fmt.Errorf("saving outputs of hook '%s': %w", hookConfig.Name, err)
+			}
+		}
 	}
 
 	return nil
@@ -129,20 +172,37 @@ func (h *HooksRunner) GetScript(hookConfig *HookConfig, envVars []string) (tools
 		return bash.NewBashScript(h.commandRunner, h.cwd, envVars), nil
 	case ShellTypePowershell:
 		return powershell.NewPowershellScript(h.commandRunner, h.cwd, envVars), nil
+	case ShellTypePython:
+		return python.NewPythonScript(h.commandRunner, h.cwd, envVars), nil
+	case ShellTypeNode:
+		return node.NewNodeScript(h.commandRunner, h.cwd, envVars), nil
+	case ShellTypeDotNet:
+		return dotnet.NewDotNetScript(h.commandRunner, h.cwd, envVars), nil
+	case ShellTypeExec:
+		return newExecScript(h.commandRunner, h.cwd, envVars), nil
 	default:
 		return nil, fmt.Errorf(
-			"shell type '%s' is not a valid option. Only 'sh' and 'pwsh' are supported",
+			"shell type '%s' is not a valid option. Only 'sh', 'pwsh', 'python', 'node', 'dotnet' and 'exec' are supported",
 			hookConfig.Shell,
 		)
 	}
 }
 
-func (h *HooksRunner) execHook(ctx context.Context, hookConfig *HookConfig, options *tools.ExecOptions) error {
+// execHook runs the hook and returns the outputs that it has written to its output file
+func (h *HooksRunner) execHook(
+	ctx context.Context,
+	hookConfig *HookConfig,
+	options *tools.ExecOptions,
+) (map[string]string, error) {
 	if options == nil {
 		options = &tools.ExecOptions{}
 	}
 
 	hookEnv := environment.NewWithValues("temp", h.env.Dotenv())
+	for key, value := range h.extraEnv {
+		hookEnv.DotenvSet(key, value)
+	}
+
 	if len(hookConfig.Secrets) > 0 {
 		err := h.serviceLocator.Invoke(func(keyvaultService keyvault.KeyVaultService) error {
 			for key, value := range hookConfig.Secrets {
@@ -162,13 +222,21 @@ func (h *HooksRunner) execHook(ctx context.Context, hookConfig *HookConfig, opti
 			return nil
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
+	outputPath, err := createHookOutputFile(hookConfig.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.Remove(outputPath)
+	hookEnv.DotenvSet(HookOutputEnvVarName, outputPath)
+
 	script, err := h.GetScript(hookConfig, hookEnv.Environ())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	formatter := h.console.GetFormatter()
@@ -192,17 +260,39 @@ func (h *HooksRunner) execHook(ctx context.Context, hookConfig *HookConfig, opti
 	}
 	options.UserPwsh = string(hookConfig.Shell)
 
-	log.Printf("Executing script '%s'\n", hookConfig.path)
-	res, err := script.Execute(ctx, hookConfig.path, *options)
+	scriptPath := hookConfig.path
+	if hookConfig.Shell == ShellTypeExec && hookConfig.location == ScriptLocationInline {
+		scriptPath = hookConfig.script
+	}
+
+	execCtx := ctx
+	if hookConfig.timeout > 0 {
+		var cancel context.CancelFunc
+		execCtx, cancel = context.WithTimeout(ctx, hookConfig.timeout)
+		defer cancel()
+	}
+
+	log.Printf("Executing script '%s'\n", scriptPath)
+	res, err := script.Execute(execCtx, scriptPath, *options)
 	if err != nil {
 		execErr := fmt.Errorf(
 			"'%s' hook failed with exit code: '%d', Path: '%s'. : %w",
 			hookConfig.Name,
 			res.ExitCode,
-			hookConfig.path,
+			scriptPath,
 			err,
 		)
 
+		if errors.Is(execCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			execErr = fmt.Errorf(
+				"'%s' hook timed out after '%s', Path: '%s'. : %w",
+				hookConfig.Name,
+				hookConfig.timeout,
+				scriptPath,
+				execCtx.Err(),
+			)
+		}
+
 		// If an error occurred log the failure but continue
 		if hookConfig.ContinueOnError {
 			h.console.Message(ctx, output.WithBold("%s", output.WithWarningFormat("WARNING: %s", execErr.Error())))
@@ -212,15 +302,25 @@ func (h *HooksRunner) execHook(ctx context.Context, hookConfig *HookConfig, opti
 			)
 			log.Println(execErr.Error())
 		} else {
-			return execErr
+			return nil, execErr
 		}
 	}
 
 	// Delete any temporary inline scripts after execution
 	// Removing temp scripts only on success to support better debugging with failing scripts.
-	if hookConfig.location == ScriptLocationInline {
+	if hookConfig.location == ScriptLocationInline && hookConfig.path != "" {
 		defer os.Remove(hookConfig.path)
 	}
 
-	return nil
+	// Outputs are only merged into the environment when the hook succeeded
+	if err != nil {
+		return nil, nil
+	}
+
+	outputs, err := readHookOutputs(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' hook outputs are invalid: %w", hookConfig.Name, err)
+	}
+
+	return outputs, nil
 }
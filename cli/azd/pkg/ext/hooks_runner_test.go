@@ -6,9 +6,13 @@ package ext
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"reflect"
+	"runtime"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
@@ -75,7 +79,7 @@ func Test_Hooks_Execute(t *testing.T) {
 			ranPreHook = true
 			require.Equal(t, "scripts/precommand.sh", args.Args[0])
 			require.Equal(t, cwd, args.Cwd)
-			require.ElementsMatch(t, env.Environ(), args.Env)
+			require.ElementsMatch(t, env.Environ(), withoutHookOutput(t, args.Env))
 			require.Equal(t, false, args.Interactive)
 
 			return exec.NewRunResult(0, "", ""), nil
@@ -110,7 +114,7 @@ func Test_Hooks_Execute(t *testing.T) {
 			ranPostHook = true
 			require.Equal(t, "scripts/postcommand.sh", args.Args[0])
 			require.Equal(t, cwd, args.Cwd)
-			require.ElementsMatch(t, env.Environ(), args.Env)
+			require.ElementsMatch(t, env.Environ(), withoutHookOutput(t, args.Env))
 			require.Equal(t, false, args.Interactive)
 
 			return exec.NewRunResult(0, "", ""), nil
@@ -145,7 +149,7 @@ func Test_Hooks_Execute(t *testing.T) {
 			ranPostHook = true
 			require.Equal(t, "scripts/preinteractive.sh", args.Args[0])
 			require.Equal(t, cwd, args.Cwd)
-			require.ElementsMatch(t, env.Environ(), args.Env)
+			require.ElementsMatch(t, env.Environ(), withoutHookOutput(t, args.Env))
 			require.Equal(t, true, args.Interactive)
 
 			return exec.NewRunResult(0, "", ""), nil
@@ -418,6 +422,170 @@ func Test_Hooks_GetScript(t *testing.T) {
 
 }
 
+func Test_Hooks_GetScript_Runtimes(t *testing.T) {
+	cwd := t.TempDir()
+	ostest.Chdir(t, cwd)
+
+	env := environment.New("test")
+	envManager := &mockenv.MockEnvManager{}
+
+	tests := []struct {
+		name          string
+		config        *HookConfig
+		createFile    bool
+		scriptType    string
+		shell         ShellType
+		location      ScriptLocation
+		tempScriptExt string
+	}{
+		{
+			name:       "PythonPath",
+			config:     &HookConfig{Run: "scripts/hook.py"},
+			createFile: true,
+			scriptType: "*python.pythonScript",
+			shell:      ShellTypePython,
+			location:   ScriptLocationPath,
+		},
+		{
+			name:       "NodePath",
+			config:     &HookConfig{Run: "scripts/hook.js"},
+			createFile: true,
+			scriptType: "*node.nodeScript",
+			shell:      ShellTypeNode,
+			location:   ScriptLocationPath,
+		},
+		{
+			name:       "NodeModulePath",
+			config:     &HookConfig{Run: "scripts/hook.mjs"},
+			createFile: true,
+			scriptType: "*node.nodeScript",
+			shell:      ShellTypeNode,
+			location:   ScriptLocationPath,
+		},
+		{
+			name:       "DotNetPath",
+			config:     &HookConfig{Run: "scripts/hook.cs"},
+			createFile: true,
+			scriptType: "*dotnet.dotNetScript",
+			shell:      ShellTypeDotNet,
+			location:   ScriptLocationPath,
+		},
+		{
+			name:          "PythonInline",
+			config:        &HookConfig{Shell: ShellTypePython, Run: "print('hello')"},
+			scriptType:    "*python.pythonScript",
+			shell:         ShellTypePython,
+			location:      ScriptLocationInline,
+			tempScriptExt: ".py",
+		},
+		{
+			name:          "NodeInline",
+			config:        &HookConfig{Shell: ShellTypeNode, Run: "console.log('hello')"},
+			scriptType:    "*node.nodeScript",
+			shell:         ShellTypeNode,
+			location:      ScriptLocationInline,
+			tempScriptExt: ".mjs",
+		},
+		{
+			name:       "ExecPath",
+			config:     &HookConfig{Shell: ShellTypeExec, Run: "bin/tool.exe"},
+			createFile: true,
+			scriptType: "*ext.execScript",
+			shell:      ShellTypeExec,
+			location:   ScriptLocationPath,
+		},
+		{
+			name:       "ExecInline",
+			config:     &HookConfig{Shell: ShellTypeExec, Run: "terraform fmt -check"},
+			scriptType: "*ext.execScript",
+			shell:      ShellTypeExec,
+			location:   ScriptLocationInline,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.createFile {
+				ensureScriptsExist(t, map[string][]*HookConfig{"test": {test.config}})
+			}
+
+			mockContext := mocks.NewMockContext(context.Background())
+			runner := NewHooksRunner(
+				NewHooksManager(cwd),
+				mockContext.CommandRunner,
+				envManager,
+				mockContext.Console,
+				cwd,
+				map[string][]*HookConfig{},
+				env,
+				mockContext.Container,
+			)
+
+			script, err := runner.GetScript(test.config, runner.env.Environ())
+			require.NoError(t, err)
+			require.Equal(t, test.scriptType, reflect.TypeOf(script).String())
+			require.Equal(t, test.shell, test.config.Shell)
+			require.Equal(t, test.location, test.config.location)
+
+			if test.tempScriptExt != "" {
+				require.Contains(t, test.config.path, os.TempDir())
+				require.Equal(t, test.tempScriptExt, filepath.Ext(test.config.path))
+				require.FileExists(t, test.config.path)
+			} else if test.location == ScriptLocationInline {
+				// Inline exec hooks don't have a script file
+				require.Empty(t, test.config.path)
+			}
+		})
+	}
+}
+
+func Test_Hooks_Execute_Exec(t *testing.T) {
+	cwd := t.TempDir()
+	ostest.Chdir(t, cwd)
+
+	env := environment.NewWithValues("test", map[string]string{"a": "apple"})
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Reload", mock.Anything, env).Return(nil)
+
+	hooksMap := map[string][]*HookConfig{
+		"preinline": {{Shell: ShellTypeExec, Run: `terraform fmt -check "infra dir"`}},
+		"prepath":   {{Shell: ShellTypeExec, Run: "bin/tool.exe"}},
+	}
+	ensureScriptsExist(t, map[string][]*HookConfig{"prepath": hooksMap["prepath"]})
+
+	var ran []exec.RunArgs
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return true
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		ran = append(ran, args)
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	runner := NewHooksRunner(
+		NewHooksManager(cwd),
+		mockContext.CommandRunner,
+		envManager,
+		mockContext.Console,
+		cwd,
+		hooksMap,
+		env,
+		mockContext.Container,
+	)
+
+	require.NoError(t, runner.RunHooks(*mockContext.Context, HookTypePre, nil, "inline"))
+	require.NoError(t, runner.RunHooks(*mockContext.Context, HookTypePre, nil, "path"))
+
+	require.Len(t, ran, 2)
+	require.Equal(t, "terraform", ran[0].Cmd)
+	require.Equal(t, []string{"fmt", "-check", "infra dir"}, ran[0].Args)
+	require.Equal(t, cwd, ran[0].Cwd)
+	require.ElementsMatch(t, env.Environ(), withoutHookOutput(t, ran[0].Env))
+
+	require.Equal(t, filepath.Join(cwd, "bin", "tool.exe"), ran[1].Cmd)
+	require.Empty(t, ran[1].Args)
+}
+
 type scriptValidationTest struct {
 	name          string
 	config        *HookConfig
@@ -514,3 +682,248 @@ func Test_GetScript_Validation(t *testing.T) {
 		})
 	}
 }
+
+func Test_Hooks_Execute_Conditions(t *testing.T) {
+	cwd := t.TempDir()
+	ostest.Chdir(t, cwd)
+
+	env := environment.NewWithValues("test", map[string]string{"AZURE_ENV_TYPE": "prod"})
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Reload", mock.Anything, env).Return(nil)
+
+	hooksMap := map[string][]*HookConfig{
+		"postprovision": {
+			{Shell: ShellTypeBash, Run: "scripts/prod.sh", If: "AZURE_ENV_TYPE == prod"},
+			{Shell: ShellTypeBash, Run: "scripts/dev.sh", If: "AZURE_ENV_TYPE == 'dev'"},
+			{Shell: ShellTypeBash, Run: "scripts/always.sh"},
+		},
+	}
+	ensureScriptsExist(t, hooksMap)
+
+	var ran []string
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return true
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		ran = append(ran, args.Args[0])
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	runner := NewHooksRunner(
+		NewHooksManager(cwd),
+		mockContext.CommandRunner,
+		envManager,
+		mockContext.Console,
+		cwd,
+		hooksMap,
+		env,
+		mockContext.Container,
+	)
+
+	require.NoError(t, runner.RunHooks(*mockContext.Context, HookTypePost, nil, "provision"))
+	require.Equal(t, []string{"scripts/prod.sh", "scripts/always.sh"}, ran)
+
+	t.Run("InvalidCondition", func(t *testing.T) {
+		hooksMap := map[string][]*HookConfig{
+			"preprovision": {{Shell: ShellTypeBash, Run: "echo 'Hello'", If: "AZURE_ENV_TYPE =="}},
+		}
+
+		runner := NewHooksRunner(
+			NewHooksManager(cwd),
+			mockContext.CommandRunner,
+			envManager,
+			mockContext.Console,
+			cwd,
+			hooksMap,
+			env,
+			mockContext.Container,
+		)
+
+		err := runner.RunHooks(*mockContext.Context, HookTypePre, nil, "provision")
+		require.ErrorContains(t, err, "expected a value to compare 'AZURE_ENV_TYPE' with")
+	})
+}
+
+func Test_Hooks_Execute_WithEnv(t *testing.T) {
+	cwd := t.TempDir()
+	ostest.Chdir(t, cwd)
+
+	env := environment.NewWithValues("test", map[string]string{"a": "apple"})
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Reload", mock.Anything, env).Return(nil)
+
+	hooksMap := map[string][]*HookConfig{
+		"posttrafficshift": {{Shell: ShellTypeBash, Run: "scripts/gate.sh", If: "TRAFFIC_WEIGHT == 10"}},
+	}
+	ensureScriptsExist(t, hooksMap)
+
+	var hookEnv []string
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "gate.sh")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		hookEnv = args.Env
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	runner := NewHooksRunner(
+		NewHooksManager(cwd),
+		mockContext.CommandRunner,
+		envManager,
+		mockContext.Console,
+		cwd,
+		hooksMap,
+		env,
+		mockContext.Container,
+	)
+
+	err := runner.WithEnv(map[string]string{"TRAFFIC_WEIGHT": "10"}).
+		RunHooks(*mockContext.Context, HookTypePost, nil, "trafficshift")
+	require.NoError(t, err)
+	require.Contains(t, hookEnv, "TRAFFIC_WEIGHT=10")
+	require.Contains(t, hookEnv, "a=apple")
+
+	// the values are not added to the environment
+	require.Equal(t, map[string]string{"a": "apple"}, env.Dotenv())
+}
+
+func Test_Hooks_Execute_Outputs(t *testing.T) {
+	cwd := t.TempDir()
+	ostest.Chdir(t, cwd)
+
+	hooksMap := map[string][]*HookConfig{
+		"postprovision": {{Shell: ShellTypeBash, Run: "scripts/postprovision.sh"}},
+	}
+	ensureScriptsExist(t, hooksMap)
+
+	newRunner := func(env *environment.Environment, envManager environment.Manager, output string) *HooksRunner {
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+			return strings.Contains(command, "postprovision.sh")
+		}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			outputPath := hookOutputPath(t, args.Env)
+			err := os.WriteFile(outputPath, []byte(output), osutil.PermissionFile)
+			return exec.NewRunResult(0, "", ""), err
+		})
+
+		return NewHooksRunner(
+			NewHooksManager(cwd),
+			mockContext.CommandRunner,
+			envManager,
+			mockContext.Console,
+			cwd,
+			hooksMap,
+			env,
+			mockContext.Container,
+		)
+	}
+
+	t.Run("Merged", func(t *testing.T) {
+		env := environment.NewWithValues("test", map[string]string{"a": "apple"})
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Reload", mock.Anything, env).Return(nil)
+		envManager.On("Save", mock.Anything, env).Return(nil)
+
+		output := "DATABASE_NAME=todo\nCONNECTION=Server=db;Port=5432\r\nCERT<<EOF\nline 1\nline 2\nEOF\n"
+		runner := newRunner(env, envManager, output)
+
+		require.NoError(t, runner.RunHooks(context.Background(), HookTypePost, nil, "provision"))
+		require.Equal(t, map[string]string{
+			"a":             "apple",
+			"DATABASE_NAME": "todo",
+			"CONNECTION":    "Server=db;Port=5432",
+			"CERT":          "line 1\nline 2",
+		}, env.Dotenv())
+		envManager.AssertCalled(t, "Save", mock.Anything, env)
+	})
+
+	t.Run("NoOutputs", func(t *testing.T) {
+		env := environment.NewWithValues("test", map[string]string{"a": "apple"})
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Reload", mock.Anything, env).Return(nil)
+
+		runner := newRunner(env, envManager, "")
+
+		require.NoError(t, runner.RunHooks(context.Background(), HookTypePost, nil, "provision"))
+		require.Equal(t, map[string]string{"a": "apple"}, env.Dotenv())
+		envManager.AssertNotCalled(t, "Save", mock.Anything, env)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		env := environment.NewWithValues("test", map[string]string{"a": "apple"})
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Reload", mock.Anything, env).Return(nil)
+
+		runner := newRunner(env, envManager, "CERT<<EOF\nline 1\n")
+
+		err := runner.RunHooks(context.Background(), HookTypePost, nil, "provision")
+		require.ErrorContains(t, err, "hook output 'CERT' is missing the closing delimiter 'EOF'")
+	})
+}
+
+func Test_Hooks_Execute_Timeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses the posix sleep command")
+	}
+
+	cwd := t.TempDir()
+	ostest.Chdir(t, cwd)
+
+	env := environment.NewWithValues("test", map[string]string{})
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Reload", mock.Anything, env).Return(nil)
+
+	hooksMap := map[string][]*HookConfig{
+		"predeploy": {{Shell: ShellTypeExec, Run: "sleep 10", Timeout: "100ms"}},
+		"prepackage": {{
+			Shell:           ShellTypeExec,
+			Run:             "sleep 10",
+			Timeout:         "100ms",
+			ContinueOnError: true,
+		}},
+		"preprovision": {{Shell: ShellTypeExec, Run: "sleep 10", Timeout: "ten minutes"}},
+	}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	runner := NewHooksRunner(
+		NewHooksManager(cwd),
+		exec.NewCommandRunner(nil),
+		envManager,
+		mockContext.Console,
+		cwd,
+		hooksMap,
+		env,
+		mockContext.Container,
+	)
+
+	start := time.Now()
+	err := runner.RunHooks(*mockContext.Context, HookTypePre, nil, "deploy")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "'predeploy' hook timed out after '100ms'")
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	require.NoError(t, runner.RunHooks(*mockContext.Context, HookTypePre, nil, "package"))
+
+	err = runner.RunHooks(*mockContext.Context, HookTypePre, nil, "provision")
+	require.ErrorContains(t, err, "timeout 'ten minutes' is not valid")
+}
+
+// hookOutputPath returns the path of the hook output file in the environment variables of a hook
+func hookOutputPath(t *testing.T, envVars []string) string {
+	for _, envVar := range envVars {
+		if value, has := strings.CutPrefix(envVar, HookOutputEnvVarName+"="); has {
+			return value
+		}
+	}
+
+	require.Fail(t, "the hook output environment variable is not set")
+	return ""
+}
+
+// withoutHookOutput returns the environment variables of a hook without the hook output environment variable
+func withoutHookOutput(t *testing.T, envVars []string) []string {
+	outputVar := HookOutputEnvVarName + "=" + hookOutputPath(t, envVars)
+	return slices.DeleteFunc(slices.Clone(envVars), func(envVar string) bool {
+		return envVar == outputVar
+	})
+}
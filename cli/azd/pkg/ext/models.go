@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 )
@@ -22,6 +23,10 @@ type ScriptLocation string
 const (
 	ShellTypeBash         ShellType      = "sh"
 	ShellTypePowershell   ShellType      = "pwsh"
+	ShellTypePython       ShellType      = "python"
+	ShellTypeNode         ShellType      = "node"
+	ShellTypeDotNet       ShellType      = "dotnet"
+	ShellTypeExec         ShellType      = "exec"
 	ScriptTypeUnknown     ShellType      = ""
 	ScriptLocationInline  ScriptLocation = "inline"
 	ScriptLocationPath    ScriptLocation = "path"
@@ -40,7 +45,9 @@ var (
 		"unable to determine script type. Ensure 'Shell' parameter is set in configuration options",
 	)
 	ErrRunRequired           error = errors.New("run is always required")
-	ErrUnsupportedScriptType error = errors.New("script type is not valid. Only '.sh' and '.ps1' are supported")
+	ErrUnsupportedScriptType error = errors.New(
+		"script type is not valid. Only '.sh', '.ps1', '.py', '.js', '.mjs', '.cjs' and '.cs' are supported",
+	)
 )
 
 // Generic action function that may return an error
@@ -58,10 +65,14 @@ type HookConfig struct {
 	cwd string
 	// When location is `inline` a script must be defined inline
 	script string
+	// The parsed value of `Timeout`, zero when the hook has no timeout
+	timeout time.Duration
+	// The parsed value of `If`, nil when the hook always runs
+	condition hookCondition
 
 	// Internal name of the hook running for a given command
 	Name string `yaml:",omitempty"`
-	// The type of script hook (bash or powershell)
+	// The type of script hook (bash, powershell, python, node, dotnet or exec)
 	Shell ShellType `yaml:"shell,omitempty"`
 	// The inline script to execute or path to existing file
 	Run string `yaml:"run,omitempty"`
@@ -73,6 +84,11 @@ type HookConfig struct {
 	Windows *HookConfig `yaml:"windows,omitempty"`
 	// When running on linux/macos use this override config
 	Posix *HookConfig `yaml:"posix,omitempty"`
+	// The maximum duration of the hook, for example '30s' or '10m'. The hook is stopped and fails when it runs longer.
+	Timeout string `yaml:"timeout,omitempty"`
+	// The condition evaluated against the environment values that must be met for the hook to run,
+	// for example 'AZURE_ENV_TYPE == prod'
+	If string `yaml:"if,omitempty"`
 	// Environment variables in this list are added to the hook script and if the value is a akvs:// reference
 	// it will be resolved to the secret value
 	Secrets map[string]string `yaml:"secrets,omitempty"`
@@ -107,6 +123,24 @@ func (hc *HookConfig) validate() error {
 		return ErrScriptTypeUnknown
 	}
 
+	if hc.Timeout != "" {
+		timeout, err := time.ParseDuration(hc.Timeout)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("timeout '%s' is not valid. Use a positive duration like '30s' or '10m'", hc.Timeout)
+		}
+
+		hc.timeout = timeout
+	}
+
+	if hc.If != "" {
+		condition, err := parseHookCondition(hc.If)
+		if err != nil {
+			return err
+		}
+
+		hc.condition = condition
+	}
+
 	if hc.location == ScriptLocationUnknown {
 		if hc.path != "" {
 			hc.location = ScriptLocationPath
@@ -115,7 +149,8 @@ func (hc *HookConfig) validate() error {
 		}
 	}
 
-	if hc.location == ScriptLocationInline {
+	// Inline exec hooks are the command line to run, rather than the contents of a script
+	if hc.location == ScriptLocationInline && hc.Shell != ShellTypeExec {
 		tempScript, err := createTempScript(hc)
 		if err != nil {
 			return err
@@ -158,6 +193,12 @@ func inferScriptTypeFromFilePath(path string) (ShellType, error) {
 		return ShellTypeBash, nil
 	case ".ps1":
 		return ShellTypePowershell, nil
+	case ".py":
+		return ShellTypePython, nil
+	case ".js", ".mjs", ".cjs":
+		return ShellTypeNode, nil
+	case ".cs":
+		return ShellTypeDotNet, nil
 	default:
 		return "", fmt.Errorf(
 			"script with file extension '%s' is not valid. %w.",
@@ -171,6 +212,7 @@ func createTempScript(hookConfig *HookConfig) (string, error) {
 	var ext string
 	scriptHeader := []string{}
 	scriptFooter := []string{}
+	commentPrefix := "#"
 
 	switch ShellType(strings.Split(string(hookConfig.Shell), " ")[0]) {
 	case ShellTypeBash:
@@ -187,6 +229,15 @@ func createTempScript(hookConfig *HookConfig) (string, error) {
 		scriptFooter = []string{
 			"if ((Test-Path -LiteralPath variable:\\LASTEXITCODE)) { exit $LASTEXITCODE }",
 		}
+	case ShellTypePython:
+		ext = "py"
+	case ShellTypeNode:
+		// Inline scripts can use import statements
+		ext = "mjs"
+		commentPrefix = "//"
+	case ShellTypeDotNet:
+		ext = "cs"
+		commentPrefix = "//"
 	}
 
 	// Write the temporary script file to OS temp dir
@@ -203,7 +254,7 @@ func createTempScript(hookConfig *HookConfig) (string, error) {
 	}
 
 	scriptBuilder.WriteString("\n")
-	scriptBuilder.WriteString("# Auto generated file from Azure Developer CLI\n")
+	scriptBuilder.WriteString(commentPrefix + " Auto generated file from Azure Developer CLI\n")
 	scriptBuilder.WriteString(hookConfig.script)
 	scriptBuilder.WriteString("\n")
 
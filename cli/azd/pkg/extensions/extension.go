@@ -23,6 +23,7 @@ type Extension struct {
 	Usage        string           `json:"usage"`
 	Path         string           `json:"path"`
 	Source       string           `json:"source"`
+	Digest       string           `json:"digest,omitempty"`
 
 	stdin  *bytes.Buffer
 	stdout *output.DynamicMultiWriter
@@ -30,6 +31,9 @@ type Extension struct {
 
 	readySignal chan error // consolidated channel, buffered with capacity 1
 	readyOnce   sync.Once  // ensures signal is sent only once
+
+	readyMu           sync.Mutex
+	readyCapabilities map[CapabilityType]bool // listen capabilities that have signaled readiness
 }
 
 // init initializes the extension's buffers and signals.
@@ -47,6 +51,26 @@ func (e *Extension) Initialize() {
 	})
 }
 
+// MarkReady records that the extension is ready to handle the requests of azd for the capability.
+// The extension is ready once all its listen capabilities are ready.
+func (e *Extension) MarkReady(capability CapabilityType) {
+	e.readyMu.Lock()
+	defer e.readyMu.Unlock()
+
+	if e.readyCapabilities == nil {
+		e.readyCapabilities = map[CapabilityType]bool{}
+	}
+	e.readyCapabilities[capability] = true
+
+	for _, listenCapability := range ListenCapabilities {
+		if e.HasCapability(listenCapability) && !e.readyCapabilities[listenCapability] {
+			return
+		}
+	}
+
+	e.Initialize()
+}
+
 // Fail signals that the extension has encountered an error.
 func (e *Extension) Fail(err error) {
 	e.readyOnce.Do(func() {
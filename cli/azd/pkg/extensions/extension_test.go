@@ -0,0 +1,34 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Extension_MarkReady(t *testing.T) {
+	extension := &Extension{
+		Id: "test.extension",
+		Capabilities: []CapabilityType{
+			CustomCommandCapability,
+			LifecycleEventsCapability,
+			ServiceTargetProviderCapability,
+		},
+	}
+	extension.init()
+
+	// The extension isn't ready until all its listen capabilities are ready
+	extension.MarkReady(ServiceTargetProviderCapability)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, extension.WaitUntilReady(ctx), context.DeadlineExceeded)
+
+	extension.MarkReady(LifecycleEventsCapability)
+	require.NoError(t, extension.WaitUntilReady(context.Background()))
+}
@@ -0,0 +1,156 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+)
+
+// LockFileName is the name of the extensions lock file, written next to azure.yaml
+const LockFileName = "azd-extensions.lock"
+
+var ErrLockFileNotFound = errors.New("extensions lock file not found")
+
+// LockFile pins the exact versions & artifact digests of the extensions installed for a project,
+// so the extensions can be restored reproducibly on other machines.
+type LockFile struct {
+	// Extensions is a map of the locked extensions keyed by extension id
+	Extensions map[string]*LockedExtension `json:"extensions"`
+}
+
+// LockedExtension is the exact version of an extension installed for a project
+type LockedExtension struct {
+	// Version is the exact version of the extension
+	Version string `json:"version"`
+	// Source is the name of the extension source the extension was installed from
+	Source string `json:"source,omitempty"`
+	// Digests are the digests of the artifacts keyed by platform (os/arch, or os for artifacts of any architecture)
+	Digests map[string]string `json:"digests,omitempty"`
+}
+
+// LockFilePath gets the path of the extensions lock file of the project in the specified directory
+func LockFilePath(projectDir string) string {
+	return filepath.Join(projectDir, LockFileName)
+}
+
+// LoadLockFile loads the extensions lock file of the project in the specified directory
+func LoadLockFile(projectDir string) (*LockFile, error) {
+	lockFilePath := LockFilePath(projectDir)
+
+	data, err := os.ReadFile(lockFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrLockFileNotFound, lockFilePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read extensions lock file: %w", err)
+	}
+
+	var lockFile *LockFile
+	if err := json.Unmarshal(data, &lockFile); err != nil {
+		return nil, fmt.Errorf("failed to parse extensions lock file %s: %w", lockFilePath, err)
+	}
+
+	if lockFile.Extensions == nil {
+		lockFile.Extensions = map[string]*LockedExtension{}
+	}
+
+	return lockFile, nil
+}
+
+// LoadOrCreateLockFile loads the extensions lock file of the project in the specified directory,
+// or creates an empty lock file when the project doesn't have one.
+func LoadOrCreateLockFile(projectDir string) (*LockFile, error) {
+	lockFile, err := LoadLockFile(projectDir)
+	if errors.Is(err, ErrLockFileNotFound) {
+		return &LockFile{Extensions: map[string]*LockedExtension{}}, nil
+	}
+
+	return lockFile, err
+}
+
+// Save writes the lock file to the project in the specified directory
+func (l *LockFile) Save(projectDir string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal extensions lock file: %w", err)
+	}
+
+	if err := os.WriteFile(LockFilePath(projectDir), append(data, '\n'), osutil.PermissionFile); err != nil {
+		return fmt.Errorf("failed to write extensions lock file: %w", err)
+	}
+
+	return nil
+}
+
+// Lock records the version & source of the installed extension, with the digests of the artifacts of the version
+// for every platform: the checksums published by the registry, and the digest of the artifact installed for the
+// current platform. The digests of the other platforms are kept as long as the version & source of the extension
+// don't change.
+func (l *LockFile) Lock(extension *Extension, version *ExtensionVersion) {
+	locked, has := l.Extensions[extension.Id]
+	if !has || locked.Version != extension.Version || locked.Source != extension.Source {
+		locked = &LockedExtension{
+			Version: extension.Version,
+			Source:  extension.Source,
+		}
+		l.Extensions[extension.Id] = locked
+	}
+
+	digests := map[string]string{}
+	if version != nil {
+		for platform, artifact := range version.Artifacts {
+			if artifact.Checksum.Algorithm != "" && artifact.Checksum.Value != "" {
+				digests[platform] = fmt.Sprintf(
+					"%s:%s", strings.ToLower(artifact.Checksum.Algorithm), strings.ToLower(artifact.Checksum.Value))
+			}
+		}
+	}
+
+	if extension.Digest != "" {
+		digests[currentPlatform()] = extension.Digest
+	}
+
+	if len(digests) > 0 && locked.Digests == nil {
+		locked.Digests = map[string]string{}
+	}
+
+	maps.Copy(locked.Digests, digests)
+}
+
+// Digest gets the digest locked for the artifact of the current platform, if any
+func (l *LockedExtension) Digest() (string, bool) {
+	for _, platform := range []string{currentPlatform(), runtime.GOOS} {
+		if digest, has := l.Digests[platform]; has {
+			return digest, true
+		}
+	}
+
+	return "", false
+}
+
+// FilterOptions gets the options to install the exact locked version of the extension.
+// The artifact digest is pinned when a digest was recorded for the current platform.
+func (l *LockedExtension) FilterOptions() *FilterOptions {
+	digest, _ := l.Digest()
+
+	return &FilterOptions{
+		Version: l.Version,
+		Source:  l.Source,
+		Digest:  digest,
+	}
+}
+
+// currentPlatform gets the platform (os/arch) key of the digests of the locked extensions
+func currentPlatform() string {
+	return fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)
+}
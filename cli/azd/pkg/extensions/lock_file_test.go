@@ -0,0 +1,135 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LockFile_SaveAndLoad(t *testing.T) {
+	projectDir := t.TempDir()
+
+	_, err := LoadLockFile(projectDir)
+	require.ErrorIs(t, err, ErrLockFileNotFound)
+
+	lockFile, err := LoadOrCreateLockFile(projectDir)
+	require.NoError(t, err)
+	require.Empty(t, lockFile.Extensions)
+
+	lockFile.Lock(&Extension{
+		Id:      "test.extension",
+		Version: "1.2.0",
+		Source:  "azd",
+		Digest:  "sha256:abc",
+	}, nil)
+	lockFile.Lock(&Extension{
+		Id:      "test.pack",
+		Version: "0.1.0",
+		Source:  "azd",
+	}, nil)
+	require.NoError(t, lockFile.Save(projectDir))
+
+	_, err = os.Stat(LockFilePath(projectDir))
+	require.NoError(t, err)
+
+	loaded, err := LoadLockFile(projectDir)
+	require.NoError(t, err)
+	require.Equal(t, lockFile, loaded)
+
+	require.Equal(t, &FilterOptions{
+		Version: "1.2.0",
+		Source:  "azd",
+		Digest:  "sha256:abc",
+	}, loaded.Extensions["test.extension"].FilterOptions())
+
+	// Extension packs don't have artifacts, so only the version is pinned
+	require.Equal(t, &FilterOptions{
+		Version: "0.1.0",
+		Source:  "azd",
+	}, loaded.Extensions["test.pack"].FilterOptions())
+}
+
+func Test_LockFile_Lock(t *testing.T) {
+	lockFile := &LockFile{
+		Extensions: map[string]*LockedExtension{
+			"test.extension": {
+				Version: "1.2.0",
+				Source:  "azd",
+				Digests: map[string]string{
+					"other/platform": "sha256:other",
+				},
+			},
+		},
+	}
+
+	t.Run("SameVersion", func(t *testing.T) {
+		lockFile.Lock(&Extension{Id: "test.extension", Version: "1.2.0", Source: "azd", Digest: "sha256:abc"}, nil)
+
+		// The digests of the other platforms are kept
+		require.Equal(t, map[string]string{
+			"other/platform":  "sha256:other",
+			currentPlatform(): "sha256:abc",
+		}, lockFile.Extensions["test.extension"].Digests)
+	})
+
+	t.Run("RegistryChecksums", func(t *testing.T) {
+		lockFile.Lock(
+			&Extension{Id: "test.extension", Version: "1.2.0", Source: "azd", Digest: "sha256:abc"},
+			&ExtensionVersion{
+				Version: "1.2.0",
+				Artifacts: map[string]ExtensionArtifact{
+					"darwin/arm64": {Checksum: ExtensionChecksum{Algorithm: "sha256", Value: "DARWIN"}},
+					"windows":      {Checksum: ExtensionChecksum{Algorithm: "sha512", Value: "windows"}},
+					"linux/arm64":  {},
+				},
+			},
+		)
+
+		// The artifacts of every platform published with a checksum are locked
+		require.Equal(t, map[string]string{
+			"other/platform":  "sha256:other",
+			"darwin/arm64":    "sha256:darwin",
+			"windows":         "sha512:windows",
+			currentPlatform(): "sha256:abc",
+		}, lockFile.Extensions["test.extension"].Digests)
+	})
+
+	t.Run("NewVersion", func(t *testing.T) {
+		lockFile.Lock(&Extension{Id: "test.extension", Version: "1.3.0", Source: "azd", Digest: "sha256:def"}, nil)
+
+		require.Equal(t, &LockedExtension{
+			Version: "1.3.0",
+			Source:  "azd",
+			Digests: map[string]string{
+				currentPlatform(): "sha256:def",
+			},
+		}, lockFile.Extensions["test.extension"])
+	})
+}
+
+func Test_LockedExtension_Digest(t *testing.T) {
+	locked := &LockedExtension{
+		Version: "1.2.0",
+		Digests: map[string]string{
+			runtime.GOOS: "sha256:os",
+		},
+	}
+
+	// Artifacts of any architecture are locked for the os
+	digest, has := locked.Digest()
+	require.True(t, has)
+	require.Equal(t, "sha256:os", digest)
+
+	locked.Digests[currentPlatform()] = "sha256:platform"
+	digest, has = locked.Digest()
+	require.True(t, has)
+	require.Equal(t, "sha256:platform", digest)
+
+	_, has = (&LockedExtension{Version: "0.1.0"}).Digest()
+	require.False(t, has)
+}
@@ -38,6 +38,7 @@ var (
 	ErrInstalledExtensionNotFound = errors.New("extension not found")
 	ErrRegistryExtensionNotFound  = errors.New("extension not found in registry")
 	ErrExtensionInstalled         = errors.New("extension already installed")
+	ErrDigestMismatch             = errors.New("artifact digest doesn't match the locked digest")
 
 	FeatureExtensions = alpha.MustFeatureKey("extensions")
 )
@@ -56,6 +57,8 @@ type FilterOptions struct {
 	Version string
 	// Source is used to specify the source of the extension to install
 	Source string
+	// Digest is used to pin the digest of the artifact to install, e.g. from an extensions lock file
+	Digest string
 }
 
 // LookupOptions is used to lookup extensions by id or namespace
@@ -73,6 +76,7 @@ type extensionFilterPredicate func(extension *ExtensionMetadata) bool
 type Manager struct {
 	sourceManager *SourceManager
 	sources       []Source
+	sourceConfigs map[string]*SourceConfig
 	installed     map[string]*Extension
 
 	configManager config.UserConfigManager
@@ -365,6 +369,7 @@ func (m *Manager) Install(ctx context.Context, id string, options *FilterOptions
 	hasArtifact := len(selectedVersion.Artifacts) > 0
 	var relativeExtensionPath string
 	var targetPath string
+	var digest string
 
 	// Install the artifacts
 	if hasArtifact {
@@ -388,6 +393,35 @@ func (m *Manager) Install(ctx context.Context, id string, options *FilterOptions
 			return nil, fmt.Errorf("checksum validation failed: %w", err)
 		}
 
+		// Step 6: Verify the signature against the trusted keys of the source
+		sourceConfig, err := m.getSourceConfig(ctx, extension.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := verifySignature(tempFilePath, artifact.Signature, sourceConfig); err != nil {
+			return nil, fmt.Errorf("signature verification failed: %w", err)
+		}
+
+		// Step 7: Validate the digest when the artifact is pinned.
+		// The digest is computed with the algorithm of the pinned digest, or of the checksum published by the registry,
+		// so it can be compared with the digests locked from the registry checksums.
+		digestAlgorithm := "sha256"
+		if algorithm, _, has := strings.Cut(options.Digest, ":"); has {
+			digestAlgorithm = algorithm
+		} else if artifact.Checksum.Algorithm != "" {
+			digestAlgorithm = strings.ToLower(artifact.Checksum.Algorithm)
+		}
+
+		digest, err = computeDigest(tempFilePath, digestAlgorithm)
+		if err != nil {
+			return nil, err
+		}
+
+		if options.Digest != "" && options.Digest != digest {
+			return nil, fmt.Errorf("%w: expected %s, got %s", ErrDigestMismatch, options.Digest, digest)
+		}
+
 		userConfigDir, err := config.GetUserConfigDir()
 		if err != nil {
 			return nil, fmt.Errorf("failed to get user config directory: %w", err)
@@ -398,7 +432,7 @@ func (m *Manager) Install(ctx context.Context, id string, options *FilterOptions
 			return nil, fmt.Errorf("failed to create target directory: %w", err)
 		}
 
-		// Step 6: Copy the artifact to the target directory
+		// Step 8: Copy the artifact to the target directory
 		// Check if artifact is a zip file, if so extract it to the target directory
 		if strings.HasSuffix(tempFilePath, ".zip") {
 			if err := rzip.ExtractToDirectory(tempFilePath, targetDir); err != nil {
@@ -432,7 +466,7 @@ func (m *Manager) Install(ctx context.Context, id string, options *FilterOptions
 		}
 	}
 
-	// Step 7: Update the user config with the installed extension
+	// Step 9: Update the user config with the installed extension
 	extensions, err := m.ListInstalled()
 	if err != nil {
 		return nil, fmt.Errorf("failed to list installed extensions: %w", err)
@@ -448,6 +482,7 @@ func (m *Manager) Install(ctx context.Context, id string, options *FilterOptions
 		Usage:        selectedVersion.Usage,
 		Path:         relativeExtensionPath,
 		Source:       extension.Source,
+		Digest:       digest,
 	}
 
 	if err := m.userConfig.Set(installedConfigKey, extensions); err != nil {
@@ -556,6 +591,16 @@ func findArtifactForCurrentOS(version *ExtensionVersion) (*ExtensionArtifact, er
 
 // downloadFile downloads a file from the given URL and saves it to a temporary directory using the filename from the URL.
 func (m *Manager) downloadArtifact(ctx context.Context, artifactUrl string) (string, error) {
+	// Artifacts of local registries, e.g. published by azd extension publish, refer to local files
+	if !strings.HasPrefix(artifactUrl, "http://") && !strings.HasPrefix(artifactUrl, "https://") {
+		tempFilePath := filepath.Join(os.TempDir(), filepath.Base(artifactUrl))
+		if err := copyFile(artifactUrl, tempFilePath); err != nil {
+			return "", fmt.Errorf("failed to copy local artifact: %w", err)
+		}
+
+		return tempFilePath, nil
+	}
+
 	req, err := azruntime.NewRequest(ctx, http.MethodGet, artifactUrl)
 	if err != nil {
 		return "", err
@@ -597,23 +642,51 @@ func (m *Manager) downloadArtifact(ctx context.Context, artifactUrl string) (str
 }
 
 func (tm *Manager) getSources(ctx context.Context, filter sourceFilterPredicate) ([]Source, error) {
-	if tm.sources != nil {
+	// All the sources are cached, since the filter differs between calls
+	if tm.sources == nil {
+		configs, err := tm.sourceManager.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing extension sources: %w", err)
+		}
+
+		sources, err := tm.createSourcesFromConfig(ctx, configs, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed initializing extension sources: %w", err)
+		}
+
+		tm.sources = sources
+		tm.sourceConfigs = map[string]*SourceConfig{}
+		for _, config := range configs {
+			tm.sourceConfigs[config.Name] = config
+		}
+	}
+
+	if filter == nil {
 		return tm.sources, nil
 	}
 
-	configs, err := tm.sourceManager.List(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed parsing extension sources: %w", err)
+	filteredSources := []Source{}
+	for _, source := range tm.sources {
+		if config, has := tm.sourceConfigs[source.Name()]; has && filter(config) {
+			filteredSources = append(filteredSources, source)
+		}
 	}
 
-	sources, err := tm.createSourcesFromConfig(ctx, configs, filter)
-	if err != nil {
-		return nil, fmt.Errorf("failed initializing extension sources: %w", err)
+	return filteredSources, nil
+}
+
+// getSourceConfig gets the config of the source with the specified name
+func (tm *Manager) getSourceConfig(ctx context.Context, name string) (*SourceConfig, error) {
+	if _, err := tm.getSources(ctx, nil); err != nil {
+		return nil, err
 	}
 
-	tm.sources = sources
+	config, has := tm.sourceConfigs[name]
+	if !has {
+		return nil, fmt.Errorf("%w, '%s'", ErrSourceNotFound, name)
+	}
 
-	return tm.sources, nil
+	return config, nil
 }
 
 func (tm *Manager) createSourcesFromConfig(
@@ -683,6 +756,46 @@ func validateChecksum(filePath string, checksum ExtensionChecksum) error {
 	return nil
 }
 
+// computeDigest computes the digest of the file at the given path with the algorithm, in the format <algorithm>:<hex>
+func computeDigest(filePath string, algorithm string) (string, error) {
+	checksum, err := hashFile(filePath, algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	return algorithm + ":" + checksum, nil
+}
+
+// computeChecksum computes the hex encoded sha256 checksum of the file at the given path
+func computeChecksum(filePath string) (string, error) {
+	return hashFile(filePath, "sha256")
+}
+
+// hashFile computes the hex encoded hash of the file at the given path with the sha256 or sha512 algorithm
+func hashFile(filePath string, algorithm string) (string, error) {
+	var hashAlgo hash.Hash
+	switch algorithm {
+	case "sha256":
+		hashAlgo = sha256.New()
+	case "sha512":
+		hashAlgo = sha512.New()
+	default:
+		return "", fmt.Errorf("unsupported digest algorithm: %s", algorithm)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file for checksum: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(hashAlgo, file); err != nil {
+		return "", fmt.Errorf("failed to compute checksum: %w", err)
+	}
+
+	return hex.EncodeToString(hashAlgo.Sum(nil)), nil
+}
+
 // Helper function to copy a file to the target directory
 func copyFile(src, dst string) error {
 	input, err := os.Open(src)
@@ -5,9 +5,12 @@ package extensions
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/sha256"
 	"crypto/sha512"
+	"encoding/base64"
 	"encoding/hex"
+	"io"
 	"net/http"
 	"os"
 	"strings"
@@ -281,6 +284,213 @@ func Test_Install_With_SemverConstraints(t *testing.T) {
 	}
 }
 
+func Test_Install_SignatureVerification(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	trustedPublicKey, trustedPrivateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, untrustedPrivateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	trustedKeys := []string{base64.StdEncoding.EncodeToString(trustedPublicKey)}
+	trustedSignature := base64.StdEncoding.EncodeToString(ed25519.Sign(trustedPrivateKey, []byte("test data")))
+	untrustedSignature := base64.StdEncoding.EncodeToString(ed25519.Sign(untrustedPrivateKey, []byte("test data")))
+
+	testCases := []struct {
+		Name             string
+		Signature        string
+		TrustedKeys      []string
+		RequireSignature bool
+		ExpectedErr      error
+	}{
+		{
+			Name:        "Signed",
+			Signature:   trustedSignature,
+			TrustedKeys: trustedKeys,
+		},
+		{
+			Name:        "SignedByUntrustedKey",
+			Signature:   untrustedSignature,
+			TrustedKeys: trustedKeys,
+			ExpectedErr: ErrSignatureInvalid,
+		},
+		{
+			Name:        "Unsigned",
+			TrustedKeys: trustedKeys,
+		},
+		{
+			Name:             "UnsignedRefused",
+			TrustedKeys:      trustedKeys,
+			RequireSignature: true,
+			ExpectedErr:      ErrArtifactUnsigned,
+		},
+		{
+			Name:      "NoTrustedKeys",
+			Signature: untrustedSignature,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			mockContext := mocks.NewMockContext(context.Background())
+			createSignedRegistryMocks(mockContext, tc.Signature)
+
+			userConfig := config.NewEmptyConfig()
+			err := userConfig.Set("extension.sources.azd", &SourceConfig{
+				Name:             "azd",
+				Type:             SourceKindUrl,
+				Location:         extensionRegistryUrl,
+				TrustedKeys:      tc.TrustedKeys,
+				RequireSignature: tc.RequireSignature,
+			})
+			require.NoError(t, err)
+			mockContext.ConfigManager.WithConfig(userConfig)
+
+			userConfigManager := config.NewUserConfigManager(mockContext.ConfigManager)
+			sourceManager := NewSourceManager(mockContext.Container, userConfigManager, mockContext.HttpClient)
+			manager, err := NewManager(userConfigManager, sourceManager, mockContext.HttpClient)
+			require.NoError(t, err)
+
+			_, err = manager.Install(*mockContext.Context, "test.extension", nil)
+			if tc.ExpectedErr != nil {
+				require.ErrorIs(t, err, tc.ExpectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_Install_LockedDigest(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	mockContext := mocks.NewMockContext(context.Background())
+	createSignedRegistryMocks(mockContext, "")
+
+	userConfigManager := config.NewUserConfigManager(mockContext.ConfigManager)
+	sourceManager := NewSourceManager(mockContext.Container, userConfigManager, mockContext.HttpClient)
+	manager, err := NewManager(userConfigManager, sourceManager, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("test data"))
+	expectedDigest := "sha256:" + hex.EncodeToString(digest[:])
+
+	t.Run("Matching", func(t *testing.T) {
+		_, err := manager.Install(*mockContext.Context, "test.extension", &FilterOptions{
+			Version: "1.0.0",
+			Digest:  expectedDigest,
+		})
+		require.NoError(t, err)
+
+		installed, err := manager.GetInstalled(LookupOptions{Id: "test.extension"})
+		require.NoError(t, err)
+		require.Equal(t, "1.0.0", installed.Version)
+		require.Equal(t, expectedDigest, installed.Digest)
+
+		require.NoError(t, manager.Uninstall("test.extension"))
+	})
+
+	t.Run("MatchingSha512", func(t *testing.T) {
+		// Digests locked from sha512 registry checksums are verified with sha512
+		digest := sha512.Sum512([]byte("test data"))
+		expectedDigest := "sha512:" + hex.EncodeToString(digest[:])
+
+		_, err := manager.Install(*mockContext.Context, "test.extension", &FilterOptions{
+			Version: "1.0.0",
+			Digest:  expectedDigest,
+		})
+		require.NoError(t, err)
+
+		installed, err := manager.GetInstalled(LookupOptions{Id: "test.extension"})
+		require.NoError(t, err)
+		require.Equal(t, expectedDigest, installed.Digest)
+
+		require.NoError(t, manager.Uninstall("test.extension"))
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		_, err := manager.Install(*mockContext.Context, "test.extension", &FilterOptions{
+			Version: "1.0.0",
+			Digest:  "sha256:0000",
+		})
+		require.ErrorIs(t, err, ErrDigestMismatch)
+
+		_, err = manager.GetInstalled(LookupOptions{Id: "test.extension"})
+		require.ErrorIs(t, err, ErrInstalledExtensionNotFound)
+	})
+}
+
+func Test_Install_FilteredSourcesAreNotCached(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	mockContext := mocks.NewMockContext(context.Background())
+	createRegistryMocks(mockContext)
+
+	userConfig := config.NewEmptyConfig()
+	err := userConfig.Set("extension.sources", map[string]any{
+		"azd":   &SourceConfig{Name: "azd", Type: SourceKindUrl, Location: extensionRegistryUrl},
+		"local": &SourceConfig{Name: "local", Type: SourceKindUrl, Location: extensionRegistryUrl},
+	})
+	require.NoError(t, err)
+	mockContext.ConfigManager.WithConfig(userConfig)
+
+	userConfigManager := config.NewUserConfigManager(mockContext.ConfigManager)
+	sourceManager := NewSourceManager(mockContext.Container, userConfigManager, mockContext.HttpClient)
+	manager, err := NewManager(userConfigManager, sourceManager, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	localExtensions, err := manager.ListFromRegistry(*mockContext.Context, &ListOptions{Source: "local"})
+	require.NoError(t, err)
+	require.Len(t, localExtensions, 1)
+
+	allExtensions, err := manager.ListFromRegistry(*mockContext.Context, nil)
+	require.NoError(t, err)
+	require.Len(t, allExtensions, 2)
+}
+
+func createSignedRegistryMocks(mockContext *mocks.MockContext, signature string) {
+	artifacts := map[string]ExtensionArtifact{}
+	for platform, artifact := range sampleArtifacts {
+		artifact.Signature = signature
+		artifacts[platform] = artifact
+	}
+
+	registry := Registry{
+		Extensions: []*ExtensionMetadata{
+			{
+				Id:          "test.extension",
+				Namespace:   "test",
+				DisplayName: "Test Extension",
+				Versions: []ExtensionVersion{
+					{
+						Version:   "1.0.0",
+						Artifacts: artifacts,
+					},
+				},
+			},
+		},
+	}
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.URL.String() == extensionRegistryUrl
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, registry)
+	})
+
+	// Return the raw artifact, since the signature is verified against the exact bytes
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return strings.HasPrefix(request.URL.String(), "https://aka.ms/azd/extensions/registry/test.extension")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return &http.Response{
+			Request:    request,
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("test data")),
+		}, nil
+	})
+}
+
 func createRegistryMocks(mockContext *mocks.MockContext) {
 	// Create a mock source
 	mockContext.HttpClient.When(func(request *http.Request) bool {
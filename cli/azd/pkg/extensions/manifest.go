@@ -0,0 +1,70 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFileName is the name of the manifest of an extension project
+const ManifestFileName = "extension.yaml"
+
+var ErrManifestNotFound = errors.New("extension manifest not found")
+
+// ExtensionManifest represents the extension.yaml manifest of an extension project
+type ExtensionManifest struct {
+	Id           string                    `yaml:"id"`
+	Namespace    string                    `yaml:"namespace,omitempty"`
+	EntryPoint   string                    `yaml:"entryPoint,omitempty"`
+	Version      string                    `yaml:"version"`
+	Capabilities []CapabilityType          `yaml:"capabilities,omitempty"`
+	DisplayName  string                    `yaml:"displayName"`
+	Description  string                    `yaml:"description"`
+	Usage        string                    `yaml:"usage,omitempty"`
+	Examples     []ExtensionExample        `yaml:"examples,omitempty"`
+	Tags         []string                  `yaml:"tags,omitempty"`
+	Dependencies []ExtensionDependency     `yaml:"dependencies,omitempty"`
+	Platforms    map[string]map[string]any `yaml:"platforms,omitempty"`
+}
+
+// LoadManifest loads & validates the manifest of the extension project in the specified directory
+func LoadManifest(extensionDir string) (*ExtensionManifest, error) {
+	manifestPath := filepath.Join(extensionDir, ManifestFileName)
+
+	data, err := os.ReadFile(manifestPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrManifestNotFound, manifestPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read extension manifest: %w", err)
+	}
+
+	var manifest *ExtensionManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse extension manifest %s: %w", manifestPath, err)
+	}
+
+	if manifest == nil || manifest.Id == "" {
+		return nil, fmt.Errorf("id is required in the extension manifest %s", manifestPath)
+	}
+
+	if _, err := semver.StrictNewVersion(manifest.Version); err != nil {
+		return nil, fmt.Errorf(
+			"version '%s' in the extension manifest %s isn't a semantic version: %w", manifest.Version, manifestPath, err)
+	}
+
+	return manifest, nil
+}
+
+// safeId gets the id of the extension usable in file names
+func (m *ExtensionManifest) safeId() string {
+	return strings.ReplaceAll(m.Id, ".", "-")
+}
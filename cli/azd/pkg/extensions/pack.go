@@ -0,0 +1,204 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/rzip"
+)
+
+// DefaultPackPlatforms are the platforms (os/arch) extensions are packed for by default
+var DefaultPackPlatforms = []string{
+	"windows/amd64",
+	"windows/arm64",
+	"darwin/amd64",
+	"darwin/arm64",
+	"linux/amd64",
+	"linux/arm64",
+}
+
+// PackOptions are the options to pack an extension
+type PackOptions struct {
+	// OutputDir is the directory the packages are written to
+	OutputDir string
+	// Platforms are the platforms (os/arch) to pack the extension for
+	Platforms []string
+}
+
+// PackedArtifact is the package of an extension for a platform
+type PackedArtifact struct {
+	// Platform is the platform (os/arch) of the package
+	Platform string
+	// Path is the path of the zip archive of the package
+	Path string
+	// EntryPoint is the name of the executable of the extension in the package
+	EntryPoint string
+	// Checksum is the sha256 checksum of the package
+	Checksum string
+}
+
+// Packer cross-compiles Go extension projects and packs them per platform
+type Packer struct {
+	commandRunner exec.CommandRunner
+}
+
+func NewPacker(commandRunner exec.CommandRunner) *Packer {
+	return &Packer{
+		commandRunner: commandRunner,
+	}
+}
+
+// Pack cross-compiles the Go extension project in the extension directory for the platforms of the options, and zips
+// the executable of each platform together with the manifest in the <output>/<id>/<version> directory.
+func (p *Packer) Pack(
+	ctx context.Context,
+	extensionDir string,
+	manifest *ExtensionManifest,
+	options PackOptions,
+) ([]*PackedArtifact, error) {
+	platforms := options.Platforms
+	if len(platforms) == 0 {
+		platforms = DefaultPackPlatforms
+	}
+
+	moduleResult, err := p.commandRunner.Run(ctx, exec.NewRunArgs("go", "list", "-m").WithCwd(extensionDir))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the Go module of the extension: %w", err)
+	}
+
+	ldflags := fmt.Sprintf("-X '%s/internal/cmd.Version=%s'", strings.TrimSpace(moduleResult.Stdout), manifest.Version)
+
+	packageDir := packageDir(options.OutputDir, manifest)
+	if err := os.MkdirAll(packageDir, osutil.PermissionDirectory); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	stagingDir, err := os.MkdirTemp("", "azd-extension-pack")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	manifestPath := filepath.Join(extensionDir, ManifestFileName)
+	artifacts := []*PackedArtifact{}
+
+	for _, platform := range platforms {
+		goos, goarch, ok := strings.Cut(platform, "/")
+		if !ok || goos == "" || goarch == "" {
+			return nil, fmt.Errorf("invalid platform '%s', platforms must be in the format os/arch", platform)
+		}
+
+		artifactName := fmt.Sprintf("%s-%s-%s", manifest.safeId(), goos, goarch)
+		entryPoint := artifactName
+		if goos == "windows" {
+			entryPoint += ".exe"
+		}
+
+		platformDir := filepath.Join(stagingDir, artifactName)
+		if err := os.MkdirAll(platformDir, osutil.PermissionDirectory); err != nil {
+			return nil, fmt.Errorf("failed to create staging directory: %w", err)
+		}
+
+		runArgs := exec.NewRunArgs(
+			"go", "build", "-ldflags", ldflags, "-o", filepath.Join(platformDir, entryPoint), ".",
+		).
+			WithCwd(extensionDir).
+			WithEnv([]string{"GOOS=" + goos, "GOARCH=" + goarch, "CGO_ENABLED=0"})
+
+		if _, err := p.commandRunner.Run(ctx, runArgs); err != nil {
+			return nil, fmt.Errorf("failed to build the extension for %s: %w", platform, err)
+		}
+
+		if err := copyFile(manifestPath, filepath.Join(platformDir, ManifestFileName)); err != nil {
+			return nil, err
+		}
+
+		artifactPath := filepath.Join(packageDir, artifactName+".zip")
+		if err := zipDirectory(platformDir, artifactPath); err != nil {
+			return nil, fmt.Errorf("failed to create archive for %s: %w", platform, err)
+		}
+
+		checksum, err := computeChecksum(artifactPath)
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts = append(artifacts, &PackedArtifact{
+			Platform:   platform,
+			Path:       artifactPath,
+			EntryPoint: entryPoint,
+			Checksum:   checksum,
+		})
+	}
+
+	return artifacts, nil
+}
+
+// ListPackedArtifacts lists the packages of the extension version in the output directory of Pack
+func ListPackedArtifacts(outputDir string, manifest *ExtensionManifest) ([]*PackedArtifact, error) {
+	packageDir := packageDir(outputDir, manifest)
+
+	entries, err := os.ReadDir(packageDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read packages of %s version %s: %w", manifest.Id, manifest.Version, err)
+	}
+
+	artifacts := []*PackedArtifact{}
+	for _, entry := range entries {
+		name, isZip := strings.CutSuffix(entry.Name(), ".zip")
+		if entry.IsDir() || !isZip {
+			continue
+		}
+
+		// Packages are named <id>-<os>-<arch>.zip
+		platform, has := strings.CutPrefix(name, manifest.safeId()+"-")
+		goos, goarch, ok := strings.Cut(platform, "-")
+		if !has || !ok {
+			return nil, fmt.Errorf("failed to infer the platform of package %s", entry.Name())
+		}
+
+		entryPoint := name
+		if goos == "windows" {
+			entryPoint += ".exe"
+		}
+
+		artifactPath := filepath.Join(packageDir, entry.Name())
+		checksum, err := computeChecksum(artifactPath)
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts = append(artifacts, &PackedArtifact{
+			Platform:   fmt.Sprintf("%s/%s", goos, goarch),
+			Path:       artifactPath,
+			EntryPoint: entryPoint,
+			Checksum:   checksum,
+		})
+	}
+
+	return artifacts, nil
+}
+
+// packageDir gets the directory of the packages of the extension version in the output directory
+func packageDir(outputDir string, manifest *ExtensionManifest) string {
+	return filepath.Join(outputDir, manifest.Id, manifest.Version)
+}
+
+// zipDirectory creates a zip archive at the target path with the contents of the source directory
+func zipDirectory(sourceDir string, targetPath string) error {
+	zipFile, err := os.Create(targetPath)
+	if err != nil {
+		return err
+	}
+	defer zipFile.Close()
+
+	return rzip.CreateFromDirectory(sourceDir, zipFile)
+}
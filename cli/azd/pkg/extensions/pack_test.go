@@ -0,0 +1,90 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Packer_Pack(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+
+	extensionDir := t.TempDir()
+	require.NoError(t, Scaffold(extensionDir, ScaffoldOptions{Id: "contoso.azd.rust"}))
+
+	manifest, err := LoadManifest(extensionDir)
+	require.NoError(t, err)
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return command == "go list -m"
+	}).Respond(exec.NewRunResult(0, "contoso.azd.rust\n", ""))
+
+	builds := map[string][]string{}
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.HasPrefix(command, "go build")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		outputPath := args.Args[slices.Index(args.Args, "-o")+1]
+		builds[filepath.Base(outputPath)] = args.Args
+
+		require.Equal(t, extensionDir, args.Cwd)
+		require.Contains(t, args.Env, "CGO_ENABLED=0")
+
+		return exec.NewRunResult(0, "", ""), os.WriteFile(outputPath, []byte("binary"), 0600)
+	})
+
+	outputDir := t.TempDir()
+	packer := NewPacker(mockContext.CommandRunner)
+	artifacts, err := packer.Pack(*mockContext.Context, extensionDir, manifest, PackOptions{
+		OutputDir: outputDir,
+		Platforms: []string{"linux/amd64", "windows/arm64"},
+	})
+	require.NoError(t, err)
+	require.Len(t, artifacts, 2)
+
+	require.Equal(t, "linux/amd64", artifacts[0].Platform)
+	require.Equal(t, "contoso-azd-rust-linux-amd64", artifacts[0].EntryPoint)
+	require.Equal(t, filepath.Join(outputDir, "contoso.azd.rust", "0.0.1", "contoso-azd-rust-linux-amd64.zip"),
+		artifacts[0].Path)
+	require.Equal(t, "windows/arm64", artifacts[1].Platform)
+	require.Equal(t, "contoso-azd-rust-windows-arm64.exe", artifacts[1].EntryPoint)
+
+	require.Contains(t, builds["contoso-azd-rust-linux-amd64"],
+		"-X 'contoso.azd.rust/internal/cmd.Version=0.0.1'")
+	require.Contains(t, builds, "contoso-azd-rust-windows-arm64.exe")
+
+	for _, artifact := range artifacts {
+		checksum, err := computeChecksum(artifact.Path)
+		require.NoError(t, err)
+		require.Equal(t, checksum, artifact.Checksum)
+	}
+
+	// The packages are listed from the output directory for publishing
+	listed, err := ListPackedArtifacts(outputDir, manifest)
+	require.NoError(t, err)
+	require.Equal(t, artifacts, listed)
+}
+
+func Test_Packer_Pack_InvalidPlatform(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return command == "go list -m"
+	}).Respond(exec.NewRunResult(0, "contoso.azd.rust\n", ""))
+
+	packer := NewPacker(mockContext.CommandRunner)
+	_, err := packer.Pack(*mockContext.Context, t.TempDir(), &ExtensionManifest{Id: "contoso.azd.rust", Version: "1.0.0"},
+		PackOptions{
+			OutputDir: t.TempDir(),
+			Platforms: []string{"linux"},
+		})
+	require.ErrorContains(t, err, "invalid platform 'linux'")
+}
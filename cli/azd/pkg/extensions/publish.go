@@ -0,0 +1,153 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+)
+
+// PublishOptions are the options to publish an extension version to a registry
+type PublishOptions struct {
+	// BaseUrl is the URL the packages are uploaded to, as <base-url>/<id>/<version>/<package>.
+	// When empty, the registry refers to the local paths of the packages.
+	BaseUrl string
+}
+
+// Publish adds the extension version with its packages to the registry.json file at the registry path, or updates the
+// version when the registry already contains it. The registry file is created when it doesn't exist.
+// Returns true when an existing version was updated.
+func Publish(
+	registryPath string,
+	manifest *ExtensionManifest,
+	artifacts []*PackedArtifact,
+	options PublishOptions,
+) (bool, error) {
+	registry := &Registry{}
+
+	data, err := os.ReadFile(registryPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("failed to read registry file: %w", err)
+	}
+
+	if err == nil {
+		if err := json.Unmarshal(data, registry); err != nil {
+			return false, fmt.Errorf("failed to parse registry file %s: %w", registryPath, err)
+		}
+	}
+
+	extensionArtifacts := map[string]ExtensionArtifact{}
+	for _, artifact := range artifacts {
+		artifactUrl, err := artifactUrl(artifact, manifest, options.BaseUrl)
+		if err != nil {
+			return false, err
+		}
+
+		// Platform specific metadata is merged from the least to the most specific platform
+		goos, goarch, _ := strings.Cut(artifact.Platform, "/")
+		metadata := map[string]any{
+			"entryPoint": artifact.EntryPoint,
+		}
+		maps.Copy(metadata, manifest.Platforms[goos])
+		maps.Copy(metadata, manifest.Platforms[goarch])
+		maps.Copy(metadata, manifest.Platforms[artifact.Platform])
+
+		extensionArtifacts[artifact.Platform] = ExtensionArtifact{
+			URL: artifactUrl,
+			Checksum: ExtensionChecksum{
+				Algorithm: "sha256",
+				Value:     artifact.Checksum,
+			},
+			AdditionalMetadata: metadata,
+		}
+	}
+
+	var extension *ExtensionMetadata
+	for _, existing := range registry.Extensions {
+		if existing.Id == manifest.Id {
+			extension = existing
+			break
+		}
+	}
+
+	if extension == nil {
+		extension = &ExtensionMetadata{
+			Versions: []ExtensionVersion{},
+		}
+		registry.Extensions = append(registry.Extensions, extension)
+	}
+
+	extension.Id = manifest.Id
+	extension.Namespace = manifest.Namespace
+	extension.DisplayName = manifest.DisplayName
+	extension.Description = manifest.Description
+	extension.Tags = manifest.Tags
+
+	version := ExtensionVersion{
+		Version:      manifest.Version,
+		Capabilities: manifest.Capabilities,
+		EntryPoint:   manifest.EntryPoint,
+		Usage:        manifest.Usage,
+		Examples:     manifest.Examples,
+		Dependencies: manifest.Dependencies,
+		Artifacts:    extensionArtifacts,
+	}
+
+	updated := false
+	for i, existing := range extension.Versions {
+		if existing.Version == manifest.Version {
+			extension.Versions[i] = version
+			updated = true
+			break
+		}
+	}
+
+	if !updated {
+		extension.Versions = append(extension.Versions, version)
+	}
+
+	data, err = json.MarshalIndent(registry, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal registry: %w", err)
+	}
+
+	if err := os.WriteFile(registryPath, append(data, '\n'), osutil.PermissionFile); err != nil {
+		return false, fmt.Errorf("failed to write registry file: %w", err)
+	}
+
+	return updated, nil
+}
+
+// FileSourcePath gets the path of the registry.json file of a file extension source
+func FileSourcePath(source *SourceConfig) (string, error) {
+	if source.Type != SourceKindFile {
+		return "", fmt.Errorf(
+			"extension source '%s' is a '%s' source, only 'file' sources can be published to", source.Name, source.Type)
+	}
+
+	return getAbsolutePath(source.Location)
+}
+
+// artifactUrl gets the URL of the package in the registry
+func artifactUrl(artifact *PackedArtifact, manifest *ExtensionManifest, baseUrl string) (string, error) {
+	if baseUrl == "" {
+		absolutePath, err := filepath.Abs(artifact.Path)
+		if err != nil {
+			return "", fmt.Errorf("failed to get absolute path of package: %w", err)
+		}
+
+		return absolutePath, nil
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/%s/%s", strings.TrimSuffix(baseUrl, "/"), manifest.Id, manifest.Version, filepath.Base(artifact.Path),
+	), nil
+}
@@ -0,0 +1,189 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Publish(t *testing.T) {
+	registryPath := filepath.Join(t.TempDir(), "registry.json")
+
+	manifest := &ExtensionManifest{
+		Id:           "contoso.azd.rust",
+		Namespace:    "rust",
+		DisplayName:  "Rust",
+		Description:  "Rust support for azd",
+		Version:      "1.0.0",
+		Capabilities: []CapabilityType{FrameworkServiceProviderCapability},
+		Platforms: map[string]map[string]any{
+			"windows":       {"shell": "pwsh"},
+			"windows/arm64": {"shell": "cmd"},
+		},
+	}
+
+	artifacts := []*PackedArtifact{
+		{
+			Platform:   "linux/amd64",
+			Path:       "dist/contoso.azd.rust/1.0.0/contoso-azd-rust-linux-amd64.zip",
+			EntryPoint: "contoso-azd-rust-linux-amd64",
+			Checksum:   "abc",
+		},
+		{
+			Platform:   "windows/amd64",
+			Path:       "dist/contoso.azd.rust/1.0.0/contoso-azd-rust-windows-amd64.zip",
+			EntryPoint: "contoso-azd-rust-windows-amd64.exe",
+			Checksum:   "def",
+		},
+		{
+			Platform:   "windows/arm64",
+			Path:       "dist/contoso.azd.rust/1.0.0/contoso-azd-rust-windows-arm64.zip",
+			EntryPoint: "contoso-azd-rust-windows-arm64.exe",
+			Checksum:   "ghi",
+		},
+	}
+
+	loadRegistry := func(t *testing.T) *Registry {
+		data, err := os.ReadFile(registryPath)
+		require.NoError(t, err)
+
+		var registry *Registry
+		require.NoError(t, json.Unmarshal(data, &registry))
+
+		return registry
+	}
+
+	t.Run("NewRegistry", func(t *testing.T) {
+		updated, err := Publish(registryPath, manifest, artifacts, PublishOptions{
+			BaseUrl: "https://contoso.com/azd/extensions/",
+		})
+		require.NoError(t, err)
+		require.False(t, updated)
+
+		registry := loadRegistry(t)
+		require.Len(t, registry.Extensions, 1)
+
+		extension := registry.Extensions[0]
+		require.Equal(t, "contoso.azd.rust", extension.Id)
+		require.Equal(t, "rust", extension.Namespace)
+		require.Len(t, extension.Versions, 1)
+		require.Equal(t, []CapabilityType{FrameworkServiceProviderCapability}, extension.Versions[0].Capabilities)
+
+		artifacts := extension.Versions[0].Artifacts
+		require.Len(t, artifacts, 3)
+		require.Equal(t,
+			"https://contoso.com/azd/extensions/contoso.azd.rust/1.0.0/contoso-azd-rust-linux-amd64.zip",
+			artifacts["linux/amd64"].URL)
+		require.Equal(t, ExtensionChecksum{Algorithm: "sha256", Value: "abc"}, artifacts["linux/amd64"].Checksum)
+		require.Equal(t, map[string]any{
+			"entryPoint": "contoso-azd-rust-linux-amd64",
+		}, artifacts["linux/amd64"].AdditionalMetadata)
+		require.Equal(t, map[string]any{
+			"entryPoint": "contoso-azd-rust-windows-amd64.exe",
+			"shell":      "pwsh",
+		}, artifacts["windows/amd64"].AdditionalMetadata)
+		require.Equal(t, "cmd", artifacts["windows/arm64"].AdditionalMetadata["shell"])
+	})
+
+	t.Run("UpdateVersion", func(t *testing.T) {
+		updated, err := Publish(registryPath, manifest, artifacts[:1], PublishOptions{})
+		require.NoError(t, err)
+		require.True(t, updated)
+
+		registry := loadRegistry(t)
+		require.Len(t, registry.Extensions[0].Versions, 1)
+
+		// Without a base URL the registry refers to the local packages
+		artifactPath, err := filepath.Abs(artifacts[0].Path)
+		require.NoError(t, err)
+		require.Len(t, registry.Extensions[0].Versions[0].Artifacts, 1)
+		require.Equal(t, artifactPath, registry.Extensions[0].Versions[0].Artifacts["linux/amd64"].URL)
+	})
+
+	t.Run("NewVersion", func(t *testing.T) {
+		newManifest := *manifest
+		newManifest.Version = "1.1.0"
+
+		updated, err := Publish(registryPath, &newManifest, artifacts, PublishOptions{})
+		require.NoError(t, err)
+		require.False(t, updated)
+
+		registry := loadRegistry(t)
+		require.Len(t, registry.Extensions, 1)
+		require.Len(t, registry.Extensions[0].Versions, 2)
+		require.Equal(t, "1.1.0", registry.Extensions[0].Versions[1].Version)
+	})
+}
+
+func Test_Publish_InstallFromFileSource(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	manifest := &ExtensionManifest{Id: "contoso.azd.rust", Version: "1.0.0", DisplayName: "Rust"}
+	platform := currentPlatform()
+
+	// Package a fake executable for the current platform
+	packageDir := t.TempDir()
+	entryPoint := "contoso-azd-rust"
+	require.NoError(t, os.WriteFile(filepath.Join(packageDir, entryPoint), []byte("binary"), 0600))
+
+	artifactPath := filepath.Join(t.TempDir(), "contoso-azd-rust.zip")
+	require.NoError(t, zipDirectory(packageDir, artifactPath))
+
+	checksum, err := computeChecksum(artifactPath)
+	require.NoError(t, err)
+
+	registryPath := filepath.Join(t.TempDir(), "registry.json")
+	_, err = Publish(registryPath, manifest, []*PackedArtifact{
+		{Platform: platform, Path: artifactPath, EntryPoint: entryPoint, Checksum: checksum},
+	}, PublishOptions{})
+	require.NoError(t, err)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	userConfig := config.NewEmptyConfig()
+	err = userConfig.Set("extension.sources.local", &SourceConfig{
+		Name:     "local",
+		Type:     SourceKindFile,
+		Location: registryPath,
+	})
+	require.NoError(t, err)
+	mockContext.ConfigManager.WithConfig(userConfig)
+
+	userConfigManager := config.NewUserConfigManager(mockContext.ConfigManager)
+	sourceManager := NewSourceManager(mockContext.Container, userConfigManager, mockContext.HttpClient)
+	manager, err := NewManager(userConfigManager, sourceManager, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	extensionVersion, err := manager.Install(*mockContext.Context, "contoso.azd.rust", nil)
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", extensionVersion.Version)
+
+	installed, err := manager.GetInstalled(LookupOptions{Id: "contoso.azd.rust"})
+	require.NoError(t, err)
+	require.Equal(t, "local", installed.Source)
+	require.Equal(t, "sha256:"+checksum, installed.Digest)
+
+	userConfigDir, err := config.GetUserConfigDir()
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(userConfigDir, installed.Path))
+}
+
+func Test_FileSourcePath(t *testing.T) {
+	_, err := FileSourcePath(&SourceConfig{Name: "azd", Type: SourceKindUrl, Location: "https://aka.ms/azd"})
+	require.ErrorContains(t, err, "only 'file' sources can be published to")
+
+	registryPath := filepath.Join(t.TempDir(), "registry.json")
+	require.NoError(t, os.WriteFile(registryPath, []byte(`{"extensions":[]}`), 0600))
+
+	path, err := FileSourcePath(&SourceConfig{Name: "local", Type: SourceKindFile, Location: registryPath})
+	require.NoError(t, err)
+	require.Equal(t, registryPath, path)
+}
@@ -24,8 +24,23 @@ const (
 	CustomCommandCapability CapabilityType = "custom-commands"
 	// Lifecycle events enable extensions to subscribe to AZD project & service lifecycle events
 	LifecycleEventsCapability CapabilityType = "lifecycle-events"
+	// Service target providers enable extensions to provide service targets for hosts that azd doesn't support
+	ServiceTargetProviderCapability CapabilityType = "service-target-provider"
+	// Framework service providers enable extensions to provide framework services for languages that azd doesn't support
+	FrameworkServiceProviderCapability CapabilityType = "framework-service-provider"
+	// Provisioning providers enable extensions to provide infrastructure provisioning providers that azd doesn't support
+	ProvisioningProviderCapability CapabilityType = "provisioning-provider"
 )
 
+// ListenCapabilities are the capabilities of extensions that listen for the requests of azd.
+// Extensions with these capabilities are started with the "listen" command, and must signal readiness for each of them.
+var ListenCapabilities = []CapabilityType{
+	LifecycleEventsCapability,
+	ServiceTargetProviderCapability,
+	FrameworkServiceProviderCapability,
+	ProvisioningProviderCapability,
+}
+
 // Extension represents an extension in the registry
 type ExtensionMetadata struct {
 	// Id is a unique identifier for the extension
@@ -82,6 +97,9 @@ type ExtensionArtifact struct {
 	URL string `json:"url"`
 	// Checksum is the checksum of the artifact
 	Checksum ExtensionChecksum `json:"checksum"`
+	// Signature is the base64 encoded detached ed25519 signature of the artifact.
+	// The signature is verified against the trusted keys of the extension source.
+	Signature string `json:"signature,omitempty"`
 	// AdditionalMetadata is a map of additional metadata for the artifact
 	AdditionalMetadata map[string]any `json:"-"`
 }
@@ -137,6 +155,7 @@ func (c *ExtensionArtifact) UnmarshalJSON(data []byte) error {
 	// Remove known fields from the temp map
 	delete(temp, "url")
 	delete(temp, "checksum")
+	delete(temp, "signature")
 
 	// Convert the remaining fields to Extras
 	c.AdditionalMetadata = map[string]any{}
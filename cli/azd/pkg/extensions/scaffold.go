@@ -0,0 +1,197 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/resources"
+)
+
+const extensionTemplatesRoot = "extension"
+
+// ScaffoldOptions are the options of a new extension project
+type ScaffoldOptions struct {
+	// Id is the unique identifier of the extension, also used as the Go module path
+	Id string
+	// Namespace is the command group of the extension commands
+	Namespace string
+	// DisplayName is the name of the extension
+	DisplayName string
+	// Description is a brief description of the extension
+	Description string
+	// Capabilities are the capabilities the extension provides
+	Capabilities []CapabilityType
+}
+
+// scaffoldData is the data of the templates of a new extension project
+type scaffoldData struct {
+	ScaffoldOptions
+	Module                   string
+	Requirements             []moduleRequirement
+	Listen                   bool
+	LifecycleEvents          bool
+	ServiceTargetProvider    bool
+	FrameworkServiceProvider bool
+	ProvisioningProvider     bool
+}
+
+// moduleRequirement is a module required by the go.mod file of a new extension project
+type moduleRequirement struct {
+	Path    string
+	Version string
+}
+
+// azdModulePath is the path of the module of the azd extension SDK
+const azdModulePath = "github.com/azure/azure-dev"
+
+// sdkModulePaths are the modules imported by the code of a new extension project
+var sdkModulePaths = []string{azdModulePath, "github.com/spf13/cobra"}
+
+// capabilityTemplates are the templates that are only scaffolded for the extensions with the capability
+var capabilityTemplates = map[string]CapabilityType{
+	"internal/cmd/service_target.go":        ServiceTargetProviderCapability,
+	"internal/cmd/framework_service.go":     FrameworkServiceProviderCapability,
+	"internal/cmd/provisioning_provider.go": ProvisioningProviderCapability,
+}
+
+// Scaffold creates a new Go extension project using the azd extension SDK in the target directory.
+// The target directory must not contain an extension project already.
+func Scaffold(targetDir string, options ScaffoldOptions) error {
+	if options.Id == "" {
+		return fmt.Errorf("extension id is required")
+	}
+
+	if _, err := os.Stat(filepath.Join(targetDir, ManifestFileName)); err == nil {
+		return fmt.Errorf("an extension project already exists in %s", targetDir)
+	}
+
+	if options.Namespace == "" {
+		options.Namespace = options.Id[strings.LastIndex(options.Id, ".")+1:]
+	}
+
+	if options.DisplayName == "" {
+		options.DisplayName = options.Id
+	}
+
+	if len(options.Capabilities) == 0 {
+		options.Capabilities = []CapabilityType{CustomCommandCapability}
+	}
+
+	data := scaffoldData{
+		ScaffoldOptions: options,
+		Module:          options.Id,
+		// Extensions that provide more than custom commands are started by azd with the listen command
+		Listen: slices.ContainsFunc(options.Capabilities, func(capability CapabilityType) bool {
+			return capability != CustomCommandCapability
+		}),
+		LifecycleEvents:          slices.Contains(options.Capabilities, LifecycleEventsCapability),
+		ServiceTargetProvider:    slices.Contains(options.Capabilities, ServiceTargetProviderCapability),
+		FrameworkServiceProvider: slices.Contains(options.Capabilities, FrameworkServiceProviderCapability),
+		ProvisioningProvider:     slices.Contains(options.Capabilities, ProvisioningProviderCapability),
+	}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		data.Requirements = sdkRequirements(buildInfo)
+	}
+
+	return fs.WalkDir(resources.ExtensionTemplates, extensionTemplatesRoot,
+		func(name string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+
+			relativePath := strings.TrimSuffix(strings.TrimPrefix(name, extensionTemplatesRoot+"/"), ".tmpl")
+			if relativePath == "internal/cmd/listen.go" && !data.Listen {
+				return nil
+			}
+
+			if capability, has := capabilityTemplates[relativePath]; has && !slices.Contains(options.Capabilities, capability) {
+				return nil
+			}
+
+			tmpl, err := template.ParseFS(resources.ExtensionTemplates, name)
+			if err != nil {
+				return fmt.Errorf("failed to parse template %s: %w", name, err)
+			}
+
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, data); err != nil {
+				return fmt.Errorf("failed to execute template %s: %w", name, err)
+			}
+
+			targetPath := filepath.Join(targetDir, filepath.FromSlash(relativePath))
+			if err := os.MkdirAll(filepath.Dir(targetPath), osutil.PermissionDirectory); err != nil {
+				return fmt.Errorf("failed to create directory for %s: %w", relativePath, err)
+			}
+
+			if err := os.WriteFile(targetPath, buf.Bytes(), osutil.PermissionFile); err != nil {
+				return fmt.Errorf("failed to write %s: %w", relativePath, err)
+			}
+
+			return nil
+		})
+}
+
+// sdkRequirements gets the requirements of the modules of the azd extension SDK, pinned to the versions azd is built
+// with, so that the extension SDK matches the protocol of azd. The modules whose version is unknown, e.g. when azd is
+// built without version control information, are resolved by go mod tidy.
+func sdkRequirements(buildInfo *debug.BuildInfo) []moduleRequirement {
+	versions := map[string]string{}
+	if buildInfo.Main.Path == azdModulePath {
+		versions[azdModulePath] = mainModuleVersion(buildInfo)
+	}
+
+	for _, dependency := range buildInfo.Deps {
+		if dependency.Replace == nil {
+			versions[dependency.Path] = dependency.Version
+		}
+	}
+
+	requirements := []moduleRequirement{}
+	for _, path := range sdkModulePaths {
+		if version := versions[path]; version != "" {
+			requirements = append(requirements, moduleRequirement{Path: path, Version: version})
+		}
+	}
+
+	return requirements
+}
+
+// mainModuleVersion gets the version of the main module azd is built from: the version of the module when azd is
+// installed from a module version, or else the pseudo-version of the commit azd is built from.
+func mainModuleVersion(buildInfo *debug.BuildInfo) string {
+	// Builds of a modified working tree are versioned with +dirty build metadata, which modules can't require
+	version, _, _ := strings.Cut(buildInfo.Main.Version, "+")
+	if strings.HasPrefix(version, "v") {
+		return version
+	}
+
+	var revision, commitTime string
+	for _, setting := range buildInfo.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.time":
+			commitTime = setting.Value
+		}
+	}
+
+	commitDate, err := time.Parse(time.RFC3339, commitTime)
+	if len(revision) < 12 || err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("v0.0.0-%s-%s", commitDate.UTC().Format("20060102150405"), revision[:12])
+}
@@ -0,0 +1,184 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Scaffold(t *testing.T) {
+	t.Run("CustomCommands", func(t *testing.T) {
+		extensionDir := t.TempDir()
+
+		err := Scaffold(extensionDir, ScaffoldOptions{
+			Id:          "contoso.azd.rust",
+			Description: "Rust support for azd",
+		})
+		require.NoError(t, err)
+
+		manifest, err := LoadManifest(extensionDir)
+		require.NoError(t, err)
+		require.Equal(t, "contoso.azd.rust", manifest.Id)
+		require.Equal(t, "rust", manifest.Namespace)
+		require.Equal(t, "contoso.azd.rust", manifest.DisplayName)
+		require.Equal(t, "0.0.1", manifest.Version)
+		require.Equal(t, []CapabilityType{CustomCommandCapability}, manifest.Capabilities)
+
+		goMod, err := os.ReadFile(filepath.Join(extensionDir, "go.mod"))
+		require.NoError(t, err)
+		require.Contains(t, string(goMod), "module contoso.azd.rust")
+
+		requireGoFiles(t, extensionDir, "main.go", "internal/cmd/root.go", "internal/cmd/context.go")
+
+		// Extensions that only provide custom commands aren't started with the listen command
+		require.NoFileExists(t, filepath.Join(extensionDir, "internal", "cmd", "listen.go"))
+	})
+
+	t.Run("LifecycleEvents", func(t *testing.T) {
+		extensionDir := t.TempDir()
+
+		err := Scaffold(extensionDir, ScaffoldOptions{
+			Id:           "contoso.azd.hooks",
+			Namespace:    "contoso-hooks",
+			DisplayName:  "Contoso Hooks",
+			Capabilities: []CapabilityType{CustomCommandCapability, LifecycleEventsCapability},
+		})
+		require.NoError(t, err)
+
+		manifest, err := LoadManifest(extensionDir)
+		require.NoError(t, err)
+		require.Equal(t, "contoso-hooks", manifest.Namespace)
+		require.Equal(t, []CapabilityType{CustomCommandCapability, LifecycleEventsCapability}, manifest.Capabilities)
+
+		requireGoFiles(t, extensionDir, "main.go", "internal/cmd/root.go", "internal/cmd/listen.go")
+	})
+
+	t.Run("ProviderOnly", func(t *testing.T) {
+		extensionDir := t.TempDir()
+
+		err := Scaffold(extensionDir, ScaffoldOptions{
+			Id:           "contoso.azd.opentofu",
+			Capabilities: []CapabilityType{ProvisioningProviderCapability},
+		})
+		require.NoError(t, err)
+
+		requireGoFiles(t, extensionDir, "internal/cmd/root.go", "internal/cmd/listen.go", "internal/cmd/provisioning_provider.go")
+
+		// Only the providers of the capabilities of the extension are scaffolded
+		require.NoFileExists(t, filepath.Join(extensionDir, "internal", "cmd", "service_target.go"))
+		require.NoFileExists(t, filepath.Join(extensionDir, "internal", "cmd", "framework_service.go"))
+
+		listen, err := os.ReadFile(filepath.Join(extensionDir, "internal", "cmd", "listen.go"))
+		require.NoError(t, err)
+		require.Contains(t, string(listen), `provisioningManager.Register(ctx, "opentofu", &provisioningProvider{})`)
+		require.Contains(t, string(listen), "receivers = append(receivers, provisioningManager.Receive)")
+		require.NotContains(t, string(listen), "serviceTargetManager")
+	})
+
+	t.Run("AllProviders", func(t *testing.T) {
+		extensionDir := t.TempDir()
+
+		err := Scaffold(extensionDir, ScaffoldOptions{
+			Id: "contoso.azd.batch",
+			Capabilities: []CapabilityType{
+				ServiceTargetProviderCapability,
+				FrameworkServiceProviderCapability,
+				ProvisioningProviderCapability,
+			},
+		})
+		require.NoError(t, err)
+
+		requireGoFiles(t, extensionDir,
+			"internal/cmd/listen.go",
+			"internal/cmd/service_target.go",
+			"internal/cmd/framework_service.go",
+			"internal/cmd/provisioning_provider.go",
+		)
+
+		// The SDK is required with the versions azd is built with
+		goMod, err := os.ReadFile(filepath.Join(extensionDir, "go.mod"))
+		require.NoError(t, err)
+		require.Regexp(t, `require \(\n(\t.+\n)*\tgithub.com/spf13/cobra v[0-9.]+\n\)`, string(goMod))
+	})
+
+	t.Run("AlreadyExists", func(t *testing.T) {
+		extensionDir := t.TempDir()
+
+		require.NoError(t, Scaffold(extensionDir, ScaffoldOptions{Id: "contoso.azd.rust"}))
+
+		err := Scaffold(extensionDir, ScaffoldOptions{Id: "contoso.azd.rust"})
+		require.ErrorContains(t, err, "an extension project already exists")
+	})
+}
+
+func Test_sdkRequirements(t *testing.T) {
+	dependencies := []*debug.Module{
+		{Path: "github.com/spf13/cobra", Version: "v1.3.0"},
+		{Path: "google.golang.org/grpc", Version: "v1.68.1"},
+	}
+
+	t.Run("ModuleVersion", func(t *testing.T) {
+		requirements := sdkRequirements(&debug.BuildInfo{
+			Main: debug.Module{Path: azdModulePath, Version: "v1.12.0"},
+			Deps: dependencies,
+		})
+
+		require.Equal(t, []moduleRequirement{
+			{Path: azdModulePath, Version: "v1.12.0"},
+			{Path: "github.com/spf13/cobra", Version: "v1.3.0"},
+		}, requirements)
+	})
+
+	t.Run("CommitPseudoVersion", func(t *testing.T) {
+		requirements := sdkRequirements(&debug.BuildInfo{
+			Main: debug.Module{Path: azdModulePath, Version: "(devel)"},
+			Deps: dependencies,
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "8a49ae5ae9ab13beeade35f91ad4b4611c2f5574"},
+				{Key: "vcs.time", Value: "2025-03-04T05:06:07Z"},
+			},
+		})
+
+		require.Equal(t, moduleRequirement{
+			Path:    azdModulePath,
+			Version: "v0.0.0-20250304050607-8a49ae5ae9ab",
+		}, requirements[0])
+	})
+
+	t.Run("DirtyPseudoVersion", func(t *testing.T) {
+		requirements := sdkRequirements(&debug.BuildInfo{
+			Main: debug.Module{Path: azdModulePath, Version: "v0.0.0-20250304050607-8a49ae5ae9ab+dirty"},
+			Deps: dependencies,
+		})
+
+		require.Equal(t, "v0.0.0-20250304050607-8a49ae5ae9ab", requirements[0].Version)
+	})
+
+	t.Run("UnknownVersion", func(t *testing.T) {
+		// The SDK is resolved by go mod tidy when azd is built without version control information
+		requirements := sdkRequirements(&debug.BuildInfo{
+			Main: debug.Module{Path: azdModulePath, Version: "(devel)"},
+			Deps: dependencies,
+		})
+
+		require.Equal(t, []moduleRequirement{
+			{Path: "github.com/spf13/cobra", Version: "v1.3.0"},
+		}, requirements)
+	})
+}
+
+// requireGoFiles requires the scaffolded Go files to exist and to be valid Go source files
+func requireGoFiles(t *testing.T, extensionDir string, files ...string) {
+	for _, file := range files {
+		_, err := parser.ParseFile(token.NewFileSet(), filepath.Join(extensionDir, file), nil, parser.AllErrors)
+		require.NoError(t, err, file)
+	}
+}
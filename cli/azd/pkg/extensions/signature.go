@@ -0,0 +1,93 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+)
+
+var (
+	ErrArtifactUnsigned  = errors.New("artifact is not signed")
+	ErrSignatureInvalid  = errors.New("artifact signature does not match any trusted key")
+	ErrTrustedKeyInvalid = errors.New("invalid trusted key")
+)
+
+// ParseTrustedKey parses a base64 encoded ed25519 public key trusted to sign the artifacts of an extension source.
+func ParseTrustedKey(key string) (ed25519.PublicKey, error) {
+	keyBytes, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w '%s': %w", ErrTrustedKeyInvalid, key, err)
+	}
+
+	if len(keyBytes) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf(
+			"%w '%s': expected a %d byte ed25519 public key, got %d bytes",
+			ErrTrustedKeyInvalid, key, ed25519.PublicKeySize, len(keyBytes),
+		)
+	}
+
+	return ed25519.PublicKey(keyBytes), nil
+}
+
+// validateTrust validates the trusted keys & the signature requirements of the extension source.
+func validateTrust(source *SourceConfig) error {
+	for _, key := range source.TrustedKeys {
+		if _, err := ParseTrustedKey(key); err != nil {
+			return err
+		}
+	}
+
+	if source.RequireSignature && len(source.TrustedKeys) == 0 {
+		return fmt.Errorf("extension source '%s' requires signatures but has no trusted keys", source.Name)
+	}
+
+	return nil
+}
+
+// verifySignature verifies the detached signature of the artifact at the given path against the trusted keys of the
+// extension source the artifact is installed from.
+// Unsigned artifacts are only refused when the source requires signatures.
+func verifySignature(filePath string, signature string, source *SourceConfig) error {
+	if signature == "" {
+		if source.RequireSignature {
+			return fmt.Errorf("%w, extension source '%s' requires signed artifacts", ErrArtifactUnsigned, source.Name)
+		}
+
+		log.Println("Artifact signature is missing, skipping signature verification")
+		return nil
+	}
+
+	if len(source.TrustedKeys) == 0 {
+		log.Printf("Extension source '%s' has no trusted keys, skipping signature verification\n", source.Name)
+		return nil
+	}
+
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode artifact signature: %w", err)
+	}
+
+	artifactBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file for signature verification: %w", err)
+	}
+
+	for _, key := range source.TrustedKeys {
+		publicKey, err := ParseTrustedKey(key)
+		if err != nil {
+			return err
+		}
+
+		if ed25519.Verify(publicKey, artifactBytes, signatureBytes) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w of extension source '%s'", ErrSignatureInvalid, source.Name)
+}
@@ -37,6 +37,10 @@ type SourceConfig struct {
 	Name     string     `json:"name,omitempty"`
 	Type     SourceKind `json:"type,omitempty"`
 	Location string     `json:"location,omitempty"`
+	// TrustedKeys are the base64 encoded ed25519 public keys trusted to sign the artifacts of the source
+	TrustedKeys []string `json:"trustedKeys,omitempty"`
+	// RequireSignature refuses to install artifacts of the source that aren't signed by a trusted key
+	RequireSignature bool `json:"requireSignature,omitempty"`
 }
 
 // SourceManager manages extension sources.
@@ -89,6 +93,10 @@ func (sm *SourceManager) Add(ctx context.Context, name string, source *SourceCon
 
 	source.Name = newKey
 
+	if err := validateTrust(source); err != nil {
+		return err
+	}
+
 	return sm.addInternal(source)
 }
 
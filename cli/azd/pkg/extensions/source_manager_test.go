@@ -39,6 +39,27 @@ func TestSourceManager_Add(t *testing.T) {
 		require.Error(t, err)
 		require.ErrorIs(t, err, ErrSourceExists)
 	})
+
+	t.Run("InvalidTrustedKey", func(t *testing.T) {
+		err := sourceManager.Add(ctx, "signed-source", &SourceConfig{
+			Type:        SourceKindUrl,
+			Location:    "http://example.com",
+			TrustedKeys: []string{"bm90IGEga2V5"},
+		})
+		require.ErrorIs(t, err, ErrTrustedKeyInvalid)
+	})
+
+	t.Run("RequireSignatureWithoutTrustedKeys", func(t *testing.T) {
+		err := sourceManager.Add(ctx, "signed-source", &SourceConfig{
+			Type:             SourceKindUrl,
+			Location:         "http://example.com",
+			RequireSignature: true,
+		})
+		require.ErrorContains(t, err, "extension source 'signed-source' requires signatures but has no trusted keys")
+
+		_, err = sourceManager.Get(ctx, "signed-source")
+		require.ErrorIs(t, err, ErrSourceNotFound)
+	})
 }
 
 func TestSourceManager_Get(t *testing.T) {
@@ -0,0 +1,243 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+)
+
+// ErrNotFound is returned when the requested GitLab resource doesn't exist.
+var ErrNotFound = errors.New("not found")
+
+// variablesPageSize is the number of variables requested per page when listing project variables.
+const variablesPageSize = 100
+
+// Project is a GitLab project, as returned by the projects API.
+type Project struct {
+	Id                int    `json:"id"`
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebUrl            string `json:"web_url"`
+	DefaultBranch     string `json:"default_branch"`
+}
+
+// Variable is a CI/CD variable of a GitLab project, as used by the project variables API.
+type Variable struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+	// Masked hides the value of the variable in job logs.
+	Masked bool `json:"masked"`
+	// Protected exposes the variable only to pipelines running on protected branches and tags.
+	Protected bool `json:"protected"`
+	// Raw disables the expansion of variable references in the value.
+	Raw bool `json:"raw"`
+	// EnvironmentScope limits the environments the variable is available to. Defaults to all environments ("*").
+	EnvironmentScope string `json:"environment_scope,omitempty"`
+}
+
+// Client is a client for the GitLab REST API (v4) of a GitLab instance, either gitlab.com or self-managed.
+type Client struct {
+	serverUrl  string
+	token      string
+	httpClient auth.HttpClient
+}
+
+// NewClient creates a client for the GitLab instance at the server URL (for example https://gitlab.example.com),
+// authenticating with a personal, group or project access token.
+func NewClient(serverUrl string, token string, httpClient auth.HttpClient) *Client {
+	return &Client{
+		serverUrl:  strings.TrimSuffix(serverUrl, "/"),
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// GetProject gets a project by its full path, such as group/subgroup/project.
+func (c *Client) GetProject(ctx context.Context, projectPath string) (*Project, error) {
+	res, err := c.send(ctx, http.MethodGet, "projects/"+url.PathEscape(projectPath), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("project '%s': %w", projectPath, ErrNotFound)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, statusError(res)
+	}
+
+	var project Project
+	if err := json.NewDecoder(res.Body).Decode(&project); err != nil {
+		return nil, fmt.Errorf("parsing project: %w", err)
+	}
+
+	return &project, nil
+}
+
+// ListVariables lists the CI/CD variables of a project. Values of the variables are not included.
+func (c *Client) ListVariables(ctx context.Context, projectId int) ([]*Variable, error) {
+	variables := []*Variable{}
+
+	for page := "1"; page != ""; {
+		res, err := c.send(ctx, http.MethodGet, fmt.Sprintf(
+			"projects/%d/variables?per_page=%d&page=%s", projectId, variablesPageSize, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			err := statusError(res)
+			res.Body.Close()
+			return nil, err
+		}
+
+		var pageVariables []*Variable
+		err = json.NewDecoder(res.Body).Decode(&pageVariables)
+		res.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parsing variables: %w", err)
+		}
+
+		for _, variable := range pageVariables {
+			variable.Value = ""
+			variables = append(variables, variable)
+		}
+
+		// GitLab returns the number of the next page in the X-Next-Page header, which is empty on the last page
+		page = res.Header.Get("X-Next-Page")
+	}
+
+	return variables, nil
+}
+
+// SetVariable creates the CI/CD variable of a project, or updates it when the variable already exists.
+func (c *Client) SetVariable(ctx context.Context, projectId int, variable *Variable) error {
+	body, err := json.Marshal(variable)
+	if err != nil {
+		return fmt.Errorf("marshalling variable: %w", err)
+	}
+
+	// Without the filter, GitLab updates any variable with the key, whatever its environment scope
+	environmentScope := variable.EnvironmentScope
+	if environmentScope == "" {
+		environmentScope = "*"
+	}
+
+	variablePath := fmt.Sprintf("projects/%d/variables/%s?%s", projectId, url.PathEscape(variable.Key),
+		url.Values{"filter[environment_scope]": {environmentScope}}.Encode())
+	res, err := c.send(ctx, http.MethodPut, variablePath, body)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	if res.StatusCode != http.StatusNotFound {
+		return statusError(res)
+	}
+
+	// The variable doesn't exist yet
+	res, err = c.send(ctx, http.MethodPost, fmt.Sprintf("projects/%d/variables", projectId), body)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return statusError(res)
+	}
+
+	return nil
+}
+
+// DeleteVariable deletes the CI/CD variable of a project.
+func (c *Client) DeleteVariable(ctx context.Context, projectId int, key string) error {
+	res, err := c.send(
+		ctx, http.MethodDelete, fmt.Sprintf("projects/%d/variables/%s", projectId, url.PathEscape(key)), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("variable '%s': %w", key, ErrNotFound)
+	}
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		return statusError(res)
+	}
+
+	return nil
+}
+
+func (c *Client) send(ctx context.Context, method string, apiPath string, body []byte) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.serverUrl+"/api/v4/"+apiPath, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, req.URL.Redacted(), err)
+	}
+
+	return res, nil
+}
+
+// statusError creates the error of an unexpected response, including the message returned by GitLab when there is one.
+func statusError(res *http.Response) error {
+	if res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden {
+		return fmt.Errorf(
+			"access denied connecting to %s (%s). Ensure the token in %s has the 'api' scope and the Maintainer role",
+			res.Request.URL.Redacted(),
+			res.Status,
+			TokenEnvVarName,
+		)
+	}
+
+	var errorBody struct {
+		Message any    `json:"message"`
+		Error   string `json:"error"`
+	}
+
+	data, _ := io.ReadAll(res.Body)
+	if err := json.Unmarshal(data, &errorBody); err == nil {
+		message := errorBody.Error
+		if errorBody.Message != nil {
+			message = fmt.Sprint(errorBody.Message)
+		}
+
+		if message != "" {
+			return fmt.Errorf(
+				"%s %s: unexpected status %s: %s", res.Request.Method, res.Request.URL.Redacted(), res.Status, message)
+		}
+	}
+
+	return fmt.Errorf("%s %s: unexpected status %s", res.Request.Method, res.Request.URL.Redacted(), res.Status)
+}
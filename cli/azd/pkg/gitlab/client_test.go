@@ -0,0 +1,94 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Client_ListVariables_Pages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v4/projects/7/variables", r.URL.Path)
+		require.Equal(t, "token", r.Header.Get("PRIVATE-TOKEN"))
+
+		page := r.URL.Query().Get("page")
+		if page == "1" {
+			w.Header().Set("X-Next-Page", "2")
+		}
+
+		_ = json.NewEncoder(w).Encode([]*Variable{
+			{Key: fmt.Sprintf("VAR_%s", page), Value: "value", Masked: true},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", "token", http.DefaultClient)
+	variables, err := client.ListVariables(context.Background(), 7)
+	require.NoError(t, err)
+	require.Equal(t, []*Variable{
+		{Key: "VAR_1", Masked: true},
+		{Key: "VAR_2", Masked: true},
+	}, variables)
+}
+
+func Test_Client_SetVariable_EnvironmentScope(t *testing.T) {
+	scopes := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		require.Equal(t, "/api/v4/projects/7/variables/KEY", r.URL.Path)
+
+		scopes = append(scopes, r.URL.Query().Get("filter[environment_scope]"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token", http.DefaultClient)
+
+	err := client.SetVariable(context.Background(), 7, &Variable{Key: "KEY", Value: "value"})
+	require.NoError(t, err)
+
+	err = client.SetVariable(context.Background(), 7, &Variable{Key: "KEY", Value: "value", EnvironmentScope: "prod"})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"*", "prod"}, scopes)
+}
+
+func Test_Client_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusForbidden)
+		case http.MethodPut:
+			w.WriteHeader(http.StatusNotFound)
+		case http.MethodPost:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":{"value":["is invalid"]}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token", http.DefaultClient)
+
+	_, err := client.GetProject(context.Background(), "group/project")
+	require.ErrorContains(t, err, "access denied")
+	require.ErrorContains(t, err, TokenEnvVarName)
+
+	err = client.SetVariable(context.Background(), 7, &Variable{Key: "KEY", Value: "value"})
+	require.ErrorContains(t, err, "400 Bad Request: map[value:[is invalid]]")
+}
+
+func Test_IsMaskable(t *testing.T) {
+	require.True(t, IsMaskable("Client~Secret_Value.1"))
+	require.True(t, IsMaskable("c2VjcmV0LXZhbHVl"))
+	require.False(t, IsMaskable("short"))
+	require.False(t, IsMaskable("has spaces in it"))
+	require.False(t, IsMaskable(`{"json":"value"}`))
+	require.False(t, IsMaskable("multi\nline-value"))
+}
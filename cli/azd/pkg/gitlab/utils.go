@@ -0,0 +1,63 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+)
+
+const (
+	// TokenEnvVarName is the environment variable with the access token used to call the GitLab API.
+	// The token requires the 'api' scope.
+	TokenEnvVarName = "GITLAB_TOKEN"
+	// HostEnvVarName is the environment variable with the URL of the GitLab instance, such as
+	// https://gitlab.example.com. It is needed when the URL can't be inferred from the git remote, for example when
+	// the ssh host of a self-managed instance is different from its web host.
+	HostEnvVarName = "GITLAB_HOST"
+)
+
+// maskableValueRegex defines the values GitLab can mask in job logs: a single line of at least 8 characters from the
+// Base64 alphabet (RFC4648), '@', ':', '.', '~', '-' and '_'.
+var maskableValueRegex = regexp.MustCompile(`^[a-zA-Z0-9+/=@:.~_-]{8,}$`)
+
+// IsMaskable checks if GitLab accepts the value for a masked variable.
+func IsMaskable(value string) bool {
+	return maskableValueRegex.MatchString(value)
+}
+
+// EnsureTokenExists gets the GitLab access token from .env or system environment variables, and prompts for it
+// when it isn't found. The returned bool indicates if the token was prompted.
+func EnsureTokenExists(ctx context.Context, env *environment.Environment, console input.Console) (string, bool, error) {
+	if value, exists := env.LookupEnv(TokenEnvVarName); exists && value != "" {
+		return value, false, nil
+	}
+
+	console.Message(ctx, fmt.Sprintf(
+		"You need a %s with the 'api' scope. Create a token by following the instructions here %s",
+		output.WithWarningFormat("GitLab access token"),
+		output.WithLinkFormat("https://docs.gitlab.com/user/profile/personal_access_tokens/")))
+	console.Message(ctx, fmt.Sprintf("(%s this prompt by setting the token to env var: %s)",
+		output.WithWarningFormat("%s", "skip"),
+		output.WithHighLightFormat("%s", TokenEnvVarName)))
+
+	token, err := console.Prompt(ctx, input.ConsoleOptions{
+		Message:    "GitLab access token:",
+		IsPassword: true,
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("asking for gitlab access token: %w", err)
+	}
+
+	// set the token as an environment variable for this cmd run
+	// note: the scope of this env var is only this shell invocation and won't be available in the caller parent shell
+	os.Setenv(TokenEnvVarName, token)
+	return token, true, nil
+}
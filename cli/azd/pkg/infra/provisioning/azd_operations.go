@@ -0,0 +1,352 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/braydonk/yaml"
+)
+
+const (
+	fileShareUploadOperation  string = "FileShareUpload"
+	blobUploadOperation       string = "BlobUpload"
+	sqlScriptOperation        string = "SqlScript"
+	postgreSqlScriptOperation string = "PostgreSqlScript"
+	mySqlScriptOperation      string = "MySqlScript"
+	cosmosDbItemsOperation    string = "CosmosDbItems"
+	keyVaultSecretsOperation  string = "KeyVaultSecrets"
+	azdOperationsFileName     string = "azd.operations.yaml"
+	// azdOperationsConfigPath is the path, in the environment config, of the fingerprints of the completed operations
+	azdOperationsConfigPath string = "provision.operations.completed"
+)
+
+type azdOperation struct {
+	Type        string
+	Description string
+	Config      any
+}
+
+type azdOperationsModel struct {
+	Operations []azdOperation
+}
+
+// azdOperationRunner runs one operation of azd.operations.yaml. The config of the operation is decoded into the runner.
+type azdOperationRunner interface {
+	// resolvePaths makes the local paths of the config absolute, relative to the directory of azd.operations.yaml
+	resolvePaths(infraPath string)
+	// paths returns the local files and directories read by the operation
+	paths() []string
+	// run executes the operation
+	run(ctx context.Context, opCtx *azdOperationContext) error
+}
+
+// azdOperationType is a type of operation supported in azd.operations.yaml.
+type azdOperationType struct {
+	// newRunner creates the runner the config of the operation is decoded into
+	newRunner func() azdOperationRunner
+	// tracked operations run again only when their config, or the content of their files, change. Operations which
+	// aren't tracked run on every provision.
+	tracked bool
+}
+
+// azdOperationTypes is the registry of the operation types supported in azd.operations.yaml, by name.
+var azdOperationTypes = map[string]azdOperationType{
+	fileShareUploadOperation: {
+		newRunner: func() azdOperationRunner { return &azdOperationFileShareUpload{} },
+	},
+	blobUploadOperation: {
+		newRunner: func() azdOperationRunner { return &azdOperationBlobUpload{} },
+		tracked:   true,
+	},
+	sqlScriptOperation: {
+		newRunner: func() azdOperationRunner { return &azdOperationSqlScript{engine: sqlServerEngine} },
+		tracked:   true,
+	},
+	postgreSqlScriptOperation: {
+		newRunner: func() azdOperationRunner { return &azdOperationSqlScript{engine: postgreSqlEngine} },
+		tracked:   true,
+	},
+	mySqlScriptOperation: {
+		newRunner: func() azdOperationRunner { return &azdOperationSqlScript{engine: mySqlEngine} },
+		tracked:   true,
+	},
+	cosmosDbItemsOperation: {
+		newRunner: func() azdOperationRunner { return &azdOperationCosmosDbItems{} },
+		tracked:   true,
+	},
+	keyVaultSecretsOperation: {
+		newRunner: func() azdOperationRunner { return &azdOperationKeyVaultSecrets{} },
+		tracked:   true,
+	},
+}
+
+// azdOperationContext holds the environment and the services available to the operations.
+type azdOperationContext struct {
+	env              *environment.Environment
+	cloud            *cloud.Cloud
+	fileShareService storage.FileShareService
+	serviceLocator   ioc.ServiceLocator
+}
+
+// loadedAzdOperation is an operation of azd.operations.yaml with its typed runner.
+type loadedAzdOperation struct {
+	azdOperation
+	runner  azdOperationRunner
+	tracked bool
+}
+
+// fingerprint identifies the operation by its type, its config and the content of the files it reads.
+func (op *loadedAzdOperation) fingerprint() (string, error) {
+	hash := sha256.New()
+	config, err := json.Marshal(op.runner)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(hash, "%s\n%s\n", op.Type, config)
+
+	for _, path := range op.runner.paths() {
+		err := filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			file, err := os.Open(filePath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			fmt.Fprintf(hash, "%s\n", filepath.ToSlash(filePath))
+			_, err = io.Copy(hash, file)
+			return err
+		})
+		if err != nil {
+			return "", fmt.Errorf("reading '%s': %w", path, err)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func azdOperations(infraPath string, env environment.Environment) (azdOperationsModel, error) {
+	path := filepath.Join(infraPath, azdOperationsFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// file not found is not an error, there's just nothing to do
+			return azdOperationsModel{}, nil
+		}
+		return azdOperationsModel{}, err
+	}
+
+	// resolve environment variables
+	expString := osutil.NewExpandableString(string(data))
+	evaluated, err := expString.Envsubst(env.Getenv)
+	if err != nil {
+		return azdOperationsModel{}, err
+	}
+	data = []byte(evaluated)
+
+	// Unmarshal the file into azdOperationsModel
+	var operations azdOperationsModel
+	err = yaml.Unmarshal(data, &operations)
+	if err != nil {
+		return azdOperationsModel{}, err
+	}
+
+	return operations, nil
+}
+
+// loadAzdOperations reads azd.operations.yaml and decodes the config of each operation into the runner of its type.
+func loadAzdOperations(infraPath string, env environment.Environment) ([]*loadedAzdOperation, error) {
+	model, err := azdOperations(infraPath, env)
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []*loadedAzdOperation
+	for _, operation := range model.Operations {
+		operationType, has := azdOperationTypes[operation.Type]
+		if !has {
+			return nil, fmt.Errorf(
+				"unsupported azd operation type '%s' for '%s'. Supported types: %s",
+				operation.Type,
+				operation.Description,
+				strings.Join(slices.Sorted(maps.Keys(azdOperationTypes)), ", "),
+			)
+		}
+
+		runner := operationType.newRunner()
+		bytes, err := json.Marshal(operation.Config)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bytes, runner); err != nil {
+			return nil, fmt.Errorf("invalid config for %s operation '%s': %w", operation.Type, operation.Description, err)
+		}
+		runner.resolvePaths(infraPath)
+
+		operations = append(operations, &loadedAzdOperation{
+			azdOperation: operation,
+			runner:       runner,
+			tracked:      operationType.tracked,
+		})
+	}
+	return operations, nil
+}
+
+// resolveOperationPath makes a local path of an operation absolute, relative to the directory of azd.operations.yaml.
+func resolveOperationPath(infraPath string, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(infraPath, path)
+}
+
+var ErrAzdOperationsNotEnabled = fmt.Errorf(
+	"azd operations (alpha feature) is required but disabled. You can enable azd operations by running: %s",
+	output.WithGrayFormat("%s", alpha.GetEnableCommand(AzdOperationsFeatureKey)))
+
+var ErrBindMountOperationDisabled = fmt.Errorf(
+	"%sYour project has bind mounts.\n  - %w\n%s\n",
+	output.WithWarningFormat("*Note: "),
+	ErrAzdOperationsNotEnabled,
+	output.WithWarningFormat("Ignoring bind mounts."),
+)
+
+var ErrAzdOperationsDisabled = fmt.Errorf(
+	"%sYour project has azd operations.\n  - %w\n%s\n",
+	output.WithWarningFormat("*Note: "),
+	ErrAzdOperationsNotEnabled,
+	output.WithWarningFormat("Ignoring azd operations."),
+)
+
+// runAzdOperations runs the operations of azd.operations.yaml, in order, after the infrastructure is deployed.
+// Tracked operations which completed in a previous provision, with the same config and files, are skipped.
+func (m *Manager) runAzdOperations(ctx context.Context, infraPath string) error {
+	operations, err := loadAzdOperations(infraPath, *m.env)
+	if !m.alphaFeatureManager.IsEnabled(AzdOperationsFeatureKey) {
+		if slices.ContainsFunc(operations, func(op *loadedAzdOperation) bool {
+			return op.Type == fileShareUploadOperation
+		}) {
+			m.console.Message(ctx, ErrBindMountOperationDisabled.Error())
+		} else if len(operations) > 0 {
+			m.console.Message(ctx, ErrAzdOperationsDisabled.Error())
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("looking for azd operations: %w", err)
+	}
+	if len(operations) == 0 {
+		return nil
+	}
+
+	opCtx := &azdOperationContext{
+		env:              m.env,
+		cloud:            m.cloud,
+		fileShareService: m.fileShareService,
+		serviceLocator:   m.serviceLocator,
+	}
+
+	previouslyCompleted := completedAzdOperations(m.env)
+	// only the fingerprints of the current operations are kept, so stale entries don't accumulate
+	completed := []string{}
+	var runErr error
+	for _, op := range operations {
+		fingerprint := ""
+		if op.tracked {
+			fingerprint, err = op.fingerprint()
+			if err != nil {
+				runErr = fmt.Errorf("computing fingerprint of %s operation '%s': %w", op.Type, op.Description, err)
+				break
+			}
+			if slices.Contains(previouslyCompleted, fingerprint) {
+				completed = append(completed, fingerprint)
+				m.console.MessageUxItem(ctx, &ux.DisplayedResource{
+					Type:  op.Type,
+					Name:  op.Description,
+					State: ux.SkippedState,
+				})
+				continue
+			}
+		}
+
+		m.console.ShowSpinner(ctx, fmt.Sprintf("Running %s operation: %s", op.Type, op.Description), input.Step)
+		if err := op.runner.run(ctx, opCtx); err != nil {
+			m.console.StopSpinner(ctx, "", input.StepFailed)
+			runErr = fmt.Errorf("running %s operation '%s': %w", op.Type, op.Description, err)
+			break
+		}
+		m.console.MessageUxItem(ctx, &ux.DisplayedResource{
+			Type:  op.Type,
+			Name:  op.Description,
+			State: ux.SucceededState,
+		})
+
+		if op.tracked {
+			completed = append(completed, fingerprint)
+		}
+	}
+
+	if runErr != nil {
+		// keep the operations which already completed, so they don't run again on the next provision
+		completed = append(completed, previouslyCompleted...)
+		slices.Sort(completed)
+		completed = slices.Compact(completed)
+	}
+
+	if !slices.Equal(completed, previouslyCompleted) {
+		if err := m.env.Config.Set(azdOperationsConfigPath, completed); err != nil {
+			return errors.Join(runErr, fmt.Errorf("tracking completed azd operations: %w", err))
+		}
+		if err := m.envManager.Save(ctx, m.env); err != nil {
+			return errors.Join(runErr, fmt.Errorf("saving environment: %w", err))
+		}
+	}
+
+	return runErr
+}
+
+// completedAzdOperations returns the fingerprints of the operations which completed in previous provisions.
+func completedAzdOperations(env *environment.Environment) []string {
+	value, has := env.Config.Get(azdOperationsConfigPath)
+	if !has {
+		return []string{}
+	}
+
+	completed := []string{}
+	switch values := value.(type) {
+	case []string:
+		completed = append(completed, values...)
+	case []any:
+		for _, value := range values {
+			if fingerprint, ok := value.(string); ok {
+				completed = append(completed, fingerprint)
+			}
+		}
+	}
+	return completed
+}
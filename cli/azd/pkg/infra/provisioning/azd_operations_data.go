@@ -0,0 +1,402 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	azruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/keyvault"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	mssql "github.com/microsoft/go-mssqldb"
+)
+
+// sqlEngine is a database engine the scripts of a SQL script operation run on.
+type sqlEngine string
+
+const (
+	sqlServerEngine  sqlEngine = "sqlserver"
+	postgreSqlEngine sqlEngine = "postgresql"
+	mySqlEngine      sqlEngine = "mysql"
+)
+
+// sqlBatchSeparatorRegex matches the lines separating the batches of a SQL Server script
+var sqlBatchSeparatorRegex = regexp.MustCompile(`(?im)^[ \t]*go[ \t]*;?[ \t]*\r?$`)
+
+// azdOperationSqlScript runs SQL scripts on a database, one after the other, for example to create its schema and
+// seed it. The scripts authenticate with a Microsoft Entra ID token of the signed in account. SQL Server scripts are
+// run by azd, batch by batch, while PostgreSQL and MySQL scripts are run with the command line tool of the engine
+// (psql or mysql), which must be installed.
+type azdOperationSqlScript struct {
+	// Server is the fully qualified domain name of the server
+	Server   string
+	Database string
+	// User is the Microsoft Entra user, or group, of the signed in account on the server. Required by PostgreSQL and
+	// MySQL.
+	User    string
+	Scripts []string
+
+	engine sqlEngine
+}
+
+func (op *azdOperationSqlScript) resolvePaths(infraPath string) {
+	for i, script := range op.Scripts {
+		op.Scripts[i] = resolveOperationPath(infraPath, script)
+	}
+}
+
+func (op *azdOperationSqlScript) paths() []string {
+	return op.Scripts
+}
+
+func (op *azdOperationSqlScript) run(ctx context.Context, opCtx *azdOperationContext) error {
+	if op.Server == "" || op.Database == "" {
+		return errors.New("server and database are required")
+	}
+	if op.engine != sqlServerEngine && op.User == "" {
+		return errors.New("user is required")
+	}
+
+	if op.engine == sqlServerEngine {
+		return op.runSqlServerScripts(ctx, opCtx)
+	}
+
+	var commandRunner exec.CommandRunner
+	if err := opCtx.serviceLocator.Resolve(&commandRunner); err != nil {
+		return err
+	}
+
+	toolName := op.toolName()
+	if err := tools.ToolInPath(toolName); err != nil {
+		return fmt.Errorf("%s is required to run the scripts: %w", toolName, err)
+	}
+
+	token, err := accessToken(ctx, opCtx, op.tokenScope(opCtx.cloud))
+	if err != nil {
+		return err
+	}
+
+	env := []string{"MYSQL_PWD=" + token}
+	if op.engine == postgreSqlEngine {
+		env = []string{"PGPASSWORD=" + token, "PGSSLMODE=require"}
+	}
+
+	for _, script := range op.Scripts {
+		if err := op.runScript(ctx, commandRunner, env, script); err != nil {
+			return fmt.Errorf("running script '%s': %w", script, err)
+		}
+	}
+
+	return nil
+}
+
+// tokenScope returns the scope of the Microsoft Entra tokens accepted by the database engine in the cloud.
+func (op *azdOperationSqlScript) tokenScope(cloud *cloud.Cloud) string {
+	if op.engine == sqlServerEngine {
+		return fmt.Sprintf("https://%s/.default", cloud.SqlDatabaseEndpointSuffix)
+	}
+
+	// Azure Database for PostgreSQL and MySQL
+	return fmt.Sprintf("https://ossrdbms-aad.%s/.default", cloud.SqlDatabaseEndpointSuffix)
+}
+
+// runSqlServerScripts runs the scripts on a SQL Server database with a token of the signed in account. Like sqlcmd,
+// the scripts are split in batches by the GO lines.
+func (op *azdOperationSqlScript) runSqlServerScripts(ctx context.Context, opCtx *azdOperationContext) error {
+	credential, err := operationCredential(ctx, opCtx)
+	if err != nil {
+		return err
+	}
+
+	tokenScope := op.tokenScope(opCtx.cloud)
+	dsn := &url.URL{
+		Scheme:   "sqlserver",
+		Host:     op.Server,
+		RawQuery: url.Values{"database": {op.Database}, "encrypt": {"true"}}.Encode(),
+	}
+	connector, err := mssql.NewConnectorWithAccessTokenProvider(dsn.String(), func(ctx context.Context) (string, error) {
+		token, err := credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{tokenScope}})
+		if err != nil {
+			return "", fmt.Errorf("getting access token: %w", err)
+		}
+		return token.Token, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	for _, script := range op.Scripts {
+		content, err := os.ReadFile(script)
+		if err != nil {
+			return fmt.Errorf("running script '%s': %w", script, err)
+		}
+
+		for i, batch := range sqlBatches(string(content)) {
+			if _, err := db.ExecContext(ctx, batch); err != nil {
+				return fmt.Errorf("running script '%s': batch %d: %w", script, i+1, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sqlBatches splits a SQL Server script in the batches separated by GO lines, skipping the empty batches.
+func sqlBatches(script string) []string {
+	batches := []string{}
+	for _, batch := range sqlBatchSeparatorRegex.Split(script, -1) {
+		if strings.TrimSpace(batch) != "" {
+			batches = append(batches, batch)
+		}
+	}
+	return batches
+}
+
+func (op *azdOperationSqlScript) toolName() string {
+	if op.engine == postgreSqlEngine {
+		return "psql"
+	}
+	return "mysql"
+}
+
+func (op *azdOperationSqlScript) runScript(
+	ctx context.Context, commandRunner exec.CommandRunner, env []string, script string) error {
+	var runArgs exec.RunArgs
+	switch op.engine {
+	case postgreSqlEngine:
+		runArgs = exec.NewRunArgs(
+			"psql", "-h", op.Server, "-U", op.User, "-d", op.Database, "-v", "ON_ERROR_STOP=1", "-f", script)
+	default:
+		file, err := os.Open(script)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		runArgs = exec.NewRunArgs(
+			"mysql", "-h", op.Server, "-u", op.User, "--ssl-mode=REQUIRED", "--enable-cleartext-plugin", op.Database).
+			WithStdIn(file)
+	}
+
+	result, err := commandRunner.Run(ctx, runArgs.WithEnv(env))
+	if err != nil {
+		return fmt.Errorf("%s: %w", result.Stderr, err)
+	}
+	return nil
+}
+
+// accessToken gets a token of the signed in account for the scope.
+func accessToken(ctx context.Context, opCtx *azdOperationContext, scope string) (string, error) {
+	credential, err := operationCredential(ctx, opCtx)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{scope}})
+	if err != nil {
+		return "", fmt.Errorf("getting access token: %w", err)
+	}
+	return token.Token, nil
+}
+
+// operationCredential returns the credential of the signed in account for the subscription of the environment.
+func operationCredential(ctx context.Context, opCtx *azdOperationContext) (azcore.TokenCredential, error) {
+	var credentialProvider account.SubscriptionCredentialProvider
+	if err := opCtx.serviceLocator.Resolve(&credentialProvider); err != nil {
+		return nil, err
+	}
+	return credentialProvider.CredentialForSubscription(ctx, opCtx.env.GetSubscriptionId())
+}
+
+// azdOperationCosmosDbItems creates or replaces the items of a JSON file in a Cosmos DB for NoSQL container.
+type azdOperationCosmosDbItems struct {
+	// Endpoint is the document endpoint of the account, for example https://<account>.documents.azure.com:443/
+	Endpoint  string
+	Database  string
+	Container string
+	// PartitionKeyPath is the path of the partition key of the container. Defaults to /id.
+	PartitionKeyPath string
+	// Path is a JSON file holding an array of items
+	Path string
+}
+
+func (op *azdOperationCosmosDbItems) resolvePaths(infraPath string) {
+	op.Path = resolveOperationPath(infraPath, op.Path)
+}
+
+func (op *azdOperationCosmosDbItems) paths() []string {
+	return []string{op.Path}
+}
+
+func (op *azdOperationCosmosDbItems) run(ctx context.Context, opCtx *azdOperationContext) error {
+	data, err := os.ReadFile(op.Path)
+	if err != nil {
+		return err
+	}
+	var items []map[string]any
+	if err := json.Unmarshal(data, &items); err != nil {
+		return fmt.Errorf("reading items from '%s': %w", op.Path, err)
+	}
+
+	endpoint, err := url.Parse(op.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return fmt.Errorf("invalid endpoint '%s'", op.Endpoint)
+	}
+
+	credential, err := operationCredential(ctx, opCtx)
+	if err != nil {
+		return err
+	}
+	var clientOptions *azcore.ClientOptions
+	if err := opCtx.serviceLocator.Resolve(&clientOptions); err != nil {
+		return err
+	}
+
+	pipeline := azruntime.NewPipeline("azd-cosmos", internal.Version, azruntime.PipelineOptions{
+		PerRetry: []policy.Policy{&cosmosAadPolicy{
+			credential: credential,
+			scope:      fmt.Sprintf("%s://%s/.default", endpoint.Scheme, endpoint.Hostname()),
+		}},
+	}, clientOptions)
+
+	partitionKeyPath := op.PartitionKeyPath
+	if partitionKeyPath == "" {
+		partitionKeyPath = "/id"
+	}
+
+	docsUrl := endpoint.JoinPath("dbs", op.Database, "colls", op.Container, "docs").String()
+	for i, item := range items {
+		if err := upsertCosmosItem(ctx, pipeline, docsUrl, partitionKeyPath, item); err != nil {
+			return fmt.Errorf("upserting item %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// upsertCosmosItem creates or replaces the item with the Cosmos DB REST API.
+func upsertCosmosItem(
+	ctx context.Context, pipeline azruntime.Pipeline, docsUrl string, partitionKeyPath string, item map[string]any) error {
+	partitionKey, err := cosmosPartitionKey(item, partitionKeyPath)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	req, err := azruntime.NewRequest(ctx, http.MethodPost, docsUrl)
+	if err != nil {
+		return err
+	}
+	req.Raw().Header.Set("x-ms-version", "2018-12-31")
+	req.Raw().Header.Set("x-ms-documentdb-is-upsert", "True")
+	req.Raw().Header.Set("x-ms-documentdb-partitionkey", partitionKey)
+	if err := req.SetBody(streaming.NopCloser(bytes.NewReader(body)), "application/json"); err != nil {
+		return err
+	}
+
+	res, err := pipeline.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if !azruntime.HasStatusCode(res, http.StatusOK, http.StatusCreated) {
+		return azruntime.NewResponseError(res)
+	}
+	return nil
+}
+
+// cosmosPartitionKey returns the partition key header of the item, the JSON array of the value at the path.
+func cosmosPartitionKey(item map[string]any, partitionKeyPath string) (string, error) {
+	var value any = item
+	for _, segment := range strings.Split(strings.Trim(partitionKeyPath, "/"), "/") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("item has no value for the partition key '%s'", partitionKeyPath)
+		}
+		if value, ok = object[segment]; !ok {
+			return "", fmt.Errorf("item has no value for the partition key '%s'", partitionKeyPath)
+		}
+	}
+
+	partitionKey, err := json.Marshal([]any{value})
+	if err != nil {
+		return "", err
+	}
+	return string(partitionKey), nil
+}
+
+// cosmosAadPolicy authenticates the requests to the Cosmos DB data plane with Microsoft Entra ID, which uses its own
+// authorization header format rather than a bearer token.
+type cosmosAadPolicy struct {
+	credential azcore.TokenCredential
+	scope      string
+}
+
+func (p *cosmosAadPolicy) Do(req *policy.Request) (*http.Response, error) {
+	token, err := p.credential.GetToken(req.Raw().Context(), policy.TokenRequestOptions{Scopes: []string{p.scope}})
+	if err != nil {
+		return nil, fmt.Errorf("getting access token: %w", err)
+	}
+
+	req.Raw().Header.Set("Authorization", url.QueryEscape("type=aad&ver=1.0&sig="+token.Token))
+	req.Raw().Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	return req.Next()
+}
+
+// azdOperationKeyVaultSecrets sets secrets in a key vault, for example the initial credentials of an application.
+type azdOperationKeyVaultSecrets struct {
+	VaultName string
+	// Secrets are the values of the secrets, by name
+	Secrets map[string]string
+}
+
+// resolvePaths is a no-op, as the operation doesn't read local files.
+func (op *azdOperationKeyVaultSecrets) resolvePaths(infraPath string) {}
+
+func (op *azdOperationKeyVaultSecrets) paths() []string {
+	return nil
+}
+
+func (op *azdOperationKeyVaultSecrets) run(ctx context.Context, opCtx *azdOperationContext) error {
+	var keyVaultService keyvault.KeyVaultService
+	if err := opCtx.serviceLocator.Resolve(&keyVaultService); err != nil {
+		return err
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(op.Secrets)) {
+		if err := keyVaultService.CreateKeyVaultSecret(
+			ctx, opCtx.env.GetSubscriptionId(), op.VaultName, name, op.Secrets[name]); err != nil {
+			return fmt.Errorf("setting secret '%s': %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
+)
+
+// azdOperationFileShareUpload uploads a local file or directory to an Azure Files share, for the bind mounts of
+// container apps.
+type azdOperationFileShareUpload struct {
+	StorageAccount string
+	FileShareName  string
+	Path           string
+}
+
+// resolvePaths is a no-op, as the sources of the bind mounts are absolute paths.
+func (op *azdOperationFileShareUpload) resolvePaths(infraPath string) {}
+
+func (op *azdOperationFileShareUpload) paths() []string {
+	return []string{op.Path}
+}
+
+func (op *azdOperationFileShareUpload) run(ctx context.Context, opCtx *azdOperationContext) error {
+	if err := bindMountOperation(
+		ctx,
+		opCtx.fileShareService,
+		opCtx.cloud.StorageEndpointSuffix,
+		opCtx.env.GetSubscriptionId(),
+		op.StorageAccount,
+		op.FileShareName,
+		op.Path); err != nil {
+		return fmt.Errorf("error binding mount: %w", err)
+	}
+	return nil
+}
+
+func bindMountOperation(
+	ctx context.Context,
+	fileShareService storage.FileShareService,
+	cloud, subId, storageAccount, fileShareName, source string) error {
+
+	shareUrl := fmt.Sprintf("https://%s.file.%s/%s", storageAccount, cloud, fileShareName)
+	return fileShareService.UploadPath(ctx, subId, shareUrl, source)
+}
+
+// azdOperationBlobUpload uploads a local file or directory to a blob container, creating the container when it
+// doesn't exist.
+type azdOperationBlobUpload struct {
+	StorageAccount string
+	ContainerName  string
+	Path           string
+	// Prefix is the virtual directory of the container the files are uploaded to. Empty for the root of the container.
+	Prefix string
+	// Sync deletes the blobs under Prefix which don't match a local file
+	Sync bool
+}
+
+func (op *azdOperationBlobUpload) resolvePaths(infraPath string) {
+	op.Path = resolveOperationPath(infraPath, op.Path)
+}
+
+func (op *azdOperationBlobUpload) paths() []string {
+	return []string{op.Path}
+}
+
+func (op *azdOperationBlobUpload) run(ctx context.Context, opCtx *azdOperationContext) error {
+	var credentialProvider account.SubscriptionCredentialProvider
+	if err := opCtx.serviceLocator.Resolve(&credentialProvider); err != nil {
+		return err
+	}
+	var clientOptions *azcore.ClientOptions
+	if err := opCtx.serviceLocator.Resolve(&clientOptions); err != nil {
+		return err
+	}
+
+	credential, err := credentialProvider.CredentialForSubscription(ctx, opCtx.env.GetSubscriptionId())
+	if err != nil {
+		return err
+	}
+
+	serviceUrl := fmt.Sprintf("https://%s.blob.%s/", op.StorageAccount, opCtx.cloud.StorageEndpointSuffix)
+	client, err := azblob.NewClient(serviceUrl, credential, &azblob.ClientOptions{ClientOptions: *clientOptions})
+	if err != nil {
+		return fmt.Errorf("creating blob client: %w", err)
+	}
+	blobClient := storage.NewBlobClient(&storage.AccountConfig{
+		AccountName:   op.StorageAccount,
+		ContainerName: op.ContainerName,
+	}, client)
+
+	files, err := op.localFiles()
+	if err != nil {
+		return err
+	}
+
+	for blobPath, filePath := range files {
+		if err := uploadBlob(ctx, blobClient, blobPath, filePath); err != nil {
+			return err
+		}
+	}
+
+	if !op.Sync {
+		return nil
+	}
+
+	blobs, err := blobClient.Items(ctx)
+	if err != nil {
+		return err
+	}
+	prefix := strings.Trim(op.Prefix, "/")
+	for _, blob := range blobs {
+		if prefix != "" && !strings.HasPrefix(blob.Path, prefix+"/") {
+			continue
+		}
+		if _, has := files[blob.Path]; !has {
+			if err := blobClient.Delete(ctx, blob.Path); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// localFiles returns the local files to upload, by the path of their blob.
+func (op *azdOperationBlobUpload) localFiles() (map[string]string, error) {
+	prefix := strings.Trim(op.Prefix, "/")
+	files := map[string]string{}
+
+	info, err := os.Stat(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		files[path.Join(prefix, filepath.Base(op.Path))] = op.Path
+		return files, nil
+	}
+
+	err = filepath.WalkDir(op.Path, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		relativePath, err := filepath.Rel(op.Path, filePath)
+		if err != nil {
+			return err
+		}
+		files[path.Join(prefix, filepath.ToSlash(relativePath))] = filePath
+		return nil
+	})
+	return files, err
+}
+
+func uploadBlob(ctx context.Context, blobClient storage.BlobClient, blobPath string, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return blobClient.Upload(ctx, blobPath, file)
+}
@@ -0,0 +1,321 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loadAzdOperations(t *testing.T) {
+	infraPath := t.TempDir()
+	env := environment.NewWithValues("test-env", map[string]string{
+		"STORAGE_ACCOUNT": "stfiles",
+		"VAULT_NAME":      "kv-test",
+	})
+
+	t.Run("typed operations", func(t *testing.T) {
+		writeAzdOperations(t, infraPath, `operations:
+- type: BlobUpload
+  description: Upload static files
+  config:
+    storageAccount: ${STORAGE_ACCOUNT}
+    containerName: web
+    path: ../static
+    sync: true
+- type: PostgreSqlScript
+  description: Create schema
+  config:
+    server: psql-test.postgres.database.azure.com
+    database: app
+    user: admins
+    scripts:
+    - sql/schema.sql
+    - /abs/seed.sql
+- type: KeyVaultSecrets
+  description: Seed secrets
+  config:
+    vaultName: ${VAULT_NAME}
+    secrets:
+      admin-user: admin
+`)
+
+		operations, err := loadAzdOperations(infraPath, *env)
+		require.NoError(t, err)
+		require.Len(t, operations, 3)
+
+		require.Equal(t, &azdOperationBlobUpload{
+			StorageAccount: "stfiles",
+			ContainerName:  "web",
+			Path:           filepath.Join(infraPath, "..", "static"),
+			Sync:           true,
+		}, operations[0].runner)
+		require.True(t, operations[0].tracked)
+
+		require.Equal(t, &azdOperationSqlScript{
+			Server:   "psql-test.postgres.database.azure.com",
+			Database: "app",
+			User:     "admins",
+			Scripts:  []string{filepath.Join(infraPath, "sql", "schema.sql"), "/abs/seed.sql"},
+			engine:   postgreSqlEngine,
+		}, operations[1].runner)
+
+		require.Equal(t, &azdOperationKeyVaultSecrets{
+			VaultName: "kv-test",
+			Secrets:   map[string]string{"admin-user": "admin"},
+		}, operations[2].runner)
+	})
+
+	t.Run("file share uploads are not tracked", func(t *testing.T) {
+		writeAzdOperations(t, infraPath, `operations:
+- type: FileShareUpload
+  description: Upload files for api
+  config:
+    storageAccount: ${STORAGE_ACCOUNT}
+    fileShareName: share
+    path: /data
+`)
+
+		operations, err := loadAzdOperations(infraPath, *env)
+		require.NoError(t, err)
+		require.Len(t, operations, 1)
+		require.False(t, operations[0].tracked)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		writeAzdOperations(t, infraPath, `operations:
+- type: QueueMessages
+  description: Send messages
+`)
+
+		_, err := loadAzdOperations(infraPath, *env)
+		require.ErrorContains(t, err, "unsupported azd operation type 'QueueMessages' for 'Send messages'")
+	})
+
+	t.Run("no file", func(t *testing.T) {
+		operations, err := loadAzdOperations(t.TempDir(), *env)
+		require.NoError(t, err)
+		require.Empty(t, operations)
+	})
+}
+
+func Test_loadedAzdOperation_fingerprint(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "seed.sql")
+	require.NoError(t, os.WriteFile(script, []byte("INSERT INTO items VALUES (1);"), osutil.PermissionFile))
+
+	op := &loadedAzdOperation{
+		azdOperation: azdOperation{Type: sqlScriptOperation},
+		runner:       &azdOperationSqlScript{Server: "sql", Database: "app", Scripts: []string{script}},
+	}
+
+	first, err := op.fingerprint()
+	require.NoError(t, err)
+	second, err := op.fingerprint()
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+
+	// changing the content of the script changes the fingerprint
+	require.NoError(t, os.WriteFile(script, []byte("INSERT INTO items VALUES (2);"), osutil.PermissionFile))
+	changedContent, err := op.fingerprint()
+	require.NoError(t, err)
+	require.NotEqual(t, first, changedContent)
+
+	// changing the config changes the fingerprint
+	op.runner.(*azdOperationSqlScript).Database = "other"
+	changedConfig, err := op.fingerprint()
+	require.NoError(t, err)
+	require.NotEqual(t, changedContent, changedConfig)
+}
+
+func Test_Manager_runAzdOperations(t *testing.T) {
+	runs := map[string]int{}
+	azdOperationTypes["Test"] = azdOperationType{
+		newRunner: func() azdOperationRunner { return &testAzdOperation{runs: runs} },
+		tracked:   true,
+	}
+	t.Cleanup(func() { delete(azdOperationTypes, "Test") })
+
+	infraPath := t.TempDir()
+	writeAzdOperations(t, infraPath, `operations:
+- type: Test
+  description: First
+  config:
+    name: first
+- type: Test
+  description: Second
+  config:
+    name: second
+`)
+
+	newManager := func(t *testing.T, env *environment.Environment, enabled bool) *Manager {
+		mockContext := mocks.NewMockContext(context.Background())
+		userConfig := config.NewEmptyConfig()
+		if enabled {
+			require.NoError(t, userConfig.Set("alpha.azd.operations", "on"))
+		}
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Save", mock.Anything, env).Return(nil)
+
+		return &Manager{
+			serviceLocator:      mockContext.Container,
+			envManager:          envManager,
+			env:                 env,
+			console:             mockContext.Console,
+			alphaFeatureManager: alpha.NewFeaturesManagerWithConfig(userConfig),
+			cloud:               cloud.AzurePublic(),
+		}
+	}
+
+	env := environment.New("test-env")
+
+	t.Run("disabled", func(t *testing.T) {
+		err := newManager(t, env, false).runAzdOperations(context.Background(), infraPath)
+		require.NoError(t, err)
+		require.Empty(t, runs)
+	})
+
+	t.Run("first provision runs all", func(t *testing.T) {
+		err := newManager(t, env, true).runAzdOperations(context.Background(), infraPath)
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{"first": 1, "second": 1}, runs)
+		require.Len(t, completedAzdOperations(env), 2)
+	})
+
+	t.Run("completed operations are skipped", func(t *testing.T) {
+		err := newManager(t, env, true).runAzdOperations(context.Background(), infraPath)
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{"first": 1, "second": 1}, runs)
+	})
+
+	t.Run("changed operation runs again", func(t *testing.T) {
+		writeAzdOperations(t, infraPath, `operations:
+- type: Test
+  description: First
+  config:
+    name: first
+- type: Test
+  description: Second
+  config:
+    name: second
+    version: 2
+`)
+
+		err := newManager(t, env, true).runAzdOperations(context.Background(), infraPath)
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{"first": 1, "second": 2}, runs)
+		// the fingerprint of the previous version of the operation is removed
+		require.Len(t, completedAzdOperations(env), 2)
+	})
+
+	t.Run("failed operation runs again", func(t *testing.T) {
+		writeAzdOperations(t, infraPath, `operations:
+- type: Test
+  description: Third
+  config:
+    name: third
+    fail: true
+`)
+
+		err := newManager(t, env, true).runAzdOperations(context.Background(), infraPath)
+		require.ErrorContains(t, err, "running Test operation 'Third': failed")
+		require.Equal(t, 1, runs["third"])
+
+		err = newManager(t, env, true).runAzdOperations(context.Background(), infraPath)
+		require.Error(t, err)
+		require.Equal(t, 2, runs["third"])
+	})
+}
+
+func Test_cosmosPartitionKey(t *testing.T) {
+	item := map[string]any{
+		"id":      "1",
+		"tenant":  map[string]any{"id": "contoso"},
+		"version": 2.0,
+	}
+
+	key, err := cosmosPartitionKey(item, "/id")
+	require.NoError(t, err)
+	require.Equal(t, `["1"]`, key)
+
+	key, err = cosmosPartitionKey(item, "/tenant/id")
+	require.NoError(t, err)
+	require.Equal(t, `["contoso"]`, key)
+
+	key, err = cosmosPartitionKey(item, "/version")
+	require.NoError(t, err)
+	require.Equal(t, `[2]`, key)
+
+	_, err = cosmosPartitionKey(item, "/category")
+	require.ErrorContains(t, err, "item has no value for the partition key '/category'")
+}
+
+func Test_sqlBatches(t *testing.T) {
+	script := "CREATE TABLE items (id INT);\nGO\n" +
+		"CREATE VIEW item_ids AS SELECT id FROM items;\r\n  go  \r\n" +
+		"INSERT INTO items VALUES (1); -- going\nGO;\n\nGO\n"
+
+	require.Equal(t, []string{
+		"CREATE TABLE items (id INT);\n",
+		"\nCREATE VIEW item_ids AS SELECT id FROM items;\r\n",
+		"\nINSERT INTO items VALUES (1); -- going\n",
+	}, sqlBatches(script))
+
+	require.Equal(t, []string{"SELECT 1;"}, sqlBatches("SELECT 1;"))
+}
+
+func Test_azdOperationSqlScript_tokenScope(t *testing.T) {
+	sqlServer := &azdOperationSqlScript{engine: sqlServerEngine}
+	postgreSql := &azdOperationSqlScript{engine: postgreSqlEngine}
+
+	require.Equal(t, "https://database.windows.net/.default", sqlServer.tokenScope(cloud.AzurePublic()))
+	require.Equal(t, "https://database.usgovcloudapi.net/.default", sqlServer.tokenScope(cloud.AzureGovernment()))
+	require.Equal(t,
+		"https://ossrdbms-aad.database.windows.net/.default", postgreSql.tokenScope(cloud.AzurePublic()))
+	require.Equal(t,
+		"https://ossrdbms-aad.database.chinacloudapi.cn/.default", postgreSql.tokenScope(cloud.AzureChina()))
+}
+
+func writeAzdOperations(t *testing.T, infraPath string, content string) {
+	err := os.WriteFile(filepath.Join(infraPath, azdOperationsFileName), []byte(content), osutil.PermissionFile)
+	require.NoError(t, err)
+}
+
+// testAzdOperation counts its runs, by name.
+type testAzdOperation struct {
+	Name    string
+	Version int
+	Fail    bool
+
+	runs map[string]int
+}
+
+func (op *testAzdOperation) resolvePaths(infraPath string) {}
+
+func (op *testAzdOperation) paths() []string {
+	return nil
+}
+
+func (op *testAzdOperation) run(ctx context.Context, opCtx *azdOperationContext) error {
+	op.runs[op.Name]++
+	if op.Fail {
+		return errFailedTestOperation
+	}
+	return nil
+}
+
+var errFailedTestOperation = errors.New("failed")
@@ -1502,6 +1502,16 @@ func (p *BicepProvider) mapBicepTypeToInterfaceType(s string) provisioning.Param
 	}
 }
 
+// isSecureBicepType returns true for the secure types of bicep, whose values are sensitive.
+func isSecureBicepType(s string) bool {
+	switch s {
+	case "secureString", "securestring", "secureObject", "secureobject":
+		return true
+	default:
+		return false
+	}
+}
+
 // Creates a normalized view of the azure output parameters and resolves inconsistencies in the output parameter name
 // casings.
 func (p *BicepProvider) createOutputParameters(
@@ -1529,8 +1539,9 @@ func (p *BicepProvider) createOutputParameters(
 		}
 
 		outputParams[paramName] = provisioning.OutputParameter{
-			Type:  p.mapBicepTypeToInterfaceType(azureParam.Type),
-			Value: azureParam.Value,
+			Type:   p.mapBicepTypeToInterfaceType(azureParam.Type),
+			Value:  azureParam.Value,
+			Secure: isSecureBicepType(azureParam.Type),
 		}
 	}
 
@@ -27,6 +27,8 @@ type InputParameter struct {
 type OutputParameter struct {
 	Type  ParameterType
 	Value interface{}
+	// Secure is set when the value is sensitive, in which case it is stored as a secret in the environment.
+	Secure bool
 }
 
 // State represents the "current state" of the infrastructure, which is the result of the most recent deployment. For ARM
@@ -45,6 +45,7 @@ const (
 	ChangeTypeIgnore      ChangeType = "Ignore"
 	ChangeTypeModify      ChangeType = "Modify"
 	ChangeTypeNoChange    ChangeType = "NoChange"
+	ChangeTypeReplace     ChangeType = "Replace"
 	ChangeTypeUnsupported ChangeType = "Unsupported"
 )
 
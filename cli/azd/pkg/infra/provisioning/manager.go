@@ -6,11 +6,10 @@ package provisioning
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"os"
 	"path/filepath"
 
+	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
@@ -18,11 +17,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
-	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
-	"github.com/azure/azure-dev/cli/azd/pkg/output"
-	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
-	"github.com/braydonk/yaml"
 )
 
 type DefaultProviderResolver func() (ProviderKind, error)
@@ -40,6 +35,7 @@ type Manager struct {
 	options             *Options
 	fileShareService    storage.FileShareService
 	cloud               *cloud.Cloud
+	externalProviders   *ExternalProviderRegistry
 }
 
 // defaultOptions for this package.
@@ -104,19 +100,8 @@ func (m *Manager) Deploy(ctx context.Context) (*DeployResult, error) {
 	if !filepath.IsAbs(infraRoot) {
 		infraRoot = filepath.Join(m.projectPath, m.options.Path)
 	}
-	bindMountOperations, err := azdFileShareUploadOperations(infraRoot, *m.env)
-	azdOperationsEnabled := m.alphaFeatureManager.IsEnabled(AzdOperationsFeatureKey)
-	if !azdOperationsEnabled && len(bindMountOperations) > 0 {
-		m.console.Message(ctx, ErrBindMountOperationDisabled.Error())
-	}
-	if azdOperationsEnabled {
-		if err != nil {
-			return nil, fmt.Errorf("looking for azd fileShare upload operations: %w", err)
-		}
-		if err := doBindMountOperation(
-			ctx, bindMountOperations, *m.env, m.console, m.fileShareService, m.cloud.StorageEndpointSuffix); err != nil {
-			return nil, fmt.Errorf("error running bind mount operation: %w", err)
-		}
+	if err := m.runAzdOperations(ctx, infraRoot); err != nil {
+		return nil, err
 	}
 
 	// make sure any spinner is stopped
@@ -125,133 +110,6 @@ func (m *Manager) Deploy(ctx context.Context) (*DeployResult, error) {
 	return deployResult, nil
 }
 
-const (
-	fileShareUploadOperation string = "FileShareUpload"
-	azdOperationsFileName    string = "azd.operations.yaml"
-)
-
-type azdOperation struct {
-	Type        string
-	Description string
-	Config      any
-}
-
-type azdOperationFileShareUpload struct {
-	Description    string
-	StorageAccount string
-	FileShareName  string
-	Path           string
-}
-
-type azdOperationsModel struct {
-	Operations []azdOperation
-}
-
-func azdOperations(infraPath string, env environment.Environment) (azdOperationsModel, error) {
-	path := filepath.Join(infraPath, azdOperationsFileName)
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			// file not found is not an error, there's just nothing to do
-			return azdOperationsModel{}, nil
-		}
-		return azdOperationsModel{}, err
-	}
-
-	// resolve environment variables
-	expString := osutil.NewExpandableString(string(data))
-	evaluated, err := expString.Envsubst(env.Getenv)
-	if err != nil {
-		return azdOperationsModel{}, err
-	}
-	data = []byte(evaluated)
-
-	// Unmarshal the file into azdOperationsModel
-	var operations azdOperationsModel
-	err = yaml.Unmarshal(data, &operations)
-	if err != nil {
-		return azdOperationsModel{}, err
-	}
-
-	return operations, nil
-}
-
-func azdFileShareUploadOperations(infraPath string, env environment.Environment) ([]azdOperationFileShareUpload, error) {
-	model, err := azdOperations(infraPath, env)
-	if err != nil {
-		return nil, err
-	}
-
-	var fileShareUploadOperations []azdOperationFileShareUpload
-	for _, operation := range model.Operations {
-		if operation.Type == fileShareUploadOperation {
-			var fileShareUpload azdOperationFileShareUpload
-			bytes, err := json.Marshal(operation.Config)
-			if err != nil {
-				return nil, err
-			}
-			err = json.Unmarshal(bytes, &fileShareUpload)
-			if err != nil {
-				return nil, err
-			}
-			fileShareUpload.Description = operation.Description
-			fileShareUploadOperations = append(fileShareUploadOperations, fileShareUpload)
-		}
-	}
-	return fileShareUploadOperations, nil
-}
-
-var ErrAzdOperationsNotEnabled = fmt.Errorf(
-	"azd operations (alpha feature) is required but disabled. You can enable azd operations by running: %s",
-	output.WithGrayFormat("%s", alpha.GetEnableCommand(AzdOperationsFeatureKey)))
-
-var ErrBindMountOperationDisabled = fmt.Errorf(
-	"%sYour project has bind mounts.\n  - %w\n%s\n",
-	output.WithWarningFormat("*Note: "),
-	ErrAzdOperationsNotEnabled,
-	output.WithWarningFormat("Ignoring bind mounts."),
-)
-
-func doBindMountOperation(
-	ctx context.Context,
-	fileShareUploadOperations []azdOperationFileShareUpload,
-	env environment.Environment,
-	console input.Console,
-	fileShareService storage.FileShareService,
-	cloudStorageEndpointSuffix string,
-) error {
-	if len(fileShareUploadOperations) > 0 {
-		console.ShowSpinner(ctx, "uploading files to fileShare", input.StepFailed)
-	}
-	for _, op := range fileShareUploadOperations {
-		if err := bindMountOperation(
-			ctx,
-			fileShareService,
-			cloudStorageEndpointSuffix,
-			env.GetSubscriptionId(),
-			op.StorageAccount,
-			op.FileShareName,
-			op.Path); err != nil {
-			return fmt.Errorf("error binding mount: %w", err)
-		}
-		console.MessageUxItem(ctx, &ux.DisplayedResource{
-			Type:  fileShareUploadOperation,
-			Name:  op.Description,
-			State: ux.SucceededState,
-		})
-	}
-	return nil
-}
-
-func bindMountOperation(
-	ctx context.Context,
-	fileShareService storage.FileShareService,
-	cloud, subId, storageAccount, fileShareName, source string) error {
-
-	shareUrl := fmt.Sprintf("https://%s.file.%s/%s", storageAccount, cloud, fileShareName)
-	return fileShareService.UploadPath(ctx, subId, shareUrl, source)
-}
-
 // Preview generates the list of changes to be applied as part of the provisioning.
 func (m *Manager) Preview(ctx context.Context) (*DeployPreviewResult, error) {
 	// Apply the infrastructure deployment
@@ -319,9 +177,9 @@ func (m *Manager) UpdateEnvironment(
 				if err != nil {
 					return fmt.Errorf("invalid value for output parameter '%s' (%s): %w", key, string(param.Type), err)
 				}
-				m.env.DotenvSet(key, string(bytes))
+				m.setEnvValue(key, string(bytes), param.Secure)
 			} else {
-				m.env.DotenvSet(key, fmt.Sprintf("%v", param.Value))
+				m.setEnvValue(key, fmt.Sprintf("%v", param.Value), param.Secure)
 			}
 		}
 
@@ -333,6 +191,15 @@ func (m *Manager) UpdateEnvironment(
 	return nil
 }
 
+// setEnvValue sets the value of an output in the environment, as a secret when the output is secure.
+func (m *Manager) setEnvValue(key string, value string, secure bool) {
+	if secure {
+		m.env.DotenvSetSecret(key, value)
+	} else {
+		m.env.DotenvSet(key, value)
+	}
+}
+
 type EnsureSubscriptionAndLocationOptions struct {
 	// LocationFilterPredicate is a function to filter the locations being displayed if prompting the user for the location.
 	LocationFiler prompt.LocationFilterPredicate
@@ -424,6 +291,7 @@ func NewManager(
 	alphaFeatureManager *alpha.FeatureManager,
 	fileShareService storage.FileShareService,
 	cloud *cloud.Cloud,
+	externalProviders *ExternalProviderRegistry,
 ) *Manager {
 	return &Manager{
 		serviceLocator:      serviceLocator,
@@ -434,6 +302,7 @@ func NewManager(
 		alphaFeatureManager: alphaFeatureManager,
 		fileShareService:    fileShareService,
 		cloud:               cloud,
+		externalProviders:   externalProviders,
 	}
 }
 
@@ -465,9 +334,25 @@ func (m *Manager) newProvider(ctx context.Context) (Provider, error) {
 		providerKey = defaultProvider
 	}
 
+	// Providers that azd doesn't provide are resolved to the provider registered by an extension
+	if externalProvider, has := m.externalProviders.Get(providerKey); has {
+		return externalProvider, nil
+	}
+
 	var provider Provider
 	err = m.serviceLocator.ResolveNamed(string(providerKey), &provider)
 	if err != nil {
+		if !providerKey.IsBuiltIn() {
+			return nil, &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf("unsupported IaC provider '%s'", providerKey),
+				Suggestion: fmt.Sprintf(
+					"Use one of the IaC providers supported by azd, or install an extension that provides the '%s' "+
+						"provider.",
+					providerKey,
+				),
+			}
+		}
+
 		return nil, fmt.Errorf("failed resolving IaC provider '%s': %w", providerKey, err)
 	}
 
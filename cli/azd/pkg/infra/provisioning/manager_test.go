@@ -5,6 +5,7 @@ package provisioning_test
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -54,6 +55,7 @@ func TestProvisionInitializesEnvironment(t *testing.T) {
 		mockContext.AlphaFeaturesManager,
 		nil,
 		cloud.AzurePublic(),
+		provisioning.NewExternalProviderRegistry(),
 	)
 	err := mgr.Initialize(*mockContext.Context, "", provisioning.Options{Provider: "test"})
 	require.NoError(t, err)
@@ -81,6 +83,7 @@ func TestManagerPreview(t *testing.T) {
 		mockContext.AlphaFeaturesManager,
 		nil,
 		cloud.AzurePublic(),
+		provisioning.NewExternalProviderRegistry(),
 	)
 	err := mgr.Initialize(*mockContext.Context, "", provisioning.Options{Provider: "test"})
 	require.NoError(t, err)
@@ -110,6 +113,7 @@ func TestManagerGetState(t *testing.T) {
 		mockContext.AlphaFeaturesManager,
 		nil,
 		cloud.AzurePublic(),
+		provisioning.NewExternalProviderRegistry(),
 	)
 	err := mgr.Initialize(*mockContext.Context, "", provisioning.Options{Provider: "test"})
 	require.NoError(t, err)
@@ -139,6 +143,7 @@ func TestManagerDeploy(t *testing.T) {
 		mockContext.AlphaFeaturesManager,
 		nil,
 		cloud.AzurePublic(),
+		provisioning.NewExternalProviderRegistry(),
 	)
 	err := mgr.Initialize(*mockContext.Context, "", provisioning.Options{Provider: "test"})
 	require.NoError(t, err)
@@ -174,6 +179,7 @@ func TestManagerDestroyWithPositiveConfirmation(t *testing.T) {
 		mockContext.AlphaFeaturesManager,
 		nil,
 		cloud.AzurePublic(),
+		provisioning.NewExternalProviderRegistry(),
 	)
 	err := mgr.Initialize(*mockContext.Context, "", provisioning.Options{Provider: "test"})
 	require.NoError(t, err)
@@ -210,6 +216,7 @@ func TestManagerDestroyWithNegativeConfirmation(t *testing.T) {
 		mockContext.AlphaFeaturesManager,
 		nil,
 		cloud.AzurePublic(),
+		provisioning.NewExternalProviderRegistry(),
 	)
 	err := mgr.Initialize(*mockContext.Context, "", provisioning.Options{Provider: "test"})
 	require.NoError(t, err)
@@ -222,6 +229,113 @@ func TestManagerDestroyWithNegativeConfirmation(t *testing.T) {
 	require.Contains(t, mockContext.Console.Output(), "Are you sure you want to destroy?")
 }
 
+func TestManagerExternalProvider(t *testing.T) {
+	env := environment.NewWithValues("test-env", map[string]string{
+		"AZURE_SUBSCRIPTION_ID": "SUBSCRIPTION_ID",
+		"AZURE_LOCATION":        "eastus2",
+	})
+
+	mockContext := mocks.NewMockContext(context.Background())
+	registerContainerDependencies(mockContext, env)
+
+	externalProvider := &fakeExternalProvider{
+		outputs: map[string]provisioning.OutputParameter{
+			"WEBSITE_URL": {Type: provisioning.ParameterTypeString, Value: "https://example.com"},
+		},
+	}
+	externalProviders := provisioning.NewExternalProviderRegistry()
+	require.NoError(t, externalProviders.Register("opentofu", externalProvider))
+
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Save", *mockContext.Context, env).Return(nil)
+
+	newManager := func() *provisioning.Manager {
+		return provisioning.NewManager(
+			mockContext.Container,
+			defaultProvider,
+			envManager,
+			env,
+			mockContext.Console,
+			mockContext.AlphaFeaturesManager,
+			nil,
+			cloud.AzurePublic(),
+			externalProviders,
+		)
+	}
+
+	t.Run("Registered", func(t *testing.T) {
+		mgr := newManager()
+		err := mgr.Initialize(*mockContext.Context, "", provisioning.Options{Provider: "opentofu"})
+		require.NoError(t, err)
+		require.Equal(t, "infra", externalProvider.options.Path)
+
+		deployResult, err := mgr.Deploy(*mockContext.Context)
+		require.NoError(t, err)
+		require.NotNil(t, deployResult)
+		require.Equal(t, "https://example.com", env.Getenv("WEBSITE_URL"))
+	})
+
+	t.Run("NotRegistered", func(t *testing.T) {
+		mgr := newManager()
+		err := mgr.Initialize(*mockContext.Context, "", provisioning.Options{Provider: "crossplane"})
+		require.ErrorContains(t, err, "unsupported IaC provider 'crossplane'")
+	})
+
+	t.Run("Misspelled", func(t *testing.T) {
+		mgr := newManager()
+		err := mgr.Initialize(*mockContext.Context, "", provisioning.Options{Provider: "terrafrom"})
+		require.ErrorContains(t, err, "unsupported IaC provider 'terrafrom': did you mean 'terraform'?")
+	})
+
+	t.Run("BuiltInProvider", func(t *testing.T) {
+		for _, kind := range []provisioning.ProviderKind{provisioning.Terraform, provisioning.DevCenter} {
+			err := externalProviders.Register(kind, &fakeExternalProvider{})
+			require.ErrorContains(t, err, fmt.Sprintf("provider '%s' is provided by azd", kind))
+		}
+	})
+}
+
+// fakeExternalProvider is a provisioning provider registered outside of azd
+type fakeExternalProvider struct {
+	options provisioning.Options
+	outputs map[string]provisioning.OutputParameter
+}
+
+func (p *fakeExternalProvider) Name() string {
+	return "Fake External"
+}
+
+func (p *fakeExternalProvider) Initialize(ctx context.Context, projectPath string, options provisioning.Options) error {
+	p.options = options
+	return nil
+}
+
+func (p *fakeExternalProvider) State(
+	ctx context.Context,
+	options *provisioning.StateOptions,
+) (*provisioning.StateResult, error) {
+	return &provisioning.StateResult{State: &provisioning.State{Outputs: p.outputs}}, nil
+}
+
+func (p *fakeExternalProvider) Deploy(ctx context.Context) (*provisioning.DeployResult, error) {
+	return &provisioning.DeployResult{Deployment: &provisioning.Deployment{Outputs: p.outputs}}, nil
+}
+
+func (p *fakeExternalProvider) Preview(ctx context.Context) (*provisioning.DeployPreviewResult, error) {
+	return &provisioning.DeployPreviewResult{}, nil
+}
+
+func (p *fakeExternalProvider) Destroy(
+	ctx context.Context,
+	options provisioning.DestroyOptions,
+) (*provisioning.DestroyResult, error) {
+	return &provisioning.DestroyResult{}, nil
+}
+
+func (p *fakeExternalProvider) EnsureEnv(ctx context.Context) error {
+	return nil
+}
+
 func registerContainerDependencies(mockContext *mocks.MockContext, env *environment.Environment) {
 	envManager := &mockenv.MockEnvManager{}
 	envManager.On("Save", *mockContext.Context, env).Return(nil)
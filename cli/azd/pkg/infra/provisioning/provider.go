@@ -5,6 +5,7 @@ package provisioning
 
 import (
 	"context"
+	"slices"
 )
 
 type ProviderKind string
@@ -15,9 +16,27 @@ const (
 	Arm          ProviderKind = "arm"
 	Terraform    ProviderKind = "terraform"
 	Pulumi       ProviderKind = "pulumi"
+	DevCenter    ProviderKind = "devcenter"
 	Test         ProviderKind = "test"
 )
 
+// builtInProviderKinds are the IaC provider kinds that azd provides.
+var builtInProviderKinds = []ProviderKind{
+	Bicep,
+	Arm,
+	Terraform,
+	Pulumi,
+	DevCenter,
+}
+
+// IsBuiltIn returns true when the provider kind is provided by azd.
+//
+// For the time being we need to include `Test` here for the unit tests to work as expected
+// App builds will pass this test but fail resolving the provider since `Test` won't be registered in the container
+func (kind ProviderKind) IsBuiltIn() bool {
+	return kind == NotSpecified || kind == Test || slices.Contains(builtInProviderKinds, kind)
+}
+
 type Options struct {
 	Provider         ProviderKind   `yaml:"provider,omitempty"`
 	Path             string         `yaml:"path,omitempty"`
@@ -0,0 +1,62 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ExternalProviderRegistry stores the provisioning providers that are provided outside of azd, such as by extensions,
+// for provider kinds that azd doesn't support.
+type ExternalProviderRegistry struct {
+	providers map[ProviderKind]Provider
+	mu        sync.RWMutex
+}
+
+// NewExternalProviderRegistry creates a new, empty instance of the ExternalProviderRegistry
+func NewExternalProviderRegistry() *ExternalProviderRegistry {
+	return &ExternalProviderRegistry{
+		providers: map[ProviderKind]Provider{},
+	}
+}
+
+// Register registers the provisioning provider for the specified provider kind.
+// Provider kinds that azd provides, or that have already been registered, can't be registered.
+func (r *ExternalProviderRegistry) Register(kind ProviderKind, provider Provider) error {
+	if kind.IsBuiltIn() {
+		return fmt.Errorf("provider '%s' is provided by azd and can't be registered", kind)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, has := r.providers[kind]; has {
+		return fmt.Errorf("provider '%s' has already been registered", kind)
+	}
+
+	r.providers[kind] = provider
+
+	return nil
+}
+
+// Unregister removes the provisioning provider registered for the specified provider kind,
+// when it's still the registered provisioning provider of the provider kind.
+func (r *ExternalProviderRegistry) Unregister(kind ProviderKind, provider Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if registered, has := r.providers[kind]; has && registered == provider {
+		delete(r.providers, kind)
+	}
+}
+
+// Get returns the provisioning provider registered for the specified provider kind
+func (r *ExternalProviderRegistry) Get(kind ProviderKind) (Provider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	provider, has := r.providers[kind]
+	return provider, has
+}
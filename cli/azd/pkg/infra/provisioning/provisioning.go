@@ -6,6 +6,7 @@ package provisioning
 import (
 	"fmt"
 
+	"github.com/azure/azure-dev/cli/azd/internal/names"
 	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 )
 
@@ -52,13 +53,21 @@ func NewEnvRefreshResultFromState(state *State) contracts.EnvRefreshResult {
 
 // Parses the specified IaC Provider to ensure whether it is valid or not
 // Defaults to `Bicep` if no provider is specified
+// Providers that azd doesn't provide are accepted when they are valid names, since they can be provided by extensions.
+// Whether such a provider is available is validated when the provider is resolved.
 func ParseProvider(kind ProviderKind) (ProviderKind, error) {
-	switch kind {
-	// For the time being we need to include `Test` here for the unit tests to work as expected
-	// App builds will pass this test but fail resolving the provider since `Test` won't be registered in the container
-	case NotSpecified, Bicep, Terraform, Test:
+	// The arm provider is only used internally and can't be selected in azure.yaml
+	if kind == Arm {
+		return ProviderKind(""), fmt.Errorf("unsupported IaC provider '%s'", kind)
+	}
+
+	if kind.IsBuiltIn() {
 		return kind, nil
 	}
 
-	return ProviderKind(""), fmt.Errorf("unsupported IaC provider '%s'", kind)
+	if err := names.ValidateExtensionKind(kind, builtInProviderKinds); err != nil {
+		return ProviderKind(""), fmt.Errorf("unsupported IaC provider '%s': %w", kind, err)
+	}
+
+	return kind, nil
 }
@@ -0,0 +1,713 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pulumi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/pulumi"
+	"github.com/braydonk/yaml"
+	"github.com/drone/envsubst"
+)
+
+const (
+	defaultModule = "main"
+	defaultPath   = "infra"
+
+	// BackendUrlEnvVarName is the environment variable that configures the Pulumi state backend, as `pulumi login`
+	// would.
+	BackendUrlEnvVarName = "PULUMI_BACKEND_URL"
+
+	passphraseEnvVarName     = "PULUMI_CONFIG_PASSPHRASE"
+	passphraseFileEnvVarName = "PULUMI_CONFIG_PASSPHRASE_FILE"
+
+	// pulumiSecretValue replaces the values of secret outputs unless they are shown.
+	pulumiSecretValue = "[secret]"
+)
+
+// PulumiProvider exposes infrastructure provisioning using Pulumi programs
+type PulumiProvider struct {
+	envManager   environment.Manager
+	env          *environment.Environment
+	prompters    prompt.Prompter
+	console      input.Console
+	cli          *pulumi.Cli
+	curPrincipal provisioning.CurrentPrincipalIdProvider
+	projectPath  string
+	options      provisioning.Options
+}
+
+// Name gets the name of the infra provider
+func (p *PulumiProvider) Name() string {
+	return "Pulumi"
+}
+
+func (p *PulumiProvider) RequiredExternalTools() []tools.ExternalTool {
+	return []tools.ExternalTool{p.cli}
+}
+
+// NewPulumiProvider creates a new instance of a Pulumi Infra provider
+func NewPulumiProvider(
+	cli *pulumi.Cli,
+	envManager environment.Manager,
+	env *environment.Environment,
+	console input.Console,
+	curPrincipal provisioning.CurrentPrincipalIdProvider,
+	prompters prompt.Prompter,
+) provisioning.Provider {
+	return &PulumiProvider{
+		envManager:   envManager,
+		env:          env,
+		console:      console,
+		cli:          cli,
+		curPrincipal: curPrincipal,
+		prompters:    prompters,
+	}
+}
+
+func (p *PulumiProvider) Initialize(ctx context.Context, projectPath string, options provisioning.Options) error {
+	p.projectPath = projectPath
+	p.options = options
+	if p.options.Module == "" {
+		p.options.Module = defaultModule
+	}
+	if p.options.Path == "" {
+		p.options.Path = defaultPath
+	}
+
+	requiredTools := p.RequiredExternalTools()
+	if err := tools.EnsureInstalled(ctx, requiredTools...); err != nil {
+		return err
+	}
+
+	if err := p.EnsureEnv(ctx); err != nil {
+		return err
+	}
+
+	backendUrl, err := p.backendUrl()
+	if err != nil {
+		return err
+	}
+
+	// Values of the environment are available to the Pulumi program, the values below take precedence over them.
+	envVars := p.env.Environ()
+	envVars = append(envVars,
+		fmt.Sprintf("%s=%s", BackendUrlEnvVarName, backendUrl),
+		// Required when using service principal login
+		fmt.Sprintf("ARM_TENANT_ID=%s", os.Getenv("ARM_TENANT_ID")),
+		fmt.Sprintf("ARM_SUBSCRIPTION_ID=%s", p.env.GetSubscriptionId()),
+		fmt.Sprintf("ARM_CLIENT_ID=%s", os.Getenv("ARM_CLIENT_ID")),
+		fmt.Sprintf("ARM_CLIENT_SECRET=%s", os.Getenv("ARM_CLIENT_SECRET")),
+		fmt.Sprintf("ARM_LOCATION=%s", p.env.GetLocation()),
+	)
+
+	passphraseEnv, err := p.passphraseEnv(backendUrl)
+	if err != nil {
+		return err
+	}
+	envVars = append(envVars, passphraseEnv...)
+
+	p.cli.SetEnv(envVars)
+	return nil
+}
+
+// EnsureEnv ensures that the environment is in a provision-ready state with required values set, prompting the user if
+// values are unset.
+//
+// An environment is considered to be in a provision-ready state if it contains both an AZURE_SUBSCRIPTION_ID and
+// AZURE_LOCATION value.
+func (p *PulumiProvider) EnsureEnv(ctx context.Context) error {
+	return provisioning.EnsureSubscriptionAndLocation(
+		ctx,
+		p.envManager,
+		p.env,
+		p.prompters,
+		provisioning.EnsureSubscriptionAndLocationOptions{},
+	)
+}
+
+// Deploy the infrastructure within the specified program through pulumi up
+func (p *PulumiProvider) Deploy(ctx context.Context) (*provisioning.DeployResult, error) {
+	deployment, err := p.prepareStack(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	modulePath := p.modulePath()
+	runResult, err := p.cli.Up(ctx, modulePath, p.stackName())
+	if err != nil {
+		return nil, fmt.Errorf("pulumi up failed: %s, err: %w", runResult, err)
+	}
+
+	outputs, err := p.createOutputParameters(ctx, modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading pulumi stack outputs failed: %w", err)
+	}
+
+	deployment.Outputs = outputs
+	return &provisioning.DeployResult{
+		Deployment: deployment,
+	}, nil
+}
+
+// Preview the changes to the infrastructure through pulumi preview
+func (p *PulumiProvider) Preview(ctx context.Context) (*provisioning.DeployPreviewResult, error) {
+	if _, err := p.prepareStack(ctx); err != nil {
+		return nil, err
+	}
+
+	runResult, err := p.cli.Preview(ctx, p.modulePath(), p.stackName())
+	if err != nil {
+		return nil, fmt.Errorf("pulumi preview failed: %w", err)
+	}
+
+	var preview pulumiPreviewOutput
+	if err := json.Unmarshal([]byte(runResult), &preview); err != nil {
+		return nil, fmt.Errorf("parsing pulumi preview output: %w", err)
+	}
+
+	return &provisioning.DeployPreviewResult{
+		Preview: &provisioning.DeploymentPreview{
+			Status: "done",
+			Properties: &provisioning.DeploymentPreviewProperties{
+				Changes: p.convertPreviewSteps(preview.Steps),
+			},
+		},
+	}, nil
+}
+
+// Destroys the resources of the stack through pulumi destroy
+func (p *PulumiProvider) Destroy(
+	ctx context.Context,
+	options provisioning.DestroyOptions,
+) (*provisioning.DestroyResult, error) {
+	if err := p.selectStack(ctx); err != nil {
+		return nil, err
+	}
+
+	//load the deployment result
+	modulePath := p.modulePath()
+	outputs, err := p.createOutputParameters(ctx, modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading pulumi stack outputs failed: %w", err)
+	}
+
+	p.console.Message(ctx, "Deleting pulumi stack resources...")
+	// pulumi doesn't use the `p.console`, we must ensure no spinner is running before calling Destroy
+	// as it could be an interactive operation if it needs confirmation
+	p.console.StopSpinner(ctx, "", input.Step)
+	destroyArgs := []string{}
+	if options.Force() {
+		destroyArgs = append(destroyArgs, "--yes", "--skip-preview")
+	}
+
+	runResult, err := p.cli.Destroy(ctx, modulePath, p.stackName(), destroyArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("pulumi destroy failed: %s, err: %w", runResult, err)
+	}
+
+	return &provisioning.DestroyResult{
+		InvalidatedEnvKeys: slices.Collect(maps.Keys(outputs)),
+	}, nil
+}
+
+func (p *PulumiProvider) State(
+	ctx context.Context,
+	options *provisioning.StateOptions,
+) (*provisioning.StateResult, error) {
+	p.console.Message(ctx, "Retrieving pulumi state...")
+	if err := p.selectStack(ctx); err != nil {
+		return nil, err
+	}
+
+	modulePath := p.modulePath()
+	outputs, err := p.createOutputParameters(ctx, modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading pulumi stack outputs failed: %w", err)
+	}
+
+	runResult, err := p.cli.StackExport(ctx, modulePath, p.stackName())
+	if err != nil {
+		return nil, fmt.Errorf("exporting pulumi stack failed: %w", err)
+	}
+
+	var export pulumiStackExport
+	if err := json.Unmarshal([]byte(runResult), &export); err != nil {
+		return nil, fmt.Errorf("parsing pulumi stack export: %w", err)
+	}
+
+	return &provisioning.StateResult{
+		State: &provisioning.State{
+			Outputs:   outputs,
+			Resources: p.collectAzureResources(export.Deployment.Resources),
+		},
+	}, nil
+}
+
+// prepareStack selects the stack of the environment and applies the configuration of the program to it.
+func (p *PulumiProvider) prepareStack(ctx context.Context) (*provisioning.Deployment, error) {
+	if err := p.selectStack(ctx); err != nil {
+		return nil, err
+	}
+
+	deployment, config, err := p.createDeployment(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating pulumi configuration failed: %w", err)
+	}
+
+	if _, err := p.cli.SetConfig(ctx, p.modulePath(), p.stackName(), config); err != nil {
+		return nil, err
+	}
+
+	return deployment, nil
+}
+
+// selectStack selects the stack of the environment, creating it when it does not exist yet.
+func (p *PulumiProvider) selectStack(ctx context.Context) error {
+	if err := p.ensureLocalBackend(); err != nil {
+		return err
+	}
+
+	runResult, err := p.cli.SelectStack(ctx, p.modulePath(), p.stackName())
+	if err != nil {
+		return fmt.Errorf("pulumi stack select failed: %s, err: %w", runResult, err)
+	}
+
+	return nil
+}
+
+// Creates the deployment object, and the stack configuration values, from the configuration file of the program.
+// The configuration file is optional.
+func (p *PulumiProvider) createDeployment(ctx context.Context) (*provisioning.Deployment, map[string]string, error) {
+	parameters := make(map[string]any)
+
+	configFilePath := p.configTemplateFilePath()
+	log.Printf("Reading configuration template file from: %s", configFilePath)
+	configBytes, err := os.ReadFile(configFilePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, fmt.Errorf("reading configuration file template: %w", err)
+	}
+
+	if err == nil {
+		replaced, err := p.substitute(ctx, string(configBytes))
+		if err != nil {
+			return nil, nil, fmt.Errorf("substituting configuration file: %w", err)
+		}
+
+		if err := json.Unmarshal([]byte(replaced), &parameters); err != nil {
+			return nil, nil, fmt.Errorf("error unmarshalling configuration values: %w", err)
+		}
+	}
+
+	templateParameters := make(map[string]provisioning.InputParameter)
+	config := make(map[string]string)
+	for key, value := range parameters {
+		templateParameters[key] = provisioning.InputParameter{
+			Type:  string(parameterType(value)),
+			Value: value,
+		}
+
+		// Pulumi configuration values are strings, structured values are read by programs as JSON.
+		if str, ok := value.(string); ok {
+			config[key] = str
+		} else {
+			jsonValue, err := json.Marshal(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("marshalling configuration value %s: %w", key, err)
+			}
+			config[key] = string(jsonValue)
+		}
+	}
+
+	return &provisioning.Deployment{
+		Parameters: templateParameters,
+	}, config, nil
+}
+
+// Replaces environment variable references in the given value.
+func (p *PulumiProvider) substitute(ctx context.Context, value string) (string, error) {
+	principalId, err := p.curPrincipal.CurrentPrincipalId(ctx)
+	if err != nil {
+		return "", fmt.Errorf("fetching current principal id: %w", err)
+	}
+
+	return envsubst.Eval(value, func(name string) string {
+		if name == environment.PrincipalIdEnvVarName {
+			return principalId
+		}
+
+		return p.env.Getenv(name)
+	})
+}
+
+// Creates a normalized view of the pulumi stack outputs.
+func (p *PulumiProvider) createOutputParameters(
+	ctx context.Context,
+	modulePath string,
+) (map[string]provisioning.OutputParameter, error) {
+	runResult, err := p.cli.StackOutput(ctx, modulePath, p.stackName(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	var outputMap map[string]any
+	if err := json.Unmarshal([]byte(runResult), &outputMap); err != nil {
+		return nil, err
+	}
+
+	// the values of secret outputs are masked when they aren't shown, which tells them apart
+	maskedResult, err := p.cli.StackOutput(ctx, modulePath, p.stackName(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	var maskedOutputMap map[string]any
+	if err := json.Unmarshal([]byte(maskedResult), &maskedOutputMap); err != nil {
+		return nil, err
+	}
+
+	outputParameters := make(map[string]provisioning.OutputParameter)
+	for k, v := range outputMap {
+		if v == nil {
+			// omit null
+			continue
+		}
+
+		outputParameters[k] = provisioning.OutputParameter{
+			Type:   parameterType(v),
+			Value:  v,
+			Secure: maskedOutputMap[k] == pulumiSecretValue,
+		}
+	}
+
+	return outputParameters, nil
+}
+
+// parameterType maps a JSON value to the parameter type shared by all provider implementations.
+func parameterType(value any) provisioning.ParameterType {
+	switch value.(type) {
+	case bool:
+		return provisioning.ParameterTypeBoolean
+	case float64:
+		return provisioning.ParameterTypeNumber
+	case []any:
+		return provisioning.ParameterTypeArray
+	case map[string]any:
+		return provisioning.ParameterTypeObject
+	default:
+		return provisioning.ParameterTypeString
+	}
+}
+
+// convertPreviewSteps converts the steps of a pulumi preview to the changes shared by all provider implementations.
+// Only the custom resources of Azure providers are considered.
+func (p *PulumiProvider) convertPreviewSteps(steps []pulumiPreviewStep) []*provisioning.DeploymentPreviewChange {
+	var changes []*provisioning.DeploymentPreviewChange
+	for _, step := range steps {
+		changeType, has := pulumiChangeTypes[step.Op]
+		if !has {
+			continue
+		}
+
+		state := step.NewState
+		if state == nil {
+			state = step.OldState
+		}
+
+		if state == nil || !state.Custom || !isAzureResourceType(state.Type) {
+			continue
+		}
+
+		change := &provisioning.DeploymentPreviewChange{
+			ChangeType:   changeType,
+			ResourceType: state.Type,
+			Name:         resourceName(step.Urn),
+		}
+		if step.OldState != nil {
+			change.ResourceId = provisioning.Resource{Id: step.OldState.Id}
+			change.Before = step.OldState.Outputs
+		}
+		if step.NewState != nil {
+			change.After = step.NewState.Inputs
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// pulumiChangeTypes maps the operations of a pulumi preview to change types. Replacements are reported once, as
+// modifications, through the 'replace' step.
+var pulumiChangeTypes = map[string]provisioning.ChangeType{
+	"create":  provisioning.ChangeTypeCreate,
+	"import":  provisioning.ChangeTypeCreate,
+	"update":  provisioning.ChangeTypeModify,
+	"replace": provisioning.ChangeTypeModify,
+	"delete":  provisioning.ChangeTypeDelete,
+	"same":    provisioning.ChangeTypeNoChange,
+}
+
+// isAzureResourceType reports whether the pulumi resource type, like 'azure-native:resources:ResourceGroup', is
+// managed by one of the Azure providers.
+func isAzureResourceType(resourceType string) bool {
+	provider, _, _ := strings.Cut(resourceType, ":")
+	return provider == "azure-native" || provider == "azure" || provider == "azapi"
+}
+
+// resourceName returns the name of a resource from its URN, which ends with '::<name>'.
+func resourceName(urn string) string {
+	if i := strings.LastIndex(urn, "::"); i >= 0 {
+		return urn[i+2:]
+	}
+
+	return urn
+}
+
+// collectAzureResources collects the set of Azure resources managed by the stack, identified by their resource id.
+func (p *PulumiProvider) collectAzureResources(stackResources []pulumiResource) []provisioning.Resource {
+	resources := []provisioning.Resource{}
+	seen := map[string]struct{}{}
+	for _, r := range stackResources {
+		if !r.Custom || !strings.HasPrefix(r.Id, "/subscriptions/") {
+			continue
+		}
+
+		if _, has := seen[r.Id]; has {
+			continue
+		}
+
+		seen[r.Id] = struct{}{}
+		resources = append(resources, provisioning.Resource{
+			Id: r.Id,
+		})
+	}
+
+	return resources
+}
+
+// backendUrl returns the URL of the state backend of the stack. The backend is read from the PULUMI_BACKEND_URL value of
+// the environment, then from the project file of the program, and defaults to a local directory within the environment.
+//
+// Local file backends and Azure Blob Storage backends are supported.
+func (p *PulumiProvider) backendUrl() (string, error) {
+	backendUrl := p.env.Getenv(BackendUrlEnvVarName)
+	if backendUrl == "" {
+		projectBackendUrl, err := p.projectBackendUrl()
+		if err != nil {
+			return "", err
+		}
+
+		backendUrl = projectBackendUrl
+	}
+
+	if backendUrl == "" {
+		return "file://" + filepath.ToSlash(p.localStateDirPath()), nil
+	}
+
+	replaced, err := envsubst.Eval(backendUrl, p.env.Getenv)
+	if err != nil {
+		return "", fmt.Errorf("substituting pulumi backend url: %w", err)
+	}
+
+	parsed, err := url.Parse(replaced)
+	if err != nil {
+		return "", fmt.Errorf("parsing pulumi backend url: %w", err)
+	}
+
+	switch parsed.Scheme {
+	case "file", "azblob":
+		return replaced, nil
+	default:
+		return "", fmt.Errorf(
+			"unsupported pulumi backend '%s', only local file (file://) and Azure Blob Storage (azblob://) backends "+
+				"are supported",
+			replaced,
+		)
+	}
+}
+
+// projectBackendUrl returns the backend url set in the Pulumi.yaml project file of the program, if any.
+func (p *PulumiProvider) projectBackendUrl() (string, error) {
+	for _, fileName := range []string{"Pulumi.yaml", "Pulumi.yml"} {
+		contents, err := os.ReadFile(filepath.Join(p.modulePath(), fileName))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return "", fmt.Errorf("reading pulumi project file: %w", err)
+		}
+
+		var project pulumiProject
+		if err := yaml.Unmarshal(contents, &project); err != nil {
+			return "", fmt.Errorf("parsing pulumi project file: %w", err)
+		}
+
+		return project.Backend.Url, nil
+	}
+
+	return "", nil
+}
+
+// passphraseEnv returns the environment variables that configure the passphrase the secrets of the stack are encrypted
+// with. Stacks stored in self-managed backends encrypt their secrets with a passphrase, unless a secrets provider is
+// configured for the stack.
+//
+// An empty passphrase is used for the local file backend unless the user has configured one, so that commands don't
+// prompt for it. Stacks of shared backends would have their secrets protected by an empty passphrase, so a passphrase
+// or a secrets provider is required for them.
+func (p *PulumiProvider) passphraseEnv(backendUrl string) ([]string, error) {
+	_, hasPassphrase := p.env.LookupEnv(passphraseEnvVarName)
+	_, hasPassphraseFile := p.env.LookupEnv(passphraseFileEnvVarName)
+	if hasPassphrase || hasPassphraseFile {
+		return nil, nil
+	}
+
+	secretsProvider, err := p.stackSecretsProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	if secretsProvider != "" && secretsProvider != "passphrase" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(backendUrl, "file://") {
+		return nil, fmt.Errorf(
+			"the secrets of pulumi stacks stored in '%s' must be protected: set %s or %s, or set the secretsprovider "+
+				"of the stack in Pulumi.%s.yaml",
+			backendUrl, passphraseEnvVarName, passphraseFileEnvVarName, p.stackName())
+	}
+
+	return []string{fmt.Sprintf("%s=", passphraseEnvVarName)}, nil
+}
+
+// stackSecretsProvider returns the secrets provider set in the Pulumi.<stack>.yaml stack file of the program, if any.
+func (p *PulumiProvider) stackSecretsProvider() (string, error) {
+	for _, ext := range []string{"yaml", "yml"} {
+		fileName := fmt.Sprintf("Pulumi.%s.%s", p.stackName(), ext)
+		contents, err := os.ReadFile(filepath.Join(p.modulePath(), fileName))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return "", fmt.Errorf("reading pulumi stack file: %w", err)
+		}
+
+		var stack pulumiStack
+		if err := yaml.Unmarshal(contents, &stack); err != nil {
+			return "", fmt.Errorf("parsing pulumi stack file: %w", err)
+		}
+
+		return stack.SecretsProvider, nil
+	}
+
+	return "", nil
+}
+
+// Creates the directory of the default local backend, which pulumi expects to exist.
+func (p *PulumiProvider) ensureLocalBackend() error {
+	backendUrl, err := p.backendUrl()
+	if err != nil {
+		return err
+	}
+
+	if backendUrl != "file://"+filepath.ToSlash(p.localStateDirPath()) {
+		return nil
+	}
+
+	if err := os.MkdirAll(p.localStateDirPath(), osutil.PermissionDirectory); err != nil {
+		return fmt.Errorf("creating pulumi state directory: %w", err)
+	}
+
+	return nil
+}
+
+// Gets the name of the stack of the current env.
+func (p *PulumiProvider) stackName() string {
+	return p.env.Name()
+}
+
+// Gets the path to the project configuration file path
+func (p *PulumiProvider) configTemplateFilePath() string {
+	configFilename := fmt.Sprintf("%s.config.json", p.options.Module)
+	return filepath.Join(p.modulePath(), configFilename)
+}
+
+// Gets the folder path to the pulumi program
+func (p *PulumiProvider) modulePath() string {
+	infraPath := p.options.Path
+	if strings.TrimSpace(infraPath) == "" {
+		infraPath = defaultPath
+	}
+
+	return filepath.Join(p.projectPath, infraPath)
+}
+
+// Gets the path to the staging .azure local state directory
+func (p *PulumiProvider) localStateDirPath() string {
+	statePath := filepath.Join(p.projectPath, ".azure", p.env.Name(), p.options.Path, ".pulumi")
+	if absPath, err := filepath.Abs(statePath); err == nil {
+		return absPath
+	}
+
+	return statePath
+}
+
+// pulumiProject is a model type for the parts of the Pulumi.yaml project file read by the provider.
+type pulumiProject struct {
+	Backend struct {
+		Url string `yaml:"url"`
+	} `yaml:"backend"`
+}
+
+// pulumiStack is a model type for the settings of a Pulumi.<stack>.yaml stack file.
+type pulumiStack struct {
+	SecretsProvider string `yaml:"secretsprovider"`
+}
+
+// pulumiPreviewOutput is a model type for the output of `pulumi preview --json`.
+type pulumiPreviewOutput struct {
+	Steps []pulumiPreviewStep `json:"steps"`
+}
+
+// pulumiPreviewStep is a model type for a step, the operation planned for one resource, of a preview.
+type pulumiPreviewStep struct {
+	Op       string          `json:"op"`
+	Urn      string          `json:"urn"`
+	OldState *pulumiResource `json:"oldState"`
+	NewState *pulumiResource `json:"newState"`
+}
+
+// pulumiStackExport is a model type for the output of `pulumi stack export`.
+type pulumiStackExport struct {
+	Deployment struct {
+		Resources []pulumiResource `json:"resources"`
+	} `json:"deployment"`
+}
+
+// pulumiResource is a model type for the state of a resource. Custom resources are the resources managed by a provider,
+// as opposed to component resources, and their id is the id of the cloud resource.
+type pulumiResource struct {
+	Urn     string         `json:"urn"`
+	Type    string         `json:"type"`
+	Id      string         `json:"id"`
+	Custom  bool           `json:"custom"`
+	Inputs  map[string]any `json:"inputs"`
+	Outputs map[string]any `json:"outputs"`
+}
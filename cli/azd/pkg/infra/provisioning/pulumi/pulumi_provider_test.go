@@ -0,0 +1,400 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pulumi
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
+	pulumiTools "github.com/azure/azure-dev/cli/azd/pkg/tools/pulumi"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockaccount"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockexec"
+	"github.com/otiai10/copy"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPulumiDeploy(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareGenericMocks(mockContext.CommandRunner)
+	prepareOutputMocks(mockContext.CommandRunner)
+
+	var configArgs []string
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "config set-all")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		configArgs = args.Args
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	var upArgs exec.RunArgs
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "up")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		upArgs = args
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	infraProvider := createPulumiProvider(t, mockContext)
+	deployResult, err := infraProvider.Deploy(*mockContext.Context)
+	require.NoError(t, err)
+	require.NotNil(t, deployResult.Deployment)
+
+	require.Equal(t, "test-env", deployResult.Deployment.Parameters["environmentName"].Value)
+	require.Equal(t, "westus2", deployResult.Deployment.Parameters["location"].Value)
+
+	require.Contains(t, configArgs, "environmentName=test-env")
+	require.Contains(t, configArgs, "principalId=11111111-1111-1111-1111-111111111111")
+	require.Contains(t, configArgs, `tags={"azd-env-name":"test-env"}`)
+
+	require.True(t, upArgs.Interactive)
+	require.Contains(t, upArgs.Args, "--yes")
+	require.Equal(t, []string{"--stack", "test-env"}, upArgs.Args[slices.Index(upArgs.Args, "--stack"):][:2])
+	require.Contains(t, upArgs.Env, "ARM_SUBSCRIPTION_ID=00000000-0000-0000-0000-000000000000")
+	require.Contains(t, upArgs.Env, "PULUMI_CONFIG_PASSPHRASE=")
+	require.Contains(
+		t,
+		upArgs.Env,
+		"PULUMI_BACKEND_URL=file://"+filepath.ToSlash(infraProvider.localStateDirPath()),
+	)
+	require.DirExists(t, infraProvider.localStateDirPath())
+
+	outputs := deployResult.Deployment.Outputs
+	require.Equal(t, provisioning.OutputParameter{
+		Type:  provisioning.ParameterTypeString,
+		Value: "rg-test-env",
+	}, outputs["RG_NAME"])
+	require.Equal(t, provisioning.ParameterTypeNumber, outputs["PORT"].Type)
+	require.Equal(t, provisioning.ParameterTypeArray, outputs["ZONES"].Type)
+	require.NotContains(t, outputs, "UNSET")
+	require.Equal(t, provisioning.OutputParameter{
+		Type:   provisioning.ParameterTypeString,
+		Value:  "s3cr3t",
+		Secure: true,
+	}, outputs["KEY"])
+}
+
+//go:embed testdata/pulumi_preview_mock.json
+var pulumiPreviewMockOutput string
+
+func TestPulumiPreview(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareGenericMocks(mockContext.CommandRunner)
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "preview --json")
+	}).Respond(exec.RunResult{
+		Stdout: pulumiPreviewMockOutput,
+	})
+
+	infraProvider := createPulumiProvider(t, mockContext)
+	previewResult, err := infraProvider.Preview(*mockContext.Context)
+	require.NoError(t, err)
+
+	changes := previewResult.Preview.Properties.Changes
+	require.Len(t, changes, 3)
+
+	require.Equal(t, provisioning.ChangeTypeCreate, changes[0].ChangeType)
+	require.Equal(t, "azure-native:resources:ResourceGroup", changes[0].ResourceType)
+	require.Equal(t, "rg", changes[0].Name)
+
+	require.Equal(t, provisioning.ChangeTypeModify, changes[1].ChangeType)
+	require.Equal(t, "storage", changes[1].Name)
+	require.Contains(t, changes[1].ResourceId.Id, "/storageAccounts/sttestenv")
+
+	require.Equal(t, provisioning.ChangeTypeDelete, changes[2].ChangeType)
+	require.Equal(t, "web", changes[2].Name)
+}
+
+func TestPulumiDestroy(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareGenericMocks(mockContext.CommandRunner)
+	prepareOutputMocks(mockContext.CommandRunner)
+
+	var destroyArgs []string
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "destroy")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		destroyArgs = args.Args
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	infraProvider := createPulumiProvider(t, mockContext)
+	destroyOptions := provisioning.NewDestroyOptions(true, false)
+	destroyResult, err := infraProvider.Destroy(*mockContext.Context, destroyOptions)
+	require.NoError(t, err)
+
+	require.Contains(t, destroyResult.InvalidatedEnvKeys, "AZURE_LOCATION")
+	require.Contains(t, destroyResult.InvalidatedEnvKeys, "RG_NAME")
+	require.Contains(t, destroyArgs, "--yes")
+}
+
+//go:embed testdata/pulumi_stack_export_mock.json
+var pulumiStackExportMockOutput string
+
+func TestPulumiState(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareGenericMocks(mockContext.CommandRunner)
+	prepareOutputMocks(mockContext.CommandRunner)
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "stack export")
+	}).Respond(exec.RunResult{
+		Stdout: pulumiStackExportMockOutput,
+	})
+
+	infraProvider := createPulumiProvider(t, mockContext)
+	getStateResult, err := infraProvider.State(*mockContext.Context, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, "westus2", getStateResult.State.Outputs["AZURE_LOCATION"].Value)
+	require.Equal(t, []provisioning.Resource{
+		{Id: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-test-env"},
+	}, getStateResult.State.Resources)
+}
+
+func TestPulumiBackendUrl(t *testing.T) {
+	tests := []struct {
+		name       string
+		envValue   string
+		projectUrl string
+		expected   string
+		wantErr    bool
+	}{
+		{
+			name:     "Default",
+			expected: "file://",
+		},
+		{
+			name:     "AzureBlob",
+			envValue: "azblob://state?storage_account=${STORAGE_ACCOUNT}",
+			expected: "azblob://state?storage_account=sttestenv",
+		},
+		{
+			name:       "ProjectFile",
+			projectUrl: "file://~",
+			expected:   "file://~",
+		},
+		{
+			name:       "EnvOverridesProjectFile",
+			envValue:   "azblob://state",
+			projectUrl: "file://~",
+			expected:   "azblob://state",
+		},
+		{
+			name:     "Unsupported",
+			envValue: "https://api.pulumi.com",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := t.TempDir()
+			infraDir := filepath.Join(projectDir, "infra")
+			require.NoError(t, os.MkdirAll(infraDir, 0755))
+
+			project := "name: test\nruntime: yaml\n"
+			if tt.projectUrl != "" {
+				project += "backend:\n  url: " + tt.projectUrl + "\n"
+			}
+			require.NoError(t, os.WriteFile(filepath.Join(infraDir, "Pulumi.yaml"), []byte(project), 0600))
+
+			env := environment.NewWithValues("test-env", map[string]string{
+				"STORAGE_ACCOUNT": "sttestenv",
+			})
+			if tt.envValue != "" {
+				env.DotenvSet(BackendUrlEnvVarName, tt.envValue)
+			}
+
+			provider := &PulumiProvider{
+				env:         env,
+				projectPath: projectDir,
+				options:     provisioning.Options{Path: defaultPath, Module: defaultModule},
+			}
+
+			backendUrl, err := provider.backendUrl()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(backendUrl, tt.expected), "unexpected backend url %s", backendUrl)
+		})
+	}
+}
+
+func TestPulumiPassphraseEnv(t *testing.T) {
+	tests := []struct {
+		name            string
+		backendUrl      string
+		envValues       map[string]string
+		secretsProvider string
+		expected        []string
+		wantErr         bool
+	}{
+		{
+			name:       "LocalBackend",
+			backendUrl: "file://~",
+			expected:   []string{"PULUMI_CONFIG_PASSPHRASE="},
+		},
+		{
+			name:       "SharedBackend",
+			backendUrl: "azblob://state",
+			wantErr:    true,
+		},
+		{
+			name:       "SharedBackendWithPassphrase",
+			backendUrl: "azblob://state",
+			envValues:  map[string]string{passphraseEnvVarName: "passphrase"},
+		},
+		{
+			name:       "SharedBackendWithPassphraseFile",
+			backendUrl: "azblob://state",
+			envValues:  map[string]string{passphraseFileEnvVarName: "passphrase.txt"},
+		},
+		{
+			name:            "SharedBackendWithSecretsProvider",
+			backendUrl:      "azblob://state",
+			secretsProvider: "azurekeyvault://vault.vault.azure.net/keys/pulumi",
+		},
+		{
+			name:            "SharedBackendWithPassphraseSecretsProvider",
+			backendUrl:      "azblob://state",
+			secretsProvider: "passphrase",
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := t.TempDir()
+			infraDir := filepath.Join(projectDir, "infra")
+			require.NoError(t, os.MkdirAll(infraDir, 0755))
+
+			if tt.secretsProvider != "" {
+				stack := "secretsprovider: " + tt.secretsProvider + "\n"
+				require.NoError(t, os.WriteFile(filepath.Join(infraDir, "Pulumi.test-env.yaml"), []byte(stack), 0600))
+			}
+
+			provider := &PulumiProvider{
+				env:         environment.NewWithValues("test-env", tt.envValues),
+				projectPath: projectDir,
+				options:     provisioning.Options{Path: defaultPath, Module: defaultModule},
+			}
+
+			envVars, err := provider.passphraseEnv(tt.backendUrl)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, envVars)
+		})
+	}
+}
+
+func createPulumiProvider(t *testing.T, mockContext *mocks.MockContext) *PulumiProvider {
+	projectDir := t.TempDir()
+	require.NoError(t, copy.Copy("testdata/infra", filepath.Join(projectDir, "infra")))
+
+	options := provisioning.Options{
+		Module: "main",
+	}
+
+	env := environment.NewWithValues("test-env", map[string]string{
+		"AZURE_ENV_NAME":        "test-env",
+		"AZURE_LOCATION":        "westus2",
+		"AZURE_SUBSCRIPTION_ID": "00000000-0000-0000-0000-000000000000",
+	})
+
+	resourceService := azapi.NewResourceService(mockContext.SubscriptionCredentialProvider, mockContext.ArmClientOptions)
+	accountManager := &mockaccount.MockAccountManager{
+		Subscriptions: []account.Subscription{
+			{
+				Id:   "00000000-0000-0000-0000-000000000000",
+				Name: "test",
+			},
+		},
+		Locations: []account.Location{
+			{
+				Name:                "location",
+				DisplayName:         "Test Location",
+				RegionalDisplayName: "(US) Test Location",
+			},
+		},
+	}
+
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	provider := NewPulumiProvider(
+		pulumiTools.NewCli(mockContext.CommandRunner),
+		envManager,
+		env,
+		mockContext.Console,
+		&mockCurrentPrincipal{},
+		prompt.NewDefaultPrompter(env, mockContext.Console, accountManager, resourceService, cloud.AzurePublic()),
+	)
+
+	err := provider.Initialize(*mockContext.Context, projectDir, options)
+	require.NoError(t, err)
+
+	return provider.(*PulumiProvider)
+}
+
+func prepareGenericMocks(commandRunner *mockexec.MockCommandRunner) {
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "pulumi version")
+	}).Respond(exec.RunResult{
+		Stdout: "v3.100.0",
+	})
+
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "stack select")
+	}).Respond(exec.RunResult{})
+
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "config set-all")
+	}).Respond(exec.RunResult{})
+}
+
+func prepareOutputMocks(commandRunner *mockexec.MockCommandRunner) {
+	//nolint:lll
+	output := `{"AZURE_LOCATION": "westus2", "RG_NAME": "rg-test-env", "PORT": 8080, "ZONES": ["1", "2"], "UNSET": null, "KEY": "%s"}`
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "stack output")
+	}).Respond(exec.RunResult{
+		Stdout: fmt.Sprintf(output, "[secret]"),
+	})
+
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "stack output") && slices.Contains(args.Args, "--show-secrets")
+	}).Respond(exec.RunResult{
+		Stdout: fmt.Sprintf(output, "s3cr3t"),
+	})
+}
+
+type mockCurrentPrincipal struct{}
+
+func (m *mockCurrentPrincipal) CurrentPrincipalId(_ context.Context) (string, error) {
+	return "11111111-1111-1111-1111-111111111111", nil
+}
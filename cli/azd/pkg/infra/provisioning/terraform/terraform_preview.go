@@ -0,0 +1,257 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+)
+
+const (
+	// terraformModeData is the mode of data resources, which are read by terraform but never changed.
+	terraformModeData = "data"
+	// placeholder for the values which are only known once the plan is applied, as displayed by terraform
+	knownAfterApplyValue = "(known after apply)"
+	// placeholder for the values marked as sensitive, as displayed by terraform
+	sensitiveValue = "(sensitive value)"
+)
+
+// terraformPlanOutput is a model type for the output of `terraform show` for a plan file.
+// see https://developer.hashicorp.com/terraform/internals/json-format#plan-representation for more information on the
+// shape of the JSON data
+type terraformPlanOutput struct {
+	FormatVersion   string                    `json:"format_version"`
+	ResourceChanges []terraformResourceChange `json:"resource_changes"`
+}
+
+// terraformResourceChange is the model type for a change to a resource in a terraform plan.
+type terraformResourceChange struct {
+	Address string `json:"address"`
+	// "mode" can be "managed", for resources, or "data", for data resources
+	Mode   string          `json:"mode"`
+	Type   string          `json:"type"`
+	Name   string          `json:"name"`
+	Change terraformChange `json:"change"`
+}
+
+// terraformChange is the model type for the change-representation of a terraform plan.
+// see https://developer.hashicorp.com/terraform/internals/json-format#change-representation for more information on the
+// shape of the JSON data
+type terraformChange struct {
+	// Actions are the actions applied to the resource, one of: ["no-op"], ["create"], ["read"], ["update"],
+	// ["delete", "create"], ["create", "delete"], ["delete"] or ["forget"]
+	Actions []string `json:"actions"`
+	Before  any      `json:"before"`
+	After   any      `json:"after"`
+	// AfterUnknown mirrors the structure of After, with true for the values only known once the plan is applied
+	AfterUnknown any `json:"after_unknown"`
+	// BeforeSensitive and AfterSensitive mirror the structure of Before and After, with true for sensitive values
+	BeforeSensitive any `json:"before_sensitive"`
+	AfterSensitive  any `json:"after_sensitive"`
+}
+
+// showPlan reads the changes of the plan file with `terraform show`.
+func (t *TerraformProvider) showPlan(
+	ctx context.Context,
+	modulePath string,
+	planFilePath string,
+) (*terraformPlanOutput, error) {
+	runResult, err := t.cli.Show(ctx, modulePath, planFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("showing plan failed: %s, err:%w", runResult, err)
+	}
+
+	var planOutput terraformPlanOutput
+	if err := json.Unmarshal([]byte(runResult), &planOutput); err != nil {
+		return nil, fmt.Errorf("parsing plan: %w", err)
+	}
+
+	return &planOutput, nil
+}
+
+// previewChanges maps the changes of the managed resources of the plan to the changes of a deployment preview.
+// Sensitive values are masked and the values only known once the plan is applied are replaced by a placeholder.
+func (p *terraformPlanOutput) previewChanges() []*provisioning.DeploymentPreviewChange {
+	changes := []*provisioning.DeploymentPreviewChange{}
+	for _, resourceChange := range p.ResourceChanges {
+		if resourceChange.Mode == terraformModeData {
+			continue
+		}
+
+		change := resourceChange.Change
+		before := maskValueOrNil(change.Before, change.BeforeSensitive, sensitiveValue)
+		after := maskValueOrNil(change.After, change.AfterSensitive, sensitiveValue)
+		after = maskValueOrNil(after, change.AfterUnknown, knownAfterApplyValue)
+
+		previewChange := &provisioning.DeploymentPreviewChange{
+			ChangeType:   changeTypeFromActions(change.Actions),
+			ResourceId:   provisioning.Resource{Id: resourceId(change)},
+			ResourceType: resourceChange.Type,
+			Name:         resourceChange.Address,
+			Before:       before,
+			After:        after,
+		}
+
+		switch previewChange.ChangeType {
+		case provisioning.ChangeTypeModify, provisioning.ChangeTypeReplace:
+			previewChange.Delta = propertyChanges("", before, after)
+		case provisioning.ChangeTypeUnsupported:
+			previewChange.UnsupportedReason = fmt.Sprintf("unsupported terraform actions %v", change.Actions)
+		}
+
+		changes = append(changes, previewChange)
+	}
+
+	return changes
+}
+
+// changeTypeFromActions maps the terraform actions of a change to the change type of a deployment preview. Replacing
+// a resource, by deleting and creating it in either order, is a replace, as the resource isn't modified in place.
+func changeTypeFromActions(actions []string) provisioning.ChangeType {
+	switch {
+	case slices.Equal(actions, []string{"no-op"}):
+		return provisioning.ChangeTypeNoChange
+	case slices.Equal(actions, []string{"create"}):
+		return provisioning.ChangeTypeCreate
+	case slices.Equal(actions, []string{"update"}):
+		return provisioning.ChangeTypeModify
+	case slices.Equal(actions, []string{"delete"}):
+		return provisioning.ChangeTypeDelete
+	case slices.Equal(actions, []string{"delete", "create"}), slices.Equal(actions, []string{"create", "delete"}):
+		return provisioning.ChangeTypeReplace
+	case slices.Equal(actions, []string{"read"}), slices.Equal(actions, []string{"forget"}):
+		return provisioning.ChangeTypeIgnore
+	default:
+		return provisioning.ChangeTypeUnsupported
+	}
+}
+
+// resourceId returns the id of the resource, as set by the azurerm and azapi providers, from the state before the
+// change, or after the change when the resource doesn't exist yet and its id is known.
+func resourceId(change terraformChange) string {
+	for _, values := range []any{change.Before, change.After} {
+		if valuesMap, ok := values.(map[string]any); ok {
+			if id, ok := valuesMap["id"].(string); ok && id != "" {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// maskValue replaces the parts of value marked as true in mask by the placeholder. mask mirrors the structure of
+// value, as the sensitive and unknown values of a terraform change do. Values marked in mask but missing in value are
+// added, as terraform omits the unknown values from the values after the change.
+func maskValue(value any, mask any, placeholder string) any {
+	switch typedMask := mask.(type) {
+	case bool:
+		if typedMask {
+			return placeholder
+		}
+		return value
+	case map[string]any:
+		valueMap, isMap := value.(map[string]any)
+		if !isMap && value != nil {
+			return value
+		}
+		masked := make(map[string]any, len(valueMap))
+		maps.Copy(masked, valueMap)
+		for key, keyMask := range typedMask {
+			keyValue, has := valueMap[key]
+			if maskedValue := maskValueOrNil(keyValue, keyMask, placeholder); maskedValue != nil || has {
+				masked[key] = maskedValue
+			}
+		}
+		return masked
+	case []any:
+		valueList, isList := value.([]any)
+		if !isList {
+			return value
+		}
+		masked := slices.Clone(valueList)
+		for i := range masked {
+			if i < len(typedMask) {
+				masked[i] = maskValueOrNil(masked[i], typedMask[i], placeholder)
+			}
+		}
+		return masked
+	default:
+		return value
+	}
+}
+
+// maskValueOrNil masks the value, returning nil when nothing is masked in a missing value.
+func maskValueOrNil(value any, mask any, placeholder string) any {
+	masked := maskValue(value, mask, placeholder)
+	if value == nil {
+		if maskedMap, ok := masked.(map[string]any); ok && len(maskedMap) == 0 {
+			return nil
+		}
+	}
+	return masked
+}
+
+// propertyChanges compares the properties of a resource before and after a change. Nested objects are compared
+// property by property, while arrays are compared as a whole.
+func propertyChanges(path string, before any, after any) []provisioning.DeploymentPreviewPropertyChange {
+	beforeMap, _ := before.(map[string]any)
+	afterMap, _ := after.(map[string]any)
+
+	keys := slices.Collect(maps.Keys(beforeMap))
+	for key := range afterMap {
+		if _, has := beforeMap[key]; !has {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	changes := []provisioning.DeploymentPreviewPropertyChange{}
+	for _, key := range keys {
+		propertyPath := key
+		if path != "" {
+			propertyPath = path + "." + key
+		}
+
+		beforeValue := beforeMap[key]
+		afterValue := afterMap[key]
+		if reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+
+		change := provisioning.DeploymentPreviewPropertyChange{
+			Path:   propertyPath,
+			Before: beforeValue,
+			After:  afterValue,
+		}
+
+		_, beforeIsMap := beforeValue.(map[string]any)
+		_, afterIsMap := afterValue.(map[string]any)
+		_, beforeIsList := beforeValue.([]any)
+		_, afterIsList := afterValue.([]any)
+
+		switch {
+		case beforeValue == nil:
+			change.ChangeType = provisioning.PropertyChangeTypeCreate
+		case afterValue == nil:
+			change.ChangeType = provisioning.PropertyChangeTypeDelete
+		case beforeIsMap && afterIsMap:
+			change.ChangeType = provisioning.PropertyChangeTypeModify
+			change.Children = propertyChanges(propertyPath, beforeValue, afterValue)
+		case beforeIsList && afterIsList:
+			change.ChangeType = provisioning.PropertyChangeTypeArray
+		default:
+			change.ChangeType = provisioning.PropertyChangeTypeModify
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
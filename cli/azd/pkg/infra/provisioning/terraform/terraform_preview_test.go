@@ -0,0 +1,139 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package terraform
+
+import (
+	_ "embed"
+	"encoding/json"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/terraform_show_plan_mock.json
+var terraformShowPlanMockOutput string
+
+func Test_terraformPlanOutput_previewChanges(t *testing.T) {
+	var planOutput terraformPlanOutput
+	require.NoError(t, json.Unmarshal([]byte(terraformShowPlanMockOutput), &planOutput))
+
+	changes := planOutput.previewChanges()
+	// the data source is not a change to an Azure resource
+	require.Len(t, changes, 5)
+
+	t.Run("no change", func(t *testing.T) {
+		change := changes[0]
+		require.Equal(t, provisioning.ChangeTypeNoChange, change.ChangeType)
+		require.Equal(t, "azurerm_resource_group", change.ResourceType)
+		require.Equal(t, "azurerm_resource_group.rg", change.Name)
+		require.Equal(t,
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-test-env", change.ResourceId.Id)
+		require.Empty(t, change.Delta)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		change := changes[1]
+		require.Equal(t, provisioning.ChangeTypeModify, change.ChangeType)
+		require.Equal(t, []provisioning.DeploymentPreviewPropertyChange{
+			{
+				ChangeType: provisioning.PropertyChangeTypeModify,
+				Path:       "access_tier",
+				Before:     "Hot",
+				After:      "Cool",
+			},
+			{
+				ChangeType: provisioning.PropertyChangeTypeCreate,
+				Path:       "allowed_copy_scope",
+				After:      "AAD",
+			},
+			{
+				ChangeType: provisioning.PropertyChangeTypeArray,
+				Path:       "ip_rules",
+				Before:     []any{"10.0.0.1"},
+				After:      []any{"10.0.0.1", "10.0.0.2"},
+			},
+			{
+				ChangeType: provisioning.PropertyChangeTypeModify,
+				Path:       "tags",
+				Before:     map[string]any{"azd-env-name": "test-env", "owner": "team-a"},
+				After:      map[string]any{"azd-env-name": "test-env"},
+				Children: []provisioning.DeploymentPreviewPropertyChange{
+					{
+						ChangeType: provisioning.PropertyChangeTypeDelete,
+						Path:       "tags.owner",
+						Before:     "team-a",
+					},
+				},
+			},
+		}, change.Delta)
+
+		// sensitive values are masked, and the masked key isn't reported as changed
+		require.Equal(t, sensitiveValue, change.Before.(map[string]any)["primary_access_key"])
+		require.Equal(t, sensitiveValue, change.After.(map[string]any)["primary_access_key"])
+	})
+
+	t.Run("create", func(t *testing.T) {
+		change := changes[2]
+		require.Equal(t, provisioning.ChangeTypeCreate, change.ChangeType)
+		require.Equal(t, "module.app.azurerm_linux_web_app.web", change.Name)
+		require.Empty(t, change.ResourceId.Id)
+		require.Nil(t, change.Before)
+		require.Equal(t, map[string]any{
+			"name":             "app-test-env",
+			"location":         "westus2",
+			"id":               knownAfterApplyValue,
+			"default_hostname": knownAfterApplyValue,
+		}, change.After)
+		require.Empty(t, change.Delta)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		change := changes[3]
+		require.Equal(t, provisioning.ChangeTypeReplace, change.ChangeType)
+		require.Equal(t, []provisioning.DeploymentPreviewPropertyChange{
+			{
+				ChangeType: provisioning.PropertyChangeTypeModify,
+				Path:       "id",
+				Before: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-test-env/providers/" +
+					"Microsoft.KeyVault/vaults/kv-test-env",
+				After: knownAfterApplyValue,
+			},
+			{
+				ChangeType: provisioning.PropertyChangeTypeModify,
+				Path:       "sku_name",
+				Before:     "standard",
+				After:      "premium",
+			},
+		}, change.Delta)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		change := changes[4]
+		require.Equal(t, provisioning.ChangeTypeDelete, change.ChangeType)
+		require.NotEmpty(t, change.ResourceId.Id)
+		require.Nil(t, change.After)
+	})
+}
+
+func Test_changeTypeFromActions(t *testing.T) {
+	tests := []struct {
+		actions  []string
+		expected provisioning.ChangeType
+	}{
+		{[]string{"no-op"}, provisioning.ChangeTypeNoChange},
+		{[]string{"create"}, provisioning.ChangeTypeCreate},
+		{[]string{"update"}, provisioning.ChangeTypeModify},
+		{[]string{"delete"}, provisioning.ChangeTypeDelete},
+		{[]string{"delete", "create"}, provisioning.ChangeTypeReplace},
+		{[]string{"create", "delete"}, provisioning.ChangeTypeReplace},
+		{[]string{"read"}, provisioning.ChangeTypeIgnore},
+		{[]string{"forget"}, provisioning.ChangeTypeIgnore},
+		{[]string{"unknown"}, provisioning.ChangeTypeUnsupported},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.expected, changeTypeFromActions(test.actions), "actions %v", test.actions)
+	}
+}
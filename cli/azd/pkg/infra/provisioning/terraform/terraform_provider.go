@@ -219,17 +219,23 @@ func (t *TerraformProvider) Deploy(ctx context.Context) (*provisioning.DeployRes
 }
 
 func (t *TerraformProvider) Preview(ctx context.Context) (*provisioning.DeployPreviewResult, error) {
-	// terraform uses plan() to display the what-if output
-	// no changes are added to the properties
-	_, _, err := t.plan(ctx)
+	// terraform uses plan() to display the what-if output, and the changes are read back from the plan file
+	_, deploymentDetails, err := t.plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	planOutput, err := t.showPlan(ctx, t.modulePath(), deploymentDetails.PlanFilePath)
 	if err != nil {
 		return nil, err
 	}
 
 	return &provisioning.DeployPreviewResult{
 		Preview: &provisioning.DeploymentPreview{
-			Status:     "done",
-			Properties: &provisioning.DeploymentPreviewProperties{},
+			Status: "done",
+			Properties: &provisioning.DeploymentPreviewProperties{
+				Changes: planOutput.previewChanges(),
+			},
 		},
 	}, nil
 }
@@ -448,8 +454,9 @@ func (t *TerraformProvider) convertOutputs(outputMap map[string]terraformOutput)
 		}
 
 		outputParameters[k] = provisioning.OutputParameter{
-			Type:  t.mapTerraformTypeToInterfaceType(v.Type),
-			Value: v.Value,
+			Type:   t.mapTerraformTypeToInterfaceType(v.Type),
+			Value:  v.Value,
+			Secure: v.Sensitive,
 		}
 	}
 	return outputParameters
@@ -59,6 +59,26 @@ func TestTerraformPlan(t *testing.T) {
 	require.NotEmpty(t, deploymentPlan.localStateFilePath)
 }
 
+func TestTerraformPreview(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareGenericMocks(mockContext.CommandRunner)
+	preparePlanningMocks(mockContext.CommandRunner)
+	prepareShowPlanMocks(mockContext.CommandRunner)
+
+	infraProvider := createTerraformProvider(t, mockContext)
+	previewResult, err := infraProvider.Preview(*mockContext.Context)
+
+	require.Nil(t, err)
+	require.NotNil(t, previewResult.Preview)
+
+	changes := previewResult.Preview.Properties.Changes
+	require.Len(t, changes, 5)
+	require.Equal(t, provisioning.ChangeTypeNoChange, changes[0].ChangeType)
+	require.Equal(t, provisioning.ChangeTypeModify, changes[1].ChangeType)
+	require.Equal(t, "azurerm_storage_account", changes[1].ResourceType)
+	require.NotEmpty(t, changes[1].Delta)
+}
+
 func TestTerraformDestroy(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	prepareGenericMocks(mockContext.CommandRunner)
@@ -188,6 +208,15 @@ func prepareShowMocks(commandRunner *mockexec.MockCommandRunner) {
 	})
 }
 
+func prepareShowPlanMocks(commandRunner *mockexec.MockCommandRunner) {
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && strings.Contains(command, "show") && strings.Contains(command, ".tfplan")
+	}).Respond(exec.RunResult{
+		Stdout: terraformShowPlanMockOutput,
+		Stderr: "",
+	})
+}
+
 func prepareDestroyMocks(commandRunner *mockexec.MockCommandRunner) {
 	commandRunner.When(func(args exec.RunArgs, command string) bool {
 		return args.Cmd == "terraform" && strings.Contains(command, "init")
@@ -14,6 +14,7 @@ import (
 const (
 	SucceededState DisplayedResourceState = "Succeeded"
 	FailedState    DisplayedResourceState = "Failed"
+	SkippedState   DisplayedResourceState = "Skipped"
 )
 
 type DisplayedResourceState string
@@ -33,6 +34,8 @@ func (cr *DisplayedResource) ToString(currentIndentation string) string {
 		prefix = donePrefix
 	case FailedState:
 		prefix = failedPrefix
+	case SkippedState:
+		prefix = skippedPrefix
 	default:
 		prefix = donePrefix
 	}
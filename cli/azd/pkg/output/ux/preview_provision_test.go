@@ -33,6 +33,11 @@ func TestPreviewProvision(t *testing.T) {
 				Name:      "resource name 3",
 				Operation: OperationTypeDelete,
 			},
+			{
+				Type:      "Other",
+				Name:      "resource name 4",
+				Operation: OperationTypeReplace,
+			},
 		},
 	}
 
@@ -18,3 +18,4 @@ type UxItem interface {
 
 var donePrefix string = output.WithSuccessFormat("(✓) Done:")
 var failedPrefix string = output.WithErrorFormat("(x) Failed:")
+var skippedPrefix string = output.WithGrayFormat("(-) Skipped:")
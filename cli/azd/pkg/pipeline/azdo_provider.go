@@ -711,6 +711,21 @@ func (p *AzdoCiProvider) credentialOptions(
 	infraOptions provisioning.Options,
 	authType PipelineAuthType,
 	credentials *entraid.AzureCredentials,
+) (*CredentialOptions, error) {
+	p.credentials = credentials
+	return p.serviceConnectionCredentialOptions(
+		ctx, repoDetails, infraOptions, authType, credentials, azdo.ServiceConnectionName)
+}
+
+// serviceConnectionCredentialOptions gets the credential options of the service connection. The service connection is
+// created for federated credentials, as its issuer and subject are needed by the federated identity credential.
+func (p *AzdoCiProvider) serviceConnectionCredentialOptions(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	infraOptions provisioning.Options,
+	authType PipelineAuthType,
+	credentials *entraid.AzureCredentials,
+	serviceConnectionName string,
 ) (*CredentialOptions, error) {
 	// Default auth type to client-credentials for terraform
 	if infraOptions.Provider == provisioning.Terraform && authType == "" {
@@ -725,22 +740,13 @@ func (p *AzdoCiProvider) credentialOptions(
 
 	// If not specified default to federated credentials
 	if authType == "" || authType == AuthTypeFederated {
-		p.credentials = credentials
 		details := repoDetails.details.(*AzdoRepositoryDetails)
-		org, _, err := azdo.EnsureOrgNameExists(ctx, p.envManager, p.Env, p.console)
+		connection, err := p.getConnection(ctx)
 		if err != nil {
 			return nil, err
 		}
-		pat, _, err := azdo.EnsurePatExists(ctx, p.Env, p.console)
-		if err != nil {
-			return nil, err
-		}
-		connection, err := azdo.GetConnection(ctx, org, pat)
-		if err != nil {
-			return nil, err
-		}
-		sConnection, err := azdo.CreateServiceConnection(
-			ctx, connection, details.projectId, details.projectName, *p.Env, p.credentials, p.console)
+		sConnection, err := azdo.CreateNamedServiceConnection(
+			ctx, connection, details.projectId, details.projectName, serviceConnectionName, credentials, p.console)
 		if err != nil {
 			return nil, err
 		}
@@ -765,6 +771,19 @@ func (p *AzdoCiProvider) credentialOptions(
 	}, nil
 }
 
+// getConnection returns the connection to the Azure DevOps organization, authenticated with the PAT.
+func (p *AzdoCiProvider) getConnection(ctx context.Context) (*azuredevops.Connection, error) {
+	org, _, err := azdo.EnsureOrgNameExists(ctx, p.envManager, p.Env, p.console)
+	if err != nil {
+		return nil, err
+	}
+	pat, _, err := azdo.EnsurePatExists(ctx, p.Env, p.console)
+	if err != nil {
+		return nil, err
+	}
+	return azdo.GetConnection(ctx, org, pat)
+}
+
 // configureConnection set up Azure DevOps with the Azure credential
 func (p *AzdoCiProvider) configureConnection(
 	ctx context.Context,
@@ -841,6 +860,97 @@ func (p *AzdoCiProvider) configurePipeline(
 	}, nil
 }
 
+// ***  stagedCiProvider implementation ******
+
+func (p *AzdoCiProvider) stageCredentialOptions(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	stage *pipelineStage,
+	infraOptions provisioning.Options,
+	authType PipelineAuthType,
+	credentials *entraid.AzureCredentials,
+) (*CredentialOptions, error) {
+	return p.serviceConnectionCredentialOptions(
+		ctx, repoDetails, infraOptions, authType, credentials, azdo.StageServiceConnectionName(stage.name()))
+}
+
+// configureStage creates or updates the service connection, the Azure Pipelines environment and the variable group
+// of the stage. Deployments to the environment of a stage requiring an approval wait for the approval of the user
+// authenticated with the PAT.
+func (p *AzdoCiProvider) configureStage(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	stage *pipelineStage,
+	infraOptions provisioning.Options,
+	servicePrincipal *graphsdk.ServicePrincipal,
+	credentialOptions *CredentialOptions,
+	credentials *entraid.AzureCredentials,
+	options *configurePipelineOptions,
+) error {
+	details := repoDetails.details.(*AzdoRepositoryDetails)
+	connection, err := p.getConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	serviceConnectionName := azdo.StageServiceConnectionName(stage.name())
+	if !credentialOptions.EnableFederatedCredentials {
+		// federated credentials are set up in stageCredentialOptions
+		_, err := azdo.CreateNamedServiceConnection(
+			ctx, connection, details.projectId, details.projectName, serviceConnectionName, credentials, p.console)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = azdo.CreateOrUpdateEnvironment(
+		ctx, connection, details.projectId, stage.name(), stage.requireApproval, p.console)
+	if err != nil {
+		return err
+	}
+
+	_, err = azdo.CreateOrUpdateVariableGroup(
+		ctx,
+		connection,
+		details.projectId,
+		details.projectName,
+		azdo.StageVariableGroupName(stage.name()),
+		serviceConnectionName,
+		stage.env,
+		credentials,
+		infraOptions,
+		options.secrets,
+		options.variables,
+		p.console,
+	)
+	return err
+}
+
+// configureStagedPipeline creates the Azdo pipeline running the deployment stages
+func (p *AzdoCiProvider) configureStagedPipeline(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	stages []*pipelineStage,
+	options *configurePipelineOptions,
+) (CiPipeline, error) {
+	details := repoDetails.details.(*AzdoRepositoryDetails)
+	connection, err := p.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	buildDefinition, err := azdo.CreateStagedPipeline(
+		ctx, details.projectId, azdo.AzurePipelineName, details.repoName, connection)
+	if err != nil {
+		return nil, err
+	}
+	details.buildDefinition = buildDefinition
+
+	return &pipeline{
+		repoDetails: details,
+	}, nil
+}
+
 // pipeline is the implementation for a CiPipeline for Azure DevOps
 type pipeline struct {
 	repoDetails *AzdoRepositoryDetails
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"log"
 	"net/url"
 	"path/filepath"
 	"regexp"
@@ -462,15 +463,32 @@ func (p *GitHubCiProvider) configureConnection(
 	credentialOptions *CredentialOptions,
 	credentials *entraid.AzureCredentials,
 ) error {
-	repoSlug := repoDetails.owner + "/" + repoDetails.repoName
+	values := &gitHubValues{
+		ghCli:    p.ghCli,
+		repoSlug: repoDetails.owner + "/" + repoDetails.repoName,
+	}
+	return p.configureValuesConnection(ctx, values, p.env, infraOptions, servicePrincipal, credentialOptions, credentials)
+}
+
+// configureValuesConnection sets the credentials and the variables used by the pipeline to deploy the environment as
+// secrets and variables of the repository, or of the GitHub environment of the values.
+func (p *GitHubCiProvider) configureValuesConnection(
+	ctx context.Context,
+	values *gitHubValues,
+	env *environment.Environment,
+	infraOptions provisioning.Options,
+	servicePrincipal *graphsdk.ServicePrincipal,
+	credentialOptions *CredentialOptions,
+	credentials *entraid.AzureCredentials,
+) error {
 	if credentialOptions.EnableClientCredentials {
-		err := p.configureClientCredentialsAuth(ctx, infraOptions, repoSlug, credentials)
+		err := p.configureClientCredentialsAuth(ctx, infraOptions, values, credentials)
 		if err != nil {
 			return fmt.Errorf("configuring client credentials auth: %w", err)
 		}
 	}
 
-	if err := p.setPipelineVariables(ctx, repoSlug, infraOptions, servicePrincipal); err != nil {
+	if err := p.setPipelineVariables(ctx, values, env, infraOptions, servicePrincipal); err != nil {
 		return fmt.Errorf("failed setting pipeline variables: %w", err)
 	}
 
@@ -483,18 +501,19 @@ func (p *GitHubCiProvider) configureConnection(
 // scoped deployments, a series of RS_ variables for terraform remote state)
 func (p *GitHubCiProvider) setPipelineVariables(
 	ctx context.Context,
-	repoSlug string,
+	values *gitHubValues,
+	env *environment.Environment,
 	infraOptions provisioning.Options,
 	servicePrincipal *graphsdk.ServicePrincipal,
 ) error {
 	for name, value := range map[string]string{
-		environment.EnvNameEnvVarName:        p.env.Name(),
-		environment.LocationEnvVarName:       p.env.GetLocation(),
-		environment.SubscriptionIdEnvVarName: p.env.GetSubscriptionId(),
+		environment.EnvNameEnvVarName:        env.Name(),
+		environment.LocationEnvVarName:       env.GetLocation(),
+		environment.SubscriptionIdEnvVarName: env.GetSubscriptionId(),
 		environment.TenantIdEnvVarName:       *servicePrincipal.AppOwnerOrganizationId,
 		"AZURE_CLIENT_ID":                    servicePrincipal.AppId,
 	} {
-		if err := p.ghCli.SetVariable(ctx, repoSlug, name, value); err != nil {
+		if err := values.setVariable(ctx, name, value); err != nil {
 			return fmt.Errorf("failed setting %s variable: %w", name, err)
 		}
 		p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
@@ -506,7 +525,7 @@ func (p *GitHubCiProvider) setPipelineVariables(
 	if infraOptions.Provider == provisioning.Terraform {
 		remoteStateKeys := []string{"RS_RESOURCE_GROUP", "RS_STORAGE_ACCOUNT", "RS_CONTAINER_NAME"}
 		for _, key := range remoteStateKeys {
-			value, ok := p.env.LookupEnv(key)
+			value, ok := env.LookupEnv(key)
 			if !ok || strings.TrimSpace(value) == "" {
 				p.console.StopSpinner(ctx, "Configuring terraform", input.StepWarning)
 				p.console.MessageUxItem(ctx, &ux.WarningMessage{
@@ -525,7 +544,7 @@ func (p *GitHubCiProvider) setPipelineVariables(
 			}
 
 			// env var was found
-			if err := p.ghCli.SetVariable(ctx, repoSlug, key, value); err != nil {
+			if err := values.setVariable(ctx, key, value); err != nil {
 				return fmt.Errorf("setting terraform remote state variables: %w", err)
 			}
 			p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
@@ -536,8 +555,8 @@ func (p *GitHubCiProvider) setPipelineVariables(
 	}
 
 	if infraOptions.Provider == provisioning.Bicep {
-		if rgName, has := p.env.LookupEnv(environment.ResourceGroupEnvVarName); has {
-			if err := p.ghCli.SetVariable(ctx, repoSlug, environment.ResourceGroupEnvVarName, rgName); err != nil {
+		if rgName, has := env.LookupEnv(environment.ResourceGroupEnvVarName); has {
+			if err := values.setVariable(ctx, environment.ResourceGroupEnvVarName, rgName); err != nil {
 				return fmt.Errorf("failed setting %s variable: %w", environment.ResourceGroupEnvVarName, err)
 			}
 		}
@@ -550,7 +569,7 @@ func (p *GitHubCiProvider) setPipelineVariables(
 func (p *GitHubCiProvider) configureClientCredentialsAuth(
 	ctx context.Context,
 	infraOptions provisioning.Options,
-	repoSlug string,
+	values *gitHubValues,
 	credentials *entraid.AzureCredentials,
 ) error {
 	/* #nosec G101 - Potential hardcoded credentials - false positive */
@@ -560,7 +579,7 @@ func (p *GitHubCiProvider) configureClientCredentialsAuth(
 		return fmt.Errorf("failed marshalling azure credentials: %w", err)
 	}
 
-	if err := p.ghCli.SetSecret(ctx, repoSlug, secretName, string(credsJson)); err != nil {
+	if err := values.setSecret(ctx, secretName, string(credsJson)); err != nil {
 		return fmt.Errorf("failed setting %s secret: %w", secretName, err)
 	}
 	p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
@@ -578,7 +597,7 @@ func (p *GitHubCiProvider) configureClientCredentialsAuth(
 			"ARM_CLIENT_SECRET": {credentials.ClientSecret, true},
 		} {
 			if !info.secret {
-				if err := p.ghCli.SetVariable(ctx, repoSlug, key, info.value); err != nil {
+				if err := values.setVariable(ctx, key, info.value); err != nil {
 					return fmt.Errorf("setting github variable %s:: %w", key, err)
 				}
 				p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
@@ -586,7 +605,7 @@ func (p *GitHubCiProvider) configureClientCredentialsAuth(
 					Kind: ux.GitHubVariable,
 				})
 			} else {
-				if err := p.ghCli.SetSecret(ctx, repoSlug, key, info.value); err != nil {
+				if err := values.setSecret(ctx, key, info.value); err != nil {
 					return fmt.Errorf("setting github secret %s:: %w", key, err)
 				}
 				p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
@@ -607,7 +626,10 @@ func (p *GitHubCiProvider) configurePipeline(
 	repoDetails *gitRepositoryDetails,
 	options *configurePipelineOptions,
 ) (CiPipeline, error) {
-	repoSlug := repoDetails.owner + "/" + repoDetails.repoName
+	values := &gitHubValues{
+		ghCli:    p.ghCli,
+		repoSlug: repoDetails.owner + "/" + repoDetails.repoName,
+	}
 
 	// Variables and Secrets for a gh-actions are independent from the gh-action. They are set on the repository level.
 	// We need to clean up the previous values before setting the new ones.
@@ -616,19 +638,8 @@ func (p *GitHubCiProvider) configurePipeline(
 	// - When there was a previous additional variable/secret set and then it was updated to empty string or unset from .env.
 	msg := ""
 	var procErr error
-	ciSecrets, ciVariables := []string{}, []string{}
 	if len(options.projectVariables) > 0 {
 		msg = "Setting up project's variables to be used in the pipeline"
-		ciSecretsInstance, err := p.ghCli.ListSecrets(ctx, repoSlug)
-		if err != nil {
-			return nil, fmt.Errorf("unable to get list of repository secrets: %w", err)
-		}
-		ciVariablesInstance, err := p.ghCli.ListVariables(ctx, repoSlug)
-		if err != nil {
-			return nil, fmt.Errorf("unable to get list of repository variables: %w", err)
-		}
-		ciSecrets = ciSecretsInstance
-		ciVariables = ciVariablesInstance
 		p.console.ShowSpinner(ctx, msg, input.Step)
 	}
 
@@ -642,12 +653,42 @@ func (p *GitHubCiProvider) configurePipeline(
 					"",
 					"GitHub Action secrets are now configured. You can view GitHub action secrets that were " +
 						"created at this link:",
-					output.WithLinkFormat("https://github.com/%s/settings/secrets/actions", repoSlug),
+					output.WithLinkFormat("https://github.com/%s/settings/secrets/actions", values.repoSlug),
 					""},
 			})
 		}
 	}()
 
+	if procErr = p.setProjectValues(ctx, values, options); procErr != nil {
+		return nil, procErr
+	}
+
+	return &workflow{
+		repoDetails: repoDetails,
+	}, nil
+}
+
+// setProjectValues sets the variables and secrets from the options. The previous values of the variables and secrets
+// defined on azure.yaml which are not set anymore are removed first.
+func (p *GitHubCiProvider) setProjectValues(
+	ctx context.Context,
+	values *gitHubValues,
+	options *configurePipelineOptions,
+) error {
+	ciSecrets, ciVariables := []string{}, []string{}
+	if len(options.projectVariables) > 0 {
+		ciSecretsInstance, err := values.listSecrets(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to get list of repository secrets: %w", err)
+		}
+		ciVariablesInstance, err := values.listVariables(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to get list of repository variables: %w", err)
+		}
+		ciSecrets = ciSecretsInstance
+		ciVariables = ciVariablesInstance
+	}
+
 	// create map of variables for O(1) lookup during clean up
 	variablesAndSecretsMap := make(map[string]string, len(options.projectVariables)+len(options.projectSecrets))
 	for _, value := range options.projectVariables {
@@ -665,10 +706,8 @@ func (p *GitHubCiProvider) configurePipeline(
 		}
 		// only delete if the secret is defined in the project's secrets or variables (azure.yaml)
 		if _, exists := variablesAndSecretsMap[existingSecret]; exists {
-			deleteErr := p.ghCli.DeleteSecret(ctx, repoSlug, existingSecret)
-			if deleteErr != nil {
-				procErr = fmt.Errorf("failed deleting %s secret: %w", existingSecret, deleteErr)
-				return nil, procErr
+			if err := values.deleteSecret(ctx, existingSecret); err != nil {
+				return fmt.Errorf("failed deleting %s secret: %w", existingSecret, err)
 			}
 		}
 	}
@@ -680,34 +719,197 @@ func (p *GitHubCiProvider) configurePipeline(
 		}
 		// only delete if the variable is defined in the project's secrets or variables (azure.yaml)
 		if _, exists := variablesAndSecretsMap[existingVariable]; exists {
-			deleteErr := p.ghCli.DeleteVariable(ctx, repoSlug, existingVariable)
-			if deleteErr != nil {
-				procErr = fmt.Errorf("failed deleting %s variable: %w", existingVariable, deleteErr)
-				return nil, procErr
+			if err := values.deleteVariable(ctx, existingVariable); err != nil {
+				return fmt.Errorf("failed deleting %s variable: %w", existingVariable, err)
 			}
 		}
 	}
 
 	// set the new variables and secrets
 	for key, value := range options.secrets {
-		if err := p.ghCli.SetSecret(ctx, repoSlug, key, value); err != nil {
-			procErr = fmt.Errorf("failed setting %s secret: %w", key, err)
-			return nil, procErr
+		if err := values.setSecret(ctx, key, value); err != nil {
+			return fmt.Errorf("failed setting %s secret: %w", key, err)
 		}
 	}
 
 	for key, value := range options.variables {
-		if err := p.ghCli.SetVariable(ctx, repoSlug, key, value); err != nil {
-			procErr = fmt.Errorf("failed setting %s secret: %w", key, err)
-			return nil, procErr
+		if err := values.setVariable(ctx, key, value); err != nil {
+			return fmt.Errorf("failed setting %s secret: %w", key, err)
 		}
 	}
 
+	return nil
+}
+
+// ***  stagedCiProvider implementation ******
+
+func (p *GitHubCiProvider) stageCredentialOptions(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	stage *pipelineStage,
+	infraOptions provisioning.Options,
+	authType PipelineAuthType,
+	credentials *entraid.AzureCredentials,
+) (*CredentialOptions, error) {
+	// Default auth type to client-credentials for terraform
+	if infraOptions.Provider == provisioning.Terraform && authType == "" {
+		authType = AuthTypeClientCredentials
+	}
+
+	if authType == AuthTypeClientCredentials {
+		return &CredentialOptions{
+			EnableClientCredentials: true,
+		}, nil
+	}
+
+	// The token of a job deploying to a GitHub environment has the environment as subject, regardless of the branch
+	// or the event triggering the workflow.
+	repoSlug := repoDetails.owner + "/" + repoDetails.repoName
+	credentialSafeName := strings.ReplaceAll(repoSlug, "/", "-")
+
+	return &CredentialOptions{
+		EnableFederatedCredentials: true,
+		FederatedCredentialOptions: []*graphsdk.FederatedIdentityCredential{
+			{
+				Name:        url.PathEscape(fmt.Sprintf("%s-environment-%s", credentialSafeName, stage.name())),
+				Issuer:      federatedIdentityIssuer,
+				Subject:     fmt.Sprintf("repo:%s:environment:%s", repoSlug, stage.name()),
+				Description: to.Ptr("Created by Azure Developer CLI"),
+				Audiences:   []string{federatedIdentityAudience},
+			},
+		},
+	}, nil
+}
+
+// configureStage creates or updates the GitHub environment of the stage and sets the credentials, variables and
+// secrets of the stage as variables and secrets of the GitHub environment. Deployments to the environment of a stage
+// requiring an approval wait for the review of the user logged in to the GitHub CLI.
+func (p *GitHubCiProvider) configureStage(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	stage *pipelineStage,
+	infraOptions provisioning.Options,
+	servicePrincipal *graphsdk.ServicePrincipal,
+	credentialOptions *CredentialOptions,
+	credentials *entraid.AzureCredentials,
+	options *configurePipelineOptions,
+) error {
+	values := &gitHubValues{
+		ghCli:    p.ghCli,
+		repoSlug: repoDetails.owner + "/" + repoDetails.repoName,
+		envName:  stage.name(),
+	}
+
+	var reviewerIds []int
+	if stage.requireApproval {
+		userId, err := p.ghCli.GetCurrentUserId(ctx)
+		if err != nil {
+			return err
+		}
+		reviewerIds = append(reviewerIds, userId)
+	}
+
+	err := p.ghCli.CreateOrUpdateEnvironment(ctx, values.repoSlug, values.envName, reviewerIds)
+	if err != nil && len(reviewerIds) > 0 {
+		// Required reviewers are not available for the private repositories of some GitHub plans
+		log.Printf("creating environment %s with required reviewers: %v", values.envName, err)
+		p.console.MessageUxItem(ctx, &ux.WarningMessage{
+			Description: fmt.Sprintf(
+				"Required reviewers couldn't be added to the GitHub environment %s. Deployments to %s won't wait "+
+					"for an approval. Add a protection rule to the environment in the settings of the repository.",
+				values.envName, values.envName),
+		})
+		err = p.ghCli.CreateOrUpdateEnvironment(ctx, values.repoSlug, values.envName, nil)
+	}
+	if err != nil {
+		return err
+	}
+	p.console.MessageUxItem(ctx, &ux.DisplayedResource{
+		Type: "GitHub environment",
+		Name: values.envName,
+	})
+
+	err = p.configureValuesConnection(
+		ctx, values, stage.env, infraOptions, servicePrincipal, credentialOptions, credentials)
+	if err != nil {
+		return err
+	}
+
+	return p.setProjectValues(ctx, values, options)
+}
+
+// configureStagedPipeline is a no-op for GitHub, as the pipeline is automatically created from the workflow files in
+// .github directory, and the variables and secrets are set on the GitHub environment of each stage.
+func (p *GitHubCiProvider) configureStagedPipeline(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	stages []*pipelineStage,
+	options *configurePipelineOptions,
+) (CiPipeline, error) {
+	p.console.MessageUxItem(ctx, &ux.MultilineMessage{
+		Lines: []string{
+			"",
+			"GitHub environments are now configured. You can view the environments and their protection rules, " +
+				"variables and secrets at this link:",
+			output.WithLinkFormat("https://github.com/%s/%s/settings/environments", repoDetails.owner, repoDetails.repoName),
+			""},
+	})
+
 	return &workflow{
 		repoDetails: repoDetails,
 	}, nil
 }
 
+// gitHubValues sets the variables and secrets of a repository or, when envName is set, of one of its GitHub
+// environments.
+type gitHubValues struct {
+	ghCli    *github.Cli
+	repoSlug string
+	envName  string
+}
+
+func (v *gitHubValues) listSecrets(ctx context.Context) ([]string, error) {
+	if v.envName != "" {
+		return v.ghCli.ListEnvironmentSecrets(ctx, v.repoSlug, v.envName)
+	}
+	return v.ghCli.ListSecrets(ctx, v.repoSlug)
+}
+
+func (v *gitHubValues) listVariables(ctx context.Context) ([]string, error) {
+	if v.envName != "" {
+		return v.ghCli.ListEnvironmentVariables(ctx, v.repoSlug, v.envName)
+	}
+	return v.ghCli.ListVariables(ctx, v.repoSlug)
+}
+
+func (v *gitHubValues) setSecret(ctx context.Context, name string, value string) error {
+	if v.envName != "" {
+		return v.ghCli.SetEnvironmentSecret(ctx, v.repoSlug, v.envName, name, value)
+	}
+	return v.ghCli.SetSecret(ctx, v.repoSlug, name, value)
+}
+
+func (v *gitHubValues) setVariable(ctx context.Context, name string, value string) error {
+	if v.envName != "" {
+		return v.ghCli.SetEnvironmentVariable(ctx, v.repoSlug, v.envName, name, value)
+	}
+	return v.ghCli.SetVariable(ctx, v.repoSlug, name, value)
+}
+
+func (v *gitHubValues) deleteSecret(ctx context.Context, name string) error {
+	if v.envName != "" {
+		return v.ghCli.DeleteEnvironmentSecret(ctx, v.repoSlug, v.envName, name)
+	}
+	return v.ghCli.DeleteSecret(ctx, v.repoSlug, name)
+}
+
+func (v *gitHubValues) deleteVariable(ctx context.Context, name string) error {
+	if v.envName != "" {
+		return v.ghCli.DeleteEnvironmentVariable(ctx, v.repoSlug, v.envName, name)
+	}
+	return v.ghCli.DeleteVariable(ctx, v.repoSlug, name)
+}
+
 // workflow is the implementation for a CiPipeline for GitHub
 type workflow struct {
 	repoDetails *gitRepositoryDetails
@@ -0,0 +1,552 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/gitlab"
+	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+)
+
+// GitLabScmProvider implements ScmProvider using GitLab, either gitlab.com or a self-managed instance, as the provider
+// for source control manager.
+type GitLabScmProvider struct {
+	env        *environment.Environment
+	console    input.Console
+	gitCli     *git.Cli
+	httpClient auth.HttpClient
+}
+
+func NewGitLabScmProvider(
+	env *environment.Environment,
+	console input.Console,
+	gitCli *git.Cli,
+	httpClient auth.HttpClient,
+) ScmProvider {
+	return &GitLabScmProvider{
+		env:        env,
+		console:    console,
+		gitCli:     gitCli,
+		httpClient: httpClient,
+	}
+}
+
+// GitLabRepositoryDetails provides extra state needed for the GitLab provider.
+// this is stored as the details property in repoDetails
+type GitLabRepositoryDetails struct {
+	// serverUrl is the URL of the GitLab instance, which is also the issuer of its OIDC id tokens
+	serverUrl string
+	// projectPath is the full path of the project, including its groups
+	projectPath string
+	projectId   int
+	webUrl      string
+}
+
+// ***  subareaProvider implementation ******
+
+// requiredTools return the list of external tools required by
+// GitLab provider during its execution.
+func (p *GitLabScmProvider) requiredTools(_ context.Context) ([]tools.ExternalTool, error) {
+	return []tools.ExternalTool{}, nil
+}
+
+// preConfigureCheck makes sure there is an access token to call the GitLab API.
+func (p *GitLabScmProvider) preConfigureCheck(
+	ctx context.Context,
+	pipelineManagerArgs PipelineManagerArgs,
+	infraOptions provisioning.Options,
+	projectPath string,
+) (bool, error) {
+	_, updated, err := gitlab.EnsureTokenExists(ctx, p.env, p.console)
+	return updated, err
+}
+
+// name returns the name of the provider
+func (p *GitLabScmProvider) Name() string {
+	return gitLabDisplayName
+}
+
+// ***  scmProvider implementation ******
+
+// configureGitRemote prompts for the url of the GitLab project to set as the remote of the local git project.
+// The project must already exist on the GitLab instance.
+func (p *GitLabScmProvider) configureGitRemote(
+	ctx context.Context,
+	repoPath string,
+	remoteName string,
+) (string, error) {
+	for {
+		remoteUrl, err := p.console.Prompt(ctx, input.ConsoleOptions{
+			Message: fmt.Sprintf("Enter the url of the GitLab project to use for remote %s:", remoteName),
+		})
+		if err != nil {
+			return "", fmt.Errorf("prompting for remote url: %w", err)
+		}
+
+		if _, err := parseGitLabRemote(remoteUrl, p.env.Getenv(gitlab.HostEnvVarName)); err != nil {
+			p.console.Message(ctx, fmt.Sprintf("error: \"%s\" is not a valid GitLab project URL.", remoteUrl))
+			continue
+		}
+
+		return remoteUrl, nil
+	}
+}
+
+// gitRepoDetails extracts the information from a GitLab remote url into general scm concepts
+// like owner, name and path, and looks up the project on the GitLab instance.
+func (p *GitLabScmProvider) gitRepoDetails(ctx context.Context, remoteUrl string) (*gitRepositoryDetails, error) {
+	remote, err := parseGitLabRemote(remoteUrl, p.env.Getenv(gitlab.HostEnvVarName))
+	if err != nil {
+		return nil, err
+	}
+
+	client := newGitLabClient(remote.serverUrl, p.env, p.httpClient)
+	project, err := client.GetProject(ctx, remote.projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("looking for GitLab project: %w", err)
+	}
+
+	namespace, projectName := splitGitLabProjectPath(project.PathWithNamespace)
+	return &gitRepositoryDetails{
+		owner:    namespace,
+		repoName: projectName,
+		remote:   remoteUrl,
+		url:      project.WebUrl,
+		details: &GitLabRepositoryDetails{
+			serverUrl:   remote.serverUrl,
+			projectPath: project.PathWithNamespace,
+			projectId:   project.Id,
+			webUrl:      project.WebUrl,
+		},
+	}, nil
+}
+
+// preventGitPush is nil for GitLab
+func (p *GitLabScmProvider) preventGitPush(
+	ctx context.Context,
+	gitRepo *gitRepositoryDetails,
+	remoteName string,
+	branchName string) (bool, error) {
+	return false, nil
+}
+
+// GitPush pushes the changes using the git credentials configured for the remote
+func (p *GitLabScmProvider) GitPush(
+	ctx context.Context,
+	gitRepo *gitRepositoryDetails,
+	remoteName string,
+	branchName string) error {
+	return p.gitCli.PushUpstream(ctx, gitRepo.gitProjectPath, remoteName, branchName)
+}
+
+// ErrRemoteHostIsNotGitLab the error used when a remote url can't be parsed as a GitLab project url
+var ErrRemoteHostIsNotGitLab = errors.New("not a GitLab project url")
+
+// defines the structure of an scp-like ssh git remote, such as git@gitlab.example.com:group/project.git
+var gitLabRemoteScpUrlRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]+@([a-zA-Z0-9.-]+):(.+?)(?:\.git)?/?$`)
+
+type gitLabRemote struct {
+	serverUrl   string
+	projectPath string
+}
+
+// parseGitLabRemote extracts the URL of the GitLab instance and the project path from a GitLab remote url.
+// the url can be in the form of:
+//   - https://[user@]host[:port]/[group]/[subgroup]/[project][.git]
+//   - ssh://git@host[:port]/[group]/[subgroup]/[project][.git]
+//   - git@host:[group]/[subgroup]/[project][.git]
+//
+// The instance URL is inferred as https://host for ssh remotes, unless the host override is set. When the override is
+// set and the instance is installed under a relative URL, such as https://example.com/gitlab, the relative URL is
+// removed from the project path.
+func parseGitLabRemote(remoteUrl string, hostOverride string) (*gitLabRemote, error) {
+	var serverUrl, projectPath string
+
+	if captures := gitLabRemoteScpUrlRegex.FindStringSubmatch(remoteUrl); captures != nil &&
+		!strings.Contains(remoteUrl, "://") {
+		serverUrl = "https://" + captures[1]
+		projectPath = captures[2]
+	} else {
+		parsed, err := url.Parse(remoteUrl)
+		if err != nil || parsed.Host == "" {
+			return nil, fmt.Errorf("%w: %s", ErrRemoteHostIsNotGitLab, remoteUrl)
+		}
+
+		switch parsed.Scheme {
+		case "https", "http":
+			serverUrl = fmt.Sprintf("%s://%s", parsed.Scheme, parsed.Host)
+		case "ssh":
+			serverUrl = "https://" + parsed.Hostname()
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrRemoteHostIsNotGitLab, remoteUrl)
+		}
+
+		projectPath = strings.TrimSuffix(strings.Trim(parsed.Path, "/"), ".git")
+	}
+
+	if hostOverride != "" {
+		override, err := url.Parse(strings.TrimSuffix(hostOverride, "/"))
+		if err != nil || override.Host == "" {
+			return nil, fmt.Errorf("invalid GitLab instance URL '%s' in %s", hostOverride, gitlab.HostEnvVarName)
+		}
+
+		serverUrl = override.String()
+		if relativeUrl := strings.Trim(override.Path, "/"); relativeUrl != "" {
+			projectPath = strings.TrimPrefix(projectPath, relativeUrl+"/")
+		}
+	}
+
+	// projects always belong to a user or group namespace
+	if namespace, projectName := splitGitLabProjectPath(projectPath); namespace == "" || projectName == "" {
+		return nil, fmt.Errorf("%w: %s", ErrRemoteHostIsNotGitLab, remoteUrl)
+	}
+
+	return &gitLabRemote{
+		serverUrl:   serverUrl,
+		projectPath: projectPath,
+	}, nil
+}
+
+// splitGitLabProjectPath splits the full path of a project into its namespace, which can include subgroups, and name.
+func splitGitLabProjectPath(projectPath string) (string, string) {
+	separator := strings.LastIndex(projectPath, "/")
+	if separator < 0 {
+		return "", projectPath
+	}
+
+	return projectPath[:separator], projectPath[separator+1:]
+}
+
+// newGitLabClient creates a client for the GitLab instance using the access token from the environment.
+func newGitLabClient(serverUrl string, env *environment.Environment, httpClient auth.HttpClient) *gitlab.Client {
+	return gitlab.NewClient(serverUrl, env.Getenv(gitlab.TokenEnvVarName), httpClient)
+}
+
+// GitLabCiProvider implements a CiProvider using GitLab CI/CD to run the pipeline defined in .gitlab-ci.yml.
+type GitLabCiProvider struct {
+	env        *environment.Environment
+	console    input.Console
+	httpClient auth.HttpClient
+}
+
+func NewGitLabCiProvider(
+	env *environment.Environment,
+	console input.Console,
+	httpClient auth.HttpClient,
+) CiProvider {
+	return &GitLabCiProvider{
+		env:        env,
+		console:    console,
+		httpClient: httpClient,
+	}
+}
+
+// ***  subareaProvider implementation ******
+
+// requiredTools defines the requires tools for GitLab to be used as CI manager
+func (p *GitLabCiProvider) requiredTools(_ context.Context) ([]tools.ExternalTool, error) {
+	return []tools.ExternalTool{}, nil
+}
+
+// preConfigureCheck makes sure there is an access token to call the GitLab API, and that the authentication type is
+// supported by the provisioning provider.
+func (p *GitLabCiProvider) preConfigureCheck(
+	ctx context.Context,
+	pipelineManagerArgs PipelineManagerArgs,
+	infraOptions provisioning.Options,
+	projectPath string,
+) (bool, error) {
+	_, updated, err := gitlab.EnsureTokenExists(ctx, p.env, p.console)
+	if err != nil {
+		return updated, err
+	}
+
+	// Federated Auth + Terraform is not a supported combination
+	if infraOptions.Provider == provisioning.Terraform &&
+		PipelineAuthType(pipelineManagerArgs.PipelineAuthTypeName) == AuthTypeFederated {
+		return false, fmt.Errorf(
+			//nolint:lll
+			"Terraform does not support federated authentication. To explicitly use client credentials set the %s flag. %w",
+			output.WithBackticks("--auth-type client-credentials"),
+			ErrAuthNotSupported,
+		)
+	}
+
+	return updated, nil
+}
+
+// name returns the name of the provider.
+func (p *GitLabCiProvider) Name() string {
+	return gitLabDisplayName
+}
+
+// ***  ciProvider implementation ******
+
+// credentialOptions configures federated credentials for the id tokens GitLab issues to the pipelines of the current
+// and main branches. The subject of GitLab id tokens is project_path:<project>:ref_type:branch:ref:<branch>, for both
+// branch and merge request pipelines.
+func (p *GitLabCiProvider) credentialOptions(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	infraOptions provisioning.Options,
+	authType PipelineAuthType,
+	credentials *entraid.AzureCredentials,
+) (*CredentialOptions, error) {
+	// Default auth type to client-credentials for terraform
+	if infraOptions.Provider == provisioning.Terraform && authType == "" {
+		authType = AuthTypeClientCredentials
+	}
+
+	if authType == AuthTypeClientCredentials {
+		return &CredentialOptions{
+			EnableClientCredentials: true,
+		}, nil
+	}
+
+	// If not specified default to federated credentials
+	if authType == "" || authType == AuthTypeFederated {
+		details := repoDetails.details.(*GitLabRepositoryDetails)
+
+		// Configure federated auth for both main branch and current branch
+		branches := []string{repoDetails.branch}
+		if !slices.Contains(branches, "main") {
+			branches = append(branches, "main")
+		}
+
+		credentialSafeName := strings.ReplaceAll(details.projectPath, "/", "-")
+		federatedCredentials := []*graphsdk.FederatedIdentityCredential{}
+		for _, branch := range branches {
+			federatedCredentials = append(federatedCredentials, &graphsdk.FederatedIdentityCredential{
+				Name:        url.PathEscape(fmt.Sprintf("%s-%s", credentialSafeName, strings.ReplaceAll(branch, "/", "-"))),
+				Issuer:      details.serverUrl,
+				Subject:     fmt.Sprintf("project_path:%s:ref_type:branch:ref:%s", details.projectPath, branch),
+				Description: to.Ptr("Created by Azure Developer CLI"),
+				Audiences:   []string{federatedIdentityAudience},
+			})
+		}
+
+		return &CredentialOptions{
+			EnableFederatedCredentials: true,
+			FederatedCredentialOptions: federatedCredentials,
+		}, nil
+	}
+
+	return &CredentialOptions{
+		EnableClientCredentials:    false,
+		EnableFederatedCredentials: false,
+	}, nil
+}
+
+// configureConnection sets the CI/CD variables of the GitLab project the pipeline uses to log in to Azure and to run
+// the provisioning provider.
+func (p *GitLabCiProvider) configureConnection(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	infraOptions provisioning.Options,
+	servicePrincipal *graphsdk.ServicePrincipal,
+	credentialOptions *CredentialOptions,
+	credentials *entraid.AzureCredentials,
+) error {
+	details := repoDetails.details.(*GitLabRepositoryDetails)
+	client := newGitLabClient(details.serverUrl, p.env, p.httpClient)
+
+	variables := map[string]string{
+		environment.EnvNameEnvVarName:        p.env.Name(),
+		environment.LocationEnvVarName:       p.env.GetLocation(),
+		environment.SubscriptionIdEnvVarName: p.env.GetSubscriptionId(),
+		environment.TenantIdEnvVarName:       *servicePrincipal.AppOwnerOrganizationId,
+		"AZURE_CLIENT_ID":                    servicePrincipal.AppId,
+	}
+	secrets := map[string]string{}
+
+	if credentialOptions.EnableClientCredentials {
+		/* #nosec G101 - Potential hardcoded credentials - false positive */
+		secrets["AZURE_CLIENT_SECRET"] = credentials.ClientSecret
+
+		if infraOptions.Provider == provisioning.Terraform {
+			variables["ARM_TENANT_ID"] = credentials.TenantId
+			variables["ARM_CLIENT_ID"] = credentials.ClientId
+			secrets["ARM_CLIENT_SECRET"] = credentials.ClientSecret
+		}
+	}
+
+	if infraOptions.Provider == provisioning.Terraform {
+		for _, key := range []string{"RS_RESOURCE_GROUP", "RS_STORAGE_ACCOUNT", "RS_CONTAINER_NAME"} {
+			value, ok := p.env.LookupEnv(key)
+			if !ok || strings.TrimSpace(value) == "" {
+				p.console.StopSpinner(ctx, "Configuring terraform", input.StepWarning)
+				p.console.MessageUxItem(ctx, &ux.WarningMessage{
+					Description: "Terraform Remote State configuration is invalid",
+					HidePrefix:  true,
+				})
+				p.console.Message(
+					ctx,
+					fmt.Sprintf(
+						"Visit %s for more information on configuring Terraform remote state",
+						output.WithLinkFormat("https://aka.ms/azure-dev/terraform"),
+					),
+				)
+				p.console.Message(ctx, "")
+				return errors.New("terraform remote state is not correctly configured")
+			}
+
+			variables[key] = value
+		}
+	}
+
+	if infraOptions.Provider == provisioning.Bicep {
+		if rgName, has := p.env.LookupEnv(environment.ResourceGroupEnvVarName); has {
+			variables[environment.ResourceGroupEnvVarName] = rgName
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(variables)) {
+		if err := p.setVariable(ctx, client, details.projectId, name, variables[name], false); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(secrets)) {
+		if err := p.setVariable(ctx, client, details.projectId, name, secrets[name], true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// configurePipeline sets the project's variables and secrets as CI/CD variables of the GitLab project. The pipeline
+// itself is defined by the .gitlab-ci.yml file, which GitLab runs on push.
+func (p *GitLabCiProvider) configurePipeline(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	options *configurePipelineOptions,
+) (CiPipeline, error) {
+	details := repoDetails.details.(*GitLabRepositoryDetails)
+	client := newGitLabClient(details.serverUrl, p.env, p.httpClient)
+
+	// Variables and secrets are set on the project level, independently from the pipeline definition.
+	// Like GitHub, previous values of the project's variables and secrets are removed when they are no longer set,
+	// so a secret that becomes a variable (or an unset value) doesn't leak its previous value to the pipeline.
+	if len(options.projectVariables) > 0 || len(options.projectSecrets) > 0 {
+		existingVariables, err := client.ListVariables(ctx, details.projectId)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get list of project variables: %w", err)
+		}
+
+		for _, existing := range existingVariables {
+			_, isVariable := options.variables[existing.Key]
+			_, isSecret := options.secrets[existing.Key]
+			if isVariable || isSecret {
+				// the variable will be updated
+				continue
+			}
+
+			// only delete if the variable is defined in the project's secrets or variables (azure.yaml)
+			if slices.Contains(options.projectVariables, existing.Key) ||
+				slices.Contains(options.projectSecrets, existing.Key) {
+				if err := client.DeleteVariable(ctx, details.projectId, existing.Key); err != nil &&
+					!errors.Is(err, gitlab.ErrNotFound) {
+					return nil, fmt.Errorf("failed deleting %s variable: %w", existing.Key, err)
+				}
+			}
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(options.secrets)) {
+		if err := p.setVariable(ctx, client, details.projectId, key, options.secrets[key], true); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(options.variables)) {
+		if err := p.setVariable(ctx, client, details.projectId, key, options.variables[key], false); err != nil {
+			return nil, err
+		}
+	}
+
+	p.console.MessageUxItem(ctx, &ux.MultilineMessage{
+		Lines: []string{
+			"",
+			"GitLab CI/CD variables are now configured. You can view the variables that were created at this link:",
+			output.WithLinkFormat("%s/-/settings/ci_cd#js-cicd-variables-settings", details.webUrl),
+			""},
+	})
+
+	return &gitLabPipeline{
+		repoDetails: details,
+	}, nil
+}
+
+// setVariable creates or updates a CI/CD variable of the project. Secrets are masked in job logs when GitLab supports
+// masking their value; otherwise they are stored unmasked and a warning is displayed.
+// Values are raw, so GitLab doesn't expand '$' references in them.
+func (p *GitLabCiProvider) setVariable(
+	ctx context.Context,
+	client *gitlab.Client,
+	projectId int,
+	key string,
+	value string,
+	secret bool,
+) error {
+	variable := &gitlab.Variable{
+		Key:   key,
+		Value: value,
+		Raw:   true,
+	}
+
+	kind := ux.GitHubVariable
+	if secret {
+		kind = ux.GitHubSecret
+		variable.Masked = gitlab.IsMaskable(value)
+		if !variable.Masked {
+			p.console.MessageUxItem(ctx, &ux.WarningMessage{
+				Description: fmt.Sprintf(
+					"The value of %s can't be masked by GitLab and won't be hidden in job logs.", key),
+			})
+		}
+	}
+
+	if err := client.SetVariable(ctx, projectId, variable); err != nil {
+		return fmt.Errorf("failed setting %s variable: %w", key, err)
+	}
+
+	p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
+		Name: key,
+		Kind: kind,
+	})
+
+	return nil
+}
+
+// gitLabPipeline is the implementation for a CiPipeline for GitLab
+type gitLabPipeline struct {
+	repoDetails *GitLabRepositoryDetails
+}
+
+func (p *gitLabPipeline) name() string {
+	return "pipelines"
+}
+
+func (p *gitLabPipeline) url() string {
+	return p.repoDetails.webUrl + "/-/pipelines"
+}
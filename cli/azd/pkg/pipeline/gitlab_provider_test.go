@@ -0,0 +1,343 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/gitlab"
+	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseGitLabRemote(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteUrl    string
+		hostOverride string
+		serverUrl    string
+		projectPath  string
+		expectError  bool
+	}{
+		{
+			name:        "https",
+			remoteUrl:   "https://gitlab.com/group/project.git",
+			serverUrl:   "https://gitlab.com",
+			projectPath: "group/project",
+		},
+		{
+			name:        "https with user, port and subgroups",
+			remoteUrl:   "https://user@gitlab.example.com:8443/group/sub/project",
+			serverUrl:   "https://gitlab.example.com:8443",
+			projectPath: "group/sub/project",
+		},
+		{
+			name:        "scp-like ssh",
+			remoteUrl:   "git@gitlab.example.com:group/sub/project.git",
+			serverUrl:   "https://gitlab.example.com",
+			projectPath: "group/sub/project",
+		},
+		{
+			name:        "ssh with port",
+			remoteUrl:   "ssh://git@gitlab.example.com:2222/group/project.git",
+			serverUrl:   "https://gitlab.example.com",
+			projectPath: "group/project",
+		},
+		{
+			name:         "host override with relative url",
+			remoteUrl:    "https://example.com/gitlab/group/project.git",
+			hostOverride: "https://example.com/gitlab/",
+			serverUrl:    "https://example.com/gitlab",
+			projectPath:  "group/project",
+		},
+		{
+			name:         "host override for ssh host",
+			remoteUrl:    "git@ssh.gitlab.example.com:group/project.git",
+			hostOverride: "https://gitlab.example.com",
+			serverUrl:    "https://gitlab.example.com",
+			projectPath:  "group/project",
+		},
+		{
+			name:        "missing namespace",
+			remoteUrl:   "https://gitlab.com/project.git",
+			expectError: true,
+		},
+		{
+			name:        "not a url",
+			remoteUrl:   "project",
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			remote, err := parseGitLabRemote(test.remoteUrl, test.hostOverride)
+			if test.expectError {
+				require.ErrorIs(t, err, ErrRemoteHostIsNotGitLab)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.serverUrl, remote.serverUrl)
+			require.Equal(t, test.projectPath, remote.projectPath)
+		})
+	}
+}
+
+func Test_gitLab_provider_getRepoDetails(t *testing.T) {
+	server := newFakeGitLabServer(t)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	env := environment.NewWithValues("test-env", map[string]string{
+		gitlab.TokenEnvVarName: fakeGitLabToken,
+	})
+	provider := NewGitLabScmProvider(env, mockContext.Console, nil, http.DefaultClient)
+
+	details, err := provider.gitRepoDetails(*mockContext.Context, server.URL+"/group/sub/project.git")
+	require.NoError(t, err)
+	require.Equal(t, "group/sub", details.owner)
+	require.Equal(t, "project", details.repoName)
+	require.Equal(t, server.URL+"/group/sub/project", details.url)
+
+	gitLabDetails := details.details.(*GitLabRepositoryDetails)
+	require.Equal(t, server.URL, gitLabDetails.serverUrl)
+	require.Equal(t, fakeGitLabProjectId, gitLabDetails.projectId)
+
+	_, err = provider.gitRepoDetails(*mockContext.Context, server.URL+"/group/missing.git")
+	require.ErrorIs(t, err, gitlab.ErrNotFound)
+}
+
+func Test_gitLab_provider_credentialOptions(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	provider := NewGitLabCiProvider(environment.New("test-env"), mockContext.Console, http.DefaultClient)
+
+	repoDetails := &gitRepositoryDetails{
+		branch: "feature/login",
+		details: &GitLabRepositoryDetails{
+			serverUrl:   "https://gitlab.example.com",
+			projectPath: "group/sub/project",
+		},
+	}
+
+	t.Run("federated", func(t *testing.T) {
+		options, err := provider.credentialOptions(
+			*mockContext.Context, repoDetails, provisioning.Options{}, "", &entraid.AzureCredentials{})
+		require.NoError(t, err)
+		require.True(t, options.EnableFederatedCredentials)
+		require.False(t, options.EnableClientCredentials)
+		require.Len(t, options.FederatedCredentialOptions, 2)
+
+		credential := options.FederatedCredentialOptions[0]
+		require.Equal(t, "group-sub-project-feature-login", credential.Name)
+		require.Equal(t, "https://gitlab.example.com", credential.Issuer)
+		require.Equal(t, "project_path:group/sub/project:ref_type:branch:ref:feature/login", credential.Subject)
+		require.Equal(t, []string{federatedIdentityAudience}, credential.Audiences)
+
+		require.Equal(t,
+			"project_path:group/sub/project:ref_type:branch:ref:main", options.FederatedCredentialOptions[1].Subject)
+	})
+
+	t.Run("terraform defaults to client credentials", func(t *testing.T) {
+		options, err := provider.credentialOptions(
+			*mockContext.Context,
+			repoDetails,
+			provisioning.Options{Provider: provisioning.Terraform},
+			"",
+			&entraid.AzureCredentials{},
+		)
+		require.NoError(t, err)
+		require.True(t, options.EnableClientCredentials)
+		require.False(t, options.EnableFederatedCredentials)
+	})
+}
+
+func Test_gitLab_provider_configureConnection(t *testing.T) {
+	server := newFakeGitLabServer(t)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	env := environment.NewWithValues("test-env", map[string]string{
+		gitlab.TokenEnvVarName:               fakeGitLabToken,
+		environment.LocationEnvVarName:       "westus2",
+		environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+	})
+	provider := NewGitLabCiProvider(env, mockContext.Console, http.DefaultClient)
+
+	err := provider.configureConnection(
+		*mockContext.Context,
+		server.repoDetails(),
+		provisioning.Options{Provider: provisioning.Bicep},
+		&graphsdk.ServicePrincipal{
+			AppId:                  "CLIENT_ID",
+			AppOwnerOrganizationId: to.Ptr("TENANT_ID"),
+		},
+		&CredentialOptions{EnableClientCredentials: true},
+		&entraid.AzureCredentials{ClientSecret: "Client~Secret_Value.1"},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, "test-env", server.variables["AZURE_ENV_NAME"].Value)
+	require.Equal(t, "TENANT_ID", server.variables["AZURE_TENANT_ID"].Value)
+	require.Equal(t, "CLIENT_ID", server.variables["AZURE_CLIENT_ID"].Value)
+	require.False(t, server.variables["AZURE_CLIENT_ID"].Masked)
+	require.True(t, server.variables["AZURE_CLIENT_ID"].Raw)
+
+	require.Equal(t, "Client~Secret_Value.1", server.variables["AZURE_CLIENT_SECRET"].Value)
+	require.True(t, server.variables["AZURE_CLIENT_SECRET"].Masked)
+}
+
+func Test_gitLab_provider_configurePipeline(t *testing.T) {
+	server := newFakeGitLabServer(t)
+	server.variables["OLD_SECRET"] = &gitlab.Variable{Key: "OLD_SECRET", Value: "old-value", Masked: true}
+	server.variables["UNRELATED"] = &gitlab.Variable{Key: "UNRELATED", Value: "keep"}
+	server.variables["VAR_1"] = &gitlab.Variable{Key: "VAR_1", Value: "previous"}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	env := environment.NewWithValues("test-env", map[string]string{
+		gitlab.TokenEnvVarName: fakeGitLabToken,
+	})
+	provider := NewGitLabCiProvider(env, mockContext.Console, http.DefaultClient)
+
+	pipeline, err := provider.configurePipeline(*mockContext.Context, server.repoDetails(), &configurePipelineOptions{
+		projectVariables: []string{"VAR_1", "OLD_SECRET"},
+		projectSecrets:   []string{"SECRET_1"},
+		variables:        map[string]string{"VAR_1": "value-1"},
+		secrets: map[string]string{
+			"SECRET_1":                       "s3cr3t-value",
+			"AZD_INITIAL_ENVIRONMENT_CONFIG": `{"infra":{"parameters":{}}}`,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/group/sub/project/-/pipelines", pipeline.url())
+
+	// variables of azure.yaml which are no longer set are removed, other variables are kept
+	require.NotContains(t, server.variables, "OLD_SECRET")
+	require.Equal(t, "keep", server.variables["UNRELATED"].Value)
+
+	require.Equal(t, "value-1", server.variables["VAR_1"].Value)
+	require.False(t, server.variables["VAR_1"].Masked)
+
+	require.Equal(t, "s3cr3t-value", server.variables["SECRET_1"].Value)
+	require.True(t, server.variables["SECRET_1"].Masked)
+
+	// JSON values can't be masked by GitLab
+	require.False(t, server.variables["AZD_INITIAL_ENVIRONMENT_CONFIG"].Masked)
+	require.Contains(t, mockContext.Console.Output(),
+		"Warning: The value of AZD_INITIAL_ENVIRONMENT_CONFIG can't be masked by GitLab and won't be hidden in job logs.")
+}
+
+const (
+	fakeGitLabToken     = "glpat-test-token"
+	fakeGitLabProjectId = 42
+)
+
+// fakeGitLabServer is a local stand-in of the projects & project variables APIs of a GitLab instance.
+type fakeGitLabServer struct {
+	*httptest.Server
+	mu        sync.Mutex
+	variables map[string]*gitlab.Variable
+}
+
+func newFakeGitLabServer(t *testing.T) *fakeGitLabServer {
+	server := &fakeGitLabServer{
+		variables: map[string]*gitlab.Variable{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/projects/{path}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("path") != "group/sub/project" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"404 Project Not Found"}`))
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(gitlab.Project{
+			Id:                fakeGitLabProjectId,
+			Name:              "project",
+			PathWithNamespace: "group/sub/project",
+			WebUrl:            server.URL + "/group/sub/project",
+		})
+	})
+
+	variablesPath := "/api/v4/projects/" + strconv.Itoa(fakeGitLabProjectId) + "/variables"
+	mux.HandleFunc("GET "+variablesPath, func(w http.ResponseWriter, r *http.Request) {
+		variables := []*gitlab.Variable{}
+		for _, variable := range server.variables {
+			variables = append(variables, &gitlab.Variable{Key: variable.Key, Value: variable.Value})
+		}
+
+		_ = json.NewEncoder(w).Encode(variables)
+	})
+	mux.HandleFunc("POST "+variablesPath, func(w http.ResponseWriter, r *http.Request) {
+		var variable gitlab.Variable
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&variable))
+		if _, has := server.variables[variable.Key]; has {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		server.variables[variable.Key] = &variable
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(variable)
+	})
+	mux.HandleFunc("PUT "+variablesPath+"/{key}", func(w http.ResponseWriter, r *http.Request) {
+		if _, has := server.variables[r.PathValue("key")]; !has {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var variable gitlab.Variable
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&variable))
+		server.variables[variable.Key] = &variable
+		_ = json.NewEncoder(w).Encode(variable)
+	})
+	mux.HandleFunc("DELETE "+variablesPath+"/{key}", func(w http.ResponseWriter, r *http.Request) {
+		if _, has := server.variables[r.PathValue("key")]; !has {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		delete(server.variables, r.PathValue("key"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != fakeGitLabToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (s *fakeGitLabServer) repoDetails() *gitRepositoryDetails {
+	return &gitRepositoryDetails{
+		owner:    "group/sub",
+		repoName: "project",
+		branch:   "main",
+		url:      s.URL + "/group/sub/project",
+		details: &GitLabRepositoryDetails{
+			serverUrl:   s.URL,
+			projectPath: "group/sub/project",
+			projectId:   fakeGitLabProjectId,
+			webUrl:      s.URL + "/group/sub/project",
+		},
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"slices"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
@@ -135,6 +136,54 @@ type CiProvider interface {
 	) (*CredentialOptions, error)
 }
 
+// pipelineStage is a deployment stage of a pipeline deploying to several azd environments, one after the other.
+type pipelineStage struct {
+	// env is the azd environment deployed by the stage
+	env *environment.Environment
+	// requireApproval indicates that the deployment of the stage waits for a manual approval
+	requireApproval bool
+}
+
+// name returns the name of the azd environment deployed by the stage, which is also the name of its CI environment.
+func (s *pipelineStage) name() string {
+	return s.env.Name()
+}
+
+// stagedCiProvider is implemented by the CI providers that can configure a pipeline deploying to several azd
+// environments. Each azd environment gets a CI environment, with its own connection to Azure, variables and secrets,
+// which is deployed by one stage of the pipeline.
+type stagedCiProvider interface {
+	// stageCredentialOptions gets the credential options that should be configured for the deployment stage
+	stageCredentialOptions(
+		ctx context.Context,
+		repoDetails *gitRepositoryDetails,
+		stage *pipelineStage,
+		infraOptions provisioning.Options,
+		authType PipelineAuthType,
+		credentials *entraid.AzureCredentials,
+	) (*CredentialOptions, error)
+	// configureStage creates or updates the CI environment of the deployment stage, with its approval gate, and sets
+	// up its connection to Azure and the variables and secrets from the options
+	configureStage(
+		ctx context.Context,
+		repoDetails *gitRepositoryDetails,
+		stage *pipelineStage,
+		infraOptions provisioning.Options,
+		servicePrincipal *graphsdk.ServicePrincipal,
+		credentialOptions *CredentialOptions,
+		credentials *entraid.AzureCredentials,
+		options *configurePipelineOptions,
+	) error
+	// configureStagedPipeline set up or create the CI pipeline running the deployment stages and return information
+	// about it
+	configureStagedPipeline(
+		ctx context.Context,
+		repoDetails *gitRepositoryDetails,
+		stages []*pipelineStage,
+		options *configurePipelineOptions,
+	) (CiPipeline, error)
+}
+
 // mergeProjectVariablesAndSecrets returns the list of variables and secrets to be used in the pipeline
 // The initial values reference azd known values, which are merged with the ones defined on azure.yaml by the user.
 func mergeProjectVariablesAndSecrets(
@@ -169,6 +218,9 @@ const (
 	azdoRoot          string = ".azdo"
 	azdoRootAlt       string = ".azuredevops"
 	azdoPipelines     string = "pipelines"
+	gitLabDisplayName string = "GitLab"
+	gitLabCode               = "gitlab"
+	gitLabCiFileName  string = ".gitlab-ci.yml"
 	envPersistedKey   string = "AZD_PIPELINE_PROVIDER"
 )
 
@@ -200,6 +252,14 @@ var (
 			DefaultFile: pipelineFileNames[0],
 			DisplayName: azdoDisplayName,
 		},
+		// GitLab runs the pipeline defined in the .gitlab-ci.yml file at the root of the repository
+		ciProviderGitLab: {
+			RootDirectories:     []string{""},
+			PipelineDirectories: []string{""},
+			Files:               []string{gitLabCiFileName},
+			DefaultFile:         gitLabCiFileName,
+			DisplayName:         gitLabDisplayName,
+		},
 	}
 )
 
@@ -218,11 +278,12 @@ type ciProviderType string
 const (
 	ciProviderGitHubActions ciProviderType = gitHubCode
 	ciProviderAzureDevOps   ciProviderType = azdoCode
+	ciProviderGitLab        ciProviderType = gitLabCode
 )
 
 func toCiProviderType(provider string) (ciProviderType, error) {
 	result := ciProviderType(provider)
-	if result == ciProviderGitHubActions || result == ciProviderAzureDevOps {
+	if result == ciProviderGitHubActions || result == ciProviderAzureDevOps || result == ciProviderGitLab {
 		return result, nil
 	}
 	return "", fmt.Errorf("invalid ci provider type %s", provider)
@@ -253,4 +314,6 @@ type projectProperties struct {
 	AuthType      PipelineAuthType
 	Variables     []string
 	Secrets       []string
+	// Environments are the azd environments deployed by the pipeline, with one deployment stage per environment
+	Environments []string
 }
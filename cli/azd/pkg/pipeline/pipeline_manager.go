@@ -64,6 +64,9 @@ type PipelineManagerArgs struct {
 	PipelineProvider             string
 	PipelineAuthTypeName         string
 	ServiceManagementReference   string
+	// PipelineEnvironments are the azd environments deployed by the pipeline, in order, with one deployment stage per
+	// environment. When empty, the pipeline deploys the current environment.
+	PipelineEnvironments []string
 }
 
 // CredentialOptions represents the options for configuring credentials for a pipeline.
@@ -126,6 +129,10 @@ func NewPipelineManager(
 		keyVaultService:   keyVaultService,
 	}
 
+	for i, envName := range args.PipelineEnvironments {
+		args.PipelineEnvironments[i] = strings.TrimSpace(envName)
+	}
+
 	// check that scm and ci providers are set
 	if err := pipelineProvider.initialize(ctx, args.PipelineProvider); err != nil {
 		return nil, err
@@ -245,6 +252,11 @@ func (pm *PipelineManager) Configure(ctx context.Context, projectName string) (r
 		}
 	}
 
+	stages, err := pm.loadStages(ctx)
+	if err != nil {
+		return result, err
+	}
+
 	infra := pm.infra
 	// run pre-config validations.
 	rootPath := pm.azdCtx.ProjectDirectory()
@@ -269,11 +281,163 @@ func (pm *PipelineManager) Configure(ctx context.Context, projectName string) (r
 		)
 	}
 
+	var ciPipeline CiPipeline
+	if len(stages) > 0 {
+		ciPipeline, err = pm.configureStages(ctx, projectName, smr, gitRepoInfo, stages)
+	} else {
+		ciPipeline, err = pm.configureEnvironment(ctx, projectName, smr, gitRepoInfo)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	// The CI pipeline should be set-up and ready at this point.
+	// azd offers to push changes to the scm to start a new pipeline run
+	doPush, err := pm.console.Confirm(ctx, input.ConsoleOptions{
+		Message:      "Would you like to commit and push your local changes to start the configured CI pipeline?",
+		DefaultValue: true,
+	})
+	if err != nil {
+		return result, fmt.Errorf("prompting to push: %w", err)
+	}
+
+	// scm provider can prevent from pushing changes and/or use the
+	// interactive console for setting up any missing details.
+	// For example, GitHub provider would check if GH-actions are disabled.
+	if doPush {
+		preventPush, err := pm.scmProvider.preventGitPush(
+			ctx,
+			gitRepoInfo,
+			pm.args.PipelineRemoteName,
+			gitRepoInfo.branch)
+		if err != nil {
+			return result, fmt.Errorf("check git push prevent: %w", err)
+		}
+		// revert user's choice when prevent git push returns true
+		doPush = !preventPush
+	}
+
+	if doPush {
+		err = pm.pushGitRepo(ctx, gitRepoInfo, gitRepoInfo.branch)
+		if err != nil {
+			return result, fmt.Errorf("git push: %w", err)
+		}
+
+		// The spinner can't run during `pushing changes` the next UX messages are purely simulated
+		displayMsg := "Pushing changes"
+		pm.console.Message(ctx, "") // new line before the step
+		pm.console.ShowSpinner(ctx, displayMsg, input.Step)
+		pm.console.StopSpinner(ctx, displayMsg, input.GetStepResultFormat(err))
+
+		displayMsg = "Queuing pipeline"
+		pm.console.ShowSpinner(ctx, displayMsg, input.Step)
+		gitRepoInfo.pushStatus = true
+		pm.console.StopSpinner(ctx, displayMsg, input.GetStepResultFormat(err))
+	} else {
+		pm.console.Message(ctx,
+			fmt.Sprintf(
+				"To fully enable pipeline you need to push this repo to the upstream "+
+					"using 'git push --set-upstream %s %s'.\n",
+				pm.args.PipelineRemoteName,
+				gitRepoInfo.branch))
+	}
+
+	return &PipelineConfigResult{
+		RepositoryLink: gitRepoInfo.url,
+		PipelineLink:   ciPipeline.url(),
+	}, nil
+}
+
+// configureEnvironment creates or updates the service principal used by the pipeline to deploy the current
+// environment, sets up the connection from the pipeline to Azure and configures the pipeline.
+func (pm *PipelineManager) configureEnvironment(
+	ctx context.Context,
+	projectName string,
+	smr *string,
+	gitRepoInfo *gitRepositoryDetails,
+) (CiPipeline, error) {
+	infra := pm.infra
+	servicePrincipal, applicationName, err := pm.ensureServicePrincipal(ctx, pm.env, projectName, smr, "")
+	if err != nil {
+		return nil, err
+	}
+
+	repoSlug := gitRepoInfo.owner + "/" + gitRepoInfo.repoName
+	displayMsg := fmt.Sprintf("Configuring repository %s to use credentials for %s", repoSlug, applicationName)
+	pm.console.ShowSpinner(ctx, displayMsg, input.Step)
+
+	subscriptionId := pm.env.GetSubscriptionId()
+	credentials := &entraid.AzureCredentials{
+		ClientId:       servicePrincipal.AppId,
+		TenantId:       *servicePrincipal.AppOwnerOrganizationId,
+		SubscriptionId: subscriptionId,
+	}
+
+	// Get the requested credential options from the CI provider
+	credentialOptions, err := pm.ciProvider.credentialOptions(
+		ctx,
+		gitRepoInfo,
+		infra.Options,
+		PipelineAuthType(pm.args.PipelineAuthTypeName),
+		credentials,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credential options: %w", err)
+	}
+
+	credentials, err = pm.applyCredentialOptions(ctx, subscriptionId, servicePrincipal, credentialOptions, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pm.ciProvider.configureConnection(
+		ctx,
+		gitRepoInfo,
+		infra.Options,
+		servicePrincipal,
+		credentialOptions,
+		credentials,
+	)
+
+	pm.console.StopSpinner(ctx, "", input.GetStepResultFormat(err))
+	if err != nil {
+		return nil, err
+	}
+
+	pm.configOptions.variables, pm.configOptions.secrets, err = pm.pipelineVariablesAndSecrets(ctx, pm.env)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pm.grantKeyVaultAccess(ctx, pm.configOptions.variables, servicePrincipal); err != nil {
+		return nil, err
+	}
+
+	// config pipeline handles setting or creating the provider pipeline to be used
+	return pm.ciProvider.configurePipeline(ctx, gitRepoInfo, pm.configOptions)
+}
+
+// ensureServicePrincipal creates the service principal used by the pipeline to deploy the environment, or updates the
+// existing one, and saves its client id in the environment. When a new service principal is created with the default
+// naming convention, the nameSuffix is appended to its name.
+// The returned string is the display name of the application of the service principal.
+func (pm *PipelineManager) ensureServicePrincipal(
+	ctx context.Context,
+	env *environment.Environment,
+	projectName string,
+	smr *string,
+	nameSuffix string,
+) (*graphsdk.ServicePrincipal, string, error) {
 	// see if SP already exists - This step will not create the SP if it doesn't exist.
 	spConfig, err := servicePrincipal(
-		ctx, pm.env.Getenv(AzurePipelineClientIdEnvVarName), pm.env.GetSubscriptionId(), pm.args, pm.entraIdService)
+		ctx, env.Getenv(AzurePipelineClientIdEnvVarName), env.GetSubscriptionId(), pm.args, pm.entraIdService)
 	if err != nil {
-		return result, err
+		return nil, "", err
+	}
+
+	if spConfig.servicePrincipal == nil && spConfig.lookupKind == "" && nameSuffix != "" {
+		spConfig.applicationName = fmt.Sprintf("%s-%s", spConfig.applicationName, nameSuffix)
+		spConfig.appIdOrName = spConfig.applicationName
 	}
 
 	// Update the message depending on the SP already exists or not
@@ -295,13 +459,13 @@ func (pm *PipelineManager) Configure(ctx context.Context, projectName string) (r
 	}
 	servicePrincipal, err := pm.entraIdService.CreateOrUpdateServicePrincipal(
 		ctx,
-		pm.env.GetSubscriptionId(),
+		env.GetSubscriptionId(),
 		spConfig.appIdOrName,
 		options)
 
 	if err != nil {
 		pm.console.StopSpinner(ctx, displayMsg, input.GetStepResultFormat(err))
-		return result, fmt.Errorf("failed to create or update service principal: %w", err)
+		return nil, "", fmt.Errorf("failed to create or update service principal: %w", err)
 	}
 
 	if !strings.Contains(displayMsg, servicePrincipal.AppId) {
@@ -310,34 +474,23 @@ func (pm *PipelineManager) Configure(ctx context.Context, projectName string) (r
 	pm.console.StopSpinner(ctx, displayMsg, input.GetStepResultFormat(err))
 
 	// Set in .env to be retrieved for any additional runs
-	pm.env.DotenvSet(AzurePipelineClientIdEnvVarName, servicePrincipal.AppId)
-	if err := pm.envManager.Save(ctx, pm.env); err != nil {
-		return result, fmt.Errorf("failed to save environment: %w", err)
-	}
-
-	repoSlug := gitRepoInfo.owner + "/" + gitRepoInfo.repoName
-	displayMsg = fmt.Sprintf("Configuring repository %s to use credentials for %s", repoSlug, spConfig.applicationName)
-	pm.console.ShowSpinner(ctx, displayMsg, input.Step)
-
-	subscriptionId := pm.env.GetSubscriptionId()
-	credentials := &entraid.AzureCredentials{
-		ClientId:       servicePrincipal.AppId,
-		TenantId:       *servicePrincipal.AppOwnerOrganizationId,
-		SubscriptionId: subscriptionId,
+	env.DotenvSet(AzurePipelineClientIdEnvVarName, servicePrincipal.AppId)
+	if err := pm.envManager.Save(ctx, env); err != nil {
+		return nil, "", fmt.Errorf("failed to save environment: %w", err)
 	}
 
-	// Get the requested credential options from the CI provider
-	credentialOptions, err := pm.ciProvider.credentialOptions(
-		ctx,
-		gitRepoInfo,
-		infra.Options,
-		PipelineAuthType(pm.args.PipelineAuthTypeName),
-		credentials,
-	)
-	if err != nil {
-		return result, fmt.Errorf("failed to get credential options: %w", err)
-	}
+	return servicePrincipal, spConfig.applicationName, nil
+}
 
+// applyCredentialOptions resets the client secret and creates the federated identity credentials of the service
+// principal, as requested by the credential options. The returned credentials include the new client secret.
+func (pm *PipelineManager) applyCredentialOptions(
+	ctx context.Context,
+	subscriptionId string,
+	servicePrincipal *graphsdk.ServicePrincipal,
+	credentialOptions *CredentialOptions,
+	credentials *entraid.AzureCredentials,
+) (*entraid.AzureCredentials, error) {
 	// Enable client credentials if requested
 	if credentialOptions.EnableClientCredentials {
 		spinnerMessage := "Configuring client credentials for service principal"
@@ -346,7 +499,7 @@ func (pm *PipelineManager) Configure(ctx context.Context, projectName string) (r
 		creds, err := pm.entraIdService.ResetPasswordCredentials(ctx, subscriptionId, servicePrincipal.AppId)
 		pm.console.StopSpinner(ctx, spinnerMessage, input.GetStepResultFormat(err))
 		if err != nil {
-			return result, fmt.Errorf("failed to reset password credentials: %w", err)
+			return nil, fmt.Errorf("failed to reset password credentials: %w", err)
 		}
 
 		credentials = creds
@@ -360,7 +513,7 @@ func (pm *PipelineManager) Configure(ctx context.Context, projectName string) (r
 			credentialOptions.FederatedCredentialOptions,
 		)
 		if err != nil {
-			return result, fmt.Errorf("failed to create federated credentials: %w", err)
+			return nil, fmt.Errorf("failed to create federated credentials: %w", err)
 		}
 
 		for _, credential := range createdCredentials {
@@ -374,26 +527,21 @@ func (pm *PipelineManager) Configure(ctx context.Context, projectName string) (r
 		}
 	}
 
-	err = pm.ciProvider.configureConnection(
-		ctx,
-		gitRepoInfo,
-		infra.Options,
-		servicePrincipal,
-		credentialOptions,
-		credentials,
-	)
-
-	pm.console.StopSpinner(ctx, "", input.GetStepResultFormat(err))
-	if err != nil {
-		return result, err
-	}
+	return credentials, nil
+}
 
+// pipelineVariablesAndSecrets returns the variables and secrets to set in the pipeline to deploy the environment:
+// the azd defaults merged with the ones defined on azure.yaml, with the akvs secrets resolved from Azure Key Vault.
+func (pm *PipelineManager) pipelineVariablesAndSecrets(
+	ctx context.Context,
+	env *environment.Environment,
+) (variables map[string]string, secrets map[string]string, err error) {
 	// Adding environment.AzdInitialEnvironmentConfigName as a secret to the pipeline as the base configuration for
 	// whenever a new environment is created. This means loading the local environment config into a pipeline secret which
 	// azd will use to restore the the config on CI
-	localEnvConfig, err := json.Marshal(pm.env.Config.ResolvedRaw())
+	localEnvConfig, err := json.Marshal(env.Config.ResolvedRaw())
 	if err != nil {
-		return result, fmt.Errorf("failed to marshal environment config: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal environment config: %w", err)
 	}
 
 	defaultAzdSecrets := map[string]string{
@@ -403,41 +551,52 @@ func (pm *PipelineManager) Configure(ctx context.Context, projectName string) (r
 	defaultAzdVariables := map[string]string{}
 	// If the user has set the resource group name as an environment variable, we need to pass it to the pipeline
 	// as this likely means rg-deployment
-	if rgGroup, exists := pm.env.LookupEnv(environment.ResourceGroupEnvVarName); exists {
+	if rgGroup, exists := env.LookupEnv(environment.ResourceGroupEnvVarName); exists {
 		defaultAzdVariables[environment.ResourceGroupEnvVarName] = rgGroup
 	}
 
 	// Merge azd default variables and secrets with the ones defined on azure.yaml
-	pm.configOptions.variables, pm.configOptions.secrets = mergeProjectVariablesAndSecrets(
+	variables, secrets = mergeProjectVariablesAndSecrets(
 		pm.configOptions.projectVariables, pm.configOptions.projectSecrets,
-		defaultAzdVariables, defaultAzdSecrets, pm.env.Dotenv())
+		defaultAzdVariables, defaultAzdSecrets, env.Dotenv())
 
 	// resolve akvs secrets
 	// For each akvs in the secrets array:
 	// azd gets the value from Azure Key Vault and use it as a secret in the pipeline
-	for key, value := range pm.configOptions.secrets {
+	for key, value := range secrets {
 		if !strings.HasPrefix(value, "akvs://") {
 			continue
 		}
 		kvSecret, err := pm.keyVaultService.SecretFromAkvs(ctx, value)
 		if err != nil {
-			return result, fmt.Errorf("failed to resolve akvs '%s': %w", key, err)
+			return nil, nil, fmt.Errorf("failed to resolve akvs '%s': %w", key, err)
 		}
-		pm.configOptions.secrets[key] = kvSecret
+		secrets[key] = kvSecret
 	}
+
+	return variables, secrets, nil
+}
+
+// grantKeyVaultAccess assigns the service principal the role to read the secrets of the Key Vaults referenced by the
+// akvs variables, so the pipeline can resolve them.
+func (pm *PipelineManager) grantKeyVaultAccess(
+	ctx context.Context,
+	variables map[string]string,
+	servicePrincipal *graphsdk.ServicePrincipal,
+) error {
 	// For each akvs in the variables array:
 	// azd must grant read access role to the pipelines's identity to read the akvs
-	displayMsg = "Assigning read access role for Key Vault to service principal"
+	displayMsg := "Assigning read access role for Key Vault to service principal"
 	pm.console.ShowSpinner(ctx, displayMsg, input.Step)
 	kvAccounts := make(map[string]struct{})
-	for key, value := range pm.configOptions.variables {
+	for key, value := range variables {
 		if !strings.HasPrefix(value, "akvs://") {
 			continue
 		}
 
 		akvs, err := keyvault.ParseAzureKeyVaultSecret(value)
 		if err != nil {
-			return result, fmt.Errorf("failed to parse akvs '%s': %w", key, err)
+			return fmt.Errorf("failed to parse akvs '%s': %w", key, err)
 		}
 		kvId := akvs.SubscriptionId + akvs.VaultName
 		if _, ok := kvAccounts[kvId]; ok {
@@ -448,7 +607,7 @@ func (pm *PipelineManager) Configure(ctx context.Context, projectName string) (r
 		// can't use keyvaultService.Get() because it requires the resource group name and we don't save it for akvs
 		allKvFromSub, err := pm.keyVaultService.ListSubscriptionVaults(ctx, akvs.SubscriptionId)
 		if err != nil {
-			return result, fmt.Errorf(
+			return fmt.Errorf(
 				"assigning read access role for Key Vault to service principal: %w", err)
 		}
 		var vaultResourceId string
@@ -460,7 +619,7 @@ func (pm *PipelineManager) Configure(ctx context.Context, projectName string) (r
 			return false
 		})
 		if !foundKeyVault {
-			return result, fmt.Errorf(
+			return fmt.Errorf(
 				"assigning read access role for Key Vault to service principal: "+
 					"key vault '%s' not found in subscription '%s'", akvs.VaultName, akvs.SubscriptionId)
 		}
@@ -470,75 +629,15 @@ func (pm *PipelineManager) Configure(ctx context.Context, projectName string) (r
 		err = pm.entraIdService.CreateRbac(
 			ctx, akvs.SubscriptionId, vaultResourceId, keyvault.RoleIdKeyVaultSecretsUser, *servicePrincipal.Id)
 		if err != nil {
-			return result, fmt.Errorf(
+			return fmt.Errorf(
 				"assigning read access role for Key Vault to service principal: %w", err)
 		}
 		// save the kvId to avoid assigning the role multiple times for the same key vault
 		kvAccounts[kvId] = struct{}{}
 	}
-	pm.console.StopSpinner(ctx, displayMsg, input.GetStepResultFormat(err))
-
-	// config pipeline handles setting or creating the provider pipeline to be used
-	ciPipeline, err := pm.ciProvider.configurePipeline(ctx, gitRepoInfo, pm.configOptions)
-	if err != nil {
-		return result, err
-	}
-
-	// The CI pipeline should be set-up and ready at this point.
-	// azd offers to push changes to the scm to start a new pipeline run
-	doPush, err := pm.console.Confirm(ctx, input.ConsoleOptions{
-		Message:      "Would you like to commit and push your local changes to start the configured CI pipeline?",
-		DefaultValue: true,
-	})
-	if err != nil {
-		return result, fmt.Errorf("prompting to push: %w", err)
-	}
+	pm.console.StopSpinner(ctx, displayMsg, input.StepDone)
 
-	// scm provider can prevent from pushing changes and/or use the
-	// interactive console for setting up any missing details.
-	// For example, GitHub provider would check if GH-actions are disabled.
-	if doPush {
-		preventPush, err := pm.scmProvider.preventGitPush(
-			ctx,
-			gitRepoInfo,
-			pm.args.PipelineRemoteName,
-			gitRepoInfo.branch)
-		if err != nil {
-			return result, fmt.Errorf("check git push prevent: %w", err)
-		}
-		// revert user's choice when prevent git push returns true
-		doPush = !preventPush
-	}
-
-	if doPush {
-		err = pm.pushGitRepo(ctx, gitRepoInfo, gitRepoInfo.branch)
-		if err != nil {
-			return result, fmt.Errorf("git push: %w", err)
-		}
-
-		// The spinner can't run during `pushing changes` the next UX messages are purely simulated
-		displayMsg := "Pushing changes"
-		pm.console.Message(ctx, "") // new line before the step
-		pm.console.ShowSpinner(ctx, displayMsg, input.Step)
-		pm.console.StopSpinner(ctx, displayMsg, input.GetStepResultFormat(err))
-
-		displayMsg = "Queuing pipeline"
-		pm.console.ShowSpinner(ctx, displayMsg, input.Step)
-		gitRepoInfo.pushStatus = true
-		pm.console.StopSpinner(ctx, displayMsg, input.GetStepResultFormat(err))
-	} else {
-		pm.console.Message(ctx,
-			fmt.Sprintf(
-				"To fully enable pipeline you need to push this repo to the upstream "+
-					"using 'git push --set-upstream %s %s'.\n",
-				pm.args.PipelineRemoteName,
-				gitRepoInfo.branch))
-	}
-
-	return &PipelineConfigResult{
-		RepositoryLink: gitRepoInfo.url,
-		PipelineLink:   ciPipeline.url(),
-	}, nil
+	return nil
 }
 
 // requiredTools get all the provider's required tools.
@@ -758,9 +857,10 @@ func (pm *PipelineManager) resolveProviderAndDetermine(
 // Logic:
 //   - If the user specifies a provider through the arguments, that provider is used.
 //   - If no provider is specified:
-//   - If both GitHub and Azure DevOps configurations are detected, prompt the user to choose which one to use.
+//   - If configurations of several providers are detected, prompt the user to choose which one to use.
 //   - If only GitHub configuration is found, use GitHub Actions.
 //   - If only Azure DevOps configuration is found, use Azure DevOps.
+//   - If only GitLab configuration (.gitlab-ci.yml) is found, use GitLab CI/CD.
 //   - If no configuration is found, prompt the user to select which one to set up.
 //   - Default to GitHub Actions if no provider is specified or selected.
 //   - Prompt the user to confirm adding the azure-dev file if it’s missing, and inform them where the file is created.
@@ -827,6 +927,23 @@ func (pm *PipelineManager) initialize(ctx context.Context, override string) erro
 		authType = AuthTypeClientCredentials
 	}
 
+	if len(pm.args.PipelineEnvironments) > 0 {
+		if !supportsStages(pipelineProvider) {
+			return errStagesNotSupported(pipelineProviderFiles[pipelineProvider].DisplayName)
+		}
+
+		if hasPipelineFile(pipelineProvider, repoRoot) {
+			pm.console.MessageUxItem(ctx, &ux.WarningMessage{
+				Description: fmt.Sprintf(
+					"The existing pipeline definition is not updated. Make sure it has a deployment stage for each "+
+						"environment (%s), which uses the %s CI environment of the same name.",
+					strings.Join(pm.args.PipelineEnvironments, ", "),
+					pipelineProviderFiles[pipelineProvider].DisplayName,
+				),
+			})
+		}
+	}
+
 	// Check and prompt for missing CI/CD files
 	if err := pm.checkAndPromptForProviderFiles(
 		ctx, projectProperties{
@@ -848,11 +965,16 @@ func (pm *PipelineManager) initialize(ctx context.Context, override string) erro
 	}
 
 	var scmProviderName, ciProviderName, displayName string
-	if pipelineProvider == ciProviderAzureDevOps {
+	switch pipelineProvider {
+	case ciProviderAzureDevOps:
 		scmProviderName = string(ciProviderAzureDevOps)
 		ciProviderName = scmProviderName
 		displayName = azdoDisplayName
-	} else {
+	case ciProviderGitLab:
+		scmProviderName = string(ciProviderGitLab)
+		ciProviderName = scmProviderName
+		displayName = gitLabDisplayName
+	default:
 		scmProviderName = string(ciProviderGitHubActions)
 		ciProviderName = scmProviderName
 		displayName = gitHubDisplayName
@@ -973,7 +1095,7 @@ func (pm *PipelineManager) promptForCiFiles(ctx context.Context, props projectPr
 		ctx,
 		fmt.Sprintf(
 			"The default %s file, which contains a basic workflow to help you get started, is missing from your project.",
-			output.WithHighLightFormat("azure-dev.yml"),
+			output.WithHighLightFormat(pipelineProviderFiles[props.CiProvider].DefaultFile),
 		),
 	)
 	pm.console.Message(ctx, "")
@@ -1034,7 +1156,7 @@ func (pm *PipelineManager) promptForCiFiles(ctx context.Context, props projectPr
 }
 
 func generatePipelineDefinition(path string, props projectProperties) error {
-	embedFilePath := fmt.Sprintf("pipeline/.%s/azure-dev.ymlt", props.CiProvider)
+	embedFilePath := pipelineTemplatePath(props.CiProvider, len(props.Environments) > 0)
 	tmpl, err := template.
 		New("azure-dev.yml").
 		Option("missingkey=error").
@@ -1049,12 +1171,14 @@ func generatePipelineDefinition(path string, props projectProperties) error {
 		InstallDotNetForAspire bool
 		Variables              []string
 		Secrets                []string
+		Stages                 []pipelineTemplateStage
 	}{
 		BranchName:             props.BranchName,
 		FedCredLogIn:           props.AuthType == AuthTypeFederated,
 		InstallDotNetForAspire: props.HasAppHost,
 		Variables:              props.Variables,
 		Secrets:                props.Secrets,
+		Stages:                 newPipelineTemplateStages(props.Environments),
 	})
 	if err != nil {
 		return fmt.Errorf("executing template: %w", err)
@@ -1083,33 +1207,26 @@ func (pm *PipelineManager) determineProvider(ctx context.Context, repoRoot strin
 	log.Printf("Checking for CI/CD YAML files in the repository root: %s", repoRoot)
 
 	// Check for existence of official YAML files in the repo root
-	hasGitHubYml := hasPipelineFile(ciProviderGitHubActions, repoRoot)
-	hasAzDevOpsYml := hasPipelineFile(ciProviderAzureDevOps, repoRoot)
-
-	log.Printf("GitHub Actions YAML exists: %v", hasGitHubYml)
-	log.Printf("Azure DevOps YAML exists: %v", hasAzDevOpsYml)
+	var detectedProviders []ciProviderType
+	for _, provider := range []ciProviderType{ciProviderGitHubActions, ciProviderAzureDevOps, ciProviderGitLab} {
+		hasYml := hasPipelineFile(provider, repoRoot)
+		log.Printf("%s YAML exists: %v", pipelineProviderFiles[provider].DisplayName, hasYml)
 
-	switch {
-	case (!hasGitHubYml && !hasAzDevOpsYml) || (hasGitHubYml && hasAzDevOpsYml):
-		// No official YAML files found for either provider or both are found
-		log.Printf("Neither or both YAML files found. Prompting user for provider selection.")
-		return pm.promptForProvider(ctx)
-
-	case hasGitHubYml && !hasAzDevOpsYml:
-		// GitHub Actions YAML found, Azure DevOps YAML not found
-		log.Printf("Only GitHub Actions YAML found. Selecting GitHub Actions as the provider.")
-		return ciProviderGitHubActions, nil
-
-	case hasAzDevOpsYml && !hasGitHubYml:
-		// Azure DevOps YAML found, GitHub Actions YAML not found
-		log.Printf("Only Azure DevOps YAML found. Selecting Azure DevOps as the provider.")
-		return ciProviderAzureDevOps, nil
+		if hasYml {
+			detectedProviders = append(detectedProviders, provider)
+		}
+	}
 
-	default:
-		// Default to GitHub Actions if no provider is specified
-		log.Printf("Defaulting to GitHub Actions as the provider.")
-		return ciProviderGitHubActions, nil
+	if len(detectedProviders) == 1 {
+		// Only the YAML file of one provider found
+		log.Printf("Only %s YAML found. Selecting it as the provider.",
+			pipelineProviderFiles[detectedProviders[0]].DisplayName)
+		return detectedProviders[0], nil
 	}
+
+	// No official YAML files found for any provider or several are found
+	log.Printf("No YAML files or YAML files for several providers found. Prompting user for provider selection.")
+	return pm.promptForProvider(ctx)
 }
 
 // promptForProvider prompts the user to select a CI/CD provider.
@@ -1118,7 +1235,7 @@ func (pm *PipelineManager) promptForProvider(ctx context.Context) (ciProviderTyp
 	pm.console.Message(ctx, "")
 	choice, err := pm.console.Select(ctx, input.ConsoleOptions{
 		Message: "Select a provider:",
-		Options: []string{gitHubDisplayName, azdoDisplayName},
+		Options: []string{gitHubDisplayName, azdoDisplayName, gitLabDisplayName},
 	})
 	if err != nil {
 		return "", fmt.Errorf("prompting for CI/CD provider: %w", err)
@@ -1126,10 +1243,13 @@ func (pm *PipelineManager) promptForProvider(ctx context.Context) (ciProviderTyp
 
 	log.Printf("User selected choice: %d", choice)
 
-	if choice == 0 {
+	switch choice {
+	case 0:
 		return ciProviderGitHubActions, nil
-	} else if choice == 1 {
+	case 1:
 		return ciProviderAzureDevOps, nil
+	case 2:
+		return ciProviderGitLab, nil
 	}
 
 	return "", nil // This case should never occur with the current options.
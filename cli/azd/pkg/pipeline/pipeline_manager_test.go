@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
@@ -128,6 +129,25 @@ func Test_PipelineManager_Initialize(t *testing.T) {
 		deleteYamlFiles(t, tempDir)
 	})
 
+	t.Run("no files - gitlab selected", func(t *testing.T) {
+		mockContext = resetContext(tempDir, ctx)
+
+		deleteYamlFiles(t, tempDir)
+
+		simulateUserInteraction(mockContext, ciProviderGitLab, true)
+
+		manager, err := createPipelineManager(mockContext, azdContext, nil, nil)
+		assert.NotNil(t, manager)
+		verifyProvider(t, manager, ciProviderGitLab, err)
+
+		// Check if the .gitlab-ci.yml file was created at the root of the repository
+		gitLabYmlPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitLab].Files[0])
+		assert.FileExists(t, gitLabYmlPath)
+		assert.Equal(t, filepath.Join(tempDir, ".gitlab-ci.yml"), gitLabYmlPath)
+
+		deleteYamlFiles(t, tempDir)
+	})
+
 	t.Run("from persisted data azdo error", func(t *testing.T) {
 		// User selects Azure DevOps, but the required directory is missing
 		mockContext = resetContext(tempDir, ctx)
@@ -713,6 +733,138 @@ func Test_promptForCiFiles(t *testing.T) {
 		assert.NoError(t, err)
 		snapshot.SnapshotT(t, normalizeEOL(content))
 	})
+	t.Run("no files - gitlab selected - no app host - fed Cred", func(t *testing.T) {
+		tempDir := t.TempDir()
+		expectedPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitLab].Files[0])
+		err := generatePipelineDefinition(expectedPath, projectProperties{
+			CiProvider:    ciProviderGitLab,
+			InfraProvider: infraProviderBicep,
+			RepoRoot:      tempDir,
+			HasAppHost:    false,
+			BranchName:    "main",
+			AuthType:      AuthTypeFederated,
+		})
+		assert.NoError(t, err)
+		// should've created the pipeline
+		assert.FileExists(t, expectedPath)
+		// open the file and check the content
+		content, err := os.ReadFile(expectedPath)
+		assert.NoError(t, err)
+		snapshot.SnapshotT(t, normalizeEOL(content))
+	})
+	t.Run("no files - gitlab selected - App host - client cred", func(t *testing.T) {
+		tempDir := t.TempDir()
+		expectedPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitLab].Files[0])
+		err := generatePipelineDefinition(expectedPath, projectProperties{
+			CiProvider:    ciProviderGitLab,
+			InfraProvider: infraProviderBicep,
+			RepoRoot:      tempDir,
+			HasAppHost:    true,
+			BranchName:    "main",
+			AuthType:      AuthTypeClientCredentials,
+		})
+		assert.NoError(t, err)
+		// should've created the pipeline
+		assert.FileExists(t, expectedPath)
+		// open the file and check the content
+		content, err := os.ReadFile(expectedPath)
+		assert.NoError(t, err)
+		snapshot.SnapshotT(t, normalizeEOL(content))
+	})
+	t.Run("no files - gitlab selected - branch name", func(t *testing.T) {
+		tempDir := t.TempDir()
+		expectedPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitLab].Files[0])
+		err := generatePipelineDefinition(expectedPath, projectProperties{
+			CiProvider:    ciProviderGitLab,
+			InfraProvider: infraProviderBicep,
+			RepoRoot:      tempDir,
+			HasAppHost:    false,
+			BranchName:    "non-main",
+			AuthType:      AuthTypeFederated,
+		})
+		assert.NoError(t, err)
+		// should've created the pipeline
+		assert.FileExists(t, expectedPath)
+		// open the file and check the content
+		content, err := os.ReadFile(expectedPath)
+		assert.NoError(t, err)
+		snapshot.SnapshotT(t, normalizeEOL(content))
+	})
+	t.Run("no files - github selected - environments - fed Cred", func(t *testing.T) {
+		tempDir := t.TempDir()
+		path := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitHubActions].PipelineDirectories[0])
+		err := os.MkdirAll(path, osutil.PermissionDirectory)
+		assert.NoError(t, err)
+		expectedPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitHubActions].Files[0])
+		err = generatePipelineDefinition(expectedPath, projectProperties{
+			CiProvider:    ciProviderGitHubActions,
+			InfraProvider: infraProviderBicep,
+			RepoRoot:      tempDir,
+			HasAppHost:    false,
+			BranchName:    "main",
+			AuthType:      AuthTypeFederated,
+			Variables:     []string{"VAR_1"},
+			Secrets:       []string{"SECRET_1"},
+			Environments:  []string{"app-dev", "app-prod"},
+		})
+		assert.NoError(t, err)
+		// should've created the pipeline
+		assert.FileExists(t, expectedPath)
+		// open the file and check the content
+		content, err := os.ReadFile(expectedPath)
+		assert.NoError(t, err)
+		snapshot.SnapshotT(t, normalizeEOL(content))
+	})
+	t.Run("no files - github selected - environments - client cred", func(t *testing.T) {
+		tempDir := t.TempDir()
+		path := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitHubActions].PipelineDirectories[0])
+		err := os.MkdirAll(path, osutil.PermissionDirectory)
+		assert.NoError(t, err)
+		expectedPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitHubActions].Files[0])
+		err = generatePipelineDefinition(expectedPath, projectProperties{
+			CiProvider:    ciProviderGitHubActions,
+			InfraProvider: infraProviderBicep,
+			RepoRoot:      tempDir,
+			HasAppHost:    true,
+			BranchName:    "main",
+			AuthType:      AuthTypeClientCredentials,
+			Variables:     []string{"VAR_1"},
+			Secrets:       []string{"SECRET_1"},
+			Environments:  []string{"app-dev", "app-prod"},
+		})
+		assert.NoError(t, err)
+		// should've created the pipeline
+		assert.FileExists(t, expectedPath)
+		// open the file and check the content
+		content, err := os.ReadFile(expectedPath)
+		assert.NoError(t, err)
+		snapshot.SnapshotT(t, normalizeEOL(content))
+	})
+	t.Run("no files - azdo selected - environments - fed Cred", func(t *testing.T) {
+		tempDir := t.TempDir()
+		path := filepath.Join(tempDir, pipelineProviderFiles[ciProviderAzureDevOps].PipelineDirectories[0])
+		err := os.MkdirAll(path, osutil.PermissionDirectory)
+		assert.NoError(t, err)
+		expectedPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderAzureDevOps].Files[0])
+		err = generatePipelineDefinition(expectedPath, projectProperties{
+			CiProvider:    ciProviderAzureDevOps,
+			InfraProvider: infraProviderBicep,
+			RepoRoot:      tempDir,
+			HasAppHost:    true,
+			BranchName:    "main",
+			AuthType:      AuthTypeFederated,
+			Variables:     []string{"VAR_1"},
+			Secrets:       []string{"SECRET_1"},
+			Environments:  []string{"app-dev", "app-prod"},
+		})
+		assert.NoError(t, err)
+		// should've created the pipeline
+		assert.FileExists(t, expectedPath)
+		// open the file and check the content
+		content, err := os.ReadFile(expectedPath)
+		assert.NoError(t, err)
+		snapshot.SnapshotT(t, normalizeEOL(content))
+	})
 }
 
 func Test_promptForCiFiles_azureDevOpsDirectory(t *testing.T) {
@@ -798,6 +950,7 @@ func createPipelineManager(
 	)
 	mockContext.Container.MustRegisterSingleton(github.NewGitHubCli)
 	mockContext.Container.MustRegisterSingleton(git.NewCli)
+	ioc.RegisterInstance[auth.HttpClient](mockContext.Container, mockContext.HttpClient)
 
 	// Pipeline providers
 	pipelineProviderMap := map[string]any{
@@ -805,6 +958,8 @@ func createPipelineManager(
 		"github-scm": NewGitHubScmProvider,
 		"azdo-ci":    NewAzdoCiProvider,
 		"azdo-scm":   NewAzdoScmProvider,
+		"gitlab-ci":  NewGitLabCiProvider,
+		"gitlab-scm": NewGitLabScmProvider,
 	}
 
 	for provider, constructor := range pipelineProviderMap {
@@ -957,20 +1112,28 @@ func createPipelineFiles(t *testing.T, baseDir string, provider ciProviderType,
 func deleteYamlFiles(t *testing.T, tempDir string, deleteOptions ...ciProviderType) {
 	shouldDeleteGitHub := true
 	shouldDeleteAzdo := true
+	shouldDeleteGitLab := true
 
 	if len(deleteOptions) > 0 {
 		shouldDeleteGitHub = false
 		shouldDeleteAzdo = false
+		shouldDeleteGitLab = false
 		for _, option := range deleteOptions {
 			switch option {
 			case ciProviderGitHubActions:
 				shouldDeleteGitHub = true
 			case ciProviderAzureDevOps:
 				shouldDeleteAzdo = true
+			case ciProviderGitLab:
+				shouldDeleteGitLab = true
 			}
 		}
 	}
 
+	if shouldDeleteGitLab {
+		deletePipelineFiles(t, tempDir, ciProviderGitLab)
+	}
+
 	if shouldDeleteGitHub {
 		deletePipelineFiles(t, tempDir, ciProviderGitHubActions)
 	}
@@ -997,6 +1160,8 @@ func simulateUserInteraction(mockContext *mocks.MockContext, providerLabel ciPro
 		providerIndex = 0
 	case ciProviderAzureDevOps:
 		providerIndex = 1
+	case ciProviderGitLab:
+		providerIndex = 2
 	default:
 		providerIndex = 0
 	}
@@ -1024,6 +1189,9 @@ func verifyProvider(t *testing.T, manager *PipelineManager, providerLabel ciProv
 	case ciProviderAzureDevOps:
 		assert.IsType(t, &AzdoScmProvider{}, manager.scmProvider)
 		assert.IsType(t, &AzdoCiProvider{}, manager.ciProvider)
+	case ciProviderGitLab:
+		assert.IsType(t, &GitLabScmProvider{}, manager.scmProvider)
+		assert.IsType(t, &GitLabCiProvider{}, manager.ciProvider)
 	default:
 		t.Fatalf("%s is not a known pipeline provider", providerLabel)
 	}
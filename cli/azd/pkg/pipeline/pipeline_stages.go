@@ -0,0 +1,242 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdo"
+	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/resources"
+)
+
+// stageIdInvalidCharsRegex matches the characters of an environment name that are not valid in the id of a stage or
+// job of a pipeline definition.
+var stageIdInvalidCharsRegex = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// pipelineTemplateStage is the deployment stage of an azd environment, as used by the pipeline definition templates.
+type pipelineTemplateStage struct {
+	// EnvName is the name of the azd environment deployed by the stage, and of its CI environment
+	EnvName string
+	// Id identifies the stage, or job, in the pipeline definition
+	Id string
+	// DependsOn is the id of the previous stage. Empty for the first stage.
+	DependsOn string
+	// ServiceConnection is the name of the Azure DevOps service connection used by the stage
+	ServiceConnection string
+	// VariableGroup is the name of the Azure DevOps variable group with the variables and secrets of the stage
+	VariableGroup string
+}
+
+// newPipelineTemplateStages creates the deployment stages of the environments, in order, for the pipeline definition
+// templates.
+func newPipelineTemplateStages(envNames []string) []pipelineTemplateStage {
+	stages := make([]pipelineTemplateStage, 0, len(envNames))
+	for i, envName := range envNames {
+		stage := pipelineTemplateStage{
+			EnvName:           envName,
+			Id:                stageId(envName),
+			ServiceConnection: azdo.StageServiceConnectionName(envName),
+			VariableGroup:     azdo.StageVariableGroupName(envName),
+		}
+		if i > 0 {
+			stage.DependsOn = stages[i-1].Id
+		}
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+// stageId returns the id of the deployment stage of the environment in the pipeline definition.
+func stageId(envName string) string {
+	return "deploy_" + stageIdInvalidCharsRegex.ReplaceAllString(envName, "_")
+}
+
+// pipelineTemplatePath returns the path, in the embedded resources, of the template of the pipeline definition of the
+// provider. Pipelines deploying to several environments use the template with deployment stages.
+func pipelineTemplatePath(provider ciProviderType, staged bool) string {
+	if staged {
+		return fmt.Sprintf("pipeline/.%s/azure-dev-stages.ymlt", provider)
+	}
+	return fmt.Sprintf("pipeline/.%s/azure-dev.ymlt", provider)
+}
+
+// supportsStages checks if azd can generate a pipeline definition with deployment stages for the provider.
+func supportsStages(provider ciProviderType) bool {
+	_, err := fs.Stat(resources.PipelineFiles, pipelineTemplatePath(provider, true))
+	return err == nil
+}
+
+// errStagesNotSupported is returned when several environments are requested for a provider that can't deploy them.
+func errStagesNotSupported(providerDisplayName string) error {
+	return fmt.Errorf(
+		"%s doesn't support deploying to several environments. Remove the %s flag or use another provider",
+		providerDisplayName,
+		output.WithBackticks("--environments"),
+	)
+}
+
+// loadStages loads the azd environments deployed by the pipeline, when it deploys to several environments, and returns
+// the deployment stage of each environment. Every stage after the first one requires an approval before deploying.
+func (pm *PipelineManager) loadStages(ctx context.Context) ([]*pipelineStage, error) {
+	if len(pm.args.PipelineEnvironments) == 0 {
+		return nil, nil
+	}
+
+	if _, ok := pm.ciProvider.(stagedCiProvider); !ok {
+		return nil, errStagesNotSupported(pm.ciProvider.Name())
+	}
+
+	// Each environment is deployed by its own service principal, so that a stage can't deploy the other environments
+	if len(pm.args.PipelineEnvironments) > 1 &&
+		(pm.args.PipelineServicePrincipalId != "" || pm.args.PipelineServicePrincipalName != "") {
+		return nil, fmt.Errorf(
+			"%s and %s can't be used when deploying to several environments, as every environment is deployed by "+
+				"its own service principal. Remove the flag, or set %s in each environment to use an existing "+
+				"service principal",
+			output.WithBackticks("--principal-id"),
+			output.WithBackticks("--principal-name"),
+			AzurePipelineClientIdEnvVarName,
+		)
+	}
+
+	stages := make([]*pipelineStage, 0, len(pm.args.PipelineEnvironments))
+	names := map[string]struct{}{}
+	for i, name := range pm.args.PipelineEnvironments {
+		if name == "" {
+			return nil, errors.New("the names of the environments can't be empty")
+		}
+		if _, has := names[name]; has {
+			return nil, fmt.Errorf("environment '%s' is listed more than once", name)
+		}
+		names[name] = struct{}{}
+
+		env := pm.env
+		if name != pm.env.Name() {
+			loaded, err := pm.envManager.Get(ctx, name)
+			if errors.Is(err, environment.ErrNotFound) {
+				return nil, fmt.Errorf(
+					"environment '%s' was not found. Create and provision it with %s before configuring the pipeline",
+					name,
+					output.WithHighLightFormat("azd provision -e %s", name),
+				)
+			} else if err != nil {
+				return nil, fmt.Errorf("loading environment '%s': %w", name, err)
+			}
+			env = loaded
+		}
+
+		for _, key := range []string{environment.SubscriptionIdEnvVarName, environment.LocationEnvVarName} {
+			if env.Getenv(key) == "" {
+				return nil, fmt.Errorf(
+					"environment '%s' has no value for %s. Provision it with %s before configuring the pipeline",
+					name,
+					key,
+					output.WithHighLightFormat("azd provision -e %s", name),
+				)
+			}
+		}
+
+		stages = append(stages, &pipelineStage{
+			env:             env,
+			requireApproval: i > 0,
+		})
+	}
+
+	return stages, nil
+}
+
+// configureStages creates or updates the service principal of each deployment stage, sets up the CI environment of
+// each stage and configures the pipeline running the stages.
+func (pm *PipelineManager) configureStages(
+	ctx context.Context,
+	projectName string,
+	smr *string,
+	gitRepoInfo *gitRepositoryDetails,
+	stages []*pipelineStage,
+) (CiPipeline, error) {
+	stagedProvider := pm.ciProvider.(stagedCiProvider)
+	infra := pm.infra
+	repoSlug := gitRepoInfo.owner + "/" + gitRepoInfo.repoName
+
+	for _, stage := range stages {
+		pm.console.Message(ctx, "")
+		pm.console.Message(ctx, fmt.Sprintf(
+			"Configuring the deployment stage of environment %s", output.WithHighLightFormat(stage.name())))
+
+		servicePrincipal, applicationName, err := pm.ensureServicePrincipal(
+			ctx, stage.env, projectName, smr, stage.name())
+		if err != nil {
+			return nil, err
+		}
+
+		displayMsg := fmt.Sprintf(
+			"Configuring environment %s of repository %s to use credentials for %s",
+			stage.name(), repoSlug, applicationName)
+		pm.console.ShowSpinner(ctx, displayMsg, input.Step)
+
+		subscriptionId := stage.env.GetSubscriptionId()
+		credentials := &entraid.AzureCredentials{
+			ClientId:       servicePrincipal.AppId,
+			TenantId:       *servicePrincipal.AppOwnerOrganizationId,
+			SubscriptionId: subscriptionId,
+		}
+
+		credentialOptions, err := stagedProvider.stageCredentialOptions(
+			ctx,
+			gitRepoInfo,
+			stage,
+			infra.Options,
+			PipelineAuthType(pm.args.PipelineAuthTypeName),
+			credentials,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get credential options: %w", err)
+		}
+
+		credentials, err = pm.applyCredentialOptions(ctx, subscriptionId, servicePrincipal, credentialOptions, credentials)
+		if err != nil {
+			return nil, err
+		}
+
+		options := &configurePipelineOptions{
+			provisioningProvider: pm.configOptions.provisioningProvider,
+			projectVariables:     pm.configOptions.projectVariables,
+			projectSecrets:       pm.configOptions.projectSecrets,
+		}
+		options.variables, options.secrets, err = pm.pipelineVariablesAndSecrets(ctx, stage.env)
+		if err != nil {
+			return nil, err
+		}
+
+		err = stagedProvider.configureStage(
+			ctx,
+			gitRepoInfo,
+			stage,
+			infra.Options,
+			servicePrincipal,
+			credentialOptions,
+			credentials,
+			options,
+		)
+		pm.console.StopSpinner(ctx, "", input.GetStepResultFormat(err))
+		if err != nil {
+			return nil, fmt.Errorf("configuring environment %s: %w", stage.name(), err)
+		}
+
+		if err := pm.grantKeyVaultAccess(ctx, options.variables, servicePrincipal); err != nil {
+			return nil, err
+		}
+	}
+
+	pm.console.Message(ctx, "")
+	return stagedProvider.configureStagedPipeline(ctx, gitRepoInfo, stages, pm.configOptions)
+}
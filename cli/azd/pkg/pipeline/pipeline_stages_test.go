@@ -0,0 +1,162 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newPipelineTemplateStages(t *testing.T) {
+	stages := newPipelineTemplateStages([]string{"app-dev", "app.prod"})
+
+	require.Equal(t, []pipelineTemplateStage{
+		{
+			EnvName:           "app-dev",
+			Id:                "deploy_app_dev",
+			ServiceConnection: "azconnection-app-dev",
+			VariableGroup:     "azd-app-dev",
+		},
+		{
+			EnvName:           "app.prod",
+			Id:                "deploy_app_prod",
+			DependsOn:         "deploy_app_dev",
+			ServiceConnection: "azconnection-app.prod",
+			VariableGroup:     "azd-app.prod",
+		},
+	}, stages)
+}
+
+func Test_supportsStages(t *testing.T) {
+	require.True(t, supportsStages(ciProviderGitHubActions))
+	require.True(t, supportsStages(ciProviderAzureDevOps))
+	require.False(t, supportsStages(ciProviderGitLab))
+}
+
+func Test_PipelineManager_loadStages(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	provisioned := map[string]string{
+		environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+		environment.LocationEnvVarName:       "eastus2",
+	}
+	current := environment.NewWithValues("app-dev", provisioned)
+	prod := environment.NewWithValues("app-prod", provisioned)
+
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Get", mock.Anything, "app-prod").Return(prod, nil)
+	envManager.On("Get", mock.Anything, "app-test").Return(environment.New("app-test"), nil)
+	envManager.On("Get", mock.Anything, "missing").Return((*environment.Environment)(nil), environment.ErrNotFound)
+
+	newManager := func(ciProvider CiProvider, envNames ...string) *PipelineManager {
+		return &PipelineManager{
+			env:        current,
+			envManager: envManager,
+			ciProvider: ciProvider,
+			args:       &PipelineManagerArgs{PipelineEnvironments: envNames},
+		}
+	}
+	gitHubProvider := NewGitHubCiProvider(current, nil, nil, nil, nil, mockContext.Console)
+
+	t.Run("no environments", func(t *testing.T) {
+		stages, err := newManager(gitHubProvider).loadStages(*mockContext.Context)
+		require.NoError(t, err)
+		require.Nil(t, stages)
+	})
+
+	t.Run("stages in order", func(t *testing.T) {
+		stages, err := newManager(gitHubProvider, "app-dev", "app-prod").loadStages(*mockContext.Context)
+		require.NoError(t, err)
+		require.Len(t, stages, 2)
+		require.Same(t, current, stages[0].env)
+		require.False(t, stages[0].requireApproval)
+		require.Same(t, prod, stages[1].env)
+		require.True(t, stages[1].requireApproval)
+	})
+
+	t.Run("provider without stages", func(t *testing.T) {
+		gitLabProvider := NewGitLabCiProvider(current, mockContext.Console, http.DefaultClient)
+		_, err := newManager(gitLabProvider, "app-dev").loadStages(*mockContext.Context)
+		require.ErrorContains(t, err, "GitLab doesn't support deploying to several environments")
+	})
+
+	t.Run("service principal with several environments", func(t *testing.T) {
+		manager := newManager(gitHubProvider, "app-dev", "app-prod")
+		manager.args.PipelineServicePrincipalName = "app-sp"
+		_, err := manager.loadStages(*mockContext.Context)
+		require.ErrorContains(t, err, "can't be used when deploying to several environments")
+	})
+
+	t.Run("service principal with one environment", func(t *testing.T) {
+		manager := newManager(gitHubProvider, "app-prod")
+		manager.args.PipelineServicePrincipalId = "CLIENT_ID"
+		stages, err := manager.loadStages(*mockContext.Context)
+		require.NoError(t, err)
+		require.Len(t, stages, 1)
+	})
+
+	t.Run("duplicated environment", func(t *testing.T) {
+		_, err := newManager(gitHubProvider, "app-dev", "app-dev").loadStages(*mockContext.Context)
+		require.ErrorContains(t, err, "environment 'app-dev' is listed more than once")
+	})
+
+	t.Run("missing environment", func(t *testing.T) {
+		_, err := newManager(gitHubProvider, "app-dev", "missing").loadStages(*mockContext.Context)
+		require.ErrorContains(t, err, "environment 'missing' was not found")
+	})
+
+	t.Run("environment not provisioned", func(t *testing.T) {
+		_, err := newManager(gitHubProvider, "app-test").loadStages(*mockContext.Context)
+		require.ErrorContains(t, err, "environment 'app-test' has no value for AZURE_SUBSCRIPTION_ID")
+	})
+}
+
+func Test_gitHub_provider_stageCredentialOptions(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	provider := NewGitHubCiProvider(
+		environment.New("app-dev"), nil, nil, nil, nil, mockContext.Console).(*GitHubCiProvider)
+	repoDetails := &gitRepositoryDetails{
+		owner:    "Azure",
+		repoName: "azure-dev",
+		branch:   "main",
+	}
+	stage := &pipelineStage{env: environment.New("app-prod"), requireApproval: true}
+
+	t.Run("federated", func(t *testing.T) {
+		options, err := provider.stageCredentialOptions(
+			*mockContext.Context, repoDetails, stage, provisioning.Options{}, "", &entraid.AzureCredentials{})
+		require.NoError(t, err)
+		require.True(t, options.EnableFederatedCredentials)
+		require.False(t, options.EnableClientCredentials)
+		require.Len(t, options.FederatedCredentialOptions, 1)
+
+		credential := options.FederatedCredentialOptions[0]
+		require.Equal(t, "Azure-azure-dev-environment-app-prod", credential.Name)
+		require.Equal(t, federatedIdentityIssuer, credential.Issuer)
+		require.Equal(t, "repo:Azure/azure-dev:environment:app-prod", credential.Subject)
+		require.Equal(t, []string{federatedIdentityAudience}, credential.Audiences)
+	})
+
+	t.Run("terraform defaults to client credentials", func(t *testing.T) {
+		options, err := provider.stageCredentialOptions(
+			*mockContext.Context,
+			repoDetails,
+			stage,
+			provisioning.Options{Provider: provisioning.Terraform},
+			"",
+			&entraid.AzureCredentials{},
+		)
+		require.NoError(t, err)
+		require.True(t, options.EnableClientCredentials)
+		require.False(t, options.EnableFederatedCredentials)
+	})
+}
@@ -279,7 +279,10 @@ func (ch *ContainerHelper) Deploy(
 	var remoteImage string
 	var err error
 
-	if serviceConfig.Docker.RemoteBuild {
+	if rollback, ok := packageOutput.Details.(*rollbackPackageResult); ok {
+		// The image of a previous deployment is already in the registry
+		remoteImage = rollback.RemoteImage
+	} else if serviceConfig.Docker.RemoteBuild {
 		remoteImage, err = ch.runRemoteBuild(ctx, serviceConfig, targetResource, progress)
 	} else if useDotnetPublishForDockerBuild(serviceConfig) {
 		remoteImage, err = ch.runDotnetPublish(ctx, serviceConfig, targetResource, progress)
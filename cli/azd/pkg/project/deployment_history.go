@@ -0,0 +1,425 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+)
+
+// DeploymentStatus is the result of the deployment of a service.
+type DeploymentStatus string
+
+const (
+	DeploymentSucceeded DeploymentStatus = "succeeded"
+	DeploymentFailed    DeploymentStatus = "failed"
+)
+
+// maxDeploymentsPerService is the number of deployments kept in the history of each service. The archived packages of
+// older deployments are removed.
+const maxDeploymentsPerService = 10
+
+// deploymentHistoryFileName is the name of the file storing the deployment history, in the deployments directory of
+// the environment.
+const deploymentHistoryFileName = "history.json"
+
+// ErrNoPreviousDeployment is returned when a service does not have a previous deployment to roll back to.
+var ErrNoPreviousDeployment = errors.New("no previous deployment")
+
+// DeploymentRecord is an entry of the deployment history of an environment.
+type DeploymentRecord struct {
+	Id        string            `json:"id"`
+	Service   string            `json:"service"`
+	Timestamp time.Time         `json:"timestamp"`
+	Kind      ServiceTargetKind `json:"kind,omitempty"`
+	Status    DeploymentStatus  `json:"status"`
+	Error     string            `json:"error,omitempty"`
+	// The container image that was deployed, for container based services
+	Image string `json:"image,omitempty"`
+	// The file name of the archived package, for file based packages
+	Artifact string `json:"artifact,omitempty"`
+	// The SHA-256 digest of the package file
+	PackageDigest string `json:"packageDigest,omitempty"`
+	// The commit checked out in the project repository at the time of the deployment
+	GitCommit        string   `json:"gitCommit,omitempty"`
+	TargetResourceId string   `json:"targetResourceId,omitempty"`
+	Endpoints        []string `json:"endpoints,omitempty"`
+	// The id of the redeployed deployment, when the deployment is a rollback
+	RollbackOf string `json:"rollbackOf,omitempty"`
+}
+
+// CanRollback returns true when the deployment succeeded and its artifact is available to be deployed again.
+func (r *DeploymentRecord) CanRollback() bool {
+	return r.Status == DeploymentSucceeded && (r.Image != "" || r.Artifact != "")
+}
+
+// sameArtifact returns true when both deployments deployed the same artifact.
+func (r *DeploymentRecord) sameArtifact(other *DeploymentRecord) bool {
+	if r.Image != "" || other.Image != "" {
+		return r.Image == other.Image
+	}
+
+	return r.PackageDigest != "" && r.PackageDigest == other.PackageDigest
+}
+
+type deploymentHistoryFile struct {
+	// The sequence number of the last deployment, used to generate deployment ids
+	LastId      int                 `json:"lastId"`
+	Deployments []*DeploymentRecord `json:"deployments"`
+}
+
+// rollbackPackageResult are the details of the package of a rollback to a container image that was pushed to the
+// registry by a previous deployment.
+type rollbackPackageResult struct {
+	RemoteImage string
+}
+
+// restoredPackageResult are the details of the package of a rollback to an archived package, which is a temporary copy
+// removed by [DeploymentHistory.Release].
+type restoredPackageResult struct{}
+
+// DeploymentHistory records the deployments of the services of an environment, and provides the artifacts of previous
+// deployments to roll back to.
+type DeploymentHistory struct {
+	azdCtx *azdcontext.AzdContext
+	env    *environment.Environment
+	gitCli *git.Cli
+}
+
+// NewDeploymentHistory creates the deployment history of the environment.
+func NewDeploymentHistory(
+	azdCtx *azdcontext.AzdContext,
+	env *environment.Environment,
+	gitCli *git.Cli,
+) *DeploymentHistory {
+	return &DeploymentHistory{
+		azdCtx: azdCtx,
+		env:    env,
+		gitCli: gitCli,
+	}
+}
+
+// List returns the deployments of the service, or of all services when the service name is empty, newest first.
+func (h *DeploymentHistory) List(serviceName string) ([]*DeploymentRecord, error) {
+	history, err := h.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*DeploymentRecord
+	for _, record := range slices.Backward(history.Deployments) {
+		if serviceName == "" || record.Service == serviceName {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// Get returns the deployment with the given id.
+func (h *DeploymentHistory) Get(id string) (*DeploymentRecord, error) {
+	history, err := h.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range history.Deployments {
+		if record.Id == id {
+			return record, nil
+		}
+	}
+
+	return nil, fmt.Errorf("deployment '%s' not found in the history of environment '%s'", id, h.env.Name())
+}
+
+// Start creates the record of a deployment of the package of the service. File packages are archived before the
+// deployment, as service targets may remove them once deployed. The record is added to the history by [Complete].
+func (h *DeploymentHistory) Start(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	packageResult *ServicePackageResult,
+) (*DeploymentRecord, error) {
+	history, err := h.load()
+	if err != nil {
+		return nil, err
+	}
+
+	record := &DeploymentRecord{
+		Id:        strconv.Itoa(history.LastId + 1),
+		Service:   serviceConfig.Name,
+		Timestamp: time.Now().UTC(),
+		Kind:      serviceConfig.Host,
+	}
+
+	commit, err := h.gitCli.GetCurrentCommit(ctx, h.azdCtx.ProjectDirectory())
+	if err != nil {
+		log.Printf("failed getting the current commit of the project, skipping: %v", err)
+	} else {
+		record.GitCommit = commit
+	}
+
+	if packageResult == nil {
+		return record, nil
+	}
+
+	if info, err := os.Stat(packageResult.PackagePath); err == nil && !info.IsDir() {
+		artifact := fmt.Sprintf("%s-%s%s", record.Service, record.Id, filepath.Ext(packageResult.PackagePath))
+		if err := os.MkdirAll(h.directory(), osutil.PermissionDirectory); err != nil {
+			return nil, fmt.Errorf("creating deployments directory: %w", err)
+		}
+
+		digest, err := copyFile(packageResult.PackagePath, filepath.Join(h.directory(), artifact))
+		if err != nil {
+			return nil, fmt.Errorf("archiving package: %w", err)
+		}
+
+		record.Artifact = artifact
+		record.PackageDigest = digest
+	}
+
+	if details, ok := packageResult.Details.(*rollbackPackageResult); ok {
+		record.Image = details.RemoteImage
+	}
+
+	return record, nil
+}
+
+// Complete adds the deployment to the history with the result of the deployment, and removes the oldest deployments of
+// the service.
+func (h *DeploymentHistory) Complete(
+	record *DeploymentRecord,
+	deployResult *ServiceDeployResult,
+	deployErr error,
+) error {
+	history, err := h.load()
+	if err != nil {
+		return err
+	}
+
+	if deployErr != nil {
+		record.Status = DeploymentFailed
+		record.Error = deployErr.Error()
+	} else {
+		record.Status = DeploymentSucceeded
+	}
+
+	if deployResult != nil {
+		record.TargetResourceId = deployResult.TargetResourceId
+		record.Endpoints = deployResult.Endpoints
+	}
+
+	// Container based services save the image they deployed in the environment
+	if record.Image == "" && record.Artifact == "" && deployErr == nil {
+		record.Image = h.env.GetServiceProperty(record.Service, "IMAGE_NAME")
+	}
+
+	history.Deployments = append(history.Deployments, record)
+	if id, err := strconv.Atoi(record.Id); err == nil && id > history.LastId {
+		history.LastId = id
+	}
+
+	var pruned []*DeploymentRecord
+	count := 0
+	for _, existing := range slices.Backward(history.Deployments) {
+		if existing.Service != record.Service {
+			continue
+		}
+
+		count++
+		if count > maxDeploymentsPerService {
+			pruned = append(pruned, existing)
+		}
+	}
+
+	history.Deployments = slices.DeleteFunc(history.Deployments, func(existing *DeploymentRecord) bool {
+		return slices.Contains(pruned, existing)
+	})
+
+	for _, existing := range pruned {
+		if existing.Artifact != "" {
+			if err := os.Remove(filepath.Join(h.directory(), existing.Artifact)); err != nil &&
+				!errors.Is(err, os.ErrNotExist) {
+				log.Printf("failed removing archived package '%s': %v", existing.Artifact, err)
+			}
+		}
+	}
+
+	return h.save(history)
+}
+
+// RollbackTarget returns the deployment of the service to roll back to. When an id is provided, it is the deployment
+// with that id. Otherwise, it is the latest successful deployment of an artifact other than the current one.
+func (h *DeploymentHistory) RollbackTarget(serviceName string, id string) (*DeploymentRecord, error) {
+	if id != "" {
+		record, err := h.Get(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if serviceName != "" && record.Service != serviceName {
+			return nil, fmt.Errorf("deployment '%s' is a deployment of service '%s'", id, record.Service)
+		}
+
+		if !record.CanRollback() {
+			return nil, fmt.Errorf("deployment '%s' cannot be deployed again, as it did not succeed or its package was "+
+				"not archived", id)
+		}
+
+		return record, nil
+	}
+
+	records, err := h.List(serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	var current *DeploymentRecord
+	for _, record := range records {
+		if record.Status != DeploymentSucceeded {
+			continue
+		}
+
+		if current == nil {
+			current = record
+			continue
+		}
+
+		if record.CanRollback() && !record.sameArtifact(current) {
+			return record, nil
+		}
+	}
+
+	return nil, fmt.Errorf("service '%s': %w", serviceName, ErrNoPreviousDeployment)
+}
+
+// Package returns the package to deploy to roll back to the deployment. Archived packages are copied to a temporary
+// file, as service targets may remove the package once deployed. The package must be released with [Release] once
+// deployed.
+func (h *DeploymentHistory) Package(record *DeploymentRecord) (*ServicePackageResult, error) {
+	if record.Image != "" {
+		return &ServicePackageResult{
+			PackagePath: record.Image,
+			Details: &rollbackPackageResult{
+				RemoteImage: record.Image,
+			},
+		}, nil
+	}
+
+	if record.Artifact == "" {
+		return nil, fmt.Errorf("deployment '%s' does not have an archived package", record.Id)
+	}
+
+	tempFile, err := os.CreateTemp("", fmt.Sprintf("%s-*%s", record.Service, filepath.Ext(record.Artifact)))
+	if err != nil {
+		return nil, fmt.Errorf("creating package file: %w", err)
+	}
+	tempFile.Close()
+
+	digest, err := copyFile(filepath.Join(h.directory(), record.Artifact), tempFile.Name())
+	if err != nil {
+		_ = os.Remove(tempFile.Name())
+		return nil, fmt.Errorf("restoring package of deployment '%s': %w", record.Id, err)
+	}
+
+	if record.PackageDigest != "" && digest != record.PackageDigest {
+		_ = os.Remove(tempFile.Name())
+		return nil, fmt.Errorf("the archived package of deployment '%s' was modified", record.Id)
+	}
+
+	return &ServicePackageResult{
+		PackagePath: tempFile.Name(),
+		Details:     &restoredPackageResult{},
+	}, nil
+}
+
+// Release removes the temporary copy of an archived package returned by [Package], if any.
+func (h *DeploymentHistory) Release(packageResult *ServicePackageResult) error {
+	if _, ok := packageResult.Details.(*restoredPackageResult); !ok {
+		return nil
+	}
+
+	if err := os.Remove(packageResult.PackagePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing restored package: %w", err)
+	}
+
+	return nil
+}
+
+// directory returns the directory of the deployment history of the environment.
+func (h *DeploymentHistory) directory() string {
+	return filepath.Join(h.azdCtx.EnvironmentRoot(h.env.Name()), "deployments")
+}
+
+func (h *DeploymentHistory) load() (*deploymentHistoryFile, error) {
+	history := &deploymentHistoryFile{}
+
+	contents, err := os.ReadFile(filepath.Join(h.directory(), deploymentHistoryFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading deployment history: %w", err)
+	}
+
+	if err := json.Unmarshal(contents, history); err != nil {
+		return nil, fmt.Errorf("parsing deployment history: %w", err)
+	}
+
+	return history, nil
+}
+
+func (h *DeploymentHistory) save(history *deploymentHistoryFile) error {
+	contents, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling deployment history: %w", err)
+	}
+
+	if err := os.MkdirAll(h.directory(), osutil.PermissionDirectory); err != nil {
+		return fmt.Errorf("creating deployments directory: %w", err)
+	}
+
+	if err := os.WriteFile(
+		filepath.Join(h.directory(), deploymentHistoryFileName), contents, osutil.PermissionFile); err != nil {
+		return fmt.Errorf("writing deployment history: %w", err)
+	}
+
+	return nil
+}
+
+// copyFile copies the source file to the destination path and returns the SHA-256 digest of its contents.
+func copyFile(sourcePath string, destinationPath string) (string, error) {
+	sourceFile, err := os.Open(sourcePath)
+	if err != nil {
+		return "", fmt.Errorf("opening source file: %w", err)
+	}
+	defer sourceFile.Close()
+
+	destinationFile, err := os.Create(destinationPath)
+	if err != nil {
+		return "", fmt.Errorf("creating destination file: %w", err)
+	}
+	defer destinationFile.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(destinationFile, hash), sourceFile); err != nil {
+		return "", fmt.Errorf("copying file: %w", err)
+	}
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DeploymentHistory_FilePackages(t *testing.T) {
+	ctx := context.Background()
+	history, _ := newTestDeploymentHistory(t)
+	serviceConfig := &ServiceConfig{Name: "api", Host: AppServiceTarget}
+
+	deploy := func(contents string, deployErr error) *DeploymentRecord {
+		packagePath := filepath.Join(t.TempDir(), "api.zip")
+		require.NoError(t, os.WriteFile(packagePath, []byte(contents), 0600))
+
+		record, err := history.Start(ctx, serviceConfig, &ServicePackageResult{PackagePath: packagePath})
+		require.NoError(t, err)
+
+		// Service targets may remove the package once deployed
+		require.NoError(t, os.Remove(packagePath))
+
+		require.NoError(t, history.Complete(record, &ServiceDeployResult{TargetResourceId: "app"}, deployErr))
+		return record
+	}
+
+	_, err := history.RollbackTarget("api", "")
+	require.ErrorIs(t, err, ErrNoPreviousDeployment)
+
+	v1 := deploy("v1", nil)
+	deploy("v2", nil)
+	deploy("v3", errors.New("failed"))
+
+	records, err := history.List("api")
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, "3", records[0].Id)
+	require.Equal(t, DeploymentFailed, records[0].Status)
+	require.Equal(t, "failed", records[0].Error)
+	require.Equal(t, "app", records[1].TargetResourceId)
+
+	previous, err := history.RollbackTarget("api", "")
+	require.NoError(t, err)
+	require.Equal(t, v1.Id, previous.Id)
+
+	packageResult, err := history.Package(previous)
+	require.NoError(t, err)
+
+	contents, err := os.ReadFile(packageResult.PackagePath)
+	require.NoError(t, err)
+	require.Equal(t, "v1", string(contents))
+
+	require.NoError(t, history.Release(packageResult))
+	require.NoFileExists(t, packageResult.PackagePath)
+
+	_, err = history.RollbackTarget("api", "3")
+	require.ErrorContains(t, err, "cannot be deployed again")
+
+	_, err = history.RollbackTarget("web", v1.Id)
+	require.ErrorContains(t, err, "is a deployment of service 'api'")
+}
+
+func Test_DeploymentHistory_RollbackSkipsCurrentArtifact(t *testing.T) {
+	ctx := context.Background()
+	history, env := newTestDeploymentHistory(t)
+	serviceConfig := &ServiceConfig{Name: "web", Host: ContainerAppTarget}
+
+	deploy := func(image string, packageResult *ServicePackageResult) *DeploymentRecord {
+		record, err := history.Start(ctx, serviceConfig, packageResult)
+		require.NoError(t, err)
+
+		env.SetServiceProperty("web", "IMAGE_NAME", image)
+		require.NoError(t, history.Complete(record, &ServiceDeployResult{}, nil))
+		return record
+	}
+
+	deploy("myregistry.azurecr.io/web:1", &ServicePackageResult{PackagePath: "web:1"})
+	deploy("myregistry.azurecr.io/web:2", &ServicePackageResult{PackagePath: "web:2"})
+
+	previous, err := history.RollbackTarget("web", "")
+	require.NoError(t, err)
+	require.Equal(t, "myregistry.azurecr.io/web:1", previous.Image)
+
+	packageResult, err := history.Package(previous)
+	require.NoError(t, err)
+	require.Equal(t, &rollbackPackageResult{RemoteImage: "myregistry.azurecr.io/web:1"}, packageResult.Details)
+
+	rollback := deploy("myregistry.azurecr.io/web:1", packageResult)
+	require.Equal(t, "myregistry.azurecr.io/web:1", rollback.Image)
+
+	// Rolling back again returns to the image deployed before the rollback
+	previous, err = history.RollbackTarget("web", "")
+	require.NoError(t, err)
+	require.Equal(t, "myregistry.azurecr.io/web:2", previous.Image)
+}
+
+func Test_DeploymentHistory_Prune(t *testing.T) {
+	ctx := context.Background()
+	history, _ := newTestDeploymentHistory(t)
+	serviceConfig := &ServiceConfig{Name: "api", Host: AppServiceTarget}
+
+	for i := 0; i < maxDeploymentsPerService+2; i++ {
+		packagePath := filepath.Join(t.TempDir(), "api.zip")
+		require.NoError(t, os.WriteFile(packagePath, []byte{byte(i)}, 0600))
+
+		record, err := history.Start(ctx, serviceConfig, &ServicePackageResult{PackagePath: packagePath})
+		require.NoError(t, err)
+		require.NoError(t, history.Complete(record, nil, nil))
+	}
+
+	records, err := history.List("")
+	require.NoError(t, err)
+	require.Len(t, records, maxDeploymentsPerService)
+	require.Equal(t, "12", records[0].Id)
+	require.Equal(t, "3", records[len(records)-1].Id)
+
+	require.NoFileExists(t, filepath.Join(history.directory(), "api-1.zip"))
+	require.NoFileExists(t, filepath.Join(history.directory(), "api-2.zip"))
+	require.FileExists(t, filepath.Join(history.directory(), "api-3.zip"))
+}
+
+func newTestDeploymentHistory(t *testing.T) (*DeploymentHistory, *environment.Environment) {
+	env := environment.NewWithValues("test", map[string]string{})
+	azdCtx := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+
+	return NewDeploymentHistory(azdCtx, env, git.NewCli(exec.NewCommandRunner(nil))), env
+}
@@ -7,7 +7,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 
+	"github.com/azure/azure-dev/cli/azd/internal/names"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 )
@@ -25,30 +27,52 @@ const (
 	ServiceLanguageJava       ServiceLanguageKind = "java"
 	ServiceLanguageDocker     ServiceLanguageKind = "docker"
 	ServiceLanguageSwa        ServiceLanguageKind = "swa"
+	ServiceLanguageGradle     ServiceLanguageKind = "gradle"
 )
 
+// builtInServiceLanguageKinds are the service languages that azd provides as languages in azure.yaml.
+//
+// Excluding ServiceLanguageSwa and ServiceLanguageGradle since they are implicitly derived currently,
+// and not actual languages
+var builtInServiceLanguageKinds = []ServiceLanguageKind{
+	ServiceLanguageNone,
+	ServiceLanguageDotNet,
+	ServiceLanguageCsharp,
+	ServiceLanguageFsharp,
+	ServiceLanguageJavaScript,
+	ServiceLanguageTypeScript,
+	ServiceLanguagePython,
+	ServiceLanguageJava,
+	ServiceLanguageDocker,
+}
+
+// IsBuiltIn returns true when the service language kind is provided by azd.
+func (slk ServiceLanguageKind) IsBuiltIn() bool {
+	return slk == ServiceLanguageSwa || slk == ServiceLanguageGradle || slices.Contains(builtInServiceLanguageKinds, slk)
+}
+
+// parseServiceLanguage validates the language of a service.
+// Languages that azd doesn't provide are accepted when they are valid names, since they can be provided by extensions.
+// Whether such a language is available is validated when the framework service of the service is resolved.
 func parseServiceLanguage(kind ServiceLanguageKind) (ServiceLanguageKind, error) {
 	// aliases
 	if string(kind) == "py" {
 		return ServiceLanguagePython, nil
 	}
 
-	switch kind {
-	case ServiceLanguageNone,
-		ServiceLanguageDotNet,
-		ServiceLanguageCsharp,
-		ServiceLanguageFsharp,
-		ServiceLanguageJavaScript,
-		ServiceLanguageTypeScript,
-		ServiceLanguagePython,
-		ServiceLanguageJava,
-		ServiceLanguageDocker:
-		// Excluding ServiceLanguageSwa since it is implicitly derived currently,
-		// and not an actual language
+	if kind == ServiceLanguageSwa || kind == ServiceLanguageGradle {
+		return ServiceLanguageKind("Unsupported"), fmt.Errorf("unsupported language '%s'", kind)
+	}
+
+	if kind.IsBuiltIn() {
 		return kind, nil
 	}
 
-	return ServiceLanguageKind("Unsupported"), fmt.Errorf("unsupported language '%s'", kind)
+	if err := names.ValidateExtensionKind(kind, builtInServiceLanguageKinds); err != nil {
+		return ServiceLanguageKind("Unsupported"), fmt.Errorf("unsupported language '%s': %w", kind, err)
+	}
+
+	return kind, nil
 }
 
 type FrameworkRequirements struct {
@@ -0,0 +1,63 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ExternalFrameworkServiceRegistry stores the framework services that are provided outside of azd, such as by extensions,
+// for languages that azd doesn't support.
+type ExternalFrameworkServiceRegistry struct {
+	frameworkServices map[ServiceLanguageKind]FrameworkService
+	mu                sync.RWMutex
+}
+
+// NewExternalFrameworkServiceRegistry creates a new, empty instance of the ExternalFrameworkServiceRegistry
+func NewExternalFrameworkServiceRegistry() *ExternalFrameworkServiceRegistry {
+	return &ExternalFrameworkServiceRegistry{
+		frameworkServices: map[ServiceLanguageKind]FrameworkService{},
+	}
+}
+
+// Register registers the framework service for the specified language.
+// Languages that azd provides, or that have already been registered, can't be registered.
+func (r *ExternalFrameworkServiceRegistry) Register(language ServiceLanguageKind, frameworkService FrameworkService) error {
+	// "py" is an alias of the python language
+	if language.IsBuiltIn() || language == "py" {
+		return fmt.Errorf("language '%s' is provided by azd and can't be registered", language)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, has := r.frameworkServices[language]; has {
+		return fmt.Errorf("language '%s' has already been registered", language)
+	}
+
+	r.frameworkServices[language] = frameworkService
+
+	return nil
+}
+
+// Unregister removes the framework service registered for the specified language,
+// when it's still the registered framework service of the language.
+func (r *ExternalFrameworkServiceRegistry) Unregister(language ServiceLanguageKind, frameworkService FrameworkService) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if registered, has := r.frameworkServices[language]; has && registered == frameworkService {
+		delete(r.frameworkServices, language)
+	}
+}
+
+// Get returns the framework service registered for the specified language
+func (r *ExternalFrameworkServiceRegistry) Get(language ServiceLanguageKind) (FrameworkService, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	frameworkService, has := r.frameworkServices[language]
+	return frameworkService, has
+}
@@ -0,0 +1,61 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExternalFrameworkServiceRegistry(t *testing.T) {
+	t.Run("Register", func(t *testing.T) {
+		registry := NewExternalFrameworkServiceRegistry()
+		frameworkService := &fakeFramework{}
+
+		err := registry.Register("rust", frameworkService)
+		require.NoError(t, err)
+
+		actual, has := registry.Get("rust")
+		require.True(t, has)
+		require.Same(t, frameworkService, actual)
+
+		_, has = registry.Get("go")
+		require.False(t, has)
+	})
+
+	t.Run("AlreadyRegistered", func(t *testing.T) {
+		registry := NewExternalFrameworkServiceRegistry()
+		require.NoError(t, registry.Register("rust", &fakeFramework{}))
+
+		err := registry.Register("rust", &fakeFramework{})
+		require.ErrorContains(t, err, "language 'rust' has already been registered")
+	})
+
+	t.Run("BuiltInLanguage", func(t *testing.T) {
+		registry := NewExternalFrameworkServiceRegistry()
+
+		for _, language := range []ServiceLanguageKind{ServiceLanguageJava, ServiceLanguageGradle, "py"} {
+			err := registry.Register(language, &fakeFramework{})
+			require.ErrorContains(t, err, "is provided by azd")
+		}
+	})
+}
+
+func Test_parseServiceLanguage_External(t *testing.T) {
+	kind, err := parseServiceLanguage("rust")
+	require.NoError(t, err)
+	require.Equal(t, ServiceLanguageKind("rust"), kind)
+	require.False(t, kind.IsBuiltIn())
+
+	kind, err = parseServiceLanguage("py")
+	require.NoError(t, err)
+	require.Equal(t, ServiceLanguagePython, kind)
+
+	_, err = parseServiceLanguage(ServiceLanguageSwa)
+	require.Error(t, err)
+
+	_, err = parseServiceLanguage("pyhton")
+	require.ErrorContains(t, err, "unsupported language 'pyhton': did you mean 'python'?")
+}
@@ -0,0 +1,319 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/gradle"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/javac"
+	"github.com/otiai10/copy"
+)
+
+// gradleBuildFiles are the files that mark a directory as a gradle project, in order of precedence.
+var gradleBuildFiles = []string{
+	"build.gradle",
+	"build.gradle.kts",
+	"settings.gradle",
+	"settings.gradle.kts",
+}
+
+type gradleProject struct {
+	env       *environment.Environment
+	gradleCli *gradle.Cli
+	javacCli  *javac.Cli
+}
+
+// NewGradleProject creates a new instance of a gradle project
+func NewGradleProject(env *environment.Environment, gradleCli *gradle.Cli, javaCli *javac.Cli) FrameworkService {
+	return &gradleProject{
+		env:       env,
+		gradleCli: gradleCli,
+		javacCli:  javaCli,
+	}
+}
+
+// isGradleProject returns true when the directory contains a gradle build and no maven pom.xml.
+// Maven takes precedence for directories that contain both, which keeps existing projects unchanged.
+func isGradleProject(path string) (bool, error) {
+	if _, err := os.Stat(filepath.Join(path, "pom.xml")); err == nil {
+		return false, nil
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+
+	for _, buildFile := range gradleBuildFiles {
+		if _, err := os.Stat(filepath.Join(path, buildFile)); err == nil {
+			return true, nil
+		} else if !os.IsNotExist(err) {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+func (g *gradleProject) Requirements() FrameworkRequirements {
+	return FrameworkRequirements{
+		// Gradle will automatically restore & build the project if needed
+		Package: FrameworkPackageRequirements{
+			RequireRestore: false,
+			RequireBuild:   false,
+		},
+	}
+}
+
+// Gets the required external tools for the project
+func (g *gradleProject) RequiredExternalTools(_ context.Context, _ *ServiceConfig) []tools.ExternalTool {
+	return []tools.ExternalTool{
+		g.gradleCli,
+		g.javacCli,
+	}
+}
+
+// Initializes the gradle project
+func (g *gradleProject) Initialize(ctx context.Context, serviceConfig *ServiceConfig) error {
+	g.gradleCli.SetPath(serviceConfig.Path(), serviceConfig.Project.Path)
+	return nil
+}
+
+// Restores dependencies using the Gradle CLI
+func (g *gradleProject) Restore(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceRestoreResult, error) {
+	progress.SetProgress(NewServiceProgress("Resolving gradle dependencies"))
+	if err := g.gradleCli.ResolveDependencies(ctx, serviceConfig.Path()); err != nil {
+		return nil, fmt.Errorf("resolving gradle dependencies: %w", err)
+	}
+
+	return &ServiceRestoreResult{}, nil
+}
+
+// Builds the gradle project
+func (g *gradleProject) Build(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	restoreOutput *ServiceRestoreResult,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceBuildResult, error) {
+	progress.SetProgress(NewServiceProgress("Compiling gradle project"))
+	if err := g.gradleCli.Compile(ctx, serviceConfig.Path()); err != nil {
+		return nil, err
+	}
+
+	return &ServiceBuildResult{
+		Restore:         restoreOutput,
+		BuildOutputPath: serviceConfig.Path(),
+	}, nil
+}
+
+func (g *gradleProject) Package(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	buildOutput *ServiceBuildResult,
+	progress *async.Progress[ServiceProgress],
+) (*ServicePackageResult, error) {
+	progress.SetProgress(NewServiceProgress("Packaging gradle project"))
+
+	if serviceConfig.Host == AzureFunctionTarget && serviceConfig.OutputPath == "" {
+		usesPlugin, err := usesGradleFunctionsPlugin(serviceConfig.Path())
+		if err != nil {
+			return nil, err
+		}
+
+		if !usesPlugin {
+			return g.packageFunctionApp(ctx, serviceConfig, buildOutput, progress)
+		}
+	}
+
+	if serviceConfig.Host == AzureFunctionTarget {
+		if err := g.gradleCli.RunTask(ctx, serviceConfig.Path(), "azureFunctionsPackage"); err != nil {
+			return nil, err
+		}
+
+		if serviceConfig.OutputPath != "" {
+			// If the 'dist' property is specified, we use it directly.
+			return &ServicePackageResult{
+				Build:       buildOutput,
+				PackagePath: filepath.Join(serviceConfig.Path(), serviceConfig.OutputPath),
+			}, nil
+		}
+
+		funcAppDir, err := g.funcAppDir(serviceConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ServicePackageResult{
+			Build:       buildOutput,
+			PackagePath: funcAppDir,
+		}, nil
+	}
+
+	if err := g.gradleCli.Package(ctx, serviceConfig.Path()); err != nil {
+		return nil, err
+	}
+
+	packageDest, err := os.MkdirTemp("", "azd")
+	if err != nil {
+		return nil, fmt.Errorf("creating staging directory: %w", err)
+	}
+
+	packageSrcPath := buildOutput.BuildOutputPath
+	if packageSrcPath == "" {
+		packageSrcPath = serviceConfig.Path()
+	}
+
+	if serviceConfig.OutputPath != "" {
+		packageSrcPath = filepath.Join(packageSrcPath, serviceConfig.OutputPath)
+	} else {
+		packageSrcPath = filepath.Join(packageSrcPath, "build", "libs")
+	}
+
+	packageSrcFileInfo, err := os.Stat(packageSrcPath)
+	if err != nil {
+		if serviceConfig.OutputPath == "" {
+			return nil, fmt.Errorf("reading default gradle libs path %s: %w", packageSrcPath, err)
+		} else {
+			return nil, fmt.Errorf("reading dist path %s: %w", packageSrcPath, err)
+		}
+	}
+
+	archive := ""
+	if packageSrcFileInfo.IsDir() {
+		archive, err = discoverArchive(packageSrcPath, isPlainArchive)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		archive = packageSrcPath
+		if !isSupportedJavaArchive(archive) {
+			ext := filepath.Ext(archive)
+			return nil, fmt.Errorf(
+				"file %s with extension %s is not a supported java archive file (.ear, .war, .jar)", ext, archive)
+		}
+	}
+
+	progress.SetProgress(NewServiceProgress("Copying deployment package"))
+	ext := strings.ToLower(filepath.Ext(archive))
+	err = copy.Copy(archive, filepath.Join(packageDest, AppServiceJavaPackageName+ext))
+	if err != nil {
+		return nil, fmt.Errorf("copying to staging directory failed: %w", err)
+	}
+
+	return &ServicePackageResult{
+		Build:       buildOutput,
+		PackagePath: packageDest,
+	}, nil
+}
+
+// isPlainArchive returns true for the archives produced by the 'jar' and 'war' tasks when the Spring Boot gradle
+// plugin is applied. These sit next to the executable boot archive in build/libs and are not deployable on their own.
+func isPlainArchive(name string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(name, filepath.Ext(name)), "-plain")
+}
+
+// gradleFunctionsPluginId is the id of azure-functions-gradle-plugin.
+const gradleFunctionsPluginId = "com.microsoft.azure.azurefunctions"
+
+// usesGradleFunctionsPlugin returns true when the build script of the project applies azure-functions-gradle-plugin.
+func usesGradleFunctionsPlugin(projectPath string) (bool, error) {
+	for _, name := range []string{"build.gradle", "build.gradle.kts"} {
+		contents, err := os.ReadFile(filepath.Join(projectPath, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return false, fmt.Errorf("reading %s: %w", name, err)
+		}
+
+		if strings.Contains(string(contents), gradleFunctionsPluginId) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// packageFunctionApp stages the function app of a project that does not use azure-functions-gradle-plugin, from the
+// archive assembled by gradle and the runtime dependencies of the project.
+func (g *gradleProject) packageFunctionApp(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	buildOutput *ServiceBuildResult,
+	progress *async.Progress[ServiceProgress],
+) (*ServicePackageResult, error) {
+	if err := g.gradleCli.Package(ctx, serviceConfig.Path()); err != nil {
+		return nil, err
+	}
+
+	archive, err := discoverArchive(filepath.Join(serviceConfig.Path(), "build", "libs"), isPlainArchive)
+	if err != nil {
+		return nil, err
+	}
+
+	stagingDir, err := os.MkdirTemp("", "azd")
+	if err != nil {
+		return nil, fmt.Errorf("creating staging directory: %w", err)
+	}
+
+	progress.SetProgress(NewServiceProgress("Copying function app dependencies"))
+	if err := g.gradleCli.CopyDependencies(
+		ctx, serviceConfig.Path(), filepath.Join(stagingDir, javaFunctionsLibDir)); err != nil {
+		return nil, err
+	}
+
+	progress.SetProgress(NewServiceProgress("Generating function app metadata"))
+	if err := stageJavaFunctionApp(serviceConfig.Path(), archive, stagingDir); err != nil {
+		return nil, err
+	}
+
+	return &ServicePackageResult{
+		Build:       buildOutput,
+		PackagePath: stagingDir,
+	}, nil
+}
+
+// funcAppDir returns the directory of the function app packaged by azure-functions-gradle-plugin for the given service.
+//
+// The app is staged under build/azure-functions/<appName>.
+func (g *gradleProject) funcAppDir(svc *ServiceConfig) (string, error) {
+	functionsStagingRel := filepath.Join("build", "azure-functions")
+	functionsStagingDir := filepath.Join(svc.Path(), functionsStagingRel)
+
+	entries, err := os.ReadDir(functionsStagingDir)
+	if err != nil {
+		return "", fmt.Errorf("reading azure-functions directory: %w", err)
+	}
+
+	dirs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+
+	if len(dirs) == 1 {
+		return filepath.Join(functionsStagingDir, dirs[0]), nil
+	}
+
+	for i := range dirs {
+		dirs[i] = filepath.Join(functionsStagingRel, dirs[i])
+	}
+
+	return "", fmt.Errorf(
+		//nolint:lll
+		"expected a single staging directory in %s, found: [%s]. Specify 'dist' in azure.yaml to select a specific directory",
+		functionsStagingRel,
+		strings.Join(dirs, ", "))
+}
@@ -0,0 +1,168 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/gradle"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/javac"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/ostest"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GradleProject(t *testing.T) {
+	ostest.Chdir(t, t.TempDir())
+	require.NoError(t, os.MkdirAll("./src/api", osutil.PermissionDirectory))
+	err := os.WriteFile(filepath.Join(".", "src", "api", getGradlewCmd()), nil, osutil.PermissionExecutableFile)
+	require.NoError(t, err)
+
+	t.Run("Restore", func(t *testing.T) {
+		var runArgs exec.RunArgs
+
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.CommandRunner.
+			When(func(args exec.RunArgs, command string) bool {
+				return strings.Contains(command, fmt.Sprintf("%s dependencies", getGradlewCmd()))
+			}).
+			RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+				runArgs = args
+				return exec.NewRunResult(0, "", ""), nil
+			})
+
+		serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageJava)
+		gradleProject := NewGradleProject(
+			environment.New("test"), gradle.NewCli(mockContext.CommandRunner), javac.NewCli(mockContext.CommandRunner))
+		require.NoError(t, gradleProject.Initialize(*mockContext.Context, serviceConfig))
+
+		result, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceRestoreResult, error) {
+			return gradleProject.Restore(*mockContext.Context, serviceConfig, progress)
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.Contains(t, runArgs.Cmd, getGradlewCmd())
+		require.Equal(t, serviceConfig.Path(), runArgs.Cwd)
+		require.Equal(t, []string{"dependencies", "--console=plain"}, runArgs.Args)
+	})
+
+	t.Run("Build", func(t *testing.T) {
+		var runArgs exec.RunArgs
+
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.CommandRunner.
+			When(func(args exec.RunArgs, command string) bool {
+				return strings.Contains(command, fmt.Sprintf("%s classes", getGradlewCmd()))
+			}).
+			RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+				runArgs = args
+				return exec.NewRunResult(0, "", ""), nil
+			})
+
+		serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageJava)
+		gradleProject := NewGradleProject(
+			environment.New("test"), gradle.NewCli(mockContext.CommandRunner), javac.NewCli(mockContext.CommandRunner))
+		require.NoError(t, gradleProject.Initialize(*mockContext.Context, serviceConfig))
+
+		result, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceBuildResult, error) {
+			return gradleProject.Build(*mockContext.Context, serviceConfig, nil, progress)
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.Equal(t, []string{"classes", "--console=plain"}, runArgs.Args)
+	})
+
+	t.Run("Package", func(t *testing.T) {
+		var runArgs exec.RunArgs
+
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.CommandRunner.
+			When(func(args exec.RunArgs, command string) bool {
+				return strings.Contains(command, fmt.Sprintf("%s assemble", getGradlewCmd()))
+			}).
+			RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+				runArgs = args
+				return exec.NewRunResult(0, "", ""), nil
+			})
+
+		serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageJava)
+
+		// Simulate the outputs of the Spring Boot gradle plugin: a boot jar and a plain jar
+		libsDir := filepath.Join(serviceConfig.Path(), "build", "libs")
+		require.NoError(t, os.MkdirAll(libsDir, osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(filepath.Join(libsDir, "api-0.0.1.jar"), []byte("boot"), osutil.PermissionFile))
+		require.NoError(t,
+			os.WriteFile(filepath.Join(libsDir, "api-0.0.1-plain.jar"), []byte("plain"), osutil.PermissionFile))
+
+		gradleProject := NewGradleProject(
+			environment.New("test"), gradle.NewCli(mockContext.CommandRunner), javac.NewCli(mockContext.CommandRunner))
+		require.NoError(t, gradleProject.Initialize(*mockContext.Context, serviceConfig))
+
+		result, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServicePackageResult, error) {
+			return gradleProject.Package(
+				*mockContext.Context,
+				serviceConfig,
+				&ServiceBuildResult{
+					BuildOutputPath: serviceConfig.Path(),
+				},
+				progress,
+			)
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.Equal(t, []string{"assemble", "--console=plain"}, runArgs.Args)
+
+		contents, err := os.ReadFile(filepath.Join(result.PackagePath, AppServiceJavaPackageName+".jar"))
+		require.NoError(t, err)
+		require.Equal(t, "boot", string(contents))
+	})
+}
+
+func Test_isGradleProject(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  bool
+	}{
+		{name: "Groovy", files: []string{"build.gradle"}, want: true},
+		{name: "Kotlin", files: []string{"build.gradle.kts"}, want: true},
+		{name: "SettingsOnly", files: []string{"settings.gradle"}, want: true},
+		{name: "MavenPrecedence", files: []string{"pom.xml", "build.gradle"}, want: false},
+		{name: "None", files: []string{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, file := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, file), nil, osutil.PermissionFile))
+			}
+
+			actual, err := isGradleProject(dir)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, actual)
+		})
+	}
+}
+
+func getGradlewCmd() string {
+	if runtime.GOOS == "windows" {
+		return "gradlew.bat"
+	} else {
+		return "gradlew"
+	}
+}
@@ -5,6 +5,7 @@ package project
 
 import (
 	"context"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"os"
@@ -68,7 +69,17 @@ func (m *mavenProject) Restore(
 	progress *async.Progress[ServiceProgress],
 ) (*ServiceRestoreResult, error) {
 	progress.SetProgress(NewServiceProgress("Resolving maven dependencies"))
-	if err := m.mavenCli.ResolveDependencies(ctx, serviceConfig.Path()); err != nil {
+	reactorPath, module, err := findMavenReactor(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if reactorPath != "" {
+		err = m.mavenCli.ResolveModuleDependencies(ctx, reactorPath, module)
+	} else {
+		err = m.mavenCli.ResolveDependencies(ctx, serviceConfig.Path())
+	}
+	if err != nil {
 		return nil, fmt.Errorf("resolving maven dependencies: %w", err)
 	}
 
@@ -83,7 +94,17 @@ func (m *mavenProject) Build(
 	progress *async.Progress[ServiceProgress],
 ) (*ServiceBuildResult, error) {
 	progress.SetProgress(NewServiceProgress("Compiling maven project"))
-	if err := m.mavenCli.Compile(ctx, serviceConfig.Path()); err != nil {
+	reactorPath, module, err := findMavenReactor(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if reactorPath != "" {
+		err = m.mavenCli.CompileModule(ctx, reactorPath, module)
+	} else {
+		err = m.mavenCli.Compile(ctx, serviceConfig.Path())
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -100,7 +121,31 @@ func (m *mavenProject) Package(
 	progress *async.Progress[ServiceProgress],
 ) (*ServicePackageResult, error) {
 	progress.SetProgress(NewServiceProgress("Packaging maven project"))
-	if err := m.mavenCli.Package(ctx, serviceConfig.Path()); err != nil {
+	reactorPath, module, err := findMavenReactor(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if serviceConfig.Host == AzureFunctionTarget && serviceConfig.OutputPath == "" {
+		usesPlugin, err := m.usesFunctionsPlugin(ctx, serviceConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		// The function app of projects that don't use azure-functions-maven-plugin is staged by azd
+		if !usesPlugin {
+			return m.packageFunctionApp(ctx, serviceConfig, reactorPath, module, buildOutput, progress)
+		}
+	}
+
+	// A module of a multi-module project is built from the reactor root, so that the sibling modules it depends on
+	// are built with it instead of being resolved from a repository.
+	if reactorPath != "" {
+		err = m.mavenCli.PackageModule(ctx, reactorPath, module)
+	} else {
+		err = m.mavenCli.Package(ctx, serviceConfig.Path())
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -151,7 +196,7 @@ func (m *mavenProject) Package(
 
 	archive := ""
 	if packageSrcFileInfo.IsDir() {
-		archive, err = m.discoverArchive(packageSrcPath)
+		archive, err = discoverArchive(packageSrcPath, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -177,6 +222,83 @@ func (m *mavenProject) Package(
 	}, nil
 }
 
+// mavenFunctionsPlugin is the group and artifact id of azure-functions-maven-plugin.
+const mavenFunctionsPlugin = "com.microsoft.azure:azure-functions-maven-plugin"
+
+// pomBuildPlugins is the part of a POM that lists the build plugins of a project.
+type pomBuildPlugins struct {
+	Plugins []struct {
+		GroupId    string `xml:"groupId"`
+		ArtifactId string `xml:"artifactId"`
+	} `xml:"build>plugins>plugin"`
+}
+
+// usesFunctionsPlugin returns true when the effective POM of the project uses azure-functions-maven-plugin, including
+// when the plugin is inherited from a parent POM.
+func (m *mavenProject) usesFunctionsPlugin(ctx context.Context, serviceConfig *ServiceConfig) (bool, error) {
+	effectivePom, err := m.mavenCli.EffectivePom(ctx, filepath.Join(serviceConfig.Path(), "pom.xml"))
+	if err != nil {
+		return false, err
+	}
+
+	var pom pomBuildPlugins
+	if err := xml.Unmarshal([]byte(effectivePom), &pom); err != nil {
+		return false, fmt.Errorf("parsing effective pom: %w", err)
+	}
+
+	for _, plugin := range pom.Plugins {
+		if plugin.GroupId+":"+plugin.ArtifactId == mavenFunctionsPlugin {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// packageFunctionApp packages a project that does not use azure-functions-maven-plugin, and stages its function app from
+// the archive packaged by maven and the runtime dependencies of the project.
+func (m *mavenProject) packageFunctionApp(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	reactorPath string,
+	module string,
+	buildOutput *ServiceBuildResult,
+	progress *async.Progress[ServiceProgress],
+) (*ServicePackageResult, error) {
+	stagingDir, err := os.MkdirTemp("", "azd")
+	if err != nil {
+		return nil, fmt.Errorf("creating staging directory: %w", err)
+	}
+
+	// The dependencies of a module are copied by the build that packages it, so that sibling modules are resolved from
+	// the reactor.
+	libDir := filepath.Join(stagingDir, javaFunctionsLibDir)
+	if reactorPath != "" {
+		err = m.mavenCli.PackageModuleWithDependencies(ctx, reactorPath, module, libDir)
+	} else if err = m.mavenCli.Package(ctx, serviceConfig.Path()); err == nil {
+		progress.SetProgress(NewServiceProgress("Copying function app dependencies"))
+		err = m.mavenCli.CopyDependencies(ctx, serviceConfig.Path(), libDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := discoverArchive(filepath.Join(serviceConfig.Path(), "target"), isOriginalArchive)
+	if err != nil {
+		return nil, err
+	}
+
+	progress.SetProgress(NewServiceProgress("Generating function app metadata"))
+	if err := stageJavaFunctionApp(serviceConfig.Path(), archive, stagingDir); err != nil {
+		return nil, err
+	}
+
+	return &ServicePackageResult{
+		Build:       buildOutput,
+		PackagePath: stagingDir,
+	}, nil
+}
+
 // funcAppDir returns the directory of the function app packaged by azure-functions-maven-plugin for the given service.
 //
 // The app is typically packaged under target/azure-functions.
@@ -229,12 +351,99 @@ func (m *mavenProject) funcAppDir(ctx context.Context, svc *ServiceConfig) (stri
 		strings.Join(dirs, ", "))
 }
 
+// pomModules is the part of a POM that lists the modules of a multi-module project.
+type pomModules struct {
+	Modules []string `xml:"modules>module"`
+}
+
+// findMavenReactor finds the root of the multi-module project that the service is a module of. The parent directories
+// of the service are searched, up to the project directory, for POMs that declare the service as a module. The
+// outermost such POM is the reactor root.
+//
+// The reactor root directory and the path of the service module relative to it are returned. Empty strings are
+// returned when the service is not a module of a multi-module project.
+func findMavenReactor(svc *ServiceConfig) (string, string, error) {
+	svcPath, err := filepath.Abs(svc.Path())
+	if err != nil {
+		return "", "", err
+	}
+
+	projectPath, err := filepath.Abs(svc.Project.Path)
+	if err != nil {
+		return "", "", err
+	}
+
+	reactorPath := ""
+	modulePath := svcPath
+	for dir := filepath.Dir(svcPath); ; dir = filepath.Dir(dir) {
+		rel, err := filepath.Rel(projectPath, dir)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			break
+		}
+
+		isModule, err := declaresModule(dir, modulePath)
+		if err != nil {
+			return "", "", err
+		}
+
+		if isModule {
+			reactorPath = dir
+			modulePath = dir
+		}
+
+		if dir == projectPath || filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	if reactorPath == "" {
+		return "", "", nil
+	}
+
+	module, err := filepath.Rel(reactorPath, svcPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	return reactorPath, filepath.ToSlash(module), nil
+}
+
+// declaresModule returns true when the pom.xml in dir declares moduleDir as one of its modules.
+func declaresModule(dir string, moduleDir string) (bool, error) {
+	contents, err := os.ReadFile(filepath.Join(dir, "pom.xml"))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("reading pom.xml: %w", err)
+	}
+
+	var pom pomModules
+	if err := xml.Unmarshal(contents, &pom); err != nil {
+		return false, fmt.Errorf("parsing %s: %w", filepath.Join(dir, "pom.xml"), err)
+	}
+
+	for _, module := range pom.Modules {
+		modulePath := filepath.Join(dir, filepath.FromSlash(strings.TrimSpace(module)))
+		if strings.EqualFold(filepath.Base(modulePath), "pom.xml") {
+			modulePath = filepath.Dir(modulePath)
+		}
+
+		if modulePath == moduleDir {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func isSupportedJavaArchive(archiveFile string) bool {
 	ext := strings.ToLower(filepath.Ext(archiveFile))
 	return ext == ".jar" || ext == ".war" || ext == ".ear"
 }
 
-func (m *mavenProject) discoverArchive(dir string) (string, error) {
+// discoverArchive finds the single java archive (.jar, .war, .ear) in dir.
+// Archives for which exclude returns true are not considered.
+func discoverArchive(dir string, exclude func(name string) bool) (string, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return "", fmt.Errorf("discovering java archive files in %s: %w", dir, err)
@@ -247,6 +456,10 @@ func (m *mavenProject) discoverArchive(dir string) (string, error) {
 		}
 
 		name := entry.Name()
+		if exclude != nil && exclude(name) {
+			continue
+		}
+
 		if isSupportedJavaArchive(name) {
 			archiveFiles = append(archiveFiles, name)
 		}
@@ -339,6 +339,32 @@ func Test_MavenProject_FuncApp_Package(t *testing.T) {
 			return exec.NewRunResult(0, "", ""), nil
 		})
 
+	// usesFunctionsPlugin is whether the effective pom uses azure-functions-maven-plugin.
+	usesFunctionsPlugin := true
+	mockContext.CommandRunner.
+		When(func(args exec.RunArgs, command string) bool {
+			return strings.Contains(command, getMvnwCmd()+" help:effective-pom")
+		}).
+		RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			plugin := "maven-jar-plugin"
+			if usesFunctionsPlugin {
+				plugin = "azure-functions-maven-plugin"
+			}
+
+			return exec.NewRunResult(0, fmt.Sprintf(`[INFO] Effective POMs
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <build>
+    <plugins>
+      <plugin>
+        <groupId>com.microsoft.azure</groupId>
+        <artifactId>%s</artifactId>
+      </plugin>
+    </plugins>
+  </build>
+</project>
+[INFO] BUILD SUCCESS`, plugin), ""), nil
+		})
+
 	// mvnFuncAppNameProperty is the value of the maven property that holds the function app name.
 	mvnFuncAppNameProperty := ""
 	mockContext.CommandRunner.
@@ -459,6 +485,139 @@ func Test_MavenProject_FuncApp_Package(t *testing.T) {
 		require.NotNil(t, result)
 		require.Equal(t, result.PackagePath, filepath.Join(svc.Path(), svc.OutputPath))
 	})
+
+	t.Run("stages function app without azure-functions-maven-plugin", func(t *testing.T) {
+		usesFunctionsPlugin = false
+		var svc ServiceConfig = *serviceConfig
+
+		err = os.RemoveAll(filepath.Join(svc.Path(), "target"))
+		require.NoError(t, err)
+		// the staging directory of an older build with azure-functions-maven-plugin is not deployed
+		err = os.MkdirAll(filepath.Join(svc.Path(), "target", "azure-functions", "stale"), osutil.PermissionDirectory)
+		require.NoError(t, err)
+
+		writeTestJar(t, filepath.Join(svc.Path(), "target", "api-1.0.jar"), map[string][]byte{
+			"com/example/Function.class": buildTestClass("com/example/Function", []testMethod{
+				{
+					Name: "run",
+					Annotations: []testAnnotation{
+						functionsAnnotation("FunctionName", testElement{"value", 's', "run"}),
+					},
+					Parameters: [][]testAnnotation{
+						{functionsAnnotation("HttpTrigger", testElement{"name", 's', "req"})},
+					},
+				},
+			}),
+		})
+
+		mockContext.CommandRunner.
+			When(func(args exec.RunArgs, command string) bool {
+				return strings.Contains(command, getMvnwCmd()+" dependency:copy-dependencies")
+			}).
+			RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+				outputDir := strings.TrimPrefix(args.Args[2], "-DoutputDirectory=")
+				if err := os.MkdirAll(outputDir, osutil.PermissionDirectory); err != nil {
+					return exec.NewRunResult(1, "", ""), err
+				}
+
+				err := os.WriteFile(filepath.Join(outputDir, "gson-2.10.jar"), nil, osutil.PermissionFile)
+				return exec.NewRunResult(0, "", ""), err
+			})
+
+		result, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServicePackageResult, error) {
+			return mavenProject.Package(
+				*mockContext.Context,
+				&svc,
+				&ServiceBuildResult{
+					BuildOutputPath: svc.Path(),
+				},
+				progress,
+			)
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		t.Cleanup(func() { _ = os.RemoveAll(result.PackagePath) })
+
+		require.FileExists(t, filepath.Join(result.PackagePath, "api-1.0.jar"))
+		require.FileExists(t, filepath.Join(result.PackagePath, "host.json"))
+		require.FileExists(t, filepath.Join(result.PackagePath, "run", "function.json"))
+		require.FileExists(t, filepath.Join(result.PackagePath, "lib", "gson-2.10.jar"))
+	})
+}
+
+func Test_MavenProject_MultiModule(t *testing.T) {
+	temp := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(temp, getMvnwCmd()), nil, osutil.PermissionExecutableFile))
+	require.NoError(t, os.WriteFile(filepath.Join(temp, "pom.xml"), []byte(`
+		<project>
+			<modelVersion>4.0.0</modelVersion>
+			<groupId>com.example</groupId>
+			<artifactId>parent</artifactId>
+			<version>0.0.1</version>
+			<packaging>pom</packaging>
+			<modules>
+				<module>library</module>
+				<module>services</module>
+			</modules>
+		</project>`), osutil.PermissionFile))
+
+	servicesDir := filepath.Join(temp, "services")
+	require.NoError(t, os.MkdirAll(servicesDir, osutil.PermissionDirectory))
+	require.NoError(t, os.WriteFile(filepath.Join(servicesDir, "pom.xml"), []byte(`
+		<project>
+			<modelVersion>4.0.0</modelVersion>
+			<artifactId>services</artifactId>
+			<packaging>pom</packaging>
+			<modules>
+				<module>api</module>
+			</modules>
+		</project>`), osutil.PermissionFile))
+
+	svc := &ServiceConfig{
+		Project:         &ProjectConfig{Path: temp},
+		Name:            "api",
+		RelativePath:    filepath.Join("services", "api"),
+		Host:            AppServiceTarget,
+		Language:        ServiceLanguageJava,
+		EventDispatcher: ext.NewEventDispatcher[ServiceLifecycleEventArgs](),
+	}
+	targetDir := filepath.Join(svc.Path(), "target")
+	require.NoError(t, os.MkdirAll(targetDir, osutil.PermissionDirectory))
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "api.jar"), []byte("test"), osutil.PermissionFile))
+
+	var runArgs exec.RunArgs
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.
+		When(func(args exec.RunArgs, command string) bool {
+			return strings.Contains(command, getMvnwCmd())
+		}).
+		RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			runArgs = args
+			return exec.NewRunResult(0, "", ""), nil
+		})
+
+	mavenProject := NewMavenProject(
+		environment.New("test"), maven.NewCli(mockContext.CommandRunner), javac.NewCli(mockContext.CommandRunner))
+	require.NoError(t, mavenProject.Initialize(*mockContext.Context, svc))
+
+	restoreResult, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceRestoreResult, error) {
+		return mavenProject.Restore(*mockContext.Context, svc, progress)
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, restoreResult)
+	require.Equal(t, temp, runArgs.Cwd)
+	require.Equal(t, []string{"dependency:resolve", "-pl", "services/api", "-am"}, runArgs.Args)
+
+	result, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServicePackageResult, error) {
+		return mavenProject.Package(*mockContext.Context, svc, &ServiceBuildResult{}, progress)
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, temp, runArgs.Cwd)
+	require.Equal(t, []string{"package", "-DskipTests", "-pl", "services/api", "-am"}, runArgs.Args)
 }
 
 func getMvnwCmd() string {
@@ -0,0 +1,419 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// javaClass is the subset of a compiled Java class that is needed to discover the functions of a function app: the
+// name of the class and the annotations of its methods and their parameters.
+type javaClass struct {
+	// The fully qualified name of the class, for example 'com.example.Function'
+	Name    string
+	Methods []javaMethod
+}
+
+type javaMethod struct {
+	Name        string
+	Annotations []javaAnnotation
+	// The annotations of each parameter of the method
+	ParameterAnnotations [][]javaAnnotation
+}
+
+// javaAnnotation is an annotation with retention RUNTIME. Element values are strings (for strings, enum constants and
+// classes), int64, float64, bool, nested annotations or slices of values.
+type javaAnnotation struct {
+	// The fully qualified name of the annotation type, for example 'com.microsoft.azure.functions.annotation.FunctionName'
+	Type     string
+	Elements map[string]any
+}
+
+// SimpleName returns the name of the annotation type without its package.
+func (a javaAnnotation) SimpleName() string {
+	return a.Type[strings.LastIndex(a.Type, ".")+1:]
+}
+
+const javaClassMagic = 0xCAFEBABE
+
+// Constant pool tags of the class file format
+const (
+	constantUtf8               = 1
+	constantInteger            = 3
+	constantFloat              = 4
+	constantLong               = 5
+	constantDouble             = 6
+	constantClass              = 7
+	constantString             = 8
+	constantFieldref           = 9
+	constantMethodref          = 10
+	constantInterfaceMethodref = 11
+	constantNameAndType        = 12
+	constantMethodHandle       = 15
+	constantMethodType         = 16
+	constantDynamic            = 17
+	constantInvokeDynamic      = 18
+	constantModule             = 19
+	constantPackage            = 20
+)
+
+type constantPoolEntry struct {
+	tag   byte
+	utf8  string
+	index uint16
+	value any
+}
+
+type classFileReader struct {
+	r    io.Reader
+	pool []constantPoolEntry
+	err  error
+}
+
+// parseJavaClass parses the contents of a .class file.
+func parseJavaClass(contents []byte) (*javaClass, error) {
+	cr := &classFileReader{r: bytes.NewReader(contents)}
+
+	if cr.u4() != javaClassMagic {
+		return nil, errors.New("not a java class file")
+	}
+
+	// minor and major versions
+	cr.u2()
+	cr.u2()
+
+	if err := cr.readConstantPool(); err != nil {
+		return nil, err
+	}
+
+	// access flags
+	cr.u2()
+	thisClass := cr.u2()
+	// super class
+	cr.u2()
+
+	interfacesCount := cr.u2()
+	for i := 0; i < int(interfacesCount); i++ {
+		cr.u2()
+	}
+
+	className, err := cr.className(thisClass)
+	if err != nil {
+		return nil, err
+	}
+
+	class := &javaClass{
+		Name: className,
+	}
+
+	// Fields are skipped, only their attributes need to be read
+	fieldsCount := cr.u2()
+	for i := 0; i < int(fieldsCount); i++ {
+		if _, err := cr.readMember(); err != nil {
+			return nil, err
+		}
+	}
+
+	methodsCount := cr.u2()
+	for i := 0; i < int(methodsCount); i++ {
+		method, err := cr.readMember()
+		if err != nil {
+			return nil, err
+		}
+
+		class.Methods = append(class.Methods, *method)
+	}
+
+	if cr.err != nil {
+		return nil, fmt.Errorf("reading class file: %w", cr.err)
+	}
+
+	return class, nil
+}
+
+func (cr *classFileReader) read(n int) []byte {
+	if cr.err != nil {
+		return make([]byte, n)
+	}
+
+	buf := make([]byte, n)
+	_, cr.err = io.ReadFull(cr.r, buf)
+	return buf
+}
+
+func (cr *classFileReader) u1() byte {
+	return cr.read(1)[0]
+}
+
+func (cr *classFileReader) u2() uint16 {
+	return binary.BigEndian.Uint16(cr.read(2))
+}
+
+func (cr *classFileReader) u4() uint32 {
+	return binary.BigEndian.Uint32(cr.read(4))
+}
+
+func (cr *classFileReader) readConstantPool() error {
+	count := cr.u2()
+	cr.pool = make([]constantPoolEntry, count)
+
+	for i := 1; i < int(count); i++ {
+		tag := cr.u1()
+		entry := constantPoolEntry{tag: tag}
+
+		switch tag {
+		case constantUtf8:
+			// Class files use a modified UTF-8 encoding, which only differs from UTF-8 for the null character and
+			// supplementary characters. Neither is expected in the names and values used by function annotations.
+			entry.utf8 = string(cr.read(int(cr.u2())))
+		case constantInteger:
+			entry.value = int64(int32(cr.u4()))
+		case constantFloat:
+			entry.value = float64(math.Float32frombits(cr.u4()))
+		case constantLong:
+			entry.value = int64(uint64(cr.u4())<<32 | uint64(cr.u4()))
+		case constantDouble:
+			entry.value = math.Float64frombits(uint64(cr.u4())<<32 | uint64(cr.u4()))
+		case constantClass, constantString, constantMethodType, constantModule, constantPackage:
+			entry.index = cr.u2()
+		case constantFieldref, constantMethodref, constantInterfaceMethodref, constantNameAndType,
+			constantDynamic, constantInvokeDynamic:
+			cr.u2()
+			cr.u2()
+		case constantMethodHandle:
+			cr.u1()
+			cr.u2()
+		default:
+			if cr.err != nil {
+				return fmt.Errorf("reading constant pool: %w", cr.err)
+			}
+
+			return fmt.Errorf("unsupported constant pool tag %d", tag)
+		}
+
+		cr.pool[i] = entry
+
+		// Long and double constants take two entries of the pool
+		if tag == constantLong || tag == constantDouble {
+			i++
+		}
+	}
+
+	if cr.err != nil {
+		return fmt.Errorf("reading constant pool: %w", cr.err)
+	}
+
+	return nil
+}
+
+func (cr *classFileReader) constant(index uint16) (constantPoolEntry, error) {
+	if index == 0 || int(index) >= len(cr.pool) {
+		return constantPoolEntry{}, fmt.Errorf("invalid constant pool index %d", index)
+	}
+
+	return cr.pool[index], nil
+}
+
+func (cr *classFileReader) utf8(index uint16) (string, error) {
+	entry, err := cr.constant(index)
+	if err != nil {
+		return "", err
+	}
+
+	if entry.tag != constantUtf8 {
+		return "", fmt.Errorf("constant pool entry %d is not a string", index)
+	}
+
+	return entry.utf8, nil
+}
+
+func (cr *classFileReader) className(index uint16) (string, error) {
+	entry, err := cr.constant(index)
+	if err != nil {
+		return "", err
+	}
+
+	if entry.tag != constantClass {
+		return "", fmt.Errorf("constant pool entry %d is not a class", index)
+	}
+
+	name, err := cr.utf8(entry.index)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ReplaceAll(name, "/", "."), nil
+}
+
+// readMember reads a field or a method, with the annotations of its attributes.
+func (cr *classFileReader) readMember() (*javaMethod, error) {
+	// access flags
+	cr.u2()
+	nameIndex := cr.u2()
+	// descriptor
+	cr.u2()
+
+	if cr.err != nil {
+		return nil, fmt.Errorf("reading class file: %w", cr.err)
+	}
+
+	name, err := cr.utf8(nameIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	member := &javaMethod{
+		Name: name,
+	}
+
+	attributesCount := cr.u2()
+	for i := 0; i < int(attributesCount); i++ {
+		attributeName, err := cr.utf8(cr.u2())
+		if err != nil {
+			return nil, err
+		}
+
+		contents := cr.read(int(cr.u4()))
+		if cr.err != nil {
+			return nil, fmt.Errorf("reading class file: %w", cr.err)
+		}
+
+		attribute := &classFileReader{r: bytes.NewReader(contents), pool: cr.pool}
+		switch attributeName {
+		case "RuntimeVisibleAnnotations":
+			member.Annotations, err = attribute.readAnnotations()
+		case "RuntimeVisibleParameterAnnotations":
+			count := attribute.u1()
+			member.ParameterAnnotations = make([][]javaAnnotation, count)
+			for p := 0; p < int(count) && err == nil; p++ {
+				member.ParameterAnnotations[p], err = attribute.readAnnotations()
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("reading annotations of '%s': %w", name, err)
+		}
+
+		if attribute.err != nil {
+			return nil, fmt.Errorf("reading annotations of '%s': %w", name, attribute.err)
+		}
+	}
+
+	return member, nil
+}
+
+func (cr *classFileReader) readAnnotations() ([]javaAnnotation, error) {
+	count := cr.u2()
+	annotations := make([]javaAnnotation, 0, count)
+	for i := 0; i < int(count); i++ {
+		annotation, err := cr.readAnnotation()
+		if err != nil {
+			return nil, err
+		}
+
+		annotations = append(annotations, annotation)
+	}
+
+	return annotations, nil
+}
+
+func (cr *classFileReader) readAnnotation() (javaAnnotation, error) {
+	descriptor, err := cr.utf8(cr.u2())
+	if err != nil {
+		return javaAnnotation{}, err
+	}
+
+	annotation := javaAnnotation{
+		Type:     typeNameFromDescriptor(descriptor),
+		Elements: map[string]any{},
+	}
+
+	count := cr.u2()
+	for i := 0; i < int(count); i++ {
+		name, err := cr.utf8(cr.u2())
+		if err != nil {
+			return javaAnnotation{}, err
+		}
+
+		value, err := cr.readElementValue()
+		if err != nil {
+			return javaAnnotation{}, err
+		}
+
+		annotation.Elements[name] = value
+	}
+
+	return annotation, nil
+}
+
+func (cr *classFileReader) readElementValue() (any, error) {
+	tag := cr.u1()
+	if cr.err != nil {
+		return nil, cr.err
+	}
+
+	switch tag {
+	case 'B', 'C', 'I', 'S', 'J', 'F', 'D':
+		entry, err := cr.constant(cr.u2())
+		if err != nil {
+			return nil, err
+		}
+
+		return entry.value, nil
+	case 'Z':
+		entry, err := cr.constant(cr.u2())
+		if err != nil {
+			return nil, err
+		}
+
+		value, _ := entry.value.(int64)
+		return value != 0, nil
+	case 's':
+		return cr.utf8(cr.u2())
+	case 'e':
+		// The type of the enum is not needed, only the name of the constant
+		cr.u2()
+		return cr.utf8(cr.u2())
+	case 'c':
+		descriptor, err := cr.utf8(cr.u2())
+		if err != nil {
+			return nil, err
+		}
+
+		return typeNameFromDescriptor(descriptor), nil
+	case '@':
+		return cr.readAnnotation()
+	case '[':
+		count := cr.u2()
+		values := make([]any, 0, count)
+		for i := 0; i < int(count); i++ {
+			value, err := cr.readElementValue()
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, value)
+		}
+
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unsupported annotation element tag '%c'", tag)
+	}
+}
+
+// typeNameFromDescriptor returns the fully qualified name of a type from its descriptor, for example
+// 'com.example.Type' for 'Lcom/example/Type;'.
+func typeNameFromDescriptor(descriptor string) string {
+	if strings.HasPrefix(descriptor, "L") && strings.HasSuffix(descriptor, ";") {
+		descriptor = descriptor[1 : len(descriptor)-1]
+	}
+
+	return strings.ReplaceAll(descriptor, "/", ".")
+}
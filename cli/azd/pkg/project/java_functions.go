@@ -0,0 +1,435 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/otiai10/copy"
+)
+
+// The package of the annotations of azure-functions-java-library
+const javaFunctionsAnnotationPackage = "com.microsoft.azure.functions.annotation."
+
+// javaFunctionsLibraryArtifactId is the artifact of azure-functions-java-library, which is provided by the Java worker
+// of the Functions host and is not deployed with the function app.
+const javaFunctionsLibraryArtifactId = "azure-functions-java-library"
+
+// javaFunctionsLibDir is the directory of the dependencies of a Java function app.
+const javaFunctionsLibDir = "lib"
+
+// defaultJavaFunctionsHostJson is the host.json of Java function apps that don't provide one.
+const defaultJavaFunctionsHostJson = `{
+  "version": "2.0",
+  "extensionBundle": {
+    "id": "Microsoft.Azure.Functions.ExtensionBundle",
+    "version": "[4.*, 5.0.0)"
+  }
+}
+`
+
+var functionNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_\-]{0,127}$`)
+
+// javaBinding is the function.json binding type and direction of a binding annotation.
+type javaBinding struct {
+	Type      string
+	Direction string
+}
+
+// javaBindings are the binding annotations of azure-functions-java-library, by simple name.
+var javaBindings = map[string]javaBinding{
+	"HttpTrigger":            {"httpTrigger", "in"},
+	"HttpOutput":             {"http", "out"},
+	"TimerTrigger":           {"timerTrigger", "in"},
+	"QueueTrigger":           {"queueTrigger", "in"},
+	"QueueOutput":            {"queue", "out"},
+	"BlobTrigger":            {"blobTrigger", "in"},
+	"BlobInput":              {"blob", "in"},
+	"BlobOutput":             {"blob", "out"},
+	"TableInput":             {"table", "in"},
+	"TableOutput":            {"table", "out"},
+	"EventHubTrigger":        {"eventHubTrigger", "in"},
+	"EventHubOutput":         {"eventHub", "out"},
+	"EventGridTrigger":       {"eventGridTrigger", "in"},
+	"EventGridOutput":        {"eventGrid", "out"},
+	"ServiceBusQueueTrigger": {"serviceBusTrigger", "in"},
+	"ServiceBusTopicTrigger": {"serviceBusTrigger", "in"},
+	"ServiceBusQueueOutput":  {"serviceBus", "out"},
+	"ServiceBusTopicOutput":  {"serviceBus", "out"},
+	"CosmosDBTrigger":        {"cosmosDBTrigger", "in"},
+	"CosmosDBInput":          {"cosmosDB", "in"},
+	"CosmosDBOutput":         {"cosmosDB", "out"},
+	"SendGridOutput":         {"sendGrid", "out"},
+	"TwilioSmsOutput":        {"twilioSms", "out"},
+}
+
+// storageBindingTypes are the binding types to which the connection of @StorageAccount applies.
+var storageBindingTypes = []string{"queueTrigger", "queue", "blobTrigger", "blob", "table"}
+
+// javaFunction is the function.json of a function of a Java function app.
+type javaFunction struct {
+	ScriptFile string           `json:"scriptFile"`
+	EntryPoint string           `json:"entryPoint"`
+	Bindings   []map[string]any `json:"bindings"`
+}
+
+// stageJavaFunctionApp lays out the function app of the archive in stagingDir, the way azure-functions-maven-plugin
+// does: the archive and the host.json of the project at the root, a function.json for each method annotated with
+// @FunctionName, and the dependencies in the lib directory, which are expected to be copied by the caller.
+func stageJavaFunctionApp(projectPath string, archive string, stagingDir string) error {
+	functions, err := javaFunctions(archive)
+	if err != nil {
+		return err
+	}
+
+	// The names of the functions are the names of their directories, so they are validated before anything is written
+	var errs []error
+	for name := range functions {
+		if !functionNameRegex.MatchString(name) {
+			errs = append(errs, fmt.Errorf("function name '%s' is not valid", name))
+		}
+	}
+	if len(errs) > 0 {
+		slices.SortFunc(errs, func(a, b error) int { return strings.Compare(a.Error(), b.Error()) })
+		return fmt.Errorf("invalid function app: %w", errors.Join(errs...))
+	}
+
+	archiveName := filepath.Base(archive)
+	if err := copy.Copy(archive, filepath.Join(stagingDir, archiveName)); err != nil {
+		return fmt.Errorf("copying %s to staging directory: %w", archiveName, err)
+	}
+
+	// The annotations library is provided by the Java worker of the Functions host
+	if err := removeJavaFunctionsLibrary(filepath.Join(stagingDir, javaFunctionsLibDir)); err != nil {
+		return err
+	}
+
+	hostJson := []byte(defaultJavaFunctionsHostJson)
+	if contents, err := os.ReadFile(filepath.Join(projectPath, "host.json")); err == nil {
+		hostJson = contents
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("reading host.json: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(stagingDir, "host.json"), hostJson, osutil.PermissionFile); err != nil {
+		return fmt.Errorf("writing host.json: %w", err)
+	}
+
+	for name, function := range functions {
+		if err := os.MkdirAll(filepath.Join(stagingDir, name), osutil.PermissionDirectory); err != nil {
+			return fmt.Errorf("creating directory of function '%s': %w", name, err)
+		}
+
+		contents, err := json.MarshalIndent(function, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshalling function.json of function '%s': %w", name, err)
+		}
+
+		if err := os.WriteFile(
+			filepath.Join(stagingDir, name, "function.json"), contents, osutil.PermissionFile); err != nil {
+			return fmt.Errorf("writing function.json of function '%s': %w", name, err)
+		}
+	}
+
+	return validateJavaFunctionApp(stagingDir)
+}
+
+// javaFunctions returns the functions of the classes of the archive, by function name.
+func javaFunctions(archive string) (map[string]*javaFunction, error) {
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", archive, err)
+	}
+	defer reader.Close()
+
+	functions := map[string]*javaFunction{}
+	for _, file := range reader.File {
+		if !strings.HasSuffix(file.Name, ".class") || file.FileInfo().IsDir() {
+			continue
+		}
+
+		contents, err := readZipFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s in %s: %w", file.Name, archive, err)
+		}
+
+		// Classes that can't be parsed, like classes of newer class file versions or of shaded dependencies, are
+		// skipped. The function app is only rejected when no functions are found.
+		class, err := parseJavaClass(contents)
+		if err != nil {
+			log.Printf("skipping %s in %s: %v", file.Name, archive, err)
+			continue
+		}
+
+		for _, method := range class.Methods {
+			name, function := newJavaFunction(class, method, "../"+filepath.Base(archive))
+			if function == nil {
+				continue
+			}
+
+			if _, has := functions[name]; has {
+				return nil, fmt.Errorf("function '%s' is defined more than once", name)
+			}
+
+			functions[name] = function
+		}
+	}
+
+	return functions, nil
+}
+
+// newJavaFunction returns the function of a method annotated with @FunctionName, or nil when the method is not a
+// function.
+func newJavaFunction(class *javaClass, method javaMethod, scriptFile string) (string, *javaFunction) {
+	var name string
+	var storageConnection string
+	var returnBindings []map[string]any
+
+	for _, annotation := range method.Annotations {
+		switch {
+		case annotation.Type == javaFunctionsAnnotationPackage+"FunctionName":
+			name, _ = annotation.Elements["value"].(string)
+		case annotation.Type == javaFunctionsAnnotationPackage+"StorageAccount":
+			storageConnection, _ = annotation.Elements["value"].(string)
+		default:
+			// Output bindings of the return value of the function
+			if binding := newJavaBinding(annotation); binding != nil {
+				if _, has := binding["name"]; !has {
+					binding["name"] = "$return"
+				}
+
+				returnBindings = append(returnBindings, binding)
+			}
+		}
+	}
+
+	if name == "" {
+		return "", nil
+	}
+
+	function := &javaFunction{
+		ScriptFile: scriptFile,
+		EntryPoint: class.Name + "." + method.Name,
+		Bindings:   []map[string]any{},
+	}
+
+	for _, annotations := range method.ParameterAnnotations {
+		for _, annotation := range annotations {
+			if binding := newJavaBinding(annotation); binding != nil {
+				function.Bindings = append(function.Bindings, binding)
+			}
+		}
+	}
+
+	function.Bindings = append(function.Bindings, returnBindings...)
+
+	hasHttpTrigger := slices.ContainsFunc(function.Bindings, func(binding map[string]any) bool {
+		return binding["type"] == "httpTrigger"
+	})
+	hasHttpOutput := slices.ContainsFunc(function.Bindings, func(binding map[string]any) bool {
+		return binding["type"] == "http"
+	})
+
+	// HTTP triggered functions respond with their return value by default
+	if hasHttpTrigger && !hasHttpOutput {
+		function.Bindings = append(function.Bindings, map[string]any{
+			"type":      "http",
+			"direction": "out",
+			"name":      "$return",
+		})
+	}
+
+	if storageConnection != "" {
+		for _, binding := range function.Bindings {
+			bindingType, _ := binding["type"].(string)
+			if _, has := binding["connection"]; !has && slices.Contains(storageBindingTypes, bindingType) {
+				binding["connection"] = storageConnection
+			}
+		}
+	}
+
+	return name, function
+}
+
+// newJavaBinding returns the function.json binding of a binding annotation, or nil when the annotation is not a
+// binding. The elements of the annotation are the properties of the binding.
+func newJavaBinding(annotation javaAnnotation) map[string]any {
+	if !strings.HasPrefix(annotation.Type, javaFunctionsAnnotationPackage) {
+		return nil
+	}
+
+	binding := map[string]any{}
+	if annotation.SimpleName() == "CustomBinding" {
+		// The type and direction of custom bindings are elements of the annotation
+		for key, value := range annotation.Elements {
+			binding[key] = value
+		}
+
+		return binding
+	}
+
+	known, has := javaBindings[annotation.SimpleName()]
+	if !has {
+		return nil
+	}
+
+	for key, value := range annotation.Elements {
+		binding[key] = value
+	}
+
+	binding["type"] = known.Type
+	binding["direction"] = known.Direction
+
+	return binding
+}
+
+// validateJavaFunctionApp validates the layout of a staged Java function app before it is deployed.
+func validateJavaFunctionApp(stagingDir string) error {
+	var host struct {
+		Version string `json:"version"`
+	}
+
+	contents, err := os.ReadFile(filepath.Join(stagingDir, "host.json"))
+	if err != nil {
+		return fmt.Errorf("reading host.json: %w", err)
+	}
+
+	if err := json.Unmarshal(contents, &host); err != nil {
+		return fmt.Errorf("parsing host.json: %w", err)
+	}
+
+	if host.Version != "2.0" {
+		return fmt.Errorf("host.json has version '%s', expected '2.0'", host.Version)
+	}
+
+	entries, err := os.ReadDir(stagingDir)
+	if err != nil {
+		return fmt.Errorf("reading staging directory: %w", err)
+	}
+
+	var errs []error
+	functionCount := 0
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == javaFunctionsLibDir {
+			continue
+		}
+
+		contents, err := os.ReadFile(filepath.Join(stagingDir, entry.Name(), "function.json"))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("reading function.json of function '%s': %w", entry.Name(), err)
+		}
+
+		functionCount++
+
+		var function javaFunction
+		if err := json.Unmarshal(contents, &function); err != nil {
+			errs = append(errs, fmt.Errorf("parsing function.json of function '%s': %w", entry.Name(), err))
+			continue
+		}
+
+		if err := validateJavaFunction(stagingDir, entry.Name(), &function); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if functionCount == 0 {
+		errs = append(errs, errors.New(
+			"no functions found. Annotate the methods of the functions with @FunctionName from azure-functions-java-library"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid function app: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func validateJavaFunction(stagingDir string, name string, function *javaFunction) error {
+	if !functionNameRegex.MatchString(name) {
+		return fmt.Errorf("function name '%s' is not valid", name)
+	}
+
+	if _, err := os.Stat(filepath.Join(stagingDir, name, filepath.FromSlash(function.ScriptFile))); err != nil {
+		return fmt.Errorf("script file '%s' of function '%s' not found", function.ScriptFile, name)
+	}
+
+	if function.EntryPoint == "" {
+		return fmt.Errorf("function '%s' does not have an entry point", name)
+	}
+
+	triggers := 0
+	names := map[string]bool{}
+	for _, binding := range function.Bindings {
+		bindingType, _ := binding["type"].(string)
+		bindingName, _ := binding["name"].(string)
+		if bindingType == "" || bindingName == "" {
+			return fmt.Errorf("function '%s' has a binding without a type or a name", name)
+		}
+
+		if names[bindingName] {
+			return fmt.Errorf("function '%s' has more than one binding named '%s'", name, bindingName)
+		}
+
+		names[bindingName] = true
+
+		if strings.HasSuffix(bindingType, "Trigger") {
+			triggers++
+		}
+	}
+
+	if triggers != 1 {
+		return fmt.Errorf("function '%s' must have exactly one trigger, found %d", name, triggers)
+	}
+
+	return nil
+}
+
+// removeJavaFunctionsLibrary removes azure-functions-java-library from the dependencies of the function app.
+func removeJavaFunctionsLibrary(libDir string) error {
+	entries, err := os.ReadDir(libDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("reading dependencies of the function app: %w", err)
+	}
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), javaFunctionsLibraryArtifactId+"-") {
+			if err := os.Remove(filepath.Join(libDir, entry.Name())); err != nil {
+				return fmt.Errorf("removing %s: %w", entry.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// isOriginalArchive returns true for the archive that maven-shade-plugin keeps next to the shaded archive. It is not
+// deployable on its own.
+func isOriginalArchive(name string) bool {
+	return strings.HasPrefix(name, "original-")
+}
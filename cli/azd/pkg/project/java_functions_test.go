@@ -0,0 +1,470 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseJavaClass(t *testing.T) {
+	contents := buildTestClass("com/example/Function", []testMethod{
+		{Name: "<init>"},
+		{
+			Name: "run",
+			Annotations: []testAnnotation{
+				functionsAnnotation("FunctionName", testElement{"value", 's', "hello"}),
+			},
+			Parameters: [][]testAnnotation{
+				{
+					functionsAnnotation("HttpTrigger",
+						testElement{"name", 's', "req"},
+						testElement{"methods", '[', []testElement{
+							{"", 'e', "GET"},
+							{"", 'e', "POST"},
+						}},
+						testElement{"authLevel", 'e', "ANONYMOUS"},
+					),
+				},
+				nil,
+			},
+		},
+	})
+
+	class, err := parseJavaClass(contents)
+	require.NoError(t, err)
+	require.Equal(t, "com.example.Function", class.Name)
+	require.Len(t, class.Methods, 2)
+
+	method := class.Methods[1]
+	require.Equal(t, "run", method.Name)
+	require.Equal(t, []javaAnnotation{
+		{
+			Type:     javaFunctionsAnnotationPackage + "FunctionName",
+			Elements: map[string]any{"value": "hello"},
+		},
+	}, method.Annotations)
+
+	require.Len(t, method.ParameterAnnotations, 2)
+	require.Empty(t, method.ParameterAnnotations[1])
+	require.Equal(t, "HttpTrigger", method.ParameterAnnotations[0][0].SimpleName())
+	require.Equal(t, map[string]any{
+		"name":      "req",
+		"methods":   []any{"GET", "POST"},
+		"authLevel": "ANONYMOUS",
+	}, method.ParameterAnnotations[0][0].Elements)
+
+	_, err = parseJavaClass([]byte("not a class"))
+	require.Error(t, err)
+}
+
+func Test_stageJavaFunctionApp(t *testing.T) {
+	projectPath := t.TempDir()
+	stagingDir := t.TempDir()
+
+	archive := filepath.Join(projectPath, "functions-1.0.jar")
+	writeTestJar(t, archive, map[string][]byte{
+		"META-INF/MANIFEST.MF": []byte("Manifest-Version: 1.0\n"),
+		// classes that can't be parsed are skipped
+		"shaded/Unsupported.class": []byte("not a class file"),
+		"com/example/Function.class": buildTestClass("com/example/Function", []testMethod{
+			{
+				Name: "hello",
+				Annotations: []testAnnotation{
+					functionsAnnotation("FunctionName", testElement{"value", 's', "hello"}),
+				},
+				Parameters: [][]testAnnotation{
+					{
+						functionsAnnotation("HttpTrigger",
+							testElement{"name", 's', "req"},
+							testElement{"methods", '[', []testElement{{"", 'e', "GET"}}},
+							testElement{"authLevel", 'e', "ANONYMOUS"},
+						),
+					},
+					{
+						{Type: "Lcom/example/NotABinding;"},
+					},
+				},
+			},
+			{
+				Name: "process",
+				Annotations: []testAnnotation{
+					functionsAnnotation("FunctionName", testElement{"value", 's', "process"}),
+					functionsAnnotation("StorageAccount", testElement{"value", 's', "AzureWebJobsStorage"}),
+					functionsAnnotation("QueueOutput", testElement{"queueName", 's', "processed"}),
+				},
+				Parameters: [][]testAnnotation{
+					{
+						functionsAnnotation("QueueTrigger",
+							testElement{"name", 's', "message"},
+							testElement{"queueName", 's', "orders"},
+						),
+					},
+				},
+			},
+			{Name: "helper"},
+		}),
+	})
+
+	libDir := filepath.Join(stagingDir, javaFunctionsLibDir)
+	require.NoError(t, os.MkdirAll(libDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "gson-2.10.jar"), nil, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "azure-functions-java-library-3.1.0.jar"), nil, 0600))
+
+	require.NoError(t, stageJavaFunctionApp(projectPath, archive, stagingDir))
+
+	require.FileExists(t, filepath.Join(stagingDir, "functions-1.0.jar"))
+	require.FileExists(t, filepath.Join(libDir, "gson-2.10.jar"))
+	require.NoFileExists(t, filepath.Join(libDir, "azure-functions-java-library-3.1.0.jar"))
+
+	hostJson, err := os.ReadFile(filepath.Join(stagingDir, "host.json"))
+	require.NoError(t, err)
+	require.Equal(t, defaultJavaFunctionsHostJson, string(hostJson))
+
+	require.Equal(t, javaFunction{
+		ScriptFile: "../functions-1.0.jar",
+		EntryPoint: "com.example.Function.hello",
+		Bindings: []map[string]any{
+			{"type": "httpTrigger", "direction": "in", "name": "req", "methods": []any{"GET"}, "authLevel": "ANONYMOUS"},
+			{"type": "http", "direction": "out", "name": "$return"},
+		},
+	}, readTestFunction(t, stagingDir, "hello"))
+
+	require.Equal(t, javaFunction{
+		ScriptFile: "../functions-1.0.jar",
+		EntryPoint: "com.example.Function.process",
+		Bindings: []map[string]any{
+			{
+				"type":       "queueTrigger",
+				"direction":  "in",
+				"name":       "message",
+				"queueName":  "orders",
+				"connection": "AzureWebJobsStorage",
+			},
+			{
+				"type":       "queue",
+				"direction":  "out",
+				"name":       "$return",
+				"queueName":  "processed",
+				"connection": "AzureWebJobsStorage",
+			},
+		},
+	}, readTestFunction(t, stagingDir, "process"))
+
+	require.NoDirExists(t, filepath.Join(stagingDir, "helper"))
+}
+
+func Test_stageJavaFunctionApp_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		methods []testMethod
+		wantErr string
+		// whether the function app is rejected before anything is written to the staging directory
+		wantNothingStaged bool
+	}{
+		{
+			name:    "NoFunctions",
+			methods: []testMethod{{Name: "run"}},
+			wantErr: "no functions found",
+		},
+		{
+			name: "NoTrigger",
+			methods: []testMethod{
+				{
+					Name: "run",
+					Annotations: []testAnnotation{
+						functionsAnnotation("FunctionName", testElement{"value", 's', "run"}),
+					},
+					Parameters: [][]testAnnotation{
+						{functionsAnnotation("BlobInput", testElement{"name", 's', "blob"})},
+					},
+				},
+			},
+			wantErr: "function 'run' must have exactly one trigger, found 0",
+		},
+		{
+			name: "InvalidName",
+			methods: []testMethod{
+				{
+					Name: "run",
+					Annotations: []testAnnotation{
+						functionsAnnotation("FunctionName", testElement{"value", 's', "1run"}),
+					},
+					Parameters: [][]testAnnotation{
+						{functionsAnnotation("TimerTrigger", testElement{"name", 's', "timer"})},
+					},
+				},
+			},
+			wantErr:           "function name '1run' is not valid",
+			wantNothingStaged: true,
+		},
+		{
+			name: "PathName",
+			methods: []testMethod{
+				{
+					Name: "run",
+					Annotations: []testAnnotation{
+						functionsAnnotation("FunctionName", testElement{"value", 's', "../run"}),
+					},
+					Parameters: [][]testAnnotation{
+						{functionsAnnotation("TimerTrigger", testElement{"name", 's', "timer"})},
+					},
+				},
+			},
+			wantErr:           "function name '../run' is not valid",
+			wantNothingStaged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectPath := t.TempDir()
+			archive := filepath.Join(projectPath, "functions.jar")
+			writeTestJar(t, archive, map[string][]byte{
+				"com/example/Function.class": buildTestClass("com/example/Function", tt.methods),
+			})
+
+			stagingDir := filepath.Join(t.TempDir(), "staging")
+			require.NoError(t, os.Mkdir(stagingDir, osutil.PermissionDirectory))
+
+			err := stageJavaFunctionApp(projectPath, archive, stagingDir)
+			require.ErrorContains(t, err, tt.wantErr)
+
+			if tt.wantNothingStaged {
+				entries, err := os.ReadDir(filepath.Dir(stagingDir))
+				require.NoError(t, err)
+				require.Len(t, entries, 1)
+
+				entries, err = os.ReadDir(stagingDir)
+				require.NoError(t, err)
+				require.Empty(t, entries)
+			}
+		})
+	}
+}
+
+func Test_stageJavaFunctionApp_ProjectHostJson(t *testing.T) {
+	projectPath := t.TempDir()
+	stagingDir := t.TempDir()
+
+	hostJson := `{"version": "2.0", "logging": {"logLevel": {"default": "Warning"}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "host.json"), []byte(hostJson), 0600))
+
+	archive := filepath.Join(projectPath, "functions.jar")
+	writeTestJar(t, archive, map[string][]byte{
+		"com/example/Function.class": buildTestClass("com/example/Function", []testMethod{
+			{
+				Name: "run",
+				Annotations: []testAnnotation{
+					functionsAnnotation("FunctionName", testElement{"value", 's', "run"}),
+				},
+				Parameters: [][]testAnnotation{
+					{functionsAnnotation("TimerTrigger",
+						testElement{"name", 's', "timer"},
+						testElement{"schedule", 's', "0 */5 * * * *"},
+					)},
+				},
+			},
+		}),
+	})
+
+	require.NoError(t, stageJavaFunctionApp(projectPath, archive, stagingDir))
+
+	contents, err := os.ReadFile(filepath.Join(stagingDir, "host.json"))
+	require.NoError(t, err)
+	require.Equal(t, hostJson, string(contents))
+}
+
+func readTestFunction(t *testing.T, stagingDir string, name string) javaFunction {
+	contents, err := os.ReadFile(filepath.Join(stagingDir, name, "function.json"))
+	require.NoError(t, err)
+
+	var function javaFunction
+	require.NoError(t, json.Unmarshal(contents, &function))
+	return function
+}
+
+func writeTestJar(t *testing.T, path string, files map[string][]byte) {
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	writer := zip.NewWriter(file)
+	for name, contents := range files {
+		entry, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write(contents)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+}
+
+type testMethod struct {
+	Name        string
+	Annotations []testAnnotation
+	Parameters  [][]testAnnotation
+}
+
+type testAnnotation struct {
+	// The descriptor of the annotation type, for example 'Lcom/example/Annotation;'
+	Type     string
+	Elements []testElement
+}
+
+// testElement is an element of an annotation. The value is a string for the 's' and 'e' tags, an int32 for 'I', a
+// slice of elements for '[' and an annotation for '@'.
+type testElement struct {
+	Name  string
+	Tag   byte
+	Value any
+}
+
+func functionsAnnotation(name string, elements ...testElement) testAnnotation {
+	return testAnnotation{
+		Type:     "L" + strings.ReplaceAll(javaFunctionsAnnotationPackage, ".", "/") + name + ";",
+		Elements: elements,
+	}
+}
+
+// testClassBuilder encodes the subset of the class file format read by parseJavaClass.
+type testClassBuilder struct {
+	pool  bytes.Buffer
+	count uint16
+	utf8s map[string]uint16
+}
+
+func (b *testClassBuilder) add(tag byte, data ...any) uint16 {
+	b.pool.WriteByte(tag)
+	for _, value := range data {
+		_ = binary.Write(&b.pool, binary.BigEndian, value)
+	}
+
+	b.count++
+	index := b.count
+	if tag == constantLong || tag == constantDouble {
+		b.count++
+	}
+
+	return index
+}
+
+func (b *testClassBuilder) utf8(value string) uint16 {
+	if index, has := b.utf8s[value]; has {
+		return index
+	}
+
+	index := b.add(constantUtf8, uint16(len(value)), []byte(value))
+	b.utf8s[value] = index
+	return index
+}
+
+func (b *testClassBuilder) annotations(out *bytes.Buffer, annotations []testAnnotation) {
+	_ = binary.Write(out, binary.BigEndian, uint16(len(annotations)))
+	for _, annotation := range annotations {
+		b.annotation(out, annotation)
+	}
+}
+
+func (b *testClassBuilder) annotation(out *bytes.Buffer, annotation testAnnotation) {
+	_ = binary.Write(out, binary.BigEndian, b.utf8(annotation.Type))
+	_ = binary.Write(out, binary.BigEndian, uint16(len(annotation.Elements)))
+	for _, element := range annotation.Elements {
+		_ = binary.Write(out, binary.BigEndian, b.utf8(element.Name))
+		b.elementValue(out, element)
+	}
+}
+
+func (b *testClassBuilder) elementValue(out *bytes.Buffer, element testElement) {
+	out.WriteByte(element.Tag)
+	switch element.Tag {
+	case 's':
+		_ = binary.Write(out, binary.BigEndian, b.utf8(element.Value.(string)))
+	case 'e':
+		_ = binary.Write(out, binary.BigEndian, b.utf8("Lcom/example/Enum;"))
+		_ = binary.Write(out, binary.BigEndian, b.utf8(element.Value.(string)))
+	case 'I':
+		_ = binary.Write(out, binary.BigEndian, b.add(constantInteger, element.Value.(int32)))
+	case '[':
+		values := element.Value.([]testElement)
+		_ = binary.Write(out, binary.BigEndian, uint16(len(values)))
+		for _, value := range values {
+			b.elementValue(out, value)
+		}
+	case '@':
+		b.annotation(out, element.Value.(testAnnotation))
+	}
+}
+
+func buildTestClass(name string, methods []testMethod) []byte {
+	b := &testClassBuilder{utf8s: map[string]uint16{}}
+
+	// Constants that are not referenced by annotations, including one taking two entries of the pool
+	b.add(constantLong, int64(42))
+	b.add(constantMethodref, uint16(1), uint16(1))
+
+	thisClass := b.add(constantClass, b.utf8(name))
+	superClass := b.add(constantClass, b.utf8("java/lang/Object"))
+
+	var members bytes.Buffer
+	// No fields
+	_ = binary.Write(&members, binary.BigEndian, uint16(0))
+	_ = binary.Write(&members, binary.BigEndian, uint16(len(methods)))
+	for _, method := range methods {
+		_ = binary.Write(&members, binary.BigEndian, uint16(0x0001))
+		_ = binary.Write(&members, binary.BigEndian, b.utf8(method.Name))
+		_ = binary.Write(&members, binary.BigEndian, b.utf8("()V"))
+
+		var attributes [][2]any
+		if len(method.Annotations) > 0 {
+			var contents bytes.Buffer
+			b.annotations(&contents, method.Annotations)
+			attributes = append(attributes, [2]any{"RuntimeVisibleAnnotations", contents.Bytes()})
+		}
+
+		if len(method.Parameters) > 0 {
+			var contents bytes.Buffer
+			contents.WriteByte(byte(len(method.Parameters)))
+			for _, annotations := range method.Parameters {
+				b.annotations(&contents, annotations)
+			}
+			attributes = append(attributes, [2]any{"RuntimeVisibleParameterAnnotations", contents.Bytes()})
+		}
+
+		_ = binary.Write(&members, binary.BigEndian, uint16(len(attributes)))
+		for _, attribute := range attributes {
+			contents := attribute[1].([]byte)
+			_ = binary.Write(&members, binary.BigEndian, b.utf8(attribute[0].(string)))
+			_ = binary.Write(&members, binary.BigEndian, uint32(len(contents)))
+			members.Write(contents)
+		}
+	}
+
+	// No class attributes
+	_ = binary.Write(&members, binary.BigEndian, uint16(0))
+
+	var class bytes.Buffer
+	_ = binary.Write(&class, binary.BigEndian, uint32(javaClassMagic))
+	_ = binary.Write(&class, binary.BigEndian, uint16(0))
+	_ = binary.Write(&class, binary.BigEndian, uint16(61))
+	_ = binary.Write(&class, binary.BigEndian, b.count+1)
+	class.Write(b.pool.Bytes())
+	_ = binary.Write(&class, binary.BigEndian, uint16(0x0021))
+	_ = binary.Write(&class, binary.BigEndian, thisClass)
+	_ = binary.Write(&class, binary.BigEndian, superClass)
+	// No interfaces
+	_ = binary.Write(&class, binary.BigEndian, uint16(0))
+	class.Write(members.Bytes())
+
+	return class.Bytes()
+}
@@ -39,6 +39,8 @@ type ServiceConfig struct {
 	K8s AksOptions `yaml:"k8s,omitempty"`
 	// The optional Azure Spring Apps options
 	Spring SpringOptions `yaml:"spring,omitempty"`
+	// The optional Azure Container Apps options
+	ContainerApp ContainerAppOptions `yaml:"containerApp,omitempty"`
 	// The infrastructure provisioning configuration
 	Infra provisioning.Options `yaml:"infra,omitempty"`
 	// Hook configuration for service
@@ -0,0 +1,90 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// logStreamIdleTimeout is the time after which a log stream that can't stop by itself is closed when no new line is
+// written, when the logs aren't followed.
+const logStreamIdleTimeout = 5 * time.Second
+
+// copyLogLines copies the lines of the log stream to the writer, prefixed with prefix. Each line is written with a single
+// call to the writer. When idleTimeout is set, the stream is closed once no new line is read for the duration.
+func copyLogLines(
+	ctx context.Context,
+	stream io.ReadCloser,
+	writer io.Writer,
+	prefix string,
+	idleTimeout time.Duration,
+) error {
+	defer stream.Close()
+
+	var idle atomic.Bool
+	if idleTimeout > 0 {
+		timer := time.AfterFunc(idleTimeout, func() {
+			idle.Store(true)
+			stream.Close()
+		})
+		defer timer.Stop()
+
+		writer = &resetTimerWriter{writer: writer, reset: func() { timer.Reset(idleTimeout) }}
+	}
+
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		if _, err := fmt.Fprintf(writer, "%s%s\n", prefix, scanner.Text()); err != nil {
+			return err
+		}
+	}
+
+	// following the logs ends when the context is canceled
+	if err := scanner.Err(); err != nil && !idle.Load() && ctx.Err() == nil {
+		return fmt.Errorf("reading log stream: %w", err)
+	}
+
+	return nil
+}
+
+// writeLogLine writes a line of the logs of a service, without its new line, with the time the service wrote it at
+// when the writer records it. A zero timestamp is unknown.
+func writeLogLine(writer io.Writer, timestamp time.Time, line string) error {
+	if lineWriter, ok := writer.(ServiceLogLineWriter); ok && !timestamp.IsZero() {
+		return lineWriter.WriteLine(timestamp, line)
+	}
+
+	_, err := fmt.Fprintf(writer, "%s\n", line)
+	return err
+}
+
+// resetTimerWriter resets the idle timer of a log stream each time a line is written.
+type resetTimerWriter struct {
+	writer io.Writer
+	reset  func()
+}
+
+func (w *resetTimerWriter) Write(p []byte) (int, error) {
+	w.reset()
+	return w.writer.Write(p)
+}
+
+// syncWriter serializes the writes of the log streams of a service, so their lines don't interleave.
+type syncWriter struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.writer.Write(p)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_copyLogLines(t *testing.T) {
+	t.Run("EndOfStream", func(t *testing.T) {
+		writer := &strings.Builder{}
+		stream := io.NopCloser(strings.NewReader("starting\nlistening on 8080"))
+
+		err := copyLogLines(context.Background(), stream, writer, "[web-1] ", 0)
+		require.NoError(t, err)
+		require.Equal(t, "[web-1] starting\n[web-1] listening on 8080\n", writer.String())
+	})
+
+	t.Run("IdleTimeout", func(t *testing.T) {
+		writer := &strings.Builder{}
+		reader, pipeWriter := io.Pipe()
+		defer pipeWriter.Close()
+
+		go func() {
+			_, _ = pipeWriter.Write([]byte("Welcome, you are now connected to log-streaming service.\n"))
+		}()
+
+		// the stream never ends, it is closed once no new line is written for the idle timeout
+		err := copyLogLines(context.Background(), reader, writer, "", 50*time.Millisecond)
+		require.NoError(t, err)
+		require.Equal(t, "Welcome, you are now connected to log-streaming service.\n", writer.String())
+	})
+}
+
+func Test_kubectlLogWriter(t *testing.T) {
+	writer := &testLineWriter{}
+	stream := io.NopCloser(strings.NewReader(
+		"[pod/web-1/web] 2024-05-01T11:58:00.123456789Z listening on 8080\n" +
+			"[pod/web-1/web] unexpected line\n"))
+
+	err := copyLogLines(context.Background(), stream, &kubectlLogWriter{writer: writer}, "", 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"2024-05-01T11:58:00.123456789Z [pod/web-1/web] listening on 8080"}, writer.lines)
+	require.Equal(t, "[pod/web-1/web] unexpected line\n", writer.String())
+}
+
+// testLineWriter records the lines written with their time.
+type testLineWriter struct {
+	strings.Builder
+	lines []string
+}
+
+func (w *testLineWriter) WriteLine(timestamp time.Time, line string) error {
+	w.lines = append(w.lines, timestamp.Format(time.RFC3339Nano)+" "+line)
+	return nil
+}
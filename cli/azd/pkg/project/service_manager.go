@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
@@ -32,6 +33,9 @@ const (
 	ServiceEventBuild      ext.Event = "build"
 	ServiceEventPackage    ext.Event = "package"
 	ServiceEventDeploy     ext.Event = "deploy"
+	// ServiceEventTrafficShift is raised after each step of a progressive container app deployment. The post hooks of
+	// the event gate the deployment: when they fail, the traffic is rolled back to the previous revision.
+	ServiceEventTrafficShift ext.Event = "trafficshift"
 )
 
 var (
@@ -40,6 +44,7 @@ var (
 		ServiceEventRestore,
 		ServiceEventPackage,
 		ServiceEventDeploy,
+		ServiceEventTrafficShift,
 	}
 )
 
@@ -113,12 +118,14 @@ type ServiceManager interface {
 type ServiceOperationCache map[string]any
 
 type serviceManager struct {
-	env                 *environment.Environment
-	resourceManager     ResourceManager
-	serviceLocator      ioc.ServiceLocator
-	operationCache      ServiceOperationCache
-	alphaFeatureManager *alpha.FeatureManager
-	initialized         map[*ServiceConfig]map[any]bool
+	env                    *environment.Environment
+	resourceManager        ResourceManager
+	serviceLocator         ioc.ServiceLocator
+	operationCache         ServiceOperationCache
+	alphaFeatureManager    *alpha.FeatureManager
+	externalServiceTargets *ExternalServiceTargetRegistry
+	externalFrameworks     *ExternalFrameworkServiceRegistry
+	initialized            map[*ServiceConfig]map[any]bool
 }
 
 // NewServiceManager creates a new instance of the ServiceManager component
@@ -128,14 +135,18 @@ func NewServiceManager(
 	serviceLocator ioc.ServiceLocator,
 	operationCache ServiceOperationCache,
 	alphaFeatureManager *alpha.FeatureManager,
+	externalServiceTargets *ExternalServiceTargetRegistry,
+	externalFrameworks *ExternalFrameworkServiceRegistry,
 ) ServiceManager {
 	return &serviceManager{
-		env:                 env,
-		resourceManager:     resourceManager,
-		serviceLocator:      serviceLocator,
-		operationCache:      operationCache,
-		alphaFeatureManager: alphaFeatureManager,
-		initialized:         map[*ServiceConfig]map[any]bool{},
+		env:                    env,
+		resourceManager:        resourceManager,
+		serviceLocator:         serviceLocator,
+		operationCache:         operationCache,
+		alphaFeatureManager:    alphaFeatureManager,
+		externalServiceTargets: externalServiceTargets,
+		externalFrameworks:     externalFrameworks,
+		initialized:            map[*ServiceConfig]map[any]bool{},
 	}
 }
 
@@ -473,6 +484,14 @@ func (sm *serviceManager) Deploy(
 			containerEnvName,
 			string(azapi.AzureResourceTypeContainerAppEnvironment),
 		)
+	} else if _, isExternal := sm.externalServiceTargets.Get(serviceConfig.Host); isExternal {
+		// Service targets provided by extensions can deploy to targets that are not Azure resources.
+		// The Azure resource tagged for the service is provided when it exists.
+		targetResource, err = sm.resourceManager.GetTargetResource(ctx, sm.env.GetSubscriptionId(), serviceConfig)
+		if err != nil {
+			log.Printf("no target resource found for service '%s': %v", serviceConfig.Name, err)
+			targetResource = environment.NewTargetResource(sm.env.GetSubscriptionId(), "", "", "")
+		}
 	} else {
 		targetResource, err = sm.resourceManager.GetTargetResource(ctx, sm.env.GetSubscriptionId(), serviceConfig)
 		if err != nil {
@@ -520,7 +539,20 @@ func (sm *serviceManager) GetServiceTarget(ctx context.Context, serviceConfig *S
 		}
 	}
 
+	// Hosts that azd doesn't provide are resolved to the service target registered by an extension
+	if externalTarget, has := sm.externalServiceTargets.Get(serviceConfig.Host); has {
+		return externalTarget, nil
+	}
+
 	if err := sm.serviceLocator.ResolveNamed(host, &target); err != nil {
+		if !serviceConfig.Host.IsBuiltIn() {
+			return nil, &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf("unsupported host '%s' for service '%s'", host, serviceConfig.Name),
+				Suggestion: fmt.Sprintf(
+					"Use one of the hosts supported by azd, or install an extension that provides the '%s' host.", host),
+			}
+		}
+
 		return nil, fmt.Errorf(
 			"failed to resolve service host '%s' for service '%s', %w",
 			serviceConfig.Host,
@@ -541,7 +573,32 @@ func (sm *serviceManager) GetFrameworkService(ctx context.Context, serviceConfig
 		serviceConfig.Language = ServiceLanguageDocker
 	}
 
-	if err := sm.serviceLocator.ResolveNamed(string(serviceConfig.Language), &frameworkService); err != nil {
+	frameworkName := string(serviceConfig.Language)
+	// Java projects are built with maven by default, and with gradle when the service only contains a gradle build
+	if serviceConfig.Language == ServiceLanguageJava {
+		isGradle, err := isGradleProject(serviceConfig.Path())
+		if err != nil {
+			return nil, fmt.Errorf("checking for gradle build files: %w", err)
+		}
+		if isGradle {
+			frameworkName = string(ServiceLanguageGradle)
+		}
+	}
+
+	// Languages that azd doesn't provide are resolved to the framework service registered by an extension
+	if externalFramework, has := sm.externalFrameworks.Get(serviceConfig.Language); has {
+		frameworkService = externalFramework
+	} else if err := sm.serviceLocator.ResolveNamed(frameworkName, &frameworkService); err != nil {
+		if !serviceConfig.Language.IsBuiltIn() {
+			return nil, &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf("unsupported language '%s' for service '%s'", serviceConfig.Language, serviceConfig.Name),
+				Suggestion: fmt.Sprintf(
+					"Use one of the languages supported by azd, or install an extension that provides the '%s' language.",
+					serviceConfig.Language,
+				),
+			}
+		}
+
 		return nil, fmt.Errorf(
 			"failed to resolve language '%s' for service '%s', %w",
 			serviceConfig.Language,
@@ -554,7 +611,9 @@ func (sm *serviceManager) GetFrameworkService(ctx context.Context, serviceConfig
 	// For hosts which run in containers, if the source project is not already a container, we need to wrap it in a docker
 	// project that handles the containerization.
 	requiresLanguage := serviceConfig.Language != ServiceLanguageDocker && serviceConfig.Language != ServiceLanguageNone
-	if serviceConfig.Host.RequiresContainer() && requiresLanguage {
+	requiresContainer := serviceConfig.Host.RequiresContainer() ||
+		(serviceConfig.Host == SpringAppTarget && serviceConfig.Spring.Source == SpringSourceContainer)
+	if requiresContainer && requiresLanguage {
 		if err := sm.serviceLocator.ResolveNamed(string(ServiceLanguageDocker), &compositeFramework); err != nil {
 			return nil, fmt.Errorf(
 				"failed resolving composite framework service for '%s', language '%s': %w",
@@ -58,7 +58,15 @@ func createServiceManager(
 			},
 		}))
 
-	return NewServiceManager(env, resourceManager, mockContext.Container, operationCache, alphaManager)
+	return NewServiceManager(
+		env,
+		resourceManager,
+		mockContext.Container,
+		operationCache,
+		alphaManager,
+		NewExternalServiceTargetRegistry(),
+		NewExternalFrameworkServiceRegistry(),
+	)
 }
 
 func Test_ServiceManager_GetRequiredTools(t *testing.T) {
@@ -268,6 +276,41 @@ func Test_ServiceManager_GetFrameworkService(t *testing.T) {
 	})
 }
 
+func Test_ServiceManager_GetFrameworkService_External(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	setupMocksForServiceManager(mockContext)
+	env := environment.New("test")
+
+	externalFrameworks := NewExternalFrameworkServiceRegistry()
+	externalFramework := &fakeFramework{}
+	require.NoError(t, externalFrameworks.Register("rust", externalFramework))
+
+	sm := NewServiceManager(
+		env,
+		nil,
+		mockContext.Container,
+		ServiceOperationCache{},
+		alpha.NewFeaturesManagerWithConfig(config.NewEmptyConfig()),
+		NewExternalServiceTargetRegistry(),
+		externalFrameworks,
+	)
+
+	t.Run("Registered", func(t *testing.T) {
+		serviceConfig := createTestServiceConfig("./src/api", ServiceTargetFake, "rust")
+
+		framework, err := sm.GetFrameworkService(*mockContext.Context, serviceConfig)
+		require.NoError(t, err)
+		require.Same(t, externalFramework, framework)
+	})
+
+	t.Run("NotRegistered", func(t *testing.T) {
+		serviceConfig := createTestServiceConfig("./src/api", ServiceTargetFake, "bazel")
+
+		_, err := sm.GetFrameworkService(*mockContext.Context, serviceConfig)
+		require.ErrorContains(t, err, "unsupported language 'bazel' for service 'api'")
+	})
+}
+
 func Test_ServiceManager_GetServiceTarget(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	setupMocksForServiceManager(mockContext)
@@ -281,6 +324,41 @@ func Test_ServiceManager_GetServiceTarget(t *testing.T) {
 	require.IsType(t, new(fakeServiceTarget), serviceTarget)
 }
 
+func Test_ServiceManager_GetServiceTarget_External(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	setupMocksForServiceManager(mockContext)
+	env := environment.New("test")
+
+	externalServiceTargets := NewExternalServiceTargetRegistry()
+	externalServiceTarget := &fakeServiceTarget{}
+	require.NoError(t, externalServiceTargets.Register("azure.batch", externalServiceTarget))
+
+	sm := NewServiceManager(
+		env,
+		nil,
+		mockContext.Container,
+		ServiceOperationCache{},
+		alpha.NewFeaturesManagerWithConfig(config.NewEmptyConfig()),
+		externalServiceTargets,
+		NewExternalFrameworkServiceRegistry(),
+	)
+
+	t.Run("Registered", func(t *testing.T) {
+		serviceConfig := createTestServiceConfig("./src/api", "azure.batch", ServiceLanguageFake)
+
+		serviceTarget, err := sm.GetServiceTarget(*mockContext.Context, serviceConfig)
+		require.NoError(t, err)
+		require.Same(t, externalServiceTarget, serviceTarget)
+	})
+
+	t.Run("NotRegistered", func(t *testing.T) {
+		serviceConfig := createTestServiceConfig("./src/api", "azure.vmss", ServiceLanguageFake)
+
+		_, err := sm.GetServiceTarget(*mockContext.Context, serviceConfig)
+		require.ErrorContains(t, err, "unsupported host 'azure.vmss' for service 'api'")
+	})
+}
+
 func Test_ServiceManager_CacheResults(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	setupMocksForServiceManager(mockContext)
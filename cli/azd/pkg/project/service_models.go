@@ -4,6 +4,7 @@
 package project
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -25,6 +26,25 @@ type ServiceLifecycleEventArgs struct {
 	Args    map[string]any
 }
 
+// ServiceEventEnvArg is the key of the event args holding environment values, as a map[string]string, that are only
+// available to the hooks of the event and aren't saved in the environment.
+const ServiceEventEnvArg = "env"
+
+// deployedServicesKey is the context key of the names of the services deployed by the current command.
+type deployedServicesKey struct{}
+
+// WithDeployedServices returns a context holding the names of the services deployed by the current command, in the
+// order they are deployed. Service targets use them to coordinate the services deploying to the same resource.
+func WithDeployedServices(ctx context.Context, serviceNames []string) context.Context {
+	return context.WithValue(ctx, deployedServicesKey{}, serviceNames)
+}
+
+// deployedServices returns the names of the services deployed by the current command, nil when unknown.
+func deployedServices(ctx context.Context) []string {
+	serviceNames, _ := ctx.Value(deployedServicesKey{}).([]string)
+	return serviceNames
+}
+
 // ServiceProgress represents an incremental progress message
 // during a service operation such as restore, build, package & deploy
 type ServiceProgress struct {
@@ -104,6 +124,9 @@ type ServiceDeployResult struct {
 	Kind             ServiceTargetKind `json:"kind"`
 	Endpoints        []string          `json:"endpoints"`
 	Details          interface{}       `json:"details"`
+	// DeferredTo is the service deploying the changes of this service, when they are deployed together with the changes
+	// of a service deployed later by the same command
+	DeferredTo string `json:"deferredTo,omitempty"`
 }
 
 // Supports rendering messages for UX items
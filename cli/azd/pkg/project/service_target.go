@@ -7,8 +7,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/azure/azure-dev/cli/azd/internal/names"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
@@ -40,24 +44,43 @@ func (stk ServiceTargetKind) RequiresContainer() bool {
 	return false
 }
 
+// builtInServiceTargetKinds are the service target kinds that azd provides as service hosts in azure.yaml.
+//
+// NOTE: We do not support DotNetContainerAppTarget as a listed service host type in azure.yaml, hence
+// it not include in this list. We should think about if we should support this in azure.yaml because
+// presently it's the only service target that is tied to a language.
+var builtInServiceTargetKinds = []ServiceTargetKind{
+	AppServiceTarget,
+	ContainerAppTarget,
+	AzureFunctionTarget,
+	StaticWebAppTarget,
+	SpringAppTarget,
+	AksTarget,
+	AiEndpointTarget,
+}
+
+// IsBuiltIn returns true when the service target kind is provided by azd.
+func (stk ServiceTargetKind) IsBuiltIn() bool {
+	return stk == NonSpecifiedTarget || stk == DotNetContainerAppTarget || slices.Contains(builtInServiceTargetKinds, stk)
+}
+
+// parseServiceHost validates the service host of a service.
+// Hosts that azd doesn't provide are accepted when they are valid names, since they can be provided by extensions.
+// Whether such a host is available is validated when the service target of the service is resolved.
 func parseServiceHost(kind ServiceTargetKind) (ServiceTargetKind, error) {
-	switch kind {
-
-	// NOTE: We do not support DotNetContainerAppTarget as a listed service host type in azure.yaml, hence
-	// it not include in this switch statement. We should think about if we should support this in azure.yaml because
-	// presently it's the only service target that is tied to a language.
-	case AppServiceTarget,
-		ContainerAppTarget,
-		AzureFunctionTarget,
-		StaticWebAppTarget,
-		SpringAppTarget,
-		AksTarget,
-		AiEndpointTarget:
+	if kind == NonSpecifiedTarget || kind == DotNetContainerAppTarget {
+		return ServiceTargetKind(""), fmt.Errorf("unsupported host '%s'", kind)
+	}
 
+	if kind.IsBuiltIn() {
 		return kind, nil
 	}
 
-	return ServiceTargetKind(""), fmt.Errorf("unsupported host '%s'", kind)
+	if err := names.ValidateExtensionKind(kind, builtInServiceTargetKinds); err != nil {
+		return ServiceTargetKind(""), fmt.Errorf("unsupported host '%s': %w", kind, err)
+	}
+
+	return kind, nil
 }
 
 type ServiceTarget interface {
@@ -94,6 +117,34 @@ type ServiceTarget interface {
 	) ([]string, error)
 }
 
+// ServiceLogsTarget is implemented by the service targets that can stream the console logs of the services they host.
+type ServiceLogsTarget interface {
+	// Logs writes the console logs of the service to the writer, line by line. Each line is written with a single call to
+	// the writer. When the writer is a ServiceLogLineWriter, the lines whose time is known are written with WriteLine.
+	Logs(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+		options *ServiceLogsOptions,
+		writer io.Writer,
+	) error
+}
+
+// ServiceLogLineWriter is implemented by the writers of the logs of the services that record the time of each line.
+type ServiceLogLineWriter interface {
+	io.Writer
+	// WriteLine writes a line, without its new line, that the service wrote at the timestamp.
+	WriteLine(timestamp time.Time, line string) error
+}
+
+// ServiceLogsOptions are the options for streaming the logs of a service.
+type ServiceLogsOptions struct {
+	// Follow keeps streaming the logs as they are written, until the context is canceled
+	Follow bool
+	// Since only includes the logs more recent than the duration. Zero includes the most recent lines only.
+	Since time.Duration
+}
+
 // NewServiceDeployResult is a helper function to create a new ServiceDeployResult
 func NewServiceDeployResult(
 	relatedResourceId string,
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/url"
 	"os"
@@ -512,6 +513,100 @@ func (t *aksTarget) Endpoints(
 	return endpoints, nil
 }
 
+// Logs streams the logs of all the containers of the pods of the k8s deployment of the service, each line prefixed with
+// the pod and the container it comes from.
+func (t *aksTarget) Logs(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	options *ServiceLogsOptions,
+	writer io.Writer,
+) error {
+	if err := t.validateTargetResource(targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	if err := tools.EnsureInstalled(ctx, t.kubectl); err != nil {
+		return err
+	}
+
+	t.kubectl.SetEnv(t.env.Dotenv())
+	if kubeConfigPath := t.env.Getenv(kubectl.KubeConfigEnvVarName); kubeConfigPath != "" {
+		t.kubectl.SetKubeConfig(kubeConfigPath)
+	}
+
+	namespace := t.getK8sNamespace(serviceConfig)
+	if _, err := t.ensureClusterContext(ctx, serviceConfig, targetResource, namespace); err != nil {
+		return err
+	}
+
+	deploymentName := serviceConfig.K8s.Deployment.Name
+	if deploymentName == "" {
+		deploymentName = serviceConfig.Name
+	}
+
+	deployment, err := kubectl.GetResource[kubectl.Deployment](
+		ctx, t.kubectl, kubectl.ResourceTypeDeployment, deploymentName, &kubectl.KubeCliFlags{Namespace: namespace})
+	if err != nil {
+		return fmt.Errorf("failed getting deployment '%s': %w", deploymentName, err)
+	}
+
+	if deployment.Spec.Selector == nil || len(deployment.Spec.Selector.MatchLabels) == 0 {
+		return fmt.Errorf("deployment '%s' has no label selector for its pods", deploymentName)
+	}
+
+	// the lines of the pods are copied one by one, so they are written with a single call to the writer
+	reader, pipeWriter := io.Pipe()
+	copyDone := make(chan error, 1)
+	go func() {
+		copyDone <- copyLogLines(ctx, reader, &kubectlLogWriter{writer: writer}, "", 0)
+	}()
+
+	err = t.kubectl.Logs(
+		ctx,
+		deployment.Spec.Selector.String(),
+		&kubectl.LogsOptions{
+			Follow:     options.Follow,
+			Since:      options.Since,
+			Timestamps: true,
+		},
+		pipeWriter,
+		&kubectl.KubeCliFlags{Namespace: namespace},
+	)
+	pipeWriter.Close()
+
+	return errors.Join(err, <-copyDone)
+}
+
+// kubectlLogWriter writes the lines of the logs of kubectl, each starting with the time it was written at after the
+// pod and container prefix, with the time removed from the line.
+type kubectlLogWriter struct {
+	writer io.Writer
+}
+
+func (w *kubectlLogWriter) Write(p []byte) (int, error) {
+	line := strings.TrimSuffix(string(p), "\n")
+
+	prefix := ""
+	if strings.HasPrefix(line, "[") {
+		if end := strings.Index(line, "] "); end != -1 {
+			prefix, line = line[:end+2], line[end+2:]
+		}
+	}
+
+	var timestamp time.Time
+	value, message, _ := strings.Cut(line, " ")
+	if parsed, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		timestamp, line = parsed, message
+	}
+
+	if err := writeLogLine(w.writer, timestamp, prefix+line); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
 func (t *aksTarget) validateTargetResource(
 	targetResource *environment.TargetResource,
 ) error {
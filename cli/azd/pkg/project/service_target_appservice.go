@@ -6,6 +6,7 @@ package project
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -143,6 +144,37 @@ func (st *appServiceTarget) Endpoints(
 	return endpoints, nil
 }
 
+// Logs streams the application logs of the App Service. The log stream starts with the most recent logs of the app,
+// regardless of options.Since. When the logs aren't followed, the stream ends once no new line is written for a while.
+func (st *appServiceTarget) Logs(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	options *ServiceLogsOptions,
+	writer io.Writer,
+) error {
+	if err := st.validateTargetResource(targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	stream, err := st.cli.OpenAppServiceLogStream(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+	)
+	if err != nil {
+		return err
+	}
+
+	idleTimeout := logStreamIdleTimeout
+	if options.Follow {
+		idleTimeout = 0
+	}
+
+	return copyLogLines(ctx, stream, writer, "", idleTimeout)
+}
+
 func (st *appServiceTarget) validateTargetResource(
 	targetResource *environment.TargetResource,
 ) error {
@@ -6,22 +6,88 @@ package project
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
 	"github.com/azure/azure-dev/cli/azd/pkg/containerapps"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/sethvargo/go-retry"
 )
 
+// ContainerAppDeploymentStrategy is the strategy used to send the traffic to a new revision of a container app.
+type ContainerAppDeploymentStrategy string
+
+const (
+	// ContainerAppDeploymentAllAtOnce sends all the traffic to the new revision at once
+	ContainerAppDeploymentAllAtOnce ContainerAppDeploymentStrategy = "allAtOnce"
+	// ContainerAppDeploymentCanary shifts the traffic to the new revision in steps, checking the new revision between
+	// the steps
+	ContainerAppDeploymentCanary ContainerAppDeploymentStrategy = "canary"
+)
+
+const (
+	defaultRevisionHealthCheckTimeout  = 2 * time.Minute
+	defaultRevisionHealthCheckInterval = 5 * time.Second
+)
+
+// defaultCanarySteps are the steps of a canary deployment which doesn't configure its own.
+var defaultCanarySteps = []ContainerAppTrafficStep{
+	{Weight: 10, Pause: "1m"},
+	{Weight: 50, Pause: "1m"},
+	{Weight: 100},
+}
+
+// The Azure Container Apps options
+type ContainerAppOptions struct {
+	// The name of the container, or init container, of the revision template the image of the service is deployed to.
+	// Defaults to the first container. Services deploying to the same container app must each set their container.
+	Container string `yaml:"container,omitempty"`
+	// The deployment strategy of new revisions. Only applies to container apps in multiple revision mode.
+	Deployment ContainerAppDeploymentOptions `yaml:"deployment,omitempty"`
+}
+
+// The Azure Container Apps deployment options
+type ContainerAppDeploymentOptions struct {
+	// The strategy used to send traffic to the new revision. Defaults to 'allAtOnce'
+	Strategy ContainerAppDeploymentStrategy `yaml:"strategy,omitempty"`
+	// The traffic steps of a canary deployment. Defaults to 10% and 50%, with a pause of one minute, then 100%
+	Steps []ContainerAppTrafficStep `yaml:"steps,omitempty"`
+	// The HTTP health check of the new revision, run after each step of a canary deployment
+	HealthCheck *ContainerAppHealthCheck `yaml:"healthCheck,omitempty"`
+}
+
+// A traffic step of a canary deployment
+type ContainerAppTrafficStep struct {
+	// The percentage of the traffic sent to the new revision
+	Weight int32 `yaml:"weight"`
+	// The time to wait before the new revision is checked, like '30s' or '5m'
+	Pause string `yaml:"pause,omitempty"`
+}
+
+// The HTTP health check of a new revision
+type ContainerAppHealthCheck struct {
+	// The path requested on the new revision, which must respond with a 2xx status code
+	Path string `yaml:"path"`
+	// The time the new revision has to become healthy, like '30s' or '5m'. Defaults to 2 minutes
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
 type containerAppTarget struct {
 	env                 *environment.Environment
 	envManager          environment.Manager
 	containerHelper     *ContainerHelper
 	containerAppService containerapps.ContainerAppService
 	resourceManager     ResourceManager
+	httpClient          auth.HttpClient
 }
 
 // NewContainerAppTarget creates the container app service target.
@@ -34,6 +100,7 @@ func NewContainerAppTarget(
 	containerHelper *ContainerHelper,
 	containerAppService containerapps.ContainerAppService,
 	resourceManager ResourceManager,
+	httpClient auth.HttpClient,
 ) ServiceTarget {
 	return &containerAppTarget{
 		env:                 env,
@@ -41,6 +108,7 @@ func NewContainerAppTarget(
 		containerHelper:     containerHelper,
 		containerAppService: containerAppService,
 		resourceManager:     resourceManager,
+		httpClient:          httpClient,
 	}
 }
 
@@ -86,22 +154,37 @@ func (at *containerAppTarget) Deploy(
 		return nil, err
 	}
 
+	trafficShift, err := at.trafficShift(serviceConfig, progress)
+	if err != nil {
+		return nil, err
+	}
+
 	containerAppOptions := containerapps.ContainerAppOptions{
-		ApiVersion: serviceConfig.ApiVersion,
+		ApiVersion:   serviceConfig.ApiVersion,
+		TrafficShift: trafficShift,
 	}
 
-	imageName := at.env.GetServiceProperty(serviceConfig.Name, "IMAGE_NAME")
-	progress.SetProgress(NewServiceProgress("Updating container app revision"))
-	err = at.containerAppService.AddRevision(
-		ctx,
-		targetResource.SubscriptionId(),
-		targetResource.ResourceGroupName(),
-		targetResource.ResourceName(),
-		imageName,
-		&containerAppOptions,
-	)
+	images, deferredTo, err := at.revisionImages(ctx, serviceConfig, targetResource)
 	if err != nil {
-		return nil, fmt.Errorf("updating container app service: %w", err)
+		return nil, err
+	}
+
+	if deferredTo != "" {
+		progress.SetProgress(NewServiceProgress(
+			fmt.Sprintf("Deferring container app revision to service %s", deferredTo)))
+	} else {
+		progress.SetProgress(NewServiceProgress("Updating container app revision"))
+		err = at.containerAppService.AddRevision(
+			ctx,
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+			images,
+			&containerAppOptions,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("updating container app service: %w", err)
+		}
 	}
 
 	progress.SetProgress(NewServiceProgress("Fetching endpoints for container app service"))
@@ -117,8 +200,9 @@ func (at *containerAppTarget) Deploy(
 			targetResource.ResourceGroupName(),
 			targetResource.ResourceName(),
 		),
-		Kind:      ContainerAppTarget,
-		Endpoints: endpoints,
+		Kind:       ContainerAppTarget,
+		Endpoints:  endpoints,
+		DeferredTo: deferredTo,
 	}, nil
 }
 
@@ -150,6 +234,36 @@ func (at *containerAppTarget) Endpoints(
 	}
 }
 
+// Logs streams the console logs of the latest revision of the container app. When the service deploys to a container of
+// the app, only the logs of the container are included.
+func (at *containerAppTarget) Logs(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	options *ServiceLogsOptions,
+	writer io.Writer,
+) error {
+	if err := at.validateTargetResource(targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	return at.containerAppService.Logs(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		&containerapps.LogsOptions{
+			ContainerAppOptions: containerapps.ContainerAppOptions{
+				ApiVersion: serviceConfig.ApiVersion,
+			},
+			Follow:        options.Follow,
+			Since:         options.Since,
+			ContainerName: serviceConfig.ContainerApp.Container,
+		},
+		writer,
+	)
+}
+
 func (at *containerAppTarget) validateTargetResource(
 	targetResource *environment.TargetResource,
 ) error {
@@ -183,3 +297,213 @@ func (at *containerAppTarget) addPreProvisionChecks(_ context.Context, serviceCo
 		return at.envManager.Save(ctx, at.env)
 	})
 }
+
+// revisionImages returns the images of the new revision of the container app: the image of the service, and the images
+// of the other services deploying to the same container app, earlier in the current command. So all the images are
+// rolled out in one revision, the revision is deferred to the last of these services, returned in deferredTo.
+func (at *containerAppTarget) revisionImages(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+) (images []containerapps.ContainerImage, deferredTo string, err error) {
+	images = []containerapps.ContainerImage{
+		{
+			ContainerName: serviceConfig.ContainerApp.Container,
+			Image:         at.env.GetServiceProperty(serviceConfig.Name, "IMAGE_NAME"),
+		},
+	}
+
+	deployedLater := false
+	for _, serviceName := range deployedServices(ctx) {
+		if serviceName == serviceConfig.Name {
+			deployedLater = true
+			continue
+		}
+
+		otherService, has := serviceConfig.Project.Services[serviceName]
+		if !has || otherService.Host != ContainerAppTarget {
+			continue
+		}
+
+		otherResource, err := at.resourceManager.GetTargetResource(ctx, at.env.GetSubscriptionId(), otherService)
+		if err != nil {
+			return nil, "", fmt.Errorf("getting target resource of service '%s': %w", serviceName, err)
+		}
+		if !strings.EqualFold(otherResource.ResourceGroupName(), targetResource.ResourceGroupName()) ||
+			!strings.EqualFold(otherResource.ResourceName(), targetResource.ResourceName()) {
+			continue
+		}
+
+		if serviceConfig.ContainerApp.Container == "" || otherService.ContainerApp.Container == "" ||
+			serviceConfig.ContainerApp.Container == otherService.ContainerApp.Container {
+			return nil, "", fmt.Errorf(
+				"services '%s' and '%s' deploy to the container app '%s' and must each set a different "+
+					"'containerApp.container'",
+				serviceConfig.Name,
+				serviceName,
+				targetResource.ResourceName(),
+			)
+		}
+
+		if deployedLater {
+			deferredTo = serviceName
+			continue
+		}
+
+		images = append(images, containerapps.ContainerImage{
+			ContainerName: otherService.ContainerApp.Container,
+			Image:         at.env.GetServiceProperty(serviceName, "IMAGE_NAME"),
+		})
+	}
+
+	if deferredTo != "" {
+		return nil, deferredTo, nil
+	}
+
+	return images, "", nil
+}
+
+// trafficShift returns the traffic shift of the deployment strategy of the service, nil when all the traffic is sent
+// to the new revision at once.
+func (at *containerAppTarget) trafficShift(
+	serviceConfig *ServiceConfig,
+	progress *async.Progress[ServiceProgress],
+) (*containerapps.TrafficShift, error) {
+	deployment := serviceConfig.ContainerApp.Deployment
+	switch deployment.Strategy {
+	case "", ContainerAppDeploymentAllAtOnce:
+		return nil, nil
+	case ContainerAppDeploymentCanary:
+	default:
+		return nil, fmt.Errorf(
+			"deployment strategy '%s' is not valid. Only '%s' and '%s' are supported",
+			deployment.Strategy,
+			ContainerAppDeploymentAllAtOnce,
+			ContainerAppDeploymentCanary,
+		)
+	}
+
+	configSteps := deployment.Steps
+	if len(configSteps) == 0 {
+		configSteps = defaultCanarySteps
+	}
+
+	steps := make([]containerapps.TrafficShiftStep, len(configSteps))
+	for i, step := range configSteps {
+		if step.Weight < 1 || step.Weight > 100 || (i > 0 && step.Weight <= configSteps[i-1].Weight) {
+			return nil, fmt.Errorf(
+				"traffic step weight '%d' is not valid. Weights must increase from 1 to 100 percent", step.Weight)
+		}
+
+		var pause time.Duration
+		if step.Pause != "" {
+			parsed, err := time.ParseDuration(step.Pause)
+			if err != nil || parsed < 0 {
+				return nil, fmt.Errorf(
+					"traffic step pause '%s' is not valid. Use a duration like '30s' or '5m'", step.Pause)
+			}
+			pause = parsed
+		}
+
+		steps[i] = containerapps.TrafficShiftStep{Weight: step.Weight, Pause: pause}
+	}
+
+	healthCheckTimeout := defaultRevisionHealthCheckTimeout
+	if deployment.HealthCheck != nil && deployment.HealthCheck.Timeout != "" {
+		parsed, err := time.ParseDuration(deployment.HealthCheck.Timeout)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf(
+				"health check timeout '%s' is not valid. Use a positive duration like '30s' or '5m'",
+				deployment.HealthCheck.Timeout,
+			)
+		}
+		healthCheckTimeout = parsed
+	}
+
+	return &containerapps.TrafficShift{
+		Steps: steps,
+		Gate: func(ctx context.Context, status containerapps.TrafficShiftStatus) error {
+			if deployment.HealthCheck != nil {
+				progress.SetProgress(NewServiceProgress(
+					fmt.Sprintf("Checking health of revision %s", status.RevisionName)))
+				if err := at.checkRevisionHealth(
+					ctx, status, deployment.HealthCheck.Path, healthCheckTimeout); err != nil {
+					return err
+				}
+			}
+
+			return at.raiseTrafficShiftEvent(ctx, serviceConfig, status)
+		},
+		Progress: func(message string) {
+			progress.SetProgress(NewServiceProgress(message))
+		},
+	}, nil
+}
+
+// checkRevisionHealth requests the health check path on the revision until it responds with a 2xx status code, or the
+// timeout elapses.
+func (at *containerAppTarget) checkRevisionHealth(
+	ctx context.Context,
+	status containerapps.TrafficShiftStatus,
+	path string,
+	timeout time.Duration,
+) error {
+	if status.RevisionFqdn == "" {
+		return fmt.Errorf("revision '%s' has no ingress to check its health", status.RevisionName)
+	}
+
+	healthUrl := fmt.Sprintf("https://%s/%s", status.RevisionFqdn, strings.TrimPrefix(path, "/"))
+	err := retry.Do(
+		ctx,
+		retry.WithMaxDuration(timeout, retry.NewConstant(defaultRevisionHealthCheckInterval)),
+		func(ctx context.Context) error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthUrl, nil)
+			if err != nil {
+				return err
+			}
+
+			res, err := at.httpClient.Do(req)
+			if err != nil {
+				return retry.RetryableError(err)
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode < 200 || res.StatusCode > 299 {
+				return retry.RetryableError(fmt.Errorf("responded with status code %d", res.StatusCode))
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("health check '%s' failed: %w", healthUrl, err)
+	}
+
+	return nil
+}
+
+// raiseTrafficShiftEvent runs the traffic shift hooks of the service. The revision and the percentage of traffic it
+// receives are passed to the hooks as service properties, which are not saved in the environment.
+func (at *containerAppTarget) raiseTrafficShiftEvent(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	status containerapps.TrafficShiftStatus,
+) error {
+	servicePrefix := fmt.Sprintf("SERVICE_%s_", environment.Key(serviceConfig.Name))
+
+	return serviceConfig.RaiseEvent(
+		ctx,
+		ext.Event("post"+ServiceEventTrafficShift),
+		ServiceLifecycleEventArgs{
+			Project: serviceConfig.Project,
+			Service: serviceConfig,
+			Args: map[string]any{
+				ServiceEventEnvArg: map[string]string{
+					servicePrefix + "REVISION_NAME":  status.RevisionName,
+					servicePrefix + "REVISION_FQDN":  status.RevisionFqdn,
+					servicePrefix + "TRAFFIC_WEIGHT": strconv.Itoa(int(status.Weight)),
+				},
+			},
+		},
+	)
+}
@@ -5,9 +5,12 @@ package project
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
@@ -28,6 +31,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
 	"github.com/azure/azure-dev/cli/azd/test/ostest"
 	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -179,6 +183,7 @@ func createContainerAppServiceTarget(
 		containerHelper,
 		containerAppService,
 		resourceManager,
+		mockContext.HttpClient,
 	)
 }
 
@@ -254,3 +259,248 @@ func setupMocksForContainerApps(mockContext *mocks.MockContext) {
 	mockazsdk.MockContainerAppUpdate(mockContext, subscriptionId, resourceGroup, appName, containerApp)
 	mockazsdk.MockContainerRegistryTokenExchange(mockContext, subscriptionId, subscriptionId, "REFRESH_TOKEN")
 }
+
+func Test_ContainerApp_TrafficShift(t *testing.T) {
+	status := containerapps.TrafficShiftStatus{
+		TrafficShiftStep: containerapps.TrafficShiftStep{Weight: 10},
+		RevisionName:     "api--azd-1",
+		RevisionFqdn:     "api--azd-1.eastus2.azurecontainerapps.io",
+	}
+
+	setup := func(t *testing.T, deployment ContainerAppDeploymentOptions) (
+		*mocks.MockContext, *environment.Environment, *ServiceConfig, *containerAppTarget) {
+		mockContext := mocks.NewMockContext(context.Background())
+		env := createEnv()
+		serviceConfig := createTestServiceConfig(t.TempDir(), ContainerAppTarget, ServiceLanguageTypeScript)
+		serviceConfig.ContainerApp.Deployment = deployment
+
+		serviceTarget := createContainerAppServiceTarget(mockContext, env).(*containerAppTarget)
+		return mockContext, env, serviceConfig, serviceTarget
+	}
+
+	t.Run("AllAtOnce", func(t *testing.T) {
+		_, _, serviceConfig, serviceTarget := setup(t, ContainerAppDeploymentOptions{})
+
+		trafficShift, err := serviceTarget.trafficShift(serviceConfig, async.NewProgress[ServiceProgress]())
+		require.NoError(t, err)
+		require.Nil(t, trafficShift)
+	})
+
+	t.Run("DefaultSteps", func(t *testing.T) {
+		_, _, serviceConfig, serviceTarget := setup(t, ContainerAppDeploymentOptions{
+			Strategy: ContainerAppDeploymentCanary,
+		})
+
+		trafficShift, err := serviceTarget.trafficShift(serviceConfig, async.NewProgress[ServiceProgress]())
+		require.NoError(t, err)
+		require.Equal(t, []containerapps.TrafficShiftStep{
+			{Weight: 10, Pause: time.Minute},
+			{Weight: 50, Pause: time.Minute},
+			{Weight: 100},
+		}, trafficShift.Steps)
+	})
+
+	t.Run("InvalidSteps", func(t *testing.T) {
+		tests := map[string]ContainerAppDeploymentOptions{
+			"deployment strategy 'blueGreen' is not valid": {Strategy: "blueGreen"},
+			"traffic step weight '50' is not valid": {
+				Strategy: ContainerAppDeploymentCanary,
+				Steps:    []ContainerAppTrafficStep{{Weight: 50}, {Weight: 50}},
+			},
+			"traffic step pause 'soon' is not valid": {
+				Strategy: ContainerAppDeploymentCanary,
+				Steps:    []ContainerAppTrafficStep{{Weight: 50, Pause: "soon"}},
+			},
+			"health check timeout '0s' is not valid": {
+				Strategy:    ContainerAppDeploymentCanary,
+				HealthCheck: &ContainerAppHealthCheck{Path: "/health", Timeout: "0s"},
+			},
+		}
+
+		for expectedErr, deployment := range tests {
+			_, _, serviceConfig, serviceTarget := setup(t, deployment)
+			_, err := serviceTarget.trafficShift(serviceConfig, async.NewProgress[ServiceProgress]())
+			require.ErrorContains(t, err, expectedErr)
+		}
+	})
+
+	t.Run("Gate", func(t *testing.T) {
+		mockContext, env, serviceConfig, serviceTarget := setup(t, ContainerAppDeploymentOptions{
+			Strategy:    ContainerAppDeploymentCanary,
+			HealthCheck: &ContainerAppHealthCheck{Path: "/health"},
+		})
+
+		var healthUrl string
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.URL.Host == status.RevisionFqdn
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			healthUrl = request.URL.String()
+			return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+		})
+
+		var hookWeight string
+		err := serviceConfig.AddHandler(
+			"posttrafficshift",
+			func(ctx context.Context, args ServiceLifecycleEventArgs) error {
+				hookWeight = args.Args[ServiceEventEnvArg].(map[string]string)["SERVICE_API_TRAFFIC_WEIGHT"]
+				return nil
+			},
+		)
+		require.NoError(t, err)
+
+		err = runTrafficShiftGate(t, *mockContext.Context, serviceTarget, serviceConfig, status)
+		require.NoError(t, err)
+		require.Equal(t, "https://api--azd-1.eastus2.azurecontainerapps.io/health", healthUrl)
+		require.Equal(t, "10", hookWeight)
+		// the revision is only passed to the hooks
+		require.Empty(t, env.GetServiceProperty("api", "REVISION_NAME"))
+	})
+
+	t.Run("GateHookFails", func(t *testing.T) {
+		mockContext, _, serviceConfig, serviceTarget := setup(t, ContainerAppDeploymentOptions{
+			Strategy: ContainerAppDeploymentCanary,
+		})
+
+		err := serviceConfig.AddHandler(
+			"posttrafficshift",
+			func(ctx context.Context, args ServiceLifecycleEventArgs) error {
+				return errors.New("error rate too high")
+			},
+		)
+		require.NoError(t, err)
+
+		err = runTrafficShiftGate(t, *mockContext.Context, serviceTarget, serviceConfig, status)
+		require.ErrorContains(t, err, "error rate too high")
+	})
+
+	t.Run("UnhealthyRevision", func(t *testing.T) {
+		mockContext, _, serviceConfig, serviceTarget := setup(t, ContainerAppDeploymentOptions{
+			Strategy:    ContainerAppDeploymentCanary,
+			HealthCheck: &ContainerAppHealthCheck{Path: "/health", Timeout: "10ms"},
+		})
+
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.URL.Host == status.RevisionFqdn
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return mocks.CreateEmptyHttpResponse(request, http.StatusServiceUnavailable)
+		})
+
+		err := runTrafficShiftGate(t, *mockContext.Context, serviceTarget, serviceConfig, status)
+		require.ErrorContains(t, err, "responded with status code 503")
+	})
+}
+
+// runTrafficShiftGate runs the gate of the traffic shift of the service for the status.
+func runTrafficShiftGate(
+	t *testing.T,
+	ctx context.Context,
+	serviceTarget *containerAppTarget,
+	serviceConfig *ServiceConfig,
+	status containerapps.TrafficShiftStatus,
+) error {
+	_, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (bool, error) {
+		trafficShift, err := serviceTarget.trafficShift(serviceConfig, progress)
+		if err != nil {
+			return false, err
+		}
+		return true, trafficShift.Gate(ctx, status)
+	})
+	return err
+}
+
+func Test_ContainerApp_RevisionImages(t *testing.T) {
+	ctx := context.Background()
+	env := environment.NewWithValues("test", map[string]string{
+		environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+		"SERVICE_API_IMAGE_NAME":             "REGISTRY.azurecr.io/api:azd-deploy-1",
+		"SERVICE_OTEL_IMAGE_NAME":            "REGISTRY.azurecr.io/otel:azd-deploy-1",
+		"SERVICE_WEB_IMAGE_NAME":             "REGISTRY.azurecr.io/web:azd-deploy-1",
+	})
+
+	appResource := environment.NewTargetResource(
+		"SUBSCRIPTION_ID", "RESOURCE_GROUP", "app", string(azapi.AzureResourceTypeContainerApp))
+	webResource := environment.NewTargetResource(
+		"SUBSCRIPTION_ID", "RESOURCE_GROUP", "web", string(azapi.AzureResourceTypeContainerApp))
+
+	newProject := func(apiContainer string) *ProjectConfig {
+		projectConfig := &ProjectConfig{Services: map[string]*ServiceConfig{}}
+		for _, service := range []*ServiceConfig{
+			{Name: "api", Host: ContainerAppTarget, ContainerApp: ContainerAppOptions{Container: apiContainer}},
+			{Name: "otel", Host: ContainerAppTarget, ContainerApp: ContainerAppOptions{Container: "otel-collector"}},
+			{Name: "web", Host: ContainerAppTarget},
+			{Name: "func", Host: AzureFunctionTarget},
+		} {
+			service.Project = projectConfig
+			projectConfig.Services[service.Name] = service
+		}
+		return projectConfig
+	}
+
+	newTarget := func(projectConfig *ProjectConfig) *containerAppTarget {
+		resourceManager := &MockResourceManager{}
+		for _, name := range []string{"api", "otel"} {
+			resourceManager.
+				On("GetTargetResource", mock.Anything, "SUBSCRIPTION_ID", projectConfig.Services[name]).
+				Return(appResource, nil)
+		}
+		resourceManager.
+			On("GetTargetResource", mock.Anything, "SUBSCRIPTION_ID", projectConfig.Services["web"]).
+			Return(webResource, nil)
+
+		return &containerAppTarget{env: env, resourceManager: resourceManager}
+	}
+
+	deployCtx := WithDeployedServices(ctx, []string{"api", "otel", "web", "func"})
+
+	t.Run("SingleService", func(t *testing.T) {
+		projectConfig := newProject("api")
+		images, deferredTo, err := newTarget(projectConfig).revisionImages(ctx, projectConfig.Services["api"], appResource)
+		require.NoError(t, err)
+		require.Empty(t, deferredTo)
+		require.Equal(t, []containerapps.ContainerImage{
+			{ContainerName: "api", Image: "REGISTRY.azurecr.io/api:azd-deploy-1"},
+		}, images)
+	})
+
+	t.Run("DeferredToLastService", func(t *testing.T) {
+		projectConfig := newProject("api")
+		images, deferredTo, err := newTarget(projectConfig).
+			revisionImages(deployCtx, projectConfig.Services["api"], appResource)
+		require.NoError(t, err)
+		require.Equal(t, "otel", deferredTo)
+		require.Empty(t, images)
+	})
+
+	t.Run("LastServiceDeploysAllImages", func(t *testing.T) {
+		projectConfig := newProject("api")
+		images, deferredTo, err := newTarget(projectConfig).
+			revisionImages(deployCtx, projectConfig.Services["otel"], appResource)
+		require.NoError(t, err)
+		require.Empty(t, deferredTo)
+		require.Equal(t, []containerapps.ContainerImage{
+			{ContainerName: "otel-collector", Image: "REGISTRY.azurecr.io/otel:azd-deploy-1"},
+			{ContainerName: "api", Image: "REGISTRY.azurecr.io/api:azd-deploy-1"},
+		}, images)
+	})
+
+	t.Run("OtherContainerApp", func(t *testing.T) {
+		projectConfig := newProject("api")
+		images, deferredTo, err := newTarget(projectConfig).
+			revisionImages(deployCtx, projectConfig.Services["web"], webResource)
+		require.NoError(t, err)
+		require.Empty(t, deferredTo)
+		require.Equal(t, []containerapps.ContainerImage{
+			{Image: "REGISTRY.azurecr.io/web:azd-deploy-1"},
+		}, images)
+	})
+
+	t.Run("MissingContainer", func(t *testing.T) {
+		projectConfig := newProject("")
+		_, _, err := newTarget(projectConfig).revisionImages(deployCtx, projectConfig.Services["otel"], appResource)
+		require.ErrorContains(
+			t,
+			err,
+			"services 'otel' and 'api' deploy to the container app 'app' and must each set a different 'containerApp.container'",
+		)
+	})
+}
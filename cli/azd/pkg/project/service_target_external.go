@@ -0,0 +1,62 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ExternalServiceTargetRegistry stores the service targets that are provided outside of azd, such as by extensions,
+// for host kinds that azd doesn't support.
+type ExternalServiceTargetRegistry struct {
+	targets map[ServiceTargetKind]ServiceTarget
+	mu      sync.RWMutex
+}
+
+// NewExternalServiceTargetRegistry creates a new, empty instance of the ExternalServiceTargetRegistry
+func NewExternalServiceTargetRegistry() *ExternalServiceTargetRegistry {
+	return &ExternalServiceTargetRegistry{
+		targets: map[ServiceTargetKind]ServiceTarget{},
+	}
+}
+
+// Register registers the service target for the specified host kind.
+// Host kinds that azd provides, or that have already been registered, can't be registered.
+func (r *ExternalServiceTargetRegistry) Register(kind ServiceTargetKind, target ServiceTarget) error {
+	if kind.IsBuiltIn() {
+		return fmt.Errorf("host '%s' is provided by azd and can't be registered", kind)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, has := r.targets[kind]; has {
+		return fmt.Errorf("host '%s' has already been registered", kind)
+	}
+
+	r.targets[kind] = target
+
+	return nil
+}
+
+// Unregister removes the service target registered for the specified host kind,
+// when it's still the registered service target of the host kind.
+func (r *ExternalServiceTargetRegistry) Unregister(kind ServiceTargetKind, target ServiceTarget) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if registered, has := r.targets[kind]; has && registered == target {
+		delete(r.targets, kind)
+	}
+}
+
+// Get returns the service target registered for the specified host kind
+func (r *ExternalServiceTargetRegistry) Get(kind ServiceTargetKind) (ServiceTarget, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	target, has := r.targets[kind]
+	return target, has
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExternalServiceTargetRegistry(t *testing.T) {
+	t.Run("Register", func(t *testing.T) {
+		registry := NewExternalServiceTargetRegistry()
+		target := &fakeServiceTarget{}
+
+		err := registry.Register("azure.batch", target)
+		require.NoError(t, err)
+
+		actual, has := registry.Get("azure.batch")
+		require.True(t, has)
+		require.Same(t, target, actual)
+
+		_, has = registry.Get("azure.vmss")
+		require.False(t, has)
+	})
+
+	t.Run("AlreadyRegistered", func(t *testing.T) {
+		registry := NewExternalServiceTargetRegistry()
+		require.NoError(t, registry.Register("azure.batch", &fakeServiceTarget{}))
+
+		err := registry.Register("azure.batch", &fakeServiceTarget{})
+		require.ErrorContains(t, err, "host 'azure.batch' has already been registered")
+	})
+
+	t.Run("Unregister", func(t *testing.T) {
+		registry := NewExternalServiceTargetRegistry()
+		target := &fakeServiceTarget{}
+		require.NoError(t, registry.Register("azure.batch", target))
+
+		// Only the registered service target of the host is removed
+		registry.Unregister("azure.batch", &fakeServiceTarget{})
+		_, has := registry.Get("azure.batch")
+		require.True(t, has)
+
+		registry.Unregister("azure.batch", target)
+		_, has = registry.Get("azure.batch")
+		require.False(t, has)
+	})
+
+	t.Run("BuiltInHost", func(t *testing.T) {
+		registry := NewExternalServiceTargetRegistry()
+
+		for _, kind := range []ServiceTargetKind{AppServiceTarget, ContainerAppTarget, DotNetContainerAppTarget} {
+			err := registry.Register(kind, &fakeServiceTarget{})
+			require.ErrorContains(t, err, "is provided by azd")
+		}
+	})
+}
+
+func Test_parseServiceHost_External(t *testing.T) {
+	kind, err := parseServiceHost("azure.batch")
+	require.NoError(t, err)
+	require.Equal(t, ServiceTargetKind("azure.batch"), kind)
+	require.False(t, kind.IsBuiltIn())
+
+	_, err = parseServiceHost(DotNetContainerAppTarget)
+	require.Error(t, err)
+
+	_, err = parseServiceHost("")
+	require.ErrorContains(t, err, "unsupported host ''")
+
+	_, err = parseServiceHost("containerap")
+	require.ErrorContains(t, err, "unsupported host 'containerap': did you mean 'containerapp'?")
+}
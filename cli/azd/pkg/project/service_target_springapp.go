@@ -4,12 +4,21 @@
 package project
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appplatform/armappplatform/v2"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
@@ -19,18 +28,53 @@ import (
 
 const (
 	defaultDeploymentName = "default"
+	defaultBuilderName    = "default"
+)
+
+// SpringSource is how the service is deployed to Azure Spring Apps.
+type SpringSource string
+
+const (
+	// The jar or war file produced when packaging the service is uploaded. This is the default.
+	SpringSourceJar SpringSource = "jar"
+	// A container image is built and pushed to the container registry of the environment.
+	SpringSourceContainer SpringSource = "container"
+	// The source code of the service is uploaded and built by the build service of an Enterprise tier instance.
+	SpringSourceSource SpringSource = "source"
 )
 
 // The Azure Spring Apps configuration options
 type SpringOptions struct {
 	// The deployment name of ASA app
 	DeploymentName string `yaml:"deploymentName"`
+	// How the service is deployed: jar (default), container or source
+	Source SpringSource `yaml:"source,omitempty"`
+	// The builder of the build service that builds source deployments. Defaults to 'default'.
+	Builder string `yaml:"builder,omitempty"`
+	// When set, new versions are deployed to the deployment that is not active, which is then made active once healthy.
+	BlueGreen *SpringBlueGreenOptions `yaml:"blueGreen,omitempty"`
 }
 
+// SpringBlueGreenOptions configures blue-green deployments of an Azure Spring Apps app.
+type SpringBlueGreenOptions struct {
+	// The two deployments that take turns being active. Defaults to 'blue' and 'green'.
+	Deployments []string `yaml:"deployments,omitempty"`
+	// How long to wait for the new deployment to be healthy before making it active, such as '5m'. Defaults to 10m.
+	HealthCheckTimeout string `yaml:"healthCheckTimeout,omitempty"`
+}
+
+var defaultBlueGreenDeployments = []string{"blue", "green"}
+
+const defaultHealthCheckTimeout = 10 * time.Minute
+
+// springHealthCheckInterval is the delay between checks of the instances of a new deployment
+var springHealthCheckInterval = 10 * time.Second
+
 type springAppTarget struct {
-	env           *environment.Environment
-	envManager    environment.Manager
-	springService azapi.SpringService
+	env             *environment.Environment
+	envManager      environment.Manager
+	containerHelper *ContainerHelper
+	springService   azapi.SpringService
 }
 
 // NewSpringAppTarget creates the spring app service target.
@@ -40,20 +84,46 @@ type springAppTarget struct {
 func NewSpringAppTarget(
 	env *environment.Environment,
 	envManager environment.Manager,
+	containerHelper *ContainerHelper,
 	springService azapi.SpringService,
 ) ServiceTarget {
 	return &springAppTarget{
-		env:           env,
-		envManager:    envManager,
-		springService: springService,
+		env:             env,
+		envManager:      envManager,
+		containerHelper: containerHelper,
+		springService:   springService,
 	}
 }
 
 func (st *springAppTarget) RequiredExternalTools(ctx context.Context, serviceConfig *ServiceConfig) []tools.ExternalTool {
+	if serviceConfig.Spring.Source == SpringSourceContainer {
+		return st.containerHelper.RequiredExternalTools(ctx, serviceConfig)
+	}
+
 	return []tools.ExternalTool{}
 }
 
 func (st *springAppTarget) Initialize(ctx context.Context, serviceConfig *ServiceConfig) error {
+	switch serviceConfig.Spring.Source {
+	case "", SpringSourceJar, SpringSourceContainer, SpringSourceSource:
+	default:
+		return fmt.Errorf(
+			"unsupported spring source '%s' for service '%s', expected one of: jar, container, source",
+			serviceConfig.Spring.Source,
+			serviceConfig.Name,
+		)
+	}
+
+	if blueGreen := serviceConfig.Spring.BlueGreen; blueGreen != nil {
+		if len(blueGreen.Deployments) != 0 && len(blueGreen.Deployments) != 2 {
+			return fmt.Errorf("spring.blueGreen.deployments of service '%s' must list two deployments", serviceConfig.Name)
+		}
+
+		if _, err := blueGreen.healthCheckTimeout(); err != nil {
+			return fmt.Errorf("invalid spring.blueGreen.healthCheckTimeout of service '%s': %w", serviceConfig.Name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -67,7 +137,8 @@ func (st *springAppTarget) Package(
 	return packageOutput, nil
 }
 
-// Upload artifact to Storage File and deploy to Spring App
+// Deploys the service to a deployment of the Spring App. The deployment source is uploaded from the package output, built
+// by the build service, or pushed as a container image, depending on the spring source of the service.
 func (st *springAppTarget) Deploy(
 	ctx context.Context,
 	serviceConfig *ServiceConfig,
@@ -79,30 +150,134 @@ func (st *springAppTarget) Deploy(
 		return nil, fmt.Errorf("validating target resource: %w", err)
 	}
 
+	blueGreen := serviceConfig.Spring.BlueGreen
 	deploymentName := serviceConfig.Spring.DeploymentName
-	if deploymentName == "" {
-		deploymentName = defaultDeploymentName
+	activate := true
+	if blueGreen != nil {
+		staging, hasActive, err := st.stagingDeployment(ctx, serviceConfig, targetResource)
+		if err != nil {
+			return nil, err
+		}
+
+		deploymentName = staging
+		// the first deployment of the app is made active directly, since there is no traffic to protect
+		activate = !hasActive
+	} else {
+		if deploymentName == "" {
+			deploymentName = defaultDeploymentName
+		}
+
+		_, err := st.springService.GetSpringAppDeployment(
+			ctx,
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+			serviceConfig.Name,
+			deploymentName,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf(
+				"get deployment '%s' of Spring App '%s' failed: %w", serviceConfig.Name, deploymentName, err)
+		}
+	}
+
+	var source armappplatform.UserSourceInfoClassification
+	var err error
+	switch serviceConfig.Spring.Source {
+	case SpringSourceContainer:
+		source, err = st.containerSource(ctx, serviceConfig, packageOutput, targetResource, progress)
+	case SpringSourceSource:
+		source, err = st.buildSource(ctx, serviceConfig, targetResource, progress)
+	default:
+		source, err = st.artifactSource(ctx, serviceConfig, packageOutput, targetResource, progress)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := st.springService.GetSpringAppDeployment(
+	progress.SetProgress(NewServiceProgress(fmt.Sprintf("Deploying to spring app deployment '%s'", deploymentName)))
+	res, err := st.springService.CreateOrUpdateSpringAppDeployment(
 		ctx,
 		targetResource.SubscriptionId(),
 		targetResource.ResourceGroupName(),
 		targetResource.ResourceName(),
 		serviceConfig.Name,
 		deploymentName,
+		source,
 	)
+	if err != nil {
+		return nil, fmt.Errorf("deploying service %s: %w", serviceConfig.Name, err)
+	}
+
+	if !activate {
+		timeout, err := blueGreen.healthCheckTimeout()
+		if err != nil {
+			return nil, err
+		}
+
+		progress.SetProgress(
+			NewServiceProgress(fmt.Sprintf("Waiting for spring app deployment '%s' to be healthy", deploymentName)))
+		if err := st.waitForHealthy(ctx, serviceConfig, targetResource, deploymentName, timeout); err != nil {
+			return nil, fmt.Errorf(
+				"deployment '%s' of Spring App '%s' is not healthy, the active deployment was kept: %w",
+				deploymentName, serviceConfig.Name, err)
+		}
+	}
+
+	progress.SetProgress(NewServiceProgress(fmt.Sprintf("Activating spring app deployment '%s'", deploymentName)))
+	err = st.springService.SetSpringAppActiveDeployment(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		serviceConfig.Name,
+		deploymentName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("activating deployment '%s' of service %s: %w", deploymentName, serviceConfig.Name, err)
+	}
 
+	progress.SetProgress(NewServiceProgress("Fetching endpoints for spring app service"))
+	endpoints, err := st.Endpoints(ctx, serviceConfig, targetResource)
 	if err != nil {
-		return nil, fmt.Errorf("get deployment '%s' of Spring App '%s' failed: %w", serviceConfig.Name, deploymentName, err)
+		return nil, err
 	}
 
-	// TODO: Consider support container image and buildpacks deployment in the future
-	// For now, Azure Spring Apps only support jar deployment
-	ext := ".jar"
-	artifactPath := filepath.Join(packageOutput.PackagePath, AppServiceJavaPackageName+ext)
+	sdr := NewServiceDeployResult(
+		azure.SpringAppRID(
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+		),
+		SpringAppTarget,
+		*res,
+		endpoints,
+	)
+	sdr.Package = packageOutput
+
+	return sdr, nil
+}
 
-	_, err = os.Stat(artifactPath)
+// artifactSource uploads the jar or war file of the package output.
+func (st *springAppTarget) artifactSource(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	packageOutput *ServicePackageResult,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (armappplatform.UserSourceInfoClassification, error) {
+	sourceType := "Jar"
+	artifactPath := filepath.Join(packageOutput.PackagePath, AppServiceJavaPackageName+".jar")
+	if _, err := os.Stat(artifactPath); errors.Is(err, os.ErrNotExist) {
+		warPath := filepath.Join(packageOutput.PackagePath, AppServiceJavaPackageName+".war")
+		if _, err := os.Stat(warPath); err == nil {
+			sourceType = "War"
+			artifactPath = warPath
+		}
+	}
+
+	_, err := os.Stat(artifactPath)
 	if errors.Is(err, os.ErrNotExist) {
 		return nil, fmt.Errorf("artifact %s does not exist: %w", artifactPath, err)
 	}
@@ -125,47 +300,335 @@ func (st *springAppTarget) Deploy(
 		return nil, fmt.Errorf("failed to upload spring artifact: %w", err)
 	}
 
-	progress.SetProgress(NewServiceProgress("Deploying spring artifact"))
+	// save the storage relative, otherwise the relative path will be overwritten
+	// in the deployment from Bicep/Terraform
+	st.env.SetServiceProperty(serviceConfig.Name, "RELATIVE_PATH", *relativePath)
+	if err := st.envManager.Save(ctx, st.env); err != nil {
+		return nil, fmt.Errorf("failed updating environment with relative path, %w", err)
+	}
 
-	res, err := st.springService.DeploySpringAppArtifact(
+	if sourceType == "War" {
+		return &armappplatform.UploadedUserSourceInfo{
+			Type:         to.Ptr(sourceType),
+			RelativePath: relativePath,
+		}, nil
+	}
+
+	return &armappplatform.JarUploadedUserSourceInfo{
+		Type:         to.Ptr(sourceType),
+		RelativePath: relativePath,
+	}, nil
+}
+
+// containerSource builds the container image of the service and pushes it to the container registry.
+//
+// Azure Spring Apps pulls the image with the credentials of the registry, which must have the admin user enabled.
+func (st *springAppTarget) containerSource(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	packageOutput *ServicePackageResult,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (armappplatform.UserSourceInfoClassification, error) {
+	// Login, tag & push container image to ACR
+	deployResult, err := st.containerHelper.Deploy(ctx, serviceConfig, packageOutput, targetResource, true, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteImage := st.env.GetServiceProperty(serviceConfig.Name, "IMAGE_NAME")
+	if details, ok := deployResult.Details.(*dockerDeployResult); ok {
+		remoteImage = details.RemoteImageTag
+	}
+
+	server, image, found := strings.Cut(remoteImage, "/")
+	if !found {
+		return nil, fmt.Errorf("image '%s' does not include a container registry", remoteImage)
+	}
+
+	progress.SetProgress(NewServiceProgress("Fetching container registry credentials"))
+	credentials, err := st.containerHelper.Credentials(ctx, serviceConfig, targetResource)
+	if err != nil {
+		return nil, fmt.Errorf("getting container registry credentials: %w", err)
+	}
+
+	return &armappplatform.CustomContainerUserSourceInfo{
+		Type: to.Ptr("Container"),
+		CustomContainer: &armappplatform.CustomContainer{
+			Server:         to.Ptr(server),
+			ContainerImage: to.Ptr(image),
+			ImageRegistryCredential: &armappplatform.ImageRegistryCredential{
+				Username: to.Ptr(credentials.Username),
+				Password: to.Ptr(credentials.Password),
+			},
+		},
+	}, nil
+}
+
+// buildSource uploads the source code of the service to the build service of an Enterprise tier instance, and builds it.
+func (st *springAppTarget) buildSource(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (armappplatform.UserSourceInfoClassification, error) {
+	tier, err := st.springService.GetSpringServiceTier(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(tier, "Enterprise") {
+		return nil, fmt.Errorf(
+			"spring source 'source' of service '%s' requires the build service of an Enterprise tier instance, "+
+				"but '%s' is %s tier", serviceConfig.Name, targetResource.ResourceName(), tier)
+	}
+
+	progress.SetProgress(NewServiceProgress("Packing spring source"))
+	sourcePath, err := packSpringSource(serviceConfig.Path())
+	if sourcePath != "" {
+		defer os.Remove(sourcePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("packing source of service %s: %w", serviceConfig.Name, err)
+	}
+
+	progress.SetProgress(NewServiceProgress("Uploading spring source"))
+	relativePath, err := st.springService.UploadSpringBuildSource(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		sourcePath,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload spring source: %w", err)
+	}
+
+	builder := serviceConfig.Spring.Builder
+	if builder == "" {
+		builder = defaultBuilderName
+	}
+
+	progress.SetProgress(NewServiceProgress("Building spring source"))
+	buildResultId, err := st.springService.BuildSpringAppSource(
 		ctx,
 		targetResource.SubscriptionId(),
 		targetResource.ResourceGroupName(),
 		targetResource.ResourceName(),
 		serviceConfig.Name,
 		*relativePath,
-		deploymentName,
+		builder,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("deploying service %s: %w", serviceConfig.Name, err)
+		return nil, err
 	}
 
-	// save the storage relative, otherwise the relative path will be overwritten
-	// in the deployment from Bicep/Terraform
-	st.env.SetServiceProperty(serviceConfig.Name, "RELATIVE_PATH", *relativePath)
-	if err := st.envManager.Save(ctx, st.env); err != nil {
-		return nil, fmt.Errorf("failed updating environment with relative path, %w", err)
+	return &armappplatform.BuildResultUserSourceInfo{
+		Type:          to.Ptr("BuildResult"),
+		BuildResultID: buildResultId,
+	}, nil
+}
+
+// stagingDeployment returns the blue-green deployment that is not active, and whether the other one is active.
+func (st *springAppTarget) stagingDeployment(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+) (string, bool, error) {
+	names := serviceConfig.Spring.BlueGreen.Deployments
+	if len(names) == 0 {
+		names = defaultBlueGreenDeployments
 	}
 
-	progress.SetProgress(NewServiceProgress("Fetching endpoints for spring app service"))
-	endpoints, err := st.Endpoints(ctx, serviceConfig, targetResource)
+	deployments, err := st.springService.ListSpringAppDeployments(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		serviceConfig.Name,
+	)
 	if err != nil {
-		return nil, err
+		return "", false, err
 	}
 
-	sdr := NewServiceDeployResult(
-		azure.SpringAppRID(
+	for _, deployment := range deployments {
+		if !deployment.Active {
+			continue
+		}
+
+		switch deployment.Name {
+		case names[0]:
+			return names[1], true, nil
+		case names[1]:
+			return names[0], true, nil
+		default:
+			// The app is served by a deployment outside of the blue-green pair, such as the one created with the app.
+			// Traffic moves to the blue-green pair once the first of them is healthy.
+			return names[0], true, nil
+		}
+	}
+
+	return names[0], false, nil
+}
+
+// waitForHealthy waits until all the instances of the deployment are running and, for apps registered with a service
+// registry, discovered as up.
+func (st *springAppTarget) waitForHealthy(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	deploymentName string,
+	timeout time.Duration,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		deployments, err := st.springService.ListSpringAppDeployments(
+			ctx,
 			targetResource.SubscriptionId(),
 			targetResource.ResourceGroupName(),
 			targetResource.ResourceName(),
-		),
-		SpringAppTarget,
-		*res,
-		endpoints,
-	)
-	sdr.Package = packageOutput
+			serviceConfig.Name,
+		)
+		if err != nil {
+			return err
+		}
 
-	return sdr, nil
+		idx := slices.IndexFunc(deployments, func(d azapi.SpringAppDeployment) bool {
+			return d.Name == deploymentName
+		})
+		if idx == -1 {
+			return fmt.Errorf("deployment '%s' was not found", deploymentName)
+		}
+
+		healthy, err := isSpringDeploymentHealthy(deployments[idx])
+		if err != nil {
+			return err
+		}
+		if healthy {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s waiting for instances to be running", timeout)
+		case <-time.After(springHealthCheckInterval):
+		}
+	}
+}
+
+// isSpringDeploymentHealthy returns true when all the instances of the deployment are running. An error is returned
+// when an instance failed, since waiting longer will not make the deployment healthy.
+func isSpringDeploymentHealthy(deployment azapi.SpringAppDeployment) (bool, error) {
+	if len(deployment.Instances) == 0 {
+		return false, nil
+	}
+
+	for _, instance := range deployment.Instances {
+		switch instance.Status {
+		case "Running":
+		case "Failed":
+			return false, fmt.Errorf("instance %s failed: %s", instance.Name, instance.Reason)
+		default:
+			return false, nil
+		}
+
+		switch instance.DiscoveryStatus {
+		case "", "UP", "N/A", "UNREGISTERED":
+		default:
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (o *SpringBlueGreenOptions) healthCheckTimeout() (time.Duration, error) {
+	if o.HealthCheckTimeout == "" {
+		return defaultHealthCheckTimeout, nil
+	}
+
+	return time.ParseDuration(o.HealthCheckTimeout)
+}
+
+// packSpringSource creates a tarball of the source code at root into a temporary file, and returns the path to it.
+// Build outputs and the .git folder are excluded, since the build service builds the code itself.
+func packSpringSource(root string) (string, error) {
+	archive, err := os.CreateTemp("", "azd-spring-source*.tar.gz")
+	if err != nil {
+		return "", err
+	}
+	defer archive.Close()
+
+	gw := gzip.NewWriter(archive)
+	tw := tar.NewWriter(gw)
+
+	err = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == root {
+			return nil
+		}
+
+		if d.IsDir() {
+			switch d.Name() {
+			case ".git", "target", "build", ".gradle":
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		hdr, err := tar.FileInfoHeader(info, info.Name())
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return archive.Name(), err
+	}
+
+	if err := tw.Close(); err != nil {
+		return archive.Name(), err
+	}
+
+	return archive.Name(), gw.Close()
 }
 
 // Gets the exposed endpoints for the Spring Apps Service
@@ -188,6 +651,71 @@ func (st *springAppTarget) Endpoints(
 	return springAppProperties.Url, nil
 }
 
+// Logs streams the console logs of the instances of the active deployment of the Spring app, each line prefixed with the
+// instance it comes from.
+func (st *springAppTarget) Logs(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	options *ServiceLogsOptions,
+	writer io.Writer,
+) error {
+	if err := st.validateTargetResource(targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	deployments, err := st.springService.ListSpringAppDeployments(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		serviceConfig.Name,
+	)
+	if err != nil {
+		return err
+	}
+
+	idx := slices.IndexFunc(deployments, func(d azapi.SpringAppDeployment) bool {
+		return d.Active
+	})
+	if idx == -1 || len(deployments[idx].Instances) == 0 {
+		return fmt.Errorf("spring app '%s' has no running instance", serviceConfig.Name)
+	}
+
+	instances := deployments[idx].Instances
+	lineWriter := &syncWriter{writer: writer}
+	errs := make([]error, len(instances))
+	var wg sync.WaitGroup
+	for i, instance := range instances {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			stream, err := st.springService.OpenSpringAppLogStream(
+				ctx,
+				targetResource.SubscriptionId(),
+				targetResource.ResourceGroupName(),
+				targetResource.ResourceName(),
+				serviceConfig.Name,
+				instance.Name,
+				&azapi.SpringAppLogStreamOptions{
+					Follow: options.Follow,
+					Since:  options.Since,
+				},
+			)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			errs[i] = copyLogLines(ctx, stream, lineWriter, fmt.Sprintf("[%s] ", instance.Name), 0)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
 func (st *springAppTarget) validateTargetResource(
 	targetResource *environment.TargetResource,
 ) error {
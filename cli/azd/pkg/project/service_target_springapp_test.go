@@ -4,11 +4,24 @@
 package project
 
 import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appplatform/armappplatform/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/azure/azure-dev/cli/azd/test/ostest"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -53,3 +66,314 @@ func TestNewSpringAppTargetTypeValidation(t *testing.T) {
 		})
 	}
 }
+
+func Test_SpringAppTarget_Deploy(t *testing.T) {
+	targetResource := environment.NewTargetResource(
+		"SUB_ID", "RG_ID", "spring-instance", string(azapi.AzureResourceTypeSpringApp))
+
+	t.Run("Jar", func(t *testing.T) {
+		packagePath := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(packagePath, AppServiceJavaPackageName+".jar"), nil, 0600))
+
+		springService := newFakeSpringService(azapi.SpringAppDeployment{Name: "default", Active: true})
+		serviceTarget, env := createSpringAppServiceTarget(t, springService)
+		serviceConfig := createTestServiceConfig("./src/api", SpringAppTarget, ServiceLanguageJava)
+
+		result, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+			return serviceTarget.Deploy(
+				context.Background(),
+				serviceConfig,
+				&ServicePackageResult{PackagePath: packagePath},
+				targetResource,
+				progress,
+			)
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.Equal(t, []string{"default"}, springService.activated)
+		require.Equal(t, "Jar", *springService.sources["default"].GetUserSourceInfo().Type)
+		require.Equal(t, "relative/app.jar", env.GetServiceProperty("api", "RELATIVE_PATH"))
+	})
+
+	t.Run("War", func(t *testing.T) {
+		packagePath := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(packagePath, AppServiceJavaPackageName+".war"), nil, 0600))
+
+		springService := newFakeSpringService(azapi.SpringAppDeployment{Name: "default", Active: true})
+		serviceTarget, _ := createSpringAppServiceTarget(t, springService)
+		serviceConfig := createTestServiceConfig("./src/api", SpringAppTarget, ServiceLanguageJava)
+
+		_, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+			return serviceTarget.Deploy(
+				context.Background(),
+				serviceConfig,
+				&ServicePackageResult{PackagePath: packagePath},
+				targetResource,
+				progress,
+			)
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "War", *springService.sources["default"].GetUserSourceInfo().Type)
+	})
+
+	t.Run("BlueGreen", func(t *testing.T) {
+		packagePath := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(packagePath, AppServiceJavaPackageName+".jar"), nil, 0600))
+
+		springService := newFakeSpringService(
+			azapi.SpringAppDeployment{Name: "blue", Active: true},
+			azapi.SpringAppDeployment{Name: "green"},
+		)
+		serviceTarget, _ := createSpringAppServiceTarget(t, springService)
+		serviceConfig := createTestServiceConfig("./src/api", SpringAppTarget, ServiceLanguageJava)
+		serviceConfig.Spring.BlueGreen = &SpringBlueGreenOptions{}
+
+		_, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+			return serviceTarget.Deploy(
+				context.Background(),
+				serviceConfig,
+				&ServicePackageResult{PackagePath: packagePath},
+				targetResource,
+				progress,
+			)
+		})
+
+		require.NoError(t, err)
+		require.Contains(t, springService.sources, "green")
+		require.NotContains(t, springService.sources, "blue")
+		require.Equal(t, []string{"green"}, springService.activated)
+	})
+
+	t.Run("BlueGreenUnhealthy", func(t *testing.T) {
+		packagePath := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(packagePath, AppServiceJavaPackageName+".jar"), nil, 0600))
+
+		springService := newFakeSpringService(
+			azapi.SpringAppDeployment{Name: "blue"},
+			azapi.SpringAppDeployment{Name: "green", Active: true},
+		)
+		springService.instances = []azapi.SpringAppDeploymentInstance{
+			{Name: "blue-1", Status: "Failed", Reason: "CrashLoopBackOff"},
+		}
+		serviceTarget, _ := createSpringAppServiceTarget(t, springService)
+		serviceConfig := createTestServiceConfig("./src/api", SpringAppTarget, ServiceLanguageJava)
+		serviceConfig.Spring.BlueGreen = &SpringBlueGreenOptions{}
+
+		_, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+			return serviceTarget.Deploy(
+				context.Background(),
+				serviceConfig,
+				&ServicePackageResult{PackagePath: packagePath},
+				targetResource,
+				progress,
+			)
+		})
+
+		require.ErrorContains(t, err, "CrashLoopBackOff")
+		require.Contains(t, springService.sources, "blue")
+		require.Empty(t, springService.activated)
+	})
+
+	t.Run("SourceRequiresEnterprise", func(t *testing.T) {
+		springService := newFakeSpringService(azapi.SpringAppDeployment{Name: "default", Active: true})
+		springService.tier = "Standard"
+		serviceTarget, _ := createSpringAppServiceTarget(t, springService)
+		serviceConfig := createTestServiceConfig("./src/api", SpringAppTarget, ServiceLanguageJava)
+		serviceConfig.Spring.Source = SpringSourceSource
+
+		_, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+			return serviceTarget.Deploy(
+				context.Background(), serviceConfig, &ServicePackageResult{}, targetResource, progress)
+		})
+
+		require.ErrorContains(t, err, "Enterprise tier")
+	})
+
+	t.Run("Source", func(t *testing.T) {
+		ostest.Chdir(t, t.TempDir())
+		require.NoError(t, os.MkdirAll(filepath.Join("src", "api", "target"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join("src", "api", "pom.xml"), nil, 0600))
+
+		springService := newFakeSpringService(azapi.SpringAppDeployment{Name: "default", Active: true})
+		springService.tier = "Enterprise"
+		serviceTarget, _ := createSpringAppServiceTarget(t, springService)
+		serviceConfig := createTestServiceConfig("./src/api", SpringAppTarget, ServiceLanguageJava)
+		serviceConfig.Spring.Source = SpringSourceSource
+
+		_, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+			return serviceTarget.Deploy(
+				context.Background(), serviceConfig, &ServicePackageResult{}, targetResource, progress)
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "default", springService.builder)
+		source, ok := springService.sources["default"].(*armappplatform.BuildResultUserSourceInfo)
+		require.True(t, ok)
+		require.Equal(t, "build-result-id", *source.BuildResultID)
+	})
+}
+
+func Test_SpringAppTarget_Initialize(t *testing.T) {
+	serviceTarget := &springAppTarget{}
+	serviceConfig := createTestServiceConfig("./src/api", SpringAppTarget, ServiceLanguageJava)
+
+	serviceConfig.Spring = SpringOptions{Source: "zip"}
+	require.ErrorContains(t, serviceTarget.Initialize(context.Background(), serviceConfig), "unsupported spring source")
+
+	serviceConfig.Spring = SpringOptions{BlueGreen: &SpringBlueGreenOptions{Deployments: []string{"blue"}}}
+	require.ErrorContains(t, serviceTarget.Initialize(context.Background(), serviceConfig), "two deployments")
+
+	serviceConfig.Spring = SpringOptions{BlueGreen: &SpringBlueGreenOptions{HealthCheckTimeout: "soon"}}
+	require.ErrorContains(t, serviceTarget.Initialize(context.Background(), serviceConfig), "healthCheckTimeout")
+
+	serviceConfig.Spring = SpringOptions{
+		Source:    SpringSourceContainer,
+		BlueGreen: &SpringBlueGreenOptions{Deployments: []string{"a", "b"}, HealthCheckTimeout: "5m"},
+	}
+	require.NoError(t, serviceTarget.Initialize(context.Background(), serviceConfig))
+}
+
+func Test_packSpringSource(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "src", "main"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "target"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "pom.xml"), []byte("<project/>"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "src", "main", "App.java"), nil, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "target", "app.jar"), nil, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".git", "HEAD"), nil, 0600))
+
+	archivePath, err := packSpringSource(root)
+	require.NoError(t, err)
+	defer os.Remove(archivePath)
+
+	f, err := os.Open(archivePath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	var names []string
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+	}
+
+	require.ElementsMatch(t, []string{"pom.xml", "src/main/App.java"}, names)
+}
+
+func createSpringAppServiceTarget(
+	t *testing.T,
+	springService azapi.SpringService,
+) (ServiceTarget, *environment.Environment) {
+	env := environment.New("test")
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Save", mock.Anything, env).Return(nil)
+
+	return NewSpringAppTarget(env, envManager, nil, springService), env
+}
+
+// fakeSpringService is an in-memory azapi.SpringService. The instances of new deployments are running, unless
+// instances is set.
+type fakeSpringService struct {
+	azapi.SpringService
+
+	deployments []azapi.SpringAppDeployment
+	instances   []azapi.SpringAppDeploymentInstance
+	tier        string
+	builder     string
+	sources     map[string]armappplatform.UserSourceInfoClassification
+	activated   []string
+}
+
+func newFakeSpringService(deployments ...azapi.SpringAppDeployment) *fakeSpringService {
+	return &fakeSpringService{
+		deployments: deployments,
+		instances:   []azapi.SpringAppDeploymentInstance{{Name: "instance-1", Status: "Running", DiscoveryStatus: "UP"}},
+		sources:     map[string]armappplatform.UserSourceInfoClassification{},
+	}
+}
+
+func (f *fakeSpringService) GetSpringAppProperties(
+	ctx context.Context, subscriptionId, resourceGroup, instanceName, appName string,
+) (*azapi.SpringAppProperties, error) {
+	return &azapi.SpringAppProperties{Url: []string{"https://" + appName + ".azuremicroservices.io"}}, nil
+}
+
+func (f *fakeSpringService) GetSpringAppDeployment(
+	ctx context.Context, subscriptionId, resourceGroup, instanceName, appName, deploymentName string,
+) (*string, error) {
+	for _, deployment := range f.deployments {
+		if deployment.Name == deploymentName {
+			return &deployment.Name, nil
+		}
+	}
+
+	return nil, fmt.Errorf("deployment %s not found", deploymentName)
+}
+
+func (f *fakeSpringService) UploadSpringArtifact(
+	ctx context.Context, subscriptionId, resourceGroup, instanceName, appName, artifactPath string,
+) (*string, error) {
+	relativePath := "relative/" + filepath.Base(artifactPath)
+	return &relativePath, nil
+}
+
+func (f *fakeSpringService) ListSpringAppDeployments(
+	ctx context.Context, subscriptionId, resourceGroup, instanceName, appName string,
+) ([]azapi.SpringAppDeployment, error) {
+	return f.deployments, nil
+}
+
+func (f *fakeSpringService) CreateOrUpdateSpringAppDeployment(
+	ctx context.Context,
+	subscriptionId, resourceGroup, instanceName, appName, deploymentName string,
+	source armappplatform.UserSourceInfoClassification,
+) (*string, error) {
+	f.sources[deploymentName] = source
+	for i := range f.deployments {
+		if f.deployments[i].Name == deploymentName {
+			f.deployments[i].Instances = f.instances
+			return &deploymentName, nil
+		}
+	}
+
+	f.deployments = append(f.deployments, azapi.SpringAppDeployment{Name: deploymentName, Instances: f.instances})
+	return &deploymentName, nil
+}
+
+func (f *fakeSpringService) SetSpringAppActiveDeployment(
+	ctx context.Context, subscriptionId, resourceGroup, instanceName, appName, deploymentName string,
+) error {
+	f.activated = append(f.activated, deploymentName)
+	return nil
+}
+
+func (f *fakeSpringService) GetSpringServiceTier(
+	ctx context.Context, subscriptionId, resourceGroup, instanceName string,
+) (string, error) {
+	return f.tier, nil
+}
+
+func (f *fakeSpringService) UploadSpringBuildSource(
+	ctx context.Context, subscriptionId, resourceGroup, instanceName, sourcePath string,
+) (*string, error) {
+	relativePath := "relative/source.tar.gz"
+	return &relativePath, nil
+}
+
+func (f *fakeSpringService) BuildSpringAppSource(
+	ctx context.Context, subscriptionId, resourceGroup, instanceName, appName, relativePath, builder string,
+) (*string, error) {
+	f.builder = builder
+	buildResultId := "build-result-id"
+	return &buildResultId, nil
+}
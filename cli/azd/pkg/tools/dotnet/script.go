@@ -0,0 +1,57 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package dotnet
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+// Creates a new DotNetScript command runner, for file-based C# programs
+func NewDotNetScript(commandRunner exec.CommandRunner, cwd string, envVars []string) tools.Script {
+	return &dotNetScript{
+		commandRunner: commandRunner,
+		cwd:           cwd,
+		envVars:       envVars,
+	}
+}
+
+type dotNetScript struct {
+	commandRunner exec.CommandRunner
+	cwd           string
+	envVars       []string
+}
+
+// Executes the specified C# file with 'dotnet run', which requires the .NET 10 SDK or later
+// When interactive is true will attach to stdin, stdout & stderr
+func (ds *dotNetScript) Execute(ctx context.Context, path string, options tools.ExecOptions) (exec.RunResult, error) {
+	if err := tools.ToolInPath("dotnet"); err != nil {
+		return exec.RunResult{}, &internal.ErrorWithSuggestion{
+			Err: err,
+			Suggestion: fmt.Sprintf("The .NET SDK is not installed or not in the path. To install .NET, visit %s",
+				output.WithLinkFormat("https://dotnet.microsoft.com/download")),
+		}
+	}
+
+	runArgs := newDotNetRunArgs("run", "--file", path)
+	runArgs = runArgs.
+		WithCwd(ds.cwd).
+		WithEnv(slices.Concat(runArgs.Env, ds.envVars))
+
+	if options.Interactive != nil {
+		runArgs = runArgs.WithInteractive(*options.Interactive)
+	}
+
+	if options.StdOut != nil {
+		runArgs = runArgs.WithStdOut(options.StdOut)
+	}
+
+	return ds.commandRunner.Run(ctx, runArgs)
+}
@@ -96,6 +96,10 @@ var notGitRepositoryRegex = regexp.MustCompile("(fatal|error): not a git reposit
 var ErrNoSuchRemote = errors.New("no such remote")
 var ErrNotRepository = errors.New("not a git repository")
 var gitUntrackedFileRegex = regexp.MustCompile("untracked files present|new file")
+var remoteRefNotFoundRegex = regexp.MustCompile("couldn't find remote ref")
+var pushRejectedRegex = regexp.MustCompile(`\[rejected\]|\[remote rejected\]|non-fast-forward|fetch first`)
+var ErrRemoteBranchNotFound = errors.New("remote branch not found")
+var ErrPushRejected = errors.New("push rejected")
 
 func (cli *Cli) GetRemoteUrl(ctx context.Context, repositoryPath string, remoteName string) (string, error) {
 	runArgs := newRunArgs("-C", repositoryPath, "remote", "get-url", remoteName)
@@ -123,6 +127,19 @@ func (cli *Cli) GetCurrentBranch(ctx context.Context, repositoryPath string) (st
 	return strings.TrimSpace(res.Stdout), nil
 }
 
+// GetCurrentCommit returns the full hash of the commit checked out in the repository.
+func (cli *Cli) GetCurrentCommit(ctx context.Context, repositoryPath string) (string, error) {
+	runArgs := newRunArgs("-C", repositoryPath, "rev-parse", "HEAD")
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if notGitRepositoryRegex.MatchString(res.Stderr) {
+		return "", ErrNotRepository
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get current commit: %w", err)
+	}
+
+	return strings.TrimSpace(res.Stdout), nil
+}
+
 func (cli *Cli) GetRepoRoot(ctx context.Context, repositoryPath string) (string, error) {
 	runArgs := newRunArgs("-C", repositoryPath, "rev-parse", "--show-toplevel")
 	res, err := cli.commandRunner.Run(ctx, runArgs)
@@ -215,6 +232,86 @@ func (cli *Cli) PushUpstream(ctx context.Context, repositoryPath string, origin
 	return nil
 }
 
+// Fetch fetches the branch from the remote, into FETCH_HEAD. ErrRemoteBranchNotFound is returned when the remote does
+// not have the branch.
+func (cli *Cli) Fetch(ctx context.Context, repositoryPath string, remoteName string, branch string) error {
+	runArgs := newRunArgs("-C", repositoryPath, "fetch", "--quiet", remoteName, branch)
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if remoteRefNotFoundRegex.MatchString(res.Stderr) {
+		return ErrRemoteBranchNotFound
+	} else if err != nil {
+		return fmt.Errorf("failed to fetch: %w", err)
+	}
+
+	return nil
+}
+
+// Checkout checks out the branch, resetting it to startPoint and discarding any local changes. The branch is created
+// when it does not exist. When startPoint is empty, the branch is reset to the current commit.
+func (cli *Cli) Checkout(ctx context.Context, repositoryPath string, branch string, startPoint string) error {
+	args := []string{"-C", repositoryPath, "checkout", "--quiet", "--force", "-B", branch}
+	if startPoint != "" {
+		args = append(args, startPoint)
+	}
+
+	_, err := cli.commandRunner.Run(ctx, newRunArgs(args...))
+	if err != nil {
+		return fmt.Errorf("failed to checkout branch: %w", err)
+	}
+
+	return nil
+}
+
+// Push pushes the branch to the remote, without prompting. ErrPushRejected is returned when the remote branch has
+// commits that the local branch does not have.
+func (cli *Cli) Push(ctx context.Context, repositoryPath string, remoteName string, branch string) error {
+	runArgs := newRunArgs("-C", repositoryPath, "push", "--quiet", remoteName, branch)
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if pushRejectedRegex.MatchString(res.Stderr) {
+		return ErrPushRejected
+	} else if err != nil {
+		return fmt.Errorf("failed to push: %w", err)
+	}
+
+	return nil
+}
+
+// HasChanges returns whether the working tree of the repository has changes, including untracked files, compared to
+// the current commit.
+func (cli *Cli) HasChanges(ctx context.Context, repositoryPath string) (bool, error) {
+	runArgs := newRunArgs("-C", repositoryPath, "status", "--porcelain")
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return false, fmt.Errorf("failed to get status: %w", err)
+	}
+
+	return strings.TrimSpace(res.Stdout) != "", nil
+}
+
+// HasIdentity returns whether git can determine the author and committer identity of commits in the repository, from
+// the git configuration or the environment.
+func (cli *Cli) HasIdentity(ctx context.Context, repositoryPath string) bool {
+	for _, ident := range []string{"GIT_AUTHOR_IDENT", "GIT_COMMITTER_IDENT"} {
+		if _, err := cli.commandRunner.Run(ctx, newRunArgs("-C", repositoryPath, "var", ident)); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CommitAs commits the staged changes with the given identity, for repositories where no identity is configured.
+func (cli *Cli) CommitAs(ctx context.Context, repositoryPath string, message string, name string, email string) error {
+	runArgs := newRunArgs(
+		"-C", repositoryPath, "-c", "user.name="+name, "-c", "user.email="+email, "commit", "-m", message)
+	_, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
 func (cli *Cli) ListStagedFiles(ctx context.Context, repositoryPath string) (string, error) {
 	runArgs := newRunArgs("-C", repositoryPath, "ls-files", "--stage")
 	res, err := cli.commandRunner.Run(ctx, runArgs)
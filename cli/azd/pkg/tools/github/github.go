@@ -6,6 +6,7 @@ package github
 import (
 	"archive/tar"
 	"archive/zip"
+	"bytes"
 	"compress/gzip"
 	"context"
 	"encoding/json"
@@ -14,6 +15,7 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -286,6 +288,113 @@ func (cli *Cli) DeleteVariable(ctx context.Context, repoSlug string, name string
 	return nil
 }
 
+func (cli *Cli) ListEnvironmentSecrets(ctx context.Context, repoSlug string, envName string) ([]string, error) {
+	runArgs := cli.newRunArgs("-R", repoSlug, "secret", "list", "--env", envName)
+	output, err := cli.run(ctx, runArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed running gh secret list: %w", err)
+	}
+	return ghOutputToList(output.Stdout), nil
+}
+
+func (cli *Cli) ListEnvironmentVariables(ctx context.Context, repoSlug string, envName string) ([]string, error) {
+	runArgs := cli.newRunArgs("-R", repoSlug, "variable", "list", "--env", envName)
+	output, err := cli.run(ctx, runArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed running gh variable list: %w", err)
+	}
+	return ghOutputToList(output.Stdout), nil
+}
+
+func (cli *Cli) SetEnvironmentSecret(
+	ctx context.Context, repoSlug string, envName string, name string, value string) error {
+	runArgs := cli.newRunArgs("-R", repoSlug, "secret", "set", name, "--env", envName).
+		WithStdIn(strings.NewReader(value))
+	_, err := cli.run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed running gh secret set: %w", err)
+	}
+	return nil
+}
+
+func (cli *Cli) SetEnvironmentVariable(
+	ctx context.Context, repoSlug string, envName string, name string, value string) error {
+	runArgs := cli.newRunArgs("-R", repoSlug, "variable", "set", name, "--env", envName).
+		WithStdIn(strings.NewReader(value))
+	_, err := cli.run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed running gh variable set: %w", err)
+	}
+	return nil
+}
+
+func (cli *Cli) DeleteEnvironmentSecret(ctx context.Context, repoSlug string, envName string, name string) error {
+	runArgs := cli.newRunArgs("-R", repoSlug, "secret", "delete", name, "--env", envName)
+	_, err := cli.run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed running gh secret delete: %w", err)
+	}
+	return nil
+}
+
+func (cli *Cli) DeleteEnvironmentVariable(ctx context.Context, repoSlug string, envName string, name string) error {
+	runArgs := cli.newRunArgs("-R", repoSlug, "variable", "delete", name, "--env", envName)
+	_, err := cli.run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed running gh variable delete: %w", err)
+	}
+	return nil
+}
+
+// GetCurrentUserId returns the id of the user logged in to the GitHub CLI.
+func (cli *Cli) GetCurrentUserId(ctx context.Context) (int, error) {
+	runArgs := cli.newRunArgs("api", "/user")
+	res, err := cli.run(ctx, runArgs)
+	if err != nil {
+		return 0, fmt.Errorf("getting current user: %w", err)
+	}
+
+	var user struct {
+		Id int `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(res.Stdout), &user); err != nil {
+		return 0, fmt.Errorf("could not unmarshal output as a user: %w, output: %s", err, res.Stdout)
+	}
+	return user.Id, nil
+}
+
+// CreateOrUpdateEnvironment creates the deployment environment of the repository, or updates it when it already exists.
+// When reviewerIds is not empty, jobs deploying to the environment wait for the approval of one of the reviewers.
+// Required reviewers are not available for private repositories of all the GitHub plans.
+func (cli *Cli) CreateOrUpdateEnvironment(
+	ctx context.Context, repoSlug string, envName string, reviewerIds []int) error {
+	type reviewer struct {
+		Type string `json:"type"`
+		Id   int    `json:"id"`
+	}
+
+	body := struct {
+		Reviewers []reviewer `json:"reviewers,omitempty"`
+	}{}
+	for _, id := range reviewerIds {
+		body.Reviewers = append(body.Reviewers, reviewer{Type: "User", Id: id})
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshalling environment: %w", err)
+	}
+
+	runArgs := cli.newRunArgs(
+		"api", "--method", "PUT", fmt.Sprintf("/repos/%s/environments/%s", repoSlug, url.PathEscape(envName)),
+		"--input", "-").
+		WithStdIn(bytes.NewReader(payload))
+	if _, err := cli.run(ctx, runArgs); err != nil {
+		return fmt.Errorf("failed creating environment %s: %w", envName, err)
+	}
+	return nil
+}
+
 // ghCliVersionRegexp fetches the version number from the output of gh --version, which looks like this:
 //
 // gh version 2.6.0 (2022-03-15)
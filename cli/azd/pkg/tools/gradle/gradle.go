@@ -0,0 +1,264 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gradle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	osexec "os/exec"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+var _ tools.ExternalTool = (*Cli)(nil)
+
+type Cli struct {
+	commandRunner   exec.CommandRunner
+	projectPath     string
+	rootProjectPath string
+
+	// Lazily initialized. Access through gradleCmd.
+	gradleCmdStr  string
+	gradleCmdOnce sync.Once
+	gradleCmdErr  error
+}
+
+func (cli *Cli) Name() string {
+	return "Gradle"
+}
+
+func (cli *Cli) InstallUrl() string {
+	return "https://gradle.org/install"
+}
+
+func (cli *Cli) CheckInstalled(ctx context.Context) error {
+	_, err := cli.gradleCmd()
+	if err != nil {
+		return err
+	}
+
+	if ver, err := cli.extractVersion(ctx); err == nil {
+		log.Printf("gradle version: %s", ver)
+	}
+
+	return nil
+}
+
+func (cli *Cli) SetPath(projectPath string, rootProjectPath string) {
+	cli.projectPath = projectPath
+	cli.rootProjectPath = rootProjectPath
+}
+
+func (cli *Cli) gradleCmd() (string, error) {
+	cli.gradleCmdOnce.Do(func() {
+		gradleCmd, err := getGradlePath(cli.projectPath, cli.rootProjectPath)
+		if err != nil {
+			cli.gradleCmdErr = err
+		} else {
+			cli.gradleCmdStr = gradleCmd
+		}
+	})
+
+	if cli.gradleCmdErr != nil {
+		return "", cli.gradleCmdErr
+	}
+
+	return cli.gradleCmdStr, nil
+}
+
+func getGradlePath(projectPath string, rootProjectPath string) (string, error) {
+	gradlew, err := getGradleWrapperPath(projectPath, rootProjectPath)
+	if gradlew != "" {
+		return gradlew, nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed finding gradlew in repository path: %w", err)
+	}
+
+	gradle, err := osexec.LookPath("gradle")
+	if err == nil {
+		return gradle, nil
+	}
+
+	if !errors.Is(err, osexec.ErrNotFound) {
+		return "", fmt.Errorf("failed looking up gradle in PATH: %w", err)
+	}
+
+	return "", errors.New(
+		"gradle could not be found. Install either Gradle or Gradle Wrapper by " +
+			"visiting https://gradle.org/install/ or https://docs.gradle.org/current/userguide/gradle_wrapper.html",
+	)
+}
+
+// getGradleWrapperPath finds the path to gradlew in the project directory, up to the root project directory.
+//
+// An error is returned if an unexpected error occurred while finding.
+// If gradlew is not found, an empty string is returned with
+// no error.
+func getGradleWrapperPath(projectPath string, rootProjectPath string) (string, error) {
+	searchDir, err := filepath.Abs(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	root, err := filepath.Abs(rootProjectPath)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		gradlew, err := osexec.LookPath(filepath.Join(searchDir, "gradlew"))
+		if err == nil {
+			log.Printf("found gradlew as: %s\n", gradlew)
+			return gradlew, nil
+		}
+
+		if !errors.Is(err, os.ErrNotExist) && !errors.Is(err, osexec.ErrNotFound) {
+			return "", err
+		}
+
+		searchDir = filepath.Dir(searchDir)
+
+		// Past root, terminate search and return not found
+		if len(searchDir) < len(root) {
+			return "", nil
+		}
+	}
+}
+
+// gradleVersionRegexp captures the version number of gradle from the output of "gradle --version"
+//
+// the output of gradle --version looks something like this:
+//
+// ------------------------------------------------------------
+// Gradle 8.5
+// ------------------------------------------------------------
+//
+// Build time:   2023-11-29 14:08:57 UTC
+// Revision:     28aca86a7180baa17117e0e5ba01d8ea9feca598
+var gradleVersionRegexp = regexp.MustCompile(`(?m)^Gradle (\S+)\s*$`)
+
+func (cli *Cli) extractVersion(ctx context.Context) (string, error) {
+	gradleCmd, err := cli.gradleCmd()
+	if err != nil {
+		return "", err
+	}
+
+	runArgs := exec.NewRunArgs(gradleCmd, "--version")
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s --version: %w", gradleCmd, err)
+	}
+
+	parts := gradleVersionRegexp.FindStringSubmatch(res.Stdout)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("could not parse %s --version output, did not match expected format", gradleCmd)
+	}
+
+	return parts[1], nil
+}
+
+// ResolveDependencies downloads the dependencies of the project by running the 'dependencies' task.
+func (cli *Cli) ResolveDependencies(ctx context.Context, projectPath string) error {
+	if err := cli.run(ctx, projectPath, "dependencies"); err != nil {
+		return fmt.Errorf("gradle dependencies on project '%s' failed: %w", projectPath, err)
+	}
+
+	return nil
+}
+
+// Compile compiles the main source set of the project by running the 'classes' task.
+func (cli *Cli) Compile(ctx context.Context, projectPath string) error {
+	if err := cli.run(ctx, projectPath, "classes"); err != nil {
+		return fmt.Errorf("gradle classes on project '%s' failed: %w", projectPath, err)
+	}
+
+	return nil
+}
+
+// Package assembles the outputs of the project, such as the Spring Boot jar or war, without running tests.
+func (cli *Cli) Package(ctx context.Context, projectPath string) error {
+	if err := cli.run(ctx, projectPath, "assemble"); err != nil {
+		return fmt.Errorf("gradle assemble on project '%s' failed: %w", projectPath, err)
+	}
+
+	return nil
+}
+
+// RunTask runs an arbitrary gradle task, for example 'azureFunctionsPackage', on the project.
+func (cli *Cli) RunTask(ctx context.Context, projectPath string, task string) error {
+	if err := cli.run(ctx, projectPath, task); err != nil {
+		return fmt.Errorf("gradle %s on project '%s' failed: %w", task, projectPath, err)
+	}
+
+	return nil
+}
+
+// copyDependenciesInitScript adds a task copying the runtime classpath of java projects to the directory of the
+// 'azdDependenciesDir' property, without requiring changes to the build of the project.
+const copyDependenciesInitScript = `allprojects {
+    plugins.withId("java") {
+        tasks.register("azdCopyDependencies", Copy) {
+            from configurations.runtimeClasspath
+            into project.findProperty("azdDependenciesDir")
+        }
+    }
+}
+`
+
+// CopyDependencies copies the runtime dependencies of the project to outputDir, including the archives of the
+// projects of the build it depends on.
+func (cli *Cli) CopyDependencies(ctx context.Context, projectPath string, outputDir string) error {
+	initScript, err := os.CreateTemp("", "azd-init-*.gradle")
+	if err != nil {
+		return fmt.Errorf("creating init script: %w", err)
+	}
+	defer os.Remove(initScript.Name())
+
+	_, err = initScript.WriteString(copyDependenciesInitScript)
+	initScript.Close()
+	if err != nil {
+		return fmt.Errorf("writing init script: %w", err)
+	}
+
+	if err := cli.run(
+		ctx,
+		projectPath,
+		"--init-script", initScript.Name(),
+		"azdCopyDependencies",
+		"-PazdDependenciesDir="+outputDir,
+	); err != nil {
+		return fmt.Errorf("copying dependencies of gradle project '%s' failed: %w", projectPath, err)
+	}
+
+	return nil
+}
+
+func (cli *Cli) run(ctx context.Context, projectPath string, args ...string) error {
+	gradleCmd, err := cli.gradleCmd()
+	if err != nil {
+		return err
+	}
+
+	// Gradle resolves the build (settings.gradle) by searching upwards from the working directory, and runs the
+	// requested tasks for the project in the working directory only.
+	runArgs := exec.NewRunArgs(gradleCmd, append(args, "--console=plain")...).WithCwd(projectPath)
+	_, err = cli.commandRunner.Run(ctx, runArgs)
+	return err
+}
+
+func NewCli(commandRunner exec.CommandRunner) *Cli {
+	return &Cli{
+		commandRunner: commandRunner,
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gradle
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockexec"
+	"github.com/azure/azure-dev/cli/azd/test/ostest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getGradlePath(t *testing.T) {
+	rootPath := t.TempDir()
+	sourcePath := filepath.Join(rootPath, "src")
+	projectPath := filepath.Join(sourcePath, "api")
+	pathDir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	ostest.Unsetenv(t, "PATH")
+
+	tests := []struct {
+		name        string
+		gradlewPath []string
+		gradlePath  []string
+		envVar      map[string]string
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:        "GradlewProjectPath",
+			gradlewPath: []string{projectPath},
+			want:        filepath.Join(projectPath, gradlewWithExt()),
+		},
+		{
+			name:        "GradlewRootPath",
+			gradlewPath: []string{rootPath},
+			want:        filepath.Join(rootPath, gradlewWithExt()),
+		},
+		{
+			name:        "GradlewFirst",
+			gradlewPath: []string{rootPath},
+			gradlePath:  []string{pathDir},
+			envVar:      map[string]string{"PATH": pathDir},
+			want:        filepath.Join(rootPath, gradlewWithExt()),
+		},
+		{
+			name:       "Gradle",
+			gradlePath: []string{pathDir},
+			envVar:     map[string]string{"PATH": pathDir},
+			want:       filepath.Join(pathDir, gradleWithExt()),
+		},
+		{name: "NotFound", want: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			placeExecutable(t, gradlewWithExt(), tt.gradlewPath...)
+			placeExecutable(t, gradleWithExt(), tt.gradlePath...)
+			ostest.Setenvs(t, tt.envVar)
+
+			actual, err := getGradlePath(projectPath, rootPath)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+}
+
+func Test_extractVersion(t *testing.T) {
+	execMock := mockexec.NewMockCommandRunner().
+		When(func(a exec.RunArgs, command string) bool { return a.Args[0] == "--version" }).
+		RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			return exec.NewRunResult(0, heredoc.Doc(`
+
+			------------------------------------------------------------
+			Gradle 8.5
+			------------------------------------------------------------
+
+			Build time:   2023-11-29 14:08:57 UTC
+			Revision:     28aca86a7180baa17117e0e5ba01d8ea9feca598
+
+			Kotlin:       1.9.20
+			Groovy:       3.0.17
+			`), ""), nil
+		})
+
+	projectPath := t.TempDir()
+	gradle := NewCli(execMock)
+	gradle.SetPath(projectPath, projectPath)
+	placeExecutable(t, gradlewWithExt(), projectPath)
+
+	ver, err := gradle.extractVersion(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "8.5", ver)
+}
+
+func Test_Package(t *testing.T) {
+	var runArgs exec.RunArgs
+	execMock := mockexec.NewMockCommandRunner().
+		When(func(a exec.RunArgs, command string) bool { return true }).
+		RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			runArgs = args
+			return exec.NewRunResult(0, "", ""), nil
+		})
+
+	projectPath := t.TempDir()
+	gradle := NewCli(execMock)
+	gradle.SetPath(projectPath, projectPath)
+	placeExecutable(t, gradlewWithExt(), projectPath)
+
+	err := gradle.Package(context.Background(), projectPath)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(projectPath, gradlewWithExt()), runArgs.Cmd)
+	require.Equal(t, []string{"assemble", "--console=plain"}, runArgs.Args)
+	require.Equal(t, projectPath, runArgs.Cwd)
+}
+
+func placeExecutable(t *testing.T, name string, dirs ...string) {
+	for _, createPath := range dirs {
+		toCreate := filepath.Join(createPath, name)
+		ostest.Create(t, toCreate)
+
+		err := os.Chmod(toCreate, 0755)
+		require.NoError(t, err)
+	}
+}
+
+func gradleWithExt() string {
+	if runtime.GOOS == "windows" {
+		// For Windows, we want to test EXT resolution behavior
+		return "gradle.bat"
+	} else {
+		return "gradle"
+	}
+}
+
+func gradlewWithExt() string {
+	if runtime.GOOS == "windows" {
+		// For Windows, we want to test EXT resolution behavior
+		return "gradlew.bat"
+	} else {
+		return "gradlew"
+	}
+}
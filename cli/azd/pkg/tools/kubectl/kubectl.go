@@ -7,11 +7,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
@@ -243,6 +245,88 @@ func (cli *Cli) RolloutStatus(
 	return &res, nil
 }
 
+// LogsOptions are the options of the logs of the pods selected by a label selector
+type LogsOptions struct {
+	// Follow keeps streaming the logs until the context is canceled
+	Follow bool
+	// Since only includes the logs more recent than the duration. Zero includes the most recent lines only.
+	Since time.Duration
+	// Timestamps starts each line, after its prefix, with the time it was written at in RFC 3339 format
+	Timestamps bool
+}
+
+// defaultMaxLogRequests is the number of logs kubectl follows concurrently by default
+const defaultMaxLogRequests = 5
+
+// Writes the logs of all the containers of the pods matching the label selector to the writer.
+// Each line is prefixed with the pod and the container it comes from.
+func (cli *Cli) Logs(
+	ctx context.Context,
+	selector string,
+	options *LogsOptions,
+	writer io.Writer,
+	flags *KubeCliFlags,
+) error {
+	if options == nil {
+		options = &LogsOptions{}
+	}
+
+	runArgs := exec.
+		NewRunArgs("kubectl", "logs", "-l", selector, "--all-containers", "--prefix").
+		WithStdOut(writer)
+
+	if options.Follow {
+		// the logs of each container of each pod are followed concurrently, which kubectl limits by default
+		maxLogRequests, err := cli.containerCount(ctx, selector, flags)
+		if err != nil {
+			return err
+		}
+
+		runArgs = runArgs.AppendParams(
+			"--follow", fmt.Sprintf("--max-log-requests=%d", max(maxLogRequests, defaultMaxLogRequests)))
+	}
+	if options.Since > 0 {
+		runArgs = runArgs.AppendParams(fmt.Sprintf("--since=%s", options.Since))
+	}
+	if options.Timestamps {
+		runArgs = runArgs.AppendParams("--timestamps")
+	}
+
+	_, err := cli.executeCommandWithArgs(ctx, runArgs, flags)
+	// following the logs ends when the context is canceled
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("kubectl logs: %w", err)
+	}
+
+	return nil
+}
+
+// containerCount returns the number of containers, including the init containers, of the pods matching the label
+// selector.
+func (cli *Cli) containerCount(ctx context.Context, selector string, flags *KubeCliFlags) (int, error) {
+	podFlags := &KubeCliFlags{Output: OutputTypeJson}
+	if flags != nil {
+		podFlags.Namespace = flags.Namespace
+	}
+
+	res, err := cli.Exec(ctx, podFlags, "get", string(ResourceTypePod), "-l", selector)
+	if err != nil {
+		return 0, fmt.Errorf("failed getting pods, %w", err)
+	}
+
+	var pods List[Pod]
+	if err := json.Unmarshal([]byte(res.Stdout), &pods); err != nil {
+		return 0, fmt.Errorf("failed unmarshalling pods JSON, %w", err)
+	}
+
+	count := 0
+	for _, pod := range pods.Items {
+		count += len(pod.Spec.Containers) + len(pod.Spec.InitContainers)
+	}
+
+	return count, nil
+}
+
 // Executes a k8s CLI command from the specified arguments and flags
 func (cli *Cli) Exec(ctx context.Context, flags *KubeCliFlags, args ...string) (exec.RunResult, error) {
 	runArgs := exec.
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
@@ -141,6 +142,33 @@ func Test_Command_Args(t *testing.T) {
 				return err
 			},
 		},
+		"logs": {
+			mockCommandPredicate: "kubectl logs",
+			expectedCmd:          "kubectl",
+			expectedArgs: []string{
+				"logs", "-l", "app=web,tier=frontend", "--all-containers", "--prefix", "--follow",
+				"--max-log-requests=6", "--since=10m0s", "--timestamps", "-n", "test-namespace",
+			},
+			testFn: func() error {
+				// the logs of the 6 containers of the pods are followed concurrently
+				mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+					return strings.Contains(command, "kubectl get pod -l app=web,tier=frontend -n test-namespace -o json")
+				}).Respond(exec.NewRunResult(0, `{"items": [
+					{"spec": {"containers": [{"name": "web"}, {"name": "proxy"}], "initContainers": [{"name": "init"}]}},
+					{"spec": {"containers": [{"name": "web"}, {"name": "proxy"}], "initContainers": [{"name": "init"}]}}
+				]}`, ""))
+
+				selector := &LabelSelector{MatchLabels: map[string]string{"tier": "frontend", "app": "web"}}
+
+				return cli.Logs(*mockContext.Context, selector.String(), &LogsOptions{
+					Follow:     true,
+					Since:      10 * time.Minute,
+					Timestamps: true,
+				}, io.Discard, &KubeCliFlags{
+					Namespace: "test-namespace",
+				})
+			},
+		},
 	}
 
 	for testName, config := range tests {
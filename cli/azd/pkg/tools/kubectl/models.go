@@ -6,12 +6,15 @@ package kubectl
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 )
 
 type ResourceType string
 
 const (
 	ResourceTypeDeployment ResourceType = "deployment"
+	ResourceTypePod        ResourceType = "pod"
 	ResourceTypeIngress    ResourceType = "ing"
 	ResourceTypeService    ResourceType = "svc"
 	KubeConfigEnvVarName   string       = "KUBECONFIG"
@@ -43,7 +46,24 @@ type ResourceMetadata struct {
 type Deployment ResourceWithSpec[DeploymentSpec, DeploymentStatus]
 
 type DeploymentSpec struct {
-	Replicas int `json:"replicas" yaml:"replicas"`
+	Replicas int            `json:"replicas" yaml:"replicas"`
+	Selector *LabelSelector `json:"selector" yaml:"selector"`
+}
+
+// LabelSelector selects resources, such as the pods of a deployment, by their labels
+type LabelSelector struct {
+	MatchLabels map[string]string `json:"matchLabels" yaml:"matchLabels"`
+}
+
+// String returns the selector in the format of the label selectors of the k8s CLI, such as "app=web,tier=frontend"
+func (s *LabelSelector) String() string {
+	labels := make([]string, 0, len(s.MatchLabels))
+	for key, value := range s.MatchLabels {
+		labels = append(labels, fmt.Sprintf("%s=%s", key, value))
+	}
+	slices.Sort(labels)
+
+	return strings.Join(labels, ",")
 }
 
 type DeploymentStatus struct {
@@ -53,6 +73,18 @@ type DeploymentStatus struct {
 	UpdatedReplicas   int `json:"updatedReplicas"   yaml:"updatedReplicas"`
 }
 
+// Pod is a pod, with the containers it runs
+type Pod ResourceWithSpec[PodSpec, any]
+
+type PodSpec struct {
+	Containers     []Container `json:"containers"     yaml:"containers"`
+	InitContainers []Container `json:"initContainers" yaml:"initContainers"`
+}
+
+type Container struct {
+	Name string `json:"name" yaml:"name"`
+}
+
 type Ingress ResourceWithSpec[IngressSpec, IngressStatus]
 
 type IngressSpec struct {
@@ -18,7 +18,9 @@ import (
 	osexec "os/exec"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/otiai10/copy"
 )
 
 var _ tools.ExternalTool = (*Cli)(nil)
@@ -187,6 +189,23 @@ func (cli *Cli) Compile(ctx context.Context, projectPath string) error {
 	return nil
 }
 
+// CompileModule compiles a module of the multi-module project located at reactorPath, along with the modules it
+// depends on. module is the path of the module directory relative to reactorPath.
+func (cli *Cli) CompileModule(ctx context.Context, reactorPath string, module string) error {
+	mvnCmd, err := cli.mvnCmd()
+	if err != nil {
+		return err
+	}
+
+	runArgs := exec.NewRunArgs(mvnCmd, "compile", "-pl", module, "-am").WithCwd(reactorPath)
+	_, err = cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("mvn compile on module '%s' of project '%s' failed: %w", module, reactorPath, err)
+	}
+
+	return nil
+}
+
 func (cli *Cli) Package(ctx context.Context, projectPath string) error {
 	mvnCmd, err := cli.mvnCmd()
 	if err != nil {
@@ -203,6 +222,24 @@ func (cli *Cli) Package(ctx context.Context, projectPath string) error {
 	return nil
 }
 
+// PackageModule packages a module of the multi-module project located at reactorPath, along with the modules it
+// depends on. module is the path of the module directory relative to reactorPath.
+func (cli *Cli) PackageModule(ctx context.Context, reactorPath string, module string) error {
+	mvnCmd, err := cli.mvnCmd()
+	if err != nil {
+		return err
+	}
+
+	// Maven's package phase includes tests by default. Skip it explicitly.
+	runArgs := exec.NewRunArgs(mvnCmd, "package", "-DskipTests", "-pl", module, "-am").WithCwd(reactorPath)
+	_, err = cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("mvn package on module '%s' of project '%s' failed: %w", module, reactorPath, err)
+	}
+
+	return nil
+}
+
 func (cli *Cli) ResolveDependencies(ctx context.Context, projectPath string) error {
 	mvnCmd, err := cli.mvnCmd()
 	if err != nil {
@@ -217,6 +254,88 @@ func (cli *Cli) ResolveDependencies(ctx context.Context, projectPath string) err
 	return nil
 }
 
+// ResolveModuleDependencies resolves the dependencies of a module of the multi-module project located at reactorPath,
+// along with the modules it depends on. module is the path of the module directory relative to reactorPath.
+func (cli *Cli) ResolveModuleDependencies(ctx context.Context, reactorPath string, module string) error {
+	mvnCmd, err := cli.mvnCmd()
+	if err != nil {
+		return err
+	}
+
+	runArgs := exec.NewRunArgs(mvnCmd, "dependency:resolve", "-pl", module, "-am").WithCwd(reactorPath)
+	_, err = cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("mvn dependency:resolve on module '%s' of project '%s' failed: %w", module, reactorPath, err)
+	}
+
+	return nil
+}
+
+// CopyDependencies copies the runtime dependencies of the project to outputDir.
+func (cli *Cli) CopyDependencies(ctx context.Context, projectPath string, outputDir string) error {
+	mvnCmd, err := cli.mvnCmd()
+	if err != nil {
+		return err
+	}
+
+	runArgs := exec.NewRunArgs(
+		mvnCmd, "dependency:copy-dependencies", "-DincludeScope=runtime", "-DoutputDirectory="+outputDir,
+	).WithCwd(projectPath)
+	_, err = cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("mvn dependency:copy-dependencies on project '%s' failed: %w", projectPath, err)
+	}
+
+	return nil
+}
+
+// moduleDependenciesDir is the directory, relative to each module of a build, where
+// [Cli.PackageModuleWithDependencies] copies the runtime dependencies of the module.
+const moduleDependenciesDir = "target/azd-dependencies"
+
+// PackageModuleWithDependencies packages a module of the multi-module project located at reactorPath, along with the
+// modules it depends on, and copies the runtime dependencies of the module to outputDir. Sibling modules are copied as
+// the archives packaged by the same build. module is the path of the module directory relative to reactorPath.
+func (cli *Cli) PackageModuleWithDependencies(
+	ctx context.Context,
+	reactorPath string,
+	module string,
+	outputDir string,
+) error {
+	mvnCmd, err := cli.mvnCmd()
+	if err != nil {
+		return err
+	}
+
+	// The relative output directory is resolved against the directory of each module of the build, which keeps the
+	// dependencies of the module apart from the ones of the modules it depends on.
+	dependenciesDir := filepath.Join(reactorPath, module, filepath.FromSlash(moduleDependenciesDir))
+	if err := os.RemoveAll(dependenciesDir); err != nil {
+		return fmt.Errorf("removing dependencies of module '%s': %w", module, err)
+	}
+
+	runArgs := exec.NewRunArgs(
+		mvnCmd, "package", "dependency:copy-dependencies", "-DskipTests", "-DincludeScope=runtime",
+		"-DoutputDirectory="+moduleDependenciesDir, "-pl", module, "-am",
+	).WithCwd(reactorPath)
+	_, err = cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf(
+			"mvn dependency:copy-dependencies on module '%s' of project '%s' failed: %w", module, reactorPath, err)
+	}
+
+	if _, err := os.Stat(dependenciesDir); errors.Is(err, os.ErrNotExist) {
+		// the module has no runtime dependencies
+		return os.MkdirAll(outputDir, osutil.PermissionDirectory)
+	}
+
+	if err := copy.Copy(dependenciesDir, outputDir); err != nil {
+		return fmt.Errorf("copying dependencies of module '%s': %w", module, err)
+	}
+
+	return nil
+}
+
 var ErrPropertyNotFound = errors.New("property not found")
 
 func (cli *Cli) GetProperty(ctx context.Context, propertyPath string, projectPath string) (string, error) {
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"testing"
 
 	"github.com/MakeNowJust/heredoc/v2"
@@ -131,6 +132,44 @@ func Test_extractVersion(t *testing.T) {
 	require.Equal(t, "3.9.1", ver)
 }
 
+func Test_PackageModuleWithDependencies(t *testing.T) {
+	reactorPath := t.TempDir()
+	outputDir := filepath.Join(t.TempDir(), "lib")
+
+	var runArgs exec.RunArgs
+	execMock := mockexec.NewMockCommandRunner().
+		When(func(a exec.RunArgs, command string) bool { return slices.Contains(a.Args, "package") }).
+		RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			runArgs = args
+
+			// each module of the build copies its dependencies to its own directory
+			for module, dependency := range map[string]string{"library": "commons-1.0.jar", "api": "library-1.0.jar"} {
+				dependenciesDir := filepath.Join(args.Cwd, module, "target", "azd-dependencies")
+				require.NoError(t, os.MkdirAll(dependenciesDir, 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(dependenciesDir, dependency), nil, 0600))
+			}
+
+			return exec.NewRunResult(0, "", ""), nil
+		})
+
+	mvn := NewCli(execMock)
+	mvn.SetPath(reactorPath, reactorPath)
+	placeExecutable(t, mvnwWithExt(), reactorPath)
+
+	err := mvn.PackageModuleWithDependencies(context.Background(), reactorPath, "api", outputDir)
+	require.NoError(t, err)
+	require.Equal(t, reactorPath, runArgs.Cwd)
+	require.Equal(t, []string{
+		"package", "dependency:copy-dependencies", "-DskipTests", "-DincludeScope=runtime",
+		"-DoutputDirectory=target/azd-dependencies", "-pl", "api", "-am",
+	}, runArgs.Args)
+
+	entries, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "library-1.0.jar", entries[0].Name())
+}
+
 func placeExecutable(t *testing.T, name string, dirs ...string) {
 	for _, createPath := range dirs {
 		toCreate := filepath.Join(createPath, name)
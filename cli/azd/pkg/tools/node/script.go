@@ -0,0 +1,87 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+// Creates a new NodeScript command runner
+func NewNodeScript(commandRunner exec.CommandRunner, cwd string, envVars []string) tools.Script {
+	return &nodeScript{
+		commandRunner: commandRunner,
+		cwd:           cwd,
+		envVars:       envVars,
+	}
+}
+
+type nodeScript struct {
+	commandRunner exec.CommandRunner
+	cwd           string
+	envVars       []string
+}
+
+// Executes the specified JavaScript file with node
+// Packages are resolved from the closest node_modules directory of the working directory, including for scripts that
+// are located outside of it.
+// When interactive is true will attach to stdin, stdout & stderr
+func (ns *nodeScript) Execute(ctx context.Context, path string, options tools.ExecOptions) (exec.RunResult, error) {
+	if err := tools.ToolInPath("node"); err != nil {
+		return exec.RunResult{}, &internal.ErrorWithSuggestion{
+			Err: err,
+			Suggestion: fmt.Sprintf("Node.js is not installed or not in the path. To install Node.js, visit %s",
+				output.WithLinkFormat("https://nodejs.org/")),
+		}
+	}
+
+	envVars := slices.Clone(ns.envVars)
+	if nodeModules := findNodeModules(ns.cwd); nodeModules != "" {
+		envVars = append(envVars,
+			"NODE_PATH="+nodeModules,
+			fmt.Sprintf(
+				"PATH=%s%c%s", filepath.Join(nodeModules, ".bin"), os.PathListSeparator, os.Getenv("PATH")),
+		)
+	}
+
+	runArgs := exec.NewRunArgs("node", path).
+		WithCwd(ns.cwd).
+		WithEnv(envVars)
+
+	if options.Interactive != nil {
+		runArgs = runArgs.WithInteractive(*options.Interactive)
+	}
+
+	if options.StdOut != nil {
+		runArgs = runArgs.WithStdOut(options.StdOut)
+	}
+
+	return ns.commandRunner.Run(ctx, runArgs)
+}
+
+// findNodeModules returns the node_modules directory of dir or of its closest parent that has one, or an empty string
+// when there is none.
+func findNodeModules(dir string) string {
+	for {
+		nodeModules := filepath.Join(dir, "node_modules")
+		if info, err := os.Stat(nodeModules); err == nil && info.IsDir() {
+			return nodeModules
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+
+		dir = parent
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_findNodeModules(t *testing.T) {
+	root := t.TempDir()
+	hooksDir := filepath.Join(root, "src", "hooks")
+	require.NoError(t, os.MkdirAll(hooksDir, osutil.PermissionDirectory))
+
+	require.Empty(t, findNodeModules(hooksDir))
+
+	nodeModules := filepath.Join(root, "node_modules")
+	require.NoError(t, os.MkdirAll(nodeModules, osutil.PermissionDirectory))
+	require.Equal(t, nodeModules, findNodeModules(hooksDir))
+
+	closest := filepath.Join(hooksDir, "node_modules")
+	require.NoError(t, os.MkdirAll(closest, osutil.PermissionDirectory))
+	require.Equal(t, closest, findNodeModules(hooksDir))
+}
@@ -0,0 +1,237 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pulumi
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/blang/semver/v4"
+)
+
+var _ tools.ExternalTool = (*Cli)(nil)
+
+type Cli struct {
+	commandRunner exec.CommandRunner
+	env           []string
+}
+
+func NewCli(commandRunner exec.CommandRunner) *Cli {
+	return &Cli{
+		commandRunner: commandRunner,
+	}
+}
+
+func (cli *Cli) Name() string {
+	return "Pulumi CLI"
+}
+
+func (cli *Cli) InstallUrl() string {
+	return "https://www.pulumi.com/docs/install/"
+}
+
+func (cli *Cli) versionInfo() tools.VersionInfo {
+	return tools.VersionInfo{
+		MinimumVersion: semver.Version{
+			Major: 3,
+			Minor: 0,
+			Patch: 0},
+		UpdateCommand: "Download newer version from https://www.pulumi.com/docs/install/",
+	}
+}
+
+func (cli *Cli) CheckInstalled(ctx context.Context) error {
+	err := tools.ToolInPath("pulumi")
+	if err != nil {
+		return err
+	}
+
+	versionOutput, err := tools.ExecuteCommand(ctx, cli.commandRunner, "pulumi", "version")
+	if err != nil {
+		return fmt.Errorf("checking %s version: %w", cli.Name(), err)
+	}
+
+	log.Printf("pulumi version: %s", versionOutput)
+
+	pulumiSemver, err := tools.ExtractVersion(versionOutput)
+	if err != nil {
+		return fmt.Errorf("converting to semver version fails: %w", err)
+	}
+	updateDetail := cli.versionInfo()
+	if pulumiSemver.LT(updateDetail.MinimumVersion) {
+		return &tools.ErrSemver{ToolName: cli.Name(), VersionInfo: updateDetail}
+	}
+	return nil
+}
+
+// Set environment variables to be used in all pulumi commands
+func (cli *Cli) SetEnv(env []string) {
+	cli.env = env
+}
+
+func (cli *Cli) runCommand(ctx context.Context, args ...string) (exec.RunResult, error) {
+	runArgs := exec.
+		NewRunArgs("pulumi", args...).
+		WithEnv(cli.env)
+
+	return cli.commandRunner.Run(ctx, runArgs)
+}
+
+func (cli *Cli) runInteractive(ctx context.Context, args ...string) (exec.RunResult, error) {
+	runArgs := exec.
+		NewRunArgs("pulumi", args...).
+		WithEnv(cli.env).
+		WithInteractive(true)
+
+	return cli.commandRunner.Run(ctx, runArgs)
+}
+
+// stackArgs returns the arguments that run a command against the given stack of the project at projectPath.
+func stackArgs(projectPath string, stack string) []string {
+	return []string{"--cwd", projectPath, "--stack", stack}
+}
+
+// SelectStack selects the given stack of the project at projectPath, creating it when it does not exist yet.
+func (cli *Cli) SelectStack(ctx context.Context, projectPath string, stack string) (string, error) {
+	args := []string{"stack", "select", stack, "--create", "--non-interactive", "--cwd", projectPath}
+
+	cmdRes, err := cli.runCommand(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed running pulumi stack select: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return cmdRes.Stdout, nil
+}
+
+// SetConfig sets the given plain text configuration values on the stack.
+func (cli *Cli) SetConfig(
+	ctx context.Context,
+	projectPath string,
+	stack string,
+	values map[string]string,
+) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	args := []string{"config", "set-all", "--non-interactive"}
+	args = append(args, stackArgs(projectPath, stack)...)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		args = append(args, "--plaintext", fmt.Sprintf("%s=%s", key, values[key]))
+	}
+
+	cmdRes, err := cli.runCommand(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed running pulumi config set-all: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return cmdRes.Stdout, nil
+}
+
+// Preview runs `pulumi preview` and returns its JSON output.
+func (cli *Cli) Preview(
+	ctx context.Context,
+	projectPath string,
+	stack string,
+	additionalArgs ...string,
+) (string, error) {
+	args := []string{"preview", "--json", "--non-interactive"}
+	args = append(args, stackArgs(projectPath, stack)...)
+
+	args = append(args, additionalArgs...)
+	cmdRes, err := cli.runCommand(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed running pulumi preview: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return cmdRes.Stdout, nil
+}
+
+func (cli *Cli) Up(ctx context.Context, projectPath string, stack string, additionalArgs ...string) (string, error) {
+	args := []string{"up", "--yes", "--skip-preview"}
+	args = append(args, stackArgs(projectPath, stack)...)
+
+	args = append(args, additionalArgs...)
+	cmdRes, err := cli.runInteractive(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed running pulumi up: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return cmdRes.Stdout, nil
+}
+
+// StackOutput returns the outputs of the stack as JSON. The values of secret outputs are included when showSecrets is set,
+// and are replaced with "[secret]" otherwise.
+func (cli *Cli) StackOutput(ctx context.Context, projectPath string, stack string, showSecrets bool) (string, error) {
+	args := []string{"stack", "output", "--json"}
+	if showSecrets {
+		args = append(args, "--show-secrets")
+	}
+	args = append(args, stackArgs(projectPath, stack)...)
+
+	cmdRes, err := cli.runCommand(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed running pulumi stack output: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return cmdRes.Stdout, nil
+}
+
+// StackExport returns the checkpoint of the stack as JSON, which contains the resources the stack manages.
+func (cli *Cli) StackExport(ctx context.Context, projectPath string, stack string) (string, error) {
+	args := []string{"stack", "export"}
+	args = append(args, stackArgs(projectPath, stack)...)
+
+	cmdRes, err := cli.runCommand(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed running pulumi stack export: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return cmdRes.Stdout, nil
+}
+
+func (cli *Cli) Destroy(ctx context.Context, projectPath string, stack string, additionalArgs ...string) (string, error) {
+	args := []string{"destroy"}
+	args = append(args, stackArgs(projectPath, stack)...)
+
+	args = append(args, additionalArgs...)
+	cmdRes, err := cli.runInteractive(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed running pulumi destroy: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return cmdRes.Stdout, nil
+}
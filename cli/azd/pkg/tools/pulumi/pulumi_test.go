@@ -0,0 +1,61 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pulumi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithEnv(t *testing.T) {
+	ran := false
+	expectedEnvVars := []string{"PULUMI_BACKEND_URL=file://MYDIR"}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi"
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		ran = true
+		require.Equal(t, expectedEnvVars, args.Env)
+
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	cli := NewCli(mockContext.CommandRunner)
+	cli.SetEnv(expectedEnvVars)
+
+	_, err := cli.SelectStack(*mockContext.Context, "path/to/project", "dev")
+
+	require.NoError(t, err)
+	require.True(t, ran)
+}
+
+func Test_SetConfig(t *testing.T) {
+	var runArgs []string
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi"
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		runArgs = args.Args
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	cli := NewCli(mockContext.CommandRunner)
+	_, err := cli.SetConfig(*mockContext.Context, "path/to/project", "dev", map[string]string{
+		"location":        "westus2",
+		"environmentName": "dev",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"config", "set-all", "--non-interactive", "--cwd", "path/to/project", "--stack", "dev",
+		"--plaintext", "environmentName=dev",
+		"--plaintext", "location=westus2",
+	}, runArgs)
+}
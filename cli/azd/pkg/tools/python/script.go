@@ -0,0 +1,95 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package python
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+// virtualEnvNames are the conventional names of the directory of the virtual environment of a project.
+var virtualEnvNames = []string{".venv", "venv"}
+
+// Creates a new PythonScript command runner
+func NewPythonScript(commandRunner exec.CommandRunner, cwd string, envVars []string) tools.Script {
+	return &pythonScript{
+		commandRunner: commandRunner,
+		cwd:           cwd,
+		envVars:       envVars,
+	}
+}
+
+type pythonScript struct {
+	commandRunner exec.CommandRunner
+	cwd           string
+	envVars       []string
+}
+
+// Executes the specified python script
+// The interpreter of the virtual environment in the working directory is used when there is one.
+// When interactive is true will attach to stdin, stdout & stderr
+func (ps *pythonScript) Execute(ctx context.Context, path string, options tools.ExecOptions) (exec.RunResult, error) {
+	envVars := slices.Clone(ps.envVars)
+
+	pyString, virtualEnv := findVirtualEnv(ps.cwd)
+	if virtualEnv != "" {
+		// Activate the virtual environment for the processes started by the script
+		envVars = append(envVars,
+			"VIRTUAL_ENV="+virtualEnv,
+			fmt.Sprintf("PATH=%s%c%s", filepath.Dir(pyString), os.PathListSeparator, os.Getenv("PATH")),
+		)
+	} else {
+		var err error
+		pyString, err = checkPath()
+		if err != nil {
+			return exec.RunResult{}, &internal.ErrorWithSuggestion{
+				Err: err,
+				Suggestion: fmt.Sprintf("Python is not installed or not in the path. To install Python, visit %s",
+					output.WithLinkFormat("https://wiki.python.org/moin/BeginnersGuide/Download")),
+			}
+		}
+	}
+
+	runArgs := exec.NewRunArgs(pyString, path).
+		WithCwd(ps.cwd).
+		WithEnv(envVars)
+
+	if options.Interactive != nil {
+		runArgs = runArgs.WithInteractive(*options.Interactive)
+	}
+
+	if options.StdOut != nil {
+		runArgs = runArgs.WithStdOut(options.StdOut)
+	}
+
+	return ps.commandRunner.Run(ctx, runArgs)
+}
+
+// findVirtualEnv returns the python interpreter and the directory of the virtual environment in dir, or empty strings
+// when there is none.
+func findVirtualEnv(dir string) (string, string) {
+	for _, name := range virtualEnvNames {
+		virtualEnv := filepath.Join(dir, name)
+
+		pyString := filepath.Join(virtualEnv, "bin", "python")
+		if runtime.GOOS == "windows" {
+			pyString = filepath.Join(virtualEnv, "Scripts", "python.exe")
+		}
+
+		if _, err := os.Stat(pyString); err == nil {
+			return pyString, virtualEnv
+		}
+	}
+
+	return "", ""
+}
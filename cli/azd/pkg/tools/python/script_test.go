@@ -0,0 +1,49 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package python
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PythonScript_Execute_VirtualEnv(t *testing.T) {
+	cwd := t.TempDir()
+
+	pyString := filepath.Join(cwd, ".venv", "bin", "python")
+	if runtime.GOOS == "windows" {
+		pyString = filepath.Join(cwd, ".venv", "Scripts", "python.exe")
+	}
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(pyString), osutil.PermissionDirectory))
+	require.NoError(t, os.WriteFile(pyString, nil, osutil.PermissionExecutableFile))
+
+	var runArgs exec.RunArgs
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return true
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		runArgs = args
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	script := NewPythonScript(mockContext.CommandRunner, cwd, []string{"a=apple"})
+	_, err := script.Execute(*mockContext.Context, "hooks/predeploy.py", tools.ExecOptions{})
+	require.NoError(t, err)
+
+	require.Equal(t, pyString, runArgs.Cmd)
+	require.Equal(t, []string{"hooks/predeploy.py"}, runArgs.Args)
+	require.Equal(t, cwd, runArgs.Cwd)
+	require.Equal(t, "a=apple", runArgs.Env[0])
+	require.Contains(t, runArgs.Env, "VIRTUAL_ENV="+filepath.Join(cwd, ".venv"))
+}
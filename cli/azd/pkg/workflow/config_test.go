@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
 	"github.com/braydonk/yaml"
 	"github.com/stretchr/testify/require"
 )
@@ -100,6 +101,43 @@ func Test_WorkflowMap_UnmarshallYAML(t *testing.T) {
 		assertWorkflow(t, upWorkflow)
 	})
 
+	t.Run("script and parallel steps", func(t *testing.T) {
+		var workflowMap WorkflowMap
+		yamlString := heredoc.Doc(`
+			release:
+			  - azd: provision
+			  - name: seed
+			    run: scripts/seed.py
+			    if: AZURE_ENV_TYPE == prod
+			    continueOnError: true
+			  - parallel:
+			      - azd: deploy api
+			      - run: echo 'Hello'
+			        shell: sh
+		`)
+
+		err := yaml.Unmarshal([]byte(yamlString), &workflowMap)
+		require.NoError(t, err)
+
+		releaseWorkflow, ok := workflowMap["release"]
+		require.True(t, ok)
+		require.NoError(t, releaseWorkflow.Validate())
+		require.Len(t, releaseWorkflow.Steps, 3)
+
+		seed := releaseWorkflow.Steps[1]
+		require.Equal(t, StepKindScript, seed.Kind())
+		require.Equal(t, "seed", seed.Name)
+		require.Equal(t, "scripts/seed.py", seed.Run)
+		require.Equal(t, "AZURE_ENV_TYPE == prod", seed.If)
+		require.True(t, seed.ContinueOnError)
+
+		parallel := releaseWorkflow.Steps[2]
+		require.Equal(t, StepKindParallel, parallel.Kind())
+		require.Len(t, parallel.Parallel, 2)
+		require.Equal(t, []string{"deploy", "api"}, parallel.Parallel[0].AzdCommand.Args)
+		require.Equal(t, ext.ShellTypeBash, parallel.Parallel[1].Shell)
+	})
+
 	t.Run("invalid workflow", func(t *testing.T) {
 		var workflowMap WorkflowMap
 		yamlString := heredoc.Doc(`
@@ -4,11 +4,18 @@
 package workflow
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"strings"
+	"sync"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
 )
 
 // AzdCommandRunner abstracts the execution of an azd command given an set of arguments and context.
@@ -17,31 +24,211 @@ type AzdCommandRunner interface {
 	ExecuteContext(ctx context.Context) error
 }
 
+// StepRunner abstracts the execution of the steps of a workflow that don't run within the current azd command, and the
+// evaluation of the conditions of steps against the environment values.
+type StepRunner interface {
+	// RunScript runs the script of a step. The output of the script is written to stdout when set.
+	RunScript(ctx context.Context, name string, script *ext.HookConfig, stdout io.Writer) error
+	// RunAzdProcess runs an azd command in a separate azd process, writing its output to stdout.
+	// Used for azd commands that run in parallel with other steps.
+	RunAzdProcess(ctx context.Context, args []string, stdout io.Writer) error
+	// EvaluateCondition evaluates the condition of a step against the current environment values
+	EvaluateCondition(ctx context.Context, condition string) (bool, error)
+}
+
 // Runner is responsible for executing a workflow
 type Runner struct {
-	azdRunner AzdCommandRunner
-	console   input.Console
+	azdRunner  AzdCommandRunner
+	stepRunner StepRunner
+	console    input.Console
+
+	// Serializes the console output of steps that run in parallel
+	consoleMu sync.Mutex
 }
 
 // NewRunner creates a new instance of the Runner.
-func NewRunner(azdRunner AzdCommandRunner, console input.Console) *Runner {
+func NewRunner(azdRunner AzdCommandRunner, stepRunner StepRunner, console input.Console) *Runner {
 	return &Runner{
-		azdRunner: azdRunner,
-		console:   console,
+		azdRunner:  azdRunner,
+		stepRunner: stepRunner,
+		console:    console,
 	}
 }
 
 // Run executes the specified workflow against the root cobra command
 func (r *Runner) Run(ctx context.Context, workflow *Workflow) error {
+	if err := workflow.Validate(); err != nil {
+		return err
+	}
+
 	for _, step := range workflow.Steps {
-		if len(step.AzdCommand.Args) > 0 {
-			r.azdRunner.SetArgs(step.AzdCommand.Args)
+		if err := r.runStep(ctx, step, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DryRun displays the steps of the specified workflow without executing them.
+// The conditions of the steps are evaluated against the current environment values.
+func (r *Runner) DryRun(ctx context.Context, workflow *Workflow) error {
+	if err := workflow.Validate(); err != nil {
+		return err
+	}
+
+	r.console.Message(ctx, fmt.Sprintf("Workflow %s:", output.WithHighLightFormat(workflow.Name)))
+
+	return r.planSteps(ctx, workflow.Steps, 1)
+}
+
+func (r *Runner) planSteps(ctx context.Context, steps []*Step, depth int) error {
+	indent := strings.Repeat("  ", depth)
+
+	for i, step := range steps {
+		var description string
+		switch step.Kind() {
+		case StepKindAzd:
+			description = fmt.Sprintf("azd %s", strings.Join(step.AzdCommand.Args, " "))
+		case StepKindScript:
+			shell := string(step.Shell)
+			if shell == "" {
+				shell = "script"
+			}
+			description = fmt.Sprintf("%s: %s", shell, step.Run)
+		case StepKindParallel:
+			description = "parallel:"
+		}
+
+		if step.Name != "" {
+			description = fmt.Sprintf("%s (%s)", step.Name, description)
+		}
+
+		notes := []string{}
+		if step.If != "" {
+			met, err := r.stepRunner.EvaluateCondition(ctx, step.If)
+			if err != nil {
+				return fmt.Errorf("evaluating the condition of step '%s': %w", step, err)
+			}
+
+			if met {
+				notes = append(notes, fmt.Sprintf("if %s: condition met", step.If))
+			} else {
+				notes = append(notes, fmt.Sprintf("if %s: skipped, condition not met", step.If))
+			}
 		}
 
-		if err := r.azdRunner.ExecuteContext(ctx); err != nil {
-			return fmt.Errorf("error executing step command '%s': %w", strings.Join(step.AzdCommand.Args, " "), err)
+		if step.ContinueOnError {
+			notes = append(notes, "continues on error")
+		}
+
+		line := fmt.Sprintf("%s%d. %s", indent, i+1, description)
+		if len(notes) > 0 {
+			line += output.WithGrayFormat(" [%s]", strings.Join(notes, ", "))
+		}
+
+		r.console.Message(ctx, line)
+
+		if step.Kind() == StepKindParallel {
+			if err := r.planSteps(ctx, step.Parallel, depth+1); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
+
+// runStep runs a single step of a workflow.
+// When stdout is set the step runs in parallel with other steps, and its output is written to stdout.
+func (r *Runner) runStep(ctx context.Context, step *Step, stdout io.Writer) error {
+	if step.If != "" {
+		met, err := r.stepRunner.EvaluateCondition(ctx, step.If)
+		if err != nil {
+			return fmt.Errorf("evaluating the condition of step '%s': %w", step, err)
+		}
+
+		if !met {
+			log.Printf("skipping step '%s', the condition '%s' is not met", step, step.If)
+			r.message(ctx, output.WithGrayFormat("Skipping step '%s', the condition '%s' is not met", step, step.If))
+			return nil
+		}
+	}
+
+	var err error
+	switch step.Kind() {
+	case StepKindAzd:
+		if stdout != nil {
+			err = r.stepRunner.RunAzdProcess(ctx, step.AzdCommand.Args, stdout)
+		} else {
+			r.azdRunner.SetArgs(step.AzdCommand.Args)
+			err = r.azdRunner.ExecuteContext(ctx)
+		}
+
+		if err != nil {
+			err = fmt.Errorf("error executing step command '%s': %w", strings.Join(step.AzdCommand.Args, " "), err)
+		}
+	case StepKindScript:
+		script := &ext.HookConfig{
+			Shell: step.Shell,
+			Run:   step.Run,
+		}
+
+		if err = r.stepRunner.RunScript(ctx, step.String(), script, stdout); err != nil {
+			err = fmt.Errorf("error executing step script '%s': %w", step, err)
+		}
+	case StepKindParallel:
+		err = r.runParallel(ctx, step.Parallel)
+	}
+
+	if err != nil && step.ContinueOnError {
+		log.Println(err.Error())
+		r.message(ctx, output.WithWarningFormat("WARNING: %s", err.Error()))
+		r.message(ctx, output.WithWarningFormat("The workflow will continue since continueOnError has been set to true."))
+		return nil
+	}
+
+	return err
+}
+
+// runParallel runs the specified steps in parallel and waits for all of them to complete.
+// The output of each step is displayed once the step has completed.
+func (r *Runner) runParallel(ctx context.Context, steps []*Step) error {
+	errs := make([]error, len(steps))
+
+	var wg sync.WaitGroup
+	for i, step := range steps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var stdout bytes.Buffer
+			errs[i] = r.runStep(ctx, step, &stdout)
+
+			status := "completed"
+			if errs[i] != nil {
+				status = "failed"
+			}
+
+			r.consoleMu.Lock()
+			defer r.consoleMu.Unlock()
+
+			r.console.Message(ctx, output.WithBold("Step '%s' %s", step, status))
+			if stdout.Len() > 0 {
+				r.console.Message(ctx, strings.TrimRight(stdout.String(), "\n"))
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// message displays a message in the console, serialized with the output of steps that run in parallel
+func (r *Runner) message(ctx context.Context, message string) {
+	r.consoleMu.Lock()
+	defer r.consoleMu.Unlock()
+
+	r.console.Message(ctx, message)
+}
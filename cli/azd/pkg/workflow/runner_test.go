@@ -0,0 +1,240 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockinput"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Runner_Run(t *testing.T) {
+	t.Run("Sequential", func(t *testing.T) {
+		azdRunner, stepRunner, runner := newTestRunner()
+		workflow := &Workflow{
+			Name: "release",
+			Steps: []*Step{
+				NewAzdCommandStep("provision"),
+				{Run: "scripts/seed.py"},
+				NewAzdCommandStep("deploy", "--all"),
+			},
+		}
+
+		require.NoError(t, runner.Run(context.Background(), workflow))
+		require.Equal(t, []string{"provision", "deploy --all"}, azdRunner.ran)
+		require.Equal(t, []string{"script scripts/seed.py"}, stepRunner.ran)
+	})
+
+	t.Run("Conditions", func(t *testing.T) {
+		azdRunner, stepRunner, runner := newTestRunner()
+		stepRunner.env["AZURE_ENV_TYPE"] = "prod"
+		workflow := &Workflow{
+			Name: "release",
+			Steps: []*Step{
+				{AzdCommand: Command{Args: []string{"provision"}}, If: "AZURE_ENV_TYPE == prod"},
+				{Run: "scripts/seed.py", If: "AZURE_ENV_TYPE == dev"},
+				{Run: "scripts/smoke.py", If: "!SKIP_TESTS"},
+			},
+		}
+
+		require.NoError(t, runner.Run(context.Background(), workflow))
+		require.Equal(t, []string{"provision"}, azdRunner.ran)
+		require.Equal(t, []string{"script scripts/smoke.py"}, stepRunner.ran)
+	})
+
+	t.Run("ContinueOnError", func(t *testing.T) {
+		azdRunner, stepRunner, runner := newTestRunner()
+		stepRunner.failing = "scripts/seed.py"
+		workflow := &Workflow{
+			Name: "release",
+			Steps: []*Step{
+				{Run: "scripts/seed.py", ContinueOnError: true},
+				NewAzdCommandStep("deploy", "--all"),
+			},
+		}
+
+		require.NoError(t, runner.Run(context.Background(), workflow))
+		require.Equal(t, []string{"deploy --all"}, azdRunner.ran)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		azdRunner, stepRunner, runner := newTestRunner()
+		stepRunner.failing = "scripts/seed.py"
+		workflow := &Workflow{
+			Name: "release",
+			Steps: []*Step{
+				{Name: "seed", Run: "scripts/seed.py"},
+				NewAzdCommandStep("deploy", "--all"),
+			},
+		}
+
+		err := runner.Run(context.Background(), workflow)
+		require.ErrorContains(t, err, "error executing step script 'seed'")
+		require.Empty(t, azdRunner.ran)
+	})
+
+	t.Run("Parallel", func(t *testing.T) {
+		azdRunner, stepRunner, runner := newTestRunner()
+		stepRunner.failing = "scripts/lint.sh"
+		workflow := &Workflow{
+			Name: "release",
+			Steps: []*Step{
+				NewAzdCommandStep("provision"),
+				{
+					Parallel: []*Step{
+						NewAzdCommandStep("deploy", "api"),
+						NewAzdCommandStep("deploy", "web"),
+						{Run: "scripts/lint.sh", ContinueOnError: true},
+					},
+				},
+				{Run: "scripts/smoke.py"},
+			},
+		}
+
+		require.NoError(t, runner.Run(context.Background(), workflow))
+
+		// azd commands that run in parallel run in separate processes
+		require.Equal(t, []string{"provision"}, azdRunner.ran)
+		require.ElementsMatch(t, []string{
+			"process deploy api",
+			"process deploy web",
+			"script scripts/lint.sh",
+		}, stepRunner.ran[:3])
+		require.Equal(t, "script scripts/smoke.py", stepRunner.ran[3])
+	})
+
+	t.Run("ParallelFailure", func(t *testing.T) {
+		azdRunner, stepRunner, runner := newTestRunner()
+		stepRunner.failing = "deploy api"
+		workflow := &Workflow{
+			Name: "release",
+			Steps: []*Step{
+				{
+					Parallel: []*Step{
+						NewAzdCommandStep("deploy", "api"),
+						NewAzdCommandStep("deploy", "web"),
+					},
+				},
+				NewAzdCommandStep("provision"),
+			},
+		}
+
+		err := runner.Run(context.Background(), workflow)
+		require.ErrorContains(t, err, "error executing step command 'deploy api'")
+		require.ElementsMatch(t, []string{"process deploy api", "process deploy web"}, stepRunner.ran)
+		require.Empty(t, azdRunner.ran)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, _, runner := newTestRunner()
+		workflow := &Workflow{
+			Name: "release",
+			Steps: []*Step{
+				{AzdCommand: Command{Args: []string{"provision"}}, Run: "scripts/seed.py"},
+			},
+		}
+
+		err := runner.Run(context.Background(), workflow)
+		require.ErrorContains(t, err, "must specify exactly one of 'azd', 'run' or 'parallel'")
+	})
+}
+
+func Test_Runner_DryRun(t *testing.T) {
+	azdRunner, stepRunner, runner := newTestRunner()
+	stepRunner.env["AZURE_ENV_TYPE"] = "prod"
+	console := runner.console.(*mockinput.MockConsole)
+
+	workflow := &Workflow{
+		Name: "release",
+		Steps: []*Step{
+			NewAzdCommandStep("provision"),
+			{Name: "seed", Run: "scripts/seed.py", Shell: ext.ShellTypePython, If: "AZURE_ENV_TYPE == prod"},
+			{
+				Parallel: []*Step{
+					NewAzdCommandStep("deploy", "api"),
+					{Run: "scripts/lint.sh", ContinueOnError: true, If: "AZURE_ENV_TYPE == dev"},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, runner.DryRun(context.Background(), workflow))
+	require.Empty(t, azdRunner.ran)
+	require.Empty(t, stepRunner.ran)
+
+	require.Equal(t, []string{
+		"Workflow release:",
+		"  1. azd provision",
+		"  2. seed (python: scripts/seed.py) [if AZURE_ENV_TYPE == prod: condition met]",
+		"  3. parallel:",
+		"    1. azd deploy api",
+		"    2. script: scripts/lint.sh [if AZURE_ENV_TYPE == dev: skipped, condition not met, continues on error]",
+	}, console.Output())
+}
+
+func newTestRunner() (*testAzdRunner, *testStepRunner, *Runner) {
+	azdRunner := &testAzdRunner{}
+	stepRunner := &testStepRunner{env: map[string]string{}}
+	return azdRunner, stepRunner, NewRunner(azdRunner, stepRunner, mockinput.NewMockConsole())
+}
+
+type testAzdRunner struct {
+	args []string
+	ran  []string
+}
+
+func (r *testAzdRunner) SetArgs(args []string) {
+	r.args = args
+}
+
+func (r *testAzdRunner) ExecuteContext(ctx context.Context) error {
+	r.ran = append(r.ran, strings.Join(r.args, " "))
+	return nil
+}
+
+type testStepRunner struct {
+	mu      sync.Mutex
+	env     map[string]string
+	failing string
+	ran     []string
+}
+
+func (r *testStepRunner) RunScript(ctx context.Context, name string, script *ext.HookConfig, stdout io.Writer) error {
+	return r.run("script", script.Run, stdout)
+}
+
+func (r *testStepRunner) RunAzdProcess(ctx context.Context, args []string, stdout io.Writer) error {
+	return r.run("process", strings.Join(args, " "), stdout)
+}
+
+func (r *testStepRunner) EvaluateCondition(ctx context.Context, condition string) (bool, error) {
+	return ext.EvaluateCondition(condition, func(key string) (string, bool) {
+		value, has := r.env[key]
+		return value, has
+	})
+}
+
+func (r *testStepRunner) run(kind string, value string, stdout io.Writer) error {
+	r.mu.Lock()
+	r.ran = append(r.ran, fmt.Sprintf("%s %s", kind, value))
+	r.mu.Unlock()
+
+	if stdout != nil {
+		fmt.Fprintf(stdout, "running %s\n", value)
+	}
+
+	if value == r.failing {
+		return errors.New("failed")
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
 	"github.com/braydonk/yaml"
 )
 
@@ -16,6 +17,17 @@ type Workflow struct {
 	Steps []*Step `yaml:"steps,omitempty"`
 }
 
+// Validate returns an error when any of the steps of the workflow is not valid
+func (w *Workflow) Validate() error {
+	for _, step := range w.Steps {
+		if err := step.Validate(); err != nil {
+			return fmt.Errorf("workflow '%s' is invalid: %w", w.Name, err)
+		}
+	}
+
+	return nil
+}
+
 // UnmarshalYAML will unmarshal the Workflow from YAML.
 // The workflow YAML can be specified as either a simple array of steps or a more verbose map/struct style
 func (w *Workflow) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -87,10 +99,99 @@ func (w *Workflow) unmarshalSteps(rawSteps any) ([]*Step, error) {
 	return steps, nil
 }
 
+// StepKind is the kind of work that a step performs
+type StepKind string
+
+const (
+	// Executes an azd command
+	StepKindAzd StepKind = "azd"
+	// Executes a script, with the same shells and runtimes as hooks
+	StepKindScript StepKind = "script"
+	// Executes a group of steps in parallel
+	StepKindParallel StepKind = "parallel"
+)
+
 // Step stores a single step to execute within a workflow
-// This struct can be expanded over time to support other types of steps/commands
+// A step executes either an azd command, a script or a group of steps in parallel.
 type Step struct {
+	// Optional name of the step, used in the output and in the errors of the workflow
+	Name string `yaml:"name,omitempty"`
+	// The azd command to execute
 	AzdCommand Command `yaml:"azd,omitempty"`
+	// The inline script or relative path of the script to execute from the project path
+	Run string `yaml:"run,omitempty"`
+	// The type of shell or runtime of the script, inferred from the file extension of the script when not set
+	Shell ext.ShellType `yaml:"shell,omitempty"`
+	// The steps to execute in parallel
+	Parallel []*Step `yaml:"parallel,omitempty"`
+	// The condition evaluated against the environment values that must be met for the step to run
+	If string `yaml:"if,omitempty"`
+	// When set to true the workflow continues when the step fails
+	ContinueOnError bool `yaml:"continueOnError,omitempty"`
+}
+
+// Kind returns the kind of work that the step performs
+func (s *Step) Kind() StepKind {
+	switch {
+	case len(s.Parallel) > 0:
+		return StepKindParallel
+	case s.Run != "":
+		return StepKindScript
+	default:
+		return StepKindAzd
+	}
+}
+
+// String returns the name of the step, or a description of its work when the step has no name
+func (s *Step) String() string {
+	if s.Name != "" {
+		return s.Name
+	}
+
+	switch s.Kind() {
+	case StepKindParallel:
+		return fmt.Sprintf("parallel (%d steps)", len(s.Parallel))
+	case StepKindScript:
+		return s.Run
+	default:
+		return "azd " + strings.Join(s.AzdCommand.Args, " ")
+	}
+}
+
+// Validate returns an error when the step, or any of its parallel steps, is not valid
+func (s *Step) Validate() error {
+	kinds := 0
+	if len(s.AzdCommand.Args) > 0 {
+		kinds++
+	}
+	if s.Run != "" {
+		kinds++
+	}
+	if len(s.Parallel) > 0 {
+		kinds++
+	}
+
+	if kinds != 1 {
+		return fmt.Errorf("step '%s' must specify exactly one of 'azd', 'run' or 'parallel'", s)
+	}
+
+	if s.Shell != "" && s.Kind() != StepKindScript {
+		return fmt.Errorf("step '%s' specifies a shell, but doesn't run a script", s)
+	}
+
+	if s.If != "" {
+		if err := ext.ValidateCondition(s.If); err != nil {
+			return fmt.Errorf("step '%s' is invalid: %w", s, err)
+		}
+	}
+
+	for _, step := range s.Parallel {
+		if err := step.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // NewAzdCommandStep creates a new step that executes an azd command with the specified name and args
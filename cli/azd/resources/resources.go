@@ -33,3 +33,6 @@ var AiPythonApp embed.FS
 
 //go:embed pipeline/*
 var PipelineFiles embed.FS
+
+//go:embed extension
+var ExtensionTemplates embed.FS
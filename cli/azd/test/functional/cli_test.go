@@ -1074,6 +1074,6 @@ func assertEnvValuesStored(t *testing.T, env *environment.Environment) {
 
 func envFromAzdRoot(ctx context.Context, azdRootDir string, envName string) (*environment.Environment, error) {
 	azdCtx := azdcontext.NewAzdContextWithDirectory(azdRootDir)
-	localDataStore := environment.NewLocalFileDataStore(azdCtx, config.NewFileConfigManager(config.NewManager()))
+	localDataStore := environment.NewLocalFileDataStore(azdCtx, config.NewFileConfigManager(config.NewManager()), nil)
 	return localDataStore.Get(ctx, envName)
 }
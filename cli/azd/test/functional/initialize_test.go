@@ -57,7 +57,7 @@ func Test_CommandsAndActions_Initialize(t *testing.T) {
 	// Set environment for commands that require environment.
 	envName := "envname"
 	azdCtx := azdcontext.NewAzdContextWithDirectory(tempDir)
-	localDataStore := environment.NewLocalFileDataStore(azdCtx, config.NewFileConfigManager(config.NewManager()))
+	localDataStore := environment.NewLocalFileDataStore(azdCtx, config.NewFileConfigManager(config.NewManager()), nil)
 
 	require.NoError(t, err)
 	err = azdCtx.SetProjectState(azdcontext.ProjectState{DefaultEnvironment: envName})